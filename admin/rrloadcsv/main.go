@@ -34,6 +34,10 @@ var App struct {
 	dbdir          *sql.DB                    // phonebook db
 	dbrr           *sql.DB                    //rentroll db
 	AcctDep        string                     // account depository
+	ARSim          string                     // account rule to simulate, an ARID or a rule
+	ARSimAmount    rlib.Money                 // amount of the simulated transaction
+	ARSimRAID      int64                      // Rental Agreement of the simulated transaction
+	ARSimRID       int64                      // Rentable of the simulated transaction
 	ARFile         string                     // account rules
 	AsmtFile       string                     // Assessments
 	AssignFile     string                     // assign custom attributes
@@ -49,6 +53,7 @@ var App struct {
 	DepositoryFile string                     // Depository
 	DMFile         string                     // Deposit Methods
 	InvoiceFile    string                     // Invoice
+	MeterFile      string                     // sub-meter readings
 	NoteTypeFile   string                     // note types
 	PetFile        string                     // assign pets
 	PmtTypeFile    string                     // payment types
@@ -66,6 +71,7 @@ var App struct {
 	RSpFile        string                     // Rentable specialties
 	RspRefsFile    string                     // assign specialties to rentables
 	RTFile         string                     // Rentable types csv file
+	SalesFile      string                     // tenant sales reports
 	SLFile         string                     // StringLists
 	SrcFile        string                     // Sources
 	VehicleFile    string                     // vehicles that belong to people
@@ -78,6 +84,10 @@ var App struct {
 func readCommandLineArgs() {
 	asmtPtr := flag.String("A", "", "add Assessments via csv file")
 	arPtr := flag.String("ar", "", "add AccountRules via csv file")
+	arsimPtr := flag.String("arsim", "", "evaluate an AccountRule without posting it: an ARID or a rule such as \"d 12000 _, c 40001 _\"")
+	amtPtr := flag.String("amount", "0", "amount of the transaction for -arsim")
+	simRAIDPtr := flag.Int64("raid", 0, "Rental Agreement for -arsim")
+	simRIDPtr := flag.Int64("rid", 0, "Rentable for -arsim")
 	rpptr := flag.String("a", "", "add RatePlans via csv file")
 	dbuPtr := flag.String("B", "ec2-user", "database user name")
 	bizPtr := flag.String("b", "", "add Business via csv file")
@@ -113,6 +123,8 @@ func readCommandLineArgs() {
 	vehiclePtr := flag.String("V", "", "add people vehicles via csv file")
 	verPtr := flag.Bool("v", false, "prints the version to stdout")
 	depositPtr := flag.String("y", "", "add Deposits via csv file")
+	salesPtr := flag.String("sales", "", "add tenant sales reports via csv file")
+	meterPtr := flag.String("meter", "", "add sub-meter readings via csv file")
 	noconPtr := flag.Bool("nocon", false, "if specified, inhibit Console output")
 	noauth := flag.Bool("noauth", false, "if specified, inhibit authentication")
 
@@ -129,6 +141,9 @@ func readCommandLineArgs() {
 
 	App.AcctDep = *pAD
 	App.ARFile = *arPtr
+	App.ARSim = strings.TrimSpace(*arsimPtr)
+	App.ARSimRAID = *simRAIDPtr
+	App.ARSimRID = *simRIDPtr
 	App.AsmtFile = *asmtPtr
 	App.AssignFile = *asgnPtr
 	App.BizFile = *bizPtr
@@ -158,12 +173,19 @@ func readCommandLineArgs() {
 	App.RSpFile = *rspPtr
 	App.RspRefsFile = *rsrefsPtr
 	App.RTFile = *rtPtr
+	App.SalesFile = *salesPtr
+	App.MeterFile = *meterPtr
 	App.SLFile = *slPtr
 	App.SrcFile = *src
 	App.VehicleFile = *vehiclePtr
 	App.NoAuth = *noauth
 
 	var err error
+	App.ARSimAmount, err = rlib.ParseMoney(*amtPtr)
+	if err != nil {
+		fmt.Printf("Invalid amount:  %s\n", *amtPtr)
+		os.Exit(1)
+	}
 	s := *pDates
 	if len(s) > 0 {
 		ss := strings.Split(s, ",")
@@ -286,8 +308,8 @@ func main() {
 			fmt.Printf("Could not load Business with BID(%d)\n", b2.BID)
 			os.Exit(1)
 		}
-	} else if len(App.AsmtFile) > 0 || len(App.RcptFile) > 0 {
-		fmt.Printf("To load Assessments or Receipts you must provide a business unit\n")
+	} else if len(App.AsmtFile) > 0 || len(App.RcptFile) > 0 || len(App.ARSim) > 0 {
+		fmt.Printf("To load Assessments or Receipts, or to simulate an AccountRule, you must provide a business unit\n")
 		os.Exit(1)
 	}
 	if App.Xbiz.P.BID > 0 {
@@ -340,6 +362,8 @@ func main() {
 		{Fname: App.AssignFile, Handler: rcsv.LoadCustomAttributeRefsCSV},
 		{Fname: App.NoteTypeFile, Handler: rcsv.LoadNoteTypesCSV},
 		{Fname: App.InvoiceFile, Handler: rcsv.LoadInvoicesCSV},
+		{Fname: App.SalesFile, Handler: rcsv.LoadSalesReportsCSV},
+		{Fname: App.MeterFile, Handler: rcsv.LoadMeterReadingsCSV},
 	}
 
 	for i := 0; i < len(h); i++ {
@@ -427,4 +451,43 @@ func main() {
 
 		fmt.Printf("%s\n", r[idx].Handler(ctx, &r[idx]))
 	}
+
+	if len(App.ARSim) > 0 {
+		doAcctRuleSim(ctx)
+	}
+}
+
+// doAcctRuleSim evaluates the account rule in App.ARSim and prints the
+// debits and credits it would make. Nothing is posted.
+func doAcctRuleSim(ctx context.Context) {
+	var arid int64
+	rule := App.ARSim
+	if id, err := strconv.ParseInt(rule, 10, 64); err == nil {
+		arid, rule = id, ""
+	}
+	d1, d2 := App.DtStart, App.DtStop
+	if d1.IsZero() {
+		now := time.Now()
+		d1 = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		d2 = d1
+	}
+
+	sim, err := rlib.SimulateAcctRule(ctx, &App.Xbiz, arid, rule, App.ARSimRID, App.ARSimRAID, &d1, &d2, App.ARSimAmount, 1.0)
+	if err != nil {
+		fmt.Printf("Could not evaluate the account rule: %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("Rule: %s\n\n", sim.Rule)
+	fmt.Printf("%-6s  %-10s  %-30s  %12s  %12s  %s\n", "Action", "GLNumber", "Name", "Debit", "Credit", "Formula")
+	for _, l := range sim.Lines {
+		if l.Action == "d" {
+			fmt.Printf("%-6s  %-10s  %-30s  %12.2f  %12s  %s\n", l.Action, l.GLNumber, l.Name, l.Amount, "", l.Expr)
+		} else {
+			fmt.Printf("%-6s  %-10s  %-30s  %12s  %12.2f  %s\n", l.Action, l.GLNumber, l.Name, "", l.Amount, l.Expr)
+		}
+	}
+	fmt.Printf("%-6s  %-10s  %-30s  %12.2f  %12.2f\n", "", "", "Total", sim.Debits, sim.Credits)
+	for _, p := range sim.Problems {
+		fmt.Printf("PROBLEM: %s\n", p)
+	}
 }
@@ -0,0 +1,436 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// apPosting is one debit/credit pair of an accounts payable Journal entry
+type apPosting struct {
+	debit  int64      // LID debited
+	credit int64      // LID credited
+	rid    int64      // Rentable, if any
+	raid   int64      // Rental Agreement, if any
+	tcid   int64      // Transactant, if any
+	amt    rlib.Money // amount
+}
+
+// postAPJournal writes a Journal entry for an accounts payable document
+// along with a JournalAllocation and a pair of LedgerEntries for each
+// posting.
+//
+// INPUTS
+//  ctx     = db context
+//  bid     = business
+//  dt      = date of the entry
+//  typ     = rlib.JNLTYPEBILL, rlib.JNLTYPEVPMT or rlib.JNLTYPECHK
+//  id      = BILLID, VPID or CKID of the document
+//  comment = journal comment
+//  p       = the postings
+//
+// RETURNS
+//  the JID of the new Journal entry
+//  any error encountered
+//-------------------------------------------------------------------------------------
+func postAPJournal(ctx context.Context, bid int64, dt *time.Time, typ, id int64, comment string, p []apPosting) (int64, error) {
+	glnum := map[int64]string{}
+	tot := rlib.Money(0)
+	for i := 0; i < len(p); i++ {
+		tot += p[i].amt
+		for _, lid := range []int64{p[i].debit, p[i].credit} {
+			if _, ok := glnum[lid]; ok {
+				continue
+			}
+			l, err := rlib.GetLedger(ctx, lid)
+			if err != nil {
+				return 0, err
+			}
+			glnum[lid] = l.GLNumber
+		}
+	}
+
+	jnl := rlib.Journal{
+		BID:     bid,
+		Dt:      *dt,
+		Amount:  tot,
+		Type:    typ,
+		ID:      id,
+		Comment: comment,
+	}
+	if _, err := rlib.InsertJournal(ctx, &jnl); err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(p); i++ {
+		amt := p[i].amt
+		ja := rlib.JournalAllocation{
+			JID:      jnl.JID,
+			BID:      bid,
+			RID:      p[i].rid,
+			RAID:     p[i].raid,
+			TCID:     p[i].tcid,
+			Amount:   amt,
+			AcctRule: fmt.Sprintf("d %s %.2f, c %s %.2f", glnum[p[i].debit], amt, glnum[p[i].credit], amt),
+		}
+		if _, err := rlib.InsertJournalAllocationEntry(ctx, &ja); err != nil {
+			return 0, err
+		}
+		l := rlib.LedgerEntry{
+			BID:    bid,
+			JID:    jnl.JID,
+			JAID:   ja.JAID,
+			RID:    ja.RID,
+			RAID:   ja.RAID,
+			TCID:   ja.TCID,
+			Dt:     jnl.Dt,
+			LID:    p[i].debit,
+			Amount: amt,
+		}
+		if _, err := rlib.InsertLedgerEntry(ctx, &l); err != nil {
+			return 0, err
+		}
+		l.LID = p[i].credit
+		l.Amount = -amt
+		if _, err := rlib.InsertLedgerEntry(ctx, &l); err != nil {
+			return 0, err
+		}
+	}
+	return jnl.JID, nil
+}
+
+// checkPostingAccount adds a GLAccountNoPost error to e if lid is not a GL
+// Account of business bid that allows posting.
+//-------------------------------------------------------------------------------------
+func checkPostingAccount(ctx context.Context, bid, lid int64, e []BizError) []BizError {
+	l, err := rlib.GetLedger(ctx, lid)
+	if err != nil {
+		return AddErrToBizErrlist(err, e)
+	}
+	if l.LID == 0 || l.BID != bid || !l.AllowPost {
+		return bizErrf(e, GLAccountNoPost, lid)
+	}
+	return e
+}
+
+// ValidateBill checks bill b and its lines before it is posted. If b has no
+// Accounts Payable account the first one of the business is used and if it
+// has no due date it is set using the vendor's terms.
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill, b.BL holds its lines
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ValidateBill(ctx context.Context, b *rlib.Bill) []BizError {
+	var e []BizError
+	v, err := rlib.GetVendor(ctx, b.VID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if v.VID == 0 || v.BID != b.BID {
+		return bizErrf(e, VendorNotFound, b.VID, b.BID)
+	}
+	if b.APLID == 0 {
+		var xbiz rlib.XBusiness
+		if err = rlib.InitBizInternals(b.BID, &xbiz); err != nil {
+			return bizErrSys(&err)
+		}
+		m := rlib.GetPayableAccounts(b.BID)
+		if len(m) == 0 {
+			return bizErrf(e, NoPayablesAccount, b.BID)
+		}
+		b.APLID = m[0]
+	}
+	e = checkPostingAccount(ctx, b.BID, b.APLID, e)
+
+	tot := rlib.Money(0)
+	for i := 0; i < len(b.BL); i++ {
+		b.BL[i].BID = b.BID
+		if b.BL[i].LID == 0 {
+			b.BL[i].LID = v.DefaultLID
+		}
+		e = checkPostingAccount(ctx, b.BID, b.BL[i].LID, e)
+		tot += b.BL[i].Amount
+	}
+	if b.Amount <= 0 || len(b.BL) == 0 {
+		e = AddBizErrToList(e, InvalidField)
+	} else if tot != b.Amount {
+		e = bizErrf(e, BillLineTotal, tot, b.Amount)
+	}
+	if b.DtDue.Year() <= 1970 {
+		b.DtDue = rlib.BillDueDate(&b.Dt, v.Terms)
+	}
+	return append(e, CheckPeriodOpen(ctx, b.BID, &b.Dt)...)
+}
+
+// InsertBill validates bill b, writes it and its lines and posts it: each
+// line's GL Account is debited and the Accounts Payable account credited.
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill, b.BL holds its lines
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func InsertBill(ctx context.Context, b *rlib.Bill) []BizError {
+	if e := ValidateBill(ctx, b); len(e) > 0 {
+		return e
+	}
+	if err := rlib.InsertBill(ctx, b); err != nil {
+		return bizErrSys(&err)
+	}
+	var p []apPosting
+	for i := 0; i < len(b.BL); i++ {
+		b.BL[i].BILLID = b.BILLID
+		if err := rlib.InsertBillLine(ctx, &b.BL[i]); err != nil {
+			return bizErrSys(&err)
+		}
+		p = append(p, apPosting{debit: b.BL[i].LID, credit: b.APLID, rid: b.BL[i].RID, amt: b.BL[i].Amount})
+	}
+	comment := fmt.Sprintf("bill %s", rlib.IDtoShortString("BILL", b.BILLID))
+	jid, err := postAPJournal(ctx, b.BID, &b.Dt, rlib.JNLTYPEBILL, b.BILLID, comment, p)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	b.JID = jid
+	if err = rlib.UpdateBill(ctx, b); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// VoidBill voids bill b by posting the reverse of its journal entry. A bill
+// with payments cannot be voided, void the payments first. If the bill is in
+// a closed period the reversal is posted on the first open date.
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func VoidBill(ctx context.Context, b *rlib.Bill) []BizError {
+	if b.FLAGS&rlib.BILLVoid != 0 {
+		return nil // it's already void
+	}
+	m, err := rlib.GetBillPayments(ctx, b.BILLID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if len(m) > 0 {
+		return bizErrf(nil, BillHasPayments, b.BILLID)
+	}
+	bl, err := rlib.GetBillLines(ctx, b.BILLID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	revdt, err := reversalDate(ctx, b.BID, &b.Dt)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	var p []apPosting
+	for i := 0; i < len(bl); i++ {
+		p = append(p, apPosting{debit: bl[i].LID, credit: b.APLID, rid: bl[i].RID, amt: -bl[i].Amount})
+	}
+	comment := fmt.Sprintf("void of bill %s", rlib.IDtoShortString("BILL", b.BILLID))
+	if _, err = postAPJournal(ctx, b.BID, &revdt, rlib.JNLTYPEBILL, b.BILLID, comment, p); err != nil {
+		return bizErrSys(&err)
+	}
+	b.FLAGS |= rlib.BILLVoid
+	if err = rlib.UpdateBill(ctx, b); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// BillBalance returns the unpaid portion of bill b as of dt
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill
+//  dt  = payments made on or before this date are counted
+//
+// RETURNS
+//  the balance
+//  any error encountered
+//-------------------------------------------------------------------------------------
+func BillBalance(ctx context.Context, b *rlib.Bill, dt *time.Time) (rlib.Money, error) {
+	paid, err := rlib.GetBillPaidAmount(ctx, b.BILLID, dt)
+	if err != nil {
+		return 0, err
+	}
+	return b.Amount - paid, nil
+}
+
+// ValidateVendorPayment checks payment p and its allocations before it is
+// posted.
+//
+// INPUTS
+//  ctx = db context
+//  p   = the payment, p.VPA holds the bills it pays
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ValidateVendorPayment(ctx context.Context, p *rlib.VendorPayment) []BizError {
+	var e []BizError
+	v, err := rlib.GetVendor(ctx, p.VID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if v.VID == 0 || v.BID != p.BID {
+		return bizErrf(e, VendorNotFound, p.VID, p.BID)
+	}
+	if p.Method != rlib.VPMTMETHODcheck && p.Method != rlib.VPMTMETHODach {
+		e = bizErrf(e, VendorPaymentMethod, p.Method)
+	}
+	d, err := rlib.GetDepository(ctx, p.DEPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if d.DEPID == 0 || d.BID != p.BID {
+		e = bizErrf(e, DepositoryNotFound, p.DEPID, p.BID)
+	} else {
+		e = checkPostingAccount(ctx, p.BID, d.LID, e)
+	}
+
+	tot := rlib.Money(0)
+	for i := 0; i < len(p.VPA); i++ {
+		p.VPA[i].BID = p.BID
+		b, err := rlib.GetBill(ctx, p.VPA[i].BILLID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if b.BILLID == 0 || b.BID != p.BID || b.VID != p.VID || b.FLAGS&rlib.BILLVoid != 0 {
+			e = bizErrf(e, BillNotPayable, p.VPA[i].BILLID, p.VID)
+			continue
+		}
+		bal, err := BillBalance(ctx, &b, &rlib.ENDOFTIME)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if p.VPA[i].Amount <= 0 {
+			e = AddBizErrToList(e, InvalidField)
+		} else if p.VPA[i].Amount > bal {
+			e = bizErrf(e, BillOverpaid, p.VPA[i].Amount, b.BILLID, bal)
+		}
+		tot += p.VPA[i].Amount
+	}
+	if p.Amount <= 0 || len(p.VPA) == 0 {
+		e = AddBizErrToList(e, InvalidField)
+	} else if tot != p.Amount {
+		e = bizErrf(e, VendorPaymentTotal, tot, p.Amount)
+	}
+	return append(e, CheckPeriodOpen(ctx, p.BID, &p.Dt)...)
+}
+
+// vendorPaymentPostings returns the postings for payment p: for each bill it
+// pays the bill's Accounts Payable account is debited and the Depository's
+// GL Account credited. Amounts are multiplied by sign.
+//-------------------------------------------------------------------------------------
+func vendorPaymentPostings(ctx context.Context, p *rlib.VendorPayment, sign rlib.Money) ([]apPosting, error) {
+	var m []apPosting
+	d, err := rlib.GetDepository(ctx, p.DEPID)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(p.VPA); i++ {
+		b, err := rlib.GetBill(ctx, p.VPA[i].BILLID)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, apPosting{debit: b.APLID, credit: d.LID, amt: sign * p.VPA[i].Amount})
+	}
+	return m, nil
+}
+
+// InsertVendorPayment validates payment p, writes it and its allocations
+// and posts it.
+//
+// INPUTS
+//  ctx = db context
+//  p   = the payment, p.VPA holds the bills it pays
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func InsertVendorPayment(ctx context.Context, p *rlib.VendorPayment) []BizError {
+	if e := ValidateVendorPayment(ctx, p); len(e) > 0 {
+		return e
+	}
+	if err := rlib.InsertVendorPayment(ctx, p); err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(p.VPA); i++ {
+		p.VPA[i].VPID = p.VPID
+		if err := rlib.InsertVendorPaymentAllocation(ctx, &p.VPA[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	m, err := vendorPaymentPostings(ctx, p, 1)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	comment := fmt.Sprintf("%s payment %s", rlib.VendorPaymentMethodName(p.Method), rlib.IDtoShortString("VPMT", p.VPID))
+	if p.JID, err = postAPJournal(ctx, p.BID, &p.Dt, rlib.JNLTYPEVPMT, p.VPID, comment, m); err != nil {
+		return bizErrSys(&err)
+	}
+	if err = rlib.UpdateVendorPayment(ctx, p); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// VoidVendorPayment voids payment p by posting the reverse of its journal
+// entry. The bills it paid are open again and the check written for it, if
+// any, is void. If the payment is in a closed period the reversal is posted
+// on the first open date.
+//
+// INPUTS
+//  ctx = db context
+//  p   = the payment
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func VoidVendorPayment(ctx context.Context, p *rlib.VendorPayment) []BizError {
+	if p.FLAGS&rlib.VPMTVoid != 0 {
+		return nil // it's already void
+	}
+	var err error
+	if p.VPA, err = rlib.GetVendorPaymentAllocations(ctx, p.VPID); err != nil {
+		return bizErrSys(&err)
+	}
+	revdt, err := reversalDate(ctx, p.BID, &p.Dt)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	m, err := vendorPaymentPostings(ctx, p, -1)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	comment := fmt.Sprintf("void of payment %s", rlib.IDtoShortString("VPMT", p.VPID))
+	if _, err = postAPJournal(ctx, p.BID, &revdt, rlib.JNLTYPEVPMT, p.VPID, comment, m); err != nil {
+		return bizErrSys(&err)
+	}
+	p.FLAGS |= rlib.VPMTVoid
+	if err = rlib.UpdateVendorPayment(ctx, p); err != nil {
+		return bizErrSys(&err)
+	}
+	c, err := rlib.GetBankCheckByVPID(ctx, p.VPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if c.CKID > 0 {
+		c.FLAGS |= rlib.CHECKVoid
+		if err = rlib.UpdateBankCheck(ctx, &c); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
@@ -80,6 +80,10 @@ func UpdateAssessment(ctx context.Context, anew *rlib.Assessment, mode int, dt *
 		(!aold.Start.Equal(anew.Start)) ||
 		(!aold.Stop.Equal(anew.Stop))
 	if reverse {
+		if errlist = CheckPeriodOpen(ctx, anew.BID, &anew.Start); len(errlist) > 0 {
+			return errlist
+		}
+
 		//---------------------------------------------------------------------------
 		// Reverse any instances which will be out of the new updated date range
 		// This must be done before calling ReverseAssessment, which modifies
@@ -183,8 +187,8 @@ func UpdateAssessmentEndDate(ctx context.Context, a *rlib.Assessment, dt *time.T
 		}
 		// found an instance, we need to prorate it
 		rlib.Console("Found instance to prorate: ASMID = %d\n", ai.ASMID)
-		amount, n, p := rlib.SimpleProrateAmount(ai.Amount, ai.RentCycle, ai.ProrationCycle, &ai.Start, dt, &ai.Start)
-		ai.Amount = amount
+		amount, n, p := rlib.SimpleProrateAmount(ai.Amount.Float(), ai.RentCycle, ai.ProrationCycle, &ai.Start, dt, &ai.Start)
+		ai.Amount = rlib.MoneyFromFloat(amount)
 		ai.RentCycle = rlib.RECURNONE
 		ai.ProrationCycle = rlib.RECURNONE
 		ai.Stop = ai.Start
@@ -245,7 +249,8 @@ func ReverseAssessment(ctx context.Context, aold *rlib.Assessment, mode int, dt
 		//---------------------------------------------------------
 		if epoch.RentCycle == rlib.RECURNONE {
 			// rlib.Console("EXITING ReverseAssessment.  PT 2\n")
-			return ReverseAssessmentInstance(ctx, &epoch, dt)
+			errlist = ReverseAssessmentInstance(ctx, &epoch, dt)
+			break
 		}
 
 		//---------------------------------------------------------
@@ -275,6 +280,9 @@ func ReverseAssessment(ctx context.Context, aold *rlib.Assessment, mode int, dt
 		return bizErrSys(&err)
 	}
 	// rlib.Console("EXITING ReverseAssessment\n")
+	if len(errlist) == 0 {
+		rlib.EmitWebhookEvent(ctx, aold.BID, rlib.WHEVTassessmentReversed, aold)
+	}
 	return errlist
 }
 
@@ -365,7 +373,9 @@ func ReverseAssessmentsAfterStop(ctx context.Context, aold *rlib.Assessment, dtS
 }
 
 // ReverseAssessmentInstance reverses a single instance of an assessment.
-// If the assessment has already been reversed, we return immediately.
+// If the assessment has already been reversed, we return immediately. If the
+// assessment is in a closed period the reversal is posted on the first open
+// date. The concessions posted on a rent assessment are reversed with it.
 //
 // INPUTS
 //    aold = the assessment to reverse
@@ -388,6 +398,15 @@ func ReverseAssessmentInstance(ctx context.Context, aold *rlib.Assessment, dt *t
 	anew.FLAGS |= 0x4 // set bit 2 to mark that this assessment is void
 	anew.AppendComment(fmt.Sprintf("Reversal of %s", aold.IDtoString()))
 
+	revdt, err := reversalDate(ctx, aold.BID, &aold.Start)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if !revdt.Equal(aold.Start) {
+		anew.Start = revdt
+		anew.Stop = revdt
+	}
+
 	// rlib.Console("RAI: anew = %#v\n", anew)
 
 	errlist := InsertAssessment(ctx, &anew, 1)
@@ -398,7 +417,7 @@ func ReverseAssessmentInstance(ctx context.Context, aold *rlib.Assessment, dt *t
 
 	aold.AppendComment(fmt.Sprintf("Reversed by %s", anew.IDtoString()))
 	aold.FLAGS |= 0x4 // set bit 2 to mark that this assessment is void
-	err := rlib.UpdateAssessment(ctx, aold)
+	err = rlib.UpdateAssessment(ctx, aold)
 	if err != nil {
 		rlib.Console("RAI: err 2\n")
 		return bizErrSys(&err)
@@ -421,6 +440,21 @@ func ReverseAssessmentInstance(ctx context.Context, aold *rlib.Assessment, dt *t
 			return be
 		}
 	}
+
+	//---------------------------------------------------------
+	// If concessions were posted on this rent charge, reverse
+	// them too and give the concessions their charge back
+	//---------------------------------------------------------
+	m, err := rlib.UnapplyConcessions(ctx, aold)
+	if err != nil {
+		rlib.Console("RAI: err 5\n")
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if be := ReverseAssessmentInstance(ctx, &m[i], dt); len(be) > 0 {
+			return be
+		}
+	}
 	rlib.Console("Exiting ReverseAssessmentInstance\n")
 	return nil
 }
@@ -728,7 +762,8 @@ func DeallocateAppliedFunds(ctx context.Context, a *rlib.Assessment, asmtRevID i
 }
 
 // InsertAssessment performs bizlogic checks first, then inserts the Assessment,
-// then adds the associated Journal and Ledger entries
+// then adds the associated Journal and Ledger entries. The assessment is
+// rejected if it starts in a closed period.
 //
 // INPUTS
 //    a = the assessment to insert
@@ -747,6 +782,9 @@ func InsertAssessment(ctx context.Context, a *rlib.Assessment, exp int) []BizErr
 	if len(errlist) > 0 {
 		return errlist
 	}
+	if errlist = CheckPeriodOpen(ctx, a.BID, &a.Start); len(errlist) > 0 {
+		return errlist
+	}
 
 	rlib.Console("A.  a.ASMID = %d, a.BID = %d, a.ARID = %d\n", a.ASMID, a.BID, a.ARID)
 	var xbiz rlib.XBusiness
@@ -803,6 +841,7 @@ func InsertAssessment(ctx context.Context, a *rlib.Assessment, exp int) []BizErr
 		}
 	}
 	// rlib.Console("D\n")
+	rlib.EmitWebhookEvent(ctx, a.BID, rlib.WHEVTassessmentCreated, a)
 	return nil
 }
 
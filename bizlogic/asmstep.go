@@ -0,0 +1,197 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// SaveAssessmentStep validates and saves a scheduled change of a recurring
+// assessment. A step sets either a new Amount or an escalation Pct, only an
+// escalation can repeat each anniversary. A step that has been applied
+// cannot be changed.
+//
+// INPUTS
+//  ctx = db context
+//  s   = the step, ASTID is 0 for a new step
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveAssessmentStep(ctx context.Context, s *rlib.AssessmentStep) []BizError {
+	var errlist []BizError
+	if s.ASTID > 0 {
+		old, err := rlib.GetAssessmentStep(ctx, s.ASTID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.FLAGS&rlib.ASTEPApplied != 0 {
+			return bizErrf(nil, AsmStepApplied, s.ASTID)
+		}
+	}
+	a, err := rlib.GetAssessment(ctx, s.ASMID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if a.ASMID == 0 || a.BID != s.BID || a.PASMID != 0 || a.RentCycle == rlib.RECURNONE || a.FLAGS&rlib.ASMREVERSED != 0 {
+		return bizErrf(nil, AsmStepNotRecurring, s.ASMID)
+	}
+	s.RAID = a.RAID
+	if !s.Dt.After(a.Start) || !s.Dt.Before(a.Stop) {
+		errlist = bizErrf(errlist, AsmStepDate, s.Dt.Format(rlib.RRDATEFMT3), a.Start.Format(rlib.RRDATEFMT3), a.Stop.Format(rlib.RRDATEFMT3), a.ASMID)
+	}
+	s.FLAGS &= rlib.ASTEPAnnual
+	s.NewASMID = 0
+	s.DtApplied = time.Time{}
+	if (s.Amount > 0) == (s.Pct != 0) || s.Amount < 0 || s.Pct <= -100 || (s.FLAGS&rlib.ASTEPAnnual != 0 && s.Pct == 0) {
+		errlist = AddBizErrToList(errlist, AsmStepAmount)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if s.ASTID == 0 {
+		err = rlib.InsertAssessmentStep(ctx, s)
+	} else {
+		err = rlib.UpdateAssessmentStep(ctx, s)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// DeleteAssessmentStep deletes a step that has not been applied
+//
+// INPUTS
+//  ctx = db context
+//  s   = the step
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func DeleteAssessmentStep(ctx context.Context, s *rlib.AssessmentStep) []BizError {
+	if s.FLAGS&rlib.ASTEPApplied != 0 {
+		return bizErrf(nil, AsmStepApplied, s.ASTID)
+	}
+	if err := rlib.DeleteAssessmentStep(ctx, s.ASTID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ApplyAssessmentStep splits the recurring assessment of step s on its date:
+// the definition is ended on s.Dt and a copy with the new amount starts on
+// s.Dt and stops when the original did. Instances already made on or after
+// s.Dt at the old amount are reversed; the copy makes them again up to
+// today. The other pending steps of the assessment move to the copy. An
+// annual escalation schedules its next anniversary.
+//
+// If the assessment was reversed or ends before the step, the step is
+// marked skipped.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  s   = the step
+//  now = date the step is applied
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ApplyAssessmentStep(ctx context.Context, s *rlib.AssessmentStep, now *time.Time) []BizError {
+	if s.FLAGS&rlib.ASTEPApplied != 0 {
+		return bizErrf(nil, AsmStepApplied, s.ASTID)
+	}
+	a, err := rlib.GetAssessment(ctx, s.ASMID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if a.ASMID == 0 || a.PASMID != 0 || a.RentCycle == rlib.RECURNONE {
+		return bizErrf(nil, AsmStepNotRecurring, s.ASMID)
+	}
+	s.DtApplied = *now
+	if a.FLAGS&rlib.ASMREVERSED != 0 || !s.Dt.Before(a.Stop) {
+		s.FLAGS |= rlib.ASTEPApplied | rlib.ASTEPSkipped
+		if err = rlib.UpdateAssessmentStep(ctx, s); err != nil {
+			return bizErrSys(&err)
+		}
+		return nil
+	}
+
+	//--------------------------------------------------
+	// reverse the instances made at the old amount
+	//--------------------------------------------------
+	stop := a.Stop
+	m, err := rlib.GetAssessmentInstancesByParent(ctx, a.ASMID, &s.Dt, &stop)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].Start.Before(s.Dt) || m[i].FLAGS&rlib.ASMREVERSED != 0 {
+			continue
+		}
+		if errlist := ReverseAssessment(ctx, &m[i], 0, now); len(errlist) > 0 {
+			return errlist
+		}
+	}
+
+	//--------------------------------------------------
+	// split the definition
+	//--------------------------------------------------
+	if err = UpdateAssessmentEndDate(ctx, &a, &s.Dt); err != nil {
+		return bizErrSys(&err)
+	}
+	n := a
+	n.ASMID = 0
+	n.InvoiceNo = 0
+	n.FLAGS &= 0x8ffffffffffffffc // zero bits 0:1
+	n.Start = s.Dt
+	n.Stop = stop
+	n.Amount = rlib.AssessmentStepAmount(a.Amount, s)
+	n.Comment = ""
+	if len(s.Comment) > 0 {
+		n.AppendComment(s.Comment)
+	}
+	n.AppendComment(fmt.Sprintf("step %d, was %s", s.ASTID, a.Amount))
+	if errlist := InsertAssessment(ctx, &n, 1); len(errlist) > 0 {
+		return errlist
+	}
+
+	//--------------------------------------------------
+	// the other pending steps now change the copy
+	//--------------------------------------------------
+	steps, err := rlib.GetAssessmentStepsByASMID(ctx, a.ASMID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(steps); i++ {
+		if steps[i].ASTID == s.ASTID || steps[i].FLAGS&rlib.ASTEPApplied != 0 {
+			continue
+		}
+		steps[i].ASMID = n.ASMID
+		if err = rlib.UpdateAssessmentStep(ctx, &steps[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+
+	s.NewASMID = n.ASMID
+	s.FLAGS |= rlib.ASTEPApplied
+	if err = rlib.UpdateAssessmentStep(ctx, s); err != nil {
+		return bizErrSys(&err)
+	}
+	if s.FLAGS&rlib.ASTEPAnnual != 0 {
+		next := *s
+		next.ASTID = 0
+		next.ASMID = n.ASMID
+		next.NewASMID = 0
+		next.Dt = s.Dt.AddDate(1, 0, 0)
+		next.DtApplied = time.Time{}
+		next.FLAGS = rlib.ASTEPAnnual
+		if next.Dt.Before(stop) {
+			if err = rlib.InsertAssessmentStep(ctx, &next); err != nil {
+				return bizErrSys(&err)
+			}
+		}
+	}
+	return nil
+}
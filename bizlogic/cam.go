@@ -0,0 +1,356 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// camTenant collects the Rental Agreement versions of one tenant in the year
+type camTenant struct {
+	ras      []rlib.RentalAgreement
+	latest   *rlib.RentalAgreement
+	rid      int64          // rentable for the true-up
+	sqftdays int64          // square feet x days leased
+	days     map[int64]bool // the days leased, as days since d1
+}
+
+// ComputeCAMRecon computes the reconciliation of CAM pool p for the year
+// d1 - d2. Nothing is saved.
+//
+// The expenses are the activity in the pool's GL accounts. They are
+// allocated to each commercial tenant, a Rental Agreement with an
+// ExpenseAdjustmentType, by its share of the square feet of the property
+// for the days it leased its space. All versions of an amended Rental
+// Agreement are one tenant; the latest version's terms apply and its
+// Rental Agreement gets the true-up.
+//
+// INPUTS
+//  ctx = db context
+//  p   = the pool
+//  d1  = start of the year
+//  d2  = end of the year, not included
+//
+// RETURNS
+//  the reconciliation
+//  its items, one per tenant
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ComputeCAMRecon(ctx context.Context, p *rlib.CAMPool, d1, d2 *time.Time) (rlib.CAMRecon, []rlib.CAMReconItem, []BizError) {
+	var (
+		items []rlib.CAMReconItem
+		xbiz  rlib.XBusiness
+	)
+	r := rlib.CAMRecon{BID: p.BID, CAMPID: p.CAMPID, DtStart: *d1, DtStop: *d2}
+
+	lids, err := rlib.CAMPoolLIDs(p.LIDs)
+	if err != nil {
+		return r, items, bizErrSys(&err)
+	}
+	if len(lids) == 0 {
+		return r, items, bizErrf(nil, CAMNoAccounts, p.CAMPID)
+	}
+	if err = rlib.GetXBusiness(ctx, p.BID, &xbiz); err != nil {
+		return r, items, bizErrSys(&err)
+	}
+	if r.Expenses, err = camExpenses(ctx, p.BID, lids, d1, d2); err != nil {
+		return r, items, bizErrSys(&err)
+	}
+
+	//--------------------------------------------------
+	// The size of each rentable and of the property
+	//--------------------------------------------------
+	sqft := map[int64]int64{}
+	rentables, err := rlib.GetRentablesByBusiness(ctx, p.BID)
+	if err != nil {
+		return r, items, bizErrSys(&err)
+	}
+	last := d2.AddDate(0, 0, -1)
+	for i := 0; i < len(rentables); i++ {
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, rentables[i].RID, &last)
+		if err != nil {
+			return r, items, bizErrSys(&err)
+		}
+		if rtr.RTID == 0 {
+			continue // the rentable was not there at the end of the year
+		}
+		n, err := rlib.RentableTypeSqft(&xbiz, rtr.RTID)
+		if err != nil {
+			return r, items, bizErrSys(&err)
+		}
+		sqft[rentables[i].RID] = n
+		r.TotalSqft += n
+	}
+	if r.TotalSqft == 0 {
+		return r, items, bizErrf(nil, CAMNoSqft)
+	}
+	yeardays := int64(d2.Sub(*d1).Hours()/24 + 0.5)
+
+	//--------------------------------------------------
+	// Group the Rental Agreement versions by tenant
+	//--------------------------------------------------
+	ras, err := rlib.GetRentalAgreementsByRange(ctx, p.BID, d1, d2)
+	if err != nil {
+		return r, items, bizErrSys(&err)
+	}
+	tenants := map[int64]*camTenant{}
+	var keys []int64
+	for i := 0; i < len(ras); i++ {
+		if ras[i].FLAGS&0xf < rlib.RASTATEMoveIn {
+			continue // not in effect
+		}
+		key := ras[i].ORIGIN
+		if key == 0 {
+			key = ras[i].RAID
+		}
+		t, ok := tenants[key]
+		if !ok {
+			t = &camTenant{days: map[int64]bool{}}
+			tenants[key] = t
+			keys = append(keys, key)
+		}
+		t.ras = append(t.ras, ras[i])
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	baseyears := map[time.Time]rlib.Money{}
+	var errlist []BizError
+	for _, key := range keys {
+		t := tenants[key]
+		for i := 0; i < len(t.ras); i++ {
+			if t.latest == nil || t.ras[i].AgreementStart.After(t.latest.AgreementStart) {
+				t.latest = &t.ras[i]
+			}
+		}
+		if t.latest.ExpenseAdjustmentType == rlib.EXPADJNone {
+			continue // not a commercial lease with pass-throughs
+		}
+
+		//--------------------------------------------------
+		// square feet x days leased, the days leased and
+		// the estimated charges billed, over all versions
+		//--------------------------------------------------
+		a := rlib.CAMReconItem{BID: p.BID, RAID: t.latest.RAID}
+		for i := 0; i < len(t.ras); i++ {
+			ra := &t.ras[i]
+			rars, err := rlib.GetRentalAgreementRentables(ctx, ra.RAID, d1, d2)
+			if err != nil {
+				return r, items, bizErrSys(&err)
+			}
+			for j := 0; j < len(rars); j++ {
+				if t.rid == 0 || ra.RAID == t.latest.RAID {
+					t.rid = rars[j].RID // prefer a rentable of the latest version
+				}
+				start := camMaxTime(*d1, rars[j].RARDtStart, ra.AgreementStart)
+				stop := camMinTime(*d2, rars[j].RARDtStop, ra.AgreementStop)
+				for dt := start; dt.Before(stop); dt = dt.AddDate(0, 0, 1) {
+					t.sqftdays += sqft[rars[j].RID]
+					t.days[int64(dt.Sub(*d1).Hours()/24)] = true
+				}
+			}
+			if p.EstimateARID > 0 {
+				m, err := rlib.GetAssessmentsByRAIDARID(ctx, ra.RAID, p.EstimateARID, d1, d2)
+				if err != nil {
+					return r, items, bizErrSys(&err)
+				}
+				for j := 0; j < len(m); j++ {
+					a.Estimates += m[j].Amount
+				}
+			}
+		}
+		if len(t.days) == 0 {
+			continue
+		}
+		a.RID = t.rid
+		a.Days = int64(len(t.days))
+		a.Sqft = int64(float64(t.sqftdays)/float64(a.Days) + 0.5)
+
+		//--------------------------------------------------
+		// expenses of the tenant's base year
+		//--------------------------------------------------
+		var base rlib.Money
+		if t.latest.ExpenseAdjustmentType == rlib.EXPADJBaseYear {
+			if t.latest.BaseYearEnd.Year() <= 1970 {
+				errlist = bizErrf(errlist, CAMNoBaseYear, t.latest.RAID)
+				continue
+			}
+			b2 := t.latest.BaseYearEnd.AddDate(0, 0, 1)
+			b1 := b2.AddDate(-1, 0, 0)
+			var ok bool
+			if base, ok = baseyears[b2]; !ok {
+				if base, err = camExpenses(ctx, p.BID, lids, &b1, &b2); err != nil {
+					return r, items, bizErrSys(&err)
+				}
+				baseyears[b2] = base
+			}
+		}
+		rlib.CAMComputeItem(&a, t.latest.ExpenseAdjustmentType, t.latest.ExpensesStop, r.Expenses, base, r.TotalSqft, yeardays)
+		items = append(items, a)
+	}
+	return r, items, errlist
+}
+
+// camExpenses returns the total activity of GL accounts lids in d1 - d2
+func camExpenses(ctx context.Context, bid int64, lids []int64, d1, d2 *time.Time) (rlib.Money, error) {
+	var tot rlib.Money
+	for _, lid := range lids {
+		amt, err := rlib.GetAccountActivity(ctx, bid, lid, d1, d2)
+		if err != nil {
+			return tot, err
+		}
+		tot += amt
+	}
+	return tot, nil
+}
+
+func camMaxTime(t ...time.Time) time.Time {
+	m := t[0]
+	for i := 1; i < len(t); i++ {
+		if t[i].After(m) {
+			m = t[i]
+		}
+	}
+	return m
+}
+
+func camMinTime(t ...time.Time) time.Time {
+	m := t[0]
+	for i := 1; i < len(t); i++ {
+		if t[i].Before(m) {
+			m = t[i]
+		}
+	}
+	return m
+}
+
+// SaveCAMRecon computes and saves the reconciliation of CAM pool p for the
+// year d1 - d2. An earlier reconciliation of the same year that has not been
+// posted is replaced.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  p   = the pool
+//  d1  = start of the year
+//  d2  = end of the year, not included
+//
+// RETURNS
+//  the saved reconciliation
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveCAMRecon(ctx context.Context, p *rlib.CAMPool, d1, d2 *time.Time) (rlib.CAMRecon, []BizError) {
+	r, items, errlist := ComputeCAMRecon(ctx, p, d1, d2)
+	if len(errlist) > 0 {
+		return r, errlist
+	}
+	m, err := rlib.GetCAMReconsByCAMPID(ctx, p.CAMPID)
+	if err != nil {
+		return r, bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if !m[i].DtStart.Equal(*d1) {
+			continue
+		}
+		if errlist = DeleteCAMRecon(ctx, &m[i]); len(errlist) > 0 {
+			return r, errlist
+		}
+	}
+	if err = rlib.InsertCAMRecon(ctx, &r); err != nil {
+		return r, bizErrSys(&err)
+	}
+	for i := 0; i < len(items); i++ {
+		items[i].CAMRID = r.CAMRID
+		if err = rlib.InsertCAMReconItem(ctx, &items[i]); err != nil {
+			return r, bizErrSys(&err)
+		}
+	}
+	return r, nil
+}
+
+// DeleteCAMRecon deletes reconciliation r and its items. A posted
+// reconciliation cannot be deleted.
+//
+// INPUTS
+//  ctx = db context
+//  r   = the reconciliation
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func DeleteCAMRecon(ctx context.Context, r *rlib.CAMRecon) []BizError {
+	if r.FLAGS&rlib.CAMRECONPosted != 0 {
+		return bizErrf(nil, CAMReconPosted, r.CAMRID)
+	}
+	if err := rlib.DeleteCAMReconItems(ctx, r.CAMRID); err != nil {
+		return bizErrSys(&err)
+	}
+	if err := rlib.DeleteCAMRecon(ctx, r.CAMRID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// PostCAMRecon makes the true-up assessments of reconciliation r. A tenant
+// who was billed too little is charged with the pool's TrueUpARID, one who
+// was billed too much is credited with its CreditARID. The reconciliation
+// is marked posted and can no longer be changed.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  r   = the reconciliation
+//  dt  = date of the assessments
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func PostCAMRecon(ctx context.Context, r *rlib.CAMRecon, dt *time.Time) []BizError {
+	if r.FLAGS&rlib.CAMRECONPosted != 0 {
+		return bizErrf(nil, CAMReconPosted, r.CAMRID)
+	}
+	p, err := rlib.GetCAMPool(ctx, r.CAMPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if p.TrueUpARID == 0 || p.CreditARID == 0 {
+		return bizErrf(nil, CAMNoTrueUpARID, p.CAMPID)
+	}
+	m, err := rlib.GetCAMReconItems(ctx, r.CAMRID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].TrueUp == 0 || m[i].ASMID > 0 {
+			continue
+		}
+		asm := rlib.Assessment{
+			BID:            r.BID,
+			RID:            m[i].RID,
+			RAID:           m[i].RAID,
+			Amount:         m[i].TrueUp,
+			Start:          *dt,
+			Stop:           *dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           p.TrueUpARID,
+			Comment:        fmt.Sprintf("%s reconciliation %s - %s", p.Name, r.DtStart.Format(rlib.RRDATEFMT3), r.DtStop.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3)),
+		}
+		if m[i].TrueUp < 0 {
+			asm.Amount = -asm.Amount
+			asm.ARID = p.CreditARID
+		}
+		if errlist := InsertAssessment(ctx, &asm, 0); len(errlist) > 0 {
+			return errlist
+		}
+		m[i].ASMID = asm.ASMID
+		if err = rlib.UpdateCAMReconItem(ctx, &m[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	r.FLAGS |= rlib.CAMRECONPosted
+	r.DtPosted = *dt
+	if err = rlib.UpdateCAMRecon(ctx, r); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+)
+
+// nextCheckNo assigns the next check number of Depository depid. The
+// CheckAccount row stays locked until the transaction in ctx ends so checks
+// written at the same time get different numbers. It must be called with a
+// transaction in ctx.
+//
+// INPUTS
+//  ctx   = db context, with a transaction
+//  depid = the Depository the check is drawn on
+//
+// RETURNS
+//  the check number
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func nextCheckNo(ctx context.Context, depid int64) (int64, []BizError) {
+	ca, err := rlib.GetCheckAccountForUpdate(ctx, depid)
+	if err != nil {
+		return 0, bizErrSys(&err)
+	}
+	if ca.CKAID == 0 {
+		return 0, bizErrf(nil, NoCheckAccount, depid)
+	}
+	n := ca.NextCheckNo
+	ca.NextCheckNo++
+	if err = rlib.UpdateCheckAccount(ctx, &ca); err != nil {
+		return 0, bizErrSys(&err)
+	}
+	return n, nil
+}
+
+// ValidateCheck checks c before it is written. A check that is not for a
+// VendorPayment must have a GL Account to debit.
+//
+// INPUTS
+//  ctx = db context
+//  c   = the check
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ValidateCheck(ctx context.Context, c *rlib.BankCheck) []BizError {
+	var e []BizError
+	d, err := rlib.GetDepository(ctx, c.DEPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if d.DEPID == 0 || d.BID != c.BID {
+		e = bizErrf(e, DepositoryNotFound, c.DEPID, c.BID)
+	} else if c.VPID == 0 {
+		e = checkPostingAccount(ctx, c.BID, d.LID, e)
+		e = checkPostingAccount(ctx, c.BID, c.DebitLID, e)
+	}
+	c.Payee = strings.TrimSpace(c.Payee)
+	if len(c.Payee) == 0 || c.Amount <= 0 {
+		e = AddBizErrToList(e, InvalidField)
+	}
+	return append(e, CheckPeriodOpen(ctx, c.BID, &c.Dt)...)
+}
+
+// WriteCheck validates check c, assigns it the next number of its
+// Depository, writes it and posts it: c.DebitLID is debited and the
+// Depository's GL Account credited. Use WriteVendorPaymentCheck for checks
+// that pay bills. It must be called with a transaction in ctx.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  c   = the check
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func WriteCheck(ctx context.Context, c *rlib.BankCheck) []BizError {
+	c.VPID = 0
+	if e := ValidateCheck(ctx, c); len(e) > 0 {
+		return e
+	}
+	var e []BizError
+	if c.CheckNo, e = nextCheckNo(ctx, c.DEPID); len(e) > 0 {
+		return e
+	}
+	if err := rlib.InsertBankCheck(ctx, c); err != nil {
+		return bizErrSys(&err)
+	}
+	d, err := rlib.GetDepository(ctx, c.DEPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	p := []apPosting{{debit: c.DebitLID, credit: d.LID, raid: c.RAID, tcid: c.TCID, amt: c.Amount}}
+	comment := fmt.Sprintf("check %d to %s", c.CheckNo, c.Payee)
+	if c.JID, err = postAPJournal(ctx, c.BID, &c.Dt, rlib.JNLTYPECHK, c.CKID, comment, p); err != nil {
+		return bizErrSys(&err)
+	}
+	if err = rlib.UpdateBankCheck(ctx, c); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// WriteVendorPaymentCheck writes the check for vendor payment p. The payee,
+// amount, date and Depository are taken from the payment and the check
+// number is saved as the payment's DocNo. The payment has already been
+// posted so the check is not posted again. It must be called with a
+// transaction in ctx.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  p   = the vendor payment
+//  c   = the check, only Memo is used from the caller
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func WriteVendorPaymentCheck(ctx context.Context, p *rlib.VendorPayment, c *rlib.BankCheck) []BizError {
+	if p.FLAGS&rlib.VPMTVoid != 0 || p.Method != rlib.VPMTMETHODcheck {
+		return bizErrf(nil, NotCheckPayment, p.VPID)
+	}
+	x, err := rlib.GetBankCheckByVPID(ctx, p.VPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if x.CKID > 0 {
+		return bizErrf(nil, VendorPaymentHasCheck, p.VPID, x.CheckNo)
+	}
+	v, err := rlib.GetVendor(ctx, p.VID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if v.VID == 0 {
+		return bizErrf(nil, VendorNotFound, p.VID, p.BID)
+	}
+
+	c.BID = p.BID
+	c.DEPID = p.DEPID
+	c.Dt = p.Dt
+	c.Payee = v.Name
+	c.PayeeAddress = vendorAddress(&v)
+	c.Amount = p.Amount
+	c.VPID = p.VPID
+	c.JID = p.JID
+	c.DebitLID = 0
+	if len(c.Memo) == 0 {
+		c.Memo = p.Comment
+	}
+	if e := ValidateCheck(ctx, c); len(e) > 0 {
+		return e
+	}
+	var e []BizError
+	if c.CheckNo, e = nextCheckNo(ctx, c.DEPID); len(e) > 0 {
+		return e
+	}
+	if err = rlib.InsertBankCheck(ctx, c); err != nil {
+		return bizErrSys(&err)
+	}
+	p.DocNo = fmt.Sprintf("%d", c.CheckNo)
+	if err = rlib.UpdateVendorPayment(ctx, p); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// vendorAddress returns the mailing address of v, one line per line
+func vendorAddress(v *rlib.Vendor) string {
+	var m []string
+	for _, s := range []string{v.Address, v.Address2} {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			m = append(m, s)
+		}
+	}
+	s := strings.TrimSpace(v.City)
+	if len(v.State) > 0 {
+		s = strings.TrimSpace(s + ", " + v.State)
+	}
+	s = strings.TrimSpace(s + " " + v.PostalCode)
+	if len(s) > 0 {
+		m = append(m, strings.TrimPrefix(s, ", "))
+	}
+	return strings.Join(m, "\n")
+}
+
+// VoidCheck voids check c. A check for a vendor payment voids the payment,
+// which reopens the bills it paid. Any other check is voided by posting the
+// reverse of its journal entry. If the check is in a closed period the
+// reversal is posted on the first open date.
+//
+// INPUTS
+//  ctx = db context
+//  c   = the check
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func VoidCheck(ctx context.Context, c *rlib.BankCheck) []BizError {
+	if c.FLAGS&rlib.CHECKVoid != 0 {
+		return nil // it's already void
+	}
+	if c.VPID > 0 {
+		p, err := rlib.GetVendorPayment(ctx, c.VPID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if p.VPID > 0 && p.FLAGS&rlib.VPMTVoid == 0 {
+			return VoidVendorPayment(ctx, &p) // it voids this check
+		}
+	} else {
+		d, err := rlib.GetDepository(ctx, c.DEPID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		revdt, err := reversalDate(ctx, c.BID, &c.Dt)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		p := []apPosting{{debit: c.DebitLID, credit: d.LID, raid: c.RAID, tcid: c.TCID, amt: -c.Amount}}
+		comment := fmt.Sprintf("void of check %d", c.CheckNo)
+		if _, err = postAPJournal(ctx, c.BID, &revdt, rlib.JNLTYPECHK, c.CKID, comment, p); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	c.FLAGS |= rlib.CHECKVoid
+	if err := rlib.UpdateBankCheck(ctx, c); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
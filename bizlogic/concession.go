@@ -0,0 +1,108 @@
+package bizlogic
+
+import (
+	"context"
+	"rentroll/rlib"
+	"strings"
+)
+
+// concessionTermsCheck validates the terms of a concession: exactly one of
+// amount and pct, the number of rent charges, and the concession expense
+// account rule.
+//
+// INPUTS
+//  ctx    = db context
+//  bid    = the business
+//  arid   = account rule of the concession expense
+//  amount = amount off each rent charge
+//  pct    = percent off each rent charge
+//  cycles = number of rent charges
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func concessionTermsCheck(ctx context.Context, bid, arid int64, amount rlib.Money, pct float64, cycles int64) []BizError {
+	var errlist []BizError
+	if (amount > 0) == (pct > 0) || amount < 0 || pct < 0 || pct > 100 {
+		errlist = bizErrf(errlist, ConcessionTerms)
+	}
+	if cycles < 1 {
+		errlist = bizErrf(errlist, ConcessionCycles)
+	}
+	ar, err := rlib.GetAR(ctx, arid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if ar.ARID == 0 || ar.BID != bid {
+		errlist = bizErrf(errlist, ConcessionNoAR, arid, bid)
+	}
+	return errlist
+}
+
+// SaveConcession validates and saves a business's concession definition.
+// Changes do not affect the Rental Agreements that were already given the
+// concession, they have their own copy of the terms.
+//
+// INPUTS
+//  ctx = db context
+//  c   = the concession, CONID is 0 for a new one
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveConcession(ctx context.Context, c *rlib.Concession) []BizError {
+	var errlist []BizError
+	c.Name = strings.TrimSpace(c.Name)
+	if len(c.Name) == 0 {
+		errlist = AddBizErrToList(errlist, MissingName)
+	}
+	if c.CONID > 0 {
+		old, err := rlib.GetConcession(ctx, c.CONID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.CONID == 0 || old.BID != c.BID {
+			return bizErrf(errlist, ConcessionNotOffered, c.CONID, c.BID)
+		}
+	}
+	errlist = append(errlist, concessionTermsCheck(ctx, c.BID, c.ARID, c.Amount, c.Pct, c.Cycles)...)
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	var err error
+	if c.CONID == 0 {
+		err = rlib.InsertConcession(ctx, c)
+	} else {
+		err = rlib.UpdateConcession(ctx, c)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ValidateRentalAgreementConcession validates a concession given on the
+// rent of a Rentable in a Rental Agreement.
+//
+// INPUTS
+//  ctx = db context
+//  a   = the concession given
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ValidateRentalAgreementConcession(ctx context.Context, a *rlib.RentalAgreementConcession) []BizError {
+	con, err := rlib.GetConcession(ctx, a.CONID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if con.CONID == 0 || con.BID != a.BID {
+		return bizErrf(nil, ConcessionNotOffered, a.CONID, a.BID)
+	}
+	errlist := concessionTermsCheck(ctx, a.BID, a.ARID, a.Amount, a.Pct, a.Cycles)
+	if a.Applied < 0 || a.Applied > a.Cycles {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+	return errlist
+}
@@ -140,7 +140,15 @@ func SaveDeposit(ctx context.Context, a *rlib.Deposit, newRcpts []int64) []BizEr
 	// rlib.Console("SaveDeposit: 0\n")
 	var e []BizError
 	var rlist []rlib.Receipt
-	tot := float64(0)
+	tot := rlib.Money(0)
+
+	//----------------------------------------------------------------
+	// Deposits in a closed period cannot be added or changed
+	//----------------------------------------------------------------
+	if e = CheckPeriodOpen(ctx, a.BID, &a.Dt); len(e) > 0 {
+		return e
+	}
+
 	//----------------------------------------------------------------
 	// First, validate that all newRcpts are eligible for inclusion
 	// in this receipt
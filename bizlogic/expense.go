@@ -7,8 +7,27 @@ import (
 	"time"
 )
 
+// InsertExpense inserts a new expense and adds the associated Journal and
+// Ledger entries. The expense is rejected if it is dated in a closed period.
+//-----------------------------------------------------------------------------
+func InsertExpense(ctx context.Context, a *rlib.Expense) []BizError {
+	if errlist := CheckPeriodOpen(ctx, a.BID, &a.Dt); len(errlist) > 0 {
+		return errlist
+	}
+	_, err := rlib.InsertExpense(ctx, a)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	var xbiz rlib.XBusiness
+	if err = rlib.ProcessNewExpense(ctx, a, &xbiz); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
 // ReverseExpense reverse an expense. If the Expense has already been reversed
-// it returns immediately.
+// it returns immediately. If the expense is in a closed period the reversal
+// is posted on the first open date.
 //-----------------------------------------------------------------------------
 func ReverseExpense(ctx context.Context, aold *rlib.Expense, dt *time.Time) []BizError {
 	var errlist []BizError
@@ -16,14 +35,20 @@ func ReverseExpense(ctx context.Context, aold *rlib.Expense, dt *time.Time) []Bi
 		return nil // it's already reversed
 	}
 
+	revdt, err := reversalDate(ctx, aold.BID, &aold.Dt)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+
 	anew := *aold
+	anew.Dt = revdt
 	anew.EXPID = 0
 	anew.Amount = -anew.Amount
 	anew.RPEXPID = aold.EXPID
 	anew.FLAGS |= 0x4 // set bit 2 to mark that this expense is void
 	anew.Comment = fmt.Sprintf("Reversal of %s", aold.IDtoShortString())
 
-	_, err := rlib.InsertExpense(ctx, &anew)
+	_, err = rlib.InsertExpense(ctx, &anew)
 	if err != nil {
 		return bizErrSys(&err)
 	}
@@ -81,6 +106,9 @@ func UpdateExpense(ctx context.Context, anew *rlib.Expense, dt *time.Time) []Biz
 	//   Dt
 	//---------------------------------------------------------------------------------
 	if aold.ARID != anew.ARID || aold.Amount != anew.Amount || (!aold.Dt.Equal(anew.Dt)) {
+		if errlist = CheckPeriodOpen(ctx, anew.BID, &anew.Dt); len(errlist) > 0 {
+			return errlist
+		}
 		errlist = ReverseExpense(ctx, &aold, dt) // reverse the expense itself
 		if errlist != nil {
 			return errlist
@@ -0,0 +1,634 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// GetActivePaymentGateway returns gateway pgid if it is an active gateway
+// of business bid
+//
+// INPUTS
+//  ctx  = db context
+//  bid  = the business
+//  pgid = the gateway
+//
+// RETURNS
+//  the gateway
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func GetActivePaymentGateway(ctx context.Context, bid, pgid int64) (rlib.PaymentGateway, []BizError) {
+	pg, err := rlib.GetPaymentGateway(ctx, pgid)
+	if err != nil {
+		return pg, bizErrSys(&err)
+	}
+	if pg.PGID == 0 || pg.BID != bid || pg.FLAGS&rlib.PGInactive != 0 {
+		return pg, bizErrf(nil, GatewayUnknown, pgid, bid)
+	}
+	return pg, nil
+}
+
+// SavePaymentGateway validates and saves the configuration of a payment
+// gateway. The payment type, depository and account rule used for its
+// receipts must belong to the business.
+//
+// INPUTS
+//  ctx = db context
+//  pg  = the gateway, PGID is 0 for a new gateway
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SavePaymentGateway(ctx context.Context, pg *rlib.PaymentGateway) []BizError {
+	var errlist []BizError
+	pg.Name = strings.TrimSpace(pg.Name)
+	pg.URL = strings.TrimSpace(pg.URL)
+	if len(pg.Name) == 0 {
+		errlist = AddBizErrToList(errlist, MissingName)
+	}
+	if !strings.HasPrefix(pg.URL, "http://") && !strings.HasPrefix(pg.URL, "https://") {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+	var pt rlib.PaymentType
+	if err := rlib.GetPaymentType(ctx, pg.PMTID, &pt); err != nil {
+		return bizErrSys(&err)
+	}
+	dep, err := rlib.GetDepository(ctx, pg.DEPID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	ar, err := rlib.GetAR(ctx, pg.ARID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if pt.PMTID == 0 || pt.BID != pg.BID || dep.DEPID == 0 || dep.BID != pg.BID || ar.ARID == 0 || ar.BID != pg.BID {
+		errlist = bizErrf(errlist, GatewayConfig, pg.Name, pg.BID)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if pg.PGID == 0 {
+		err = rlib.InsertPaymentGateway(ctx, pg)
+	} else {
+		err = rlib.UpdatePaymentGateway(ctx, pg)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// gatewayErr turns an error from the gateway API into a BizError
+//-----------------------------------------------------------------------------
+func gatewayErr(err error) []BizError {
+	if _, ok := err.(*rlib.GatewayDecline); ok {
+		return bizErrf(nil, GatewayDeclined, err.Error())
+	}
+	return bizErrf(nil, GatewayUnavailable, err.Error())
+}
+
+// SaveCard tokenizes card at gateway pg and saves the token as a card of
+// payor tcid. The card number is not kept.
+//
+// INPUTS
+//  ctx  = db context
+//  api  = client for the gateway
+//  pg   = the gateway
+//  tcid = the card holder
+//  card = the card
+//
+// RETURNS
+//  the saved CardToken
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveCard(ctx context.Context, api rlib.PaymentGatewayAPI, pg *rlib.PaymentGateway, tcid int64, card *rlib.GatewayCard) (rlib.CardToken, []BizError) {
+	var t rlib.Transactant
+	if err := rlib.GetTransactant(ctx, tcid, &t); err != nil {
+		return rlib.CardToken{}, bizErrSys(&err)
+	}
+	if t.TCID == 0 || t.BID != pg.BID {
+		return rlib.CardToken{}, bizErrf(nil, UnknownTCID, tcid, pg.BID)
+	}
+	ct, err := api.Tokenize(card)
+	if err != nil {
+		return ct, gatewayErr(err)
+	}
+	ct.BID = pg.BID
+	ct.TCID = tcid
+	ct.PGID = pg.PGID
+	if err = rlib.InsertCardToken(ctx, &ct); err != nil {
+		return ct, bizErrSys(&err)
+	}
+	return ct, nil
+}
+
+// RemoveCard marks card ct removed. It stays on file for the transactions
+// that were made with it.
+//
+// INPUTS
+//  ctx = db context
+//  ct  = the card
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func RemoveCard(ctx context.Context, ct *rlib.CardToken) []BizError {
+	ct.FLAGS |= rlib.CARDRemoved
+	if err := rlib.UpdateCardToken(ctx, ct); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// PayorAmountDue returns what payor tcid owes on dt: the unpaid portion of
+// the assessments the payor is responsible for less the funds of the
+// payor's receipts that have not been allocated yet.
+//
+// INPUTS
+//  ctx  = db context
+//  bid  = the business
+//  tcid = the payor
+//  dt   = the date
+//
+// RETURNS
+//  the amount due, may be negative if the payor has a credit
+//  any error encountered
+//-----------------------------------------------------------------------------
+func PayorAmountDue(ctx context.Context, bid, tcid int64, dt *time.Time) (rlib.Money, error) {
+	var due rlib.Money
+	m, err := GetAllUnpaidAssessmentsForPayor(ctx, bid, tcid, dt)
+	if err != nil {
+		return due, err
+	}
+	for i := 0; i < len(m); i++ {
+		due += AssessmentUnpaidPortion(ctx, &m[i])
+	}
+	n, err := rlib.GetUnallocatedReceiptsByPayor(ctx, bid, tcid)
+	if err != nil {
+		return due, err
+	}
+	for i := 0; i < len(n); i++ {
+		due -= RemainingReceiptFunds(ctx, &n[i])
+	}
+	return due, nil
+}
+
+// saveGatewayTransaction writes gt outside of any transaction. It is used
+// once the gateway has acted, when the result must be kept even though the
+// rest of the work was rolled back. A failure can only be logged.
+//-----------------------------------------------------------------------------
+func saveGatewayTransaction(ctx context.Context, funcname string, gt *rlib.GatewayTransaction) {
+	if err := rlib.UpdateGatewayTransaction(ctx, gt); err != nil {
+		rlib.LogAndPrintError(funcname, fmt.Errorf("gateway transaction %d (%s, status %d, %s) not saved: %s", gt.GTID, gt.TxnID, gt.Status, gt.Message, err.Error()))
+	}
+}
+
+// gatewayCallFailed records an error returned by the gateway API for
+// pending transaction gt. A decline means nothing happened at the gateway
+// and gt fails. Any other error leaves the outcome unknown, so gt stays
+// pending until it is checked at the gateway.
+//-----------------------------------------------------------------------------
+func gatewayCallFailed(ctx context.Context, funcname string, gt *rlib.GatewayTransaction, err error) []BizError {
+	if _, ok := err.(*rlib.GatewayDecline); ok {
+		gt.Status = rlib.GTSTATUSFailed
+	}
+	gt.Message = err.Error()
+	saveGatewayTransaction(ctx, funcname, gt)
+	return gatewayErr(err)
+}
+
+// startGatewayTransaction validates a charge or refund with check and
+// writes gt as pending in a transaction of its own. The pending record is
+// committed before the gateway is called so that nothing the gateway does
+// can be lost in a rollback.
+//-----------------------------------------------------------------------------
+func startGatewayTransaction(ctx context.Context, gt *rlib.GatewayTransaction, check func(ctx context.Context) []BizError) []BizError {
+	tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if errlist := check(tctx); len(errlist) > 0 {
+		tx.Rollback()
+		return errlist
+	}
+	gt.Status = rlib.GTSTATUSPending
+	if err = rlib.InsertGatewayTransaction(tctx, gt); err != nil {
+		tx.Rollback()
+		return bizErrSys(&err)
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// finishGatewayTransaction does the bookkeeping of a charge or refund the
+// gateway made, in a transaction of its own
+//-----------------------------------------------------------------------------
+func finishGatewayTransaction(ctx context.Context, finish func(ctx context.Context) error) error {
+	tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err = finish(tctx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// ChargeCard charges card ct and records the payment. If amount is 0 the
+// payor's balance due is charged. A successful charge becomes a receipt
+// with the gateway's transaction id as its DocNo, which is then allocated to
+// the payor's unpaid assessments. A decline is recorded as a failed
+// GatewayTransaction and is not returned as an error; check its Status.
+//
+// The charge is written as pending and committed before the card is
+// charged, and the receipt is written in a second transaction. If the
+// receipt cannot be recorded the charge is refunded and fails. If the
+// refund fails too, or the gateway's answer is not known, the charge stays
+// pending and must be checked at the gateway.
+//
+// INPUTS
+//  ctx    = db context, without a transaction
+//  api    = client for the gateway
+//  pg     = the gateway
+//  ct     = the card
+//  amount = amount to charge, 0 to charge the balance due
+//  dt     = date of the payment
+//
+// RETURNS
+//  the GatewayTransaction
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ChargeCard(ctx context.Context, api rlib.PaymentGatewayAPI, pg *rlib.PaymentGateway, ct *rlib.CardToken, amount rlib.Money, dt *time.Time) (rlib.GatewayTransaction, []BizError) {
+	const funcname = "ChargeCard"
+	gt := rlib.GatewayTransaction{BID: pg.BID, PGID: pg.PGID, TCID: ct.TCID, CTID: ct.CTID, Type: rlib.GTTYPECharge, Dt: *dt}
+	if ct.PGID != pg.PGID || ct.FLAGS&rlib.CARDRemoved != 0 {
+		return gt, bizErrf(nil, GatewayCardUnusable, ct.CTID, ct.TCID)
+	}
+	errlist := startGatewayTransaction(ctx, &gt, func(ctx context.Context) []BizError {
+		if errlist := CheckPeriodOpen(ctx, pg.BID, dt); len(errlist) > 0 {
+			return errlist
+		}
+		if amount == 0 {
+			var err error
+			if amount, err = PayorAmountDue(ctx, pg.BID, ct.TCID, dt); err != nil {
+				return bizErrSys(&err)
+			}
+		}
+		if amount <= 0 {
+			return bizErrf(nil, GatewayNothingDue, ct.TCID)
+		}
+		gt.Amount = amount
+		return nil
+	})
+	if len(errlist) > 0 {
+		return gt, errlist
+	}
+
+	res, err := api.Charge(ct.Token, amount, fmt.Sprintf("%s payor %d", rlib.GetBUDFromBIDList(pg.BID), ct.TCID))
+	if err != nil {
+		return gt, gatewayCallFailed(ctx, funcname, &gt, err)
+	}
+	gt.TxnID = res.TxnID
+	gt.Message = res.Message
+	if !res.Succeeded {
+		gt.Status = rlib.GTSTATUSFailed
+		saveGatewayTransaction(ctx, funcname, &gt)
+		return gt, nil
+	}
+
+	done := gt
+	done.Status = rlib.GTSTATUSSucceeded
+	err = finishGatewayTransaction(ctx, func(ctx context.Context) error {
+		r := rlib.Receipt{
+			BID:     pg.BID,
+			TCID:    ct.TCID,
+			PMTID:   pg.PMTID,
+			DEPID:   pg.DEPID,
+			Dt:      *dt,
+			DocNo:   res.TxnID,
+			Amount:  amount,
+			ARID:    pg.ARID,
+			Comment: fmt.Sprintf("%s, %s ending %s", pg.Name, ct.Brand, ct.Last4),
+		}
+		if err := InsertReceipt(ctx, &r); err != nil {
+			return err
+		}
+		done.RCPTID = r.RCPTID
+		if err := rlib.UpdateGatewayTransaction(ctx, &done); err != nil {
+			return err
+		}
+		return AutoAllocatePayorReceipts(ctx, ct.TCID, dt)
+	})
+	if err == nil {
+		return done, nil
+	}
+
+	//------------------------------------------------------------
+	// the card was charged but the payment cannot be recorded,
+	// give the money back
+	//------------------------------------------------------------
+	rlib.LogAndPrintError(funcname, fmt.Errorf("charge %s of %s to card %d not recorded: %s", res.TxnID, amount, ct.CTID, err.Error()))
+	gt.Message = fmt.Sprintf("not recorded: %s", err.Error())
+	if rf, e := api.Refund(res.TxnID, amount); e != nil || !rf.Succeeded {
+		if e == nil {
+			e = fmt.Errorf("%s", rf.Message)
+		}
+		rlib.LogAndPrintError(funcname, fmt.Errorf("refund of charge %s failed: %s", res.TxnID, e.Error()))
+		gt.Message += fmt.Sprintf(", refund failed: %s", e.Error())
+	} else {
+		gt.Status = rlib.GTSTATUSFailed
+		gt.Message += fmt.Sprintf(", refunded by %s", rf.TxnID)
+	}
+	saveGatewayTransaction(ctx, funcname, &gt)
+	return gt, bizErrSys(&err)
+}
+
+// gatewayChargeLeft returns what is left of a charge after its refunds and
+// chargebacks. Pending refunds are taken off too, so that the same part of
+// a charge cannot be refunded twice. The charge is locked until the
+// transaction in ctx ends, so that a refund or chargeback made at the same
+// time waits for this one to be written before it looks at the charge.
+//-----------------------------------------------------------------------------
+func gatewayChargeLeft(ctx context.Context, charge *rlib.GatewayTransaction) (rlib.Money, error) {
+	c, err := rlib.GetGatewayTransactionForUpdate(ctx, charge.GTID)
+	if err != nil {
+		return 0, err
+	}
+	m, err := rlib.GetGatewayTransactionsByParent(ctx, c.GTID)
+	if err != nil {
+		return 0, err
+	}
+	left := c.Amount
+	for i := 0; i < len(m); i++ {
+		if m[i].Status != rlib.GTSTATUSFailed {
+			left -= m[i].Amount
+		}
+	}
+	return left, nil
+}
+
+// takeBackCharge takes amount off the receipt of a charge. The receipt is
+// reversed and, if only part of it was taken back, a new receipt for the
+// rest replaces it as the charge's receipt. The payor's receipts are then
+// allocated again.
+//
+// INPUTS
+//  ctx    = db context, with a transaction
+//  charge = the charge
+//  amount = amount refunded or charged back
+//  why    = comment for the replacement receipt
+//  dt     = date of the refund or chargeback
+//
+// RETURNS
+//  the RCPTID of the receipt that was reversed
+//  any error encountered
+//-----------------------------------------------------------------------------
+func takeBackCharge(ctx context.Context, charge *rlib.GatewayTransaction, amount rlib.Money, why string, dt *time.Time) (int64, error) {
+	r, err := rlib.GetReceipt(ctx, charge.RCPTID)
+	if err != nil {
+		return 0, err
+	}
+	if r.RCPTID == 0 {
+		return 0, fmt.Errorf("receipt %d of gateway transaction %d not found", charge.RCPTID, charge.GTID)
+	}
+	left := r.Amount
+	if err = ReverseReceipt(ctx, &r, dt); err != nil {
+		return 0, err
+	}
+	if amount < left {
+		n := r
+		n.RCPTID = 0
+		n.PRCPTID = r.RCPTID
+		n.Dt = *dt
+		n.Amount = left - amount
+		n.FLAGS = 0
+		n.RA = nil
+		n.Comment = why
+		if err = InsertReceipt(ctx, &n); err != nil {
+			return 0, err
+		}
+		if n.DID > 0 { // the reversal was added to the deposit, so is the rest
+			var dp = rlib.DepositPart{
+				DID:    n.DID,
+				BID:    n.BID,
+				RCPTID: n.RCPTID,
+			}
+			if _, err = rlib.InsertDepositPart(ctx, &dp); err != nil {
+				return 0, err
+			}
+			dep, err := rlib.GetDeposit(ctx, n.DID)
+			if err != nil {
+				return 0, err
+			}
+			dep.Amount -= amount
+			if err = rlib.UpdateDeposit(ctx, &dep); err != nil {
+				return 0, err
+			}
+		}
+		charge.RCPTID = n.RCPTID
+		if err = rlib.UpdateGatewayTransaction(ctx, charge); err != nil {
+			return 0, err
+		}
+	}
+	return r.RCPTID, AutoAllocatePayorReceipts(ctx, r.TCID, dt)
+}
+
+// RefundCharge refunds amount of a successful charge to the card. If amount
+// is 0 all that is left of the charge is refunded. The charge's receipt is
+// reversed, with a new receipt for any part not refunded.
+//
+// The refund is written as pending and committed before the gateway is
+// asked for it, and the receipts are changed in a second transaction. A
+// refund the gateway made that cannot be recorded stays pending with the
+// reason in its Message, it must then be recorded by hand.
+//
+// INPUTS
+//  ctx    = db context, without a transaction
+//  api    = client for the gateway
+//  pg     = the gateway
+//  charge = the charge
+//  amount = amount to refund, 0 for the rest of the charge
+//  dt     = date of the refund
+//
+// RETURNS
+//  the refund GatewayTransaction
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func RefundCharge(ctx context.Context, api rlib.PaymentGatewayAPI, pg *rlib.PaymentGateway, charge *rlib.GatewayTransaction, amount rlib.Money, dt *time.Time) (rlib.GatewayTransaction, []BizError) {
+	const funcname = "RefundCharge"
+	gt := rlib.GatewayTransaction{BID: charge.BID, PGID: charge.PGID, TCID: charge.TCID, CTID: charge.CTID, Type: rlib.GTTYPERefund, ParentGTID: charge.GTID, Dt: *dt}
+	if charge.Type != rlib.GTTYPECharge || charge.Status != rlib.GTSTATUSSucceeded || charge.PGID != pg.PGID {
+		return gt, bizErrf(nil, GatewayNotCharge, charge.GTID)
+	}
+	errlist := startGatewayTransaction(ctx, &gt, func(ctx context.Context) []BizError {
+		if errlist := CheckPeriodOpen(ctx, pg.BID, dt); len(errlist) > 0 {
+			return errlist
+		}
+		left, err := gatewayChargeLeft(ctx, charge)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if amount == 0 {
+			amount = left
+		}
+		if amount <= 0 || amount > left {
+			return bizErrf(nil, GatewayRefundAmount, amount, left, charge.GTID)
+		}
+		gt.Amount = amount
+		return nil
+	})
+	if len(errlist) > 0 {
+		return gt, errlist
+	}
+
+	res, err := api.Refund(charge.TxnID, amount)
+	if err != nil {
+		return gt, gatewayCallFailed(ctx, funcname, &gt, err)
+	}
+	gt.TxnID = res.TxnID
+	gt.Message = res.Message
+	if !res.Succeeded {
+		gt.Status = rlib.GTSTATUSFailed
+		saveGatewayTransaction(ctx, funcname, &gt)
+		return gt, bizErrf(nil, GatewayDeclined, res.Message)
+	}
+
+	done := gt
+	done.Status = rlib.GTSTATUSSucceeded
+	err = finishGatewayTransaction(ctx, func(ctx context.Context) error {
+		//------------------------------------------------------------
+		// another refund may have replaced the charge's receipt
+		// since the charge was read
+		//------------------------------------------------------------
+		c, err := rlib.GetGatewayTransactionForUpdate(ctx, charge.GTID)
+		if err != nil {
+			return err
+		}
+		if done.RCPTID, err = takeBackCharge(ctx, &c, amount, fmt.Sprintf("%s less refund of %s", c.TxnID, amount), dt); err != nil {
+			return err
+		}
+		if err = rlib.UpdateGatewayTransaction(ctx, &done); err != nil {
+			return err
+		}
+		*charge = c
+		return nil
+	})
+	if err != nil {
+		rlib.LogAndPrintError(funcname, fmt.Errorf("refund %s of %s on charge %s not recorded: %s", res.TxnID, amount, charge.TxnID, err.Error()))
+		gt.Message = fmt.Sprintf("refunded but not recorded: %s", err.Error())
+		saveGatewayTransaction(ctx, funcname, &gt)
+		return gt, bizErrSys(&err)
+	}
+	return done, nil
+}
+
+// RecordChargeback records that the card holder's bank took back amount of
+// a charge. The charge's receipt is reversed as for a refund. A chargeback
+// larger than what is left of the charge takes what is left.
+//
+// INPUTS
+//  ctx       = db context, with a transaction
+//  charge    = the charge
+//  disputeID = the gateway's id for the dispute
+//  amount    = amount taken back
+//  dt        = date of the chargeback
+//
+// RETURNS
+//  the chargeback GatewayTransaction
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func RecordChargeback(ctx context.Context, charge *rlib.GatewayTransaction, disputeID string, amount rlib.Money, dt *time.Time) (rlib.GatewayTransaction, []BizError) {
+	gt := rlib.GatewayTransaction{BID: charge.BID, PGID: charge.PGID, TCID: charge.TCID, CTID: charge.CTID, Type: rlib.GTTYPEChargeback, ParentGTID: charge.GTID, TxnID: disputeID, Dt: *dt}
+	if charge.Type != rlib.GTTYPECharge || charge.Status != rlib.GTSTATUSSucceeded {
+		return gt, bizErrf(nil, GatewayNotCharge, charge.GTID)
+	}
+	left, err := gatewayChargeLeft(ctx, charge)
+	if err != nil {
+		return gt, bizErrSys(&err)
+	}
+	if amount == 0 || amount > left {
+		amount = left
+	}
+	if amount <= 0 {
+		return gt, bizErrf(nil, GatewayRefundAmount, amount, left, charge.GTID)
+	}
+	gt.Amount = amount
+	gt.Status = rlib.GTSTATUSSucceeded
+	if gt.RCPTID, err = takeBackCharge(ctx, charge, amount, fmt.Sprintf("%s less chargeback of %s", charge.TxnID, amount), dt); err != nil {
+		return gt, bizErrSys(&err)
+	}
+	if err = rlib.InsertGatewayTransaction(ctx, &gt); err != nil {
+		return gt, bizErrSys(&err)
+	}
+	return gt, nil
+}
+
+// RecordGatewayDispute records dispute d reported by gateway pg as a
+// chargeback, unless it was recorded already or its charge was not made
+// through RentRoll.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  pg  = the gateway
+//  d   = the dispute
+//  dt  = date to record the chargeback
+//
+// RETURNS
+//  the chargeback, GTID is 0 if nothing was recorded
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func RecordGatewayDispute(ctx context.Context, pg *rlib.PaymentGateway, d *rlib.GatewayDispute, dt *time.Time) (rlib.GatewayTransaction, []BizError) {
+	gt, err := rlib.GetGatewayTransactionByTxnID(ctx, pg.PGID, d.ID)
+	if err != nil {
+		return gt, bizErrSys(&err)
+	}
+	if gt.GTID > 0 {
+		return rlib.GatewayTransaction{}, nil // already recorded
+	}
+	charge, err := rlib.GetGatewayTransactionByTxnID(ctx, pg.PGID, d.ChargeID)
+	if err != nil {
+		return gt, bizErrSys(&err)
+	}
+	if charge.GTID == 0 {
+		return gt, nil
+	}
+	return RecordChargeback(ctx, &charge, d.ID, d.Amount, dt)
+}
+
+// SaveGatewaySettlements marks the transactions of each settlement with
+// the payout that settled them and the Deposit it was matched to
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  m   = the settlements from rlib.GatewaySettlements
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveGatewaySettlements(ctx context.Context, m []rlib.GatewaySettlement) []BizError {
+	for i := 0; i < len(m); i++ {
+		for j := 0; j < len(m[i].GTIDs); j++ {
+			t, err := rlib.GetGatewayTransaction(ctx, m[i].GTIDs[j])
+			if err != nil {
+				return bizErrSys(&err)
+			}
+			t.PayoutID = m[i].Payout.ID
+			t.DID = m[i].DID
+			if err = rlib.UpdateGatewayTransaction(ctx, &t); err != nil {
+				return bizErrSys(&err)
+			}
+		}
+	}
+	return nil
+}
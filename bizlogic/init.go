@@ -54,13 +54,75 @@ const (
 	RentableTypeRefDatesOverlap     = 30 // rentable type ref dates overlapping
 	UnknownRID                      = 31 // Unknown Rentable
 	InvalidRTFlag                   = 32
-	UnknownRTID                     = 33 // Unknown Rentable Type
-	UnknownRAID                     = 34 // Unknown Rental Agreement
-	UnknownARType                   = 35 // Unknown ARType
-	InvalidARFlag                   = 36 // Invalid AR Flag
-	UnknownTLDID                    = 37 // task list definition does not exist
-	ImproperTLDID                   = 38 // task list definition does not belong to the specified business
-	TaskDescrMissingName            = 39 // task descriptor missing name
+	UnknownRTID                     = 33  // Unknown Rentable Type
+	UnknownRAID                     = 34  // Unknown Rental Agreement
+	UnknownARType                   = 35  // Unknown ARType
+	InvalidARFlag                   = 36  // Invalid AR Flag
+	UnknownTLDID                    = 37  // task list definition does not exist
+	ImproperTLDID                   = 38  // task list definition does not belong to the specified business
+	TaskDescrMissingName            = 39  // task descriptor missing name
+	PostingDateClosed               = 40  // date is in a closed period
+	WorkOrderNoRentalAgreement      = 41  // work order has no rental agreement to charge
+	WorkOrderAlreadyCharged         = 42  // work order has already been charged back
+	WorkOrderChargeAmount           = 43  // charge back amount must be greater than 0
+	VendorNotFound                  = 44  // vendor does not exist in the business
+	BillLineTotal                   = 45  // bill lines do not add up to the bill amount
+	GLAccountNoPost                 = 46  // GL account does not exist or does not allow posting
+	BillHasPayments                 = 47  // bill with payments cannot be voided
+	BillOverpaid                    = 48  // amount applied to a bill exceeds its balance
+	VendorPaymentTotal              = 49  // allocations do not add up to the payment amount
+	VendorPaymentMethod             = 50  // unknown payment method
+	BillNotPayable                  = 51  // bill is void or belongs to another vendor
+	DepositoryNotFound              = 52  // depository does not exist in the business
+	NoPayablesAccount               = 53  // business has no accounts payable account
+	NoCheckAccount                  = 54  // depository has no check account
+	VendorPaymentHasCheck           = 55  // a check has already been written for the payment
+	NotCheckPayment                 = 56  // vendor payment is void or not paid by check
+	CAMReconPosted                  = 57  // CAM reconciliation has been posted
+	CAMNoBaseYear                   = 58  // base year lease without a base year
+	CAMNoSqft                       = 59  // no rentable has a size
+	CAMNoAccounts                   = 60  // CAM pool has no GL accounts
+	CAMNoTrueUpARID                 = 61  // CAM pool has no true-up or credit account rule
+	PctRentNotSetUp                 = 62  // Rental Agreement has no percentage rent terms
+	PctRentTiers                    = 63  // percentage rent tiers are missing or invalid
+	PctRentAccounts                 = 64  // percentage rent account rules are missing
+	SalesReportComputed             = 65  // sales report has been assessed
+	SalesReportOverlap              = 66  // sales report periods overlap
+	SalesReportNoRentable           = 67  // Rental Agreement has no rentable in the period
+	ReservationDates                = 68  // reservation departs on or before it arrives
+	UnknownTCID                     = 69  // Transactant does not exist in the business
+	UnknownRoomType                 = 70  // rentable type does not exist in the business
+	RoomWrongType                   = 71  // room is not of the reserved type
+	RoomNotAvailable                = 72  // room is not free for the stay
+	RoomTypeFull                    = 73  // no room of the type is available
+	ReservationNotBooked            = 74  // reservation is checked in, cancelled or a no show
+	ReservationNoRoom               = 75  // reservation has no room assigned
+	ReservationNoRentAR             = 76  // rentable type has no rent account rule
+	NightAuditWrongDate             = 77  // night is not the business date
+	NightAuditNotStarted            = 78  // night to audit has not started
+	RoomTaxNoAR                     = 79  // room tax account rule not found
+	ConcessionTerms                 = 80  // concession needs an amount or a percent off
+	ConcessionCycles                = 81  // concession applies to no rent charge
+	ConcessionNoAR                  = 82  // concession account rule not found
+	ConcessionNotOffered            = 83  // concession not found or no longer offered
+	UtilityBillPosted               = 84  // utility bill has been posted
+	UtilityMethod                   = 85  // unknown utility billing method
+	UtilityNoAR                     = 86  // utility account rule not found
+	UtilityNoUnits                  = 87  // no unit can share the utility bill
+	MeterUsageNegative              = 88  // sub-meter readings go down
+	AsmStepNotRecurring             = 89  // step on an assessment that is not a recurring definition
+	AsmStepDate                     = 90  // step date outside the assessment
+	AsmStepAmount                   = 91  // step needs an amount or an escalation
+	AsmStepApplied                  = 92  // step already applied
+	GatewayUnknown                  = 93  // payment gateway inactive or not in the business
+	GatewayConfig                   = 94  // gateway payment type, depository or account rule missing
+	GatewayCardUnusable             = 95  // card removed or not the payor's
+	GatewayDeclined                 = 96  // gateway refused the card or transaction
+	GatewayUnavailable              = 97  // could not talk to the gateway
+	GatewayNothingDue               = 98  // charge of a payor who owes nothing
+	GatewayNotCharge                = 99  // refund or chargeback of something that is not a successful charge
+	GatewayRefundAmount             = 100 // refund more than what is left of the charge
+	UtilityRatio                    = 101 // fixed ratio not above 0 or given twice for a rentable
 )
 
 // InitBizLogic loads the error messages needed for validation errors
@@ -0,0 +1,133 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// NightAudit closes the night of dt in a hotel. It must be the business
+// date, the day after the last day the night audit locked, unless no day
+// has been locked yet. For each guest in house it posts the room charge,
+// the contract rent of the room, and the room tax set in the "general"
+// business properties. Booked reservations due by dt are marked no-shows.
+// Problems found with the stays do not stop the audit, they are listed in
+// the report. Finally the day is locked with a LMLOCKED JournalMarker so
+// nothing more can be posted to it, and the business date moves to the
+// next day.
+//
+// INPUTS
+//  ctx  = db context, with a transaction
+//  xbiz = the business
+//  dt   = the night to audit
+//
+// RETURNS
+//  the night audit report
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func NightAudit(ctx context.Context, xbiz *rlib.XBusiness, dt *time.Time) (rlib.NightAuditReport, []BizError) {
+	var rpt rlib.NightAuditReport
+	bid := xbiz.P.BID
+	next := dt.AddDate(0, 0, 1)
+
+	bizdt, err := rlib.NightAuditDate(ctx, bid)
+	if err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	if !bizdt.Equal(rlib.TIME0) && !bizdt.Equal(*dt) {
+		return rpt, bizErrf(nil, NightAuditWrongDate, dt.Format(rlib.RRDATEFMT3), bizdt.Format(rlib.RRDATEFMT3))
+	}
+	if dt.After(time.Now()) {
+		return rpt, bizErrf(nil, NightAuditNotStarted, dt.Format(rlib.RRDATEFMT3))
+	}
+	bp, err := rlib.GetDataFromBusinessPropertyName(ctx, "general", bid)
+	if err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	var tax rlib.AR
+	if bp.RoomTaxRate > 0 {
+		if tax, err = rlib.GetARByName(ctx, bid, bp.RoomTaxAR); err != nil {
+			return rpt, bizErrSys(&err)
+		}
+		if tax.ARID == 0 {
+			return rpt, bizErrf(nil, RoomTaxNoAR, bp.RoomTaxAR, bid)
+		}
+	}
+
+	//------------------------------------------------------------
+	// the room and tax charges of the guests in house
+	//------------------------------------------------------------
+	guests, err := rlib.GetInHouseGuests(ctx, bid, dt)
+	if err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	for i := 0; i < len(guests); i++ {
+		g := &guests[i]
+		if g.RAR.RARID == 0 {
+			continue // no room to charge, it is in the report's problems
+		}
+		arid := xbiz.RT[g.Res.RTID].ARID
+		if arid == 0 {
+			return rpt, bizErrf(nil, ReservationNoRentAR, g.Res.RTID)
+		}
+		room := rlib.Assessment{
+			BID:            bid,
+			RID:            g.Res.RID,
+			RAID:           g.Res.RAID,
+			Amount:         g.RAR.ContractRent,
+			Start:          *dt,
+			Stop:           *dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           arid,
+			Comment:        fmt.Sprintf("Room charge, night of %s", dt.Format(rlib.RRDATEFMT3)),
+		}
+		if errlist := InsertAssessment(ctx, &room, 0); len(errlist) > 0 {
+			return rpt, errlist
+		}
+		if tax.ARID == 0 {
+			continue
+		}
+		t := room
+		t.ASMID = 0
+		t.Amount = room.Amount.Mul(bp.RoomTaxRate)
+		t.ARID = tax.ARID
+		t.Comment = fmt.Sprintf("Room tax, night of %s", dt.Format(rlib.RRDATEFMT3))
+		if t.Amount == 0 {
+			continue
+		}
+		if errlist := InsertAssessment(ctx, &t, 0); len(errlist) > 0 {
+			return rpt, errlist
+		}
+	}
+
+	//------------------------------------------------------------
+	// no-shows
+	//------------------------------------------------------------
+	res, err := rlib.GetReservationsByRange(ctx, bid, dt, &next)
+	if err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	for i := 0; i < len(res); i++ {
+		if res[i].FLAGS&rlib.RESSTATEMask != rlib.RESSTATEBooked || res[i].DtArrive.After(*dt) {
+			continue
+		}
+		res[i].FLAGS = res[i].FLAGS&^rlib.RESSTATEMask | rlib.RESSTATENoShow
+		if err = rlib.UpdateReservation(ctx, &res[i]); err != nil {
+			return rpt, bizErrSys(&err)
+		}
+	}
+
+	//------------------------------------------------------------
+	// lock the day
+	//------------------------------------------------------------
+	jm := rlib.JournalMarker{BID: bid, State: rlib.LMLOCKED, DtStart: *dt, DtStop: next}
+	if _, err = rlib.InsertJournalMarker(ctx, &jm); err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	if rpt, err = rlib.GetNightAuditReport(ctx, xbiz, dt); err != nil {
+		return rpt, bizErrSys(&err)
+	}
+	return rpt, nil
+}
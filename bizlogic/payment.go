@@ -27,12 +27,6 @@ import (
 //
 // The routines in this file help perform some of these tasks.
 
-// ROUNDINGERR may not be necessary, but if division becomes a part of the
-// floating point calculations below, it is likely that subtracting things
-// that should result in zero will not be exactly zero.  So, if the error is
-// less than $0.001 we just call it a rounding error and assume that it's 0.
-const ROUNDINGERR = float64(0.000999)
-
 // GetAllUnpaidAssessmentsForPayor determines all the Rental Agreements for
 // which the supplied Transactant is Payor, then returns a list
 // of all unpaid assessments associated with any of those Rental Agreements.
@@ -63,7 +57,7 @@ func GetAllUnpaidAssessmentsForPayor(ctx context.Context, bid, tcid int64, dt *t
 // RemainingReceiptFunds returns the amount of funds left to be allocated on
 // the supplied receipt
 //-----------------------------------------------------------------------------
-func RemainingReceiptFunds(ctx context.Context, r *rlib.Receipt) float64 {
+func RemainingReceiptFunds(ctx context.Context, r *rlib.Receipt) rlib.Money {
 	funcname := "RemainingReceiptFunds"
 	var xbiz1 rlib.XBusiness
 	var dt time.Time
@@ -83,18 +77,18 @@ func RemainingReceiptFunds(ctx context.Context, r *rlib.Receipt) float64 {
 		}
 		return tot
 	case 2:
-		return float64(0)
+		return rlib.Money(0)
 	default:
 		err := fmt.Errorf("unhandled flag bits 0-1 of FLAGS: %d", r.FLAGS&3)
 		rlib.LogAndPrintError(funcname, err)
 	}
-	return float64(0)
+	return rlib.Money(0)
 }
 
 // RemainingReceiptFundsOnDate returns the amount of funds remaining in a
 // receipt on the supplied date
 //--------------------------------------------------------------------------
-func RemainingReceiptFundsOnDate(ctx context.Context, a *rlib.Receipt, dt *time.Time) float64 {
+func RemainingReceiptFundsOnDate(ctx context.Context, a *rlib.Receipt, dt *time.Time) rlib.Money {
 	// TODO(Steve): should we ignore error?
 	m, _ := rlib.GetReceiptAllocationsThroughDate(ctx, a.RCPTID, dt)
 	amt := a.Amount
@@ -107,7 +101,7 @@ func RemainingReceiptFundsOnDate(ctx context.Context, a *rlib.Receipt, dt *time.
 // AssessmentUnpaidPortion computes and returns the unpaid portion of an
 // assessment.
 //--------------------------------------------------------------------------
-func AssessmentUnpaidPortion(ctx context.Context, a *rlib.Assessment) float64 {
+func AssessmentUnpaidPortion(ctx context.Context, a *rlib.Assessment) rlib.Money {
 	funcname := "AssessmentUnpaidPortion"
 	switch a.FLAGS & 3 {
 	case 0:
@@ -121,12 +115,12 @@ func AssessmentUnpaidPortion(ctx context.Context, a *rlib.Assessment) float64 {
 		}
 		return bal
 	case 2:
-		return float64(0)
+		return rlib.Money(0)
 	default:
 		err := fmt.Errorf("unhandled flag bits 0-1 of FLAGS: %d", a.FLAGS&3)
 		rlib.LogAndPrintError(funcname, err)
 	}
-	return float64(0)
+	return rlib.Money(0)
 }
 
 // PayAssessment handles paying an assessment, or as much as possible of
@@ -148,7 +142,7 @@ func AssessmentUnpaidPortion(ctx context.Context, a *rlib.Assessment) float64 {
 //           paid by another receipt.
 //  dt     - timestamp to mark on the allocation for this payment
 //--------------------------------------------------------------------------
-func PayAssessment(ctx context.Context, a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt *float64, dt *time.Time) error {
+func PayAssessment(ctx context.Context, a *rlib.Assessment, rcpt *rlib.Receipt, needed *rlib.Money, amt *rlib.Money, dt *time.Time) error {
 	funcname := "PayAssessment"
 
 	amtToUse := *amt
@@ -204,7 +198,7 @@ func PayAssessment(ctx context.Context, a *rlib.Assessment, rcpt *rlib.Receipt,
 	d := uint64(0x3)
 	d = ^d
 	a.FLAGS &= d // zero-out bits 0-1
-	if *needed-amtToUse < ROUNDINGERR {
+	if *needed-amtToUse <= 0 {
 		a.FLAGS |= 2 // 2 = paid in full
 		// rlib.Console("Fully paid assessment %d\n", a.ASMID)
 	} else {
@@ -223,7 +217,7 @@ func PayAssessment(ctx context.Context, a *rlib.Assessment, rcpt *rlib.Receipt,
 	// update the receipt as partially or fully allocated as needed...
 	//------------------------------------------------------------------
 	rcpt.FLAGS &= 0x7ffffffc // zero-out bits 0-1
-	if amtAvailableInRcpt-amtToUse > ROUNDINGERR {
+	if amtAvailableInRcpt-amtToUse > 0 {
 		// rlib.Console("SET RECEIPT FLAGS TO: 1 - some funds remain\n")
 		rcpt.FLAGS |= 1 // there are still some funds left */
 	} else {
@@ -371,7 +365,7 @@ func AutoAllocatePayorReceipts(ctx context.Context, tcid int64, dt *time.Time) e
 				return err
 			}
 			// rlib.Console(">>>>> Paid assesment %d using receipt %d\n", m[i].ASMID, n[j].RCPTID)
-			if needed < ROUNDINGERR { // if we've paid off the assessment...
+			if needed <= 0 { // if we've paid off the assessment...
 				break // ... then move on to the next assessment
 			}
 		}
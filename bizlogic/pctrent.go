@@ -0,0 +1,236 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// SavePctRent validates and saves the percentage rent terms p of a Rental
+// Agreement with their tiers. The tiers replace any existing ones.
+//
+// INPUTS
+//  ctx   = db context, with a transaction
+//  p     = the terms, PRID is 0 for new terms
+//  tiers = the tiers
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SavePctRent(ctx context.Context, p *rlib.PctRent, tiers []rlib.PctRentTier) []BizError {
+	var errlist []BizError
+	ra, err := rlib.GetRentalAgreement(ctx, p.RAID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if ra.RAID == 0 || ra.BID != p.BID {
+		return bizErrf(nil, UnknownRAID, p.RAID, p.BID)
+	}
+	if p.BreakpointType != rlib.PCTRENTNatural && p.BreakpointType != rlib.PCTRENTFixed {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+
+	//------------------------------------------------------------
+	// each breakpoint must be above the one before it
+	//------------------------------------------------------------
+	ok := len(tiers) > 0
+	for i := 0; i < len(tiers) && ok; i++ {
+		ok = tiers[i].Rate > 0 && tiers[i].Rate < 1 && tiers[i].Breakpoint >= 0
+		ok = ok && (i == 0 || tiers[i].Breakpoint > tiers[i-1].Breakpoint)
+	}
+	if !ok {
+		errlist = bizErrf(errlist, PctRentTiers)
+	}
+	arids := []int64{p.OverageARID}
+	if p.BreakpointType == rlib.PCTRENTNatural {
+		arids = append(arids, p.BaseARID)
+	}
+	for _, arid := range arids {
+		ar, err := rlib.GetAR(ctx, arid)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if ar.ARID == 0 || ar.BID != p.BID {
+			errlist = bizErrf(errlist, PctRentAccounts)
+			break
+		}
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	if p.PRID == 0 {
+		err = rlib.InsertPctRent(ctx, p)
+	} else {
+		err = rlib.UpdatePctRent(ctx, p)
+	}
+	if err == nil {
+		err = rlib.DeletePctRentTiers(ctx, p.PRID)
+	}
+	for i := 0; i < len(tiers) && err == nil; i++ {
+		tiers[i].PRID = p.PRID
+		tiers[i].BID = p.BID
+		err = rlib.InsertPctRentTier(ctx, &tiers[i])
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// SaveSalesReport validates and saves a tenant's sales report. A report
+// whose overage has been assessed cannot be changed. One that was computed
+// with no overage can be; it is computed again.
+//
+// INPUTS
+//  ctx = db context
+//  sr  = the report, SRID is 0 for a new report
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveSalesReport(ctx context.Context, sr *rlib.SalesReport) []BizError {
+	ra, err := rlib.GetRentalAgreement(ctx, sr.RAID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if ra.RAID == 0 || ra.BID != sr.BID {
+		return bizErrf(nil, UnknownRAID, sr.RAID, sr.BID)
+	}
+	if !sr.DtStop.After(sr.DtStart) || sr.GrossSales < 0 {
+		return AddBizErrToList(nil, InvalidField)
+	}
+	m, err := rlib.GetSalesReportsByRAID(ctx, sr.RAID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].SRID == sr.SRID {
+			if m[i].ASMID > 0 {
+				return bizErrf(nil, SalesReportComputed, sr.SRID)
+			}
+			continue
+		}
+		if m[i].DtStart.Before(sr.DtStop) && sr.DtStart.Before(m[i].DtStop) {
+			return bizErrf(nil, SalesReportOverlap, sr.RAID, sr.DtStart.Format(rlib.RRDATEFMT3), sr.DtStop.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3))
+		}
+	}
+
+	sr.FLAGS &^= rlib.SALESRPTComputed
+	sr.Breakpoint, sr.Overage, sr.ASMID = 0, 0, 0
+	if sr.SRID == 0 {
+		err = rlib.InsertSalesReport(ctx, sr)
+	} else {
+		err = rlib.UpdateSalesReport(ctx, sr)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// DeleteSalesReport deletes a sales report whose overage has not been
+// assessed
+//
+// INPUTS
+//  ctx = db context
+//  sr  = the report
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func DeleteSalesReport(ctx context.Context, sr *rlib.SalesReport) []BizError {
+	if sr.ASMID > 0 {
+		return bizErrf(nil, SalesReportComputed, sr.SRID)
+	}
+	if err := rlib.DeleteSalesReport(ctx, sr.SRID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ComputePercentageRent computes the overage rent due on sales report sr
+// under the percentage rent terms of its Rental Agreement. If any is due a
+// one time assessment is made with the terms' OverageARID. The report is
+// updated with the breakpoint, the overage and the assessment and is marked
+// computed.
+//
+// With a natural breakpoint the base rent is the BaseARID assessments of the
+// Rental Agreement in the period. If none were billed the first tier's fixed
+// breakpoint is used.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  sr  = the report
+//  dt  = date of the assessment
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ComputePercentageRent(ctx context.Context, sr *rlib.SalesReport, dt *time.Time) []BizError {
+	if sr.FLAGS&rlib.SALESRPTComputed != 0 {
+		return bizErrf(nil, SalesReportComputed, sr.SRID)
+	}
+	p, err := rlib.GetPctRentByRAID(ctx, sr.RAID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if p.PRID == 0 {
+		return bizErrf(nil, PctRentNotSetUp, sr.RAID)
+	}
+	tiers, err := rlib.GetPctRentTiers(ctx, p.PRID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if len(tiers) == 0 {
+		return bizErrf(nil, PctRentTiers)
+	}
+
+	natural := rlib.Money(0)
+	if p.BreakpointType == rlib.PCTRENTNatural {
+		m, err := rlib.GetAssessmentsByRAIDARID(ctx, sr.RAID, p.BaseARID, &sr.DtStart, &sr.DtStop)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		base := rlib.Money(0)
+		for i := 0; i < len(m); i++ {
+			base += m[i].Amount
+		}
+		natural = rlib.PctRentNaturalBreakpoint(base, tiers[0].Rate)
+	}
+	yeardays := sr.DtStart.AddDate(1, 0, 0).Sub(sr.DtStart).Hours() / 24
+	frac := sr.DtStop.Sub(sr.DtStart).Hours() / 24 / yeardays
+	sr.Breakpoint, sr.Overage = rlib.PctRentOverage(sr.GrossSales, tiers, natural, frac)
+
+	if sr.Overage > 0 {
+		rars, err := rlib.GetRentalAgreementRentables(ctx, sr.RAID, &sr.DtStart, &sr.DtStop)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if len(rars) == 0 {
+			return bizErrf(nil, SalesReportNoRentable, sr.RAID, sr.DtStart.Format(rlib.RRDATEFMT3), sr.DtStop.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3))
+		}
+		asm := rlib.Assessment{
+			BID:            sr.BID,
+			RID:            rars[0].RID,
+			RAID:           sr.RAID,
+			Amount:         sr.Overage,
+			Start:          *dt,
+			Stop:           *dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           p.OverageARID,
+			Comment:        fmt.Sprintf("Percentage rent on sales of %s for %s - %s", rlib.RRCommaf(sr.GrossSales.Float()), sr.DtStart.Format(rlib.RRDATEFMT3), sr.DtStop.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3)),
+		}
+		if errlist := InsertAssessment(ctx, &asm, 0); len(errlist) > 0 {
+			return errlist
+		}
+		sr.ASMID = asm.ASMID
+	}
+	sr.FLAGS |= rlib.SALESRPTComputed
+	if err = rlib.UpdateSalesReport(ctx, sr); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// CheckPeriodOpen returns a PostingDateClosed error if dt is in a period of
+// business bid that has been closed.
+//
+// INPUTS
+//  ctx = db context
+//  bid = the business
+//  dt  = the date of the entry being posted
+//
+// RETURNS
+//  a slice of BizErrors, nil if dt is in an open period
+//-------------------------------------------------------------------------------------
+func CheckPeriodOpen(ctx context.Context, bid int64, dt *time.Time) []BizError {
+	open, err := rlib.FirstOpenDate(ctx, bid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if !rlib.PeriodIsClosed(dt, &open) {
+		return nil
+	}
+	s := fmt.Sprintf(BizErrors[PostingDateClosed].Message, dt.Format(rlib.RRDATEFMT4), open.Format(rlib.RRDATEFMT4))
+	return []BizError{{Errno: PostingDateClosed, Message: s}}
+}
+
+// reversalDate returns the date to use for the reversal of an entry dated dt
+// in business bid. Reversals of entries in a closed period are posted on the
+// first open date.
+//
+// INPUTS
+//  ctx = db context
+//  bid = the business
+//  dt  = the date of the entry being reversed
+//
+// RETURNS
+//  the date for the reversal
+//  any error encountered
+//-------------------------------------------------------------------------------------
+func reversalDate(ctx context.Context, bid int64, dt *time.Time) (time.Time, error) {
+	open, err := rlib.FirstOpenDate(ctx, bid)
+	if err != nil {
+		return *dt, err
+	}
+	return rlib.PostingDate(dt, &open), nil
+}
@@ -62,6 +62,9 @@ func UpdateReceipt(ctx context.Context, rnew *rlib.Receipt, dt *time.Time) error
 	//---------------------------------------------------------------------------------
 	reverse := (!rold.Dt.Equal(rnew.Dt)) || rold.Amount != rnew.Amount || rold.ARID != rnew.ARID || rold.RAID != rnew.RAID
 	if reverse {
+		if be := CheckPeriodOpen(ctx, rnew.BID, &rnew.Dt); len(be) > 0 {
+			return BizErrorListToError(be)
+		}
 		err := ReverseReceipt(ctx, &rold, dt) // reverse the receipt itself
 		if err != nil {
 			return err
@@ -97,7 +100,8 @@ func UpdateReceipt(ctx context.Context, rnew *rlib.Receipt, dt *time.Time) error
 }
 
 // ReverseReceipt reverses the supplied receipt. It links the
-// reversal back to the supplied receipt
+// reversal back to the supplied receipt. If the receipt is in a closed
+// period the reversal is posted on the first open date.
 // RETURNS
 //    any error that occurred, or nil if no error
 //-------------------------------------------------------------------------------
@@ -120,10 +124,21 @@ func ReverseReceipt(ctx context.Context, r *rlib.Receipt, dt *time.Time) error {
 		}
 	}
 
+	//------------------------------------------------------
+	// Nothing can be posted into a closed period...
+	//------------------------------------------------------
+	open, err := rlib.FirstOpenDate(ctx, r.BID)
+	if err != nil {
+		return err
+	}
+	revdt := rlib.PostingDate(dt, &open)
+	dt = &revdt
+
 	//------------------------------------------------------
 	// Build the new receipt
 	//------------------------------------------------------
 	rr := *r
+	rr.Dt = rlib.PostingDate(&r.Dt, &open)
 	rr.RCPTID = int64(0)
 	rr.Amount = -rr.Amount
 	rr.Comment = fmt.Sprintf("Reversal of receipt %s", r.IDtoString())
@@ -226,6 +241,7 @@ func ReverseReceipt(ctx context.Context, r *rlib.Receipt, dt *time.Time) error {
 		ReverseAllocation(ctx, r, rr.RCPTID, dt)
 	}
 
+	rlib.EmitWebhookEvent(ctx, rr.BID, rlib.WHEVTreceiptReversed, &rr)
 	return err
 }
 
@@ -371,10 +387,18 @@ func ReverseAllocation(ctx context.Context, r *rlib.Receipt, revRCPTID int64, dt
 	return rlib.UpdateReceipt(ctx, r)
 }
 
-// InsertReceipt adds a new receipt and updates the journal and ledgers
+// InsertReceipt adds a new receipt and updates the journal and ledgers. The
+// receipt is rejected if it is dated in a closed period.
 //-------------------------------------------------------------------------------
 func InsertReceipt(ctx context.Context, a *rlib.Receipt) error {
-	return insertReceiptInternal(ctx, a, nil, nil)
+	if be := CheckPeriodOpen(ctx, a.BID, &a.Dt); len(be) > 0 {
+		return BizErrorListToError(be)
+	}
+	if err := insertReceiptInternal(ctx, a, nil, nil); err != nil {
+		return err
+	}
+	rlib.EmitWebhookEvent(ctx, a.BID, rlib.WHEVTreceiptCreated, a)
+	return nil
 }
 
 // insertReceiptInternal adds a new receipt and updates the journal and ledgers,
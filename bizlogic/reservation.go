@@ -0,0 +1,239 @@
+package bizlogic
+
+import (
+	"context"
+	"rentroll/rlib"
+)
+
+// SaveReservation validates and saves Reservation r as booked. The guest
+// must be a Transactant of the business and the stay at least one night. If
+// a room is assigned it must be of the reserved type and free for the whole
+// stay, otherwise a room of the type must be available every night. If Rate
+// is 0 it is set from the rate plan, or the market rate, of the arrival date.
+//
+// INPUTS
+//  ctx = db context
+//  r   = the reservation, RESID is 0 for a new one
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveReservation(ctx context.Context, r *rlib.Reservation) []BizError {
+	var errlist []BizError
+	var xbiz rlib.XBusiness
+
+	if r.RESID > 0 {
+		old, err := rlib.GetReservation(ctx, r.RESID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.RESID == 0 || old.BID != r.BID || old.FLAGS&rlib.RESSTATEMask != rlib.RESSTATEBooked {
+			return bizErrf(nil, ReservationNotBooked, r.RESID)
+		}
+	}
+	if !r.DtDepart.After(r.DtArrive) {
+		errlist = bizErrf(errlist, ReservationDates)
+	}
+	if r.Adults < 0 || r.Children < 0 || r.Rate < 0 || r.Deposit < 0 {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+	var t rlib.Transactant
+	if err := rlib.GetTransactant(ctx, r.TCID, &t); err != nil {
+		return bizErrSys(&err)
+	}
+	if t.TCID == 0 || t.BID != r.BID {
+		errlist = bizErrf(errlist, UnknownTCID, r.TCID, r.BID)
+	}
+	if err := rlib.GetXBusiness(ctx, r.BID, &xbiz); err != nil {
+		return bizErrSys(&err)
+	}
+	if _, ok := xbiz.RT[r.RTID]; !ok {
+		errlist = bizErrf(errlist, UnknownRoomType, r.RTID, r.BID)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	if errlist = reservationRoomCheck(ctx, r); len(errlist) > 0 {
+		return errlist
+	}
+	if r.Rate == 0 {
+		rate, err := rlib.ReservationRate(ctx, &xbiz, r.RTID, r.RPID, &r.DtArrive)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		r.Rate = rate
+	}
+
+	var err error
+	r.FLAGS = r.FLAGS&^rlib.RESSTATEMask | rlib.RESSTATEBooked
+	r.RAID = 0
+	if r.RESID == 0 {
+		err = rlib.InsertReservation(ctx, r)
+	} else {
+		err = rlib.UpdateReservation(ctx, r)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// reservationRoomCheck makes sure there is a room for reservation r. If a
+// room is assigned it must be of the reserved type and free for the stay.
+// If not, every night of the stay must have a room of the type available.
+//
+// INPUTS
+//  ctx = db context
+//  r   = the reservation
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func reservationRoomCheck(ctx context.Context, r *rlib.Reservation) []BizError {
+	d1 := r.DtArrive.Format(rlib.RRDATEFMT3)
+	d2 := r.DtDepart.Format(rlib.RRDATEFMT3)
+	if r.RID > 0 {
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, r.RID, &r.DtArrive)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if rtr.RTID != r.RTID || rtr.BID != r.BID {
+			return bizErrf(nil, RoomWrongType, r.RID, r.RTID, d1, d2)
+		}
+		ok, err := rlib.IsRentableFree(ctx, r.RID, &r.DtArrive, &r.DtDepart, r.RESID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if !ok {
+			return bizErrf(nil, RoomNotAvailable, r.RID, d1, d2)
+		}
+		return nil
+	}
+
+	a, err := rlib.GetRoomAvailability(ctx, r.BID, r.RTID, &r.DtArrive, &r.DtDepart, r.RESID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(a.Nights); i++ {
+		if a.Nights[i].Available <= 0 {
+			return bizErrf(nil, RoomTypeFull, r.RTID, a.Nights[i].Dt.Format(rlib.RRDATEFMT3))
+		}
+	}
+	return nil
+}
+
+// CancelReservation cancels a booked reservation
+//
+// INPUTS
+//  ctx = db context
+//  r   = the reservation
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func CancelReservation(ctx context.Context, r *rlib.Reservation) []BizError {
+	if r.FLAGS&rlib.RESSTATEMask != rlib.RESSTATEBooked {
+		return bizErrf(nil, ReservationNotBooked, r.RESID)
+	}
+	r.FLAGS = r.FLAGS&^rlib.RESSTATEMask | rlib.RESSTATECancelled
+	if err := rlib.UpdateReservation(ctx, r); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// CheckInReservation checks in the guest of a booked reservation. An active
+// Rental Agreement is created for the stay with the guest as payor and user
+// of the room and the nightly rate as the contract rent. The room charges
+// are posted each night by the night audit, using the account rule of the
+// room's type. The reservation is marked checked in and its RAID set.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  r   = the reservation, its RID must be set
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func CheckInReservation(ctx context.Context, r *rlib.Reservation) []BizError {
+	var xbiz rlib.XBusiness
+	if r.FLAGS&rlib.RESSTATEMask != rlib.RESSTATEBooked {
+		return bizErrf(nil, ReservationNotBooked, r.RESID)
+	}
+	if r.RID == 0 {
+		return bizErrf(nil, ReservationNoRoom, r.RESID)
+	}
+	if errlist := reservationRoomCheck(ctx, r); len(errlist) > 0 {
+		return errlist
+	}
+	if err := rlib.GetXBusiness(ctx, r.BID, &xbiz); err != nil {
+		return bizErrSys(&err)
+	}
+	if xbiz.RT[r.RTID].ARID == 0 { // the night audit needs it for the room charges
+		return bizErrf(nil, ReservationNoRentAR, r.RTID)
+	}
+
+	//------------------------------------------------------------
+	// the Rental Agreement for the stay
+	//------------------------------------------------------------
+	ra := rlib.RentalAgreement{
+		BID:             r.BID,
+		DocumentDate:    r.DtArrive,
+		AgreementStart:  r.DtArrive,
+		AgreementStop:   r.DtDepart,
+		PossessionStart: r.DtArrive,
+		PossessionStop:  r.DtDepart,
+		RentStart:       r.DtArrive,
+		RentStop:        r.DtDepart,
+		RentCycleEpoch:  r.DtArrive,
+		FLAGS:           rlib.RASTATEActive,
+		ActiveDate:      r.DtArrive,
+	}
+	if r.Adults > 1 {
+		ra.UnspecifiedAdults = r.Adults - 1 // the guest is the payor
+	}
+	ra.UnspecifiedChildren = r.Children
+	raid, err := rlib.InsertRentalAgreement(ctx, &ra)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	lm := rlib.LedgerMarker{BID: r.BID, RAID: raid, Dt: r.DtArrive, State: rlib.LMINITIAL}
+	if _, err = rlib.InsertLedgerMarker(ctx, &lm); err != nil {
+		return bizErrSys(&err)
+	}
+
+	//------------------------------------------------------------
+	// the room, the guest as its user and as the payor
+	//------------------------------------------------------------
+	rar := rlib.RentalAgreementRentable{
+		RAID:         raid,
+		BID:          r.BID,
+		RID:          r.RID,
+		ContractRent: r.Rate,
+		RARDtStart:   r.DtArrive,
+		RARDtStop:    r.DtDepart,
+	}
+	if _, err = rlib.InsertRentalAgreementRentable(ctx, &rar); err != nil {
+		return bizErrSys(&err)
+	}
+	rlm := rlib.LedgerMarker{BID: r.BID, RAID: raid, RID: r.RID, Dt: r.DtArrive, Balance: rlib.Money(0), State: rlib.LMINITIAL}
+	if _, err = rlib.InsertLedgerMarker(ctx, &rlm); err != nil {
+		return bizErrSys(&err)
+	}
+	ru := rlib.RentableUser{RID: r.RID, BID: r.BID, TCID: r.TCID, DtStart: r.DtArrive, DtStop: r.DtDepart}
+	if _, err = rlib.InsertRentableUser(ctx, &ru); err != nil {
+		return bizErrSys(&err)
+	}
+	pay := rlib.RentalAgreementPayor{RAID: raid, BID: r.BID, TCID: r.TCID, DtStart: r.DtArrive, DtStop: r.DtDepart}
+	if _, err = rlib.InsertRentalAgreementPayor(ctx, &pay); err != nil {
+		return bizErrSys(&err)
+	}
+
+	r.RAID = raid
+	r.FLAGS = r.FLAGS&^rlib.RESSTATEMask | rlib.RESSTATECheckedIn
+	if err = rlib.UpdateReservation(ctx, r); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
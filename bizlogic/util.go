@@ -54,3 +54,21 @@ func PrintBizErrorList(e []BizError) {
 		fmt.Printf("Error: %s\n", e[i].Message)
 	}
 }
+
+// bizErrf adds BizError errno to the supplied list with its message
+// formatted using args.
+//
+// INPUTS
+//  e     = the list of errors so far
+//  errno = the error number
+//  args  = values for the format verbs in the error message
+//
+// RETURNS
+//  the updated list
+//-------------------------------------------------------------------------------------
+func bizErrf(e []BizError, errno int, args ...interface{}) []BizError {
+	if errno < 0 || errno+1 > len(BizErrors) {
+		return e
+	}
+	return append(e, BizError{Errno: errno, Message: fmt.Sprintf(BizErrors[errno].Message, args...)})
+}
@@ -0,0 +1,412 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// ComputeUtilityBill splits utility bill b between the units of the
+// business. Nothing is saved.
+//
+// With Ratio Utility Billing the units are the rentables whose type has a
+// Square Feet custom attribute, or with the fixed ratio method the
+// rentables given a ratio in r. With sub-metering they are the rentables
+// with at least two readings for the utility in the period, the first and
+// the last giving the usage. Each unit's period is cut into the parts
+// rented by each Rental Agreement and the parts it was vacant. The vacant
+// parts take their share of the bill, the owner's, and are not returned.
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill
+//  r   = the fixed ratio of each unit, used by UTILMETHODRatio only
+//
+// RETURNS
+//  the charges of the Rental Agreements, sorted by Rentable
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func ComputeUtilityBill(ctx context.Context, b *rlib.UtilityBill, r []rlib.UtilityRatio) ([]rlib.UtilityCharge, []BizError) {
+	var (
+		m      []rlib.UtilityCharge
+		usage  map[int64]float64
+		ratios map[int64]float64
+		xbiz   rlib.XBusiness
+	)
+	if _, ok := rlib.UtilityMethods[b.Method]; !ok {
+		return m, bizErrf(nil, UtilityMethod, b.Method)
+	}
+	if err := rlib.GetXBusiness(ctx, b.BID, &xbiz); err != nil {
+		return m, bizErrSys(&err)
+	}
+	d1, d2 := &b.DtStart, &b.DtStop
+	last := d2.AddDate(0, 0, -1)
+	days := utilityDays(d1, d2)
+	if b.Method == rlib.UTILMETHODSubmeter {
+		var errlist []BizError
+		if usage, errlist = meterUsage(ctx, b); len(errlist) > 0 {
+			return m, errlist
+		}
+	}
+	if b.Method == rlib.UTILMETHODRatio {
+		var errlist []BizError
+		if ratios, errlist = utilityRatios(ctx, b, r); len(errlist) > 0 {
+			return m, errlist
+		}
+	}
+
+	rentables, err := rlib.GetRentablesByBusiness(ctx, b.BID)
+	if err != nil {
+		return m, bizErrSys(&err)
+	}
+	for i := 0; i < len(rentables); i++ {
+		rid := rentables[i].RID
+		rtr, err := rlib.GetRentableTypeRefForDate(ctx, rid, &last)
+		if err != nil {
+			return m, bizErrSys(&err)
+		}
+		if rtr.RTID == 0 {
+			continue // the rentable was not there at the end of the period
+		}
+		sqft, err := rlib.RentableTypeSqft(&xbiz, rtr.RTID)
+		if err != nil {
+			return m, bizErrSys(&err)
+		}
+		if _, ok := usage[rid]; b.Method == rlib.UTILMETHODSubmeter && !ok {
+			continue // not metered
+		}
+		if _, ok := ratios[rid]; b.Method == rlib.UTILMETHODRatio && !ok {
+			continue // no ratio on the bill
+		}
+		if b.Method != rlib.UTILMETHODSubmeter && b.Method != rlib.UTILMETHODRatio && sqft == 0 {
+			continue // not a dwelling unit
+		}
+
+		//--------------------------------------------------
+		// the parts rented and the parts vacant
+		//--------------------------------------------------
+		rars, err := rlib.GetAgreementsForRentable(ctx, rid, d1, d2)
+		if err != nil {
+			return m, bizErrSys(&err)
+		}
+		var parts []rlib.UtilityCharge
+		var rented []rlib.Period
+		for j := 0; j < len(rars); j++ {
+			start := camMaxTime(*d1, rars[j].RARDtStart)
+			stop := camMinTime(*d2, rars[j].RARDtStop)
+			if !start.Before(stop) {
+				continue
+			}
+			rented = append(rented, rlib.Period{D1: start, D2: stop})
+			parts = append(parts, rlib.UtilityCharge{RAID: rars[j].RAID, DtStart: start, DtStop: stop})
+		}
+		gaps := rlib.FindGaps(d1, d2, rented)
+		for j := 0; j < len(gaps); j++ {
+			parts = append(parts, rlib.UtilityCharge{DtStart: gaps[j].D1, DtStop: gaps[j].D2})
+		}
+		for j := 0; j < len(parts); j++ {
+			c := &parts[j]
+			c.BID = b.BID
+			c.UBID = b.UBID
+			c.RID = rid
+			c.Sqft = sqft
+			c.Ratio = ratios[rid]
+			c.Days = utilityDays(&c.DtStart, &c.DtStop)
+			if b.Method == rlib.UTILMETHODSubmeter && days > 0 {
+				c.MeterUsage = math.Round(usage[rid]*float64(c.Days)/float64(days)*1e4) / 1e4
+			}
+			if b.Method == rlib.UTILMETHODOccupants && c.RAID > 0 {
+				if c.Occupants, err = utilityOccupants(ctx, c); err != nil {
+					return m, bizErrSys(&err)
+				}
+			}
+		}
+		m = append(m, parts...)
+	}
+
+	total := int64(0)
+	for i := 0; i < len(m); i++ {
+		total += rlib.UtilityWeight(b.Method, &m[i])
+	}
+	if total == 0 {
+		return nil, bizErrf(nil, UtilityNoUnits, b.Utility, d1.Format(rlib.RRDATEFMT3), last.Format(rlib.RRDATEFMT3))
+	}
+	rlib.UtilityAllocate(b, m)
+
+	var charges []rlib.UtilityCharge
+	for i := 0; i < len(m); i++ {
+		if m[i].RAID > 0 {
+			charges = append(charges, m[i])
+		}
+	}
+	return charges, nil
+}
+
+// utilityDays returns the number of days in d1 - d2
+func utilityDays(d1, d2 *time.Time) int64 {
+	return int64(d2.Sub(*d1).Hours()/24 + 0.5)
+}
+
+// utilityOccupants returns the number of people using the rentable of
+// charge c during its part of the period. If no users were recorded the
+// payors of the Rental Agreement are counted.
+func utilityOccupants(ctx context.Context, c *rlib.UtilityCharge) (int64, error) {
+	people := map[int64]bool{}
+	ru, err := rlib.GetRentableUsersInRange(ctx, c.RID, &c.DtStart, &c.DtStop)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(ru); i++ {
+		people[ru[i].TCID] = true
+	}
+	if len(people) == 0 {
+		rp, err := rlib.GetRentalAgreementPayorsInRange(ctx, c.RAID, &c.DtStart, &c.DtStop)
+		if err != nil {
+			return 0, err
+		}
+		for i := 0; i < len(rp); i++ {
+			people[rp[i].TCID] = true
+		}
+	}
+	return int64(len(people)), nil
+}
+
+// meterUsage returns the usage of each sub-metered rentable in the period
+// of bill b: its last reading less its first one. Rentables with fewer than
+// two readings are left out.
+func meterUsage(ctx context.Context, b *rlib.UtilityBill) (map[int64]float64, []BizError) {
+	usage := map[int64]float64{}
+	m, err := rlib.GetMeterReadingsByRange(ctx, b.BID, b.Utility, &b.DtStart, &b.DtStop)
+	if err != nil {
+		return usage, bizErrSys(&err)
+	}
+	for i := 0; i < len(m); {
+		j := i
+		for j+1 < len(m) && m[j+1].RID == m[i].RID {
+			j++
+		}
+		if j > i {
+			if m[j].Reading < m[i].Reading {
+				return usage, bizErrf(nil, MeterUsageNegative, b.Utility, m[i].RID, m[i].Dt.Format(rlib.RRDATEFMT3), m[j].Dt.Format(rlib.RRDATEFMT3))
+			}
+			usage[m[i].RID] = m[j].Reading - m[i].Reading
+		}
+		i = j + 1
+	}
+	return usage, nil
+}
+
+// utilityRatios returns the fixed ratio of each unit in r, the ratios of
+// bill b. Each ratio must be more than 0, for a rentable of the business,
+// and given once.
+func utilityRatios(ctx context.Context, b *rlib.UtilityBill, r []rlib.UtilityRatio) (map[int64]float64, []BizError) {
+	var errlist []BizError
+	ratios := map[int64]float64{}
+	for i := 0; i < len(r); i++ {
+		rnt, err := rlib.GetRentable(ctx, r[i].RID)
+		if err != nil {
+			return ratios, bizErrSys(&err)
+		}
+		if rnt.RID == 0 || rnt.BID != b.BID {
+			errlist = bizErrf(errlist, UnknownRID, r[i].RID)
+			continue
+		}
+		if _, ok := ratios[r[i].RID]; ok || r[i].Ratio <= 0 {
+			errlist = bizErrf(errlist, UtilityRatio, r[i].RID, b.Utility)
+			continue
+		}
+		ratios[r[i].RID] = r[i].Ratio
+	}
+	return ratios, errlist
+}
+
+// SaveUtilityBill validates and saves utility bill b and computes its
+// charges. The charges of an earlier save are replaced, and so are its
+// fixed ratios. A posted bill cannot be changed.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  b   = the bill, UBID is 0 for a new bill
+//  r   = the fixed ratio of each unit, saved with UTILMETHODRatio only
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveUtilityBill(ctx context.Context, b *rlib.UtilityBill, r []rlib.UtilityRatio) []BizError {
+	var errlist []BizError
+	b.Utility = strings.TrimSpace(b.Utility)
+	if len(b.Utility) == 0 {
+		errlist = AddBizErrToList(errlist, MissingName)
+	}
+	if b.Amount <= 0 || b.CommonPct < 0 || b.CommonPct >= 100 || !b.DtStart.Before(b.DtStop) {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+	ar, err := rlib.GetAR(ctx, b.ARID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if ar.ARID == 0 || ar.BID != b.BID {
+		errlist = bizErrf(errlist, UtilityNoAR, b.ARID, b.BID)
+	}
+	if b.UBID > 0 {
+		old, err := rlib.GetUtilityBill(ctx, b.UBID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if old.FLAGS&rlib.UTILBILLPosted != 0 {
+			return bizErrf(errlist, UtilityBillPosted, b.UBID)
+		}
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	m, errlist := ComputeUtilityBill(ctx, b, r)
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	if b.UBID == 0 {
+		err = rlib.InsertUtilityBill(ctx, b)
+	} else {
+		err = rlib.UpdateUtilityBill(ctx, b)
+	}
+	if err == nil {
+		err = rlib.DeleteUtilityCharges(ctx, b.UBID)
+	}
+	for i := 0; i < len(m) && err == nil; i++ {
+		m[i].UBID = b.UBID
+		err = rlib.InsertUtilityCharge(ctx, &m[i])
+	}
+	if err == nil {
+		err = rlib.DeleteUtilityRatios(ctx, b.UBID)
+	}
+	for i := 0; i < len(r) && err == nil && b.Method == rlib.UTILMETHODRatio; i++ {
+		r[i].UBID = b.UBID
+		r[i].BID = b.BID
+		err = rlib.InsertUtilityRatio(ctx, &r[i])
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// DeleteUtilityBill deletes utility bill b, its charges and its fixed
+// ratios. A posted bill cannot be deleted.
+//
+// INPUTS
+//  ctx = db context
+//  b   = the bill
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func DeleteUtilityBill(ctx context.Context, b *rlib.UtilityBill) []BizError {
+	if b.FLAGS&rlib.UTILBILLPosted != 0 {
+		return bizErrf(nil, UtilityBillPosted, b.UBID)
+	}
+	if err := rlib.DeleteUtilityCharges(ctx, b.UBID); err != nil {
+		return bizErrSys(&err)
+	}
+	if err := rlib.DeleteUtilityRatios(ctx, b.UBID); err != nil {
+		return bizErrSys(&err)
+	}
+	if err := rlib.DeleteUtilityBill(ctx, b.UBID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// PostUtilityBill assesses the charges of utility bill b to the Rental
+// Agreements with the bill's account rule. The bill is marked posted and
+// can no longer be changed.
+//
+// INPUTS
+//  ctx = db context, with a transaction
+//  b   = the bill
+//  dt  = date of the assessments
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func PostUtilityBill(ctx context.Context, b *rlib.UtilityBill, dt *time.Time) []BizError {
+	if b.FLAGS&rlib.UTILBILLPosted != 0 {
+		return bizErrf(nil, UtilityBillPosted, b.UBID)
+	}
+	m, err := rlib.GetUtilityCharges(ctx, b.UBID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].Amount <= 0 || m[i].ASMID > 0 {
+			continue
+		}
+		a := rlib.Assessment{
+			BID:            b.BID,
+			RID:            m[i].RID,
+			RAID:           m[i].RAID,
+			Amount:         m[i].Amount,
+			Start:          *dt,
+			Stop:           *dt,
+			RentCycle:      rlib.RECURNONE,
+			ProrationCycle: rlib.RECURNONE,
+			ARID:           b.ARID,
+			Comment:        fmt.Sprintf("%s %s - %s", b.Utility, m[i].DtStart.Format(rlib.RRDATEFMT3), m[i].DtStop.AddDate(0, 0, -1).Format(rlib.RRDATEFMT3)),
+		}
+		if errlist := InsertAssessment(ctx, &a, 0); len(errlist) > 0 {
+			return errlist
+		}
+		m[i].ASMID = a.ASMID
+		if err = rlib.UpdateUtilityCharge(ctx, &m[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	b.FLAGS |= rlib.UTILBILLPosted
+	b.DtPosted = *dt
+	if err = rlib.UpdateUtilityBill(ctx, b); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// SaveMeterReading validates and saves a sub-meter reading
+//
+// INPUTS
+//  ctx = db context
+//  r   = the reading, MRID is 0 for a new reading
+//
+// RETURNS
+//  a slice of BizErrors
+//-----------------------------------------------------------------------------
+func SaveMeterReading(ctx context.Context, r *rlib.MeterReading) []BizError {
+	var errlist []BizError
+	r.Utility = strings.TrimSpace(r.Utility)
+	if len(r.Utility) == 0 {
+		errlist = AddBizErrToList(errlist, MissingName)
+	}
+	if r.Reading < 0 {
+		errlist = AddBizErrToList(errlist, InvalidField)
+	}
+	rnt, err := rlib.GetRentable(ctx, r.RID)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if rnt.RID == 0 || rnt.BID != r.BID {
+		errlist = bizErrf(errlist, UnknownRID, r.RID)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if r.MRID == 0 {
+		err = rlib.InsertMeterReading(ctx, r)
+	} else {
+		err = rlib.UpdateMeterReading(ctx, r)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package bizlogic
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// ChargeBackWorkOrder charges the tenant for work order a. A one time
+// Assessment for amt is added to the work order's Rental Agreement using
+// account rule arid and the work order is updated to point to it. A work
+// order can only be charged back once.
+//
+// INPUTS
+//  ctx  = db context
+//  a    = the work order
+//  arid = the account rule for the assessment
+//  amt  = the amount to charge
+//  dt   = the date of the assessment
+//
+// RETURNS
+//  a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ChargeBackWorkOrder(ctx context.Context, a *rlib.WorkOrder, arid int64, amt rlib.Money, dt *time.Time) []BizError {
+	if a.RAID == 0 {
+		s := fmt.Sprintf(BizErrors[WorkOrderNoRentalAgreement].Message, a.WOID)
+		return []BizError{{Errno: WorkOrderNoRentalAgreement, Message: s}}
+	}
+	if a.ChargeASMID > 0 {
+		s := fmt.Sprintf(BizErrors[WorkOrderAlreadyCharged].Message, a.WOID, a.ChargeASMID)
+		return []BizError{{Errno: WorkOrderAlreadyCharged, Message: s}}
+	}
+	if amt <= 0 {
+		return AddBizErrToList(nil, WorkOrderChargeAmount)
+	}
+
+	asm := rlib.Assessment{
+		BID:            a.BID,
+		RID:            a.RID,
+		RAID:           a.RAID,
+		Amount:         amt,
+		Start:          *dt,
+		Stop:           *dt,
+		RentCycle:      rlib.RECURNONE,
+		ProrationCycle: rlib.RECURNONE,
+		ARID:           arid,
+		Comment:        fmt.Sprintf("Charge back of work order %s", rlib.IDtoShortString("WO", a.WOID)),
+	}
+	if errlist := InsertAssessment(ctx, &asm, 0); len(errlist) > 0 {
+		return errlist
+	}
+	a.ChargeASMID = asm.ASMID
+	if err := rlib.UpdateWorkOrder(ctx, a); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -194,7 +194,7 @@ CREATE TABLE RentalAgreement (
     Renewal SMALLINT NOT NULL DEFAULT 0,                                -- 0 = not set, 1 = month to month automatic renewal, 2 = lease extension options
     SpecialProvisions VARCHAR(1024) NOT NULL DEFAULT '',                -- free-form text
     LeaseType BIGINT NOT NULL DEFAULT 0,                                -- Full Service Gross, Gross, ModifiedGross, Tripple Net
-    ExpenseAdjustmentType BIGINT NOT NULL DEFAULT 0,                    -- Base Year, No Base Year, Pass Through
+    ExpenseAdjustmentType BIGINT NOT NULL DEFAULT 0,                    -- 0 = not set, 1 = Base Year, 2 = No Base Year, 3 = Pass Through
     ExpensesStop DECIMAL(19,4) NOT NULL DEFAULT 0,                      -- cap on the amount of oexpenses that can be passed through to the tenant
     ExpenseStopCalculation VARCHAR(128) NOT NULL DEFAULT '',            -- note on how to determine the expense stop
     BaseYearEnd DATE NOT NULL DEFAULT '1970-01-01 00:00:00',            -- last day of the base year
@@ -1470,6 +1470,20 @@ CREATE TABLE ClosePeriod (
     PRIMARY KEY (CPID)
 );
 
+CREATE TABLE ClosePeriodReopen (
+    CPRID BIGINT NOT NULL AUTO_INCREMENT,                       -- Close Period Reopen ID
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    CPID BIGINT NOT NULL DEFAULT 0,                             -- the ClosePeriod that was reopened (it no longer exists)
+    TLID BIGINT NOT NULL DEFAULT 0,                             -- Task List that was used for the close
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- Date/Time of the close that was reopened
+    Reason VARCHAR(2048) NOT NULL DEFAULT '',                   -- why the period was reopened
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that reopened the period
+    PRIMARY KEY (CPRID)
+);
+
 
 -- **************************************
 -- ****                              ****
@@ -1489,3 +1503,723 @@ CREATE TABLE Flow (
     CreateBy BIGINT NOT NULL DEFAULT 0,                                                    -- who created it
     PRIMARY KEY(FlowID)
 );
+
+
+-- **************************************
+-- ****                              ****
+-- ****     REPORT SUBSCRIPTIONS     ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE ReportSubscription (
+    RSUBID BIGINT NOT NULL AUTO_INCREMENT,                      -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    ReportName VARCHAR(100) NOT NULL DEFAULT '',                -- report name as registered in the report handler table
+    DateRange BIGINT NOT NULL DEFAULT 0,                        -- relative date range: 0 = current month, 1 = last month, 2 = month to date, 3 = last 7 days, 4 = last quarter, 5 = year to date, 6 = last year
+    Format BIGINT NOT NULL DEFAULT 0,                           -- gotable output format (TABLEOUTTEXT, TABLEOUTHTML, TABLEOUTPDF, TABLEOUTCSV)
+    Recipients VARCHAR(2048) NOT NULL DEFAULT '',               -- comma separated list of email addresses
+    Cycle BIGINT NOT NULL DEFAULT 0,                            -- recurrence frequency
+    DtNext DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- next delivery date/time
+    DtLast DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- last delivery date/time
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 subscription is inactive
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RSUBID)
+);
+
+CREATE TABLE ReportDelivery (
+    RDID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    RSUBID BIGINT NOT NULL DEFAULT 0,                           -- the subscription that was delivered
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- date/time of delivery attempt
+    DtStart DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',    -- report start date
+    DtStop DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- report stop date
+    Recipients VARCHAR(2048) NOT NULL DEFAULT '',               -- who it was sent to
+    Status BIGINT NOT NULL DEFAULT 0,                           -- 0 = delivered, 1 = failed
+    Message VARCHAR(2048) NOT NULL DEFAULT '',                  -- error message if the delivery failed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RDID)
+);
+
+
+-- **************************************
+-- ****                              ****
+-- ****           WEBHOOKS           ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE WebhookSubscription (
+    WHSID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    URL VARCHAR(1024) NOT NULL DEFAULT '',                      -- where events are POSTed
+    Secret VARCHAR(256) NOT NULL DEFAULT '',                    -- key used to sign the payloads
+    Events VARCHAR(1024) NOT NULL DEFAULT '',                   -- comma separated list of event names, empty means all events
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 subscription is inactive
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (WHSID)
+);
+
+CREATE TABLE WebhookDelivery (
+    WHDID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    WHSID BIGINT NOT NULL DEFAULT 0,                            -- the subscription this event is delivered to
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Event VARCHAR(100) NOT NULL DEFAULT '',                     -- event name
+    Payload MEDIUMTEXT NOT NULL,                                -- the JSON event body
+    Status BIGINT NOT NULL DEFAULT 0,                           -- 0 = pending, 1 = delivered, 2 = failed, no more retries
+    Attempts BIGINT NOT NULL DEFAULT 0,                         -- number of delivery attempts so far
+    DtNext DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when to make the next attempt
+    DtLast DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when the last attempt was made
+    ResponseCode BIGINT NOT NULL DEFAULT 0,                     -- http status code of the last attempt
+    Message VARCHAR(2048) NOT NULL DEFAULT '',                  -- error message from the last attempt
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (WHDID)
+);
+
+-- ===========================================
+--   USER SESSION
+--   used when sessions are kept in the database
+--   so that they can be shared by several servers
+-- ===========================================
+CREATE TABLE UserSession (
+    Token VARCHAR(128) NOT NULL DEFAULT '',                     -- session token, the value of the session cookie
+    Username VARCHAR(100) NOT NULL DEFAULT '',                  -- associated username
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- user's preferred name, or first name
+    UID BIGINT NOT NULL DEFAULT 0,                              -- user's db uid
+    CoCode BIGINT NOT NULL DEFAULT 0,                           -- logged in user's company
+    ImageURL VARCHAR(1024) NOT NULL DEFAULT '',                 -- user's picture
+    Expire DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when the session expires
+    RoleID BIGINT NOT NULL DEFAULT 0,                           -- security role id
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    PRIMARY KEY (Token)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****        TENANT PORTAL         ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE PortalUser (
+    PUID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the Transactant who logs in with this credential
+    Username VARCHAR(100) NOT NULL DEFAULT '',                  -- login name, unique within the business
+    PassHash VARCHAR(128) NOT NULL DEFAULT '',                  -- hex encoded PBKDF2-SHA256 hash of the password
+    Salt VARCHAR(64) NOT NULL DEFAULT '',                       -- hex encoded salt for PassHash
+    Token VARCHAR(128) NOT NULL DEFAULT '',                     -- SHA256 hash of the current session token
+    TokenExpire DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',  -- when the current session expires
+    LastLogin DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',  -- time of the last successful login
+    FailedLogins BIGINT NOT NULL DEFAULT 0,                     -- consecutive failed login attempts
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 login disabled
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PUID)
+);
+
+CREATE TABLE MaintRequest (
+    MRQID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the Rental Agreement of the tenant who made the request
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable that needs attention
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the Transactant who made the request
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- when the request was made
+    Subject VARCHAR(100) NOT NULL DEFAULT '',                   -- short description
+    Descr VARCHAR(2048) NOT NULL DEFAULT '',                    -- what needs to be done
+    Status BIGINT NOT NULL DEFAULT 0,                           -- 0 = new, 1 = in progress, 2 = closed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (MRQID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****         WORK ORDERS          ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE WorkOrder (
+    WOID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable the work is for
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement, if any, the work is for. Needed for a charge back
+    MRQID BIGINT NOT NULL DEFAULT 0,                            -- the tenant's MaintRequest, if any, that led to this work order
+    NLID BIGINT NOT NULL DEFAULT 0,                             -- NoteList with the notes for this work order
+    Category VARCHAR(50) NOT NULL DEFAULT '',                   -- plumbing, electrical, appliance, ...
+    Priority BIGINT NOT NULL DEFAULT 0,                         -- 0 = low, 1 = normal, 2 = high, 3 = emergency
+    Status BIGINT NOT NULL DEFAULT 0,                           -- 0 = open, 1 = assigned, 2 = in progress, 3 = on hold, 4 = completed, 5 = cancelled
+    AssignedUID BIGINT NOT NULL DEFAULT 0,                      -- staff UID (from phonebook) doing the work, 0 if none
+    AssignedTo VARCHAR(100) NOT NULL DEFAULT '',                -- name of whoever is doing the work
+    Descr VARCHAR(2048) NOT NULL DEFAULT '',                    -- what needs to be done
+    DtOpen DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when the work order was opened
+    DtDone DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when it was completed or cancelled
+    Cost DECIMAL(19,4) NOT NULL DEFAULT 0.0,                    -- cost of the work
+    ChargeASMID BIGINT NOT NULL DEFAULT 0,                      -- Assessment charging the tenant for the work, 0 if none
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (WOID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****       ACCOUNTS PAYABLE       ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE Vendor (
+    VID BIGINT NOT NULL AUTO_INCREMENT,                         -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- vendor name, as it appears on checks
+    Contact VARCHAR(100) NOT NULL DEFAULT '',                   -- person to contact at the vendor
+    Address VARCHAR(100) NOT NULL DEFAULT '',
+    Address2 VARCHAR(100) NOT NULL DEFAULT '',
+    City VARCHAR(100) NOT NULL DEFAULT '',
+    State CHAR(25) NOT NULL DEFAULT '',
+    PostalCode VARCHAR(100) NOT NULL DEFAULT '',
+    Country VARCHAR(100) NOT NULL DEFAULT '',
+    Email VARCHAR(100) NOT NULL DEFAULT '',
+    Phone VARCHAR(100) NOT NULL DEFAULT '',
+    TaxID VARCHAR(25) NOT NULL DEFAULT '',                      -- EIN or SSN, needed for 1099 reporting
+    DefaultLID BIGINT NOT NULL DEFAULT 0,                       -- GL Account bills from this vendor are usually distributed to
+    Terms BIGINT NOT NULL DEFAULT 30,                           -- number of days after the bill date that payment is due
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 inactive, 1<<1 payments are reported on a 1099
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VID)
+);
+
+CREATE TABLE Bill (
+    BILLID BIGINT NOT NULL AUTO_INCREMENT,                      -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    VID BIGINT NOT NULL DEFAULT 0,                              -- the Vendor that sent the bill
+    InvoiceNo VARCHAR(50) NOT NULL DEFAULT '',                  -- the vendor's invoice number
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- bill date, the date the expense is posted
+    DtDue DATE NOT NULL DEFAULT '1970-01-01 00:00:00',          -- date payment is due
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total amount of the bill, the sum of its BillLines
+    APLID BIGINT NOT NULL DEFAULT 0,                            -- the Accounts Payable GL Account credited
+    JID BIGINT NOT NULL DEFAULT 0,                              -- Journal entry posting the bill
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 void
+    Comment VARCHAR(256) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BILLID)
+);
+
+CREATE TABLE BillLine (
+    BLID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    BILLID BIGINT NOT NULL DEFAULT 0,                           -- the Bill this line belongs to
+    LID BIGINT NOT NULL DEFAULT 0,                              -- GL Account debited
+    RID BIGINT NOT NULL DEFAULT 0,                              -- Rentable the expense is for, 0 if none
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,
+    Descr VARCHAR(256) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BLID)
+);
+
+CREATE TABLE VendorPayment (
+    VPID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    VID BIGINT NOT NULL DEFAULT 0,                              -- the Vendor paid
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository the funds come from, its GL Account is credited
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- payment date
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total paid, the sum of its VendorPaymentAllocations
+    Method BIGINT NOT NULL DEFAULT 0,                           -- 1 = check, 2 = ACH
+    DocNo VARCHAR(50) NOT NULL DEFAULT '',                      -- check number or ACH trace number
+    JID BIGINT NOT NULL DEFAULT 0,                              -- Journal entry posting the payment
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 void
+    Comment VARCHAR(256) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VPID)
+);
+
+CREATE TABLE VendorPaymentAllocation (
+    VPAID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    VPID BIGINT NOT NULL DEFAULT 0,                             -- the VendorPayment
+    BILLID BIGINT NOT NULL DEFAULT 0,                           -- the Bill it pays
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount applied to the bill
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (VPAID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****            CHECKS            ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE CheckAccount (
+    CKAID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository checks are drawn on, one CheckAccount per Depository
+    BankName VARCHAR(100) NOT NULL DEFAULT '',                  -- printed on the check
+    BankAddress VARCHAR(256) NOT NULL DEFAULT '',               -- printed on the check, lines separated by newlines
+    RoutingNo CHAR(9) NOT NULL DEFAULT '',                      -- ABA routing number for the MICR line
+    FractionalNo VARCHAR(20) NOT NULL DEFAULT '',               -- fractional routing number printed near the check number
+    PayerName VARCHAR(100) NOT NULL DEFAULT '',                 -- account holder printed on the check
+    PayerAddress VARCHAR(256) NOT NULL DEFAULT '',              -- account holder address, lines separated by newlines
+    NextCheckNo BIGINT NOT NULL DEFAULT 1,                      -- number assigned to the next check written
+    PPFID BIGINT NOT NULL DEFAULT 0,                            -- PositivePayFormat of the bank, 0 if the bank does not use positive pay
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CKAID)
+);
+
+CREATE TABLE BankCheck (
+    CKID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository the check is drawn on
+    CheckNo BIGINT NOT NULL DEFAULT 0,                          -- sequential within the Depository
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- check date
+    Payee VARCHAR(100) NOT NULL DEFAULT '',                     -- pay to the order of
+    PayeeAddress VARCHAR(256) NOT NULL DEFAULT '',              -- lines separated by newlines
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- check amount
+    Memo VARCHAR(100) NOT NULL DEFAULT '',                      -- printed on the memo line
+    DebitLID BIGINT NOT NULL DEFAULT 0,                         -- GL Account debited when the check is not for a VendorPayment
+    VPID BIGINT NOT NULL DEFAULT 0,                             -- the VendorPayment this check pays, if any
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- Transactant paid, for refunds
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement, for refunds
+    JID BIGINT NOT NULL DEFAULT 0,                              -- Journal entry posting the check, the VendorPayment's if VPID > 0
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 void, 1<<1 printed, 1<<2 issue sent to positive pay, 1<<3 void sent to positive pay
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CKID)
+);
+
+CREATE TABLE PositivePayFormat (
+    PPFID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- usually the name of the bank
+    Header VARCHAR(2048) NOT NULL DEFAULT '',                   -- template for the header record, no header if blank
+    Detail VARCHAR(2048) NOT NULL DEFAULT '',                   -- template for each check record
+    Trailer VARCHAR(2048) NOT NULL DEFAULT '',                  -- template for the trailer record, no trailer if blank
+    FileExt VARCHAR(10) NOT NULL DEFAULT 'txt',                 -- extension of the exported file name
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 lines end with CR LF rather than LF
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PPFID)
+);
+
+CREATE TABLE PositivePayExport (
+    PPXID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- the Depository whose checks were exported
+    PPFID BIGINT NOT NULL DEFAULT 0,                            -- the format used
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- checks issued on or after this date were included
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- up to but not including this date
+    Items BIGINT NOT NULL DEFAULT 0,                            -- number of check records in the file
+    IssuedTotal DECIMAL(19,4) NOT NULL DEFAULT 0.0,             -- total of the issued check records
+    VoidTotal DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- total of the void check records
+    FileName VARCHAR(100) NOT NULL DEFAULT '',                  -- name the file is downloaded as
+    Content MEDIUMTEXT NOT NULL,                                -- the file as sent to the bank
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PPXID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****        LEASE DOCUMENTS       ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE LeaseTemplate (
+    LTID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RATID BIGINT NOT NULL DEFAULT 0,                            -- the RentalAgreementTemplate this is the document of
+    Title VARCHAR(100) NOT NULL DEFAULT '',                     -- document title, also used for the file name
+    Body MEDIUMTEXT NOT NULL,                                   -- HTML template with merge fields
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (LTID)
+);
+
+CREATE TABLE LeaseDocument (
+    LDID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the Rental Agreement version, 0 while it is only a flow
+    FlowID BIGINT NOT NULL DEFAULT 0,                           -- the flow it was generated from, 0 if generated from the RA
+    LTID BIGINT NOT NULL DEFAULT 0,                             -- the LeaseTemplate used
+    FileName VARCHAR(128) NOT NULL DEFAULT '',                  -- name the file is downloaded as
+    Content MEDIUMBLOB NOT NULL,                                -- the PDF
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (LDID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****      CAM RECONCILIATION      ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE CAMPool (
+    CAMPID BIGINT NOT NULL AUTO_INCREMENT,                      -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- ex: Common Area Maintenance
+    LIDs VARCHAR(1024) NOT NULL DEFAULT '',                     -- comma separated GLAccounts whose expenses are pooled
+    EstimateARID BIGINT NOT NULL DEFAULT 0,                     -- AR used to bill the estimated charges
+    TrueUpARID BIGINT NOT NULL DEFAULT 0,                       -- AR used to charge a tenant who was billed too little
+    CreditARID BIGINT NOT NULL DEFAULT 0,                       -- AR used to credit a tenant who was billed too much
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CAMPID)
+);
+
+CREATE TABLE CAMRecon (
+    CAMRID BIGINT NOT NULL AUTO_INCREMENT,                      -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    CAMPID BIGINT NOT NULL DEFAULT 0,                           -- the pool reconciled
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- start of the year reconciled
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- end of the year, not included
+    Expenses DECIMAL(19,4) NOT NULL DEFAULT 0.0,                -- actual expenses of the pool for the year
+    TotalSqft BIGINT NOT NULL DEFAULT 0,                        -- square feet of all rentables with a size, occupied or not
+    DtPosted DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- date of the true-up assessments
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 posted, the true-up assessments have been made
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CAMRID)
+);
+
+CREATE TABLE CAMReconItem (
+    CAMRIID BIGINT NOT NULL AUTO_INCREMENT,                     -- unique id
+    CAMRID BIGINT NOT NULL DEFAULT 0,                           -- the reconciliation
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the latest Rental Agreement version in the year
+    RID BIGINT NOT NULL DEFAULT 0,                              -- Rentable the true-up is assessed to
+    Sqft BIGINT NOT NULL DEFAULT 0,                             -- square feet leased
+    Days BIGINT NOT NULL DEFAULT 0,                             -- days leased in the year
+    Share DECIMAL(19,8) NOT NULL DEFAULT 0.0,                   -- pro-rata share: Sqft/TotalSqft * Days/days in year
+    Allocated DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- Share of the year's expenses
+    BaseYear DECIMAL(19,4) NOT NULL DEFAULT 0.0,                -- Share of the base year's expenses
+    Due DECIMAL(19,4) NOT NULL DEFAULT 0.0,                     -- amount the tenant owes for the year after base year and expense stop
+    Estimates DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- estimated charges billed for the year
+    TrueUp DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- Due - Estimates, > 0 tenant owes, < 0 tenant is credited
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- the true-up assessment once posted
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CAMRIID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****       PERCENTAGE RENT        ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE PctRent (
+    PRID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement that pays percentage rent
+    BreakpointType SMALLINT NOT NULL DEFAULT 0,                 -- 1 = natural, 2 = fixed
+    BaseARID BIGINT NOT NULL DEFAULT 0,                         -- AR of the base rent, for a natural breakpoint
+    OverageARID BIGINT NOT NULL DEFAULT 0,                      -- AR used to assess the overage rent
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PRID)
+);
+
+CREATE TABLE PctRentTier (
+    PRTID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    PRID BIGINT NOT NULL DEFAULT 0,                             -- the percentage rent terms
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Breakpoint DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- annual sales above which Rate applies
+    Rate DECIMAL(19,8) NOT NULL DEFAULT 0.0,                    -- ex: 0.06 for 6%
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PRTID)
+);
+
+CREATE TABLE SalesReport (
+    SRID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement of the tenant reporting
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- start of the period reported
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- end of the period, not included
+    GrossSales DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- gross sales the tenant reported for the period
+    Breakpoint DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- first breakpoint for the period, once computed
+    Overage DECIMAL(19,4) NOT NULL DEFAULT 0.0,                 -- percentage rent due for the period, once computed
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- the overage assessment, 0 if none
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- ex: audited
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 computed, the overage has been assessed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (SRID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****         RESERVATION          ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE Reservation (
+    RESID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RTID BIGINT NOT NULL DEFAULT 0,                             -- the type of room reserved
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the room, 0 until one is assigned
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the guest
+    RPID BIGINT NOT NULL DEFAULT 0,                             -- rate plan, 0 = market rate
+    DtArrive DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- arrival date
+    DtDepart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- departure date, the last night is the day before
+    Adults SMALLINT NOT NULL DEFAULT 0,                         -- number of adults
+    Children SMALLINT NOT NULL DEFAULT 0,                       -- number of children
+    Rate DECIMAL(19,4) NOT NULL DEFAULT 0.0,                    -- nightly rate
+    Deposit DECIMAL(19,4) NOT NULL DEFAULT 0.0,                 -- deposit taken to hold the reservation
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement created at check-in
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- ex: late arrival
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- bits 0-1: 0 = booked, 1 = checked in, 2 = cancelled, 3 = no show
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RESID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****         CONCESSIONS          ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE Concession (
+    CONID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- ex: First month free
+    Description VARCHAR(256) NOT NULL DEFAULT '',               -- terms shown to the leasing agent
+    ARID BIGINT NOT NULL DEFAULT 0,                             -- account rule of the concession expense
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount off each rent charge, if Pct is 0
+    Pct DECIMAL(19,4) NOT NULL DEFAULT 0.0,                     -- percent off each rent charge, 0 - 100
+    Cycles BIGINT NOT NULL DEFAULT 0,                           -- number of rent charges it applies to
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0: 1 = no longer offered
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CONID)
+);
+
+CREATE TABLE RentalAgreementConcession (
+    RACID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- the Rental Agreement
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable whose rent is discounted
+    CONID BIGINT NOT NULL DEFAULT 0,                            -- the Concession, its terms are copied below
+    ARID BIGINT NOT NULL DEFAULT 0,                             -- account rule of the concession expense
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount off each rent charge, if Pct is 0
+    Pct DECIMAL(19,4) NOT NULL DEFAULT 0.0,                     -- percent off each rent charge, 0 - 100
+    Cycles BIGINT NOT NULL DEFAULT 0,                           -- number of rent charges it applies to
+    Applied BIGINT NOT NULL DEFAULT 0,                          -- number of rent charges it has been applied to
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- applies to rent charges on or after this date
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- reserved
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RACID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****       UTILITY BILLING        ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE UtilityBill (
+    UBID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Utility VARCHAR(50) NOT NULL DEFAULT '',                    -- ex: Water, Trash
+    ARID BIGINT NOT NULL DEFAULT 0,                             -- account rule of the resident charges
+    Method BIGINT NOT NULL DEFAULT 0,                           -- 1 = square feet, 2 = occupants, 3 = equal split, 4 = sub-meter
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount of the master bill
+    CommonPct DECIMAL(19,4) NOT NULL DEFAULT 0.0,               -- percent of the bill for common areas, not billed back
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- start of the billing period
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- end of the billing period, not included
+    DtPosted DATE NOT NULL DEFAULT '1970-01-01 00:00:00',       -- date of the resident charges
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 posted, the resident charges have been assessed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (UBID)
+);
+
+CREATE TABLE UtilityCharge (
+    UCID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    UBID BIGINT NOT NULL DEFAULT 0,                             -- the utility bill
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement charged
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable
+    DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',        -- start of the Rental Agreement's part of the period
+    DtStop DATE NOT NULL DEFAULT '1970-01-01 00:00:00',         -- end of its part, not included
+    Days BIGINT NOT NULL DEFAULT 0,                             -- days in its part of the period
+    Sqft BIGINT NOT NULL DEFAULT 0,                             -- square feet of the Rentable
+    Occupants BIGINT NOT NULL DEFAULT 0,                        -- people using the Rentable
+    MeterUsage DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- sub-meter usage in its part of the period
+    Ratio DECIMAL(19,4) NOT NULL DEFAULT 0.0,                   -- fixed ratio of the Rentable on the bill
+    Share DECIMAL(19,8) NOT NULL DEFAULT 0.0,                   -- its share of the bill after the common area part, 0 - 1
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount charged
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- the assessment once posted
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (UCID)
+);
+
+CREATE TABLE UtilityRatio (
+    URID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    UBID BIGINT NOT NULL DEFAULT 0,                             -- the utility bill
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable
+    Ratio DECIMAL(19,4) NOT NULL DEFAULT 0.0,                   -- its fixed ratio of the bill, ex: 40 of 40/35/25
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (URID)
+);
+
+CREATE TABLE MeterReading (
+    MRID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RID BIGINT NOT NULL DEFAULT 0,                              -- the Rentable metered
+    Utility VARCHAR(50) NOT NULL DEFAULT '',                    -- ex: Water
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date read
+    Reading DECIMAL(19,4) NOT NULL DEFAULT 0.0,                 -- meter reading
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (MRID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****   SCHEDULED ASSESSMENT STEPS ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE AssessmentStep (
+    ASTID BIGINT NOT NULL AUTO_INCREMENT,                       -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    RAID BIGINT NOT NULL DEFAULT 0,                             -- Rental Agreement of the assessment
+    ASMID BIGINT NOT NULL DEFAULT 0,                            -- recurring assessment definition the step changes
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date the change takes effect
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- new amount, or 0 if Pct is used
+    Pct DECIMAL(19,4) NOT NULL DEFAULT 0.0,                     -- percent escalation of the amount in force
+    NewASMID BIGINT NOT NULL DEFAULT 0,                         -- recurring assessment definition created by the step
+    DtApplied DATE NOT NULL DEFAULT '1970-01-01 00:00:00',      -- date the step was applied
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                   -- reason for the change
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 applied, 1<<1 repeats each anniversary, 1<<2 skipped, the assessment ended first
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (ASTID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****       PAYMENT GATEWAY        ****
+-- ****                              ****
+-- **************************************
+CREATE TABLE PaymentGateway (
+    PGID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    Name VARCHAR(100) NOT NULL DEFAULT '',                      -- ex: Card payments
+    URL VARCHAR(1024) NOT NULL DEFAULT '',                      -- base URL of the gateway REST API
+    APIKey VARCHAR(256) NOT NULL DEFAULT '',                    -- secret key for the gateway API
+    PMTID BIGINT NOT NULL DEFAULT 0,                            -- payment type of the receipts
+    DEPID BIGINT NOT NULL DEFAULT 0,                            -- depository the gateway pays out to
+    ARID BIGINT NOT NULL DEFAULT 0,                             -- account rule of the receipts
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 inactive
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (PGID)
+);
+
+CREATE TABLE CardToken (
+    CTID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the card holder
+    PGID BIGINT NOT NULL DEFAULT 0,                             -- gateway that issued the token
+    Token VARCHAR(100) NOT NULL DEFAULT '',                     -- gateway token, the card number is never stored
+    Brand VARCHAR(20) NOT NULL DEFAULT '',                      -- ex: Visa
+    Last4 VARCHAR(4) NOT NULL DEFAULT '',                       -- last 4 digits of the card number
+    ExpMonth BIGINT NOT NULL DEFAULT 0,                         -- expiration month, 1 - 12
+    ExpYear BIGINT NOT NULL DEFAULT 0,                          -- expiration year, ex: 2021
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- 1<<0 removed
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (CTID)
+);
+
+CREATE TABLE GatewayTransaction (
+    GTID BIGINT NOT NULL AUTO_INCREMENT,                        -- unique id
+    BID BIGINT NOT NULL DEFAULT 0,                              -- Business id
+    PGID BIGINT NOT NULL DEFAULT 0,                             -- the gateway
+    TCID BIGINT NOT NULL DEFAULT 0,                             -- the payor
+    CTID BIGINT NOT NULL DEFAULT 0,                             -- card charged
+    Type BIGINT NOT NULL DEFAULT 0,                             -- 1 = charge, 2 = refund, 3 = chargeback
+    ParentGTID BIGINT NOT NULL DEFAULT 0,                       -- the charge refunded or charged back
+    TxnID VARCHAR(100) NOT NULL DEFAULT '',                     -- gateway transaction id
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- amount, always positive
+    Dt DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date of the transaction
+    Status BIGINT NOT NULL DEFAULT 0,                           -- 1 = succeeded, 2 = failed, 3 = pending
+    Message VARCHAR(256) NOT NULL DEFAULT '',                   -- reason the gateway gave for a failure
+    RCPTID BIGINT NOT NULL DEFAULT 0,                           -- receipt of a charge, receipt reversed by a refund or chargeback
+    PayoutID VARCHAR(100) NOT NULL DEFAULT '',                  -- gateway payout that settled the transaction
+    DID BIGINT NOT NULL DEFAULT 0,                              -- Deposit the payout was matched to
+    LastModTime TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,  -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,      -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                         -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (GTID)
+);
@@ -51,6 +51,7 @@ var App struct {
 	SkipVacCheck bool     // until the code is modified to process on each command entered, if set to false, this inibits batch processing to do vacancy calc.
 	NoAuth       bool     // if true then skip authentication
 	DisableTWS   bool     // if true then don't initialize tws
+	SessionDB    bool     // if true then keep sessions in the database so they can be shared by multiple servers
 	CSVLoad      string   // if loading csv, this string will have index,filename
 	sStart       string   // start time
 	sStop        string   // stop time
@@ -84,6 +85,7 @@ func readCommandLineArgs() {
 	noconPtr := flag.Bool("nocon", false, "if specified, inhibit Console output")
 	noauth := flag.Bool("noauth", false, "if specified, inhibit authentication")
 	notws := flag.Bool("notws", false, "if specified, do not run tws")
+	sessdb := flag.Bool("sessdb", false, "if specified, keep sessions in the database so they can be shared by multiple servers")
 	confPtr := flag.String("confdir", "", "override config.json directory path")
 	rsd := flag.String("rsd", "./", "Root Static Directory path") // it will pick static content from provided path, default will be current directory
 
@@ -115,12 +117,13 @@ func readCommandLineArgs() {
 	App.RootStaticDir = *rsd
 	App.NoAuth = *noauth
 	App.DisableTWS = *notws
+	App.SessionDB = *sessdb
 	App.ConfigPath = *confPtr
 }
 
 func intTest(ctx context.Context, xbiz *rlib.XBusiness, d1, d2 *time.Time) {
 	fmt.Printf("INTERNAL TEST\n")
-	m, _ := rlib.ParseAcctRule(ctx, xbiz, 1, d1, d2, "d ${GLGENRCV} 1000.0, c 40001 ${UMR}, d 41004 ${UMR} ${aval(${GLGENRCV})} -", float64(1000), float64(8)/float64(30))
+	m, _ := rlib.ParseAcctRule(ctx, xbiz, 1, d1, d2, "d ${GLGENRCV} 1000.0, c 40001 ${UMR}, d 41004 ${UMR} ${aval(${GLGENRCV})} -", rlib.Money(100000), float64(8)/float64(30))
 
 	for i := 0; i < len(m); i++ {
 		fmt.Printf("m[%d] = %#v\n", i, m[i])
@@ -152,6 +155,7 @@ func initHTTP() {
 	http.HandleFunc("/home/", HomeUIHandler)
 	http.HandleFunc("/rhome/", RHomeUIHandler) // special purpose, receipt-only version of roller
 	http.HandleFunc("/v1/", ws.V1ServiceHandler)
+	http.HandleFunc("/metrics", ws.MetricsHandler)
 	// http.HandleFunc("/wsvc/", ReportServiceHandler)
 }
 
@@ -206,6 +210,9 @@ func main() {
 	}
 
 	rlib.InitDBHelpers(App.dbrr, App.dbdir)
+	if App.SessionDB {
+		rlib.SetSessionStore(rlib.NewSQLSessionStore())
+	}
 	rlib.SessionInit(10) // must be called before calling InitBizInternals
 	initRentRoll()
 	ws.SvcInit(App.NoAuth) // currently needed for testing
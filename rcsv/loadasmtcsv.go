@@ -181,7 +181,7 @@ func CreateAssessmentsFromCSV(ctx context.Context, sa []string, lineno int) (int
 	//-------------------------------------------------------------------
 	// Determine the amount
 	//-------------------------------------------------------------------
-	a.Amount, _ = rlib.FloatFromString(sa[Amount], "Amount is invalid")
+	a.Amount, _ = rlib.ParseMoney(sa[Amount])
 
 	//-------------------------------------------------------------------
 	// Accrual
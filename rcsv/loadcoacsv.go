@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"rentroll/rlib"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -147,10 +146,10 @@ func CreateLedgerMarkers(ctx context.Context, sa []string, lineno int) (int, err
 	//----------------------------------------------------------------------
 	// OPENING BALANCE
 	//----------------------------------------------------------------------
-	lm.Balance = float64(0) // assume a 0 starting balance
+	lm.Balance = rlib.Money(0) // assume a 0 starting balance
 	g = strings.TrimSpace(sa[Balance])
 	if len(g) > 0 {
-		x, err := strconv.ParseFloat(g, 64)
+		x, err := rlib.ParseMoney(g)
 		if err != nil {
 			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Invalid balance: %s", funcname, lineno, sa[Balance])
 		}
@@ -93,7 +93,7 @@ func CreateDepositsFromCSV(ctx context.Context, sa []string, lineno int) (int, e
 	//-------------------------------------------------------------------
 	var rcpts []int64
 	var mm []rlib.Receipt
-	var tot = float64(0)
+	var tot = rlib.Money(0)
 
 	s := strings.TrimSpace(sa[ReceiptSpec])
 	ssa := strings.Split(s, ",")
@@ -97,7 +97,7 @@ func CreateInvoicesFromCSV(ctx context.Context, sa []string, lineno int) (int, e
 	//-------------------------------------------------------------------
 	var asmts []int64
 	var mm []rlib.Assessment
-	var tot = float64(0)
+	var tot = rlib.Money(0)
 
 	s := strings.TrimSpace(sa[AssessmentSpec])
 	ssa := strings.Split(s, ",")
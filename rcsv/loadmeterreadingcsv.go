@@ -0,0 +1,96 @@
+package rcsv
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+)
+
+// 0    1          2        3           4
+// BUD, RID,       Utility, Dt,         Reading
+// REX, R00000001, Water,   2018-01-01, 10432.5
+// REX, R00000001, Water,   2018-02-01, 10611.0
+
+// CreateMeterReadingFromCSV reads a rentable's sub-meter reading for a
+// utility. The usage billed for a period is the last reading in the period
+// less the first one.
+func CreateMeterReadingFromCSV(ctx context.Context, sa []string, lineno int) (int, error) {
+	const funcname = "CreateMeterReadingFromCSV"
+	var (
+		err    error
+		mr     rlib.MeterReading
+		errmsg string
+	)
+
+	const (
+		BUD     = 0
+		RID     = iota
+		Utility = iota
+		Dt      = iota
+		Reading = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"RID", RID},
+		{"Utility", Utility},
+		{"Dt", Dt},
+		{"Reading", Reading},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	//-------------------------------------------------------------------
+	// BUD
+	//-------------------------------------------------------------------
+	cmpdes := strings.TrimSpace(sa[BUD])
+	if len(cmpdes) > 0 {
+		b2, err := rlib.GetBusinessByDesignation(ctx, cmpdes)
+		if err != nil {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d, error while getting business by designation(%s): %s", funcname, lineno, cmpdes, err.Error())
+		}
+		if b2.BID == 0 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - could not find rlib.Business named %s", funcname, lineno, cmpdes)
+		}
+		mr.BID = b2.BID
+	}
+
+	mr.RID = CSVLoaderGetRID(sa[RID])
+	if mr.RID == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid Rentable:  %s", funcname, lineno, sa[RID])
+	}
+	mr.Utility = strings.TrimSpace(sa[Utility])
+
+	if mr.Dt, err = rlib.StringToDate(sa[Dt]); err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid date:  %s", funcname, lineno, sa[Dt])
+	}
+
+	mr.Reading, errmsg = rlib.FloatFromString(sa[Reading], "Reading is invalid")
+	if len(errmsg) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Reading is invalid: %s  (%s)", funcname, lineno, sa[Reading], errmsg)
+	}
+
+	errlist := bizlogic.SaveMeterReading(ctx, &mr)
+	if len(errlist) > 0 {
+		srr := ""
+		for i := 0; i < len(errlist); i++ {
+			srr += errlist[i].Message + "\n"
+		}
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d -  error saving meter reading: %s", funcname, lineno, srr)
+	}
+	return 0, nil
+}
+
+// LoadMeterReadingsCSV loads a csv file with sub-meter readings
+func LoadMeterReadingsCSV(ctx context.Context, fname string) []error {
+	return LoadRentRollCSV(ctx, fname, CreateMeterReadingFromCSV)
+}
@@ -230,7 +230,7 @@ func CreateRentalAgreement(ctx context.Context, sa []string, lineno int) (int, e
 			if err != nil {
 				return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Could not load rentable named: %s  err = %s", funcname, lineno, sss[0], err.Error())
 			}
-			x, err := strconv.ParseFloat(strings.TrimSpace(sss[1]), 64)
+			x, err := rlib.ParseMoney(strings.TrimSpace(sss[1]))
 			if err != nil {
 				return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Invalid amount:  %s", funcname, lineno, sss[1])
 			}
@@ -315,7 +315,7 @@ func CreateRentalAgreement(ctx context.Context, sa []string, lineno int) (int, e
 			RAID:    RAID,
 			RID:     m[i].RID,
 			Dt:      m[i].RARDtStart,
-			Balance: float64(0),
+			Balance: rlib.Money(0),
 			State:   rlib.LMINITIAL,
 		}
 		_, err = rlib.InsertLedgerMarker(ctx, &rlm)
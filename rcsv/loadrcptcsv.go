@@ -212,7 +212,7 @@ func CreateReceiptsFromCSV(ctx context.Context, sa []string, lineno int) (int, e
 	//-------------------------------------------------------------------
 	// Determine the amount
 	//-------------------------------------------------------------------
-	r.Amount, _ = rlib.FloatFromString(sa[Amount], "rlib.Receipt Amount is invalid")
+	r.Amount, _ = rlib.ParseMoney(sa[Amount])
 
 	//-------------------------------------------------------------------
 	// Set the ARID
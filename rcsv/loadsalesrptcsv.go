@@ -0,0 +1,104 @@
+package rcsv
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+)
+
+// 0    1           2           3           4           5
+// BUD, RAID,       DtStart,    DtStop,     GrossSales, Comment
+// REX, RA00000012, 2018-01-01, 2018-02-01, 48210.33,   January
+// REX, RA00000012, 2018-02-01, 2018-03-01, 51877.00,
+
+// CreateSalesReportFromCSV reads a tenant's gross sales for a period and
+// creates a SalesReport. DtStop is the end of the period and is not
+// included. The percentage rent is computed by the PctRentBot once the
+// period has ended.
+func CreateSalesReportFromCSV(ctx context.Context, sa []string, lineno int) (int, error) {
+	const funcname = "CreateSalesReportFromCSV"
+	var (
+		err error
+		sr  rlib.SalesReport
+	)
+
+	const (
+		BUD        = 0
+		RAID       = iota
+		DtStart    = iota
+		DtStop     = iota
+		GrossSales = iota
+		Comment    = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"RAID", RAID},
+		{"DtStart", DtStart},
+		{"DtStop", DtStop},
+		{"GrossSales", GrossSales},
+		{"Comment", Comment},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	//-------------------------------------------------------------------
+	// BUD
+	//-------------------------------------------------------------------
+	cmpdes := strings.TrimSpace(sa[BUD])
+	if len(cmpdes) > 0 {
+		b2, err := rlib.GetBusinessByDesignation(ctx, cmpdes)
+		if err != nil {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d, error while getting business by designation(%s): %s", funcname, lineno, cmpdes, err.Error())
+		}
+		if b2.BID == 0 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - could not find rlib.Business named %s", funcname, lineno, cmpdes)
+		}
+		sr.BID = b2.BID
+	}
+
+	sr.RAID = CSVLoaderGetRAID(sa[RAID])
+	if sr.RAID == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid Rental Agreement:  %s", funcname, lineno, sa[RAID])
+	}
+
+	//-------------------------------------------------------------------
+	// Get the dates
+	//-------------------------------------------------------------------
+	if sr.DtStart, err = rlib.StringToDate(sa[DtStart]); err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid start date:  %s", funcname, lineno, sa[DtStart])
+	}
+	if sr.DtStop, err = rlib.StringToDate(sa[DtStop]); err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid stop date:  %s", funcname, lineno, sa[DtStop])
+	}
+
+	if sr.GrossSales, err = rlib.ParseMoney(sa[GrossSales]); err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - GrossSales is invalid: %s  (%s)", funcname, lineno, sa[GrossSales], err.Error())
+	}
+	sr.Comment = strings.TrimSpace(sa[Comment])
+
+	errlist := bizlogic.SaveSalesReport(ctx, &sr)
+	if len(errlist) > 0 {
+		srr := ""
+		for i := 0; i < len(errlist); i++ {
+			srr += errlist[i].Message + "\n"
+		}
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d -  error saving sales report: %s", funcname, lineno, srr)
+	}
+	return 0, nil
+}
+
+// LoadSalesReportsCSV loads a csv file with the gross sales reported by
+// tenants that pay percentage rent
+func LoadSalesReportsCSV(ctx context.Context, fname string) []error {
+	return LoadRentRollCSV(ctx, fname, CreateSalesReportFromCSV)
+}
@@ -65,6 +65,7 @@ func getCachedGLAcctSliceEntry(bid int64, accttype string) *GLAcctSliceCacheEntr
 	b, ok := glacctcache[k]
 	if ok {
 		if b == nil {
+			GLAcctCacheCtx.miss()
 			return nil
 		}
 		t := time.Now().Add(GLAcctCacheCtx.Expiry) // it's life gets extended
@@ -74,8 +75,10 @@ func getCachedGLAcctSliceEntry(bid int64, accttype string) *GLAcctSliceCacheEntr
 		GLAcctCacheCtx.SemAck <- 1                 // tell the controller we're done
 
 		// Console("Expire updated: %s,  key = %s\n", t.Format(RRDATETIMEINPFMT), k)
+		GLAcctCacheCtx.hit()
 		return b
 	}
+	GLAcctCacheCtx.miss()
 	return nil
 }
 
@@ -101,8 +104,11 @@ func storeGLAcctSliceToCache(bid int64, accttype string, m []int64) {
 	k := getGLAcctCacheKey(bid, accttype)
 	// Console("Expire: %s,  key: %s\n", t.Format(RRDATETIMEINPFMT), k)
 
-	GLAcctCacheCtx.Sem <- 1    // request write access
-	<-GLAcctCacheCtx.SemAck    // pause until we get access
+	GLAcctCacheCtx.Sem <- 1 // request write access
+	<-GLAcctCacheCtx.SemAck // pause until we get access
+	if glacctcache[k] == nil {
+		GLAcctCacheCtx.added()
+	}
 	glacctcache[k] = &b        // <<<<<<<<<<<<<<<    do the cache update
 	GLAcctCacheCtx.SemAck <- 1 // tell the controller we're done
 }
@@ -127,8 +133,11 @@ func CleanGLAcctSliceCache(force bool) {
 			continue
 		}
 		if force || now.After(*v.expire) {
-			GLAcctCacheCtx.Sem <- 1    // request write access
-			<-GLAcctCacheCtx.SemAck    // pause until we get access
+			GLAcctCacheCtx.Sem <- 1 // request write access
+			<-GLAcctCacheCtx.SemAck // pause until we get access
+			if glacctcache[k] != nil {
+				GLAcctCacheCtx.removed()
+			}
 			glacctcache[k] = nil       // <<<<<<<<<<<<<<<  do the cache update
 			GLAcctCacheCtx.SemAck <- 1 // tell the controller we're done
 		}
@@ -9,13 +9,13 @@ import (
 
 // AcctRule is a structure of the 3-tuple that makes up a whole part of an AcctRule
 type AcctRule struct {
-	Action      string  // "d" = debit, "c" = credit
-	Account     string  // GL No for the account
-	AccountOrig string  // account before substitution
-	Amount      float64 // use the entire amount of the assessment or deposit, otherwise the amount to use
-	ASMID       int64   // Used only for ReceiptAllocation; the assessment that caused this payment
-	Expr        string  // the formula of the Amount
-	AcctExpr    string  // the input Acct Expression -- may be the same as the GLNo or may be a ${ref}
+	Action      string // "d" = debit, "c" = credit
+	Account     string // GL No for the account
+	AccountOrig string // account before substitution
+	Amount      Money  // use the entire amount of the assessment or deposit, otherwise the amount to use
+	ASMID       int64  // Used only for ReceiptAllocation; the assessment that caused this payment
+	Expr        string // the formula of the Amount
+	AcctExpr    string // the input Acct Expression -- may be the same as the GLNo or may be a ${ref}
 }
 
 // VarAcctResolve replaces string references with the appropriate values for variable account names
@@ -78,8 +78,8 @@ func ParseSimpleAcctRule(s string) []AcctRule {
 	for i := 0; i < len(sa); i++ {
 		p := strings.Split(strings.TrimSpace(sa[i]), " ")
 		var a AcctRule
-		x, ok := StringToFloat64(p[2])
-		if !ok {
+		x, err := ParseMoney(p[2])
+		if err != nil {
 			continue
 		}
 		a.Amount = x
@@ -102,7 +102,25 @@ func ParseSimpleAcctRule(s string) []AcctRule {
 //
 // RETURNS:
 //     a slice of AcctRule structs that make up the account rule
-func ParseAcctRule(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time.Time, rule string, amount, pf float64) ([]AcctRule, error) {
+func ParseAcctRule(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time.Time, rule string, amount Money, pf float64) ([]AcctRule, error) {
+	return ParseAcctRuleForRA(ctx, xbiz, rid, 0, d1, d2, rule, amount, pf)
+}
+
+// ParseAcctRuleForRA is ParseAcctRule for a rule evaluated on behalf of a
+// Rental Agreement. ${CONTRACTRENT} is the rent of the Rentable in that
+// Rental Agreement.
+// INPUTS:
+//     xbiz - XBusiness struct for this business
+//      rid - the associated Rentable ID (if needed)
+//     raid - the associated Rental Agreement ID, 0 if not known
+//    d1,d2 - time period being examined
+//     rule - the actual account rule to parse
+//   amount - total amount of this transaction
+//       pf - the proration factor
+//
+// RETURNS:
+//     a slice of AcctRule structs that make up the account rule
+func ParseAcctRuleForRA(ctx context.Context, xbiz *XBusiness, rid, raid int64, d1, d2 *time.Time, rule string, amount Money, pf float64) ([]AcctRule, error) {
 	const funcname = "ParseAcctRule"
 	var (
 		m   []AcctRule
@@ -110,6 +128,7 @@ func ParseAcctRule(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time
 	)
 	// fmt.Printf("%s:  rid = %d, d1 = %s, d2 = %s, rule = %s, amount = %f, pf = %f, xbiz.P.BID = %d\n", funcname, rid, d1.Format(RRDATEFMT4), d2.Format(RRDATEFMT4), rule, amount, pf, xbiz.P.BID)
 	rpnCtx := RpnCreateCtx(xbiz, rid, d1, d2, &m, amount, pf)
+	rpnCtx.raid = raid
 	// fmt.Printf("rpnCtx.Amount = %f\n", rpnCtx.amount)
 	if len(rule) > 0 {
 		sa := strings.Split(rule, ",")
@@ -4,6 +4,7 @@ import "strings"
 
 const (
 	acctsRcv = string("accounts receivable")
+	acctsPay = string("accounts payable")
 	secDep   = string("security deposit")
 )
 
@@ -29,3 +30,9 @@ func GetReceivableAccounts(bid int64) []int64 {
 func GetSecurityDepositsAccounts(bid int64) []int64 {
 	return getAccounts(bid, secDep)
 }
+
+// GetPayableAccounts goes throughout the GLAccounts and returns
+// an array of LIDs which are of type Payables
+func GetPayableAccounts(bid int64) []int64 {
+	return getAccounts(bid, acctsPay)
+}
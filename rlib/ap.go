@@ -0,0 +1,84 @@
+package rlib
+
+import "time"
+
+// VENDORInactive et al are the Vendor FLAGS
+const (
+	VENDORInactive = 1 << 0 // vendor is no longer used
+	VENDOR1099     = 1 << 1 // payments to the vendor are reported on a 1099
+)
+
+// BILLVoid et al are the Bill and VendorPayment FLAGS
+const (
+	BILLVoid = 1 << 0 // the bill has been voided and its journal entry reversed
+	VPMTVoid = 1 << 0 // the payment has been voided and its journal entry reversed
+)
+
+// VPMTMETHODcheck et al are the ways a VendorPayment can be made
+const (
+	VPMTMETHODcheck = 1
+	VPMTMETHODach   = 2
+)
+
+// Vendor1099Threshold is the total a vendor must be paid in a year before
+// the payments must be reported on a 1099
+const Vendor1099Threshold = Money(60000)
+
+// VPMTMethodNames are the readable names of the VendorPayment methods,
+// indexed by method
+var VPMTMethodNames = []string{"", "Check", "ACH"}
+
+// APAgingDays are the upper limits, in days past due, of the AP aging
+// buckets. The first bucket is for bills that are not yet past due, anything
+// older than the last limit falls into a last bucket.
+var APAgingDays = []int64{0, 30, 60, 90}
+
+// APAgingNames are the readable names of the AP aging buckets
+var APAgingNames = []string{"Current", "1-30", "31-60", "61-90", "Over 90"}
+
+// Vendor1099 is the total paid to a Vendor in a year
+type Vendor1099 struct {
+	VID   int64
+	Name  string
+	TaxID string
+	Total Money
+}
+
+// BillDueDate returns the date a bill dated dt is due under terms of terms
+// days
+//-----------------------------------------------------------------------------
+func BillDueDate(dt *time.Time, terms int64) time.Time {
+	return dt.AddDate(0, 0, int(terms))
+}
+
+// BillDaysPastDue returns the number of whole days bill b is past due as of
+// dt. It is 0 or negative if the bill is not yet due.
+//-----------------------------------------------------------------------------
+func BillDaysPastDue(b *Bill, dt *time.Time) int64 {
+	d1 := time.Date(b.DtDue.Year(), b.DtDue.Month(), b.DtDue.Day(), 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(dt.Year(), dt.Month(), dt.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(d2.Sub(d1) / (24 * time.Hour))
+}
+
+// APAgingBucket returns the index of the AP aging bucket for a bill that is
+// days days past due. The last index, len(APAgingDays), is for anything
+// older than the last limit.
+//-----------------------------------------------------------------------------
+func APAgingBucket(days int64) int {
+	for i := 0; i < len(APAgingDays); i++ {
+		if days <= APAgingDays[i] {
+			return i
+		}
+	}
+	return len(APAgingDays)
+}
+
+// VendorPaymentMethodName returns the readable name of VendorPayment method
+// m
+//-----------------------------------------------------------------------------
+func VendorPaymentMethodName(m int64) string {
+	if m < VPMTMETHODcheck || m >= int64(len(VPMTMethodNames)) {
+		return "unknown"
+	}
+	return VPMTMethodNames[m]
+}
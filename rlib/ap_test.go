@@ -0,0 +1,56 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Accounts payable aging tests.
+
+func TestAPAgingBucket(t *testing.T) {
+	dt := time.Date(2018, time.June, 15, 17, 30, 0, 0, time.UTC)
+	var m = []struct {
+		due    time.Time
+		days   int64
+		bucket int
+	}{
+		{time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), -16, 0},
+		{time.Date(2018, time.June, 15, 0, 0, 0, 0, time.UTC), 0, 0},
+		{time.Date(2018, time.June, 14, 0, 0, 0, 0, time.UTC), 1, 1},
+		{time.Date(2018, time.May, 16, 0, 0, 0, 0, time.UTC), 30, 1},
+		{time.Date(2018, time.May, 15, 0, 0, 0, 0, time.UTC), 31, 2},
+		{time.Date(2018, time.April, 16, 0, 0, 0, 0, time.UTC), 60, 2},
+		{time.Date(2018, time.March, 17, 0, 0, 0, 0, time.UTC), 90, 3},
+		{time.Date(2018, time.March, 16, 0, 0, 0, 0, time.UTC), 91, 4},
+	}
+	for i := 0; i < len(m); i++ {
+		b := Bill{DtDue: m[i].due}
+		days := BillDaysPastDue(&b, &dt)
+		if days != m[i].days {
+			t.Errorf("%d: BillDaysPastDue expect %d, got %d\n", i, m[i].days, days)
+		}
+		if k := APAgingBucket(days); k != m[i].bucket {
+			t.Errorf("%d: APAgingBucket( %d ) expect %d (%s), got %d\n", i, days, m[i].bucket, APAgingNames[m[i].bucket], k)
+		}
+	}
+	if len(APAgingNames) != len(APAgingDays)+1 {
+		t.Errorf("APAgingNames has %d names for %d buckets\n", len(APAgingNames), len(APAgingDays)+1)
+	}
+}
+
+func TestBillDueDate(t *testing.T) {
+	dt := time.Date(2018, time.January, 31, 0, 0, 0, 0, time.UTC)
+	var m = []struct {
+		terms  int64
+		expect time.Time
+	}{
+		{0, time.Date(2018, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{10, time.Date(2018, time.February, 10, 0, 0, 0, 0, time.UTC)},
+		{30, time.Date(2018, time.March, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for i := 0; i < len(m); i++ {
+		if d := BillDueDate(&dt, m[i].terms); !d.Equal(m[i].expect) {
+			t.Errorf("%d: BillDueDate( %d ) expect %s, got %s\n", i, m[i].terms, m[i].expect.Format(RRDATEFMT4), d.Format(RRDATEFMT4))
+		}
+	}
+}
@@ -66,6 +66,7 @@ func getCachedARSliceEntry(bid int64, accttype string) *ARSliceCacheEntry {
 	b, ok := arcache[k]
 	if ok {
 		if b == nil {
+			ARCacheCtx.miss()
 			return nil
 		}
 		t := time.Now().Add(ARCacheCtx.Expiry) // it's life gets extended
@@ -75,8 +76,10 @@ func getCachedARSliceEntry(bid int64, accttype string) *ARSliceCacheEntry {
 		ARCacheCtx.SemAck <- 1                 // tell the controller we're done
 
 		// Console("Expire updated: %s,  key = %s\n", t.Format(RRDATETIMEINPFMT), k)
+		ARCacheCtx.hit()
 		return b
 	}
+	ARCacheCtx.miss()
 	return nil
 }
 
@@ -102,8 +105,11 @@ func storeARSliceToCache(bid int64, accttype string, m []int64) {
 	k := getARCacheKey(bid, accttype)
 	// Console("Expire: %s,  key: %s\n", t.Format(RRDATETIMEINPFMT), k)
 
-	ARCacheCtx.Sem <- 1    // request write access
-	<-ARCacheCtx.SemAck    // pause until we get access
+	ARCacheCtx.Sem <- 1 // request write access
+	<-ARCacheCtx.SemAck // pause until we get access
+	if arcache[k] == nil {
+		ARCacheCtx.added()
+	}
 	arcache[k] = &b        // <<<<<<<<<<<<<<<    do the cache update
 	ARCacheCtx.SemAck <- 1 // tell the controller we're done
 }
@@ -127,8 +133,11 @@ func CleanARSliceCache(force bool) {
 			continue
 		}
 		if force || now.After(*v.expire) {
-			ARCacheCtx.Sem <- 1    // request write access
-			<-ARCacheCtx.SemAck    // pause until we get access
+			ARCacheCtx.Sem <- 1 // request write access
+			<-ARCacheCtx.SemAck // pause until we get access
+			if arcache[k] != nil {
+				ARCacheCtx.removed()
+			}
 			arcache[k] = nil       // <<<<<<<<<<<<<<<  do the cache update
 			ARCacheCtx.SemAck <- 1 // tell the controller we're done
 		}
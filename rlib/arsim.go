@@ -0,0 +1,118 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AcctRuleSimLine is one debit or credit of a simulated account rule
+type AcctRuleSimLine struct {
+	Action   string // "d" = debit, "c" = credit
+	GLNumber string // the account
+	Name     string // name of the account, empty if the business has no such account
+	Expr     string // the formula of the amount
+	Amount   Money  // the amount the formula computes
+}
+
+// AcctRuleSim is the result of evaluating an account rule without posting
+// anything
+type AcctRuleSim struct {
+	Rule     string            // the rule that was evaluated
+	Lines    []AcctRuleSimLine // its debits and credits
+	Debits   Money             // total of the debits
+	Credits  Money             // total of the credits
+	Problems []string          // what would be wrong with the journal entry
+}
+
+// SimulateAcctRule evaluates an account rule the way a journal entry would
+// and returns the debits and credits it makes. Nothing is written to the
+// database. Use it to check a new rule or formula before it is used.
+//
+// INPUTS
+//  ctx    = db context
+//  xbiz   = the business
+//  arid   = the AR whose rule is evaluated, used only if rule is empty
+//  rule   = an ad hoc account rule, ex: "d 12000 _, c 40001 ${SQFT} 1.25 *"
+//  rid    = the Rentable, 0 if none
+//  raid   = the Rental Agreement, 0 if none
+//  d1, d2 = the time range
+//  amount = the amount of the transaction, the value of _
+//  pf     = the proration factor, 1.0 for a whole period
+//
+// RETURNS
+//  the simulation
+//  any error evaluating the rule
+//-----------------------------------------------------------------------------
+func SimulateAcctRule(ctx context.Context, xbiz *XBusiness, arid int64, rule string, rid, raid int64, d1, d2 *time.Time, amount Money, pf float64) (AcctRuleSim, error) {
+	var sim AcctRuleSim
+	var err error
+
+	if len(strings.TrimSpace(rule)) == 0 {
+		ar, err := GetAR(ctx, arid)
+		if err != nil {
+			return sim, err
+		}
+		if ar.ARID == 0 || ar.BID != xbiz.P.BID {
+			return sim, fmt.Errorf("account rule %d does not exist in business %d", arid, xbiz.P.BID)
+		}
+		if rule, err = buildRule(ctx, arid); err != nil {
+			return sim, err
+		}
+	}
+	sim.Rule = rule
+
+	m, err := ParseAcctRuleForRA(ctx, xbiz, rid, raid, d1, d2, rule, amount, pf)
+	if err != nil {
+		return sim, err
+	}
+	if pf < 1.0 {
+		balanceAllocations(m) // as journalAssessment does
+	}
+	for i := 0; i < len(m); i++ {
+		l := AcctRuleSimLine{Action: m[i].Action, GLNumber: m[i].Account, Expr: m[i].Expr, Amount: m[i].Amount}
+		gl, err := GetLedgerByGLNo(ctx, xbiz.P.BID, m[i].Account)
+		if err != nil {
+			return sim, err
+		}
+		if gl.LID > 0 {
+			l.Name = gl.Name
+			if !gl.AllowPost {
+				sim.Problems = append(sim.Problems, fmt.Sprintf("account %s does not allow posting", l.GLNumber))
+			}
+		} else {
+			sim.Problems = append(sim.Problems, fmt.Sprintf("account %s does not exist", l.GLNumber))
+		}
+		sim.Lines = append(sim.Lines, l)
+	}
+	simTotals(&sim, len(strings.Split(rule, ",")))
+	return sim, err
+}
+
+// simTotals adds up the debits and credits of sim and notes any problem
+// with them
+//
+// INPUTS
+//  sim   = the simulation, with its Lines
+//  parts = the number of comma separated parts of the rule
+//-----------------------------------------------------------------------------
+func simTotals(sim *AcctRuleSim, parts int) {
+	sim.Debits, sim.Credits = 0, 0
+	if n := parts - len(sim.Lines); n > 0 {
+		sim.Problems = append(sim.Problems, fmt.Sprintf("%d part(s) of the rule were ignored, each needs an action, an account and an amount", n))
+	}
+	for i, l := range sim.Lines {
+		switch l.Action {
+		case "d":
+			sim.Debits += l.Amount
+		case "c":
+			sim.Credits += l.Amount
+		default:
+			sim.Problems = append(sim.Problems, fmt.Sprintf("line %d: action %q is not d or c", i+1, l.Action))
+		}
+	}
+	if sim.Debits != sim.Credits {
+		sim.Problems = append(sim.Problems, fmt.Sprintf("debits %s do not equal credits %s", sim.Debits, sim.Credits))
+	}
+}
@@ -0,0 +1,34 @@
+package rlib
+
+import "testing"
+
+// Account rule simulator tests.
+
+func TestSimTotals(t *testing.T) {
+	var tests = []struct {
+		lines    []AcctRuleSimLine
+		parts    int
+		debits   Money
+		credits  Money
+		problems int
+	}{
+		// balanced
+		{[]AcctRuleSimLine{{Action: "d", Amount: 100000}, {Action: "c", Amount: 100000}}, 2, 100000, 100000, 0},
+		// a debit split over two credits
+		{[]AcctRuleSimLine{{Action: "d", Amount: 100000}, {Action: "c", Amount: 66667}, {Action: "c", Amount: 33333}}, 3, 100000, 100000, 0},
+		// out of balance
+		{[]AcctRuleSimLine{{Action: "d", Amount: 100000}, {Action: "c", Amount: 99999}}, 2, 100000, 99999, 1},
+		// a part with no amount was skipped
+		{[]AcctRuleSimLine{{Action: "d", Amount: 5000}, {Action: "c", Amount: 5000}}, 3, 5000, 5000, 1},
+		// bad action, which also unbalances it
+		{[]AcctRuleSimLine{{Action: "d", Amount: 5000}, {Action: "x", Amount: 5000}}, 2, 5000, 0, 2},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		sim := AcctRuleSim{Lines: tc.lines}
+		simTotals(&sim, tc.parts)
+		if sim.Debits != tc.debits || sim.Credits != tc.credits || len(sim.Problems) != tc.problems {
+			t.Errorf("test %d: expected %s/%s with %d problems, got %s/%s with %v\n", i, tc.debits, tc.credits, tc.problems, sim.Debits, sim.Credits, sim.Problems)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rlib
+
+import (
+	"sort"
+	"time"
+)
+
+// ASTEPApplied et al are the AssessmentStep FLAGS bits
+//
+//  Applied   the recurring assessment has been split at the step
+//  Annual    an escalation that repeats each anniversary of Dt
+//  Skipped   the assessment ended before the step took effect
+const (
+	ASTEPApplied = 1 << 0
+	ASTEPAnnual  = 1 << 1
+	ASTEPSkipped = 1 << 2
+)
+
+// RentChange is a change of the amount of a recurring assessment, one
+// step or one anniversary of an annual escalation
+type RentChange struct {
+	ASTID int64     // the step
+	Dt    time.Time // date the change takes effect
+	Old   Money     // amount before the change
+	New   Money     // amount after the change
+}
+
+// AssessmentStepAmount returns the amount of a recurring assessment after
+// step s when cur is the amount in force
+//
+// INPUTS
+//  cur = amount in force before the step
+//  s   = the step
+//
+// RETURNS
+//  the new amount
+//-----------------------------------------------------------------------------
+func AssessmentStepAmount(cur Money, s *AssessmentStep) Money {
+	if s.Pct != 0 {
+		return cur + cur.Mul(s.Pct/100)
+	}
+	return s.Amount
+}
+
+// ProjectAssessmentSteps returns the changes the pending steps m of one
+// recurring assessment will make before d2. Annual escalations are repeated
+// on each anniversary. Changes on or after stop, the end of the assessment,
+// never happen.
+//
+// INPUTS
+//  cur  = amount in force now
+//  m    = the pending steps of the assessment
+//  stop = stop date of the assessment
+//  d2   = project the changes before this date
+//
+// RETURNS
+//  the changes sorted by date
+//-----------------------------------------------------------------------------
+func ProjectAssessmentSteps(cur Money, m []AssessmentStep, stop, d2 *time.Time) []RentChange {
+	var c []RentChange
+	q := make([]AssessmentStep, len(m))
+	copy(q, m)
+	for len(q) > 0 {
+		sort.SliceStable(q, func(i, j int) bool { return q[i].Dt.Before(q[j].Dt) })
+		s := q[0]
+		q = q[1:]
+		if !s.Dt.Before(*d2) || !s.Dt.Before(*stop) {
+			break
+		}
+		n := AssessmentStepAmount(cur, &s)
+		c = append(c, RentChange{ASTID: s.ASTID, Dt: s.Dt, Old: cur, New: n})
+		cur = n
+		if s.FLAGS&ASTEPAnnual != 0 {
+			s.Dt = s.Dt.AddDate(1, 0, 0)
+			q = append(q, s)
+		}
+	}
+	return c
+}
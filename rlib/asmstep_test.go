@@ -0,0 +1,46 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Scheduled assessment step tests.
+
+func TestProjectAssessmentSteps(t *testing.T) {
+	d := func(y, m int) time.Time { return time.Date(y, time.Month(m), 1, 0, 0, 0, 0, time.UTC) }
+	var tests = []struct {
+		cur    Money
+		steps  []AssessmentStep
+		stop   time.Time
+		d2     time.Time
+		expect []Money // new amount of each change
+	}{
+		// a new amount
+		{100000, []AssessmentStep{{Dt: d(2019, 1), Amount: 110000}}, d(2030, 1), d(2020, 1), []Money{110000}},
+		// 3% each anniversary for three years
+		{100000, []AssessmentStep{{Dt: d(2019, 1), Pct: 3, FLAGS: ASTEPAnnual}}, d(2030, 1), d(2021, 6), []Money{103000, 106090, 109273}},
+		// a new amount, then escalations from it
+		{100000, []AssessmentStep{{Dt: d(2020, 1), Pct: 5, FLAGS: ASTEPAnnual}, {Dt: d(2019, 6), Amount: 120000}}, d(2030, 1), d(2021, 2), []Money{120000, 126000, 132300}},
+		// the assessment ends first
+		{100000, []AssessmentStep{{Dt: d(2019, 1), Pct: 2, FLAGS: ASTEPAnnual}}, d(2020, 1), d(2025, 1), []Money{102000}},
+		// nothing before d2
+		{100000, []AssessmentStep{{Dt: d(2019, 1), Amount: 90000}}, d(2030, 1), d(2019, 1), []Money{}},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		c := ProjectAssessmentSteps(tc.cur, tc.steps, &tc.stop, &tc.d2)
+		if len(c) != len(tc.expect) {
+			t.Errorf("test %d: expected %d changes, got %d\n", i, len(tc.expect), len(c))
+			continue
+		}
+		for j := 0; j < len(c); j++ {
+			if c[j].New != tc.expect[j] {
+				t.Errorf("test %d, change %d: expected %s, got %s\n", i, j, tc.expect[j], c[j].New)
+			}
+			if j > 0 && c[j].Old != c[j-1].New {
+				t.Errorf("test %d, change %d: old amount %s is not the previous new amount %s\n", i, j, c[j].Old, c[j-1].New)
+			}
+		}
+	}
+}
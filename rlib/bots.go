@@ -13,7 +13,20 @@ const (
 	ARSliceCacheBot   = int64(-6)
 	TLReportBot       = int64(-7)
 	TLInstanceBot     = int64(-8)
-	LastBotUID        = int64(-8) // set this to the uid of the last bot
+	RptSubBot         = int64(-9)
+	WebhookBot        = int64(-10)
+	CloseChkAsmBot    = int64(-11)
+	CloseChkDepBot    = int64(-12)
+	CloseChkUnalBot   = int64(-13)
+	CloseChkTBBot     = int64(-14)
+	CloseChkReconBot  = int64(-15)
+	TenantPortalBot   = int64(-16)
+	PositivePayBot    = int64(-17)
+	PctRentBot        = int64(-18)
+	NightAuditBot     = int64(-19)
+	AsmStepBot        = int64(-20)
+	GatewayBot        = int64(-21)
+	LastBotUID        = int64(-21) // set this to the uid of the last bot
 )
 
 // BotRegistryEntry is a struct to associate a bot's id with its name and
@@ -38,6 +51,19 @@ var BotReg = map[int64]BotRegistryEntry{
 	ARSliceCacheBot:   {ARSliceCacheBot, "ARSliceCacheBot", "Clean Account Rules Slice Cache"},
 	TLReportBot:       {TLReportBot, "TLReportBot", "TaskList Report Bot"},
 	TLInstanceBot:     {TLInstanceBot, "TLInstanceBot", "TaskList Instance Bot"},
+	RptSubBot:         {RptSubBot, "RptSubBot", "Report Subscription Bot"},
+	WebhookBot:        {WebhookBot, "WebhookBot", "Webhook Delivery Bot"},
+	CloseChkAsmBot:    {CloseChkAsmBot, "CloseChkAsmBot", "Close Check: Recurring Assessment Instances"},
+	CloseChkDepBot:    {CloseChkDepBot, "CloseChkDepBot", "Close Check: Undeposited Receipts"},
+	CloseChkUnalBot:   {CloseChkUnalBot, "CloseChkUnallocBot", "Close Check: Unallocated Funds"},
+	CloseChkTBBot:     {CloseChkTBBot, "CloseChkTBBot", "Close Check: Trial Balance"},
+	CloseChkReconBot:  {CloseChkReconBot, "CloseChkReconBot", "Close Check: Depository Reconciliation"},
+	TenantPortalBot:   {TenantPortalBot, "TenantPortalBot", "Tenant Portal"},
+	PositivePayBot:    {PositivePayBot, "PositivePayBot", "Positive Pay Export Bot"},
+	PctRentBot:        {PctRentBot, "PctRentBot", "Percentage Rent Bot"},
+	NightAuditBot:     {NightAuditBot, "NightAuditBot", "Hotel Night Audit Bot"},
+	AsmStepBot:        {AsmStepBot, "AsmStepBot", "Scheduled Assessment Step Bot"},
+	GatewayBot:        {GatewayBot, "GatewayBot", "Payment Gateway Dispute Bot"},
 }
 
 // BotName finds and returns the name associated with the bot uid.
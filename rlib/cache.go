@@ -1,14 +1,48 @@
 package rlib
 
-import "time"
+import (
+	"sync/atomic"
+	"time"
+)
 
 // SimpleCacheCtx describes a simple context for maintaining a
 // data cache where multiple threads of execution will need
 // write access to shared memory.
 type SimpleCacheCtx struct {
-	Expiry time.Duration // default time to live for elements in this cache
-	Sem    chan int      // the channel to request write access
-	SemAck chan int      // handshaking channel
+	Hits    int64         // lookups that found an entry, updated atomically
+	Misses  int64         // lookups that did not find an entry, updated atomically
+	Entries int64         // number of entries in the cache, updated atomically
+	Expiry  time.Duration // default time to live for elements in this cache
+	Sem     chan int      // the channel to request write access
+	SemAck  chan int      // handshaking channel
+}
+
+// hit records a successful cache lookup
+func (c *SimpleCacheCtx) hit() {
+	atomic.AddInt64(&c.Hits, 1)
+}
+
+// miss records an unsuccessful cache lookup
+func (c *SimpleCacheCtx) miss() {
+	atomic.AddInt64(&c.Misses, 1)
+}
+
+// added records that an entry was stored at a key that had none. It must be
+// called while holding write access to the cache.
+func (c *SimpleCacheCtx) added() {
+	atomic.AddInt64(&c.Entries, 1)
+}
+
+// removed records that an entry was removed from the cache. It must be
+// called while holding write access to the cache.
+func (c *SimpleCacheCtx) removed() {
+	atomic.AddInt64(&c.Entries, -1)
+}
+
+// Size returns the number of entries in the cache. Unlike walking the cache
+// map it is safe to call while other routines are updating the cache.
+func (c *SimpleCacheCtx) Size() int {
+	return int(atomic.LoadInt64(&c.Entries))
 }
 
 // InitCaches is a single entry point to initialize all simple caches in this
@@ -0,0 +1,130 @@
+package rlib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EXPADJBaseYear etc. are the values of RentalAgreement.ExpenseAdjustmentType.
+// They say how a commercial tenant shares the operating expenses:
+//
+//  Base Year     the tenant pays its share of the increase over the
+//                expenses of the year ending BaseYearEnd
+//  No Base Year  the tenant pays its share of all expenses, up to the
+//                expense stop
+//  Pass Through  the tenant pays its share of all expenses
+//
+// ExpensesStop, if set, caps what is passed through for a Base Year or a No
+// Base Year lease. It is an annual amount.
+const (
+	EXPADJNone        = 0
+	EXPADJBaseYear    = 1
+	EXPADJNoBaseYear  = 2
+	EXPADJPassThrough = 3
+)
+
+// CAMRECONPosted is the CAMRecon FLAGS bit set once the true-up assessments
+// have been made
+const CAMRECONPosted = 1 << 0
+
+// CAMSqftAttr is the RentableType custom attribute holding the size of a
+// rentable. It is the same one the rentroll report shows.
+const CAMSqftAttr = "Square Feet"
+
+// CAMPoolLIDs returns the GLAccounts of a CAMPool
+//
+// INPUTS
+//  s = CAMPool.LIDs, comma separated LIDs
+//
+// RETURNS
+//  the LIDs
+//  an error if any of them is not a number
+//-----------------------------------------------------------------------------
+func CAMPoolLIDs(s string) ([]int64, error) {
+	var m []int64
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if len(f) == 0 {
+			continue
+		}
+		lid, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || lid <= 0 {
+			return nil, fmt.Errorf("invalid GL account id %q", f)
+		}
+		m = append(m, lid)
+	}
+	return m, nil
+}
+
+// RentableTypeSqft returns the square feet of rentables of type rtid, 0 if
+// the type has no Square Feet custom attribute.
+//
+// INPUTS
+//  xbiz = the business, with its RentableTypes
+//  rtid = the RentableType
+//
+// RETURNS
+//  the square feet
+//  any error converting the attribute
+//-----------------------------------------------------------------------------
+func RentableTypeSqft(xbiz *XBusiness, rtid int64) (int64, error) {
+	rt, ok := xbiz.RT[rtid]
+	if !ok {
+		return 0, nil
+	}
+	c, ok := rt.CA[CAMSqftAttr]
+	if !ok {
+		return 0, nil
+	}
+	return IntFromString(c.Value, "invalid Square Feet attribute")
+}
+
+// CAMComputeItem computes what a tenant owes for the year. Sqft, Days and
+// Estimates must be set in a. Share, Allocated, BaseYear, Due and TrueUp are
+// set.
+//
+// The share is the tenant's square feet over the total square feet of the
+// property, reduced for the part of the year the tenant leased the space.
+// Vacant space is the landlord's.
+//
+// INPUTS
+//  a        = the tenant's item
+//  adjtype  = RentalAgreement.ExpenseAdjustmentType
+//  stop     = RentalAgreement.ExpensesStop, 0 for none
+//  expenses = the pool's expenses for the year
+//  base     = the pool's expenses for the tenant's base year
+//  sqft     = total square feet of the property
+//  days     = days in the year
+//-----------------------------------------------------------------------------
+func CAMComputeItem(a *CAMReconItem, adjtype int64, stop, expenses, base Money, sqft, days int64) {
+	a.Share, a.Allocated, a.BaseYear, a.Due = 0, 0, 0, 0
+	if sqft > 0 && days > 0 {
+		a.Share = float64(a.Sqft) / float64(sqft) * float64(a.Days) / float64(days)
+		a.Allocated = expenses.Prorate(a.Sqft*a.Days, sqft*days)
+	}
+
+	switch adjtype {
+	case EXPADJBaseYear:
+		if sqft > 0 && days > 0 {
+			a.BaseYear = base.Prorate(a.Sqft*a.Days, sqft*days)
+		}
+		if a.Allocated > a.BaseYear {
+			a.Due = a.Allocated - a.BaseYear
+		}
+	case EXPADJNoBaseYear, EXPADJPassThrough:
+		a.Due = a.Allocated
+	}
+
+	//--------------------------------------------------
+	// the expense stop is annual, prorate it for a
+	// tenant who was only there part of the year
+	//--------------------------------------------------
+	if stop > 0 && adjtype != EXPADJPassThrough && days > 0 {
+		limit := stop.Prorate(a.Days, days)
+		if a.Due > limit {
+			a.Due = limit
+		}
+	}
+	a.TrueUp = a.Due - a.Estimates
+}
@@ -0,0 +1,53 @@
+package rlib
+
+import "testing"
+
+// CAM reconciliation tests.
+
+func TestCAMPoolLIDs(t *testing.T) {
+	m, err := CAMPoolLIDs(" 12, 7,,31 ")
+	if err != nil || len(m) != 3 || m[0] != 12 || m[1] != 7 || m[2] != 31 {
+		t.Errorf("CAMPoolLIDs: got %v, %v\n", m, err)
+	}
+	if _, err = CAMPoolLIDs("12,x"); err == nil {
+		t.Errorf("CAMPoolLIDs: expected an error for a bad id\n")
+	}
+}
+
+func TestCAMComputeItem(t *testing.T) {
+	var tests = []struct {
+		sqft, days      int64
+		estimates       float64
+		adjtype         int64
+		stop, baseexp   float64
+		share           float64
+		allocated, base float64
+		due, trueup     float64
+	}{
+		// 2,500 of 10,000 sqft all year, $100,000 expenses, $80,000 base year
+		{2500, 365, 4000, EXPADJPassThrough, 0, 80000, 0.25, 25000, 0, 25000, 21000},
+		{2500, 365, 4000, EXPADJBaseYear, 0, 80000, 0.25, 25000, 20000, 5000, 1000},
+		{2500, 365, 6000, EXPADJBaseYear, 0, 80000, 0.25, 25000, 20000, 5000, -1000},
+		{2500, 365, 4000, EXPADJNoBaseYear, 0, 80000, 0.25, 25000, 0, 25000, 21000},
+		{2500, 365, 4000, EXPADJNoBaseYear, 18250, 80000, 0.25, 25000, 0, 18250, 14250},
+		{2500, 365, 4000, EXPADJBaseYear, 3650, 80000, 0.25, 25000, 20000, 3650, -350},
+		// the stop does not apply to a pass through
+		{2500, 365, 0, EXPADJPassThrough, 10, 80000, 0.25, 25000, 0, 25000, 25000},
+		// a fifth of the year, the stop is prorated
+		{2000, 73, 0, EXPADJNoBaseYear, 3650, 80000, 0.04, 4000, 0, 730, 730},
+		// expenses went down from the base year, nothing is due
+		{5000, 365, 1000, EXPADJBaseYear, 0, 120000, 0.5, 50000, 60000, 0, -1000},
+		// no adjustment type, nothing is due
+		{2500, 365, 500, EXPADJNone, 0, 80000, 0.25, 25000, 0, 0, -500},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		a := CAMReconItem{Sqft: tc.sqft, Days: tc.days, Estimates: MoneyFromFloat(tc.estimates)}
+		CAMComputeItem(&a, tc.adjtype, MoneyFromFloat(tc.stop), MoneyFromFloat(100000), MoneyFromFloat(tc.baseexp), 10000, 365)
+		if RoundToCent(a.Share*10000) != RoundToCent(tc.share*10000) || a.Allocated != MoneyFromFloat(tc.allocated) || a.BaseYear != MoneyFromFloat(tc.base) ||
+			a.Due != MoneyFromFloat(tc.due) || a.TrueUp != MoneyFromFloat(tc.trueup) {
+			t.Errorf("test %d: expected share %.4f allocated %.2f base %.2f due %.2f trueup %.2f, got %.4f %.2f %.2f %.2f %.2f\n",
+				i, tc.share, tc.allocated, tc.base, tc.due, tc.trueup, a.Share, a.Allocated, a.BaseYear, a.Due, a.TrueUp)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package rlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CHECKVoid et al are the BankCheck FLAGS
+const (
+	CHECKVoid    = 1 << 0 // the check has been voided and its journal entry reversed
+	CHECKPrinted = 1 << 1 // the check has been printed at least once
+)
+
+// MICRTransit et al are the characters that map to the E-13B control symbols
+// in the MICR fonts used to print checks (GnuMICR and most commercial fonts).
+const (
+	MICRTransit = "A" // brackets the routing number
+	MICRAmount  = "B" // brackets the amount, encoded by the bank
+	MICROnUs    = "C" // terminates the account number, brackets the check number
+	MICRDash    = "D" // dash within an account number
+)
+
+var checkOnes = []string{"", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine",
+	"Ten", "Eleven", "Twelve", "Thirteen", "Fourteen", "Fifteen", "Sixteen", "Seventeen", "Eighteen", "Nineteen"}
+var checkTens = []string{"", "", "Twenty", "Thirty", "Forty", "Fifty", "Sixty", "Seventy", "Eighty", "Ninety"}
+var checkScales = []string{"", "Thousand", "Million", "Billion"}
+
+// hundredsInWords returns n, 0 < n < 1000, in words
+func hundredsInWords(n int64) string {
+	var s []string
+	if n >= 100 {
+		s = append(s, checkOnes[n/100], "Hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		s = append(s, checkTens[n/10]+"-"+checkOnes[n%10])
+	case n >= 20:
+		s = append(s, checkTens[n/10])
+	case n > 0:
+		s = append(s, checkOnes[n])
+	}
+	return strings.Join(s, " ")
+}
+
+// AmountInWords returns amt the way it is written on a check: the dollars
+// in words and the cents as a fraction, for example 1234.56 is
+// "One Thousand Two Hundred Thirty-Four and 56/100".
+//
+// INPUTS
+//  amt = the amount, it must not be negative
+//
+// RETURNS
+//  the amount in words
+//-----------------------------------------------------------------------------
+func AmountInWords(amt Money) string {
+	cents := amt.Abs().Cents()
+	dollars := cents / 100
+	if dollars == 0 {
+		return fmt.Sprintf("Zero and %02d/100", cents%100)
+	}
+	var parts []string
+	for i := 0; dollars > 0 && i < len(checkScales); i++ {
+		if n := dollars % 1000; n > 0 {
+			s := hundredsInWords(n)
+			if len(checkScales[i]) > 0 {
+				s += " " + checkScales[i]
+			}
+			parts = append([]string{s}, parts...)
+		}
+		dollars /= 1000
+	}
+	return fmt.Sprintf("%s and %02d/100", strings.Join(parts, " "), cents%100)
+}
+
+// CheckAmountString returns amt formatted for the amount box of a check.
+// Leading asterisks fill the box so that nothing can be added in front of
+// the amount.
+//-----------------------------------------------------------------------------
+func CheckAmountString(amt Money) string {
+	s := RRCommaf(amt.Float())
+	if n := 14 - len(s); n > 0 {
+		s = strings.Repeat("*", n) + s
+	}
+	return s
+}
+
+// ValidRoutingNumber returns true if s is a 9 digit ABA routing number with
+// a valid check digit.
+//-----------------------------------------------------------------------------
+func ValidRoutingNumber(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	w := []int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		sum += int(s[i]-'0') * w[i%3]
+	}
+	return sum%10 == 0
+}
+
+// MICRLine returns the MICR line of a business check in the character set
+// of a MICR font: the check number in on-us symbols, the routing number in
+// transit symbols, then the account number followed by an on-us symbol. The
+// amount field is left blank, it is encoded by the bank of first deposit.
+//
+// INPUTS
+//  checkno = the check number
+//  routing = ABA routing number
+//  account = account number, anything other than digits and dashes is dropped
+//
+// RETURNS
+//  the MICR line
+//-----------------------------------------------------------------------------
+func MICRLine(checkno int64, routing, account string) string {
+	var acct []rune
+	for _, c := range account {
+		switch {
+		case c >= '0' && c <= '9':
+			acct = append(acct, c)
+		case c == '-':
+			acct = append(acct, []rune(MICRDash)...)
+		}
+	}
+	return fmt.Sprintf("%s%06d%s %s%s%s %s%s", MICROnUs, checkno, MICROnUs, MICRTransit, routing, MICRTransit, string(acct), MICROnUs)
+}
@@ -0,0 +1,65 @@
+package rlib
+
+import "testing"
+
+// Check printing tests.
+
+func TestAmountInWords(t *testing.T) {
+	var m = []struct {
+		amt    float64
+		expect string
+	}{
+		{0, "Zero and 00/100"},
+		{0.07, "Zero and 07/100"},
+		{1, "One and 00/100"},
+		{15.5, "Fifteen and 50/100"},
+		{40, "Forty and 00/100"},
+		{99.99, "Ninety-Nine and 99/100"},
+		{100, "One Hundred and 00/100"},
+		{1234.56, "One Thousand Two Hundred Thirty-Four and 56/100"},
+		{2000.005, "Two Thousand and 01/100"},
+		{1000001, "One Million One and 00/100"},
+		{3017250.1, "Three Million Seventeen Thousand Two Hundred Fifty and 10/100"},
+	}
+	for i := 0; i < len(m); i++ {
+		if s := AmountInWords(MoneyFromFloat(m[i].amt)); s != m[i].expect {
+			t.Errorf("%d: AmountInWords( %.3f ) expect %q, got %q\n", i, m[i].amt, m[i].expect, s)
+		}
+	}
+}
+
+func TestValidRoutingNumber(t *testing.T) {
+	var m = []struct {
+		s      string
+		expect bool
+	}{
+		{"011000015", true},
+		{"121000358", true},
+		{"121000359", false},
+		{"12100035", false},
+		{"12100035x", false},
+		{"", false},
+	}
+	for i := 0; i < len(m); i++ {
+		if b := ValidRoutingNumber(m[i].s); b != m[i].expect {
+			t.Errorf("%d: ValidRoutingNumber( %q ) expect %t, got %t\n", i, m[i].s, m[i].expect, b)
+		}
+	}
+}
+
+func TestMICRLine(t *testing.T) {
+	var m = []struct {
+		checkno int64
+		routing string
+		account string
+		expect  string
+	}{
+		{1001, "121000358", "123456789", "C001001C A121000358A 123456789C"},
+		{7, "011000015", "12-345 67", "C000007C A011000015A 12D34567C"},
+	}
+	for i := 0; i < len(m); i++ {
+		if s := MICRLine(m[i].checkno, m[i].routing, m[i].account); s != m[i].expect {
+			t.Errorf("%d: MICRLine expect %q, got %q\n", i, m[i].expect, s)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Period lock.  Once a period has been closed nothing may be posted into it.
+// The ClosePeriod Dt of the last period closed is the first open date. Any
+// date before it is in a closed period.  New entries dated in a closed period
+// are rejected. Reversals of entries in a closed period are posted on the
+// first open date.  The night audit of a hotel locks each day it closes with
+// a LMLOCKED JournalMarker, so the DtStop of the last one is also a limit.
+
+// FirstOpenDate returns the first date that can be posted to in business
+// bid. It is the later of the end of the last closed period and the end of
+// the last day locked by the night audit. If neither exists it returns TIME0.
+//
+// INPUTS
+//  ctx - context which may include a database transaction in progress
+//  bid - the business
+//
+// RETURNS
+//  the first open date
+//  any error encountered
+//-----------------------------------------------------------------------------
+func FirstOpenDate(ctx context.Context, bid int64) (time.Time, error) {
+	dt := TIME0
+	cp, err := GetLastClosePeriod(ctx, bid)
+	if err != nil {
+		return dt, err
+	}
+	if cp.CPID > 0 {
+		dt = cp.Dt
+	}
+	jm, err := GetLastJournalMarkerByState(ctx, bid, LMLOCKED)
+	if err != nil {
+		return dt, err
+	}
+	if jm.JMID > 0 && jm.DtStop.After(dt) {
+		dt = jm.DtStop
+	}
+	return dt, nil
+}
+
+// PeriodIsClosed returns true if dt is in a closed period given the first
+// open date firstOpen.
+//-----------------------------------------------------------------------------
+func PeriodIsClosed(dt, firstOpen *time.Time) bool {
+	return dt.Before(*firstOpen)
+}
+
+// PostingDate returns the date on which an entry for dt can be posted. It is
+// dt if dt is in an open period, otherwise it is firstOpen.
+//-----------------------------------------------------------------------------
+func PostingDate(dt, firstOpen *time.Time) time.Time {
+	if PeriodIsClosed(dt, firstOpen) {
+		return *firstOpen
+	}
+	return *dt
+}
+
+// CanReopenPeriod returns true if the user with the supplied uid is allowed
+// to reopen a closed period in business bid. The users are listed in the
+// PeriodReopeners of the business's "general" properties.
+//-----------------------------------------------------------------------------
+func CanReopenPeriod(ctx context.Context, bid, uid int64) (bool, error) {
+	bp, err := GetDataFromBusinessPropertyName(ctx, "general", bid)
+	if err != nil {
+		return false, err
+	}
+	return Int64InSlice(uid, bp.PeriodReopeners), nil
+}
+
+// ReopenLastClosePeriod reopens the last period closed in the business of
+// xbiz. An audit record of the reopen is written, the ClosePeriod is deleted
+// and the ledger markers its close wrote are rebuilt.
+//
+// The markers on the close date -- the GL account markers and, since the
+// Rental Agreement markers are written by the close too, the RA, RA+RID and
+// security deposit markers -- are deleted, except for the initial ones, and
+// written again from the journal starting at the markers of the previous
+// close. Posts made while the period is open change these balances, so the
+// close rebuilds them again when the period is closed again. The caller must
+// check that the user is permitted to do this.
+//
+// INPUTS
+//  ctx    - context which should include a database transaction
+//  xbiz   - the business, with its internals initialized
+//  reason - why the period is being reopened
+//
+// RETURNS
+//  the audit record
+//  any error encountered
+//-----------------------------------------------------------------------------
+func ReopenLastClosePeriod(ctx context.Context, xbiz *XBusiness, reason string) (ClosePeriodReopen, error) {
+	return reopenLastClosePeriod(ctx, xbiz.P.BID, reason, func(dt *time.Time) error {
+		return RebuildLedgerMarkers(ctx, xbiz, dt)
+	})
+}
+
+// reopenLastClosePeriod does the work of ReopenLastClosePeriod using rebuild
+// to replace the markers on the close date.
+//-----------------------------------------------------------------------------
+func reopenLastClosePeriod(ctx context.Context, bid int64, reason string, rebuild func(dt *time.Time) error) (ClosePeriodReopen, error) {
+	var a ClosePeriodReopen
+	cp, err := GetLastClosePeriod(ctx, bid)
+	if err != nil {
+		return a, err
+	}
+	if cp.CPID == 0 {
+		return a, fmt.Errorf("no period has been closed in business %d", bid)
+	}
+	a.BID = bid
+	a.CPID = cp.CPID
+	a.TLID = cp.TLID
+	a.Dt = cp.Dt
+	a.Reason = reason
+	if err = InsertClosePeriodReopen(ctx, &a); err != nil {
+		return a, err
+	}
+	if err = DeleteClosePeriod(ctx, cp.CPID); err != nil {
+		return a, err
+	}
+	if err = rebuild(&cp.Dt); err != nil {
+		return a, err
+	}
+	Ulog("Period closed on %s in business %d reopened by UID %d: %s\n", cp.Dt.Format(RRDATEFMTSQL), bid, a.CreateBy, reason)
+	return a, nil
+}
@@ -0,0 +1,120 @@
+package rlib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPostingDate(t *testing.T) {
+	open := time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)
+	apr12 := time.Date(2018, time.April, 12, 0, 0, 0, 0, time.UTC)
+	var m = []struct {
+		dt        time.Time
+		firstOpen time.Time
+		closed    bool
+		expect    time.Time
+	}{
+		{time.Date(2018, time.March, 31, 23, 59, 0, 0, time.UTC), open, true, open},
+		{time.Date(2017, time.December, 15, 0, 0, 0, 0, time.UTC), open, true, open},
+		{open, open, false, open},
+		{apr12, open, false, apr12},
+		{TIME0, TIME0, false, TIME0}, // nothing has been closed
+	}
+	for i := 0; i < len(m); i++ {
+		if c := PeriodIsClosed(&m[i].dt, &m[i].firstOpen); c != m[i].closed {
+			t.Errorf("PeriodIsClosed( %s, %s ) expect %t, got %t\n", m[i].dt, m[i].firstOpen, m[i].closed, c)
+		}
+		if d := PostingDate(&m[i].dt, &m[i].firstOpen); !d.Equal(m[i].expect) {
+			t.Errorf("PostingDate( %s, %s ) expect %s, got %s\n", m[i].dt, m[i].firstOpen, m[i].expect, d)
+		}
+	}
+}
+
+// Reopening a period deletes its ClosePeriod and hands its close date to
+// rebuild. The markers on that date are replaced by rebuild; the initial
+// markers and those of the previous close are not touched.
+func TestReopenLastClosePeriod(t *testing.T) {
+	db := newMemDB(t)
+	ctx := context.Background()
+	jan := time.Date(2018, time.January, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 28, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2018, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, dt := range []time.Time{jan, feb} {
+		cp := ClosePeriod{BID: 1, TLID: 9, Dt: dt}
+		if _, err := InsertClosePeriod(ctx, &cp); err != nil {
+			t.Fatalf("InsertClosePeriod: %s\n", err.Error())
+		}
+	}
+	var markers = []LedgerMarker{
+		{BID: 1, LID: 3, Dt: feb, Balance: 100, State: LMINITIAL},                 // 1 account created on the close date
+		{BID: 1, LID: 4, Dt: jan, Balance: 200, State: LMCLOSED},                  // 2 January close
+		{BID: 1, LID: 4, Dt: feb, Balance: 300, State: LMCLOSED},                  // 3 February close
+		{BID: 1, RAID: 7, Dt: jan, Balance: 400, State: LMCLOSED},                 // 4 January RA
+		{BID: 1, RAID: 7, Dt: feb, Balance: 500, State: LMCLOSED},                 // 5 February RA
+		{BID: 1, RAID: 7, RID: 8, Dt: feb, Balance: 600, State: LMCLOSED},         // 6 February RA+RID
+		{BID: 1, LID: 5, RAID: 7, RID: 8, Dt: feb, Balance: 700, State: LMCLOSED}, // 7 February security deposit
+		{BID: 2, LID: 6, Dt: feb, Balance: 800, State: LMCLOSED},                  // 8 another business
+	}
+	for i := 0; i < len(markers); i++ {
+		if _, err := InsertLedgerMarker(ctx, &markers[i]); err != nil {
+			t.Fatalf("InsertLedgerMarker: %s\n", err.Error())
+		}
+	}
+
+	var rebuilt []time.Time
+	rebuild := func(dt *time.Time) error {
+		if cp, err := GetLastClosePeriod(ctx, 1); err != nil || !cp.Dt.Before(*dt) {
+			t.Errorf("rebuild: expect the ClosePeriod on %s to be deleted first (err = %v)\n", dt, err)
+		}
+		rebuilt = append(rebuilt, *dt)
+		return DeleteLedgerMarkersOnDate(ctx, 1, dt)
+	}
+
+	a, err := reopenLastClosePeriod(ctx, 1, "late invoice", rebuild)
+	if err != nil {
+		t.Fatalf("ReopenLastClosePeriod: %s\n", err.Error())
+	}
+	if a.CPRID == 0 || !a.Dt.Equal(feb) || a.Reason != "late invoice" {
+		t.Errorf("ReopenLastClosePeriod: expect audit record for %s, got %#v\n", feb, a)
+	}
+	if len(rebuilt) != 1 || !rebuilt[0].Equal(feb) {
+		t.Errorf("ReopenLastClosePeriod: expect the markers on %s to be rebuilt, got %v\n", feb, rebuilt)
+	}
+	cp, err := GetLastClosePeriod(ctx, 1)
+	if err != nil || !cp.Dt.Equal(jan) {
+		t.Errorf("GetLastClosePeriod: expect %s, got %s (err = %v)\n", jan, cp.Dt, err)
+	}
+
+	var expect = []int64{1, 2, 4, 8} // LMIDs left
+	rows := db.rows("LedgerMarker")
+	if len(rows) != len(expect) {
+		t.Fatalf("ReopenLastClosePeriod: expect %d markers left, got %d\n", len(expect), len(rows))
+	}
+	for i := 0; i < len(expect); i++ {
+		if rows[i]["LMID"] != expect[i] {
+			t.Errorf("ReopenLastClosePeriod: marker %d, expect LMID %d, got %v\n", i, expect[i], rows[i]["LMID"])
+		}
+	}
+
+	lm, err := GetLedgerMarkerOnOrBefore(ctx, 1, 4, &mar)
+	if err != nil || lm.LMID != 2 {
+		t.Errorf("GetLedgerMarkerOnOrBefore: expect the January marker, got LMID %d (err = %v)\n", lm.LMID, err)
+	}
+	lm, err = GetRALedgerMarkerOnOrBefore(ctx, 7, &mar)
+	if err != nil || lm.LMID != 4 {
+		t.Errorf("GetRALedgerMarkerOnOrBefore: expect the January marker, got LMID %d (err = %v)\n", lm.LMID, err)
+	}
+
+	if _, err = reopenLastClosePeriod(ctx, 1, "again", rebuild); err != nil {
+		t.Fatalf("ReopenLastClosePeriod: %s\n", err.Error())
+	}
+	if _, err = reopenLastClosePeriod(ctx, 1, "nothing left", rebuild); err == nil {
+		t.Errorf("ReopenLastClosePeriod: expect an error when no period is closed\n")
+	}
+	m, err := GetClosePeriodReopens(ctx, 1)
+	if err != nil || len(m) != 2 {
+		t.Errorf("GetClosePeriodReopens: expect 2 audit records, got %d (err = %v)\n", len(m), err)
+	}
+}
@@ -0,0 +1,175 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CONCESSIONInactive is the Concession FLAGS bit set when a business no
+// longer offers the concession. Rental Agreements that were given it keep
+// it.
+const CONCESSIONInactive = 1 << 0
+
+// concessionAmount returns the discount concession c gives on a rent charge
+// of rent. It is Pct percent of the rent if Pct is set, otherwise Amount.
+// A concession never discounts more than the rent.
+//
+// INPUTS
+//  c    = the concession
+//  rent = what is left of the rent charge to discount
+//
+// RETURNS
+//  the discount, 0 if there is nothing to discount
+//-----------------------------------------------------------------------------
+func concessionAmount(c *RentalAgreementConcession, rent Money) Money {
+	if rent <= 0 {
+		return 0
+	}
+	amt := c.Amount
+	if c.Pct > 0 {
+		amt = rent.Mul(c.Pct / 100)
+	}
+	if amt < 0 {
+		return 0
+	}
+	if amt > rent {
+		amt = rent
+	}
+	return amt
+}
+
+// ApplyConcessions posts the concessions of a rent charge. If a is a rent
+// assessment of a Rentable in a Rental Agreement that was given
+// concessions, each concession still in effect on a.Start posts its
+// discount with an assessment to the concession's account rule, which moves
+// the amount from the receivable to the concession expense. The discount
+// assessments are offsets, payments are not applied to them. They are linked
+// to a with AssocElemType ELEMASSESSMENT and AssocElemID a.ASMID. Concessions
+// are applied in the order they start, each on what is left of the rent.
+//
+// INPUTS
+//  ctx           = db context
+//  xbiz          = the business
+//  a             = the rent assessment, already inserted
+//  d1, d2        = the period of the journal entries
+//  updateLedgers = if true the ledger entries are generated too
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func ApplyConcessions(ctx context.Context, xbiz *XBusiness, a *Assessment, d1, d2 *time.Time, updateLedgers bool) error {
+	const funcname = "ApplyConcessions"
+	if a.RAID == 0 || a.RID == 0 || a.Amount <= 0 || a.ASMID == 0 {
+		return nil
+	}
+	ar, ok := RRdb.BizTypes[a.BID].AR[a.ARID]
+	if !ok || ar.FLAGS&(1<<ARIsRentASM) == 0 {
+		return nil
+	}
+	m, err := GetRentalAgreementConcessionsByRID(ctx, a.RAID, a.RID, &a.Start)
+	if err != nil {
+		return err
+	}
+	rent := a.Amount
+	for i := 0; i < len(m); i++ {
+		amt := concessionAmount(&m[i], rent)
+		if amt == 0 {
+			continue
+		}
+		c := Assessment{
+			BID:            a.BID,
+			RID:            a.RID,
+			RAID:           a.RAID,
+			Amount:         amt,
+			Start:          a.Start,
+			Stop:           a.Start,
+			RentCycle:      RECURNONE,
+			ProrationCycle: RECURNONE,
+			AssocElemType:  ELEMASSESSMENT,
+			AssocElemID:    a.ASMID,
+			ARID:           m[i].ARID,
+			FLAGS:          0x3, // an offset, payments are not applied to it
+			Comment:        fmt.Sprintf("Concession on ASMID %d", a.ASMID),
+		}
+		if _, err = InsertAssessment(ctx, &c); err != nil {
+			LogAndPrintError(funcname, err)
+			return err
+		}
+		j, err := ProcessNewAssessmentInstance(ctx, xbiz, d1, d2, &c)
+		if err != nil {
+			LogAndPrintError(funcname, err)
+			return err
+		}
+		if updateLedgers {
+			if _, err = GenerateLedgerEntriesFromJournal(ctx, xbiz, &j, d1, d2); err != nil {
+				LogAndPrintError(funcname, err)
+				return err
+			}
+		}
+		m[i].Applied++
+		if err = UpdateRentalAgreementConcession(ctx, &m[i]); err != nil {
+			LogAndPrintError(funcname, err)
+			return err
+		}
+		rent -= amt
+	}
+	return nil
+}
+
+// UnapplyConcessions takes back the concessions posted on rent assessment a
+// when a is reversed. Each RentalAgreementConcession that was applied to a
+// gets the rent charge back: its Applied count goes down by one so it will
+// be applied to another rent charge. The concession assessments are
+// returned, the caller must reverse them along with a.
+//
+// A concession assessment is matched to the RentalAgreementConcession it
+// came from by its account rule, in the order the concessions were applied.
+//
+// INPUTS
+//  ctx = db context
+//  a   = the rent assessment being reversed
+//
+// RETURNS
+//  the concession assessments posted on a that are not yet reversed
+//  any error encountered
+//-----------------------------------------------------------------------------
+func UnapplyConcessions(ctx context.Context, a *Assessment) ([]Assessment, error) {
+	const funcname = "UnapplyConcessions"
+	var m []Assessment
+	if a.RAID == 0 || a.RID == 0 || a.ASMID == 0 {
+		return m, nil
+	}
+	c, err := GetConcessionAssessmentsByASMID(ctx, a.ASMID)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(c); i++ {
+		if c[i].FLAGS&ASMREVERSED == 0 {
+			m = append(m, c[i])
+		}
+	}
+	if len(m) == 0 {
+		return m, nil
+	}
+	rc, err := GetRentalAgreementConcessionsByRAID(ctx, a.RAID)
+	if err != nil {
+		return m, err
+	}
+	done := map[int64]bool{} // RACIDs already given back their rent charge
+	for i := 0; i < len(m); i++ {
+		for j := 0; j < len(rc); j++ {
+			if rc[j].RID != a.RID || rc[j].ARID != m[i].ARID || rc[j].Applied <= 0 || rc[j].DtStart.After(a.Start) || done[rc[j].RACID] {
+				continue
+			}
+			rc[j].Applied--
+			if err = UpdateRentalAgreementConcession(ctx, &rc[j]); err != nil {
+				LogAndPrintError(funcname, err)
+				return m, err
+			}
+			done[rc[j].RACID] = true
+			break
+		}
+	}
+	return m, nil
+}
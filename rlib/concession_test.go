@@ -0,0 +1,104 @@
+package rlib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Concession discount tests.
+
+func TestConcessionAmount(t *testing.T) {
+	var tests = []struct {
+		amount Money
+		pct    float64
+		rent   Money
+		expect Money
+	}{
+		// $50 off
+		{5000, 0, 100000, 5000},
+		// first month free
+		{0, 100, 100000, 100000},
+		// 10% off, rounds to the cent
+		{0, 10, 123455, 12346},
+		// the percent wins over the amount
+		{5000, 50, 100000, 50000},
+		// never more than the rent
+		{150000, 0, 100000, 100000},
+		// nothing left to discount
+		{5000, 0, 0, 0},
+		// a negative amount gives nothing
+		{-5000, 0, 100000, 0},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		c := RentalAgreementConcession{Amount: tc.amount, Pct: tc.pct}
+		if got := concessionAmount(&c, tc.rent); got != tc.expect {
+			t.Errorf("test %d: concessionAmount(%s, %g%%) on %s: expected %s, got %s\n", i, tc.amount, tc.pct, tc.rent, tc.expect, got)
+		}
+	}
+}
+
+// Reversing a rent charge takes back the concessions posted on it: the
+// concession assessments linked to it are returned for reversal and each
+// concession they came from is given its rent charge back.
+func TestUnapplyConcessions(t *testing.T) {
+	newMemDB(t)
+	ctx := context.Background()
+	jan := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	var rc = []RentalAgreementConcession{
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Cycles: 3, Applied: 2, DtStart: jan}, // 1
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Cycles: 3, Applied: 1, DtStart: jan}, // 2 same account rule
+		{BID: 1, RAID: 1, RID: 10, ARID: 21, Cycles: 3, Applied: 1, DtStart: jan}, // 3 its assessment is already reversed
+		{BID: 1, RAID: 1, RID: 11, ARID: 20, Cycles: 3, Applied: 1, DtStart: jan}, // 4 another Rentable
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Cycles: 3, Applied: 1, DtStart: mar}, // 5 starts after the rent charge
+		{BID: 1, RAID: 2, RID: 10, ARID: 20, Cycles: 3, Applied: 1, DtStart: jan}, // 6 another Rental Agreement
+	}
+	for i := 0; i < len(rc); i++ {
+		if err := InsertRentalAgreementConcession(ctx, &rc[i]); err != nil {
+			t.Fatalf("InsertRentalAgreementConcession: %s\n", err.Error())
+		}
+	}
+	var asm = []Assessment{
+		{BID: 1, RAID: 1, RID: 10, ARID: 30, Amount: 100000, Start: feb, Stop: feb},                                                        // 1 rent
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Amount: 5000, Start: feb, Stop: feb, AssocElemType: ELEMASSESSMENT, AssocElemID: 1, FLAGS: 3}, // 2
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Amount: 2500, Start: feb, Stop: feb, AssocElemType: ELEMASSESSMENT, AssocElemID: 1, FLAGS: 3}, // 3
+		{BID: 1, RAID: 1, RID: 10, ARID: 21, Amount: 1000, Start: feb, Stop: feb, AssocElemType: ELEMASSESSMENT, AssocElemID: 1, FLAGS: 7}, // 4 reversed
+		{BID: 1, RAID: 1, RID: 10, ARID: 20, Amount: 5000, Start: jan, Stop: jan, AssocElemType: ELEMASSESSMENT, AssocElemID: 9, FLAGS: 3}, // 5 another rent charge
+	}
+	for i := 0; i < len(asm); i++ {
+		if _, err := InsertAssessment(ctx, &asm[i]); err != nil {
+			t.Fatalf("InsertAssessment: %s\n", err.Error())
+		}
+	}
+
+	m, err := UnapplyConcessions(ctx, &asm[0])
+	if err != nil {
+		t.Fatalf("UnapplyConcessions: %s\n", err.Error())
+	}
+	if len(m) != 2 || m[0].ASMID != 2 || m[1].ASMID != 3 {
+		var got []int64
+		for i := 0; i < len(m); i++ {
+			got = append(got, m[i].ASMID)
+		}
+		t.Errorf("UnapplyConcessions: expect concession assessments [2 3], got %v\n", got)
+	}
+	var expect = []int64{1, 0, 1, 1, 1, 1}
+	for i := 0; i < len(expect); i++ {
+		a, err := GetRentalAgreementConcession(ctx, rc[i].RACID)
+		if err != nil || a.Applied != expect[i] {
+			t.Errorf("UnapplyConcessions: RACID %d, expect Applied %d, got %d (err = %v)\n", rc[i].RACID, expect[i], a.Applied, err)
+		}
+	}
+
+	//------------------------------------------------------------
+	// a charge that is not in a Rental Agreement has none
+	//------------------------------------------------------------
+	a := Assessment{ASMID: 1, BID: 1, RID: 10, Start: feb}
+	if m, err = UnapplyConcessions(ctx, &a); err != nil || len(m) != 0 {
+		t.Errorf("UnapplyConcessions: no Rental Agreement, expect nothing, got %d (err = %v)\n", len(m), err)
+	}
+}
@@ -154,6 +154,9 @@ const (
 	JNLTYPERCPT = 2 // record is the result of a Receipt
 	JNLTYPEEXP  = 3 // record is the result of an Expense
 	JNLTYPEXFER = 4 // funds transfer between accounts
+	JNLTYPEBILL = 5 // record is the result of a vendor Bill
+	JNLTYPEVPMT = 6 // record is the result of a VendorPayment
+	JNLTYPECHK  = 7 // record is the result of a BankCheck
 
 	JOURNALTYPEASMID  = 1
 	JOURNALTYPERCPTID = 2
@@ -226,6 +229,705 @@ type ClosePeriod struct {
 	CreateBy    int64
 }
 
+// ClosePeriodReopen is the audit record written when a closed period is
+// reopened.
+type ClosePeriodReopen struct {
+	CPRID       int64
+	BID         int64
+	CPID        int64     // the ClosePeriod that was reopened
+	TLID        int64     // TaskList that was used for the close
+	Dt          time.Time // date of the close that was reopened
+	Reason      string    // why it was reopened
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64 // who reopened the period
+}
+
+// ReportSubscription describes a report that is rendered and emailed to a
+// list of recipients on a recurring schedule.
+type ReportSubscription struct {
+	RSUBID      int64
+	BID         int64
+	ReportName  string    // report name as registered in the report handler table
+	DateRange   int64     // relative date range, one of the RPTRANGE* values
+	Format      int64     // gotable output format
+	Recipients  string    // comma separated list of email addresses
+	Cycle       int64     // recurrence frequency, one of the RECUR* values
+	DtNext      time.Time // next delivery date/time
+	DtLast      time.Time // last delivery date/time
+	FLAGS       uint64    // 1<<0 = subscription is inactive
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// ReportDelivery is the history of a single delivery attempt for a
+// ReportSubscription
+type ReportDelivery struct {
+	RDID        int64
+	RSUBID      int64
+	BID         int64
+	Dt          time.Time // date/time of the delivery attempt
+	DtStart     time.Time // report start date
+	DtStop      time.Time // report stop date
+	Recipients  string    // who it was sent to
+	Status      int64     // 0 = delivered, 1 = failed
+	Message     string    // error message if the delivery failed
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// WebhookSubscription is an external URL that is sent signed JSON events
+// when things happen in a business.
+type WebhookSubscription struct {
+	WHSID       int64
+	BID         int64
+	URL         string // where events are POSTed
+	Secret      string // key used to sign the payloads
+	Events      string // comma separated list of event names, empty means all
+	FLAGS       uint64 // 1<<0 = subscription is inactive
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// WebhookDelivery is a single event queued for delivery to a
+// WebhookSubscription along with the result of the attempts to deliver it.
+type WebhookDelivery struct {
+	WHDID        int64
+	WHSID        int64
+	BID          int64
+	Event        string    // event name
+	Payload      string    // JSON event body
+	Status       int64     // 0 = pending, 1 = delivered, 2 = failed, no more retries
+	Attempts     int64     // delivery attempts so far
+	DtNext       time.Time // when to make the next attempt
+	DtLast       time.Time // when the last attempt was made
+	ResponseCode int64     // http status code of the last attempt
+	Message      string    // error message from the last attempt
+	LastModTime  time.Time
+	LastModBy    int64
+	CreateTS     time.Time
+	CreateBy     int64
+}
+
+// PortalUser is the credential a tenant uses to log in to the tenant
+// portal. It is tied to a Transactant and only gives access to the Rental
+// Agreements for which that Transactant is a payor.
+type PortalUser struct {
+	PUID         int64
+	BID          int64
+	TCID         int64     // the Transactant who logs in with this credential
+	Username     string    // login name, unique within the business
+	PassHash     string    // hex encoded PBKDF2-SHA256 hash of the password
+	Salt         string    // hex encoded salt for PassHash
+	Token        string    // SHA256 hash of the current session token
+	TokenExpire  time.Time // when the current session expires
+	LastLogin    time.Time // time of the last successful login
+	FailedLogins int64     // consecutive failed login attempts
+	FLAGS        uint64    // 1<<0 = login disabled
+	LastModTime  time.Time
+	LastModBy    int64
+	CreateTS     time.Time
+	CreateBy     int64
+}
+
+// MaintRequest is a maintenance request submitted by a tenant through the
+// tenant portal.
+type MaintRequest struct {
+	MRQID       int64
+	BID         int64
+	RAID        int64     // the Rental Agreement of the tenant who made the request
+	RID         int64     // the Rentable that needs attention
+	TCID        int64     // the Transactant who made the request
+	Dt          time.Time // when the request was made
+	Subject     string    // short description
+	Descr       string    // what needs to be done
+	Status      int64     // 0 = new, 1 = in progress, 2 = closed
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// WorkOrder is a maintenance job on a Rentable. Its notes are kept in the
+// NoteList NLID. The cost of the work can be charged back to the tenant
+// with an Assessment on the Rental Agreement.
+type WorkOrder struct {
+	WOID        int64
+	BID         int64
+	RID         int64     // the Rentable the work is for
+	RAID        int64     // Rental Agreement the work is for, 0 if none
+	MRQID       int64     // the tenant's MaintRequest that led to this work order, 0 if none
+	NLID        int64     // NoteList with the notes for this work order
+	Category    string    // plumbing, electrical, appliance, ...
+	Priority    int64     // 0 = low, 1 = normal, 2 = high, 3 = emergency
+	Status      int64     // 0 = open, 1 = assigned, 2 = in progress, 3 = on hold, 4 = completed, 5 = cancelled
+	AssignedUID int64     // staff UID doing the work, 0 if none
+	AssignedTo  string    // name of whoever is doing the work
+	Descr       string    // what needs to be done
+	DtOpen      time.Time // when the work order was opened
+	DtDone      time.Time // when it was completed or cancelled
+	Cost        Money     // cost of the work
+	ChargeASMID int64     // Assessment charging the tenant for the work, 0 if none
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// Vendor is a company or person the business pays for goods or services
+type Vendor struct {
+	VID         int64
+	BID         int64
+	Name        string // vendor name, as it appears on checks
+	Contact     string // person to contact at the vendor
+	Address     string
+	Address2    string
+	City        string
+	State       string
+	PostalCode  string
+	Country     string
+	Email       string
+	Phone       string
+	TaxID       string // EIN or SSN, needed for 1099 reporting
+	DefaultLID  int64  // GL Account bills from this vendor are usually distributed to
+	Terms       int64  // number of days after the bill date that payment is due
+	FLAGS       uint64 // 1<<0 inactive, 1<<1 payments are reported on a 1099
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// Bill is an amount owed to a Vendor. Its BillLines distribute the amount
+// to GL Accounts.
+type Bill struct {
+	BILLID      int64
+	BID         int64
+	VID         int64      // the Vendor that sent the bill
+	InvoiceNo   string     // the vendor's invoice number
+	Dt          time.Time  // bill date, the date the expense is posted
+	DtDue       time.Time  // date payment is due
+	Amount      Money      // total amount of the bill, the sum of its BillLines
+	APLID       int64      // the Accounts Payable GL Account credited
+	JID         int64      // Journal entry posting the bill
+	FLAGS       uint64     // 1<<0 void
+	Comment     string     // any notes
+	BL          []BillLine // the distribution of the bill
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// BillLine is the part of a Bill charged to one GL Account
+type BillLine struct {
+	BLID        int64
+	BID         int64
+	BILLID      int64  // the Bill this line belongs to
+	LID         int64  // GL Account debited
+	RID         int64  // Rentable the expense is for, 0 if none
+	Amount      Money  // amount charged to LID
+	Descr       string // description of the charge
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// VendorPayment is a check or ACH payment to a Vendor. Its
+// VendorPaymentAllocations apply it to the Vendor's Bills.
+type VendorPayment struct {
+	VPID        int64
+	BID         int64
+	VID         int64                     // the Vendor paid
+	DEPID       int64                     // the Depository the funds come from
+	Dt          time.Time                 // payment date
+	Amount      Money                     // total paid, the sum of its allocations
+	Method      int64                     // 1 = check, 2 = ACH
+	DocNo       string                    // check number or ACH trace number
+	JID         int64                     // Journal entry posting the payment
+	FLAGS       uint64                    // 1<<0 void
+	Comment     string                    // any notes
+	VPA         []VendorPaymentAllocation // the bills this payment pays
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// VendorPaymentAllocation is the part of a VendorPayment applied to a Bill
+type VendorPaymentAllocation struct {
+	VPAID       int64
+	BID         int64
+	VPID        int64 // the VendorPayment
+	BILLID      int64 // the Bill it pays
+	Amount      Money // amount applied to the bill
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// CheckAccount holds what is printed on the checks drawn on a Depository
+// and the number of the next check written.
+type CheckAccount struct {
+	CKAID        int64
+	BID          int64
+	DEPID        int64  // the Depository checks are drawn on
+	BankName     string // printed on the check
+	BankAddress  string // lines separated by newlines
+	RoutingNo    string // ABA routing number for the MICR line
+	FractionalNo string // fractional routing number printed near the check number
+	PayerName    string // account holder printed on the check
+	PayerAddress string // lines separated by newlines
+	NextCheckNo  int64  // number assigned to the next check written
+	PPFID        int64  // PositivePayFormat of the bank, 0 if it does not use positive pay
+	LastModTime  time.Time
+	LastModBy    int64
+	CreateTS     time.Time
+	CreateBy     int64
+}
+
+// BankCheck is a check drawn on a Depository. A check for a VendorPayment
+// is posted by the payment, any other check (a security deposit refund for
+// example) debits DebitLID and credits the Depository's GL Account.
+type BankCheck struct {
+	CKID         int64
+	BID          int64
+	DEPID        int64     // the Depository the check is drawn on
+	CheckNo      int64     // sequential within the Depository
+	Dt           time.Time // check date
+	Payee        string    // pay to the order of
+	PayeeAddress string    // lines separated by newlines
+	Amount       Money     // check amount
+	Memo         string    // printed on the memo line
+	DebitLID     int64     // GL Account debited when VPID is 0
+	VPID         int64     // the VendorPayment this check pays, if any
+	TCID         int64     // Transactant paid, for refunds
+	RAID         int64     // Rental Agreement, for refunds
+	JID          int64     // Journal entry posting the check
+	FLAGS        uint64    // 1<<0 void, 1<<1 printed, 1<<2 issue sent, 1<<3 void sent
+	LastModTime  time.Time
+	LastModBy    int64
+	CreateTS     time.Time
+	CreateBy     int64
+}
+
+// PositivePayFormat describes a bank's positive pay file. Header, Detail and
+// Trailer are text/templates, see RenderPositivePay.
+type PositivePayFormat struct {
+	PPFID       int64
+	BID         int64
+	Name        string // usually the name of the bank
+	Header      string // template for the header record, no header if blank
+	Detail      string // template for each check record
+	Trailer     string // template for the trailer record, no trailer if blank
+	FileExt     string // extension of the exported file name
+	FLAGS       uint64 // 1<<0 lines end with CR LF
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// PositivePayExport is a positive pay file produced for a Depository
+type PositivePayExport struct {
+	PPXID       int64
+	BID         int64
+	DEPID       int64     // the Depository whose checks were exported
+	PPFID       int64     // the format used
+	DtStart     time.Time // checks issued on or after this date were included
+	DtStop      time.Time // up to but not including this date
+	Items       int64     // number of check records in the file
+	IssuedTotal Money     // total of the issued check records
+	VoidTotal   Money     // total of the void check records
+	FileName    string    // name the file is downloaded as
+	Content     string    // the file as sent to the bank
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// LeaseTemplate is the document of a RentalAgreementTemplate. Body is an
+// HTML template whose merge fields are filled in from the Rental Agreement.
+type LeaseTemplate struct {
+	LTID        int64
+	BID         int64
+	RATID       int64  // the RentalAgreementTemplate this is the document of
+	Title       string // document title, also used for the file name
+	Body        string // HTML template with merge fields, see LeaseDocData
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// LeaseDocument is a lease generated from a LeaseTemplate. It belongs to the
+// Rental Agreement version it was generated for. A document generated from
+// a flow that has not been saved as a Rental Agreement yet has RAID 0 until
+// it is.
+type LeaseDocument struct {
+	LDID        int64
+	BID         int64
+	RAID        int64  // the Rental Agreement version, 0 while it is only a flow
+	FlowID      int64  // the flow it was generated from, 0 if generated from the RA
+	LTID        int64  // the LeaseTemplate used
+	FileName    string // name the file is downloaded as
+	Content     []byte // the PDF
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// CAMPool is a pool of operating expenses passed through to commercial
+// tenants, usually common area maintenance. The expenses are the activity of
+// the GLAccounts in LIDs.
+type CAMPool struct {
+	CAMPID       int64
+	BID          int64
+	Name         string // ex: Common Area Maintenance
+	LIDs         string // comma separated GLAccounts whose expenses are pooled
+	EstimateARID int64  // AR used to bill the estimated charges
+	TrueUpARID   int64  // AR used to charge a tenant who was billed too little
+	CreditARID   int64  // AR used to credit a tenant who was billed too much
+	LastModTime  time.Time
+	LastModBy    int64
+	CreateTS     time.Time
+	CreateBy     int64
+}
+
+// CAMRecon is the annual reconciliation of a CAMPool
+type CAMRecon struct {
+	CAMRID      int64
+	BID         int64
+	CAMPID      int64     // the pool reconciled
+	DtStart     time.Time // start of the year reconciled
+	DtStop      time.Time // end of the year, not included
+	Expenses    Money     // actual expenses of the pool for the year
+	TotalSqft   int64     // square feet of all rentables with a size, occupied or not
+	DtPosted    time.Time // date of the true-up assessments
+	FLAGS       uint64    // 1<<0 posted
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// CAMReconItem is the reconciliation of one tenant
+type CAMReconItem struct {
+	CAMRIID     int64
+	CAMRID      int64   // the reconciliation
+	BID         int64   //
+	RAID        int64   // the latest Rental Agreement version in the year
+	RID         int64   // Rentable the true-up is assessed to
+	Sqft        int64   // square feet leased
+	Days        int64   // days leased in the year
+	Share       float64 // pro-rata share: Sqft/TotalSqft * Days/days in year
+	Allocated   Money   // Share of the year's expenses
+	BaseYear    Money   // Share of the base year's expenses
+	Due         Money   // amount owed for the year after base year and expense stop
+	Estimates   Money   // estimated charges billed for the year
+	TrueUp      Money   // Due - Estimates, > 0 tenant owes, < 0 tenant is credited
+	ASMID       int64   // the true-up assessment once posted
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// PctRent holds the percentage rent terms of a retail Rental Agreement. The
+// tenant pays, on top of its base rent, a percentage of its gross sales above
+// a breakpoint. The rates and breakpoints are its PctRentTiers.
+type PctRent struct {
+	PRID           int64
+	BID            int64
+	RAID           int64 // Rental Agreement that pays percentage rent
+	BreakpointType int64 // 1 = natural, 2 = fixed
+	BaseARID       int64 // AR of the base rent, for a natural breakpoint
+	OverageARID    int64 // AR used to assess the overage rent
+	LastModTime    time.Time
+	LastModBy      int64
+	CreateTS       time.Time
+	CreateBy       int64
+}
+
+// PctRentTier is one tier of percentage rent: Rate applies to the sales
+// above Breakpoint, up to the next tier's Breakpoint
+type PctRentTier struct {
+	PRTID       int64
+	PRID        int64   // the percentage rent terms
+	BID         int64   //
+	Breakpoint  Money   // annual sales above which Rate applies
+	Rate        float64 // ex: 0.06 for 6%
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// SalesReport is the gross sales a tenant reported for a period
+type SalesReport struct {
+	SRID        int64
+	BID         int64
+	RAID        int64     // Rental Agreement of the tenant reporting
+	DtStart     time.Time // start of the period reported
+	DtStop      time.Time // end of the period, not included
+	GrossSales  Money     // gross sales the tenant reported for the period
+	Breakpoint  Money     // first breakpoint for the period, once computed
+	Overage     Money     // percentage rent due for the period, once computed
+	ASMID       int64     // the overage assessment, 0 if none
+	Comment     string    // ex: audited
+	FLAGS       uint64    // 1<<0 computed, the overage has been assessed
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// Reservation is a guest's booking of a room of type RTID from DtArrive to
+// DtDepart. A specific room, RID, can be assigned when it is booked or any
+// time before check-in. At check-in a Rental Agreement is created for the
+// stay and saved in RAID.
+type Reservation struct {
+	RESID       int64
+	BID         int64
+	RTID        int64     // the type of room reserved
+	RID         int64     // the room, 0 until one is assigned
+	TCID        int64     // the guest
+	RPID        int64     // rate plan, 0 = market rate
+	DtArrive    time.Time // arrival date
+	DtDepart    time.Time // departure date, the last night is the day before
+	Adults      int64     // number of adults
+	Children    int64     // number of children
+	Rate        Money     // nightly rate
+	Deposit     Money     // deposit taken to hold the reservation
+	RAID        int64     // Rental Agreement created at check-in
+	Comment     string    // ex: late arrival
+	FLAGS       uint64    // bits 0-1: 0 = booked, 1 = checked in, 2 = cancelled, 3 = no show
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// Concession is a discount on rent offered by a business, ex: first month
+// free, or $50 off for 12 months. It takes Pct percent off each rent charge
+// if Pct is set, otherwise Amount, for Cycles rent charges. The discount is
+// posted to the concession expense account rule ARID.
+type Concession struct {
+	CONID       int64
+	BID         int64
+	Name        string  // ex: First month free
+	Description string  // terms shown to the leasing agent
+	ARID        int64   // account rule of the concession expense
+	Amount      Money   // amount off each rent charge, if Pct is 0
+	Pct         float64 // percent off each rent charge, 0 - 100
+	Cycles      int64   // number of rent charges it applies to
+	FLAGS       uint64  // 1<<0: 1 = no longer offered
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// RentalAgreementConcession is a Concession given on the rent of Rentable
+// RID in Rental Agreement RAID. The terms of the Concession are copied when
+// it is given so later changes to the Concession do not affect it. Applied
+// counts the rent charges it has discounted so far.
+type RentalAgreementConcession struct {
+	RACID       int64
+	BID         int64
+	RAID        int64     // the Rental Agreement
+	RID         int64     // the Rentable whose rent is discounted
+	CONID       int64     // the Concession
+	ARID        int64     // account rule of the concession expense
+	Amount      Money     // amount off each rent charge, if Pct is 0
+	Pct         float64   // percent off each rent charge, 0 - 100
+	Cycles      int64     // number of rent charges it applies to
+	Applied     int64     // number of rent charges it has been applied to
+	DtStart     time.Time // applies to rent charges on or after this date
+	FLAGS       uint64    // reserved
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// UtilityBill is a master utility bill of a business for a period, ex: the
+// property's water bill for January. Less the CommonPct part for the common
+// areas, it is billed back to the residents. Method tells how it is split:
+// by Ratio Utility Billing on the square feet, the occupants, an equal split
+// of the units or the fixed ratio of each unit stored with the bill in
+// UtilityRatio, or by the sub-meter readings of the units. The part of the
+// vacant units is not billed.
+type UtilityBill struct {
+	UBID        int64
+	BID         int64
+	Utility     string    // ex: Water, Trash
+	ARID        int64     // account rule of the resident charges
+	Method      int64     // UTILMETHODSqft, et al
+	Amount      Money     // amount of the master bill
+	CommonPct   float64   // percent of the bill for common areas, 0 - 100
+	DtStart     time.Time // start of the billing period
+	DtStop      time.Time // end of the billing period, not included
+	DtPosted    time.Time // date of the resident charges
+	FLAGS       uint64    // 1<<0 posted, the resident charges have been assessed
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// UtilityCharge is the part of a UtilityBill charged to a Rental Agreement
+// for a Rentable. It keeps the figures the charge was computed from so the
+// resident can be shown how it was arrived at.
+type UtilityCharge struct {
+	UCID        int64
+	UBID        int64 // the utility bill
+	BID         int64
+	RAID        int64     // Rental Agreement charged
+	RID         int64     // the Rentable
+	DtStart     time.Time // start of the Rental Agreement's part of the period
+	DtStop      time.Time // end of its part, not included
+	Days        int64     // days in its part of the period
+	Sqft        int64     // square feet of the Rentable
+	Occupants   int64     // people using the Rentable
+	MeterUsage  float64   // sub-meter usage in its part of the period
+	Ratio       float64   // fixed ratio of the Rentable on the bill
+	Share       float64   // its share of the bill after the common area part, 0 - 1
+	Amount      Money     // amount charged
+	ASMID       int64     // the assessment once posted
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// UtilityRatio is the fixed ratio of a Rentable in the split of a
+// UtilityBill with UTILMETHODRatio, ex: 40, 35 and 25 for three units. A
+// unit with no ratio on the bill is not billed.
+type UtilityRatio struct {
+	URID        int64
+	UBID        int64 // the utility bill
+	BID         int64
+	RID         int64   // the Rentable
+	Ratio       float64 // its fixed ratio of the bill
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// MeterReading is a reading of a Rentable's sub-meter for a utility
+type MeterReading struct {
+	MRID        int64
+	BID         int64
+	RID         int64     // the Rentable metered
+	Utility     string    // ex: Water
+	Dt          time.Time // date read
+	Reading     float64   // meter reading
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// AssessmentStep is a scheduled change of a recurring assessment: from Dt
+// the assessment is charged Amount, or the amount in force escalated by Pct
+// percent. When a step is applied the recurring definition ASMID is ended on
+// Dt and NewASMID starts on Dt with the new amount. A step that repeats each
+// anniversary schedules the next one when it is applied.
+type AssessmentStep struct {
+	ASTID       int64
+	BID         int64
+	RAID        int64     // Rental Agreement of the assessment
+	ASMID       int64     // recurring assessment definition the step changes
+	Dt          time.Time // date the change takes effect
+	Amount      Money     // new amount, or 0 if Pct is used
+	Pct         float64   // percent escalation of the amount in force
+	NewASMID    int64     // recurring assessment definition created by the step
+	DtApplied   time.Time // date the step was applied
+	Comment     string    // reason for the change
+	FLAGS       uint64    // 1<<0 applied, 1<<1 repeats each anniversary, 1<<2 skipped, the assessment ended first
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// PaymentGateway is a card payment processor account of a business. Cards
+// are tokenized and charged through its REST API at URL. Successful charges
+// become receipts with payment type PMTID and account rule ARID, and the
+// gateway pays them out to depository DEPID.
+type PaymentGateway struct {
+	PGID        int64
+	BID         int64
+	Name        string // ex: Card payments
+	URL         string // base URL of the gateway REST API
+	APIKey      string // secret key for the gateway API
+	PMTID       int64  // payment type of the receipts
+	DEPID       int64  // depository the gateway pays out to
+	ARID        int64  // account rule of the receipts
+	FLAGS       uint64 // 1<<0 inactive
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// CardToken is a payment card of a Transactant saved at a PaymentGateway.
+// Only the gateway's token and what is needed to recognize the card are
+// kept, never the card number.
+type CardToken struct {
+	CTID        int64
+	BID         int64
+	TCID        int64  // the card holder
+	PGID        int64  // gateway that issued the token
+	Token       string // gateway token, the card number is never stored
+	Brand       string // ex: Visa
+	Last4       string // last 4 digits of the card number
+	ExpMonth    int64  // expiration month, 1 - 12
+	ExpYear     int64  // expiration year, ex: 2021
+	FLAGS       uint64 // 1<<0 removed
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
+// GatewayTransaction is a charge, refund or chargeback made through a
+// PaymentGateway and the Receipt it was recorded with
+type GatewayTransaction struct {
+	GTID        int64
+	BID         int64
+	PGID        int64     // the gateway
+	TCID        int64     // the payor
+	CTID        int64     // card charged
+	Type        int64     // 1 = charge, 2 = refund, 3 = chargeback
+	ParentGTID  int64     // the charge refunded or charged back
+	TxnID       string    // gateway transaction id
+	Amount      Money     // amount, always positive
+	Dt          time.Time // date of the transaction
+	Status      int64     // 1 = succeeded, 2 = failed, 3 = pending
+	Message     string    // reason the gateway gave for a failure, or why a transaction is still pending
+	RCPTID      int64     // receipt of a charge, receipt reversed by a refund or chargeback
+	PayoutID    string    // gateway payout that settled the transaction
+	DID         int64     // Deposit the payout was matched to
+	LastModTime time.Time
+	LastModBy   int64
+	CreateTS    time.Time
+	CreateBy    int64
+}
+
 // Task is an indivually tracked work item.
 // FLAGS are defined as follows:
 //    1<<0 pre-completion required (if 0 then there is no pre-completion required)
@@ -628,12 +1330,12 @@ type RentalAgreement struct {
 	Renewal                int64       // 0 = not set, 1 = month to month automatic renewal, 2 = lease extension options
 	SpecialProvisions      string      // free-form text
 	LeaseType              int64       // Full Service Gross, Gross, ModifiedGross, Tripple Net
-	ExpenseAdjustmentType  int64       // Base Year, No Base Year, Pass Through
-	ExpensesStop           float64     // cap on the amount of oexpenses that can be passed through to the tenant
+	ExpenseAdjustmentType  int64       // 0 = not set, 1 = Base Year, 2 = No Base Year, 3 = Pass Through
+	ExpensesStop           Money       // cap on the amount of oexpenses that can be passed through to the tenant
 	ExpenseStopCalculation string      // note on how to determine the expense stop
 	BaseYearEnd            time.Time   // last day of the base year
 	ExpenseAdjustment      time.Time   // the next date on which an expense adjustment is due
-	EstimatedCharges       float64     // a periodic fee charged to the tenant to reimburse LL for anticipated expenses
+	EstimatedCharges       Money       // a periodic fee charged to the tenant to reimburse LL for anticipated expenses
 	RateChange             float64     // predetermined amount of rent increase, expressed as a percentage
 	NextRateChange         time.Time   // he next date on which a RateChange will occur
 	PermittedUses          string      // indicates primary use of the space, ex: doctor's office, or warehouse/distribution, etc.
@@ -656,7 +1358,7 @@ type RentalAgreementRentable struct {
 	BID          int64     // Business
 	RID          int64     // the Rentable
 	CLID         int64     // commission ledger -- applies if outside sales rented this rentable
-	ContractRent float64   // the rent
+	ContractRent Money     // the rent
 	RARDtStart   time.Time // start date/time for this Rentable
 	RARDtStop    time.Time // stop date/time
 	LastModTime  time.Time // when was this record last written
@@ -912,7 +1614,7 @@ type Assessment struct {
 	AssocElemType  int64     // Associated element type, example: 14 = Pet, 15 = Vehicle. Values defined in dbtypes.go
 	AssocElemID    int64     // Associated element ID. Exmaple: if AssoceElemType == 14 then AssocElemID is set to the PETID
 	RAID           int64     // associated Rental Agreement
-	Amount         float64   // how much
+	Amount         Money     // how much
 	Start          time.Time // start time
 	Stop           time.Time // stop time, may be the same as start time or later
 	RentCycle      int64     // 0 = one time only, 1 = secondly, 2 = minutely, 3 = hourly, 4 = daily, 5 = weekly, 6 = monthly, G = quarterly, 8 = yearly
@@ -943,7 +1645,7 @@ type Expense struct {
 	BID         int64
 	RID         int64
 	RAID        int64
-	Amount      float64
+	Amount      Money
 	Dt          time.Time
 	AcctRule    string
 	ARID        int64
@@ -1030,9 +1732,14 @@ type BusinessProperties struct {
 // BizProps is the golang struct for a category of business properties.
 // This struct will be marshaled into JSON data and stored in BusinessProperties
 type BizProps struct {
-	Epochs      BizPropsEpochs // default epochs for recurring assessments
-	PetFees     []string       // AR names of all Pet Fees
-	VehicleFees []string       // AR names of all Vehicle Fees
+	Epochs                BizPropsEpochs // default epochs for recurring assessments
+	PetFees               []string       // AR names of all Pet Fees
+	VehicleFees           []string       // AR names of all Vehicle Fees
+	PeriodReopeners       []int64        // UIDs of the users allowed to reopen a closed period
+	CloseUnallocatedLimit Money          // a period cannot be closed while unallocated funds exceed this amount
+	NightAudit            bool           // run the hotel night audit every night
+	RoomTaxRate           float64        // occupancy tax on the nightly room charge, ex: 0.12 for 12%
+	RoomTaxAR             string         // AR name of the occupancy tax
 }
 
 // Building defines the location of a Building that is part of a Business
@@ -1080,7 +1787,7 @@ type Receipt struct {
 	RAID            int64     // required for special case receipts
 	Dt              time.Time // date payment was received
 	DocNo           string    // check number, money order number, etc.; documents the payment
-	Amount          float64   // amount of the receipt
+	Amount          Money     // amount of the receipt
 	AcctRuleReceive string    // Account rule to apply on the receipt of this payment -- essentially - bank account and unapplied funds
 	ARID            int64     // User selected rule
 	AcctRuleApply   string    // how the funds are applied to assessments
@@ -1131,7 +1838,7 @@ type ReceiptAllocation struct {
 	BID         int64
 	RAID        int64     // which RAID is this portion of the payment associated
 	Dt          time.Time // date of this payment (may not be the same as the Receipt's)
-	Amount      float64
+	Amount      Money
 	ASMID       int64
 	AcctRule    string
 	FLAGS       uint64 // bit 2:  VOID THIS RECEIPT-ALLOCATION
@@ -1162,8 +1869,8 @@ type Deposit struct {
 	DEPID         int64         // Depository id where the deposit was made
 	DPMID         int64         // Deposit method
 	Dt            time.Time     // Date of deposit
-	Amount        float64       // the total amount of the deposit
-	ClearedAmount float64       // the amount cleared by the depository
+	Amount        Money         // the total amount of the deposit
+	ClearedAmount Money         // the amount cleared by the depository
 	FLAGS         uint64        // bitflags
 	LastModTime   time.Time     // when was this record last written
 	LastModBy     int64         // employee UID (from phonebook) that modified it
@@ -1203,7 +1910,7 @@ type Invoice struct {
 	BID         int64               // bid (remit to)
 	Dt          time.Time           // Date of invoice
 	DtDue       time.Time           // Date when the invoice is due
-	Amount      float64             // total amount of all assessments in this invoice
+	Amount      Money               // total amount of all assessments in this invoice
 	DeliveredBy string              // mail, FedEx, UPS, email, fax, hand delivered, carrier pigeon :-) ...
 	LastModTime time.Time           // when was this record last written
 	LastModBy   int64               // employee UID (from phonebook) that modified it
@@ -1411,7 +2118,7 @@ type Journal struct {
 	JID         int64               // unique id for this Journal entry
 	BID         int64               // unique id of Business
 	Dt          time.Time           // when this entry was made
-	Amount      float64             // the amount
+	Amount      Money               // the amount
 	Type        int64               // 0 = unassociated with RA, 1 means this is an assessment, 2 means it is a payment
 	ID          int64               // if Type == 0 then it is the RentableID, if Type == 1 then it is the ASMID that caused this entry, if Type ==2 then it is the RCPTID
 	Comment     string              // for notes like "prior period adjustment"
@@ -1432,7 +2139,7 @@ type JournalAllocation struct {
 	RAID        int64     // associated Rental Agreement
 	TCID        int64     // if > 0 this is the payor who made the payment - important if RID and RAID == 0 -- means the payment went to the unallocated funds account
 	RCPTID      int64     // associated receipt if TCID > 0
-	Amount      float64   // amount of this allocation
+	Amount      Money     // amount of this allocation
 	ASMID       int64     // associated AssessmentID -- source of the charge
 	EXPID       int64     // associated Expense -- source of the charge
 	AcctRule    string    // describes how this amount distributed across the accounts
@@ -1466,7 +2173,7 @@ type LedgerEntry struct {
 	RID         int64     // Rentable associated with this entry
 	TCID        int64     // Payor associated with this entry
 	Dt          time.Time // date associated with this transaction
-	Amount      float64
+	Amount      Money
 	Comment     string    // for notes like "prior period adjustment"
 	LastModTime time.Time // auto updated
 	LastModBy   int64     // user making the mod
@@ -1485,7 +2192,7 @@ type LedgerMarker struct {
 	RID         int64     // if 0 then it's the LM for the whole account, if > 0 it's the amount for the Rentable RID
 	TCID        int64     // (I think this is deprecated)  if 0 then LM for whole acct, if > 0 then it's the amount for this payor; TCID
 	Dt          time.Time // Balance is valid as of this time
-	Balance     float64   // GLAccount balance at the end of the period
+	Balance     Money     // GLAccount balance at the end of the period
 	State       int64     // 0 = Open, 1 = Closed, 2 = Locked, 3 = InitialMarker (no records prior)
 	LastModTime time.Time // auto updated
 	LastModBy   int64     // user making the mod
@@ -1964,6 +2671,203 @@ type RRprepSQL struct {
 	UpdateTBind                             *sql.Stmt
 	DeleteTBind                             *sql.Stmt
 	GetRentalAgreementChain                 *sql.Stmt
+	GetReportSubscription                   *sql.Stmt
+	GetReportSubscriptionsByBID             *sql.Stmt
+	GetDueReportSubscriptions               *sql.Stmt
+	InsertReportSubscription                *sql.Stmt
+	UpdateReportSubscription                *sql.Stmt
+	DeleteReportSubscription                *sql.Stmt
+	GetReportDeliveries                     *sql.Stmt
+	InsertReportDelivery                    *sql.Stmt
+	GetWebhookSubscription                  *sql.Stmt
+	GetWebhookSubscriptionsByBID            *sql.Stmt
+	InsertWebhookSubscription               *sql.Stmt
+	UpdateWebhookSubscription               *sql.Stmt
+	DeleteWebhookSubscription               *sql.Stmt
+	GetDueWebhookDeliveries                 *sql.Stmt
+	GetWebhookDeliveries                    *sql.Stmt
+	InsertWebhookDelivery                   *sql.Stmt
+	UpdateWebhookDelivery                   *sql.Stmt
+	DeleteLedgerMarkersOnDate               *sql.Stmt
+	InsertClosePeriodReopen                 *sql.Stmt
+	GetClosePeriodReopens                   *sql.Stmt
+	GetUserSession                          *sql.Stmt
+	GetUserSessions                         *sql.Stmt
+	InsertUserSession                       *sql.Stmt
+	UpdateUserSessionExpire                 *sql.Stmt
+	DeleteUserSession                       *sql.Stmt
+	DeleteExpiredUserSessions               *sql.Stmt
+	GetRARAcctLedgerMarkerOnOrBefore        *sql.Stmt
+	GetPortalUser                           *sql.Stmt
+	GetPortalUserByUsername                 *sql.Stmt
+	GetPortalUserByToken                    *sql.Stmt
+	GetPortalUsersByBID                     *sql.Stmt
+	InsertPortalUser                        *sql.Stmt
+	UpdatePortalUser                        *sql.Stmt
+	DeletePortalUser                        *sql.Stmt
+	GetMaintRequest                         *sql.Stmt
+	GetMaintRequestsByTCID                  *sql.Stmt
+	GetOpenMaintRequests                    *sql.Stmt
+	InsertMaintRequest                      *sql.Stmt
+	UpdateMaintRequest                      *sql.Stmt
+	GetWorkOrder                            *sql.Stmt
+	GetOpenWorkOrders                       *sql.Stmt
+	InsertWorkOrder                         *sql.Stmt
+	UpdateWorkOrder                         *sql.Stmt
+	DeleteWorkOrder                         *sql.Stmt
+	GetVendor                               *sql.Stmt
+	GetVendorsByBID                         *sql.Stmt
+	InsertVendor                            *sql.Stmt
+	UpdateVendor                            *sql.Stmt
+	DeleteVendor                            *sql.Stmt
+	GetBill                                 *sql.Stmt
+	GetBillsByDateRange                     *sql.Stmt
+	GetBillsByVID                           *sql.Stmt
+	GetBillsAsOf                            *sql.Stmt
+	InsertBill                              *sql.Stmt
+	UpdateBill                              *sql.Stmt
+	GetBillLines                            *sql.Stmt
+	InsertBillLine                          *sql.Stmt
+	GetVendorPayment                        *sql.Stmt
+	GetVendorPaymentsByDateRange            *sql.Stmt
+	InsertVendorPayment                     *sql.Stmt
+	UpdateVendorPayment                     *sql.Stmt
+	GetVendorPaymentAllocations             *sql.Stmt
+	GetBillPayments                         *sql.Stmt
+	GetBillPaidAmount                       *sql.Stmt
+	InsertVendorPaymentAllocation           *sql.Stmt
+	GetVendor1099Totals                     *sql.Stmt
+	GetCheckAccount                         *sql.Stmt
+	GetCheckAccountByDEPID                  *sql.Stmt
+	GetCheckAccountForUpdate                *sql.Stmt
+	InsertCheckAccount                      *sql.Stmt
+	UpdateCheckAccount                      *sql.Stmt
+	GetBankCheck                            *sql.Stmt
+	GetBankCheckByVPID                      *sql.Stmt
+	GetBankChecksByDEPID                    *sql.Stmt
+	GetBankChecksByDateRange                *sql.Stmt
+	InsertBankCheck                         *sql.Stmt
+	UpdateBankCheck                         *sql.Stmt
+	GetCheckAccountsWithPositivePay         *sql.Stmt
+	GetPositivePayChecks                    *sql.Stmt
+	GetPositivePayFormat                    *sql.Stmt
+	GetPositivePayFormatsByBID              *sql.Stmt
+	InsertPositivePayFormat                 *sql.Stmt
+	UpdatePositivePayFormat                 *sql.Stmt
+	DeletePositivePayFormat                 *sql.Stmt
+	GetPositivePayExport                    *sql.Stmt
+	GetPositivePayExportsByDEPID            *sql.Stmt
+	InsertPositivePayExport                 *sql.Stmt
+	GetLeaseTemplate                        *sql.Stmt
+	GetLeaseTemplateByRATID                 *sql.Stmt
+	GetLeaseTemplatesByBID                  *sql.Stmt
+	InsertLeaseTemplate                     *sql.Stmt
+	UpdateLeaseTemplate                     *sql.Stmt
+	DeleteLeaseTemplate                     *sql.Stmt
+	GetLeaseDocument                        *sql.Stmt
+	GetLeaseDocumentsByRAID                 *sql.Stmt
+	GetLeaseDocumentsByFlowID               *sql.Stmt
+	InsertLeaseDocument                     *sql.Stmt
+	UpdateLeaseDocumentsRAID                *sql.Stmt
+	GetCAMPool                              *sql.Stmt
+	GetCAMPoolsByBID                        *sql.Stmt
+	InsertCAMPool                           *sql.Stmt
+	UpdateCAMPool                           *sql.Stmt
+	DeleteCAMPool                           *sql.Stmt
+	GetCAMRecon                             *sql.Stmt
+	GetCAMReconsByCAMPID                    *sql.Stmt
+	InsertCAMRecon                          *sql.Stmt
+	UpdateCAMRecon                          *sql.Stmt
+	DeleteCAMRecon                          *sql.Stmt
+	GetCAMReconItem                         *sql.Stmt
+	GetCAMReconItems                        *sql.Stmt
+	InsertCAMReconItem                      *sql.Stmt
+	UpdateCAMReconItem                      *sql.Stmt
+	DeleteCAMReconItems                     *sql.Stmt
+	GetAssessmentsByRAIDARID                *sql.Stmt
+	GetPctRent                              *sql.Stmt
+	GetPctRentByRAID                        *sql.Stmt
+	GetPctRentsByBID                        *sql.Stmt
+	InsertPctRent                           *sql.Stmt
+	UpdatePctRent                           *sql.Stmt
+	DeletePctRent                           *sql.Stmt
+	GetPctRentTiers                         *sql.Stmt
+	InsertPctRentTier                       *sql.Stmt
+	DeletePctRentTiers                      *sql.Stmt
+	GetSalesReport                          *sql.Stmt
+	GetSalesReportsByRAID                   *sql.Stmt
+	GetSalesReportsByRange                  *sql.Stmt
+	GetSalesReportsToCompute                *sql.Stmt
+	InsertSalesReport                       *sql.Stmt
+	UpdateSalesReport                       *sql.Stmt
+	DeleteSalesReport                       *sql.Stmt
+	GetRentablesByRTID                      *sql.Stmt
+	GetReservation                          *sql.Stmt
+	GetReservationsByRange                  *sql.Stmt
+	GetBookedReservationsByRID              *sql.Stmt
+	GetUnassignedReservations               *sql.Stmt
+	InsertReservation                       *sql.Stmt
+	UpdateReservation                       *sql.Stmt
+	DeleteReservation                       *sql.Stmt
+	GetLastJournalMarkerByState             *sql.Stmt
+	GetConcession                           *sql.Stmt
+	GetConcessionsByBusiness                *sql.Stmt
+	InsertConcession                        *sql.Stmt
+	UpdateConcession                        *sql.Stmt
+	DeleteConcession                        *sql.Stmt
+	GetRentalAgreementConcession            *sql.Stmt
+	GetRentalAgreementConcessionsByRAID     *sql.Stmt
+	GetRentalAgreementConcessionsByRID      *sql.Stmt
+	InsertRentalAgreementConcession         *sql.Stmt
+	UpdateRentalAgreementConcession         *sql.Stmt
+	DeleteRentalAgreementConcession         *sql.Stmt
+	GetConcessionAssessments                *sql.Stmt
+	GetConcessionAssessmentsByASMID         *sql.Stmt
+	GetRentCollectedByRentable              *sql.Stmt
+	GetUtilityBill                          *sql.Stmt
+	GetUtilityBillsByRange                  *sql.Stmt
+	InsertUtilityBill                       *sql.Stmt
+	UpdateUtilityBill                       *sql.Stmt
+	DeleteUtilityBill                       *sql.Stmt
+	GetUtilityCharges                       *sql.Stmt
+	GetUtilityChargeByASMID                 *sql.Stmt
+	InsertUtilityCharge                     *sql.Stmt
+	UpdateUtilityCharge                     *sql.Stmt
+	DeleteUtilityCharges                    *sql.Stmt
+	GetUtilityRatios                        *sql.Stmt
+	InsertUtilityRatio                      *sql.Stmt
+	DeleteUtilityRatios                     *sql.Stmt
+	GetMeterReading                         *sql.Stmt
+	GetMeterReadingsByRange                 *sql.Stmt
+	InsertMeterReading                      *sql.Stmt
+	UpdateMeterReading                      *sql.Stmt
+	DeleteMeterReading                      *sql.Stmt
+	GetAssessmentStep                       *sql.Stmt
+	GetAssessmentStepsByASMID               *sql.Stmt
+	GetAssessmentStepsByRAID                *sql.Stmt
+	GetAssessmentStepsDue                   *sql.Stmt
+	GetAssessmentStepsPending               *sql.Stmt
+	InsertAssessmentStep                    *sql.Stmt
+	UpdateAssessmentStep                    *sql.Stmt
+	DeleteAssessmentStep                    *sql.Stmt
+	GetPaymentGateway                       *sql.Stmt
+	GetPaymentGatewaysByBID                 *sql.Stmt
+	GetAllPaymentGateways                   *sql.Stmt
+	InsertPaymentGateway                    *sql.Stmt
+	UpdatePaymentGateway                    *sql.Stmt
+	DeletePaymentGateway                    *sql.Stmt
+	GetCardToken                            *sql.Stmt
+	GetCardTokensByTCID                     *sql.Stmt
+	InsertCardToken                         *sql.Stmt
+	UpdateCardToken                         *sql.Stmt
+	GetGatewayTransaction                   *sql.Stmt
+	GetGatewayTransactionForUpdate          *sql.Stmt
+	GetGatewayTransactionByTxnID            *sql.Stmt
+	GetGatewayTransactionsByTCID            *sql.Stmt
+	GetGatewayTransactionsByRange           *sql.Stmt
+	GetGatewayTransactionsByParent          *sql.Stmt
+	InsertGatewayTransaction                *sql.Stmt
+	UpdateGatewayTransaction                *sql.Stmt
 }
 
 // DeleteBusinessFromDB deletes information from all tables if it is part of the supplied BID.
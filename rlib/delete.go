@@ -1680,3 +1680,505 @@ func DeletePayor(ctx context.Context, id int64) error {
 	}
 	return err
 }
+
+// DeleteReportSubscription deletes the ReportSubscription with the specified id from the database
+func DeleteReportSubscription(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteReportSubscription)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteReportSubscription.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting ReportSubscription id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteWebhookSubscription deletes the WebhookSubscription with the specified id from the database
+func DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteWebhookSubscription)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteWebhookSubscription.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting WebhookSubscription id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteClosePeriod deletes the ClosePeriod with the specified id from the database
+func DeleteClosePeriod(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteClosePeriod)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteClosePeriod.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting ClosePeriod id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteLedgerMarkersOnDate deletes all the LedgerMarkers of business bid
+// with date dt, except for initial markers. This includes the GLAccount
+// markers as well as the RAID, RID and TCID sub-ledger markers.
+func DeleteLedgerMarkersOnDate(ctx context.Context, bid int64, dt *time.Time) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteLedgerMarkersOnDate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteLedgerMarkersOnDate.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting LedgerMarkers for BID=%d on %s error: %v\n", bid, dt.Format(RRDATEFMTSQL), err)
+	}
+	return err
+}
+
+// DeletePortalUser deletes the PortalUser with the specified id from the database
+func DeletePortalUser(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeletePortalUser)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeletePortalUser.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting PortalUser id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteVehicle deletes the Vehicle with the specified id from the database
+func DeleteVehicle(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVehicle)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVehicle.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Vehicle id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteWorkOrder deletes the WorkOrder with the specified id from the database
+func DeleteWorkOrder(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteWorkOrder)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteWorkOrder.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting WorkOrder id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteVendor deletes the Vendor with the specified id from the database
+func DeleteVendor(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteVendor)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteVendor.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Vendor id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeletePositivePayFormat deletes the PositivePayFormat with the supplied id
+func DeletePositivePayFormat(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeletePositivePayFormat)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeletePositivePayFormat.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting PositivePayFormat id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteLeaseTemplate deletes the LeaseTemplate with the supplied id
+func DeleteLeaseTemplate(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteLeaseTemplate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteLeaseTemplate.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting LeaseTemplate id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCAMPool deletes the CAMPool with the supplied id
+func DeleteCAMPool(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteCAMPool)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteCAMPool.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting CAMPool id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCAMRecon deletes the CAMRecon with the supplied id
+func DeleteCAMRecon(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteCAMRecon)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteCAMRecon.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting CAMRecon id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCAMReconItems deletes the items of the CAMRecon with the supplied id
+func DeleteCAMReconItems(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteCAMReconItems)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteCAMReconItems.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting CAMReconItems id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeletePctRent deletes the PctRent with the supplied id
+func DeletePctRent(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeletePctRent)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeletePctRent.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting PctRent id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteSalesReport deletes the SalesReport with the supplied id
+func DeleteSalesReport(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteSalesReport)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteSalesReport.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting SalesReport id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeletePctRentTiers deletes the tiers of the PctRent with the supplied id
+func DeletePctRentTiers(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeletePctRentTiers)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeletePctRentTiers.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting PctRentTiers id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteReservation deletes the Reservation with the supplied id
+func DeleteReservation(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteReservation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteReservation.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Reservation id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteConcession deletes the Concession with the supplied id
+func DeleteConcession(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteConcession)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteConcession.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting Concession id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteRentalAgreementConcession deletes the RentalAgreementConcession with the supplied id
+func DeleteRentalAgreementConcession(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteRentalAgreementConcession)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteRentalAgreementConcession.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting RentalAgreementConcession id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteUtilityBill deletes the UtilityBill with the supplied id
+func DeleteUtilityBill(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteUtilityBill)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteUtilityBill.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting UtilityBill id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteUtilityCharges deletes the UtilityCharges of the UtilityBill with the supplied id
+func DeleteUtilityCharges(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteUtilityCharges)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteUtilityCharges.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting UtilityCharges of UtilityBill id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteUtilityRatios deletes the UtilityRatios of the UtilityBill with the supplied id
+func DeleteUtilityRatios(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteUtilityRatios)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteUtilityRatios.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting UtilityRatios of UtilityBill id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteMeterReading deletes the MeterReading with the supplied id
+func DeleteMeterReading(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteMeterReading)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteMeterReading.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting MeterReading id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteAssessmentStep deletes the AssessmentStep with the supplied id
+func DeleteAssessmentStep(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeleteAssessmentStep)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeleteAssessmentStep.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting AssessmentStep id=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeletePaymentGateway deletes the PaymentGateway with the supplied id
+func DeletePaymentGateway(ctx context.Context, id int64) error {
+	var err error
+	if delContextProblem(ctx) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.DeletePaymentGateway)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.DeletePaymentGateway.Exec(fields...)
+	}
+	if err != nil {
+		Ulog("Error deleting PaymentGateway id=%d error: %v\n", id, err)
+	}
+	return err
+}
@@ -135,8 +135,8 @@ func SimpleProrateAmount(amt float64, RentCycle, Prorate int64, d1, d2, epoch *t
 	numPeriods := int64(dur) / int64(proratedur)
 	totalPeriods := int64(cycdur) / int64(proratedur)
 	// Console("numPeriods = %d, totalPeriods = %d\n", numPeriods, totalPeriods)
-	rounded := Round(amt*float64(numPeriods)/float64(totalPeriods), .5, 2)
-	return rounded, numPeriods, totalPeriods
+	rounded := MoneyFromFloat(amt).Prorate(numPeriods, totalPeriods)
+	return rounded.Float(), numPeriods, totalPeriods
 }
 
 // SelectRentableStatusForPeriod returns a subset of Rentable states that
@@ -205,8 +205,8 @@ func GetGLAccountChildAccts(ctx context.Context, bid, lid int64) ([]int64, error
 // GetAccountActivity returns the summed Amount balance for activity
 // in GLAccount lid associated with RentalAgreement raid
 //=============================================================================
-func GetAccountActivity(ctx context.Context, bid, lid int64, d1, d2 *time.Time) (float64, error) {
-	var bal = float64(0)
+func GetAccountActivity(ctx context.Context, bid, lid int64, d1, d2 *time.Time) (Money, error) {
+	var bal = Money(0)
 	m, err := GetLedgerEntriesInRange(ctx, d1, d2, bid, lid)
 	if err != nil {
 		return bal, err
@@ -220,8 +220,8 @@ func GetAccountActivity(ctx context.Context, bid, lid int64, d1, d2 *time.Time)
 // GetRAAccountActivity returns the summed Amount balance for activity
 // in GLAccount lid associated with RentalAgreement raid
 //=============================================================================
-func GetRAAccountActivity(ctx context.Context, bid, lid, raid int64, d1, d2 *time.Time) (float64, error) {
-	var bal = float64(0)
+func GetRAAccountActivity(ctx context.Context, bid, lid, raid int64, d1, d2 *time.Time) (Money, error) {
+	var bal = Money(0)
 	m, err := GetLedgerEntriesForRAID(ctx, d1, d2, raid, lid)
 	if err != nil {
 		return bal, err
@@ -235,8 +235,8 @@ func GetRAAccountActivity(ctx context.Context, bid, lid, raid int64, d1, d2 *tim
 // GetRentableAccountActivity returns the summed Amount balance for activity
 // in GLAccount lid associated with Rentable rid
 //=============================================================================
-func GetRentableAccountActivity(ctx context.Context, bid, lid, rid int64, d1, d2 *time.Time) (float64, error) {
-	var bal = float64(0)
+func GetRentableAccountActivity(ctx context.Context, bid, lid, rid int64, d1, d2 *time.Time) (Money, error) {
+	var bal = Money(0)
 	m, err := GetLedgerEntriesForRentable(ctx, d1, d2, rid, lid)
 	if err != nil {
 		return bal, err
@@ -254,11 +254,11 @@ func GetRentableAccountActivity(ctx context.Context, bid, lid, rid int64, d1, d2
 //  dt = balance on this date
 //
 // RETURNS:
-//   float64 balance
+//   the balance
 //   error or nil
 //=============================================================================
-func GetAccountTypeBalance(ctx context.Context, a string, bid int64, dt *time.Time) (float64, error) {
-	bal := float64(0)
+func GetAccountTypeBalance(ctx context.Context, a string, bid int64, dt *time.Time) (Money, error) {
+	bal := Money(0)
 	found := false
 	for i := 0; i < len(QBAcctType); i++ { // make sure we have a valid
 		found := QBAcctType[i] == a
@@ -299,9 +299,9 @@ func GetAccountTypeBalance(ctx context.Context, a string, bid int64, dt *time.Ti
 // dt. If raid is 0 then all transactions are considered. Otherwise, only
 // transactions involving this RAID are considered.
 //=============================================================================
-func GetRAAccountBalance(ctx context.Context, bid, lid, raid int64, dt *time.Time) (float64, error) {
+func GetRAAccountBalance(ctx context.Context, bid, lid, raid int64, dt *time.Time) (Money, error) {
 	// fmt.Printf("GetRAAccountBalance: bid = %d, lid = %d, raid = %d, dt = %s ", bid, lid, raid, dt.Format(RRDATEFMT4))
-	bal := float64(0)
+	bal := Money(0)
 	//--------------------------------------------------------------------------------
 	// First, check and see if this is a Parent to any other GLAccounts. If so, then
 	// compute their totals
@@ -338,7 +338,7 @@ func GetRAAccountBalance(ctx context.Context, bid, lid, raid int64, dt *time.Tim
 	}
 
 	// Get the sum of the activity between requested date and LedgerMarker
-	var activity float64
+	var activity Money
 
 	// TODO(Steve): should we really ignore errors from here?
 	if raid != 0 {
@@ -358,7 +358,7 @@ func GetRAAccountBalance(ctx context.Context, bid, lid, raid int64, dt *time.Tim
 // It's just a wrapper around GetRAAccountBalance with raid set to 0.  This returns
 // the account balance we're after, but with a more obvious function name to call.
 //=============================================================================
-func GetAccountBalance(ctx context.Context, bid, lid int64, dt *time.Time) (float64, error) {
+func GetAccountBalance(ctx context.Context, bid, lid int64, dt *time.Time) (Money, error) {
 	return GetRAAccountBalance(ctx, bid, lid, 0, dt)
 }
 
@@ -366,9 +366,9 @@ func GetAccountBalance(ctx context.Context, bid, lid int64, dt *time.Time) (floa
 // on date dt. If rid is 0 then all transactions are considered. Otherwise,
 // only transactions involving this RID are considered.
 //=============================================================================
-func GetRentableAccountBalance(ctx context.Context, bid, lid, rid int64, dt *time.Time) (float64, error) {
+func GetRentableAccountBalance(ctx context.Context, bid, lid, rid int64, dt *time.Time) (Money, error) {
 	// fmt.Printf("GetRAAccountBalance: bid = %d, lid = %d, rid = %d, dt = %s\n", bid, lid, rid, dt.Format(RRDATEFMT4))
-	bal := float64(0)
+	bal := Money(0)
 	m, err := GetGLAccountChildAccts(ctx, bid, lid) // if parent acct, get info to compute aggregate balance
 	if err != nil {
 		return bal, err
@@ -392,7 +392,7 @@ func GetRentableAccountBalance(ctx context.Context, bid, lid, rid int64, dt *tim
 		// fmt.Printf("LedgerMarkerOnOrBefore( bid=%d, lid=%d, rid=%d,  dt = %10s ) --> LM%08d, lm.Balance = %8.2f ==>  bal = %8.2f\n", bid, lid, rid, dt.Format(RRDATEFMT4), lm.LMID, lm.Balance, bal)
 	}
 	// Get the sum of the activity between requested date and LedgerMarker
-	var activity float64
+	var activity Money
 
 	// TODO(Steve): should really ignore the errors from here?
 	if rid != 0 {
@@ -0,0 +1,431 @@
+package rlib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GTTYPECharge et al. are the GatewayTransaction Types
+const (
+	GTTYPECharge     = 1
+	GTTYPERefund     = 2
+	GTTYPEChargeback = 3
+)
+
+// GTSTATUSSucceeded et al. are the GatewayTransaction Status values. A
+// transaction is pending from before the gateway is called until it is
+// recorded. One that stays pending needs to be checked at the gateway.
+const (
+	GTSTATUSSucceeded = 1
+	GTSTATUSFailed    = 2
+	GTSTATUSPending   = 3
+)
+
+// PGInactive is the PaymentGateway FLAGS bit indicating that the gateway
+// must not be used for new charges
+const PGInactive = 1 << 0
+
+// CARDRemoved is the CardToken FLAGS bit indicating that the payor removed
+// the card
+const CARDRemoved = 1 << 0
+
+// GatewaySettleDays is how many days a Deposit may be dated before or after
+// the arrival of the gateway payout it is matched to
+const GatewaySettleDays = 3
+
+// GatewayCard is a payment card to tokenize. It is only passed through to
+// the gateway, it is never saved.
+type GatewayCard struct {
+	Number   string
+	ExpMonth int64
+	ExpYear  int64
+	CVC      string
+}
+
+// GatewayResult is the outcome of a charge or refund. A decline is not an
+// error: Succeeded is false and Message holds the gateway's reason.
+type GatewayResult struct {
+	TxnID     string
+	Succeeded bool
+	Message   string
+}
+
+// GatewayDispute is a chargeback the card holder's bank made on a charge
+type GatewayDispute struct {
+	ID       string    // dispute id
+	ChargeID string    // TxnID of the charge disputed
+	Amount   Money     // amount taken back
+	Dt       time.Time // when the dispute was opened
+}
+
+// GatewayPayout is a transfer of settled funds from the gateway to the
+// business' bank account
+type GatewayPayout struct {
+	ID     string
+	Amount Money     // net amount transferred
+	Fee    Money     // fees the gateway kept
+	Dt     time.Time // arrival date
+	TxnIDs []string  // the charges, refunds and disputes settled
+}
+
+// GatewaySettlement is a gateway payout with the GatewayTransactions it
+// settled and the Deposit it was recorded as
+type GatewaySettlement struct {
+	Payout  GatewayPayout
+	Gross   Money    // charges less refunds and chargebacks settled
+	GTIDs   []int64  // the GatewayTransactions settled
+	Unknown []string // TxnIDs of the payout that are not recorded here
+	DID     int64    // the matching Deposit, 0 if none was found
+}
+
+// PaymentGatewayAPI is what RentRoll needs from a card payment processor
+type PaymentGatewayAPI interface {
+	Tokenize(c *GatewayCard) (CardToken, error)
+	Charge(token string, amount Money, descr string) (GatewayResult, error)
+	Refund(txnID string, amount Money) (GatewayResult, error)
+	Disputes(d1, d2 *time.Time) ([]GatewayDispute, error)
+	Payouts(d1, d2 *time.Time) ([]GatewayPayout, error)
+}
+
+// GatewayDecline is the error returned when the gateway refuses a request,
+// a card that fails validation for example
+type GatewayDecline struct {
+	Message string
+	TxnID   string // the failed charge, if the gateway made one
+}
+
+func (e *GatewayDecline) Error() string {
+	return e.Message
+}
+
+// GatewayClient is a PaymentGatewayAPI for a gateway with a Stripe style
+// REST API: JSON bodies, amounts in cents, authentication with a bearer
+// API key, and a 402 status when a card is declined.
+type GatewayClient struct {
+	Client *http.Client
+	URL    string // base URL, ex: https://api.gateway.com
+	APIKey string
+}
+
+// NewPaymentGatewayAPI returns the client used to talk to gateway pg. It is
+// a variable so that tests can substitute a fake.
+var NewPaymentGatewayAPI = func(pg *PaymentGateway) PaymentGatewayAPI {
+	return NewGatewayClient(&http.Client{Timeout: 30 * time.Second}, pg)
+}
+
+// NewGatewayClient returns a GatewayClient for pg that makes its requests
+// with client.
+//-----------------------------------------------------------------------------
+func NewGatewayClient(client *http.Client, pg *PaymentGateway) *GatewayClient {
+	return &GatewayClient{
+		Client: client,
+		URL:    strings.TrimRight(pg.URL, "/"),
+		APIKey: pg.APIKey,
+	}
+}
+
+// gatewayError is the body of a non-2xx response
+type gatewayError struct {
+	Error struct {
+		Message string `json:"message"`
+		Charge  string `json:"charge"`
+	} `json:"error"`
+}
+
+// call makes a request to the gateway and decodes the response into out.
+//
+// INPUTS
+//  method - http method
+//  path   - path of the endpoint, ex: /v1/charges
+//  q      - query parameters, may be nil
+//  in     - request body, nil if there is none
+//  out    - where the response body is decoded
+//
+// RETURNS
+//  a *GatewayDecline if the gateway answered 402
+//  any other error encountered
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) call(method, path string, q url.Values, in, out interface{}) error {
+	u := g.URL + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	req.Header.Set("User-Agent", "RentRoll")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e gatewayError
+		json.Unmarshal(b, &e)
+		if len(e.Error.Message) == 0 {
+			e.Error.Message = resp.Status
+		}
+		if resp.StatusCode == http.StatusPaymentRequired {
+			return &GatewayDecline{Message: e.Error.Message, TxnID: e.Error.Charge}
+		}
+		return fmt.Errorf("gateway %s %s: %s", method, path, e.Error.Message)
+	}
+	return json.Unmarshal(b, out)
+}
+
+// gwToken is the response of POST /v1/tokens
+type gwToken struct {
+	ID       string `json:"id"`
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int64  `json:"exp_month"`
+	ExpYear  int64  `json:"exp_year"`
+}
+
+// gwCharge is the response of POST /v1/charges and POST /v1/refunds
+type gwCharge struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"failure_message"`
+}
+
+// gwDispute is an element of GET /v1/disputes
+type gwDispute struct {
+	ID      string `json:"id"`
+	Charge  string `json:"charge"`
+	Amount  int64  `json:"amount"`
+	Created int64  `json:"created"`
+}
+
+// gwPayout is an element of GET /v1/payouts
+type gwPayout struct {
+	ID           string   `json:"id"`
+	Amount       int64    `json:"amount"`
+	Fee          int64    `json:"fee"`
+	ArrivalDate  int64    `json:"arrival_date"`
+	Transactions []string `json:"transactions"`
+}
+
+// Tokenize saves card c at the gateway. The returned CardToken has Token,
+// Brand, Last4 and the expiration date set.
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) Tokenize(c *GatewayCard) (CardToken, error) {
+	var t gwToken
+	in := map[string]interface{}{
+		"number":    c.Number,
+		"exp_month": c.ExpMonth,
+		"exp_year":  c.ExpYear,
+		"cvc":       c.CVC,
+	}
+	if err := g.call("POST", "/v1/tokens", nil, in, &t); err != nil {
+		return CardToken{}, err
+	}
+	return CardToken{Token: t.ID, Brand: t.Brand, Last4: t.Last4, ExpMonth: t.ExpMonth, ExpYear: t.ExpYear}, nil
+}
+
+// Charge charges amount to the card of token
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) Charge(token string, amount Money, descr string) (GatewayResult, error) {
+	in := map[string]interface{}{
+		"amount":      amount.Cents(),
+		"currency":    "usd",
+		"source":      token,
+		"description": descr,
+	}
+	return g.result("/v1/charges", in)
+}
+
+// Refund gives back amount of the charge txnID to the card
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) Refund(txnID string, amount Money) (GatewayResult, error) {
+	in := map[string]interface{}{
+		"charge": txnID,
+		"amount": amount.Cents(),
+	}
+	return g.result("/v1/refunds", in)
+}
+
+// result posts a charge or refund and turns a decline into a GatewayResult
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) result(path string, in interface{}) (GatewayResult, error) {
+	var c gwCharge
+	err := g.call("POST", path, nil, in, &c)
+	if d, ok := err.(*GatewayDecline); ok {
+		return GatewayResult{TxnID: d.TxnID, Message: d.Message}, nil
+	}
+	if err != nil {
+		return GatewayResult{}, err
+	}
+	return GatewayResult{TxnID: c.ID, Succeeded: c.Status == "succeeded", Message: c.Message}, nil
+}
+
+// Disputes returns the disputes opened in the range d1 - d2
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) Disputes(d1, d2 *time.Time) ([]GatewayDispute, error) {
+	var r struct {
+		Data []gwDispute `json:"data"`
+	}
+	q := url.Values{}
+	q.Set("created_gte", fmt.Sprintf("%d", d1.Unix()))
+	q.Set("created_lt", fmt.Sprintf("%d", d2.Unix()))
+	if err := g.call("GET", "/v1/disputes", q, nil, &r); err != nil {
+		return nil, err
+	}
+	m := []GatewayDispute{}
+	for i := 0; i < len(r.Data); i++ {
+		m = append(m, GatewayDispute{
+			ID:       r.Data[i].ID,
+			ChargeID: r.Data[i].Charge,
+			Amount:   Money(r.Data[i].Amount),
+			Dt:       time.Unix(r.Data[i].Created, 0).UTC(),
+		})
+	}
+	return m, nil
+}
+
+// Payouts returns the payouts arriving in the range d1 - d2
+//-----------------------------------------------------------------------------
+func (g *GatewayClient) Payouts(d1, d2 *time.Time) ([]GatewayPayout, error) {
+	var r struct {
+		Data []gwPayout `json:"data"`
+	}
+	q := url.Values{}
+	q.Set("arrival_date_gte", fmt.Sprintf("%d", d1.Unix()))
+	q.Set("arrival_date_lt", fmt.Sprintf("%d", d2.Unix()))
+	if err := g.call("GET", "/v1/payouts", q, nil, &r); err != nil {
+		return nil, err
+	}
+	m := []GatewayPayout{}
+	for i := 0; i < len(r.Data); i++ {
+		m = append(m, GatewayPayout{
+			ID:     r.Data[i].ID,
+			Amount: Money(r.Data[i].Amount),
+			Fee:    Money(r.Data[i].Fee),
+			Dt:     time.Unix(r.Data[i].ArrivalDate, 0).UTC(),
+			TxnIDs: r.Data[i].Transactions,
+		})
+	}
+	return m, nil
+}
+
+// MatchPayoutDeposit finds the Deposit a gateway payout was recorded as. The
+// deposit must be for the gross amount settled or the net amount paid out,
+// and be dated within GatewaySettleDays of the payout's arrival. The one
+// nearest the arrival date that is not already used wins.
+//
+// INPUTS
+//  p     - the payout
+//  gross - total of the transactions it settled
+//  m     - the deposits to the gateway's depository around the arrival date
+//  used  - DIDs already matched to other payouts, the match is added
+//
+// RETURNS
+//  the DID of the deposit, 0 if none matches
+//-----------------------------------------------------------------------------
+func MatchPayoutDeposit(p *GatewayPayout, gross Money, m []Deposit, used map[int64]bool) int64 {
+	var did int64
+	var best time.Duration
+	for i := 0; i < len(m); i++ {
+		if used[m[i].DID] || (m[i].Amount != gross && m[i].Amount != p.Amount) {
+			continue
+		}
+		d := m[i].Dt.Sub(p.Dt)
+		if d < 0 {
+			d = -d
+		}
+		if d > GatewaySettleDays*24*time.Hour {
+			continue
+		}
+		if did == 0 || d < best {
+			did = m[i].DID
+			best = d
+		}
+	}
+	if did > 0 {
+		used[did] = true
+	}
+	return did
+}
+
+// GatewaySettlements matches the payouts of gateway pg that arrived in the
+// range d1 - d2 to the GatewayTransactions they settled and to the Deposits
+// in the gateway's depository they were recorded as. Nothing is saved.
+//
+// INPUTS
+//  ctx - db context
+//  api - client for the gateway
+//  pg  - the gateway
+//  d1  - start of the range
+//  d2  - stop of the range
+//
+// RETURNS
+//  a GatewaySettlement for each payout
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GatewaySettlements(ctx context.Context, api PaymentGatewayAPI, pg *PaymentGateway, d1, d2 *time.Time) ([]GatewaySettlement, error) {
+	var m []GatewaySettlement
+	pm, err := api.Payouts(d1, d2)
+	if err != nil {
+		return m, err
+	}
+	e1 := d1.AddDate(0, 0, -GatewaySettleDays)
+	e2 := d2.AddDate(0, 0, GatewaySettleDays+1)
+	all, err := GetAllDepositsInRange(ctx, pg.BID, &e1, &e2)
+	if err != nil {
+		return m, err
+	}
+	var deps []Deposit
+	for i := 0; i < len(all); i++ {
+		if all[i].DEPID == pg.DEPID {
+			deps = append(deps, all[i])
+		}
+	}
+	used := map[int64]bool{}
+	for i := 0; i < len(pm); i++ {
+		s := GatewaySettlement{Payout: pm[i]}
+		for j := 0; j < len(pm[i].TxnIDs); j++ {
+			t, err := GetGatewayTransactionByTxnID(ctx, pg.PGID, pm[i].TxnIDs[j])
+			if err != nil {
+				return m, err
+			}
+			if t.GTID == 0 || t.Status != GTSTATUSSucceeded {
+				s.Unknown = append(s.Unknown, pm[i].TxnIDs[j])
+				continue
+			}
+			if t.Type == GTTYPECharge {
+				s.Gross += t.Amount
+			} else {
+				s.Gross -= t.Amount
+			}
+			s.GTIDs = append(s.GTIDs, t.GTID)
+		}
+		s.DID = MatchPayoutDeposit(&pm[i], s.Gross, deps, used)
+		m = append(m, s)
+	}
+	return m, nil
+}
@@ -0,0 +1,134 @@
+package rlib
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Payment gateway tests. The client talks to a FakeGateway served locally.
+
+func TestLuhnValid(t *testing.T) {
+	var m = []struct {
+		number string
+		expect bool
+	}{
+		{"4242424242424242", true},
+		{"4242424242424241", false},
+		{"378282246310005", true},
+		{"5555555555554444", true},
+		{"4242-4242-4242-4242", false},
+		{"42", false},
+	}
+	for i := 0; i < len(m); i++ {
+		if r := LuhnValid(m[i].number); r != m[i].expect {
+			t.Errorf("LuhnValid( %q ) expect %t, got %t\n", m[i].number, m[i].expect, r)
+		}
+	}
+}
+
+func TestGatewayClient(t *testing.T) {
+	now := time.Date(2018, time.June, 4, 15, 0, 0, 0, time.UTC)
+	f := NewFakeGateway("sk_test")
+	f.FeePct = 3
+	f.Now = func() time.Time { return now }
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	g := NewGatewayClient(srv.Client(), &PaymentGateway{URL: srv.URL + "/", APIKey: "sk_test"})
+
+	//-----------------------------------------------
+	// tokenize: good, bad check digit, expired
+	//-----------------------------------------------
+	ct, err := g.Tokenize(&GatewayCard{Number: "4242424242424242", ExpMonth: 12, ExpYear: 2020, CVC: "123"})
+	if err != nil || len(ct.Token) == 0 || ct.Brand != "Visa" || ct.Last4 != "4242" || ct.ExpYear != 2020 {
+		t.Fatalf("Tokenize: got %#v, err %v\n", ct, err)
+	}
+	if _, err = g.Tokenize(&GatewayCard{Number: "4242424242424241", ExpMonth: 12, ExpYear: 2020}); err == nil {
+		t.Errorf("Tokenize: expect an error for a bad card number\n")
+	} else if _, ok := err.(*GatewayDecline); !ok {
+		t.Errorf("Tokenize: expect a GatewayDecline, got %v\n", err)
+	}
+	if _, err = g.Tokenize(&GatewayCard{Number: "4242424242424242", ExpMonth: 5, ExpYear: 2018}); err == nil {
+		t.Errorf("Tokenize: expect an error for an expired card\n")
+	}
+
+	//-----------------------------------------------
+	// charges
+	//-----------------------------------------------
+	c, err := g.Charge(ct.Token, 125050, "rent")
+	if err != nil || !c.Succeeded || len(c.TxnID) == 0 {
+		t.Fatalf("Charge: got %#v, err %v\n", c, err)
+	}
+	bad, _ := g.Tokenize(&GatewayCard{Number: FakeCardDeclined, ExpMonth: 1, ExpYear: 2030})
+	d, err := g.Charge(bad.Token, 10000, "rent")
+	if err != nil || d.Succeeded || len(d.Message) == 0 || len(d.TxnID) == 0 {
+		t.Errorf("Charge declined card: got %#v, err %v\n", d, err)
+	}
+	disp, _ := g.Tokenize(&GatewayCard{Number: FakeCardDisputed, ExpMonth: 1, ExpYear: 2030})
+	dc, _ := g.Charge(disp.Token, 20000, "rent")
+
+	//-----------------------------------------------
+	// refunds: partial, too much, the rest
+	//-----------------------------------------------
+	r, err := g.Refund(c.TxnID, 25050)
+	if err != nil || !r.Succeeded {
+		t.Errorf("Refund: got %#v, err %v\n", r, err)
+	}
+	if r, err = g.Refund(c.TxnID, 100001); err != nil || r.Succeeded {
+		t.Errorf("Refund more than the charge: expect a decline, got %#v, err %v\n", r, err)
+	}
+	now = now.AddDate(0, 0, 1)
+	if r, err = g.Refund(c.TxnID, 0); err != nil || !r.Succeeded {
+		t.Errorf("Refund the rest: got %#v, err %v\n", r, err)
+	}
+
+	//-----------------------------------------------
+	// disputes and payouts
+	//-----------------------------------------------
+	d1 := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC)
+	dm, err := g.Disputes(&d1, &d2)
+	if err != nil || len(dm) != 1 || dm[0].ChargeID != dc.TxnID || dm[0].Amount != 20000 {
+		t.Errorf("Disputes: got %#v, err %v\n", dm, err)
+	}
+	pm, err := g.Payouts(&d1, &d2)
+	if err != nil || len(pm) != 2 {
+		t.Fatalf("Payouts: expect 2, got %#v, err %v\n", pm, err)
+	}
+	// June 4: 1250.50 + 200.00 - 250.50 refund - 200.00 dispute, fee 3% of 1450.50
+	if pm[0].Fee != 4352 || pm[0].Amount != 100000-4352 || len(pm[0].TxnIDs) != 4 || !pm[0].Dt.Equal(time.Date(2018, time.June, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Payouts: June 4 got %#v\n", pm[0])
+	}
+	if pm[1].Amount != -100000 || len(pm[1].TxnIDs) != 1 {
+		t.Errorf("Payouts: June 5 got %#v\n", pm[1])
+	}
+
+	g.APIKey = "wrong"
+	if _, err = g.Payouts(&d1, &d2); err == nil {
+		t.Errorf("Payouts: expect an error with a bad API key\n")
+	}
+}
+
+func TestMatchPayoutDeposit(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2018, time.June, day, 0, 0, 0, 0, time.UTC) }
+	p := GatewayPayout{ID: "po_1", Amount: 97000, Fee: 3000, Dt: d(10)}
+	var m = []struct {
+		deps   []Deposit
+		expect int64
+	}{
+		{[]Deposit{{DID: 1, Dt: d(10), Amount: 100000}}, 1},                                      // gross
+		{[]Deposit{{DID: 1, Dt: d(12), Amount: 97000}}, 1},                                       // net
+		{[]Deposit{{DID: 1, Dt: d(14), Amount: 100000}}, 0},                                      // too late
+		{[]Deposit{{DID: 1, Dt: d(10), Amount: 99000}}, 0},                                       // wrong amount
+		{[]Deposit{{DID: 1, Dt: d(8), Amount: 100000}, {DID: 2, Dt: d(11), Amount: 100000}}, 2},  // nearest
+		{[]Deposit{{DID: 5, Dt: d(10), Amount: 100000}, {DID: 2, Dt: d(11), Amount: 100000}}, 2}, // 5 used
+	}
+	for i := 0; i < len(m); i++ {
+		used := map[int64]bool{5: true}
+		if did := MatchPayoutDeposit(&p, 100000, m[i].deps, used); did != m[i].expect {
+			t.Errorf("test %d: MatchPayoutDeposit expect DID %d, got %d\n", i, m[i].expect, did)
+		} else if did > 0 && !used[did] {
+			t.Errorf("test %d: MatchPayoutDeposit did not mark DID %d used\n", i, did)
+		}
+	}
+}
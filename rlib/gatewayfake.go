@@ -0,0 +1,306 @@
+package rlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakeGateway is an in-memory payment gateway that speaks the REST API of
+// GatewayClient. It is used by the tests and for development, serve it with
+// httptest.NewServer or http.ListenAndServe and point a PaymentGateway's URL
+// at it. These card numbers behave specially:
+//
+//      4000000000000002  every charge is declined
+//      4000000000000259  charges succeed and are then disputed in full
+//
+// Any other number that passes the Luhn check and is not expired succeeds.
+// Each day's transactions are paid out 2 days later, less FeePct of the
+// charges.
+type FakeGateway struct {
+	APIKey string           // key the requests must carry
+	FeePct float64          // percent of each charge kept as a fee
+	Now    func() time.Time // clock, time.Now if nil
+
+	mu       sync.Mutex
+	n        int64
+	tokens   map[string]string // token -> card number
+	charges  map[string]*fakeCharge
+	order    []string // charge ids in the order made
+	refunds  []fakeTxn
+	disputes []fakeTxn
+}
+
+// FakeCardDeclined and FakeCardDisputed are the FakeGateway test cards
+const (
+	FakeCardDeclined = "4000000000000002"
+	FakeCardDisputed = "4000000000000259"
+)
+
+type fakeTxn struct {
+	ID      string
+	Charge  string
+	Amount  int64
+	Created time.Time
+}
+
+type fakeCharge struct {
+	fakeTxn
+	Failed   bool
+	Refunded int64
+}
+
+// NewFakeGateway returns a FakeGateway that accepts key
+//-----------------------------------------------------------------------------
+func NewFakeGateway(key string) *FakeGateway {
+	return &FakeGateway{
+		APIKey:  key,
+		tokens:  map[string]string{},
+		charges: map[string]*fakeCharge{},
+	}
+}
+
+// LuhnValid returns true if the check digit of card number s is correct
+//-----------------------------------------------------------------------------
+func LuhnValid(s string) bool {
+	if len(s) < 12 || len(s) > 19 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		c := s[len(s)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// CardBrand returns the brand of card number s from its leading digits
+//-----------------------------------------------------------------------------
+func CardBrand(s string) string {
+	switch {
+	case len(s) == 0:
+		return "Unknown"
+	case s[0] == '4':
+		return "Visa"
+	case s[0] == '5' || s[0] == '2':
+		return "MasterCard"
+	case len(s) > 1 && s[0] == '3' && (s[1] == '4' || s[1] == '7'):
+		return "American Express"
+	case s[0] == '6':
+		return "Discover"
+	}
+	return "Unknown"
+}
+
+func (f *FakeGateway) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+func (f *FakeGateway) id(prefix string) string {
+	f.n++
+	return fmt.Sprintf("%s_%06d", prefix, f.n)
+}
+
+func fakeWrite(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func fakeFail(w http.ResponseWriter, code int, msg, charge string) {
+	var e gatewayError
+	e.Error.Message = msg
+	e.Error.Charge = charge
+	fakeWrite(w, code, &e)
+}
+
+// ServeHTTP handles the gateway API
+//-----------------------------------------------------------------------------
+func (f *FakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+f.APIKey {
+		fakeFail(w, http.StatusUnauthorized, "invalid API key", "")
+		return
+	}
+	var in map[string]interface{}
+	if r.Method == "POST" {
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			fakeFail(w, http.StatusBadRequest, err.Error(), "")
+			return
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v1/tokens":
+		f.token(w, in)
+	case "POST /v1/charges":
+		f.charge(w, in)
+	case "POST /v1/refunds":
+		f.refund(w, in)
+	case "GET /v1/disputes":
+		f.listDisputes(w, r)
+	case "GET /v1/payouts":
+		f.listPayouts(w, r)
+	default:
+		fakeFail(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path, "")
+	}
+}
+
+func fakeStr(in map[string]interface{}, k string) string {
+	s, _ := in[k].(string)
+	return s
+}
+
+func fakeInt(in map[string]interface{}, k string) int64 {
+	x, _ := in[k].(float64)
+	return int64(x)
+}
+
+func (f *FakeGateway) token(w http.ResponseWriter, in map[string]interface{}) {
+	num := fakeStr(in, "number")
+	mo, yr := fakeInt(in, "exp_month"), fakeInt(in, "exp_year")
+	now := f.now()
+	if !LuhnValid(num) {
+		fakeFail(w, http.StatusPaymentRequired, "Your card number is incorrect.", "")
+		return
+	}
+	if mo < 1 || mo > 12 || yr < int64(now.Year()) || (yr == int64(now.Year()) && mo < int64(now.Month())) {
+		fakeFail(w, http.StatusPaymentRequired, "Your card has expired.", "")
+		return
+	}
+	t := gwToken{ID: f.id("tok"), Brand: CardBrand(num), Last4: num[len(num)-4:], ExpMonth: mo, ExpYear: yr}
+	f.tokens[t.ID] = num
+	fakeWrite(w, http.StatusOK, &t)
+}
+
+func (f *FakeGateway) charge(w http.ResponseWriter, in map[string]interface{}) {
+	num, ok := f.tokens[fakeStr(in, "source")]
+	if !ok {
+		fakeFail(w, http.StatusPaymentRequired, "No such token.", "")
+		return
+	}
+	amt := fakeInt(in, "amount")
+	if amt <= 0 {
+		fakeFail(w, http.StatusBadRequest, "amount must be positive", "")
+		return
+	}
+	c := fakeCharge{fakeTxn: fakeTxn{ID: f.id("ch"), Amount: amt, Created: f.now()}}
+	c.Charge = c.ID
+	c.Failed = num == FakeCardDeclined
+	f.charges[c.ID] = &c
+	f.order = append(f.order, c.ID)
+	if c.Failed {
+		fakeFail(w, http.StatusPaymentRequired, "Your card was declined.", c.ID)
+		return
+	}
+	if num == FakeCardDisputed {
+		f.disputes = append(f.disputes, fakeTxn{ID: f.id("dp"), Charge: c.ID, Amount: amt, Created: c.Created})
+	}
+	fakeWrite(w, http.StatusOK, &gwCharge{ID: c.ID, Status: "succeeded"})
+}
+
+func (f *FakeGateway) refund(w http.ResponseWriter, in map[string]interface{}) {
+	c, ok := f.charges[fakeStr(in, "charge")]
+	if !ok || c.Failed {
+		fakeFail(w, http.StatusPaymentRequired, "No such charge.", "")
+		return
+	}
+	amt := fakeInt(in, "amount")
+	if amt == 0 {
+		amt = c.Amount - c.Refunded
+	}
+	if amt <= 0 || amt > c.Amount-c.Refunded {
+		fakeFail(w, http.StatusPaymentRequired, "Refund is greater than the unrefunded amount.", "")
+		return
+	}
+	c.Refunded += amt
+	t := fakeTxn{ID: f.id("re"), Charge: c.ID, Amount: amt, Created: f.now()}
+	f.refunds = append(f.refunds, t)
+	fakeWrite(w, http.StatusOK, &gwCharge{ID: t.ID, Status: "succeeded"})
+}
+
+// fakeRange returns the unix time range of query parameters gte and lt
+func fakeRange(r *http.Request, gte, lt string) (int64, int64) {
+	d1, _ := strconv.ParseInt(r.URL.Query().Get(gte), 10, 64)
+	d2, err := strconv.ParseInt(r.URL.Query().Get(lt), 10, 64)
+	if err != nil {
+		d2 = math.MaxInt64
+	}
+	return d1, d2
+}
+
+func (f *FakeGateway) listDisputes(w http.ResponseWriter, r *http.Request) {
+	var out struct {
+		Data []gwDispute `json:"data"`
+	}
+	out.Data = []gwDispute{}
+	d1, d2 := fakeRange(r, "created_gte", "created_lt")
+	for i := 0; i < len(f.disputes); i++ {
+		t := f.disputes[i].Created.Unix()
+		if t >= d1 && t < d2 {
+			out.Data = append(out.Data, gwDispute{ID: f.disputes[i].ID, Charge: f.disputes[i].Charge, Amount: f.disputes[i].Amount, Created: t})
+		}
+	}
+	fakeWrite(w, http.StatusOK, &out)
+}
+
+func (f *FakeGateway) listPayouts(w http.ResponseWriter, r *http.Request) {
+	var out struct {
+		Data []gwPayout `json:"data"`
+	}
+	out.Data = []gwPayout{}
+	d1, d2 := fakeRange(r, "arrival_date_gte", "arrival_date_lt")
+	m := map[int64]*gwPayout{}
+	var days []int64
+	add := func(t *fakeTxn, amt, fee int64) {
+		c := t.Created.UTC()
+		arrive := time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 2).Unix()
+		if arrive < d1 || arrive >= d2 {
+			return
+		}
+		p, ok := m[arrive]
+		if !ok {
+			p = &gwPayout{ID: "po_" + c.AddDate(0, 0, 2).Format("20060102"), ArrivalDate: arrive, Transactions: []string{}}
+			m[arrive] = p
+			days = append(days, arrive)
+		}
+		p.Amount += amt - fee
+		p.Fee += fee
+		p.Transactions = append(p.Transactions, t.ID)
+	}
+	for i := 0; i < len(f.order); i++ {
+		c := f.charges[f.order[i]]
+		if !c.Failed {
+			add(&c.fakeTxn, c.Amount, int64(math.Floor(float64(c.Amount)*f.FeePct/100+0.5)))
+		}
+	}
+	for i := 0; i < len(f.refunds); i++ {
+		add(&f.refunds[i], -f.refunds[i].Amount, 0)
+	}
+	for i := 0; i < len(f.disputes); i++ {
+		add(&f.disputes[i], -f.disputes[i].Amount, 0)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	for i := 0; i < len(days); i++ {
+		out.Data = append(out.Data, *m[days[i]])
+	}
+	fakeWrite(w, http.StatusOK, &out)
+}
@@ -429,6 +429,32 @@ func GetAllRentableAssessments(ctx context.Context, RID int64, d1, d2 *time.Time
 	return getAssessmentsByRows(ctx, rows)
 }
 
+// GetAllSingleInstanceAssessments returns the non-recurring assessments and
+// the instances of recurring assessments of business bid in the supplied
+// date range
+func GetAllSingleInstanceAssessments(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Assessment, error) {
+	var err error
+	var t []Assessment
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllSingleInstanceAssessments)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllSingleInstanceAssessments.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
 // GetUnpaidAssessmentsByRAID for the supplied RAID
 func GetUnpaidAssessmentsByRAID(ctx context.Context, RAID int64) ([]Assessment, error) {
 	var err error
@@ -521,6 +547,41 @@ func GetRecurringAssessmentDefsByRAID(ctx context.Context, RAID int64, d1, d2 *t
 	return getAssessmentsByRows(ctx, rows)
 }
 
+// GetRecurringAssessmentDefsByBusiness returns a list of the recurring
+// assessment definitions for the supplied business that are in effect during
+// the supplied time range
+// INPUTS
+//    ctx   - context
+//    bid   - business of interest
+//    d1,d2 - Define the time period of interest, d1 = start, d2 = stop
+//
+// RETURNS
+//    a slice of the definitions found
+//    any error encountered
+//-----------------------------------------------------------------------------
+func GetRecurringAssessmentDefsByBusiness(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Assessment, error) {
+	var err error
+	var t []Assessment
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRecurringAssessmentsByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRecurringAssessmentsByBusiness.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
 // GetAssessmentInstancesByRAIDRange returns a list of the recurring instances and
 // non-recurring assessments for the supplied RAID that happen in the supplied
 // time range
@@ -799,7 +860,7 @@ func GetAssessmentFirstInstance(ctx context.Context, pasmid int64) (Assessment,
 }
 
 // GetAssessmentDuplicate returns the Assessment struct for the account with the supplied asmid
-func GetAssessmentDuplicate(ctx context.Context, start *time.Time, amt float64, pasmid, rid, raid int64) (Assessment, error) {
+func GetAssessmentDuplicate(ctx context.Context, start *time.Time, amt Money, pasmid, rid, raid int64) (Assessment, error) {
 
 	var (
 		// err error
@@ -2479,6 +2540,31 @@ func GetLastJournalMarker(ctx context.Context) (JournalMarker, error) {
 	return j, err
 }
 
+// GetLastJournalMarkerByState returns the Journal marker in the supplied
+// state with the latest DtStop in business bid. JMID is 0 if there is none.
+func GetLastJournalMarkerByState(ctx context.Context, bid, state int64) (JournalMarker, error) {
+	var a JournalMarker
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return a, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, state}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLastJournalMarkerByState)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLastJournalMarkerByState.QueryRow(fields...)
+	}
+	return a, ReadJournalMarker(row, &a)
+}
+
 //=======================================================
 //  JOURNAL ALLOCATION
 //=======================================================
@@ -2944,7 +3030,8 @@ func GetTCLedgerMarkerOnOrAfter(ctx context.Context, tcid int64, dt *time.Time)
 }
 
 // GetRentableLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with
-// the supplied LID filtered for the supplied Rentable rid
+// the supplied LID filtered for the supplied Rentable rid. The markers of a
+// rental agreement or payor on the Rentable are not the Rentable's.
 func GetRentableLedgerMarkerOnOrBefore(ctx context.Context, bid, lid, rid int64, dt *time.Time) (LedgerMarker, error) {
 
 	var (
@@ -3001,6 +3088,36 @@ func GetRARentableLedgerMarkerOnOrBefore(ctx context.Context, raid, rid int64, d
 	return r, ReadLedgerMarker(row, &r)
 }
 
+// GetRARAcctLedgerMarkerOnOrBefore returns the LedgerMarker struct for the
+// GLAccount with the supplied LID filtered for the supplied Rental Agreement
+// raid and Rentable rid
+func GetRARAcctLedgerMarkerOnOrBefore(ctx context.Context, bid, lid, raid, rid int64, dt *time.Time) (LedgerMarker, error) {
+
+	var (
+		// err error
+		r LedgerMarker
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return r, ErrSessionRequired
+		}
+	}
+
+	var row *sql.Row
+	fields := []interface{}{bid, lid, raid, rid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRARAcctLedgerMarkerOnOrBefore)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRARAcctLedgerMarkerOnOrBefore.QueryRow(fields...)
+	}
+	return r, ReadLedgerMarker(row, &r)
+}
+
 /*// LoadPayorLedgerMarker returns the LedgerMarker for the supplied bid,tcid
 // values. It loads the marker on-or-before dt.  If no such LedgerMarker exists,
 // then one will be created.
@@ -4701,7 +4818,7 @@ func GetReceiptNoAllocations(ctx context.Context, rcptid int64) (Receipt, error)
 }
 
 // GetReceiptDuplicate returns a Receipt structure for the supplied RCPTID
-func GetReceiptDuplicate(ctx context.Context, dt *time.Time, amt float64, docno string) (Receipt, error) {
+func GetReceiptDuplicate(ctx context.Context, dt *time.Time, amt Money, docno string) (Receipt, error) {
 
 	var (
 		// err error
@@ -4962,17 +5079,17 @@ func GetReceiptAllocationsThroughDate(ctx context.Context, id int64, dt *time.Ti
 // @params
 //	 id = RCPTID of the receipt in question
 //   dt = date on which the unallocated amount is desired
-// @returns  float64 of:
+// @returns  Money of:
 //   receipt amount
 //   amount allocated as of dt
 //   amount unallocated as of dt
-func GetReceiptAllocationAmountsOnDate(ctx context.Context, id int64, dt *time.Time) (float64, float64, float64, error) {
+func GetReceiptAllocationAmountsOnDate(ctx context.Context, id int64, dt *time.Time) (Money, Money, Money, error) {
 
 	var (
 		err     error
-		amt     float64
-		alloc   float64
-		unalloc float64
+		amt     Money
+		alloc   Money
+		unalloc Money
 	)
 
 	// session... context
@@ -5053,6 +5170,50 @@ func GetUnallocatedReceiptsByPayor(ctx context.Context, bid, tcid int64) ([]Rece
 	return t, rows.Err()
 }
 
+// GetUnallocatedReceipts returns the receipts of the supplied business that
+// have not yet been fully allocated.
+func GetUnallocatedReceipts(ctx context.Context, bid int64) ([]Receipt, error) {
+
+	var (
+		err error
+		t   []Receipt
+	)
+
+	// session... context
+	if !(RRdb.noAuth && AppConfig.Env != extres.APPENVPROD) {
+		_, ok := SessionFromContext(ctx)
+		if !ok {
+			return t, ErrSessionRequired
+		}
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUnallocatedReceipts)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetUnallocatedReceipts.Query(fields...)
+	}
+
+	if err != nil {
+		return t, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Receipt
+		err = ReadReceipts(rows, &r)
+		if err != nil {
+			return t, err
+		}
+		t = append(t, r)
+	}
+
+	return t, rows.Err()
+}
+
 // GetPayorUnallocatedReceiptsCount returns a count of unallocated receipts for the supplied bid & tcid
 func GetPayorUnallocatedReceiptsCount(ctx context.Context, bid, tcid int64) (int, error) {
 
@@ -8101,3 +8262,2766 @@ func GetCountBusinessRentalAgreements(ctx context.Context, bid int64) (int, erro
 	}
 	return count, row.Scan(&count)
 }
+
+//=======================================================
+//  REPORT SUBSCRIPTION
+//=======================================================
+
+// GetReportSubscription returns the ReportSubscription with the supplied id
+func GetReportSubscription(ctx context.Context, id int64) (ReportSubscription, error) {
+	var a ReportSubscription
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReportSubscription)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetReportSubscription.QueryRow(fields...)
+	}
+	return a, ReadReportSubscription(row, &a)
+}
+
+// getReportSubscriptionRows reads all the ReportSubscriptions from rows
+func getReportSubscriptionRows(rows *sql.Rows) ([]ReportSubscription, error) {
+	var m []ReportSubscription
+	defer rows.Close()
+	for rows.Next() {
+		var a ReportSubscription
+		if err := ReadReportSubscriptions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetReportSubscriptionsByBID returns all the ReportSubscriptions for the
+// business with the supplied bid
+func GetReportSubscriptionsByBID(ctx context.Context, bid int64) ([]ReportSubscription, error) {
+	var m []ReportSubscription
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReportSubscriptionsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetReportSubscriptionsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	return getReportSubscriptionRows(rows)
+}
+
+// GetDueReportSubscriptions returns all active ReportSubscriptions whose
+// next delivery time is on or before dt
+func GetDueReportSubscriptions(ctx context.Context, dt *time.Time) ([]ReportSubscription, error) {
+	var m []ReportSubscription
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetDueReportSubscriptions)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetDueReportSubscriptions.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	return getReportSubscriptionRows(rows)
+}
+
+// GetReportDeliveries returns the delivery history for the ReportSubscription
+// with the supplied id, most recent first
+func GetReportDeliveries(ctx context.Context, id int64) ([]ReportDelivery, error) {
+	var m []ReportDelivery
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReportDeliveries)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetReportDeliveries.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a ReportDelivery
+		if err = ReadReportDeliveries(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  WEBHOOK SUBSCRIPTION
+//=======================================================
+
+// GetWebhookSubscription returns the WebhookSubscription with the supplied id
+func GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error) {
+	var a WebhookSubscription
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWebhookSubscription)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetWebhookSubscription.QueryRow(fields...)
+	}
+	return a, ReadWebhookSubscription(row, &a)
+}
+
+// GetWebhookSubscriptionsByBID returns all the WebhookSubscriptions for the
+// business with the supplied bid
+func GetWebhookSubscriptionsByBID(ctx context.Context, bid int64) ([]WebhookSubscription, error) {
+	var m []WebhookSubscription
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWebhookSubscriptionsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetWebhookSubscriptionsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a WebhookSubscription
+		if err = ReadWebhookSubscriptions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// getWebhookDeliveryRows reads all the WebhookDeliveries from rows
+func getWebhookDeliveryRows(rows *sql.Rows) ([]WebhookDelivery, error) {
+	var m []WebhookDelivery
+	defer rows.Close()
+	for rows.Next() {
+		var a WebhookDelivery
+		if err := ReadWebhookDeliveries(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetDueWebhookDeliveries returns all pending WebhookDeliveries whose next
+// attempt is due on or before dt
+func GetDueWebhookDeliveries(ctx context.Context, dt *time.Time) ([]WebhookDelivery, error) {
+	var m []WebhookDelivery
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetDueWebhookDeliveries)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetDueWebhookDeliveries.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	return getWebhookDeliveryRows(rows)
+}
+
+// GetWebhookDeliveries returns the most recent limit WebhookDeliveries for
+// the WebhookSubscription with the supplied id, most recent first
+func GetWebhookDeliveries(ctx context.Context, id int64, limit int) ([]WebhookDelivery, error) {
+	var m []WebhookDelivery
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{id, limit}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWebhookDeliveries)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetWebhookDeliveries.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	return getWebhookDeliveryRows(rows)
+}
+
+//=======================================================
+//  CLOSE PERIOD REOPEN
+//=======================================================
+
+// GetClosePeriodReopens returns the audit records of all the periods that
+// have been reopened in the business with the supplied bid, most recent first
+func GetClosePeriodReopens(ctx context.Context, bid int64) ([]ClosePeriodReopen, error) {
+	var m []ClosePeriodReopen
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetClosePeriodReopens)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetClosePeriodReopens.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a ClosePeriodReopen
+		if err = ReadClosePeriodReopens(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  PORTAL USER
+//=======================================================
+
+// GetPortalUser returns the PortalUser with the supplied id
+func GetPortalUser(ctx context.Context, id int64) (PortalUser, error) {
+	var a PortalUser
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPortalUser)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPortalUser.QueryRow(fields...)
+	}
+	return a, ReadPortalUser(row, &a)
+}
+
+// GetPortalUserByUsername returns the PortalUser of business bid with the
+// supplied username
+func GetPortalUserByUsername(ctx context.Context, bid int64, username string) (PortalUser, error) {
+	var a PortalUser
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{bid, username}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPortalUserByUsername)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPortalUserByUsername.QueryRow(fields...)
+	}
+	return a, ReadPortalUser(row, &a)
+}
+
+// GetPortalUserByToken returns the PortalUser whose current session token
+// hashes to token
+func GetPortalUserByToken(ctx context.Context, token string) (PortalUser, error) {
+	var a PortalUser
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{token}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPortalUserByToken)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPortalUserByToken.QueryRow(fields...)
+	}
+	return a, ReadPortalUser(row, &a)
+}
+
+// GetPortalUsersByBID returns all the PortalUsers for the business with
+// the supplied bid
+func GetPortalUsersByBID(ctx context.Context, bid int64) ([]PortalUser, error) {
+	var m []PortalUser
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPortalUsersByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPortalUsersByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PortalUser
+		if err = ReadPortalUsers(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  MAINTENANCE REQUEST
+//=======================================================
+
+// GetMaintRequest returns the MaintRequest with the supplied id
+func GetMaintRequest(ctx context.Context, id int64) (MaintRequest, error) {
+	var a MaintRequest
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMaintRequest)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetMaintRequest.QueryRow(fields...)
+	}
+	return a, ReadMaintRequest(row, &a)
+}
+
+// GetMaintRequestsByTCID returns all the MaintRequests made by transactant
+// tcid in business bid, most recent first
+func GetMaintRequestsByTCID(ctx context.Context, bid, tcid int64) ([]MaintRequest, error) {
+	var m []MaintRequest
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMaintRequestsByTCID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetMaintRequestsByTCID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a MaintRequest
+		if err = ReadMaintRequests(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetOpenMaintRequests returns all the MaintRequests of business bid that
+// are not closed, oldest first
+func GetOpenMaintRequests(ctx context.Context, bid int64) ([]MaintRequest, error) {
+	var m []MaintRequest
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetOpenMaintRequests)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetOpenMaintRequests.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a MaintRequest
+		if err = ReadMaintRequests(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  WORK ORDER
+//=======================================================
+
+// GetWorkOrder returns the WorkOrder with the supplied id
+func GetWorkOrder(ctx context.Context, id int64) (WorkOrder, error) {
+	var a WorkOrder
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetWorkOrder)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetWorkOrder.QueryRow(fields...)
+	}
+	return a, ReadWorkOrder(row, &a)
+}
+
+// GetOpenWorkOrders returns the WorkOrders of business bid that were opened
+// before dt and are not completed or cancelled, oldest first
+func GetOpenWorkOrders(ctx context.Context, bid int64, dt *time.Time) ([]WorkOrder, error) {
+	var m []WorkOrder
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetOpenWorkOrders)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetOpenWorkOrders.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a WorkOrder
+		if err = ReadWorkOrders(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetVendor returns the Vendor with the supplied VID
+func GetVendor(ctx context.Context, id int64) (Vendor, error) {
+	var a Vendor
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendor)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetVendor.QueryRow(fields...)
+	}
+	return a, ReadVendor(row, &a)
+}
+
+// GetVendorsByBID returns the Vendors of business bid sorted by name
+func GetVendorsByBID(ctx context.Context, bid int64) ([]Vendor, error) {
+	var m []Vendor
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendorsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Vendor
+		if err = ReadVendors(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBill returns the Bill with the supplied BILLID. Its
+// BillLines are not loaded, use GetBillLines.
+func GetBill(ctx context.Context, id int64) (Bill, error) {
+	var a Bill
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBill)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBill.QueryRow(fields...)
+	}
+	return a, ReadBill(row, &a)
+}
+
+// GetBillsByDateRange returns the Bills of business bid dated in the
+// range d1 - d2, including void bills
+func GetBillsByDateRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Bill, error) {
+	var m []Bill
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillsByDateRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBillsByDateRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Bill
+		if err = ReadBills(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBillsByVID returns the Bills of Vendor vid, including void bills,
+// sorted by due date
+func GetBillsByVID(ctx context.Context, vid int64) ([]Bill, error) {
+	var m []Bill
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{vid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillsByVID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBillsByVID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Bill
+		if err = ReadBills(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBillsAsOf returns the Bills of business bid dated on or before dt
+// that are not void, sorted by due date
+func GetBillsAsOf(ctx context.Context, bid int64, dt *time.Time) ([]Bill, error) {
+	var m []Bill
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillsAsOf)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBillsAsOf.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Bill
+		if err = ReadBills(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBillLines returns the BillLines of Bill billid
+func GetBillLines(ctx context.Context, billid int64) ([]BillLine, error) {
+	var m []BillLine
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{billid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillLines)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBillLines.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a BillLine
+		if err = ReadBillLines(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetVendorPayment returns the VendorPayment with the supplied VPID. Its
+// allocations are not loaded, use GetVendorPaymentAllocations.
+func GetVendorPayment(ctx context.Context, id int64) (VendorPayment, error) {
+	var a VendorPayment
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorPayment)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetVendorPayment.QueryRow(fields...)
+	}
+	return a, ReadVendorPayment(row, &a)
+}
+
+// GetVendorPaymentsByDateRange returns the VendorPayments of business bid
+// dated in the range d1 - d2, including void payments
+func GetVendorPaymentsByDateRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]VendorPayment, error) {
+	var m []VendorPayment
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorPaymentsByDateRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendorPaymentsByDateRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a VendorPayment
+		if err = ReadVendorPayments(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetVendorPaymentAllocations returns the allocations of VendorPayment vpid
+func GetVendorPaymentAllocations(ctx context.Context, vpid int64) ([]VendorPaymentAllocation, error) {
+	var m []VendorPaymentAllocation
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{vpid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendorPaymentAllocations)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendorPaymentAllocations.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a VendorPaymentAllocation
+		if err = ReadVendorPaymentAllocations(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBillPayments returns the allocations of payments that are not void
+// to Bill billid
+func GetBillPayments(ctx context.Context, billid int64) ([]VendorPaymentAllocation, error) {
+	var m []VendorPaymentAllocation
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{billid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillPayments)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBillPayments.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a VendorPaymentAllocation
+		if err = ReadVendorPaymentAllocations(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBillPaidAmount returns the total paid on Bill billid by payments that are
+// dated on or before dt and are not void
+func GetBillPaidAmount(ctx context.Context, billid int64, dt *time.Time) (Money, error) {
+	var amt Money
+	if _, ok := SessionCheck(ctx); !ok {
+		return amt, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{billid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBillPaidAmount)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBillPaidAmount.QueryRow(fields...)
+	}
+	err := row.Scan(&amt)
+	SkipSQLNoRowsError(&err)
+	return amt, err
+}
+
+// GetVendor1099Totals returns the total paid in the range d1 - d2 to each
+// Vendor of business bid whose payments are reported on a 1099, sorted by
+// vendor name. Void payments are not included.
+func GetVendor1099Totals(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Vendor1099, error) {
+	var m []Vendor1099
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetVendor1099Totals)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetVendor1099Totals.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Vendor1099
+		if err = rows.Scan(&a.VID, &a.Name, &a.TaxID, &a.Total); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCheckAccount returns the CheckAccount with the supplied CKAID
+func GetCheckAccount(ctx context.Context, id int64) (CheckAccount, error) {
+	var a CheckAccount
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCheckAccount)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCheckAccount.QueryRow(fields...)
+	}
+	return a, ReadCheckAccount(row, &a)
+}
+
+// GetCheckAccountByDEPID returns the CheckAccount of Depository depid.
+// CKAID is 0 if the Depository is not set up for checks.
+func GetCheckAccountByDEPID(ctx context.Context, depid int64) (CheckAccount, error) {
+	var a CheckAccount
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{depid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCheckAccountByDEPID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCheckAccountByDEPID.QueryRow(fields...)
+	}
+	return a, ReadCheckAccount(row, &a)
+}
+
+// GetCheckAccountForUpdate returns the CheckAccount of Depository depid and
+// locks it until the transaction in ctx ends. Use it to assign check numbers
+// so that no two checks get the same one.
+func GetCheckAccountForUpdate(ctx context.Context, depid int64) (CheckAccount, error) {
+	var a CheckAccount
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{depid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCheckAccountForUpdate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCheckAccountForUpdate.QueryRow(fields...)
+	}
+	return a, ReadCheckAccount(row, &a)
+}
+
+// GetBankCheck returns the BankCheck with the supplied CKID
+func GetBankCheck(ctx context.Context, id int64) (BankCheck, error) {
+	var a BankCheck
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankCheck)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBankCheck.QueryRow(fields...)
+	}
+	return a, ReadBankCheck(row, &a)
+}
+
+// GetBankCheckByVPID returns the check, if it is not void, written for
+// VendorPayment vpid
+func GetBankCheckByVPID(ctx context.Context, vpid int64) (BankCheck, error) {
+	var a BankCheck
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{vpid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankCheckByVPID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetBankCheckByVPID.QueryRow(fields...)
+	}
+	return a, ReadBankCheck(row, &a)
+}
+
+// GetBankChecksByDEPID returns the check register of Depository depid: the
+// checks dated in the range d1 - d2 in check number order, including void
+// checks
+func GetBankChecksByDEPID(ctx context.Context, depid int64, d1, d2 *time.Time) ([]BankCheck, error) {
+	var m []BankCheck
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{depid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankChecksByDEPID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBankChecksByDEPID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a BankCheck
+		if err = ReadBankChecks(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBankChecksByDateRange returns the checks of business bid dated in the
+// range d1 - d2 sorted by Depository and check number, including void checks
+func GetBankChecksByDateRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]BankCheck, error) {
+	var m []BankCheck
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBankChecksByDateRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBankChecksByDateRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a BankCheck
+		if err = ReadBankChecks(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCheckAccountsWithPositivePay returns the CheckAccounts of all businesses
+// whose bank uses positive pay
+func GetCheckAccountsWithPositivePay(ctx context.Context) ([]CheckAccount, error) {
+	var m []CheckAccount
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	var fields []interface{}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCheckAccountsWithPositivePay)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCheckAccountsWithPositivePay.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a CheckAccount
+		if err = ReadCheckAccounts(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetPositivePayChecks returns the checks of Depository depid that have not
+// been sent to the bank in their current state: checks dated in the range
+// d1 - d2 whose issue has not been sent and void checks dated before d2 whose
+// void has not been sent. The checks are locked until the transaction in ctx
+// ends.
+func GetPositivePayChecks(ctx context.Context, depid int64, d1, d2 *time.Time) ([]BankCheck, error) {
+	var m []BankCheck
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{depid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPositivePayChecks)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPositivePayChecks.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a BankCheck
+		if err = ReadBankChecks(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetPositivePayFormat returns the PositivePayFormat with the supplied PPFID
+func GetPositivePayFormat(ctx context.Context, id int64) (PositivePayFormat, error) {
+	var a PositivePayFormat
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPositivePayFormat)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPositivePayFormat.QueryRow(fields...)
+	}
+	return a, ReadPositivePayFormat(row, &a)
+}
+
+// GetPositivePayFormatsByBID returns the PositivePayFormats of business bid
+// sorted by name
+func GetPositivePayFormatsByBID(ctx context.Context, bid int64) ([]PositivePayFormat, error) {
+	var m []PositivePayFormat
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPositivePayFormatsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPositivePayFormatsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PositivePayFormat
+		if err = ReadPositivePayFormats(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetPositivePayExport returns the PositivePayExport with the supplied PPXID
+func GetPositivePayExport(ctx context.Context, id int64) (PositivePayExport, error) {
+	var a PositivePayExport
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPositivePayExport)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPositivePayExport.QueryRow(fields...)
+	}
+	return a, ReadPositivePayExport(row, &a)
+}
+
+// GetPositivePayExportsByDEPID returns the positive pay files of Depository
+// depid, newest first. Content is not loaded, use GetPositivePayExport.
+func GetPositivePayExportsByDEPID(ctx context.Context, depid int64) ([]PositivePayExport, error) {
+	var m []PositivePayExport
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{depid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPositivePayExportsByDEPID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPositivePayExportsByDEPID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PositivePayExport
+		if err = ReadPositivePayExports(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetLeaseTemplate reads the LeaseTemplate with the supplied LTID
+func GetLeaseTemplate(ctx context.Context, id int64) (LeaseTemplate, error) {
+	var a LeaseTemplate
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseTemplate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLeaseTemplate.QueryRow(fields...)
+	}
+	return a, ReadLeaseTemplate(row, &a)
+}
+
+// GetLeaseTemplateByRATID reads the LeaseTemplate of RentalAgreementTemplate
+// ratid. LTID is 0 if it has none.
+func GetLeaseTemplateByRATID(ctx context.Context, ratid int64) (LeaseTemplate, error) {
+	var a LeaseTemplate
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{ratid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseTemplateByRATID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLeaseTemplateByRATID.QueryRow(fields...)
+	}
+	return a, ReadLeaseTemplate(row, &a)
+}
+
+// GetLeaseTemplatesByBID returns the LeaseTemplates of business bid sorted
+// by title
+func GetLeaseTemplatesByBID(ctx context.Context, bid int64) ([]LeaseTemplate, error) {
+	var m []LeaseTemplate
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseTemplatesByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetLeaseTemplatesByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a LeaseTemplate
+		if err = ReadLeaseTemplates(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetLeaseDocument reads the LeaseDocument with the supplied LDID, including
+// the PDF
+func GetLeaseDocument(ctx context.Context, id int64) (LeaseDocument, error) {
+	var a LeaseDocument
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseDocument)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetLeaseDocument.QueryRow(fields...)
+	}
+	return a, ReadLeaseDocument(row, &a)
+}
+
+// GetLeaseDocumentsByRAID returns the LeaseDocuments of Rental Agreement
+// version raid, newest first. Content is not read.
+func GetLeaseDocumentsByRAID(ctx context.Context, raid int64) ([]LeaseDocument, error) {
+	var m []LeaseDocument
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseDocumentsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetLeaseDocumentsByRAID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a LeaseDocument
+		if err = ReadLeaseDocuments(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetLeaseDocumentsByFlowID returns the LeaseDocuments generated from flow
+// flowid that do not belong to a Rental Agreement yet, newest first. Content
+// is not read.
+func GetLeaseDocumentsByFlowID(ctx context.Context, flowid int64) ([]LeaseDocument, error) {
+	var m []LeaseDocument
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{flowid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetLeaseDocumentsByFlowID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetLeaseDocumentsByFlowID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a LeaseDocument
+		if err = ReadLeaseDocuments(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCAMPool reads the CAMPool with the supplied CAMPID
+func GetCAMPool(ctx context.Context, id int64) (CAMPool, error) {
+	var a CAMPool
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMPool)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCAMPool.QueryRow(fields...)
+	}
+	return a, ReadCAMPool(row, &a)
+}
+
+// GetCAMPoolsByBID returns the CAMPools of business bid sorted by name
+func GetCAMPoolsByBID(ctx context.Context, bid int64) ([]CAMPool, error) {
+	var m []CAMPool
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMPoolsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMPoolsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a CAMPool
+		if err = ReadCAMPools(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCAMRecon reads the CAMRecon with the supplied CAMRID
+func GetCAMRecon(ctx context.Context, id int64) (CAMRecon, error) {
+	var a CAMRecon
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMRecon)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCAMRecon.QueryRow(fields...)
+	}
+	return a, ReadCAMRecon(row, &a)
+}
+
+// GetCAMReconsByCAMPID returns the reconciliations of CAMPool campid, newest
+// year first
+func GetCAMReconsByCAMPID(ctx context.Context, campid int64) ([]CAMRecon, error) {
+	var m []CAMRecon
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{campid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconsByCAMPID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMReconsByCAMPID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a CAMRecon
+		if err = ReadCAMRecons(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCAMReconItem reads the CAMReconItem with the supplied CAMRIID
+func GetCAMReconItem(ctx context.Context, id int64) (CAMReconItem, error) {
+	var a CAMReconItem
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconItem)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCAMReconItem.QueryRow(fields...)
+	}
+	return a, ReadCAMReconItem(row, &a)
+}
+
+// GetCAMReconItems returns the tenant items of reconciliation camrid
+func GetCAMReconItems(ctx context.Context, camrid int64) ([]CAMReconItem, error) {
+	var m []CAMReconItem
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{camrid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCAMReconItems)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCAMReconItems.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a CAMReconItem
+		if err = ReadCAMReconItems(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetAssessmentsByRAIDARID returns the assessments of Rental Agreement raid
+// made with account rule arid that start in the range d1 - d2. Recurring
+// definitions and reversed assessments are not included.
+func GetAssessmentsByRAIDARID(ctx context.Context, raid, arid int64, d1, d2 *time.Time) ([]Assessment, error) {
+	var m []Assessment
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid, arid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentsByRAIDARID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentsByRAIDARID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Assessment
+		if err = ReadAssessments(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentablesByBusiness returns all the Rentables of business bid
+func GetRentablesByBusiness(ctx context.Context, bid int64) ([]Rentable, error) {
+	var m []Rentable
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentablesByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRentablesByBusiness.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Rentable
+		if err = ReadRentables(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentalAgreementsByRange returns the Rental Agreements of business bid
+// whose agreement term overlaps the range d1 - d2
+func GetRentalAgreementsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]RentalAgreement, error) {
+	var m []RentalAgreement
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllRentalAgreementsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllRentalAgreementsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreement
+		if err = ReadRentalAgreements(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentalAgreementsByBusiness returns all the Rental Agreements of
+// business bid
+func GetRentalAgreementsByBusiness(ctx context.Context, bid int64) ([]RentalAgreement, error) {
+	var m []RentalAgreement
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementByBusiness.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreement
+		if err = ReadRentalAgreements(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetPctRent reads the PctRent with the supplied PRID
+func GetPctRent(ctx context.Context, id int64) (PctRent, error) {
+	var a PctRent
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPctRent)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPctRent.QueryRow(fields...)
+	}
+	return a, ReadPctRent(row, &a)
+}
+
+// GetPctRentByRAID reads the percentage rent terms of Rental Agreement raid.
+// PRID is 0 if it has none.
+func GetPctRentByRAID(ctx context.Context, raid int64) (PctRent, error) {
+	var a PctRent
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPctRentByRAID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPctRentByRAID.QueryRow(fields...)
+	}
+	return a, ReadPctRent(row, &a)
+}
+
+// GetPctRentsByBID returns the percentage rent terms of business bid
+func GetPctRentsByBID(ctx context.Context, bid int64) ([]PctRent, error) {
+	var m []PctRent
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPctRentsByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPctRentsByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PctRent
+		if err = ReadPctRents(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetPctRentTiers returns the tiers of PctRent prid, lowest breakpoint first
+func GetPctRentTiers(ctx context.Context, prid int64) ([]PctRentTier, error) {
+	var m []PctRentTier
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{prid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPctRentTiers)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPctRentTiers.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PctRentTier
+		if err = ReadPctRentTiers(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetSalesReport reads the SalesReport with the supplied SRID
+func GetSalesReport(ctx context.Context, id int64) (SalesReport, error) {
+	var a SalesReport
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSalesReport)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetSalesReport.QueryRow(fields...)
+	}
+	return a, ReadSalesReport(row, &a)
+}
+
+// GetSalesReportsByRAID returns the sales reports of Rental Agreement raid,
+// latest period first
+func GetSalesReportsByRAID(ctx context.Context, raid int64) ([]SalesReport, error) {
+	var m []SalesReport
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSalesReportsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSalesReportsByRAID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a SalesReport
+		if err = ReadSalesReports(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetSalesReportsByRange returns the sales reports of business bid for
+// periods starting in the range d1 - d2
+func GetSalesReportsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]SalesReport, error) {
+	var m []SalesReport
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSalesReportsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSalesReportsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a SalesReport
+		if err = ReadSalesReports(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetSalesReportsToCompute returns the sales reports of all businesses whose
+// period ended by dt and whose overage has not been computed
+func GetSalesReportsToCompute(ctx context.Context, dt *time.Time) ([]SalesReport, error) {
+	var m []SalesReport
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetSalesReportsToCompute)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetSalesReportsToCompute.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a SalesReport
+		if err = ReadSalesReports(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetReservation reads the Reservation with the supplied RESID
+func GetReservation(ctx context.Context, id int64) (Reservation, error) {
+	var a Reservation
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReservation)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetReservation.QueryRow(fields...)
+	}
+	return a, ReadReservation(row, &a)
+}
+
+// GetReservationsByRange returns the Reservations of business bid, in any
+// state, for stays that overlap d1 - d2
+func GetReservationsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Reservation, error) {
+	var m []Reservation
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetReservationsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetReservationsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Reservation
+		if err = ReadReservations(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetBookedReservationsByRID returns the Reservations of room rid that are
+// booked, not yet checked in, for stays that overlap d1 - d2
+func GetBookedReservationsByRID(ctx context.Context, rid int64, d1, d2 *time.Time) ([]Reservation, error) {
+	var m []Reservation
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{rid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetBookedReservationsByRID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetBookedReservationsByRID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Reservation
+		if err = ReadReservations(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetUnassignedReservations returns the booked Reservations of business bid
+// for a room of type rtid that have no room assigned yet, for stays that
+// overlap d1 - d2
+func GetUnassignedReservations(ctx context.Context, bid, rtid int64, d1, d2 *time.Time) ([]Reservation, error) {
+	var m []Reservation
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, rtid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUnassignedReservations)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetUnassignedReservations.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Reservation
+		if err = ReadReservations(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentablesByRTID returns the Rentables of business bid that are of type
+// rtid at some time during d1 - d2
+func GetRentablesByRTID(ctx context.Context, bid, rtid int64, d1, d2 *time.Time) ([]Rentable, error) {
+	var m []Rentable
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, rtid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentablesByRTID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentablesByRTID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Rentable
+		if err = ReadRentables(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  CONCESSION
+//=======================================================
+
+// GetConcession reads the Concession with the supplied id
+func GetConcession(ctx context.Context, id int64) (Concession, error) {
+	var a Concession
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetConcession)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetConcession.QueryRow(fields...)
+	}
+	return a, ReadConcession(row, &a)
+}
+
+// GetConcessionsByBusiness returns the Concessions of business bid sorted
+// by name
+func GetConcessionsByBusiness(ctx context.Context, bid int64) ([]Concession, error) {
+	var m []Concession
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetConcessionsByBusiness)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetConcessionsByBusiness.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Concession
+		if err = ReadConcessions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentalAgreementConcession reads the RentalAgreementConcession with the
+// supplied id
+func GetRentalAgreementConcession(ctx context.Context, id int64) (RentalAgreementConcession, error) {
+	var a RentalAgreementConcession
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementConcession)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetRentalAgreementConcession.QueryRow(fields...)
+	}
+	return a, ReadRentalAgreementConcession(row, &a)
+}
+
+// GetRentalAgreementConcessionsByRAID returns all the concessions given in
+// Rental Agreement raid
+func GetRentalAgreementConcessionsByRAID(ctx context.Context, raid int64) ([]RentalAgreementConcession, error) {
+	var m []RentalAgreementConcession
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementConcessionsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementConcessionsByRAID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreementConcession
+		if err = ReadRentalAgreementConcessions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetRentalAgreementConcessionsByRID returns the concessions on the rent of
+// Rentable rid in Rental Agreement raid that still have rent charges to
+// discount and that start on or before dt
+func GetRentalAgreementConcessionsByRID(ctx context.Context, raid, rid int64, dt *time.Time) ([]RentalAgreementConcession, error) {
+	var m []RentalAgreementConcession
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid, rid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentalAgreementConcessionsByRID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentalAgreementConcessionsByRID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreementConcession
+		if err = ReadRentalAgreementConcessions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetConcessionAssessments returns the assessments of business bid that
+// post concessions, dated d1 up to d2. They are the assessments linked to
+// the rent assessment they discount. Reversed assessments are left out.
+func GetConcessionAssessments(ctx context.Context, bid int64, d1, d2 *time.Time) ([]Assessment, error) {
+	var err error
+	var t []Assessment
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{bid, ELEMASSESSMENT, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetConcessionAssessments)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetConcessionAssessments.Query(fields...)
+	}
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
+// GetConcessionAssessmentsByASMID returns the assessments that posted the
+// concessions on the rent assessment asmid, reversed ones included, in the
+// order they were posted.
+func GetConcessionAssessmentsByASMID(ctx context.Context, asmid int64) ([]Assessment, error) {
+	var err error
+	var t []Assessment
+	if _, ok := SessionCheck(ctx); !ok {
+		return t, ErrSessionRequired
+	}
+
+	var rows *sql.Rows
+	fields := []interface{}{ELEMASSESSMENT, asmid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetConcessionAssessmentsByASMID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetConcessionAssessmentsByASMID.Query(fields...)
+	}
+	if err != nil {
+		return t, err
+	}
+	return getAssessmentsByRows(ctx, rows)
+}
+
+// GetRentCollectedByRentable returns a map of RID to the total amount of
+// payments applied to rent assessments for that Rentable during d1 - d2.
+// Reversed allocations are not included.
+func GetRentCollectedByRentable(ctx context.Context, bid int64, d1, d2 *time.Time) (map[int64]Money, error) {
+	var m = map[int64]Money{}
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2, 1 << ARIsRentASM}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetRentCollectedByRentable)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetRentCollectedByRentable.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rid int64
+		var amt Money
+		if err = rows.Scan(&rid, &amt); err != nil {
+			return m, err
+		}
+		m[rid] = amt
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  UTILITY BILLING
+//=======================================================
+
+// GetUtilityBill reads the UtilityBill with the supplied id
+func GetUtilityBill(ctx context.Context, id int64) (UtilityBill, error) {
+	var a UtilityBill
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUtilityBill)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetUtilityBill.QueryRow(fields...)
+	}
+	return a, ReadUtilityBill(row, &a)
+}
+
+// GetUtilityBillsByRange returns the UtilityBills of business bid
+// whose billing period overlaps d1 - d2
+func GetUtilityBillsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]UtilityBill, error) {
+	var m []UtilityBill
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d2, d1}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUtilityBillsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetUtilityBillsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a UtilityBill
+		if err = ReadUtilityBills(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetUtilityCharges returns the UtilityCharges of UtilityBill ubid
+func GetUtilityCharges(ctx context.Context, ubid int64) ([]UtilityCharge, error) {
+	var m []UtilityCharge
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{ubid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUtilityCharges)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetUtilityCharges.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a UtilityCharge
+		if err = ReadUtilityCharges(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetUtilityRatios returns the UtilityRatios of UtilityBill ubid
+func GetUtilityRatios(ctx context.Context, ubid int64) ([]UtilityRatio, error) {
+	var m []UtilityRatio
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{ubid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUtilityRatios)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetUtilityRatios.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a UtilityRatio
+		if err = ReadUtilityRatios(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetUtilityChargeByASMID returns the UtilityCharge posted with
+// assessment asmid, UCID is 0 if there is none
+func GetUtilityChargeByASMID(ctx context.Context, asmid int64) (UtilityCharge, error) {
+	var a UtilityCharge
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{asmid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetUtilityChargeByASMID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetUtilityChargeByASMID.QueryRow(fields...)
+	}
+	return a, ReadUtilityCharge(row, &a)
+}
+
+// GetMeterReading reads the MeterReading with the supplied id
+func GetMeterReading(ctx context.Context, id int64) (MeterReading, error) {
+	var a MeterReading
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMeterReading)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetMeterReading.QueryRow(fields...)
+	}
+	return a, ReadMeterReading(row, &a)
+}
+
+// GetMeterReadingsByRange returns the readings of the sub-meters for
+// utility of business bid taken d1 through d2, both included, sorted by
+// Rentable and date
+func GetMeterReadingsByRange(ctx context.Context, bid int64, utility string, d1, d2 *time.Time) ([]MeterReading, error) {
+	var m []MeterReading
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, utility, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetMeterReadingsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetMeterReadingsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a MeterReading
+		if err = ReadMeterReadings(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  ASSESSMENT STEP
+//=======================================================
+
+// GetAssessmentStep reads the AssessmentStep with the supplied id
+func GetAssessmentStep(ctx context.Context, id int64) (AssessmentStep, error) {
+	var a AssessmentStep
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentStep)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetAssessmentStep.QueryRow(fields...)
+	}
+	return a, ReadAssessmentStep(row, &a)
+}
+
+// GetAssessmentStepsByASMID returns the steps of recurring assessment
+// asmid sorted by date
+func GetAssessmentStepsByASMID(ctx context.Context, asmid int64) ([]AssessmentStep, error) {
+	var m []AssessmentStep
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{asmid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentStepsByASMID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentStepsByASMID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a AssessmentStep
+		if err = ReadAssessmentSteps(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetAssessmentStepsByRAID returns the steps of the assessments of
+// Rental Agreement raid sorted by date
+func GetAssessmentStepsByRAID(ctx context.Context, raid int64) ([]AssessmentStep, error) {
+	var m []AssessmentStep
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{raid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentStepsByRAID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentStepsByRAID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a AssessmentStep
+		if err = ReadAssessmentSteps(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetAssessmentStepsDue returns the steps of all businesses that
+// have not been applied and take effect on or before dt
+func GetAssessmentStepsDue(ctx context.Context, dt *time.Time) ([]AssessmentStep, error) {
+	var m []AssessmentStep
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentStepsDue)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentStepsDue.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a AssessmentStep
+		if err = ReadAssessmentSteps(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetAssessmentStepsPending returns the steps of business bid that
+// have not been applied and take effect before dt, sorted by Rental
+// Agreement, assessment and date
+func GetAssessmentStepsPending(ctx context.Context, bid int64, dt *time.Time) ([]AssessmentStep, error) {
+	var m []AssessmentStep
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, dt}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAssessmentStepsPending)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetAssessmentStepsPending.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a AssessmentStep
+		if err = ReadAssessmentSteps(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+//=======================================================
+//  PAYMENT GATEWAY
+//=======================================================
+
+// GetPaymentGateway reads the PaymentGateway with the supplied id
+func GetPaymentGateway(ctx context.Context, id int64) (PaymentGateway, error) {
+	var a PaymentGateway
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentGateway)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetPaymentGateway.QueryRow(fields...)
+	}
+	return a, ReadPaymentGateway(row, &a)
+}
+
+// GetPaymentGatewaysByBID returns the PaymentGateways of business bid
+func GetPaymentGatewaysByBID(ctx context.Context, bid int64) ([]PaymentGateway, error) {
+	var m []PaymentGateway
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetPaymentGatewaysByBID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetPaymentGatewaysByBID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PaymentGateway
+		if err = ReadPaymentGateways(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetAllPaymentGateways returns the active PaymentGateways of all
+// businesses
+func GetAllPaymentGateways(ctx context.Context) ([]PaymentGateway, error) {
+	var m []PaymentGateway
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetAllPaymentGateways)
+		defer stmt.Close()
+		rows, err = stmt.Query()
+	} else {
+		rows, err = RRdb.Prepstmt.GetAllPaymentGateways.Query()
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a PaymentGateway
+		if err = ReadPaymentGateways(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetCardToken reads the CardToken with the supplied id
+func GetCardToken(ctx context.Context, id int64) (CardToken, error) {
+	var a CardToken
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCardToken)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetCardToken.QueryRow(fields...)
+	}
+	return a, ReadCardToken(row, &a)
+}
+
+// GetCardTokensByTCID returns the cards of Transactant tcid that
+// have not been removed
+func GetCardTokensByTCID(ctx context.Context, tcid int64) ([]CardToken, error) {
+	var m []CardToken
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetCardTokensByTCID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetCardTokensByTCID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a CardToken
+		if err = ReadCardTokens(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetGatewayTransaction reads the GatewayTransaction with the
+// supplied id
+func GetGatewayTransaction(ctx context.Context, id int64) (GatewayTransaction, error) {
+	var a GatewayTransaction
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransaction)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetGatewayTransaction.QueryRow(fields...)
+	}
+	return a, ReadGatewayTransaction(row, &a)
+}
+
+// GetGatewayTransactionForUpdate returns the GatewayTransaction with the
+// supplied GTID and locks it until the transaction in ctx ends. Lock a charge
+// with it before taking anything back from it so that no more than the
+// charge can be refunded or charged back.
+func GetGatewayTransactionForUpdate(ctx context.Context, id int64) (GatewayTransaction, error) {
+	var a GatewayTransaction
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{id}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransactionForUpdate)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetGatewayTransactionForUpdate.QueryRow(fields...)
+	}
+	return a, ReadGatewayTransaction(row, &a)
+}
+
+// GetGatewayTransactionByTxnID returns the transaction of gateway
+// pgid with gateway transaction id txnid, GTID is 0 if there is none
+func GetGatewayTransactionByTxnID(ctx context.Context, pgid int64, txnid string) (GatewayTransaction, error) {
+	var a GatewayTransaction
+	if _, ok := SessionCheck(ctx); !ok {
+		return a, ErrSessionRequired
+	}
+	var row *sql.Row
+	fields := []interface{}{pgid, txnid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransactionByTxnID)
+		defer stmt.Close()
+		row = stmt.QueryRow(fields...)
+	} else {
+		row = RRdb.Prepstmt.GetGatewayTransactionByTxnID.QueryRow(fields...)
+	}
+	return a, ReadGatewayTransaction(row, &a)
+}
+
+// GetGatewayTransactionsByTCID returns the transactions of payor
+// tcid, latest first
+func GetGatewayTransactionsByTCID(ctx context.Context, tcid int64) ([]GatewayTransaction, error) {
+	var m []GatewayTransaction
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{tcid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransactionsByTCID)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetGatewayTransactionsByTCID.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a GatewayTransaction
+		if err = ReadGatewayTransactions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetGatewayTransactionsByRange returns the transactions of business
+// bid dated d1 up to d2
+func GetGatewayTransactionsByRange(ctx context.Context, bid int64, d1, d2 *time.Time) ([]GatewayTransaction, error) {
+	var m []GatewayTransaction
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{bid, d1, d2}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransactionsByRange)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetGatewayTransactionsByRange.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a GatewayTransaction
+		if err = ReadGatewayTransactions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
+
+// GetGatewayTransactionsByParent returns the refunds and chargebacks of
+// charge gtid
+func GetGatewayTransactionsByParent(ctx context.Context, gtid int64) ([]GatewayTransaction, error) {
+	var m []GatewayTransaction
+	var err error
+	if _, ok := SessionCheck(ctx); !ok {
+		return m, ErrSessionRequired
+	}
+	var rows *sql.Rows
+	fields := []interface{}{gtid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.GetGatewayTransactionsByParent)
+		defer stmt.Close()
+		rows, err = stmt.Query(fields...)
+	} else {
+		rows, err = RRdb.Prepstmt.GetGatewayTransactionsByParent.Query(fields...)
+	}
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a GatewayTransaction
+		if err = ReadGatewayTransactions(rows, &a); err != nil {
+			return m, err
+		}
+		m = append(m, a)
+	}
+	return m, rows.Err()
+}
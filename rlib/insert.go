@@ -93,7 +93,7 @@ func InsertAssessment(ctx context.Context, a *Assessment) (int64, error) {
 	}
 
 	// ROUND OFF Amount up to 2 decimals
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 
 	// transaction... context
 	fields := []interface{}{
@@ -677,7 +677,7 @@ func InsertExpense(ctx context.Context, a *Expense) (int64, error) {
 		a.LastModBy = a.CreateBy
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	// transaction... context
 	fields := []interface{}{a.RPEXPID, a.BID, a.RID, a.RAID, a.Amount, a.Dt, a.AcctRule, a.ARID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
@@ -1565,7 +1565,7 @@ func InsertReceipt(ctx context.Context, a *Receipt) (int64, error) {
 		a.LastModBy = a.CreateBy
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	// transaction... context
 	fields := []interface{}{a.PRCPTID, a.BID, a.TCID, a.PMTID, a.DEPID, a.DID, a.RAID, a.Dt, a.DocNo, a.Amount, a.AcctRuleReceive, a.ARID, a.AcctRuleApply, a.FLAGS, a.Comment, a.OtherPayorName, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
@@ -1610,7 +1610,7 @@ func InsertReceiptAllocation(ctx context.Context, a *ReceiptAllocation) (int64,
 		a.LastModBy = a.CreateBy
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	// transaction... context
 	fields := []interface{}{a.RCPTID, a.BID, a.RAID, a.Dt, a.Amount, a.ASMID, a.FLAGS, a.AcctRule, a.CreateBy, a.LastModBy}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
@@ -2868,3 +2868,975 @@ func InsertVehicle(ctx context.Context, a *Vehicle) (int64, error) {
 	}
 	return rid, err
 }
+
+// InsertReportSubscription writes a new ReportSubscription record to the database
+func InsertReportSubscription(ctx context.Context, a *ReportSubscription) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.ReportName, a.DateRange, a.Format, a.Recipients, a.Cycle, a.DtNext, a.DtLast, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertReportSubscription)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertReportSubscription.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.RSUBID = int64(x)
+		}
+	} else {
+		err = insertError(err, "ReportSubscription", *a)
+	}
+	return err
+}
+
+// InsertReportDelivery writes a new ReportDelivery record to the database
+func InsertReportDelivery(ctx context.Context, a *ReportDelivery) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.RSUBID, a.BID, a.Dt, a.DtStart, a.DtStop, a.Recipients, a.Status, a.Message, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertReportDelivery)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertReportDelivery.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.RDID = int64(x)
+		}
+	} else {
+		err = insertError(err, "ReportDelivery", *a)
+	}
+	return err
+}
+
+// InsertWebhookSubscription writes a new WebhookSubscription record to the database
+func InsertWebhookSubscription(ctx context.Context, a *WebhookSubscription) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.URL, a.Secret, a.Events, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertWebhookSubscription)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertWebhookSubscription.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.WHSID = int64(x)
+		}
+	} else {
+		err = insertError(err, "WebhookSubscription", *a)
+	}
+	return err
+}
+
+// InsertWebhookDelivery writes a new WebhookDelivery record to the database
+func InsertWebhookDelivery(ctx context.Context, a *WebhookDelivery) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.WHSID, a.BID, a.Event, a.Payload, a.Status, a.Attempts, a.DtNext, a.DtLast, a.ResponseCode, a.Message, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertWebhookDelivery)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertWebhookDelivery.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.WHDID = int64(x)
+		}
+	} else {
+		err = insertError(err, "WebhookDelivery", *a)
+	}
+	return err
+}
+
+// InsertClosePeriodReopen writes a new ClosePeriodReopen record to the database
+func InsertClosePeriodReopen(ctx context.Context, a *ClosePeriodReopen) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.CPID, a.TLID, a.Dt, a.Reason, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertClosePeriodReopen)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertClosePeriodReopen.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CPRID = int64(x)
+		}
+	} else {
+		err = insertError(err, "ClosePeriodReopen", *a)
+	}
+	return err
+}
+
+// InsertPortalUser writes a new PortalUser record to the database
+func InsertPortalUser(ctx context.Context, a *PortalUser) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.TCID, a.Username, a.PassHash, a.Salt, a.Token, a.TokenExpire, a.LastLogin, a.FailedLogins, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPortalUser)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPortalUser.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PUID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PortalUser", *a)
+	}
+	return err
+}
+
+// InsertMaintRequest writes a new MaintRequest record to the database
+func InsertMaintRequest(ctx context.Context, a *MaintRequest) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt, a.Subject, a.Descr, a.Status, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertMaintRequest)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertMaintRequest.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.MRQID = int64(x)
+		}
+	} else {
+		err = insertError(err, "MaintRequest", *a)
+	}
+	return err
+}
+
+// InsertWorkOrder writes a new WorkOrder record to the database
+func InsertWorkOrder(ctx context.Context, a *WorkOrder) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RID, a.RAID, a.MRQID, a.NLID, a.Category, a.Priority, a.Status, a.AssignedUID, a.AssignedTo, a.Descr, a.DtOpen, a.DtDone, a.Cost, a.ChargeASMID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertWorkOrder)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertWorkOrder.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.WOID = int64(x)
+		}
+	} else {
+		err = insertError(err, "WorkOrder", *a)
+	}
+	return err
+}
+
+// InsertVendor writes a new Vendor record to the database
+func InsertVendor(ctx context.Context, a *Vendor) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Name, a.Contact, a.Address, a.Address2, a.City, a.State, a.PostalCode, a.Country, a.Email, a.Phone, a.TaxID, a.DefaultLID, a.Terms, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendor)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendor.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.VID = int64(x)
+		}
+	} else {
+		err = insertError(err, "Vendor", *a)
+	}
+	return err
+}
+
+// InsertBill writes a new Bill record to the database
+func InsertBill(ctx context.Context, a *Bill) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.VID, a.InvoiceNo, a.Dt, a.DtDue, a.Amount, a.APLID, a.JID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBill)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBill.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.BILLID = int64(x)
+		}
+	} else {
+		err = insertError(err, "Bill", *a)
+	}
+	return err
+}
+
+// InsertBillLine writes a new BillLine record to the database
+func InsertBillLine(ctx context.Context, a *BillLine) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.BILLID, a.LID, a.RID, a.Amount, a.Descr, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBillLine)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBillLine.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.BLID = int64(x)
+		}
+	} else {
+		err = insertError(err, "BillLine", *a)
+	}
+	return err
+}
+
+// InsertVendorPayment writes a new VendorPayment record to the database
+func InsertVendorPayment(ctx context.Context, a *VendorPayment) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.VID, a.DEPID, a.Dt, a.Amount, a.Method, a.DocNo, a.JID, a.FLAGS, a.Comment, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendorPayment)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendorPayment.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.VPID = int64(x)
+		}
+	} else {
+		err = insertError(err, "VendorPayment", *a)
+	}
+	return err
+}
+
+// InsertVendorPaymentAllocation writes a new VendorPaymentAllocation record to the database
+func InsertVendorPaymentAllocation(ctx context.Context, a *VendorPaymentAllocation) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.VPID, a.BILLID, a.Amount, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertVendorPaymentAllocation)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertVendorPaymentAllocation.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.VPAID = int64(x)
+		}
+	} else {
+		err = insertError(err, "VendorPaymentAllocation", *a)
+	}
+	return err
+}
+
+// InsertCheckAccount writes a new CheckAccount record to the database
+func InsertCheckAccount(ctx context.Context, a *CheckAccount) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.DEPID, a.BankName, a.BankAddress, a.RoutingNo, a.FractionalNo, a.PayerName, a.PayerAddress, a.NextCheckNo, a.PPFID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCheckAccount)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCheckAccount.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CKAID = int64(x)
+		}
+	} else {
+		err = insertError(err, "CheckAccount", *a)
+	}
+	return err
+}
+
+// InsertBankCheck writes a new BankCheck record to the database
+func InsertBankCheck(ctx context.Context, a *BankCheck) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.DEPID, a.CheckNo, a.Dt, a.Payee, a.PayeeAddress, a.Amount, a.Memo, a.DebitLID, a.VPID, a.TCID, a.RAID, a.JID, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertBankCheck)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertBankCheck.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CKID = int64(x)
+		}
+	} else {
+		err = insertError(err, "BankCheck", *a)
+	}
+	return err
+}
+
+// InsertPositivePayFormat writes a new PositivePayFormat record to the database
+func InsertPositivePayFormat(ctx context.Context, a *PositivePayFormat) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Name, a.Header, a.Detail, a.Trailer, a.FileExt, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPositivePayFormat)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPositivePayFormat.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PPFID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PositivePayFormat", *a)
+	}
+	return err
+}
+
+// InsertPositivePayExport writes a new PositivePayExport record to the database
+func InsertPositivePayExport(ctx context.Context, a *PositivePayExport) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.DEPID, a.PPFID, a.DtStart, a.DtStop, a.Items, a.IssuedTotal, a.VoidTotal, a.FileName, a.Content, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPositivePayExport)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPositivePayExport.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PPXID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PositivePayExport", *a)
+	}
+	return err
+}
+
+// InsertLeaseTemplate writes a new LeaseTemplate record to the database
+func InsertLeaseTemplate(ctx context.Context, a *LeaseTemplate) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RATID, a.Title, a.Body, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertLeaseTemplate)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertLeaseTemplate.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.LTID = int64(x)
+		}
+	} else {
+		err = insertError(err, "LeaseTemplate", *a)
+	}
+	return err
+}
+
+// InsertLeaseDocument writes a new LeaseDocument record to the database
+func InsertLeaseDocument(ctx context.Context, a *LeaseDocument) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.FlowID, a.LTID, a.FileName, a.Content, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertLeaseDocument)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertLeaseDocument.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.LDID = int64(x)
+		}
+	} else {
+		err = insertError(err, "LeaseDocument", *a)
+	}
+	return err
+}
+
+// InsertCAMPool writes a new CAMPool record to the database
+func InsertCAMPool(ctx context.Context, a *CAMPool) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Name, a.LIDs, a.EstimateARID, a.TrueUpARID, a.CreditARID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCAMPool)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCAMPool.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CAMPID = int64(x)
+		}
+	} else {
+		err = insertError(err, "CAMPool", *a)
+	}
+	return err
+}
+
+// InsertCAMRecon writes a new CAMRecon record to the database
+func InsertCAMRecon(ctx context.Context, a *CAMRecon) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.CAMPID, a.DtStart, a.DtStop, a.Expenses, a.TotalSqft, a.DtPosted, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCAMRecon)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCAMRecon.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CAMRID = int64(x)
+		}
+	} else {
+		err = insertError(err, "CAMRecon", *a)
+	}
+	return err
+}
+
+// InsertCAMReconItem writes a new CAMReconItem record to the database
+func InsertCAMReconItem(ctx context.Context, a *CAMReconItem) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.CAMRID, a.BID, a.RAID, a.RID, a.Sqft, a.Days, a.Share, a.Allocated, a.BaseYear, a.Due, a.Estimates, a.TrueUp, a.ASMID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCAMReconItem)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCAMReconItem.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CAMRIID = int64(x)
+		}
+	} else {
+		err = insertError(err, "CAMReconItem", *a)
+	}
+	return err
+}
+
+// InsertPctRent writes a new PctRent record to the database
+func InsertPctRent(ctx context.Context, a *PctRent) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.BreakpointType, a.BaseARID, a.OverageARID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPctRent)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPctRent.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PRID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PctRent", *a)
+	}
+	return err
+}
+
+// InsertPctRentTier writes a new PctRentTier record to the database
+func InsertPctRentTier(ctx context.Context, a *PctRentTier) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.PRID, a.BID, a.Breakpoint, a.Rate, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPctRentTier)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPctRentTier.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PRTID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PctRentTier", *a)
+	}
+	return err
+}
+
+// InsertSalesReport writes a new SalesReport record to the database
+func InsertSalesReport(ctx context.Context, a *SalesReport) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.DtStart, a.DtStop, a.GrossSales, a.Breakpoint, a.Overage, a.ASMID, a.Comment, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertSalesReport)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertSalesReport.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.SRID = int64(x)
+		}
+	} else {
+		err = insertError(err, "SalesReport", *a)
+	}
+	return err
+}
+
+// InsertReservation writes a new Reservation record to the database
+func InsertReservation(ctx context.Context, a *Reservation) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RTID, a.RID, a.TCID, a.RPID, a.DtArrive, a.DtDepart, a.Adults, a.Children, a.Rate, a.Deposit, a.RAID, a.Comment, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertReservation)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertReservation.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.RESID = int64(x)
+		}
+	} else {
+		err = insertError(err, "Reservation", *a)
+	}
+	return err
+}
+
+// InsertConcession writes a new Concession record to the database
+func InsertConcession(ctx context.Context, a *Concession) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Name, a.Description, a.ARID, a.Amount, a.Pct, a.Cycles, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertConcession)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertConcession.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CONID = int64(x)
+		}
+	} else {
+		err = insertError(err, "Concession", *a)
+	}
+	return err
+}
+
+// InsertRentalAgreementConcession writes a new RentalAgreementConcession record to the database
+func InsertRentalAgreementConcession(ctx context.Context, a *RentalAgreementConcession) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.CONID, a.ARID, a.Amount, a.Pct, a.Cycles, a.Applied, a.DtStart, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertRentalAgreementConcession)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertRentalAgreementConcession.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.RACID = int64(x)
+		}
+	} else {
+		err = insertError(err, "RentalAgreementConcession", *a)
+	}
+	return err
+}
+
+// InsertUtilityBill writes a new UtilityBill record to the database
+func InsertUtilityBill(ctx context.Context, a *UtilityBill) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Utility, a.ARID, a.Method, a.Amount, a.CommonPct, a.DtStart, a.DtStop, a.DtPosted, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertUtilityBill)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertUtilityBill.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.UBID = int64(x)
+		}
+	} else {
+		err = insertError(err, "UtilityBill", *a)
+	}
+	return err
+}
+
+// InsertUtilityCharge writes a new UtilityCharge record to the database
+func InsertUtilityCharge(ctx context.Context, a *UtilityCharge) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.UBID, a.BID, a.RAID, a.RID, a.DtStart, a.DtStop, a.Days, a.Sqft, a.Occupants, a.MeterUsage, a.Ratio, a.Share, a.Amount, a.ASMID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertUtilityCharge)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertUtilityCharge.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.UCID = int64(x)
+		}
+	} else {
+		err = insertError(err, "UtilityCharge", *a)
+	}
+	return err
+}
+
+// InsertUtilityRatio writes a new UtilityRatio record to the database
+func InsertUtilityRatio(ctx context.Context, a *UtilityRatio) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.UBID, a.BID, a.RID, a.Ratio, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertUtilityRatio)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertUtilityRatio.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.URID = int64(x)
+		}
+	} else {
+		err = insertError(err, "UtilityRatio", *a)
+	}
+	return err
+}
+
+// InsertMeterReading writes a new MeterReading record to the database
+func InsertMeterReading(ctx context.Context, a *MeterReading) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RID, a.Utility, a.Dt, a.Reading, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertMeterReading)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertMeterReading.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.MRID = int64(x)
+		}
+	} else {
+		err = insertError(err, "MeterReading", *a)
+	}
+	return err
+}
+
+// InsertAssessmentStep writes a new AssessmentStep record to the database
+func InsertAssessmentStep(ctx context.Context, a *AssessmentStep) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.RAID, a.ASMID, a.Dt, a.Amount, a.Pct, a.NewASMID, a.DtApplied, a.Comment, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertAssessmentStep)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertAssessmentStep.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.ASTID = int64(x)
+		}
+	} else {
+		err = insertError(err, "AssessmentStep", *a)
+	}
+	return err
+}
+
+// InsertPaymentGateway writes a new PaymentGateway record to the database
+func InsertPaymentGateway(ctx context.Context, a *PaymentGateway) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.Name, a.URL, a.APIKey, a.PMTID, a.DEPID, a.ARID, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertPaymentGateway)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertPaymentGateway.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.PGID = int64(x)
+		}
+	} else {
+		err = insertError(err, "PaymentGateway", *a)
+	}
+	return err
+}
+
+// InsertCardToken writes a new CardToken record to the database
+func InsertCardToken(ctx context.Context, a *CardToken) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.TCID, a.PGID, a.Token, a.Brand, a.Last4, a.ExpMonth, a.ExpYear, a.FLAGS, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertCardToken)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertCardToken.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.CTID = int64(x)
+		}
+	} else {
+		err = insertError(err, "CardToken", *a)
+	}
+	return err
+}
+
+// InsertGatewayTransaction writes a new GatewayTransaction record to the database
+func InsertGatewayTransaction(ctx context.Context, a *GatewayTransaction) error {
+	var err error
+	var res sql.Result
+
+	if err = insertSessionProblem(ctx, &a.CreateBy, &a.LastModBy); err != nil {
+		return err
+	}
+	fields := []interface{}{a.BID, a.PGID, a.TCID, a.CTID, a.Type, a.ParentGTID, a.TxnID, a.Amount, a.Dt, a.Status, a.Message, a.RCPTID, a.PayoutID, a.DID, a.CreateBy, a.LastModBy}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.InsertGatewayTransaction)
+		defer stmt.Close()
+		res, err = stmt.Exec(fields...)
+	} else {
+		res, err = RRdb.Prepstmt.InsertGatewayTransaction.Exec(fields...)
+	}
+	if nil == err {
+		x, err := res.LastInsertId()
+		if err == nil {
+			a.GTID = int64(x)
+		}
+	} else {
+		err = insertError(err, "GatewayTransaction", *a)
+	}
+	return err
+}
@@ -3,6 +3,7 @@ package rlib
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 )
 
@@ -175,8 +176,17 @@ func GetProrationCycle(ctx context.Context, dt *time.Time, rid int64, rta *[]Ren
 //        This array is the MR attribute in the RentableMarketRate struct
 //  rsa = array of rentable specialties that apply to the rentable we're calculating
 //========================================================================================================
-func CalculateGSR(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]RentableTypeRef, rsa []RentableSpecialty, xbiz *XBusiness) (float64, error) {
-	var total = float64(0) // init total
+func CalculateGSR(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]RentableTypeRef, rsa []RentableSpecialty, xbiz *XBusiness) (Money, error) {
+	gsr, err := calculateGSRCents(ctx, d1, d2, rid, rta, rsa, xbiz)
+	return moneyFromRat(gsr), err
+}
+
+// calculateGSRCents is CalculateGSR without the rounding. It returns the
+// gross scheduled rent in cents as an exact fraction so that the GSR of
+// consecutive periods can be added up before it is rounded.
+//========================================================================================================
+func calculateGSRCents(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]RentableTypeRef, rsa []RentableSpecialty, xbiz *XBusiness) (*big.Rat, error) {
+	var rent Money // sum of the rates and fees for each increment
 	// Console("Entered CalculateGSR: d1 = %s, d2 = %s, rid = %d\n", d1.Format(RRDATEFMTSQL), d2.Format(RRDATEFMTSQL), rid)
 
 	// Get the first date that overlaps the rta values
@@ -198,7 +208,7 @@ func CalculateGSR(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]Renta
 	rentCycle, _, gsrpc, err := GetProrationCycle(ctx, &dt, rid, rta, xbiz)
 	if err != nil {
 		Ulog("CalculateGSR: GetProrationCycle returned error: %s\n", err.Error())
-		return float64(0), err
+		return new(big.Rat), err
 	}
 	if rentCycle < 0 || gsrpc < 0 {
 		Ulog("CalculateGSR: warning: one or more cycle values is unset\n")
@@ -208,7 +218,7 @@ func CalculateGSR(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]Renta
 	rentCycleDur := CycleDuration(rentCycle, dt) // this is the rentcycle expressed as a duration
 	rtr, err := SelectRentableTypeRefForDate(ctx, rta, &dt)
 	if err != nil {
-		return float64(0), err
+		return new(big.Rat), err
 	}
 
 	// Console("CalculateGSR: rentCycle = %d (%v), gsrpc = %d (%v)\n", rentCycle, rentCycleDur, gsrpc, inc)
@@ -216,13 +226,19 @@ func CalculateGSR(ctx context.Context, d1, d2 time.Time, rid int64, rta *[]Renta
 
 	for d := dt; d.Before(d2); d = d.Add(inc) { // spin through the period in the defined increments
 		rate := FindApplicableMarketRate(d, d1, d2, xbiz.RT[rtr.RTID].MR) // find the rate applicable for this increment
-		rent := float64(inc) * rate / float64(rentCycleDur)               // how much for the period: inc
-		total += rent                                                     // increment the total by this amount
+		rent += MoneyFromFloat(rate)
 		for i := 0; i < len(rsa); i++ {
-			total += rsa[i].Fee * float64(inc) / float64(rentCycleDur)
+			rent += MoneyFromFloat(rsa[i].Fee)
 		}
 	}
-	return total, err
+	if rentCycleDur == 0 {
+		return new(big.Rat), err
+	}
+	//------------------------------------------------------------
+	// each increment is inc / rentCycleDur of its rate
+	//------------------------------------------------------------
+	x := new(big.Int).Mul(big.NewInt(int64(rent)), big.NewInt(int64(inc)))
+	return new(big.Rat).SetFrac(x, big.NewInt(int64(rentCycleDur))), err
 }
 
 // CalculateNumberOfCycles calculates the number of rent cycles for the supplied period d1-d2 and cycle time.
@@ -315,7 +331,7 @@ func GetRentableCycles(ctx context.Context, r *Rentable, d1, d2 *time.Time, xbiz
 // This method is necessary to account for changes in GSR during a time period.
 type GSRdata struct {
 	Dt     time.Time // datetime - in increments of GSRPC durations
-	Amount float64   // amount for GSR during this period
+	Amount Money     // amount for GSR during this period
 }
 
 // CalculateLoadedGSR calculates the gross scheduled rent including any Specialties associated with the rentable.
@@ -330,12 +346,13 @@ type GSRdata struct {
 //   time.Duration - the GSRPC for this rentable
 //   error - any error returned by the routines looking for data values
 //========================================================================================================
-func CalculateLoadedGSR(ctx context.Context, rBID, rRID int64, d1, d2 *time.Time, xbiz *XBusiness) (float64, []GSRdata, time.Duration, error) {
+func CalculateLoadedGSR(ctx context.Context, rBID, rRID int64, d1, d2 *time.Time, xbiz *XBusiness) (Money, []GSRdata, time.Duration, error) {
 	funcname := "CalculateLoadedGSR"
 	var period = time.Duration(0)
 	var m []GSRdata
 	var err error
-	gsr := float64(0) // total rent, to update on each pass through the loop below
+	var gsr Money         // total rent, to update on each pass through the loop below
+	total := new(big.Rat) // unrounded total rent in cents
 
 	// Console("Entered %s: rRID = %d, d1 = %s, d2 = %s\n", funcname, rRID, d1.Format(RRDATEINPFMT), d2.Format(RRDATEINPFMT))
 
@@ -383,7 +400,7 @@ func CalculateLoadedGSR(ctx context.Context, rBID, rRID int64, d1, d2 *time.Time
 		err = fmt.Errorf("%s: GSRPC == 0 for BID=%d, RID=%d, d1 = %s, d2 = %s", funcname, rBID, rRID, d1.Format(RRDATEFMT4), d2.Format(RRDATEFMT4))
 		Ulog(err.Error())
 		// Console(err.Error())
-		return float64(0), m, period, nil
+		return 0, m, period, nil
 	}
 
 	period = CycleDuration(gsrpc, dtFirst)           // increment of time we'll use to determine gsr in increments between dtFirst & d2
@@ -403,16 +420,20 @@ func CalculateLoadedGSR(ctx context.Context, rBID, rRID int64, d1, d2 *time.Time
 		//------------------------------------------------------------------
 		// Finally, calculate the GSR for this increment...
 		//------------------------------------------------------------------
-		rentThisPeriod, err := CalculateGSR(ctx, dt, dtNext, rRID, &rta, rsa, xbiz)
+		rentThisPeriod, err := calculateGSRCents(ctx, dt, dtNext, rRID, &rta, rsa, xbiz)
 		if err != nil {
 			return gsr, m, period, err
 		}
 
-		var g = GSRdata{Dt: dt, Amount: rentThisPeriod}
+		//------------------------------------------------------------
+		// Round the running total rather than each period so that
+		// the periods add up to the GSR without off-by-a-penny errors
+		//------------------------------------------------------------
+		total.Add(total, rentThisPeriod)
+		var g = GSRdata{Dt: dt, Amount: moneyFromRat(total) - gsr}
 		m = append(m, g)
-		gsr += rentThisPeriod
-		// Console("%s: rentThisPeriod = %.2f,  cumulative total: %.2f\n", dt.Format(RRDATEFMTSQL), rentThisPeriod, gsr)
+		gsr += g.Amount
+		// Console("%s: rentThisPeriod = %.2f,  cumulative total: %.2f\n", dt.Format(RRDATEFMTSQL), g.Amount, gsr)
 	}
-	gsr = RoundToCent(gsr) // do this to ensure that we avoid the off-by-a-penny errors
 	return gsr, m, period, err
 }
@@ -7,9 +7,9 @@ import (
 )
 
 //=================================================================================================
-func sumAllocations(m *[]AcctRule) (float64, float64) {
-	sum := float64(0.0)
-	debits := float64(0.0)
+func sumAllocations(m *[]AcctRule) (Money, Money) {
+	sum := Money(0)
+	debits := Money(0)
 	for i := 0; i < len(*m); i++ {
 		if (*m)[i].Action == "c" {
 			sum -= (*m)[i].Amount
@@ -21,6 +21,27 @@ func sumAllocations(m *[]AcctRule) (float64, float64) {
 	return sum, debits
 }
 
+// balanceAllocations makes the credits in m add up to the debits. Each amount
+// of a prorated rule is rounded to the cent on its own, so a rule that splits
+// a debit over several credits can be off by a cent. The difference goes to
+// the largest credit.
+//=================================================================================================
+func balanceAllocations(m []AcctRule) {
+	sum, _ := sumAllocations(&m)
+	if sum == 0 {
+		return
+	}
+	k := -1
+	for i := 0; i < len(m); i++ {
+		if m[i].Action == "c" && (k < 0 || m[i].Amount.Abs() > m[k].Amount.Abs()) {
+			k = i
+		}
+	}
+	if k >= 0 {
+		m[k].Amount += sum
+	}
+}
+
 // builds the account rule based on an ARID
 func buildRule(ctx context.Context, id int64) (string, error) {
 	rule, err := GetAR(ctx, id)
@@ -223,7 +244,7 @@ func journalAssessment(ctx context.Context, xbiz *XBusiness, d time.Time, a *Ass
 		return j, err
 	}
 
-	m, err := ParseAcctRule(ctx, xbiz, a.RID, d1, d2, asmRules, a.Amount, pf) // a rule such as "d 11001 1000.0, c 40001 1100.0, d 41004 100.00"
+	m, err := ParseAcctRuleForRA(ctx, xbiz, a.RID, a.RAID, d1, d2, asmRules, a.Amount, pf) // a rule such as "d 11001 1000.0, c 40001 1100.0, d 41004 100.00"
 	if err != nil {
 		return j, err
 	}
@@ -234,7 +255,6 @@ func journalAssessment(ctx context.Context, xbiz *XBusiness, d time.Time, a *Ass
 	// }
 
 	_, j.Amount = sumAllocations(&m)
-	j.Amount = RoundToCent(j.Amount)
 
 	// Console("j.Amount = %f\n", j.Amount)
 
@@ -256,34 +276,11 @@ func journalAssessment(ctx context.Context, xbiz *XBusiness, d time.Time, a *Ass
 	}
 
 	//-------------------------------------------------------------------------------------------
-	// In the event that we need to prorate, pull together the pieces and determine the
-	// fractional amounts so that all the entries can net to 0.00.  Essentially, this means
-	// handling the $0.01 off problem when dealing with fractional numbers.  The way we'll
-	// handle this is to apply the extra cent to the largest number
+	// In the event that we need to prorate, make sure that all the entries net to 0.00.
+	// This handles the $0.01 off problem when a prorated debit is split over several credits.
 	//-------------------------------------------------------------------------------------------
 	if pf < 1.0 {
-		// new method using ProcessSum
-		var asum []SumFloat
-		for i := 0; i < len(m); i++ {
-			var b SumFloat
-			if m[i].Action == "c" {
-				b.Val = -m[i].Amount
-			} else {
-				b.Val = m[i].Amount
-			}
-			b.Amount = RoundToCent(b.Val)
-			b.Remainder = b.Amount - b.Val
-			asum = append(asum, b)
-		}
-		ProcessSumFloats(asum)
-		for i := 0; i < len(asum); i++ {
-			if m[i].Action == "c" {
-				m[i].Amount = -asum[i].Amount // the adjusted value after ProcessSumFloats
-			} else {
-				m[i].Amount = asum[i].Amount // the adjusted value after ProcessSumFloats
-			}
-		}
-
+		balanceAllocations(m)
 	}
 
 	// Console("INSERTING JOURNAL: Date = %s, Type = %d, amount = %f\n", j.Dt, j.Type, j.Amount)
@@ -296,7 +293,7 @@ func journalAssessment(ctx context.Context, xbiz *XBusiness, d time.Time, a *Ass
 
 	s := ""
 	for i := 0; i < len(m); i++ {
-		s += fmt.Sprintf("%s %s %.2f", m[i].Action, m[i].AcctExpr, RoundToCent(m[i].Amount))
+		s += fmt.Sprintf("%s %s %.2f", m[i].Action, m[i].AcctExpr, m[i].Amount)
 		if i+1 < len(m) {
 			s += ", "
 		}
@@ -306,7 +303,7 @@ func journalAssessment(ctx context.Context, xbiz *XBusiness, d time.Time, a *Ass
 		ja.JID = jid
 		ja.RID = a.RID
 		ja.ASMID = a.ASMID
-		ja.Amount = RoundToCent(j.Amount)
+		ja.Amount = j.Amount
 		ja.AcctRule = s
 		ja.BID = a.BID
 		ja.RAID = a.RAID
@@ -382,7 +379,7 @@ func ProcessNewAssessmentInstance(ctx context.Context, xbiz *XBusiness, d1, d2 *
 func ProcessNewReceipt(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time, r *Receipt) (Journal, error) {
 	var j Journal
 	j.BID = xbiz.P.BID
-	j.Amount = RoundToCent(r.Amount)
+	j.Amount = r.Amount
 	j.Dt = r.Dt
 	j.Type = JNLTYPERCPT
 	j.ID = r.RCPTID
@@ -400,7 +397,7 @@ func ProcessNewReceipt(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time,
 			var ja JournalAllocation
 			ja.JID = jid
 			ja.TCID = r.TCID
-			ja.Amount = RoundToCent(r.RA[i].Amount)
+			ja.Amount = r.RA[i].Amount
 			ja.BID = j.BID
 			ja.ASMID = r.RA[i].ASMID
 			ja.AcctRule = r.RA[i].AcctRule
@@ -486,6 +483,11 @@ func ProcessJournalEntry(ctx context.Context, a *Assessment, xbiz *XBusiness, d1
 				return err
 			}
 		}
+		if a.PASMID == 0 && a.FLAGS&ASMREVERSED == 0 {
+			if err = ApplyConcessions(ctx, xbiz, a, d1, d2, updateLedgers); err != nil {
+				return err
+			}
+		}
 	} else if a.RentCycle >= RECURSECONDLY && a.RentCycle <= RECURHOURLY {
 		// TBD
 		LogAndPrint("Unhandled assessment recurrence type: %d\n", a.RentCycle)
@@ -571,6 +573,9 @@ func ProcessJournalEntry(ctx context.Context, a *Assessment, xbiz *XBusiness, d1
 						return err
 					}
 				}
+				if err = ApplyConcessions(ctx, xbiz, &a1, &dtb, &dte, updateLedgers); err != nil {
+					return err
+				}
 			} else if a.RentCycle >= RECURSECONDLY && a.RentCycle <= RECURHOURLY {
 				LogAndPrintError(funcname, fmt.Errorf("Unhandled RentCycle frequency: %d", a.RentCycle))
 			}
@@ -0,0 +1,295 @@
+package rlib
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LeaseDocPerson is a person on a lease
+type LeaseDocPerson struct {
+	Name        string // company name for a company, otherwise first and last name
+	FirstName   string
+	MiddleName  string
+	LastName    string
+	CompanyName string
+	IsCompany   bool
+	IsRenter    bool
+	IsOccupant  bool
+	IsGuarantor bool
+	Email       string
+	Phone       string // cell phone, work phone if there is none
+	Address     string // street, city, state and postal code on one line
+}
+
+// LeaseDocFee is a fee on a lease
+type LeaseDocFee struct {
+	For       string    // name of the rentable, pet or vehicle the fee is for
+	ARName    string    // account rule, what the fee is
+	Amount    float64   // amount charged each cycle
+	RentCycle int64     // RECURNONE for a one time fee
+	Cycle     string    // RentCycle as a string, "monthly"...
+	Start     time.Time // first charge
+	Stop      time.Time // last charge, same as Start for a one time fee
+	Comment   string
+}
+
+// LeaseDocRentable is a rentable on a lease
+type LeaseDocRentable struct {
+	Name string
+	Fees []LeaseDocFee // fees for the rentable
+}
+
+// LeaseDocData holds the merge fields of a LeaseTemplate. It is built from
+// the flow data of a Rental Agreement, so a lease can be generated both for
+// a saved Rental Agreement and for one that is still being worked on.
+type LeaseDocData struct {
+	BusinessName    string
+	BUD             string
+	RAID            int64 // 0 if the Rental Agreement has not been saved yet
+	DocumentDate    time.Time
+	AgreementStart  time.Time
+	AgreementStop   time.Time
+	RentStart       time.Time
+	RentStop        time.Time
+	PossessionStart time.Time
+	PossessionStop  time.Time
+	People          []LeaseDocPerson // everyone on the agreement
+	Renters         []LeaseDocPerson
+	Occupants       []LeaseDocPerson
+	Guarantors      []LeaseDocPerson
+	Rentables       []LeaseDocRentable
+	Pets            []RAPetsFlowData
+	Vehicles        []RAVehiclesFlowData
+	Fees            []LeaseDocFee // every fee: rentables, pets and vehicles
+	RecurringTotal  float64       // total of the recurring fees, one cycle each
+	OneTimeTotal    float64       // total of the one time fees
+	Generated       time.Time     // when the document was generated
+}
+
+// LeaseDocFuncs are the functions available to lease templates in addition
+// to the standard template functions:
+//
+//  date layout t  t formatted with the Go time layout, blank if t is not set
+//  money amt      amt with commas and two decimals
+//  names people   the names of people separated by commas
+//  upper s        s in upper case
+var LeaseDocFuncs = template.FuncMap{
+	"date": func(layout string, t time.Time) string {
+		if t.Year() <= 1970 {
+			return ""
+		}
+		return t.Format(layout)
+	},
+	"money": func(amt float64) string {
+		return RRCommaf(amt)
+	},
+	"names": func(m []LeaseDocPerson) string {
+		var s []string
+		for i := 0; i < len(m); i++ {
+			s = append(s, m[i].Name)
+		}
+		return strings.Join(s, ", ")
+	},
+	"upper": strings.ToUpper,
+}
+
+// LeaseDefaultBody is a starting point for a LeaseTemplate Body
+var LeaseDefaultBody = `<html>
+<head>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; }
+h1 { text-align: center; font-size: 16pt; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border: 1px solid #999; padding: 3px 6px; text-align: left; }
+td.amt { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Rental Agreement</h1>
+<p>This Rental Agreement is made on {{date "January 2, 2006" .DocumentDate}} between
+{{.BusinessName}} ("Landlord") and {{names .Renters}} ("Tenant").</p>
+{{if .Occupants}}<p>Occupants: {{names .Occupants}}</p>{{end}}
+{{if .Guarantors}}<p>Guarantors: {{names .Guarantors}}</p>{{end}}
+<p>The term of this agreement is from {{date "January 2, 2006" .AgreementStart}}
+to {{date "January 2, 2006" .AgreementStop}}. Possession begins on
+{{date "January 2, 2006" .PossessionStart}}.</p>
+<h2>Premises</h2>
+<ul>{{range .Rentables}}<li>{{.Name}}</li>{{end}}</ul>
+<h2>Charges</h2>
+<table>
+<tr><th>For</th><th>Charge</th><th>Frequency</th><th>Start</th><th>Stop</th><th>Amount</th></tr>
+{{range .Fees}}<tr><td>{{.For}}</td><td>{{.ARName}}</td><td>{{.Cycle}}</td><td>{{date "01/02/2006" .Start}}</td><td>{{date "01/02/2006" .Stop}}</td><td class="amt">{{money .Amount}}</td></tr>
+{{end}}</table>
+{{if .Pets}}<h2>Pets</h2>
+<ul>{{range .Pets}}<li>{{.Name}}, {{.Type}}, {{.Breed}}, {{.Color}}</li>{{end}}</ul>{{end}}
+{{if .Vehicles}}<h2>Vehicles</h2>
+<ul>{{range .Vehicles}}<li>{{.VehicleYear}} {{.VehicleMake}} {{.VehicleModel}}, {{.VehicleColor}}, {{.LicensePlateState}} {{.LicensePlateNumber}}</li>{{end}}</ul>{{end}}
+<br><br>
+<table>
+<tr><td>Landlord: {{.BusinessName}}<br><br><br>Signature / Date</td>
+{{range .Renters}}<td>Tenant: {{.Name}}<br><br><br>Signature / Date</td>{{end}}</tr>
+</table>
+</body>
+</html>
+`
+
+// NewLeaseDocData returns the merge fields of a lease for Rental Agreement
+// flow data raf.
+//
+// INPUTS
+//  biz  = the business
+//  raid = the Rental Agreement version, 0 if it has not been saved yet
+//  raf  = the flow data of the Rental Agreement
+//  now  = when the document is generated
+//
+// RETURNS
+//  the merge fields
+//-----------------------------------------------------------------------------
+func NewLeaseDocData(biz *Business, raid int64, raf *RAFlowJSONData, now *time.Time) LeaseDocData {
+	d := LeaseDocData{
+		BusinessName:    biz.Name,
+		BUD:             biz.Designation,
+		RAID:            raid,
+		DocumentDate:    time.Time(raf.Meta.DocumentDate),
+		AgreementStart:  time.Time(raf.Dates.AgreementStart),
+		AgreementStop:   time.Time(raf.Dates.AgreementStop),
+		RentStart:       time.Time(raf.Dates.RentStart),
+		RentStop:        time.Time(raf.Dates.RentStop),
+		PossessionStart: time.Time(raf.Dates.PossessionStart),
+		PossessionStop:  time.Time(raf.Dates.PossessionStop),
+		Pets:            raf.Pets,
+		Vehicles:        raf.Vehicles,
+		Generated:       *now,
+	}
+	if d.DocumentDate.Year() <= 1970 {
+		d.DocumentDate = *now
+	}
+	for i := 0; i < len(raf.People); i++ {
+		p := newLeaseDocPerson(&raf.People[i])
+		d.People = append(d.People, p)
+		if p.IsRenter {
+			d.Renters = append(d.Renters, p)
+		}
+		if p.IsOccupant {
+			d.Occupants = append(d.Occupants, p)
+		}
+		if p.IsGuarantor {
+			d.Guarantors = append(d.Guarantors, p)
+		}
+	}
+	for i := 0; i < len(raf.Rentables); i++ {
+		r := LeaseDocRentable{Name: raf.Rentables[i].RentableName}
+		r.Fees = d.addFees(r.Name, raf.Rentables[i].Fees)
+		d.Rentables = append(d.Rentables, r)
+	}
+	for i := 0; i < len(raf.Pets); i++ {
+		d.addFees(raf.Pets[i].Name, raf.Pets[i].Fees)
+	}
+	for i := 0; i < len(raf.Vehicles); i++ {
+		v := &raf.Vehicles[i]
+		d.addFees(strings.TrimSpace(v.VehicleMake+" "+v.VehicleModel+" "+v.LicensePlateNumber), v.Fees)
+	}
+	d.RecurringTotal = RoundToCent(d.RecurringTotal)
+	d.OneTimeTotal = RoundToCent(d.OneTimeTotal)
+	return d
+}
+
+// addFees adds fees m charged for the rentable, pet or vehicle named name
+// to d.Fees and the totals. It returns the fees it added.
+func (d *LeaseDocData) addFees(name string, m []RAFeesData) []LeaseDocFee {
+	var fees []LeaseDocFee
+	for i := 0; i < len(m); i++ {
+		f := LeaseDocFee{
+			For:       name,
+			ARName:    m[i].ARName,
+			Amount:    m[i].ContractAmount,
+			RentCycle: m[i].RentCycle,
+			Cycle:     RentalPeriodToString(m[i].RentCycle),
+			Start:     time.Time(m[i].Start),
+			Stop:      time.Time(m[i].Stop),
+			Comment:   m[i].Comment,
+		}
+		if f.RentCycle == RECURNONE {
+			d.OneTimeTotal += f.Amount
+		} else {
+			d.RecurringTotal += f.Amount
+		}
+		fees = append(fees, f)
+	}
+	d.Fees = append(d.Fees, fees...)
+	return fees
+}
+
+func newLeaseDocPerson(p *RAPeopleFlowData) LeaseDocPerson {
+	l := LeaseDocPerson{
+		FirstName:   p.FirstName,
+		MiddleName:  p.MiddleName,
+		LastName:    p.LastName,
+		CompanyName: p.CompanyName,
+		IsCompany:   p.IsCompany,
+		IsRenter:    p.IsRenter,
+		IsOccupant:  p.IsOccupant,
+		IsGuarantor: p.IsGuarantor,
+		Email:       p.PrimaryEmail,
+		Phone:       p.CellPhone,
+	}
+	if p.IsCompany {
+		l.Name = p.CompanyName
+	} else {
+		l.Name = strings.TrimSpace(p.FirstName + " " + p.LastName)
+	}
+	if len(l.Phone) == 0 {
+		l.Phone = p.WorkPhone
+	}
+	var a []string
+	for _, s := range []string{p.Address, p.Address2, p.City, strings.TrimSpace(p.State + " " + p.PostalCode)} {
+		if len(s) > 0 {
+			a = append(a, s)
+		}
+	}
+	l.Address = strings.Join(a, ", ")
+	return l
+}
+
+// RenderLeaseDocument fills in the merge fields of a lease template. The
+// template is an html/template, so merged values are escaped.
+//
+// INPUTS
+//  body = the LeaseTemplate Body
+//  d    = the merge fields
+//
+// RETURNS
+//  the HTML of the lease
+//  any error in the template
+//-----------------------------------------------------------------------------
+func RenderLeaseDocument(body string, d *LeaseDocData) (string, error) {
+	t, err := template.New("lease").Funcs(LeaseDocFuncs).Parse(body)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err = t.Execute(&b, d); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+var leaseDocFileChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// LeaseDocFileName returns the name a lease generated from template title
+// for Rental Agreement raid (or flow flowid if raid is 0) is downloaded as.
+func LeaseDocFileName(title string, raid, flowid int64, now *time.Time) string {
+	s := strings.Trim(leaseDocFileChars.ReplaceAllString(title, "-"), "-")
+	if len(s) == 0 {
+		s = "lease"
+	}
+	id := fmt.Sprintf("RA%d", raid)
+	if raid == 0 {
+		id = fmt.Sprintf("F%d", flowid)
+	}
+	return fmt.Sprintf("%s-%s-%s.pdf", s, id, now.Format("20060102"))
+}
@@ -0,0 +1,76 @@
+package rlib
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Lease document tests.
+
+func TestRenderLeaseDocument(t *testing.T) {
+	now := time.Date(2018, time.March, 5, 9, 0, 0, 0, time.UTC)
+	d1 := time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2019, time.March, 31, 0, 0, 0, 0, time.UTC)
+	biz := Business{Name: "Isola Bella", Designation: "ISO"}
+	raf := RAFlowJSONData{
+		Dates: RADatesFlowData{AgreementStart: JSONDate(d1), AgreementStop: JSONDate(d2), PossessionStart: JSONDate(d1)},
+		People: []RAPeopleFlowData{
+			{FirstName: "Jane", LastName: "Doe", IsRenter: true, City: "Denver", State: "CO", PostalCode: "80202"},
+			{CompanyName: "Doe & Sons", IsCompany: true, IsGuarantor: true},
+			{FirstName: "Tim", LastName: "Doe", IsOccupant: true},
+		},
+		Rentables: []RARentablesFlowData{
+			{RentableName: "Unit 101", Fees: []RAFeesData{
+				{ARName: "Rent", ContractAmount: 1250, RentCycle: RECURMONTHLY, Start: JSONDate(d1), Stop: JSONDate(d2)},
+				{ARName: "Security Deposit", ContractAmount: 1500, Start: JSONDate(d1), Stop: JSONDate(d1)},
+			}},
+		},
+		Pets: []RAPetsFlowData{
+			{Name: "Rex", Type: "Dog", Fees: []RAFeesData{{ARName: "Pet Rent", ContractAmount: 25.5, RentCycle: RECURMONTHLY}}},
+		},
+	}
+	d := NewLeaseDocData(&biz, 12, &raf, &now)
+	if len(d.Renters) != 1 || len(d.Guarantors) != 1 || len(d.Occupants) != 1 || len(d.People) != 3 {
+		t.Errorf("NewLeaseDocData people wrong: %+v\n", d)
+	}
+	if d.Renters[0].Address != "Denver, CO 80202" || d.Guarantors[0].Name != "Doe & Sons" {
+		t.Errorf("NewLeaseDocData person wrong: %+v %+v\n", d.Renters[0], d.Guarantors[0])
+	}
+	if len(d.Fees) != 3 || len(d.Rentables[0].Fees) != 2 || d.Fees[2].For != "Rex" {
+		t.Errorf("NewLeaseDocData fees wrong: %+v\n", d.Fees)
+	}
+	if d.RecurringTotal != 1275.5 || d.OneTimeTotal != 1500 || !d.DocumentDate.Equal(now) {
+		t.Errorf("NewLeaseDocData totals wrong: recurring %.2f, one time %.2f, date %s\n", d.RecurringTotal, d.OneTimeTotal, d.DocumentDate)
+	}
+
+	var tests = []struct {
+		body   string
+		expect string
+	}{
+		{`{{names .Renters}} / {{names .Guarantors}}`, "Jane Doe / Doe &amp; Sons"},
+		{`{{date "Jan 2, 2006" .AgreementStart}}-{{date "Jan 2, 2006" .RentStop}}`, "Apr 1, 2018-"},
+		{`{{range .Fees}}{{.ARName}} {{money .Amount}} {{.Cycle}};{{end}}`, "Rent 1,250.00 monthly;Security Deposit 1,500.00 non-recurring;Pet Rent 25.50 monthly;"},
+		{`{{upper .BusinessName}} RA{{.RAID}}`, "ISOLA BELLA RA12"},
+	}
+	for i := 0; i < len(tests); i++ {
+		s, err := RenderLeaseDocument(tests[i].body, &d)
+		if err != nil {
+			t.Errorf("test %d: RenderLeaseDocument error: %s\n", i, err.Error())
+			continue
+		}
+		if s != tests[i].expect {
+			t.Errorf("test %d: expected %q, got %q\n", i, tests[i].expect, s)
+		}
+	}
+	if _, err := RenderLeaseDocument(`{{.NoSuchField}}`, &d); err == nil {
+		t.Errorf("RenderLeaseDocument: expected an error for an unknown field\n")
+	}
+	s, err := RenderLeaseDocument(LeaseDefaultBody, &d)
+	if err != nil || !strings.Contains(s, "Unit 101") || !strings.Contains(s, "March 5, 2018") {
+		t.Errorf("RenderLeaseDocument default body: err = %v\n", err)
+	}
+	if s := LeaseDocFileName("Residential Lease (12 mo)", 0, 7, &now); s != "Residential-Lease-12-mo-F7-20180305.pdf" {
+		t.Errorf("LeaseDocFileName: got %q\n", s)
+	}
+}
@@ -101,7 +101,7 @@ func GenerateLedgerEntriesFromJournal(ctx context.Context, xbiz *XBusiness, j *J
 			l.RAID = j.JA[i].RAID
 			l.TCID = j.JA[i].TCID
 			l.Dt = j.Dt
-			l.Amount = RoundToCent(m[k].Amount)
+			l.Amount = m[k].Amount
 			if m[k].Action == "c" {
 				l.Amount = -l.Amount
 			}
@@ -111,7 +111,7 @@ func GenerateLedgerEntriesFromJournal(ctx context.Context, xbiz *XBusiness, j *J
 			}
 
 			l.LID = ledger.LID
-			if l.Amount >= float64(0.005) || l.Amount < float64(-0.005) { // ignore rounding errors
+			if l.Amount != 0 {
 				dup, err := GetLedgerEntryByJAID(ctx, l.BID, l.LID, l.JAID)
 				if err != nil {
 					Ulog("GetLedgerEntryByJAID error: %s", err.Error())
@@ -280,7 +280,7 @@ func UpdateSubLedgerMarkers(ctx context.Context, bid int64, d2 *time.Time) error
 			if processed {                         // did we process it?
 				continue // yes: move on to the next one
 			}
-			if m[i].Amount == float64(0) {
+			if m[i].Amount == 0 {
 				continue // sometimes an entry slips in with a 0 amount, ignore it
 			}
 
@@ -393,6 +393,180 @@ func GenerateLedgerMarkers(ctx context.Context, xbiz *XBusiness, d2 *time.Time)
 	//UpdatePayorSubLedgers(xbiz.P.BID, d1, d2)
 }
 
+// RebuildLedgerMarkers replaces the ledger markers of the business of xbiz
+// on dt with markers computed from the journal. All the markers on dt except
+// the initial ones are deleted, then GenerateLedgerMarkers and
+// GenerateRALedgerMarkers write them again from the markers before dt. It is
+// used when a period is closed and when it is reopened, so that entries
+// posted into a reopened period are in the markers written when it is closed
+// again.
+//
+// INPUTS
+//  ctx  - context which should include a database transaction
+//  xbiz - the business, with its internals initialized
+//  dt   - the close date
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func RebuildLedgerMarkers(ctx context.Context, xbiz *XBusiness, dt *time.Time) error {
+	if err := DeleteLedgerMarkersOnDate(ctx, xbiz.P.BID, dt); err != nil {
+		return err
+	}
+	if err := GenerateLedgerMarkers(ctx, xbiz, dt); err != nil {
+		return err
+	}
+	return GenerateRALedgerMarkers(ctx, xbiz.P.BID, dt)
+}
+
+// GenerateRALedgerMarkers writes the Rental Agreement and Rentable ledger
+// markers for business bid on d2. It is called when a period is closed so
+// that the balance lookups for statements and the rent roll only need to
+// consider the activity since the last close. For each Rental Agreement
+// that started before d2 it writes:
+//
+//   * the Rental Agreement receivable balance  (RAID, LID=0, RID=0)
+//   * the Rentable receivable balance          (RAID, RID, LID=0)
+//   * the Rentable balance of each security
+//     deposit account                          (RAID, RID, LID)
+//
+// Receivable markers are only written when the Rental Agreement or Rentable
+// has an initial marker. Markers that already exist on d2 are left alone.
+//
+// INPUTS
+//  ctx - context which may include a database transaction in progress
+//  bid - the business
+//  d2  - the close date
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GenerateRALedgerMarkers(ctx context.Context, bid int64, d2 *time.Time) error {
+	return generateRALedgerMarkers(ctx, bid, d2, raMarkerBalances{
+		ra: func(raid int64, d1 *time.Time) (Money, error) {
+			var rs RAStmtEntries
+			return GetRAIDAcctRange(ctx, raid, d1, d2, &rs)
+		},
+		rar: func(raid, rid int64, d1 *time.Time) (Money, error) {
+			return GetRARAcctRange(ctx, bid, raid, rid, d1, d2)
+		},
+		secdep: func(lid, raid, rid int64) (Money, error) {
+			return GetSecDepAcctBalanceOnDate(ctx, bid, lid, raid, rid, d2)
+		},
+	})
+}
+
+// raMarkerBalances supplies the amounts generateRALedgerMarkers needs for
+// the markers on the close date
+type raMarkerBalances struct {
+	ra     func(raid int64, d1 *time.Time) (Money, error)      // Rental Agreement receivable activity since d1
+	rar    func(raid, rid int64, d1 *time.Time) (Money, error) // Rentable receivable activity since d1
+	secdep func(lid, raid, rid int64) (Money, error)           // Rentable security deposit balance
+}
+
+// generateRALedgerMarkers does the work of GenerateRALedgerMarkers using
+// bal for the amounts.
+//-----------------------------------------------------------------------------
+func generateRALedgerMarkers(ctx context.Context, bid int64, d2 *time.Time, bal raMarkerBalances) error {
+	sda := GetSecurityDepositsAccounts(bid)
+	ras, err := GetRentalAgreementsByBusiness(ctx, bid)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(ras); i++ {
+		if !ras[i].AgreementStart.Before(*d2) {
+			continue
+		}
+		raid := ras[i].RAID
+
+		//---------------------------------------------
+		// Rental Agreement receivable balance...
+		//---------------------------------------------
+		lm, err := GetRALedgerMarkerOnOrBefore(ctx, raid, d2)
+		if err != nil {
+			return err
+		}
+		if lm.LMID > 0 && !lm.Dt.Equal(*d2) {
+			b, err := bal.ra(raid, &lm.Dt)
+			if err != nil {
+				return err
+			}
+			if err = insertCloseMarker(ctx, bid, 0, raid, 0, d2, lm.Balance+b); err != nil {
+				return err
+			}
+		}
+
+		rl, err := GetAllRentalAgreementRentables(ctx, raid)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < len(rl); j++ {
+			rid := rl[j].RID
+			if !rl[j].RARDtStart.Before(*d2) {
+				continue
+			}
+
+			//---------------------------------------------
+			// Rentable receivable balance...
+			//---------------------------------------------
+			lm, err := GetRARentableLedgerMarkerOnOrBefore(ctx, raid, rid, d2)
+			if err != nil {
+				return err
+			}
+			if lm.LMID > 0 && !lm.Dt.Equal(*d2) {
+				b, err := bal.rar(raid, rid, &lm.Dt)
+				if err != nil {
+					return err
+				}
+				if err = insertCloseMarker(ctx, bid, 0, raid, rid, d2, lm.Balance+b); err != nil {
+					return err
+				}
+			}
+
+			//---------------------------------------------
+			// Rentable security deposit balances...
+			//---------------------------------------------
+			for k := 0; k < len(sda); k++ {
+				lm, err := GetRARAcctLedgerMarkerOnOrBefore(ctx, bid, sda[k], raid, rid, d2)
+				if err != nil {
+					return err
+				}
+				if lm.LMID > 0 && lm.Dt.Equal(*d2) {
+					continue
+				}
+				b, err := bal.secdep(sda[k], raid, rid)
+				if err != nil {
+					return err
+				}
+				if err = insertCloseMarker(ctx, bid, sda[k], raid, rid, d2, b); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// insertCloseMarker writes a closed LedgerMarker with the supplied values
+//-----------------------------------------------------------------------------
+func insertCloseMarker(ctx context.Context, bid, lid, raid, rid int64, dt *time.Time, bal Money) error {
+	lm := LedgerMarker{
+		BID:     bid,
+		LID:     lid,
+		RAID:    raid,
+		RID:     rid,
+		Dt:      *dt,
+		Balance: bal,
+		State:   LMCLOSED,
+	}
+	_, err := InsertLedgerMarker(ctx, &lm)
+	if err != nil {
+		Ulog("insertCloseMarker: InsertLedgerMarker error: %s\n", err.Error())
+	}
+	return err
+}
+
 // GenerateLedgerEntries creates ledgers records based on the Journal records over the supplied time range.
 func GenerateLedgerEntries(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time) (int, error) {
 	// Console("Generate Ledger Records: BID=%d, d1 = %s, d2 = %s\n", xbiz.P.BID, d1.Format(RRDATEFMT4), d2.Format(RRDATEFMT4))
@@ -0,0 +1,188 @@
+package rlib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// The Rental Agreement marker is the one with no LID, RID or TCID. The
+// Rentable, security deposit and payor markers of the same Rental Agreement
+// must not be taken for it.
+func TestGetRALedgerMarkerOnOrBefore(t *testing.T) {
+	newMemDB(t)
+	ctx := context.Background()
+	jan := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var markers = []LedgerMarker{
+		{BID: 1, RAID: 7, Dt: jan, Balance: 100, State: LMINITIAL},                // 1 Rental Agreement
+		{BID: 1, RAID: 7, RID: 8, Dt: feb, Balance: 200, State: LMCLOSED},         // 2 Rentable
+		{BID: 1, LID: 5, RAID: 7, RID: 8, Dt: feb, Balance: 300, State: LMCLOSED}, // 3 security deposit
+		{BID: 1, RAID: 7, TCID: 9, Dt: feb, Balance: 400, State: LMCLOSED},        // 4 payor
+		{BID: 1, RAID: 6, Dt: feb, Balance: 500, State: LMCLOSED},                 // 5 another Rental Agreement
+		{BID: 1, RAID: 7, Dt: mar, Balance: 600, State: LMCLOSED},                 // 6 Rental Agreement
+	}
+	for i := 0; i < len(markers); i++ {
+		if _, err := InsertLedgerMarker(ctx, &markers[i]); err != nil {
+			t.Fatalf("InsertLedgerMarker: %s\n", err.Error())
+		}
+	}
+	var m = []struct {
+		dt   time.Time
+		lmid int64
+	}{
+		{jan.AddDate(0, 0, -1), 0},
+		{jan, 1},
+		{feb, 1},
+		{feb.AddDate(0, 0, 14), 1},
+		{mar, 6},
+	}
+	for i := 0; i < len(m); i++ {
+		lm, err := GetRALedgerMarkerOnOrBefore(ctx, 7, &m[i].dt)
+		if err != nil || lm.LMID != m[i].lmid {
+			t.Errorf("GetRALedgerMarkerOnOrBefore( 7, %s ): expect LMID %d, got %d (err = %v)\n", m[i].dt.Format(RRDATEFMT4), m[i].lmid, lm.LMID, err)
+		}
+	}
+}
+
+// The security deposit markers that GenerateRALedgerMarkers writes for a
+// Rental Agreement on a Rentable must not be taken for the Rentable's own
+// marker of the account.
+func TestGetRentableLedgerMarkerOnOrBefore(t *testing.T) {
+	newMemDB(t)
+	ctx := context.Background()
+	jan := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 1, 0, 0, 0, 0, time.UTC)
+	var markers = []LedgerMarker{
+		{BID: 1, LID: 5, RID: 8, Dt: jan, Balance: 100, State: LMINITIAL},         // 1 Rentable
+		{BID: 1, LID: 5, RAID: 7, RID: 8, Dt: feb, Balance: 200, State: LMCLOSED}, // 2 security deposit of RA 7
+		{BID: 1, LID: 5, TCID: 9, RID: 8, Dt: feb, Balance: 300, State: LMCLOSED}, // 3 payor
+		{BID: 1, LID: 6, RID: 8, Dt: feb, Balance: 400, State: LMCLOSED},          // 4 another account
+	}
+	for i := 0; i < len(markers); i++ {
+		if _, err := InsertLedgerMarker(ctx, &markers[i]); err != nil {
+			t.Fatalf("InsertLedgerMarker: %s\n", err.Error())
+		}
+	}
+	lm, err := GetRentableLedgerMarkerOnOrBefore(ctx, 1, 5, 8, &feb)
+	if err != nil || lm.LMID != 1 {
+		t.Errorf("GetRentableLedgerMarkerOnOrBefore( 1, 5, 8, %s ): expect LMID 1, got %d (err = %v)\n", feb.Format(RRDATEFMT4), lm.LMID, err)
+	}
+}
+
+// GenerateRALedgerMarkers carries each marker forward to the close date by
+// the activity since it was written, and writes the security deposit
+// balances. It must do its reads in the transaction of the close.
+func TestGenerateRALedgerMarkers(t *testing.T) {
+	db := newMemDB(t)
+	jan := time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+	RRdb.BizTypes[1] = &BusinessTypeLists{GLAccounts: map[int64]GLAccount{
+		5: {LID: 5, Name: "Security Deposit"},
+		6: {LID: 6, Name: "Rent"},
+	}}
+
+	tx, ctx, err := NewTransactionWithContext(context.Background())
+	if err != nil {
+		t.Fatalf("NewTransactionWithContext: %s\n", err.Error())
+	}
+	defer tx.Rollback()
+
+	var ras = []RentalAgreement{
+		{BID: 1, AgreementStart: jan}, // 1
+		{BID: 1, AgreementStart: d2},  // 2 starts on the close date
+		{BID: 1, AgreementStart: jan}, // 3 already has its markers
+	}
+	for i := 0; i < len(ras); i++ {
+		if _, err = InsertRentalAgreement(ctx, &ras[i]); err != nil {
+			t.Fatalf("InsertRentalAgreement: %s\n", err.Error())
+		}
+	}
+	var rars = []RentalAgreementRentable{
+		{BID: 1, RAID: 1, RID: 10, RARDtStart: jan}, // initial markers
+		{BID: 1, RAID: 1, RID: 11, RARDtStart: d2},  // starts on the close date
+		{BID: 1, RAID: 1, RID: 12, RARDtStart: feb}, // no initial marker
+		{BID: 1, RAID: 2, RID: 13, RARDtStart: d2},
+		{BID: 1, RAID: 3, RID: 14, RARDtStart: jan},
+	}
+	for i := 0; i < len(rars); i++ {
+		if _, err = InsertRentalAgreementRentable(ctx, &rars[i]); err != nil {
+			t.Fatalf("InsertRentalAgreementRentable: %s\n", err.Error())
+		}
+	}
+	var markers = []LedgerMarker{
+		{BID: 1, RAID: 1, Dt: jan, Balance: 10000, State: LMINITIAL},
+		{BID: 1, RAID: 1, RID: 10, Dt: feb, Balance: 2000, State: LMCLOSED},
+		{BID: 1, RAID: 3, Dt: d2, Balance: 3000, State: LMCLOSED},
+		{BID: 1, RAID: 3, RID: 14, Dt: d2, Balance: 3000, State: LMCLOSED},
+		{BID: 1, LID: 5, RAID: 3, RID: 14, Dt: d2, Balance: 4000, State: LMCLOSED},
+	}
+	for i := 0; i < len(markers); i++ {
+		if _, err = InsertLedgerMarker(ctx, &markers[i]); err != nil {
+			t.Fatalf("InsertLedgerMarker: %s\n", err.Error())
+		}
+	}
+
+	bal := raMarkerBalances{
+		ra: func(raid int64, d1 *time.Time) (Money, error) {
+			if raid != 1 || !d1.Equal(jan) {
+				t.Errorf("ra activity: unexpected RAID %d since %s\n", raid, d1)
+			}
+			return 500, nil
+		},
+		rar: func(raid, rid int64, d1 *time.Time) (Money, error) {
+			if raid != 1 || rid != 10 || !d1.Equal(feb) {
+				t.Errorf("rentable activity: unexpected RAID %d RID %d since %s\n", raid, rid, d1)
+			}
+			return 250, nil
+		},
+		secdep: func(lid, raid, rid int64) (Money, error) {
+			if lid != 5 || raid != 1 {
+				t.Errorf("security deposit: unexpected LID %d RAID %d\n", lid, raid)
+			}
+			return Money(rid * 100), nil
+		},
+	}
+	if err = generateRALedgerMarkers(ctx, 1, &d2, bal); err != nil {
+		t.Fatalf("generateRALedgerMarkers: %s\n", err.Error())
+	}
+	if q := db.notInTx(); len(q) > 0 {
+		t.Errorf("generateRALedgerMarkers: statements run outside the transaction: %q\n", q)
+	}
+
+	var expect = []struct {
+		lid, raid, rid int64
+		bal            Money
+	}{
+		{0, 1, 0, 10500},
+		{0, 1, 10, 2250},
+		{5, 1, 10, 1000},
+		{5, 1, 12, 1200},
+		{0, 3, 0, 3000}, // left alone
+		{5, 3, 14, 4000},
+	}
+	for i := 0; i < len(expect); i++ {
+		var lm LedgerMarker
+		switch {
+		case expect[i].rid == 0:
+			lm, err = GetRALedgerMarkerOnOrBefore(ctx, expect[i].raid, &d2)
+		case expect[i].lid == 0:
+			lm, err = GetRARentableLedgerMarkerOnOrBefore(ctx, expect[i].raid, expect[i].rid, &d2)
+		default:
+			lm, err = GetRARAcctLedgerMarkerOnOrBefore(ctx, 1, expect[i].lid, expect[i].raid, expect[i].rid, &d2)
+		}
+		if err != nil || !lm.Dt.Equal(d2) || lm.Balance != expect[i].bal {
+			t.Errorf("generateRALedgerMarkers: LID %d RAID %d RID %d, expect %s on %s, got %s on %s (err = %v)\n",
+				expect[i].lid, expect[i].raid, expect[i].rid, expect[i].bal, d2.Format(RRDATEFMT4), lm.Balance, lm.Dt.Format(RRDATEFMT4), err)
+		}
+	}
+	if n := len(db.rows("LedgerMarker")); n != len(markers)+4 {
+		t.Errorf("generateRALedgerMarkers: expect 4 new markers, got %d\n", n-len(markers))
+	}
+	lm, err := GetRARentableLedgerMarkerOnOrBefore(ctx, 1, 12, &d2)
+	if err != nil || lm.LMID != 0 {
+		t.Errorf("generateRALedgerMarkers: expect no receivable marker for a Rentable without an initial marker, got LMID %d (err = %v)\n", lm.LMID, err)
+	}
+}
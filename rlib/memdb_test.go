@@ -0,0 +1,549 @@
+package rlib
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memdb is a minimal in-memory database/sql driver for testing the rlib
+// routines that read and write a single table with simple WHERE clauses. It
+// understands prepared statements of these forms:
+//
+//   SELECT cols FROM table [WHERE conds] [ORDER BY col [ASC|DESC],...] [LIMIT n]
+//   INSERT INTO table (cols) VALUES(?,...)
+//   UPDATE table SET col=?,... [WHERE conds]
+//   DELETE FROM table [WHERE conds]
+//
+// conds are terms of the form  col op value  joined by AND, where op is one
+// of = != < <= > >= and value is ? or a literal. The first field of
+// RRdb.DBFields[table] is the auto increment primary key. Statements are
+// parsed when they are executed, so buildPreparedStatements can prepare all
+// of its statements; executing one the driver does not understand returns
+// an error.  Transactions are not isolated, but statements run outside an
+// open transaction are recorded so tests can check that a routine uses the
+// transaction in its context.
+
+type memRow map[string]driver.Value
+
+type memDB struct {
+	mu        sync.Mutex
+	tables    map[string][]memRow
+	nextID    map[string]int64
+	txOpen    int      // number of transactions in progress
+	outsideTx []string // statements run outside the open transactions
+}
+
+var (
+	memDBOnce sync.Once
+	memDBMu   sync.Mutex
+	memDBs    = map[string]*memDB{}
+)
+
+type memDriver struct{}
+
+func (memDriver) Open(name string) (driver.Conn, error) {
+	memDBMu.Lock()
+	defer memDBMu.Unlock()
+	db, ok := memDBs[name]
+	if !ok {
+		return nil, fmt.Errorf("memdb: unknown database %s", name)
+	}
+	return &memConn{db: db}, nil
+}
+
+// newMemDB points RRdb at a new, empty in-memory database and prepares the
+// rlib statements against it. RRdb is restored when the test completes.
+//-----------------------------------------------------------------------------
+func newMemDB(t *testing.T) *memDB {
+	memDBOnce.Do(func() { sql.Register("memdb", memDriver{}) })
+	db := &memDB{tables: map[string][]memRow{}, nextID: map[string]int64{}}
+	memDBMu.Lock()
+	name := t.Name()
+	memDBs[name] = db
+	memDBMu.Unlock()
+
+	save := RRdb
+	dbrr, err := sql.Open("memdb", name)
+	if err != nil {
+		t.Fatalf("newMemDB: %s\n", err.Error())
+	}
+	RRdb.Dbrr = dbrr
+	RRdb.DBFields = map[string]string{}
+	RRdb.BizTypes = map[int64]*BusinessTypeLists{}
+	RRdb.noAuth = true
+	buildPreparedStatements()
+	t.Cleanup(func() {
+		dbrr.Close()
+		RRdb = save
+		memDBMu.Lock()
+		delete(memDBs, name)
+		memDBMu.Unlock()
+	})
+	return db
+}
+
+// rows returns a copy of the rows of table
+func (db *memDB) rows(table string) []memRow {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]memRow{}, db.tables[table]...)
+}
+
+// notInTx returns the statements that were run on a connection without a
+// transaction while a transaction was in progress
+func (db *memDB) notInTx() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]string{}, db.outsideTx...)
+}
+
+type memConn struct {
+	db *memDB
+	tx bool // a transaction is in progress on this connection
+}
+
+func (c *memConn) Prepare(query string) (driver.Stmt, error) {
+	return &memStmt{db: c.db, conn: c, query: query}, nil
+}
+func (c *memConn) Close() error { return nil }
+func (c *memConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.tx = true
+	c.db.txOpen++
+	return memTx{c}, nil
+}
+
+type memTx struct{ c *memConn }
+
+func (t memTx) Commit() error   { return t.end() }
+func (t memTx) Rollback() error { return t.end() }
+func (t memTx) end() error {
+	t.c.db.mu.Lock()
+	defer t.c.db.mu.Unlock()
+	t.c.tx = false
+	t.c.db.txOpen--
+	return nil
+}
+
+type memStmt struct {
+	db    *memDB
+	conn  *memConn
+	query string
+}
+
+// track records the statement if it runs outside a transaction in progress,
+// the caller must hold s.db.mu
+func (s *memStmt) track() {
+	if s.db.txOpen > 0 && !s.conn.tx {
+		s.db.outsideTx = append(s.db.outsideTx, s.query)
+	}
+}
+
+func (s *memStmt) Close() error  { return nil }
+func (s *memStmt) NumInput() int { return -1 }
+
+type memResult struct{ id, n int64 }
+
+func (r memResult) LastInsertId() (int64, error) { return r.id, nil }
+func (r memResult) RowsAffected() (int64, error) { return r.n, nil }
+
+type memRows struct {
+	cols []string
+	data []memRow
+	i    int
+}
+
+func (r *memRows) Columns() []string { return r.cols }
+func (r *memRows) Close() error      { return nil }
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.data) {
+		return io.EOF
+	}
+	for j := 0; j < len(r.cols); j++ {
+		dest[j] = r.data[r.i][r.cols[j]]
+	}
+	r.i++
+	return nil
+}
+
+// memCond is a single  col op value  term of a WHERE clause
+type memCond struct {
+	col, op string
+	val     driver.Value
+}
+
+// memParser holds the tokens of a statement and its arguments
+type memParser struct {
+	tok  []string
+	i    int
+	args []driver.Value
+}
+
+// memTokens splits a statement into identifiers, literals and operators
+func memTokens(q string) []string {
+	var m []string
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == ';':
+			i++
+		case c == '"' || c == '\'':
+			j := strings.IndexByte(q[i+1:], c)
+			if j < 0 {
+				j = len(q) - i - 1
+			}
+			m = append(m, q[i:i+j+2])
+			i += j + 2
+		case strings.IndexByte("(),?", c) >= 0:
+			m = append(m, string(c))
+			i++
+		case strings.IndexByte("=!<>", c) >= 0:
+			j := i + 1
+			for j < len(q) && strings.IndexByte("=<>", q[j]) >= 0 {
+				j++
+			}
+			m = append(m, q[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(q) && strings.IndexByte(" \t\n;(),?=!<>", q[j]) < 0 {
+				j++
+			}
+			m = append(m, q[i:j])
+			i = j
+		}
+	}
+	return m
+}
+
+func (p *memParser) peek() string {
+	if p.i < len(p.tok) {
+		return p.tok[p.i]
+	}
+	return ""
+}
+
+func (p *memParser) next() string {
+	s := p.peek()
+	p.i++
+	return s
+}
+
+func (p *memParser) keyword(k string) bool {
+	if strings.EqualFold(p.peek(), k) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *memParser) expect(k string) error {
+	if !p.keyword(k) {
+		return fmt.Errorf("memdb: expected %s, found %q", k, p.peek())
+	}
+	return nil
+}
+
+// value returns the next argument for a ? or the value of a literal
+func (p *memParser) value() (driver.Value, error) {
+	s := p.next()
+	switch {
+	case s == "?":
+		if len(p.args) == 0 {
+			return nil, fmt.Errorf("memdb: not enough arguments")
+		}
+		v := p.args[0]
+		p.args = p.args[1:]
+		return v, nil
+	case len(s) > 1 && (s[0] == '"' || s[0] == '\''):
+		return s[1 : len(s)-1], nil
+	}
+	if x, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return x, nil
+	}
+	if x, err := strconv.ParseFloat(s, 64); err == nil {
+		return x, nil
+	}
+	return nil, fmt.Errorf("memdb: unsupported value %q", s)
+}
+
+// conds parses a WHERE clause
+func (p *memParser) conds() ([]memCond, error) {
+	var m []memCond
+	if !p.keyword("WHERE") {
+		return m, nil
+	}
+	for {
+		var c memCond
+		c.col = p.next()
+		c.op = p.next()
+		switch c.op {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+		default:
+			return m, fmt.Errorf("memdb: unsupported condition %s %s", c.col, c.op)
+		}
+		v, err := p.value()
+		if err != nil {
+			return m, err
+		}
+		c.val = v
+		m = append(m, c)
+		if !p.keyword("AND") {
+			return m, nil
+		}
+	}
+}
+
+// memCompare compares a and b as times, numbers or strings
+func memCompare(a, b driver.Value) int {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			tb, _ = StringToDate(memString(b))
+		}
+		switch {
+		case ta.Before(tb):
+			return -1
+		case ta.After(tb):
+			return 1
+		}
+		return 0
+	}
+	if _, ok := b.(time.Time); ok {
+		return -memCompare(b, a)
+	}
+	x, err1 := strconv.ParseFloat(memString(a), 64)
+	y, err2 := strconv.ParseFloat(memString(b), 64)
+	if err1 == nil && err2 == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(memString(a), memString(b))
+}
+
+func memString(v driver.Value) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(x)
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func memMatch(r memRow, m []memCond) bool {
+	for _, c := range m {
+		x := memCompare(r[c.col], c.val)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = x == 0
+		case "!=", "<>":
+			ok = x != 0
+		case "<":
+			ok = x < 0
+		case "<=":
+			ok = x <= 0
+		case ">":
+			ok = x > 0
+		case ">=":
+			ok = x >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// memNames parses a comma separated list of names up to the keyword stop
+func (p *memParser) memNames(stop string) []string {
+	var m []string
+	for p.peek() != "" && !strings.EqualFold(p.peek(), stop) {
+		if s := p.next(); s != "," {
+			m = append(m, s)
+		}
+	}
+	return m
+}
+
+func (s *memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.track()
+	p := &memParser{tok: memTokens(s.query), args: args}
+	switch strings.ToUpper(p.next()) {
+	case "INSERT":
+		if err := p.expect("INTO"); err != nil {
+			return nil, err
+		}
+		table := p.next()
+		flds, ok := RRdb.DBFields[table]
+		if !ok {
+			return nil, fmt.Errorf("memdb: unknown table %s", table)
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		cols := p.memNames(")")
+		p.next()
+		if err := p.expect("VALUES"); err != nil {
+			return nil, err
+		}
+		pk := strings.Split(flds, ",")[0]
+		s.db.nextID[table]++
+		r := memRow{pk: s.db.nextID[table], "CreateTS": time.Now(), "LastModTime": time.Now()}
+		p.next() // (
+		for i := 0; i < len(cols); i++ {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			r[cols[i]] = v
+			p.next() // , or )
+		}
+		s.db.tables[table] = append(s.db.tables[table], r)
+		return memResult{id: s.db.nextID[table], n: 1}, nil
+
+	case "UPDATE":
+		table := p.next()
+		if err := p.expect("SET"); err != nil {
+			return nil, err
+		}
+		set := memRow{}
+		for {
+			col := p.next()
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			set[col] = v
+			if p.peek() != "," {
+				break
+			}
+			p.next()
+		}
+		m, err := p.conds()
+		if err != nil {
+			return nil, err
+		}
+		n := int64(0)
+		for _, r := range s.db.tables[table] {
+			if memMatch(r, m) {
+				for k, v := range set {
+					r[k] = v
+				}
+				n++
+			}
+		}
+		return memResult{n: n}, nil
+
+	case "DELETE":
+		if err := p.expect("FROM"); err != nil {
+			return nil, err
+		}
+		table := p.next()
+		m, err := p.conds()
+		if err != nil {
+			return nil, err
+		}
+		var keep []memRow
+		for _, r := range s.db.tables[table] {
+			if !memMatch(r, m) {
+				keep = append(keep, r)
+			}
+		}
+		n := int64(len(s.db.tables[table]) - len(keep))
+		s.db.tables[table] = keep
+		return memResult{n: n}, nil
+	}
+	return nil, fmt.Errorf("memdb: unsupported statement: %s", s.query)
+}
+
+func (s *memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.track()
+	p := &memParser{tok: memTokens(s.query), args: args}
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+	cols := p.memNames("FROM")
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	table := p.next()
+	m, err := p.conds()
+	if err != nil {
+		return nil, err
+	}
+	var data []memRow
+	for _, r := range s.db.tables[table] {
+		if memMatch(r, m) {
+			data = append(data, r)
+		}
+	}
+	if p.keyword("ORDER") {
+		if err = p.expect("BY"); err != nil {
+			return nil, err
+		}
+		var cols []string
+		var desc []bool
+		for {
+			cols = append(cols, p.next())
+			desc = append(desc, p.keyword("DESC"))
+			p.keyword("ASC")
+			if !p.keyword(",") {
+				break
+			}
+		}
+		sort.SliceStable(data, func(i, j int) bool {
+			for k := 0; k < len(cols); k++ {
+				x := memCompare(data[i][cols[k]], data[j][cols[k]])
+				if desc[k] {
+					x = -x
+				}
+				if x != 0 {
+					return x < 0
+				}
+			}
+			return false
+		})
+	}
+	if p.keyword("LIMIT") {
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n, _ := strconv.Atoi(memString(v))
+		if n < len(data) {
+			data = data[:n]
+		}
+	}
+	if p.peek() != "" {
+		return nil, fmt.Errorf("memdb: unsupported statement: %s", s.query)
+	}
+	return &memRows{cols: cols, data: data}, nil
+}
@@ -0,0 +1,189 @@
+package rlib
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MetricBuckets are the upper bounds, in seconds, of the latency histogram
+// buckets used for web service requests and worker runs.
+var MetricBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// MetricHistogram accumulates the number of times an operation ran, the
+// number of times it failed, and the distribution of how long it took.
+type MetricHistogram struct {
+	Count    int64   // total number of observations
+	Failures int64   // observations that were failures
+	Sum      float64 // total seconds of all observations
+	Buckets  []int64 // Buckets[i] is the count of observations <= MetricBuckets[i]
+}
+
+// Observe adds a single observation to the histogram
+//
+// INPUTS
+//  d      - how long the operation took
+//  failed - true if the operation failed
+//-----------------------------------------------------------------------------
+func (h *MetricHistogram) Observe(d time.Duration, failed bool) {
+	if len(h.Buckets) != len(MetricBuckets) {
+		h.Buckets = make([]int64, len(MetricBuckets))
+	}
+	secs := d.Seconds()
+	h.Count++
+	h.Sum += secs
+	if failed {
+		h.Failures++
+	}
+	for i := 0; i < len(MetricBuckets); i++ {
+		if secs <= MetricBuckets[i] {
+			h.Buckets[i]++
+		}
+	}
+}
+
+// MetricSet is a collection of MetricHistograms indexed by name. It is safe
+// for concurrent use.
+type MetricSet struct {
+	mu sync.Mutex
+	m  map[string]*MetricHistogram
+}
+
+// Record adds an observation to the histogram for name, creating it if needed
+//-----------------------------------------------------------------------------
+func (s *MetricSet) Record(name string, d time.Duration, failed bool) {
+	s.mu.Lock()
+	if s.m == nil {
+		s.m = map[string]*MetricHistogram{}
+	}
+	h, ok := s.m[name]
+	if !ok {
+		h = &MetricHistogram{}
+		s.m[name] = h
+	}
+	h.Observe(d, failed)
+	s.mu.Unlock()
+}
+
+// Snapshot returns the names in the set in sorted order along with a copy of
+// each histogram.
+//-----------------------------------------------------------------------------
+func (s *MetricSet) Snapshot() ([]string, map[string]MetricHistogram) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	m := map[string]MetricHistogram{}
+	for k, v := range s.m {
+		h := *v
+		h.Buckets = append([]int64{}, v.Buckets...)
+		m[k] = h
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names, m
+}
+
+// SvcMetrics holds the request statistics for each web service command
+var SvcMetrics MetricSet
+
+// WorkerMetrics holds the run statistics for each tws worker
+var WorkerMetrics MetricSet
+
+// CacheMetric describes the state of one of the simple caches
+type CacheMetric struct {
+	Name    string
+	Entries int
+	Hits    int64
+	Misses  int64
+}
+
+// GetCacheMetrics returns the current size and lookup counts of each of the
+// simple caches. Only the caches' atomic counters are read, the cache maps
+// are not walked, so it is safe to call while the caches are being updated.
+//-----------------------------------------------------------------------------
+func GetCacheMetrics() []CacheMetric {
+	return []CacheMetric{
+		{"acctslice", GLAcctCacheCtx.Size(), atomic.LoadInt64(&GLAcctCacheCtx.Hits), atomic.LoadInt64(&GLAcctCacheCtx.Misses)},
+		{"arslice", ARCacheCtx.Size(), atomic.LoadInt64(&ARCacheCtx.Hits), atomic.LoadInt64(&ARCacheCtx.Misses)},
+		{"rarbalance", RARBalCacheCtx.Size(), atomic.LoadInt64(&RARBalCacheCtx.Hits), atomic.LoadInt64(&RARBalCacheCtx.Misses)},
+		{"secdepbalance", SecDepBalCacheCtx.Size(), atomic.LoadInt64(&SecDepBalCacheCtx.Hits), atomic.LoadInt64(&SecDepBalCacheCtx.Misses)},
+	}
+}
+
+// WriteMetrics writes the server metrics to w in the Prometheus text
+// exposition format.
+//
+// INPUTS
+//  w - where to write the metrics
+//-----------------------------------------------------------------------------
+func WriteMetrics(w io.Writer) {
+	writeHistogramMetrics(w, "rentroll_ws_request", "Web service requests", "cmd", &SvcMetrics)
+
+	c := GetCacheMetrics()
+	writeMetricHeader(w, "rentroll_cache_entries", "gauge", "Number of entries in the cache.")
+	for i := 0; i < len(c); i++ {
+		fmt.Fprintf(w, "rentroll_cache_entries{cache=%q} %d\n", c[i].Name, c[i].Entries)
+	}
+	writeMetricHeader(w, "rentroll_cache_hits_total", "counter", "Cache lookups that found an entry.")
+	for i := 0; i < len(c); i++ {
+		fmt.Fprintf(w, "rentroll_cache_hits_total{cache=%q} %d\n", c[i].Name, c[i].Hits)
+	}
+	writeMetricHeader(w, "rentroll_cache_misses_total", "counter", "Cache lookups that did not find an entry.")
+	for i := 0; i < len(c); i++ {
+		fmt.Fprintf(w, "rentroll_cache_misses_total{cache=%q} %d\n", c[i].Name, c[i].Misses)
+	}
+	writeMetricHeader(w, "rentroll_cache_hit_ratio", "gauge", "Fraction of cache lookups that found an entry.")
+	for i := 0; i < len(c); i++ {
+		r := float64(0)
+		if n := c[i].Hits + c[i].Misses; n > 0 {
+			r = float64(c[i].Hits) / float64(n)
+		}
+		fmt.Fprintf(w, "rentroll_cache_hit_ratio{cache=%q} %g\n", c[i].Name, r)
+	}
+
+	writeMetricHeader(w, "rentroll_sessions_active", "gauge", "Number of unexpired sessions.")
+	fmt.Fprintf(w, "rentroll_sessions_active %d\n", SessionCount())
+
+	writeHistogramMetrics(w, "rentroll_worker_run", "TWS worker runs", "worker", &WorkerMetrics)
+}
+
+// writeMetricHeader writes the HELP and TYPE lines for a metric
+func writeMetricHeader(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// writeHistogramMetrics writes the totals, failures, and duration histogram
+// for each entry in s. The label for each entry is lbl.
+//
+// INPUTS
+//  w      - where to write the metrics
+//  prefix - metric name prefix
+//  descr  - what is being counted, used in the help text
+//  lbl    - the label name
+//  s      - the metrics to write
+//-----------------------------------------------------------------------------
+func writeHistogramMetrics(w io.Writer, prefix, descr, lbl string, s *MetricSet) {
+	names, m := s.Snapshot()
+
+	writeMetricHeader(w, prefix+"s_total", "counter", descr+".")
+	for _, k := range names {
+		fmt.Fprintf(w, "%ss_total{%s=%q} %d\n", prefix, lbl, k, m[k].Count)
+	}
+	writeMetricHeader(w, prefix+"_failures_total", "counter", descr+" that failed.")
+	for _, k := range names {
+		fmt.Fprintf(w, "%s_failures_total{%s=%q} %d\n", prefix, lbl, k, m[k].Failures)
+	}
+	writeMetricHeader(w, prefix+"_duration_seconds", "histogram", descr+" duration in seconds.")
+	for _, k := range names {
+		h := m[k]
+		for i := 0; i < len(MetricBuckets) && i < len(h.Buckets); i++ {
+			fmt.Fprintf(w, "%s_duration_seconds_bucket{%s=%q,le=\"%g\"} %d\n", prefix, lbl, k, MetricBuckets[i], h.Buckets[i])
+		}
+		fmt.Fprintf(w, "%s_duration_seconds_bucket{%s=%q,le=\"+Inf\"} %d\n", prefix, lbl, k, h.Count)
+		fmt.Fprintf(w, "%s_duration_seconds_sum{%s=%q} %g\n", prefix, lbl, k, h.Sum)
+		fmt.Fprintf(w, "%s_duration_seconds_count{%s=%q} %d\n", prefix, lbl, k, h.Count)
+	}
+}
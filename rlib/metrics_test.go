@@ -0,0 +1,77 @@
+package rlib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Metrics histogram and exposition format tests
+
+func TestMetricHistogramObserve(t *testing.T) {
+	var h MetricHistogram
+	h.Observe(3*time.Millisecond, false)   // <= 0.005
+	h.Observe(200*time.Millisecond, true)  // <= 0.25
+	h.Observe(90*time.Second, false)       // only +Inf
+	h.Observe(1000*time.Millisecond, true) // <= 1 exactly
+
+	if h.Count != 4 || h.Failures != 2 {
+		t.Errorf("MetricHistogram: expect Count=4 Failures=2, got Count=%d Failures=%d\n", h.Count, h.Failures)
+	}
+	var expect = []int64{1, 1, 1, 1, 1, 2, 2, 3, 3, 3, 3, 3, 3}
+	for i := 0; i < len(expect); i++ {
+		if h.Buckets[i] != expect[i] {
+			t.Errorf("MetricHistogram: bucket le=%g expect %d, got %d\n", MetricBuckets[i], expect[i], h.Buckets[i])
+		}
+	}
+}
+
+func TestWriteHistogramMetrics(t *testing.T) {
+	var s MetricSet
+	s.Record("rt", 20*time.Millisecond, false)
+	s.Record("rt", 2*time.Second, true)
+	s.Record("asm", 1*time.Millisecond, false)
+
+	var b bytes.Buffer
+	writeHistogramMetrics(&b, "rentroll_ws_request", "Web service requests", "cmd", &s)
+	out := b.String()
+	t.Logf("\n%s", out)
+
+	var expect = []string{
+		"# TYPE rentroll_ws_requests_total counter\n",
+		"rentroll_ws_requests_total{cmd=\"asm\"} 1\nrentroll_ws_requests_total{cmd=\"rt\"} 2\n",
+		"rentroll_ws_request_failures_total{cmd=\"rt\"} 1\n",
+		"# TYPE rentroll_ws_request_duration_seconds histogram\n",
+		"rentroll_ws_request_duration_seconds_bucket{cmd=\"rt\",le=\"0.025\"} 1\n",
+		"rentroll_ws_request_duration_seconds_bucket{cmd=\"rt\",le=\"2.5\"} 2\n",
+		"rentroll_ws_request_duration_seconds_bucket{cmd=\"rt\",le=\"+Inf\"} 2\n",
+		"rentroll_ws_request_duration_seconds_sum{cmd=\"rt\"} 2.02\n",
+		"rentroll_ws_request_duration_seconds_count{cmd=\"asm\"} 1\n",
+	}
+	for i := 0; i < len(expect); i++ {
+		if !strings.Contains(out, expect[i]) {
+			t.Errorf("writeHistogramMetrics: output does not contain %q\n", expect[i])
+		}
+	}
+}
+
+func TestCacheMetricsEntries(t *testing.T) {
+	save := ARCacheCtx.Entries
+	defer func() { ARCacheCtx.Entries = save }()
+	ARCacheCtx.Entries = 0
+	ARCacheCtx.added()
+	ARCacheCtx.added()
+	ARCacheCtx.removed()
+
+	c := GetCacheMetrics()
+	for i := 0; i < len(c); i++ {
+		if c[i].Name == "arslice" {
+			if c[i].Entries != 1 {
+				t.Errorf("GetCacheMetrics: arslice expect 1 entry, got %d\n", c[i].Entries)
+			}
+			return
+		}
+	}
+	t.Errorf("GetCacheMetrics: arslice cache not found\n")
+}
@@ -0,0 +1,342 @@
+package rlib
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of money held as a whole number of cents. It is used
+// for every amount that is posted to the books or paid out: Assessments,
+// Receipts, their allocations, Deposits, Journals and the ledgers, Bills,
+// vendor payments and checks, CAM reconciliations, percentage rent and the
+// gross scheduled rent computed by CalculateGSR. Sums of Money are exact, so
+// journal totals and ledger balances always agree to the penny.
+//
+// Money reads and writes the DECIMAL columns of the database directly, it
+// marshals to JSON as a number with two decimal places, and it formats with
+// the %f, %g, %v and %s verbs like the float64 it replaces.
+//
+// Rates, percentages and proration factors stay float64, as do the market
+// rates and fees of the rentable types, which are converted to Money where
+// rent is computed from them, and the statistics of reports that are never
+// posted, such as the rent roll differences. Multiplying Money by a float64
+// rounds to the nearest cent, half a cent away from zero, at that one point.
+type Money int64
+
+// MoneyFromFloat returns the Money nearest to x. Half a cent rounds away
+// from zero.
+//
+// INPUTS
+//  x = the amount in dollars
+//
+// RETURNS
+//  the amount as Money
+//-----------------------------------------------------------------------------
+func MoneyFromFloat(x float64) Money {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0
+	}
+	//------------------------------------------------------------
+	// Round on the decimal representation so that amounts like
+	// 1.005, which is 1.00499999... as a float64, round the way
+	// a person would round them.
+	//------------------------------------------------------------
+	m, err := ParseMoney(strconv.FormatFloat(x, 'f', 6, 64))
+	if err != nil {
+		return Money(math.Round(x * 100))
+	}
+	return m
+}
+
+// ParseMoney converts a decimal string such as "1,234.56" or "-12.5" to
+// Money. A leading $ and any commas are ignored. Digits past the cents are
+// rounded, half a cent away from zero.
+//
+// INPUTS
+//  s = the string
+//
+// RETURNS
+//  the amount
+//  an error if s is not a number
+//-----------------------------------------------------------------------------
+func ParseMoney(s string) (Money, error) {
+	t := strings.Replace(strings.TrimSpace(s), ",", "", -1)
+	neg := false
+	if len(t) > 0 && (t[0] == '-' || t[0] == '+') {
+		neg = t[0] == '-'
+		t = t[1:]
+	}
+	t = strings.TrimPrefix(t, "$")
+	whole, frac := t, ""
+	if i := strings.IndexByte(t, '.'); i >= 0 {
+		whole, frac = t[:i], t[i+1:]
+	}
+	if len(whole) == 0 && len(frac) == 0 || len(whole) > 16 || !allDigits(whole) || !allDigits(frac) {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+
+	var c int64
+	for i := 0; i < len(whole); i++ {
+		c = c*10 + int64(whole[i]-'0')
+	}
+	for i := 0; i < 2; i++ {
+		c *= 10
+		if i < len(frac) {
+			c += int64(frac[i] - '0')
+		}
+	}
+	if len(frac) > 2 && frac[2] >= '5' {
+		c++
+	}
+	if neg {
+		c = -c
+	}
+	return Money(c), nil
+}
+
+// allDigits returns true if s has nothing but decimal digits
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float returns m in dollars as a float64. Use it to hand an amount to code
+// that works in float64, gotable cells for example, not to do arithmetic.
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// Cents returns m as a number of cents
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Round returns m rounded, half away from zero, to the supplied number of
+// decimal places. Money is always kept in whole cents so places of 2 or more
+// returns m unchanged; Round(0) rounds to whole dollars.
+func (m Money) Round(places int) Money {
+	if places >= 2 {
+		return m
+	}
+	if places < 0 {
+		places = 0
+	}
+	d := int64(100)
+	if places == 1 {
+		d = 10
+	}
+	c := int64(m)
+	r := c % d
+	c -= r
+	if 2*r >= d {
+		c += d
+	} else if 2*r <= -d {
+		c -= d
+	}
+	return Money(c)
+}
+
+// String returns m as a decimal number with two places, ex: -1234.50
+func (m Money) String() string {
+	c := int64(m)
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// Format lets m be printed with the same verbs as a float64: %.2f, %8.2f,
+// %g, %v and %s. %d prints the number of cents.
+func (m Money) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmtDirective(f, verb), int64(m))
+	case 'v', 's':
+		fmt.Fprintf(f, fmtDirective(f, 's'), m.String())
+	default:
+		fmt.Fprintf(f, fmtDirective(f, verb), m.Float())
+	}
+}
+
+// fmtDirective rebuilds the format directive that f was called with, for
+// verb
+func fmtDirective(f fmt.State, verb rune) string {
+	s := "%"
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			s += string(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		s += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		s += "." + strconv.Itoa(p)
+	}
+	return s + string(verb)
+}
+
+// Mul returns m times x rounded to the nearest cent, half a cent away from
+// zero. Use it to apply a rate or a proration factor.
+func (m Money) Mul(x float64) Money {
+	return Money(math.Round(float64(m) * x))
+}
+
+// Prorate returns m * num / den rounded to the nearest cent, half a cent
+// away from zero. Unlike Mul there is no floating point error: 100.00
+// prorated 1 day of 3 is 33.33, 2 days of 3 is 66.67.
+//
+// INPUTS
+//  num = numerator, ex: days used
+//  den = denominator, ex: days in the period. If it is 0, m is returned.
+//
+// RETURNS
+//  the prorated amount
+//-----------------------------------------------------------------------------
+func (m Money) Prorate(num, den int64) Money {
+	if den == 0 {
+		return m
+	}
+	x := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	return moneyFromRat(new(big.Rat).SetFrac(x, big.NewInt(den)))
+}
+
+// moneyFromRat returns the Money nearest to r cents, half a cent away from
+// zero.
+//-----------------------------------------------------------------------------
+func moneyFromRat(r *big.Rat) Money {
+	x := r.Num() // the denominator of a Rat is always positive
+	d := r.Denom()
+	q, rem := new(big.Int).QuoRem(x, d, new(big.Int))
+	rem.Abs(rem).Mul(rem, big.NewInt(2))
+	if rem.Cmp(d) >= 0 {
+		if x.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Money(q.Int64())
+}
+
+// Allocate splits m into parts in proportion to weights. The parts always
+// add up to m exactly: each part is rounded down and the cents left over go,
+// one each, to the parts that were rounded down the most.
+//
+// INPUTS
+//  weights = the weight of each part, ex: the days of each period
+//
+// RETURNS
+//  the parts, all 0 if the weights add up to 0
+//-----------------------------------------------------------------------------
+func (m Money) Allocate(weights []int64) []Money {
+	parts := make([]Money, len(weights))
+	total := int64(0)
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return parts
+	}
+	sign := Money(1)
+	abs := m
+	if m < 0 {
+		sign, abs = -1, -m
+	}
+	rem := make([]int64, len(weights))
+	left := abs
+	for i, w := range weights {
+		x := new(big.Int).Mul(big.NewInt(int64(abs)), big.NewInt(w))
+		q, r := new(big.Int).QuoRem(x, big.NewInt(total), new(big.Int))
+		parts[i] = Money(q.Int64())
+		rem[i] = r.Int64()
+		left -= parts[i]
+	}
+	for ; left > 0; left-- {
+		k := 0
+		for i := 1; i < len(rem); i++ {
+			if rem[i] > rem[k] {
+				k = i
+			}
+		}
+		parts[k]++
+		rem[k] = -1
+	}
+	for i := 0; i < len(parts); i++ {
+		parts[i] *= sign
+	}
+	return parts
+}
+
+// Scan implements the sql.Scanner interface so that a DECIMAL column can be
+// read directly into Money
+func (m *Money) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		*m, err = ParseMoney(string(v))
+	case string:
+		*m, err = ParseMoney(v)
+	case float64:
+		*m = MoneyFromFloat(v)
+	case int64:
+		*m = Money(v * 100)
+	default:
+		err = fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface so that Money can be written
+// to a DECIMAL column
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// MarshalJSON writes m as a JSON number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads m from a JSON number or a string holding a number
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(strings.TrimSpace(string(b)), "\"")
+	if s == "null" || len(s) == 0 {
+		*m = 0
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		x, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		*m = MoneyFromFloat(x)
+		return nil
+	}
+	x, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = x
+	return nil
+}
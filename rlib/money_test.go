@@ -0,0 +1,200 @@
+package rlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// Money tests.
+
+func TestParseMoney(t *testing.T) {
+	var tests = []struct {
+		s   string
+		m   Money
+		bad bool
+	}{
+		{"0", 0, false},
+		{"12", 1200, false},
+		{"12.5", 1250, false},
+		{"-12.34", -1234, false},
+		{"$1,234.56", 123456, false},
+		{"1.005", 101, false},
+		{"1.0049", 100, false},
+		{"-1.005", -101, false},
+		{".07", 7, false},
+		{"1500.0000", 150000, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"1.2.3", 0, true},
+	}
+	for i := 0; i < len(tests); i++ {
+		m, err := ParseMoney(tests[i].s)
+		if (err != nil) != tests[i].bad {
+			t.Errorf("test %d: %q, unexpected error result: %v\n", i, tests[i].s, err)
+			continue
+		}
+		if m != tests[i].m {
+			t.Errorf("test %d: %q, expected %d cents, got %d\n", i, tests[i].s, tests[i].m, m)
+		}
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	var tests = []struct {
+		x float64
+		m Money
+	}{
+		{0.1 + 0.2, 30},
+		{1.005, 101},
+		{2.675, 268},
+		{-2.675, -268},
+		{1234567.891, 123456789},
+	}
+	for i := 0; i < len(tests); i++ {
+		if m := MoneyFromFloat(tests[i].x); m != tests[i].m {
+			t.Errorf("test %d: %v, expected %d cents, got %d\n", i, tests[i].x, tests[i].m, m)
+		}
+	}
+}
+
+func TestMoneyRound(t *testing.T) {
+	var tests = []struct {
+		m      Money
+		places int
+		r      Money
+	}{
+		{123456, 2, 123456},
+		{123449, 0, 123400},
+		{123450, 0, 123500},
+		{-123450, 0, -123500},
+		{-123449, 0, -123400},
+		{1234, 1, 1230},
+		{1235, 1, 1240},
+		{-1235, 1, -1240},
+	}
+	for i := 0; i < len(tests); i++ {
+		if r := tests[i].m.Round(tests[i].places); r != tests[i].r {
+			t.Errorf("test %d: %d cents to %d places, expected %d cents, got %d\n", i, tests[i].m, tests[i].places, tests[i].r, r)
+		}
+	}
+}
+
+func TestMoneyProrate(t *testing.T) {
+	var tests = []struct {
+		m        Money
+		num, den int64
+		r        Money
+	}{
+		{10000, 1, 3, 3333},
+		{10000, 2, 3, 6667},
+		{-10000, 2, 3, -6667},
+		{100000, 17, 31, 54839},
+		{5, 1, 2, 3},
+		{-5, 1, 2, -3},
+		{1234, 5, 0, 1234},
+		{10000, 1, -3, -3333},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		if r := tc.m.Prorate(tc.num, tc.den); r != tc.r {
+			t.Errorf("test %d: %d * %d/%d, expected %d, got %d\n", i, tc.m, tc.num, tc.den, tc.r, r)
+		}
+	}
+}
+
+// Rounding the running total of exact amounts, the way CalculateLoadedGSR
+// does, makes the rounded parts add up to the rounded total.
+func TestMoneyFromRat(t *testing.T) {
+	var tests = []struct {
+		m        Money
+		num, den int64 // each part is m * num / den
+		n        int   // number of parts
+		r        Money // rounded total
+	}{
+		{100000, 1, 31, 31, 100000},
+		{100000, 1, 30, 31, 103333},
+		{-100000, 1, 7, 7, -100000},
+		{1, 1, 2, 3, 2},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		var sum Money
+		total := new(big.Rat)
+		part := new(big.Rat).SetFrac(big.NewInt(int64(tc.m)*tc.num), big.NewInt(tc.den))
+		for j := 0; j < tc.n; j++ {
+			total.Add(total, part)
+			p := moneyFromRat(total) - sum
+			if d := p - tc.m.Prorate(tc.num, tc.den); d < -1 || d > 1 {
+				t.Errorf("test %d: part %d is %d, more than a cent from %s\n", i, j, p, part.FloatString(2))
+			}
+			sum += p
+		}
+		if sum != tc.r {
+			t.Errorf("test %d: %d parts of %d * %d/%d, expected %d, got %d\n", i, tc.n, tc.m, tc.num, tc.den, tc.r, sum)
+		}
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	var tests = []struct {
+		m     Money
+		w     []int64
+		parts []Money
+	}{
+		{10000, []int64{1, 1, 1}, []Money{3334, 3333, 3333}},
+		{-10000, []int64{1, 1, 1}, []Money{-3334, -3333, -3333}},
+		{100, []int64{31, 28, 31}, []Money{35, 31, 34}},
+		{100, []int64{0, 0}, []Money{0, 0}},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		parts := tc.m.Allocate(tc.w)
+		sum := Money(0)
+		for j := 0; j < len(parts); j++ {
+			sum += parts[j]
+			if parts[j] != tc.parts[j] {
+				t.Errorf("test %d: part %d, expected %d, got %d\n", i, j, tc.parts[j], parts[j])
+			}
+		}
+		if sum != tc.m && len(tc.w) > 0 && tc.w[0] > 0 {
+			t.Errorf("test %d: parts add up to %d, expected %d\n", i, sum, tc.m)
+		}
+	}
+}
+
+func TestMoneyFormatAndJSON(t *testing.T) {
+	m := Money(-123456)
+	var tests = []struct {
+		got, want string
+	}{
+		{m.String(), "-1234.56"},
+		{fmt.Sprintf("%.2f", m), "-1234.56"},
+		{fmt.Sprintf("%10.2f", m), "  -1234.56"},
+		{fmt.Sprintf("%v", m), "-1234.56"},
+		{fmt.Sprintf("%d", m), "-123456"},
+		{Money(7).String(), "0.07"},
+	}
+	for i := 0; i < len(tests); i++ {
+		if tests[i].got != tests[i].want {
+			t.Errorf("test %d: expected %q, got %q\n", i, tests[i].want, tests[i].got)
+		}
+	}
+
+	var s struct {
+		A Money
+		B Money
+		C Money
+	}
+	if err := json.Unmarshal([]byte(`{"A": 12.34, "B": "5.5", "C": null}`), &s); err != nil {
+		t.Errorf("unmarshal: %s\n", err.Error())
+	}
+	if s.A != 1234 || s.B != 550 || s.C != 0 {
+		t.Errorf("unmarshal: got %d %d %d\n", s.A, s.B, s.C)
+	}
+	b, _ := json.Marshal(&s)
+	if string(b) != `{"A":12.34,"B":5.50,"C":0.00}` {
+		t.Errorf("marshal: got %s\n", string(b))
+	}
+}
@@ -0,0 +1,268 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Night audit.  A hotel closes each day with the night audit. It checks the
+// guests in house against their Rental Agreements and rooms, posts the
+// room and tax charges of the night, marks the guests who did not arrive as
+// no-shows and locks the day with a LMLOCKED JournalMarker. The day after
+// the last locked day is the business date, the next night to audit.
+
+// InHouseGuest is a checked-in Reservation staying the night being audited
+type InHouseGuest struct {
+	Res      Reservation             // the reservation
+	RAR      RentalAgreementRentable // its room in the Rental Agreement, RARID is 0 if missing
+	Problems []string                // what the night audit found wrong with the stay
+}
+
+// NightAuditAR is the revenue of an account rule on the audited night
+type NightAuditAR struct {
+	ARID   int64  // the account rule
+	Name   string // its name
+	Amount Money  // total of its charges dated on the night
+}
+
+// NightAuditReport summarizes a night of a hotel
+type NightAuditReport struct {
+	BID         int64          // the business
+	Dt          time.Time      // the night, it starts on Dt
+	Locked      bool           // the night audit has locked the day
+	Rooms       int64          // Rentables of the types rented daily
+	OutOfOrder  int64          // rooms out of service and not occupied
+	Occupied    int64          // rooms sold
+	RoomRevenue Money          // charges to the rent account rules of the room types
+	Occupancy   float64        // Occupied / rooms available, 0.0 - 1.0
+	ADR         Money          // average daily rate, RoomRevenue / Occupied
+	RevPAR      Money          // revenue per available room, RoomRevenue / rooms available
+	Revenue     []NightAuditAR // the charges of the night by account rule
+	InHouse     []InHouseGuest // the guests staying the night
+	NoShows     []Reservation  // the guests due that did not arrive
+}
+
+// NightAuditDate returns the business date of business bid, the next night
+// for the night audit. It is the day after the last day locked by the night
+// audit, or TIME0 if no day has been locked.
+//
+// INPUTS
+//  ctx = db context
+//  bid = the business
+//
+// RETURNS
+//  the business date
+//  any error encountered
+//-----------------------------------------------------------------------------
+func NightAuditDate(ctx context.Context, bid int64) (time.Time, error) {
+	jm, err := GetLastJournalMarkerByState(ctx, bid, LMLOCKED)
+	if err != nil || jm.JMID == 0 {
+		return TIME0, err
+	}
+	return jm.DtStop, nil
+}
+
+// GetInHouseGuests returns the checked-in Reservations of business bid that
+// stay the night of dt. Each is checked against its Rental Agreement, which
+// must be active and have the room for the night, and against the status of
+// the room, which must be in service.
+//
+// INPUTS
+//  ctx = db context
+//  bid = the business
+//  dt  = the night
+//
+// RETURNS
+//  the guests in house
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetInHouseGuests(ctx context.Context, bid int64, dt *time.Time) ([]InHouseGuest, error) {
+	var m []InHouseGuest
+	next := dt.AddDate(0, 0, 1)
+	res, err := GetReservationsByRange(ctx, bid, dt, &next)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(res); i++ {
+		if res[i].FLAGS&RESSTATEMask != RESSTATECheckedIn {
+			continue
+		}
+		g := InHouseGuest{Res: res[i]}
+		ra, err := GetRentalAgreement(ctx, res[i].RAID)
+		if err != nil {
+			return m, err
+		}
+		switch {
+		case ra.RAID == 0:
+			g.Problems = append(g.Problems, fmt.Sprintf("rental agreement %d not found", res[i].RAID))
+		case ra.FLAGS&0xf != RASTATEActive:
+			g.Problems = append(g.Problems, fmt.Sprintf("rental agreement %d is in state %s", ra.RAID, ra.GetStatusString()))
+		}
+
+		rars, err := GetRentalAgreementRentables(ctx, res[i].RAID, dt, &next)
+		if err != nil {
+			return m, err
+		}
+		for j := 0; j < len(rars); j++ {
+			if rars[j].RID == res[i].RID {
+				g.RAR = rars[j]
+				break
+			}
+		}
+		if g.RAR.RARID == 0 {
+			g.Problems = append(g.Problems, fmt.Sprintf("rentable %d is not in rental agreement %d", res[i].RID, res[i].RAID))
+		}
+
+		rs, err := GetRentableStatusByRange(ctx, res[i].RID, dt, &next)
+		if err != nil {
+			return m, err
+		}
+		for j := 0; j < len(rs); j++ {
+			if rs[j].UseStatus != USESTATUSinService && rs[j].UseStatus != USESTATUSunknown {
+				g.Problems = append(g.Problems, fmt.Sprintf("rentable %d is %s", res[i].RID, rs[j].UseStatusStringer()))
+				break
+			}
+		}
+		m = append(m, g)
+	}
+	return m, nil
+}
+
+// GetNightAuditReport builds the night audit report of the night of dt:
+// the occupancy, ADR and RevPAR of the rooms, the revenue by account rule,
+// the guests in house with any problems found and the no-shows. The rooms
+// are the Rentables of the types with a daily rent cycle.
+//
+// INPUTS
+//  ctx  = db context
+//  xbiz = the business
+//  dt   = the night
+//
+// RETURNS
+//  the report
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetNightAuditReport(ctx context.Context, xbiz *XBusiness, dt *time.Time) (NightAuditReport, error) {
+	var r = NightAuditReport{BID: xbiz.P.BID, Dt: *dt}
+	next := dt.AddDate(0, 0, 1)
+
+	bizdt, err := NightAuditDate(ctx, r.BID)
+	if err != nil {
+		return r, err
+	}
+	r.Locked = dt.Before(bizdt)
+
+	r.InHouse, err = GetInHouseGuests(ctx, r.BID, dt)
+	if err != nil {
+		return r, err
+	}
+	occupied := map[int64]bool{}
+	for i := 0; i < len(r.InHouse); i++ {
+		occupied[r.InHouse[i].Res.RID] = true
+	}
+	r.Occupied = int64(len(occupied))
+
+	//------------------------------------------------------------
+	// the rooms, and the rent account rules of their types
+	//------------------------------------------------------------
+	roomARs := map[int64]bool{}
+	for rtid, rt := range xbiz.RT {
+		if rt.RentCycle != RECURDAILY {
+			continue
+		}
+		if rt.ARID > 0 {
+			roomARs[rt.ARID] = true
+		}
+		rooms, err := GetRentablesByRTID(ctx, r.BID, rtid, dt, &next)
+		if err != nil {
+			return r, err
+		}
+		for i := 0; i < len(rooms); i++ {
+			r.Rooms++
+			if occupied[rooms[i].RID] {
+				continue
+			}
+			rs, err := GetRentableStatusByRange(ctx, rooms[i].RID, dt, &next)
+			if err != nil {
+				return r, err
+			}
+			for j := 0; j < len(rs); j++ {
+				if rs[j].UseStatus != USESTATUSinService && rs[j].UseStatus != USESTATUSunknown {
+					r.OutOfOrder++
+					break
+				}
+			}
+		}
+	}
+
+	//------------------------------------------------------------
+	// the revenue of the night
+	//------------------------------------------------------------
+	asms, err := GetAllSingleInstanceAssessments(ctx, r.BID, dt, &next)
+	if err != nil {
+		return r, err
+	}
+	arm, err := GetARMap(ctx, r.BID)
+	if err != nil {
+		return r, err
+	}
+	rev := map[int64]Money{}
+	for i := 0; i < len(asms); i++ {
+		if asms[i].Start.Before(*dt) {
+			continue // an instance of a longer period that started earlier
+		}
+		rev[asms[i].ARID] += asms[i].Amount
+		if roomARs[asms[i].ARID] {
+			r.RoomRevenue += asms[i].Amount
+		}
+	}
+	for arid, amt := range rev {
+		r.Revenue = append(r.Revenue, NightAuditAR{ARID: arid, Name: arm[arid].Name, Amount: amt})
+	}
+	sort.Slice(r.Revenue, func(i, j int) bool { return r.Revenue[i].Name < r.Revenue[j].Name })
+	r.Occupancy, r.ADR, r.RevPAR = nightAuditStats(r.Rooms, r.OutOfOrder, r.Occupied, r.RoomRevenue)
+
+	//------------------------------------------------------------
+	// the guests due that did not arrive
+	//------------------------------------------------------------
+	res, err := GetReservationsByRange(ctx, r.BID, dt, &next)
+	if err != nil {
+		return r, err
+	}
+	for i := 0; i < len(res); i++ {
+		if res[i].FLAGS&RESSTATEMask == RESSTATENoShow && res[i].DtArrive.Equal(*dt) {
+			r.NoShows = append(r.NoShows, res[i])
+		}
+	}
+	return r, nil
+}
+
+// nightAuditStats computes the occupancy, the average daily rate and the
+// revenue per available room of a night
+//
+// INPUTS
+//  rooms      = all the rooms
+//  outOfOrder = rooms out of service and not occupied
+//  occupied   = rooms sold
+//  revenue    = the room revenue
+//
+// RETURNS
+//  occupancy, 0.0 - 1.0
+//  ADR
+//  RevPAR
+//-----------------------------------------------------------------------------
+func nightAuditStats(rooms, outOfOrder, occupied int64, revenue Money) (float64, Money, Money) {
+	var occ float64
+	var adr, revpar Money
+	avail := rooms - outOfOrder
+	if avail > 0 {
+		occ = float64(occupied) / float64(avail)
+		revpar = revenue.Prorate(1, avail)
+	}
+	if occupied > 0 {
+		adr = revenue.Prorate(1, occupied)
+	}
+	return occ, adr, revpar
+}
@@ -0,0 +1,36 @@
+package rlib
+
+import (
+	"testing"
+)
+
+// Night audit statistics tests.
+
+func TestNightAuditStats(t *testing.T) {
+	var tests = []struct {
+		rooms, ooo, occupied int64
+		revenue              Money
+		occ                  float64
+		adr, revpar          Money
+	}{
+		// 8 of 10 rooms sold for 800.00
+		{10, 0, 8, 80000, 0.8, 10000, 8000},
+		// 2 rooms out of order
+		{10, 2, 6, 60000, 0.75, 10000, 7500},
+		// nothing sold
+		{10, 0, 0, 0, 0, 0, 0},
+		// 3 rooms sold for 250.00, ADR rounds to the cent
+		{4, 0, 3, 25000, 0.75, 8333, 6250},
+		// every room out of order
+		{2, 2, 0, 0, 0, 0, 0},
+		// no rooms
+		{0, 0, 0, 0, 0, 0, 0},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		occ, adr, revpar := nightAuditStats(tc.rooms, tc.ooo, tc.occupied, tc.revenue)
+		if occ != tc.occ || adr != tc.adr || revpar != tc.revpar {
+			t.Errorf("test %d: expected occupancy %g, ADR %s, RevPAR %s, got %g, %s, %s\n", i, tc.occ, tc.adr, tc.revpar, occ, adr, revpar)
+		}
+	}
+}
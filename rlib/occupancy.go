@@ -0,0 +1,248 @@
+package rlib
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// OccupancyStats holds the physical and economic occupancy figures for a
+// group of Rentables over a single period. The group is either all the
+// Rentables of one RentableType (RTID > 0) or all Rentables in the business
+// (RTID == 0, the totals row).
+type OccupancyStats struct {
+	RTID              int64     // the rentable type, 0 for the business totals
+	RentableType      string    // name of the rentable type
+	DtStart           time.Time // start of period
+	DtStop            time.Time // end of period (not inclusive)
+	Rentables         int64     // number of rentables of this type during the period
+	VacantRentables   int64     // number of rentables that were vacant at some point during the period
+	RentableDays      float64   // total days available (Rentables * days in period)
+	OccupiedDays      float64   // days covered by a rental agreement
+	VacantDays        float64   // days not covered by a rental agreement
+	PhysicalOccupancy float64   // OccupiedDays / RentableDays as a percent
+	GSR               Money     // gross scheduled rent for the period
+	Collected         Money     // rent payments applied during the period
+	EconomicOccupancy float64   // Collected / GSR as a percent
+	VacancyLoss       Money     // GSR attributable to the vacant days
+	Concessions       Money     // concessions posted on rent charges
+	AvgDaysVacant     float64   // VacantDays / VacantRentables
+}
+
+// OccupancyTrendPeriod is the collection of OccupancyStats for one month of
+// an occupancy trend.
+type OccupancyTrendPeriod struct {
+	DtStart time.Time        // start of the month (or the trend start)
+	DtStop  time.Time        // end of the month (or the trend stop)
+	Types   []OccupancyStats // one entry per RentableType, sorted by name
+	Total   OccupancyStats   // totals for all types
+}
+
+// OccupancyMonths breaks the supplied time range into monthly periods. The
+// first and last periods are truncated to d1 and d2 respectively, so a range
+// of Jan 15 - Mar 10 produces Jan 15 - Feb 1, Feb 1 - Mar 1, Mar 1 - Mar 10.
+//
+// INPUTS
+//  d1 - start of range
+//  d2 - end of range (not inclusive)
+//
+// RETURNS
+//  a slice of Periods covering d1 - d2
+//-----------------------------------------------------------------------------
+func OccupancyMonths(d1, d2 *time.Time) []Period {
+	var m []Period
+	for dt := *d1; dt.Before(*d2); {
+		next := time.Date(dt.Year(), dt.Month()+1, 1, 0, 0, 0, 0, dt.Location())
+		if next.After(*d2) {
+			next = *d2
+		}
+		m = append(m, Period{D1: dt, D2: next})
+		dt = next
+	}
+	return m
+}
+
+// daysInPeriod returns the number of days between d1 and d2 as a float
+//-----------------------------------------------------------------------------
+func daysInPeriod(d1, d2 *time.Time) float64 {
+	return d2.Sub(*d1).Hours() / 24
+}
+
+// finishOccupancyStats computes the percentages and averages from the
+// accumulated totals in s.
+//-----------------------------------------------------------------------------
+func finishOccupancyStats(s *OccupancyStats) {
+	if s.RentableDays > 0 {
+		s.PhysicalOccupancy = RoundToCent(100 * s.OccupiedDays / s.RentableDays)
+	}
+	if s.GSR > 0 {
+		s.EconomicOccupancy = RoundToCent(100 * s.Collected.Float() / s.GSR.Float())
+	}
+	if s.VacantRentables > 0 {
+		s.AvgDaysVacant = RoundToCent(s.VacantDays / float64(s.VacantRentables))
+	}
+}
+
+// addOccupancyStats adds the totals of b into a
+//-----------------------------------------------------------------------------
+func addOccupancyStats(a, b *OccupancyStats) {
+	a.Rentables += b.Rentables
+	a.VacantRentables += b.VacantRentables
+	a.RentableDays += b.RentableDays
+	a.OccupiedDays += b.OccupiedDays
+	a.VacantDays += b.VacantDays
+	a.GSR += b.GSR
+	a.Collected += b.Collected
+	a.VacancyLoss += b.VacancyLoss
+	a.Concessions += b.Concessions
+}
+
+// getConcessionsByRentable returns a map of RID to the total amount of the
+// concessions posted on the rent charges of that Rentable during d1 - d2.
+//-----------------------------------------------------------------------------
+func getConcessionsByRentable(ctx context.Context, bid int64, d1, d2 *time.Time) (map[int64]Money, error) {
+	var m = map[int64]Money{}
+	a, err := GetConcessionAssessments(ctx, bid, d1, d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(a); i++ {
+		m[a[i].RID] += a[i].Amount
+	}
+	return m, nil
+}
+
+// GetOccupancyStats computes the occupancy statistics for every RentableType
+// in the business for the period d1 - d2.
+//
+// INPUTS
+//  ctx  - db context
+//  xbiz - the business, InitBizInternals must have been called
+//  d1   - start of period
+//  d2   - stop of period (not inclusive)
+//
+// RETURNS
+//  the stats for the period
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetOccupancyStats(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time) (OccupancyTrendPeriod, error) {
+	const funcname = "GetOccupancyStats"
+	var (
+		p     = OccupancyTrendPeriod{DtStart: *d1, DtStop: *d2}
+		rtmap = map[int64]*OccupancyStats{}
+		days  = daysInPeriod(d1, d2)
+	)
+	p.Total.DtStart = *d1
+	p.Total.DtStop = *d2
+	p.Total.RentableType = "Total"
+
+	collected, err := GetRentCollectedByRentable(ctx, xbiz.P.BID, d1, d2)
+	if err != nil {
+		return p, err
+	}
+	concessions, err := getConcessionsByRentable(ctx, xbiz.P.BID, d1, d2)
+	if err != nil {
+		return p, err
+	}
+
+	//-------------------------------------------------------
+	// read the rentables up front so that the statements of
+	// the loop can run in the caller's transaction
+	//-------------------------------------------------------
+	rl, err := GetRentablesByBusiness(ctx, xbiz.P.BID)
+	if err != nil {
+		return p, err
+	}
+	for _, r := range rl {
+		rtr, err := GetRentableTypeRefForDate(ctx, r.RID, d1)
+		if err != nil {
+			return p, err
+		}
+		if rtr.RTID == 0 {
+			continue // the rentable did not exist (or had no type) at the start of the period
+		}
+		s, ok := rtmap[rtr.RTID]
+		if !ok {
+			s = &OccupancyStats{
+				RTID:         rtr.RTID,
+				RentableType: xbiz.RT[rtr.RTID].Name,
+				DtStart:      *d1,
+				DtStop:       *d2,
+			}
+			rtmap[rtr.RTID] = s
+		}
+		s.Rentables++
+		s.RentableDays += days
+
+		//-------------------------------------------------------
+		// Any time not covered by a rental agreement is vacant
+		//-------------------------------------------------------
+		rar, err := GetAgreementsForRentable(ctx, r.RID, d1, d2)
+		if err != nil {
+			return p, err
+		}
+		var a []Period
+		for i := 0; i < len(rar); i++ {
+			a = append(a, Period{D1: rar[i].RARDtStart, D2: rar[i].RARDtStop})
+		}
+		gaps := FindGaps(d1, d2, a)
+		vacant := float64(0)
+		for i := 0; i < len(gaps); i++ {
+			vacant += daysInPeriod(&gaps[i].D1, &gaps[i].D2)
+			loss, _, _, err := CalculateLoadedGSR(ctx, r.BID, r.RID, &gaps[i].D1, &gaps[i].D2, xbiz)
+			if err != nil {
+				Console("%s: RID %d, error calculating vacancy loss: %s\n", funcname, r.RID, err.Error())
+				continue
+			}
+			s.VacancyLoss += loss
+		}
+		if vacant > 0 {
+			s.VacantRentables++
+		}
+		s.VacantDays += vacant
+		s.OccupiedDays += days - vacant
+
+		gsr, _, _, err := CalculateLoadedGSR(ctx, r.BID, r.RID, d1, d2, xbiz)
+		if err != nil {
+			Console("%s: RID %d, error calculating GSR: %s\n", funcname, r.RID, err.Error())
+		}
+		s.GSR += gsr
+		s.Collected += collected[r.RID]
+		s.Concessions += concessions[r.RID]
+	}
+
+	for _, v := range rtmap {
+		addOccupancyStats(&p.Total, v)
+		finishOccupancyStats(v)
+		p.Types = append(p.Types, *v)
+	}
+	finishOccupancyStats(&p.Total)
+	sort.Slice(p.Types, func(i, j int) bool { return p.Types[i].RentableType < p.Types[j].RentableType })
+	return p, nil
+}
+
+// GetOccupancyTrend computes the monthly occupancy statistics for the
+// business over the period d1 - d2.
+//
+// INPUTS
+//  ctx  - db context
+//  xbiz - the business, InitBizInternals must have been called
+//  d1   - start of trend
+//  d2   - stop of trend (not inclusive)
+//
+// RETURNS
+//  a slice with one OccupancyTrendPeriod per month
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetOccupancyTrend(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time) ([]OccupancyTrendPeriod, error) {
+	var m []OccupancyTrendPeriod
+	months := OccupancyMonths(d1, d2)
+	for i := 0; i < len(months); i++ {
+		p, err := GetOccupancyStats(ctx, xbiz, &months[i].D1, &months[i].D2)
+		if err != nil {
+			return m, err
+		}
+		m = append(m, p)
+	}
+	return m, nil
+}
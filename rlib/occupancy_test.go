@@ -0,0 +1,39 @@
+package rlib
+
+import "testing"
+
+// Occupancy trend month splitting tests
+
+type occMonthData struct {
+	d1, d2 string
+	expect []string // pairs of start/stop dates
+}
+
+func TestOccupancyMonths(t *testing.T) {
+	var m = []occMonthData{
+		{"2018-01-01", "2018-02-01", []string{"2018-01-01", "2018-02-01"}},                                                         // one full month
+		{"2018-01-15", "2018-03-10", []string{"2018-01-15", "2018-02-01", "2018-02-01", "2018-03-01", "2018-03-01", "2018-03-10"}}, // partial first and last
+		{"2018-12-01", "2019-02-01", []string{"2018-12-01", "2019-01-01", "2019-01-01", "2019-02-01"}},                             // year boundary
+		{"2018-03-05", "2018-03-20", []string{"2018-03-05", "2018-03-20"}},                                                         // within one month
+		{"2018-03-05", "2018-03-05", []string{}},                                                                                   // empty range
+	}
+
+	for i := 0; i < len(m); i++ {
+		d1, _ := StringToDate(m[i].d1)
+		d2, _ := StringToDate(m[i].d2)
+		p := OccupancyMonths(&d1, &d2)
+		t.Logf("OccupancyMonths( %s, %s ) expect %d periods, got %d\n", m[i].d1, m[i].d2, len(m[i].expect)/2, len(p))
+		if len(p) != len(m[i].expect)/2 {
+			t.Errorf("OccupancyMonths( %s, %s ) expect %d periods, got %d\n", m[i].d1, m[i].d2, len(m[i].expect)/2, len(p))
+			continue
+		}
+		for j := 0; j < len(p); j++ {
+			e1, _ := StringToDate(m[i].expect[2*j])
+			e2, _ := StringToDate(m[i].expect[2*j+1])
+			if !p[j].D1.Equal(e1) || !p[j].D2.Equal(e2) {
+				t.Errorf("OccupancyMonths( %s, %s ) period %d: expect %s - %s, got %s - %s\n", m[i].d1, m[i].d2, j,
+					e1.Format(RRDATEFMT4), e2.Format(RRDATEFMT4), p[j].D1.Format(RRDATEFMT4), p[j].D2.Format(RRDATEFMT4))
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package rlib
+
+import "math"
+
+// PCTRENTNatural and PCTRENTFixed are the values of PctRent.BreakpointType.
+//
+//  Natural  the first breakpoint is the base rent billed for the period
+//           divided by the first tier's rate: the sales at which the
+//           percentage rent equals the base rent
+//  Fixed    the first breakpoint is the first tier's Breakpoint
+//
+// Breakpoints of the other tiers are always fixed. Fixed breakpoints are
+// annual amounts, they are prorated to the length of the period reported.
+const (
+	PCTRENTNatural = 1
+	PCTRENTFixed   = 2
+)
+
+// SALESRPTComputed is the SalesReport FLAGS bit set once the overage has
+// been computed and assessed
+const SALESRPTComputed = 1 << 0
+
+// PctRentOverage computes the percentage rent due on a period's sales.
+// Each tier's rate applies to the sales between its breakpoint and the next
+// tier's breakpoint; the last tier has no upper limit.
+//
+// INPUTS
+//  sales   = gross sales for the period
+//  tiers   = the tiers, lowest breakpoint first
+//  natural = if > 0, the natural breakpoint for the period. It replaces the
+//            first tier's breakpoint.
+//  frac    = length of the period as a fraction of a year, used to prorate
+//            the fixed breakpoints
+//
+// RETURNS
+//  the first breakpoint for the period
+//  the overage rent
+//-----------------------------------------------------------------------------
+func PctRentOverage(sales Money, tiers []PctRentTier, natural Money, frac float64) (Money, Money) {
+	if len(tiers) == 0 {
+		return 0, 0
+	}
+	bp := make([]Money, len(tiers))
+	for i := 0; i < len(tiers); i++ {
+		bp[i] = tiers[i].Breakpoint.Mul(frac)
+		if i == 0 && natural > 0 {
+			bp[i] = natural
+		}
+		if i > 0 && bp[i] < bp[i-1] {
+			bp[i] = bp[i-1] // a natural breakpoint can pass the next tier
+		}
+	}
+
+	overage := float64(0) // cents, rounded once at the end
+	for i := 0; i < len(tiers); i++ {
+		if sales <= bp[i] {
+			break
+		}
+		top := sales
+		if i+1 < len(tiers) && bp[i+1] < top {
+			top = bp[i+1]
+		}
+		overage += float64(top-bp[i]) * tiers[i].Rate
+	}
+	return bp[0], Money(math.Round(overage))
+}
+
+// PctRentNaturalBreakpoint returns the natural breakpoint for a period: the
+// sales at which rate times sales equals the base rent billed.
+//
+// INPUTS
+//  base = base rent billed for the period
+//  rate = the first tier's rate
+//
+// RETURNS
+//  the breakpoint, 0 if rate is 0
+//-----------------------------------------------------------------------------
+func PctRentNaturalBreakpoint(base Money, rate float64) Money {
+	if rate <= 0 {
+		return 0
+	}
+	return Money(math.Round(float64(base) / rate))
+}
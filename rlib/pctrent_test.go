@@ -0,0 +1,44 @@
+package rlib
+
+import "testing"
+
+// Percentage rent tests.
+
+func TestPctRentOverage(t *testing.T) {
+	two := []PctRentTier{{Breakpoint: MoneyFromFloat(500000), Rate: 0.06}, {Breakpoint: MoneyFromFloat(1000000), Rate: 0.04}}
+	var tests = []struct {
+		sales   float64
+		tiers   []PctRentTier
+		natural float64
+		frac    float64
+		bp      float64
+		overage float64
+	}{
+		// one tier, a year, fixed breakpoint
+		{600000, two[:1], 0, 1, 500000, 6000},
+		{400000, two[:1], 0, 1, 500000, 0},
+		{500000, two[:1], 0, 1, 500000, 0},
+		// two tiers: 6% of 500,000 - 1,000,000, 4% above
+		{1200000, two, 0, 1, 500000, 38000},
+		{800000, two, 0, 1, 500000, 18000},
+		// a quarter: the breakpoints are prorated
+		{200000, two, 0, 0.25, 125000, 4500},
+		{300000, two, 0, 0.25, 125000, 9500},
+		// natural breakpoint of 60,000 base rent at 6%
+		{1100000, two, 1000000, 1, 1000000, 4000},
+		// natural breakpoint above the second tier
+		{1300000, two, 1200000, 1, 1200000, 4000},
+		// no tiers
+		{1300000, nil, 0, 1, 0, 0},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		bp, overage := PctRentOverage(MoneyFromFloat(tc.sales), tc.tiers, MoneyFromFloat(tc.natural), tc.frac)
+		if bp != MoneyFromFloat(tc.bp) || overage != MoneyFromFloat(tc.overage) {
+			t.Errorf("test %d: expected breakpoint %.2f overage %.2f, got %.2f %.2f\n", i, tc.bp, tc.overage, bp, overage)
+		}
+	}
+	if bp := PctRentNaturalBreakpoint(MoneyFromFloat(60000), 0.06); bp != MoneyFromFloat(1000000) {
+		t.Errorf("PctRentNaturalBreakpoint: expected 1000000.00, got %.2f\n", bp)
+	}
+}
@@ -0,0 +1,259 @@
+package rlib
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Tenant portal.  Tenants log in with a PortalUser credential that is tied
+// to their Transactant.  A successful login returns a random session token.
+// Only the SHA256 hash of the token is kept in the PortalUser, so a copy of
+// the database cannot be used to take over a session.  There is one session
+// per PortalUser; logging in again replaces it.  All database work done on
+// behalf of a tenant is done as the TenantPortalBot.
+
+// PortalUser FLAGS and login settings
+const (
+	PortalUserDisabled     = uint64(1 << 0) // login is disabled
+	PortalMaxFailedLogins  = 5              // consecutive failures before the login is locked
+	PortalMinPasswordLen   = 8              // shortest password accepted
+	PortalPBKDF2Iterations = 10000          // PBKDF2 iterations used to hash passwords
+	portalSaltLen          = 16             // bytes of salt
+	portalKeyLen           = 32             // bytes in the password hash
+	portalTokenLen         = 32             // bytes in a session token
+)
+
+// PortalSessionTimeout is how long a portal session lasts without activity
+var PortalSessionTimeout = 30 * time.Minute
+
+// ErrPortalLogin is returned for a bad username or password. The same error
+// is used for both so that it does not reveal which usernames exist.
+var ErrPortalLogin = errors.New("invalid username or password")
+
+// ErrPortalLocked is returned when a login has been disabled or locked
+var ErrPortalLocked = errors.New("this login is locked, please contact the office")
+
+// ErrPortalSession is returned when the session token is unknown or expired
+var ErrPortalSession = errors.New("portal session expired, please log in")
+
+// pbkdf2SHA256 is PBKDF2 (RFC 8018) using HMAC-SHA256 as the pseudorandom
+// function.
+//
+// INPUTS
+//  password - the password
+//  salt     - the salt
+//  iter     - number of iterations
+//  keyLen   - length of the derived key in bytes
+//
+// RETURNS
+//  the derived key
+//-----------------------------------------------------------------------------
+func pbkdf2SHA256(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	nBlocks := (keyLen + hashLen - 1) / hashLen
+	var dk []byte
+	buf := make([]byte, 4)
+	for block := 1; block <= nBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		buf[0] = byte(block >> 24)
+		buf[1] = byte(block >> 16)
+		buf[2] = byte(block >> 8)
+		buf[3] = byte(block)
+		prf.Write(buf)
+		u := prf.Sum(nil)
+		t := make([]byte, len(u))
+		copy(t, u)
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:keyLen]
+}
+
+// HashPortalPassword returns the hex encoded hash of password using the hex
+// encoded salt.
+//-----------------------------------------------------------------------------
+func HashPortalPassword(password, salt string) (string, error) {
+	b, err := hex.DecodeString(salt)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(pbkdf2SHA256([]byte(password), b, PortalPBKDF2Iterations, portalKeyLen)), nil
+}
+
+// SetPortalPassword gives a a new salt and sets its PassHash from password.
+// It does not write a to the database.
+//-----------------------------------------------------------------------------
+func SetPortalPassword(a *PortalUser, password string) error {
+	if len(password) < PortalMinPasswordLen {
+		return fmt.Errorf("password must be at least %d characters", PortalMinPasswordLen)
+	}
+	b := make([]byte, portalSaltLen)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	salt := hex.EncodeToString(b)
+	h, err := HashPortalPassword(password, salt)
+	if err != nil {
+		return err
+	}
+	a.Salt = salt
+	a.PassHash = h
+	return nil
+}
+
+// CheckPortalPassword returns true if password is the password of a
+//-----------------------------------------------------------------------------
+func CheckPortalPassword(a *PortalUser, password string) bool {
+	h, err := HashPortalPassword(password, a.Salt)
+	if err != nil || len(a.PassHash) == 0 {
+		return false
+	}
+	return hmac.Equal([]byte(h), []byte(a.PassHash))
+}
+
+// portalTokenHash returns the value stored in PortalUser.Token for the
+// session token token
+//-----------------------------------------------------------------------------
+func portalTokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// PortalContext returns a copy of ctx with a session for the TenantPortalBot.
+// The session is not added to the session store so it cannot be used by a
+// browser.
+//-----------------------------------------------------------------------------
+func PortalContext(ctx context.Context) context.Context {
+	s := Session{
+		Token:    "TenantPortalBot",
+		Username: BotReg[TenantPortalBot].Designator,
+		Name:     BotReg[TenantPortalBot].Name,
+		UID:      TenantPortalBot,
+		CoCode:   -1,
+		Expire:   time.Now().Add(PortalSessionTimeout),
+		RoleID:   -1,
+	}
+	return SetSessionContextKey(ctx, &s)
+}
+
+// PortalLogin checks the username and password of a tenant. If they are
+// good a new session is started for the PortalUser.
+//
+// INPUTS
+//  ctx      - context with a TenantPortalBot session, see PortalContext
+//  bid      - the business
+//  username - login name
+//  password - password
+//  now      - current time
+//
+// RETURNS
+//  the session token
+//  the PortalUser
+//  any error encountered
+//-----------------------------------------------------------------------------
+func PortalLogin(ctx context.Context, bid int64, username, password string, now *time.Time) (string, PortalUser, error) {
+	a, err := GetPortalUserByUsername(ctx, bid, username)
+	if err != nil {
+		return "", a, err
+	}
+	if a.PUID == 0 {
+		HashPortalPassword(password, "00") // take about as long as a real check
+		return "", a, ErrPortalLogin
+	}
+	if a.FLAGS&PortalUserDisabled != 0 || a.FailedLogins >= PortalMaxFailedLogins {
+		return "", a, ErrPortalLocked
+	}
+	if !CheckPortalPassword(&a, password) {
+		a.FailedLogins++
+		if err = UpdatePortalUser(ctx, &a); err != nil {
+			return "", a, err
+		}
+		return "", a, ErrPortalLogin
+	}
+
+	b := make([]byte, portalTokenLen)
+	if _, err = rand.Read(b); err != nil {
+		return "", a, err
+	}
+	token := hex.EncodeToString(b)
+	a.Token = portalTokenHash(token)
+	a.TokenExpire = now.Add(PortalSessionTimeout)
+	a.LastLogin = *now
+	a.FailedLogins = 0
+	if err = UpdatePortalUser(ctx, &a); err != nil {
+		return "", a, err
+	}
+	return token, a, nil
+}
+
+// PortalSession returns the PortalUser whose session token is token. The
+// session is extended by PortalSessionTimeout.
+//
+// INPUTS
+//  ctx   - context with a TenantPortalBot session, see PortalContext
+//  token - the session token returned by PortalLogin
+//  now   - current time
+//
+// RETURNS
+//  the PortalUser
+//  ErrPortalSession if there is no such session or it has expired, or
+//  any other error encountered
+//-----------------------------------------------------------------------------
+func PortalSession(ctx context.Context, token string, now *time.Time) (PortalUser, error) {
+	if len(token) == 0 {
+		return PortalUser{}, ErrPortalSession
+	}
+	a, err := GetPortalUserByToken(ctx, portalTokenHash(token))
+	if err != nil {
+		return a, err
+	}
+	if a.PUID == 0 || now.After(a.TokenExpire) {
+		return PortalUser{}, ErrPortalSession
+	}
+	if a.FLAGS&PortalUserDisabled != 0 {
+		return PortalUser{}, ErrPortalLocked
+	}
+	a.TokenExpire = now.Add(PortalSessionTimeout)
+	return a, UpdatePortalUser(ctx, &a)
+}
+
+// PortalLogoff ends the session of a
+//-----------------------------------------------------------------------------
+func PortalLogoff(ctx context.Context, a *PortalUser) error {
+	a.Token = ""
+	a.TokenExpire = TIME0
+	return UpdatePortalUser(ctx, a)
+}
+
+// PortalRentalAgreements returns the RAIDs of the Rental Agreements that
+// PortalUser a may see. These are the ones for which the Transactant is,
+// or has been, a payor.
+//-----------------------------------------------------------------------------
+func PortalRentalAgreements(ctx context.Context, a *PortalUser) ([]int64, error) {
+	var m []int64
+	raps, err := GetRentalAgreementsByPayor(ctx, a.BID, a.TCID)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(raps); i++ {
+		if !Int64InSlice(raps[i].RAID, m) {
+			m = append(m, raps[i].RAID)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,66 @@
+package rlib
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Tenant portal password tests.
+
+func TestPBKDF2SHA256(t *testing.T) {
+	var m = []struct {
+		password string
+		salt     string
+		iter     int
+		keyLen   int
+		expect   string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"password", "salt", 1, 20, "120fb6cffcf8b32c43e7225256c4f837a86548c9"},
+	}
+	for i := 0; i < len(m); i++ {
+		s := hex.EncodeToString(pbkdf2SHA256([]byte(m[i].password), []byte(m[i].salt), m[i].iter, m[i].keyLen))
+		if s != m[i].expect {
+			t.Errorf("pbkdf2SHA256( %q, %q, %d, %d ) expect %s, got %s\n", m[i].password, m[i].salt, m[i].iter, m[i].keyLen, m[i].expect, s)
+		}
+	}
+}
+
+func TestPortalPassword(t *testing.T) {
+	var a, b PortalUser
+	if err := SetPortalPassword(&a, "short"); err == nil {
+		t.Errorf("SetPortalPassword accepted a password shorter than %d characters\n", PortalMinPasswordLen)
+	}
+	if err := SetPortalPassword(&a, "correct horse"); err != nil {
+		t.Fatalf("SetPortalPassword returned error: %s\n", err.Error())
+	}
+	if err := SetPortalPassword(&b, "correct horse"); err != nil {
+		t.Fatalf("SetPortalPassword returned error: %s\n", err.Error())
+	}
+	if a.Salt == b.Salt || a.PassHash == b.PassHash {
+		t.Errorf("two hashes of the same password share a salt or hash\n")
+	}
+
+	var m = []struct {
+		password string
+		expect   bool
+	}{
+		{"correct horse", true},
+		{"correct horse ", false},
+		{"Correct horse", false},
+		{"", false},
+	}
+	for i := 0; i < len(m); i++ {
+		if r := CheckPortalPassword(&a, m[i].password); r != m[i].expect {
+			t.Errorf("CheckPortalPassword( %q ) expect %t, got %t\n", m[i].password, m[i].expect, r)
+		}
+	}
+
+	var c PortalUser // no password set
+	if CheckPortalPassword(&c, "") {
+		t.Errorf("CheckPortalPassword matched a user with no password\n")
+	}
+}
@@ -0,0 +1,221 @@
+package rlib
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// CHECKIssueSent et al are the BankCheck FLAGS that track what has been sent
+// to the bank in positive pay files
+const (
+	CHECKIssueSent = 1 << 2 // the check has been sent as issued
+	CHECKVoidSent  = 1 << 3 // the check has been sent as void
+)
+
+// PPFCRLF is the PositivePayFormat FLAGS bit for files whose lines end with
+// CR LF
+const PPFCRLF = 1 << 0
+
+// PositivePayItem is one check in a positive pay file. It is the data of
+// the Detail template.
+type PositivePayItem struct {
+	AccountNo string    // account the check is drawn on
+	RoutingNo string    // routing number of the bank
+	CheckNo   int64     // check number
+	Dt        time.Time // check date
+	Amount    Money     // check amount
+	Payee     string    // pay to the order of
+	Void      bool      // true if the check is void
+	Code      string    // "I" for an issued check, "V" for a void check
+}
+
+// PositivePayData is the content of a positive pay file. It is the data of
+// the Header and Trailer templates.
+type PositivePayData struct {
+	AccountNo   string    // account the checks are drawn on
+	RoutingNo   string    // routing number of the bank
+	BankName    string    // name of the bank
+	PayerName   string    // account holder
+	Created     time.Time // when the file was produced
+	Items       []PositivePayItem
+	Count       int   // number of items
+	IssuedCount int   // number of issued items
+	VoidCount   int   // number of void items
+	IssuedTotal Money // total of the issued items
+	VoidTotal   Money // total of the void items
+	Total       Money // total of all items
+}
+
+// PositivePayDefaultFormats are starting points for the format of a bank:
+// a fixed-width file and a CSV file. Most banks need only small changes to
+// one of them.
+var PositivePayDefaultFormats = []PositivePayFormat{
+	{
+		Name:    "Fixed Width",
+		Header:  `H{{zpad 9 .RoutingNo}}{{zpad 12 .AccountNo}}{{date "20060102" .Created}}`,
+		Detail:  `D{{zpad 12 .AccountNo}}{{zpad 10 .CheckNo}}{{zpad 12 (cents .Amount)}}{{date "20060102" .Dt}}{{.Code}}{{rpad 40 (upper .Payee)}}`,
+		Trailer: `T{{zpad 12 .AccountNo}}{{zpad 6 .Count}}{{zpad 14 (cents .Total)}}`,
+		FileExt: "txt",
+		FLAGS:   PPFCRLF,
+	},
+	{
+		Name:    "CSV",
+		Header:  `Account,Check Number,Amount,Issue Date,Status,Payee`,
+		Detail:  `{{csv .AccountNo}},{{.CheckNo}},{{money .Amount}},{{date "01/02/2006" .Dt}},{{.Code}},{{csv .Payee}}`,
+		FileExt: "csv",
+	},
+}
+
+// PositivePayFuncs are the functions available to positive pay templates:
+//
+//  lpad n s       s right justified in n characters
+//  rpad n s       s left justified in n characters
+//  zpad n v       number v right justified in n characters, zero filled
+//  cents amt      amt in cents
+//  money amt      amt with two decimals and no separators
+//  date layout t  t formatted with the Go time layout
+//  csv s          s quoted for a CSV field if needed
+//  upper s        s in upper case
+//
+// Values longer than n are cut to n characters.
+var PositivePayFuncs = template.FuncMap{
+	"lpad": func(n int, s string) string {
+		if len(s) > n {
+			return s[:n]
+		}
+		return strings.Repeat(" ", n-len(s)) + s
+	},
+	"rpad": func(n int, s string) string {
+		if len(s) > n {
+			return s[:n]
+		}
+		return s + strings.Repeat(" ", n-len(s))
+	},
+	"zpad": func(n int, v interface{}) string {
+		s := fmt.Sprintf("%v", v)
+		if len(s) > n {
+			return s[len(s)-n:]
+		}
+		return strings.Repeat("0", n-len(s)) + s
+	},
+	"cents": func(amt Money) int64 {
+		return amt.Cents()
+	},
+	"money": func(amt Money) string {
+		return amt.String()
+	},
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"csv": func(s string) string {
+		if strings.ContainsAny(s, ",\"\r\n") {
+			return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+		}
+		return s
+	},
+	"upper": strings.ToUpper,
+}
+
+// NewPositivePayData returns the content of a positive pay file for checks
+// m drawn on account accountno. The counts and totals are set from m.
+//
+// INPUTS
+//  ca        = the CheckAccount of the Depository
+//  accountno = the Depository's account number
+//  m         = the checks
+//  now       = when the file is produced
+//
+// RETURNS
+//  the file content
+//-----------------------------------------------------------------------------
+func NewPositivePayData(ca *CheckAccount, accountno string, m []BankCheck, now *time.Time) PositivePayData {
+	d := PositivePayData{
+		AccountNo: accountno,
+		RoutingNo: ca.RoutingNo,
+		BankName:  ca.BankName,
+		PayerName: ca.PayerName,
+		Created:   *now,
+	}
+	for i := 0; i < len(m); i++ {
+		it := PositivePayItem{
+			AccountNo: accountno,
+			RoutingNo: ca.RoutingNo,
+			CheckNo:   m[i].CheckNo,
+			Dt:        m[i].Dt,
+			Amount:    m[i].Amount,
+			Payee:     m[i].Payee,
+			Void:      m[i].FLAGS&CHECKVoid != 0,
+			Code:      "I",
+		}
+		if it.Void {
+			it.Code = "V"
+			d.VoidCount++
+			d.VoidTotal += it.Amount
+		} else {
+			d.IssuedCount++
+			d.IssuedTotal += it.Amount
+		}
+		d.Items = append(d.Items, it)
+	}
+	d.Count = len(d.Items)
+	d.Total = d.IssuedTotal + d.VoidTotal
+	return d
+}
+
+// RenderPositivePay returns the positive pay file for d in format f: the
+// header, a detail record for each item and the trailer, one per line.
+//
+// INPUTS
+//  f = the format
+//  d = the content
+//
+// RETURNS
+//  the file
+//  any error in the templates
+//-----------------------------------------------------------------------------
+func RenderPositivePay(f *PositivePayFormat, d *PositivePayData) (string, error) {
+	var lines []string
+	parts := []struct {
+		name string
+		text string
+	}{
+		{"header", f.Header},
+		{"detail", f.Detail},
+		{"trailer", f.Trailer},
+	}
+	for _, p := range parts {
+		if len(strings.TrimSpace(p.text)) == 0 {
+			continue
+		}
+		t, err := template.New(p.name).Funcs(PositivePayFuncs).Parse(p.text)
+		if err != nil {
+			return "", err
+		}
+		if p.name != "detail" {
+			var b bytes.Buffer
+			if err = t.Execute(&b, d); err != nil {
+				return "", err
+			}
+			lines = append(lines, b.String())
+			continue
+		}
+		for i := 0; i < len(d.Items); i++ {
+			var b bytes.Buffer
+			if err = t.Execute(&b, &d.Items[i]); err != nil {
+				return "", err
+			}
+			lines = append(lines, b.String())
+		}
+	}
+	eol := "\n"
+	if f.FLAGS&PPFCRLF != 0 {
+		eol = "\r\n"
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, eol) + eol, nil
+}
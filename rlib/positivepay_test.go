@@ -0,0 +1,59 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Positive pay file tests.
+
+func TestRenderPositivePay(t *testing.T) {
+	now := time.Date(2018, time.March, 5, 9, 0, 0, 0, time.UTC)
+	ca := CheckAccount{RoutingNo: "121000358", BankName: "First Bank", PayerName: "Isola Bella"}
+	m := []BankCheck{
+		{CheckNo: 1001, Dt: time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC), Amount: 123450, Payee: "Acme Plumbing, Inc."},
+		{CheckNo: 1002, Dt: time.Date(2018, time.March, 2, 0, 0, 0, 0, time.UTC), Amount: 7500, Payee: `Joe "JJ" Smith`, FLAGS: CHECKVoid},
+	}
+	d := NewPositivePayData(&ca, "987654321", m, &now)
+	if d.Count != 2 || d.IssuedCount != 1 || d.VoidCount != 1 || d.IssuedTotal != 123450 || d.VoidTotal != 7500 || d.Total != 130950 {
+		t.Errorf("NewPositivePayData counts/totals wrong: %+v\n", d)
+	}
+
+	var tests = []struct {
+		f      PositivePayFormat
+		expect string
+	}{
+		{
+			PositivePayDefaultFormats[0],
+			"H12100035800098765432120180305\r\n" +
+				"D000987654321000000100100000012345020180301IACME PLUMBING, INC.                     \r\n" +
+				"D000987654321000000100200000000750020180302VJOE \"JJ\" SMITH                          \r\n" +
+				"T000987654321000002" + "00000000130950\r\n",
+		},
+		{
+			PositivePayDefaultFormats[1],
+			"Account,Check Number,Amount,Issue Date,Status,Payee\n" +
+				"987654321,1001,1234.50,03/01/2018,I,\"Acme Plumbing, Inc.\"\n" +
+				"987654321,1002,75.00,03/02/2018,V,\"Joe \"\"JJ\"\" Smith\"\n",
+		},
+		{
+			PositivePayFormat{Detail: `{{lpad 6 (printf "%d" .CheckNo)}}|{{rpad 3 .Payee}}`},
+			"  1001|Acm\n  1002|Joe\n",
+		},
+	}
+	for i := 0; i < len(tests); i++ {
+		s, err := RenderPositivePay(&tests[i].f, &d)
+		if err != nil {
+			t.Errorf("%d: RenderPositivePay error: %s\n", i, err.Error())
+			continue
+		}
+		if s != tests[i].expect {
+			t.Errorf("%d: RenderPositivePay expect:\n%q\ngot:\n%q\n", i, tests[i].expect, s)
+		}
+	}
+
+	bad := PositivePayFormat{Detail: "{{.NoSuchField}}"}
+	if _, err := RenderPositivePay(&bad, &d); err == nil {
+		t.Errorf("RenderPositivePay did not report an unknown field\n")
+	}
+}
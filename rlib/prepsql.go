@@ -506,6 +506,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetJournalMarkers, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker ORDER BY JMID DESC LIMIT ?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetLastJournalMarkerByState, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker WHERE BID=? AND State=? ORDER BY DtStop DESC LIMIT 1")
+	Errcheck(err)
 
 	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertJournalMarker, err = RRdb.Dbrr.Prepare("INSERT INTO JournalMarker (" + s1 + ") VALUES(" + s2 + ")")
@@ -604,9 +606,9 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND RAID=0 AND RID=0 AND LID=? AND TCID=0 AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
-	RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND Dt<=? ORDER BY Dt DESC LIMIT 1")
+	RRdb.Prepstmt.GetRALedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND LID=0 AND RID=0 AND TCID=0 AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
-	RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND Dt>=? ORDER BY Dt ASC LIMIT 1")
+	RRdb.Prepstmt.GetRALedgerMarkerOnOrAfter, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE RAID=? AND LID=0 AND RID=0 AND TCID=0 AND Dt>=? ORDER BY Dt ASC LIMIT 1")
 	Errcheck(err)
 	RRdb.Prepstmt.GetTCLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE TCID=? AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
@@ -614,10 +616,14 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetRALedgerMarkerOnOrBeforeDeprecated, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND LID=? AND RAID=? AND Dt<=?  ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
-	RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND LID=? AND RID=? and Dt<=?  ORDER BY Dt DESC LIMIT 1")
+	RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND LID=? AND RID=? AND RAID=0 AND TCID=0 AND Dt<=? ORDER BY Dt DESC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRARAcctLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? AND LID=? AND RAID=? AND RID=? AND Dt<=? ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
 	RRdb.Prepstmt.DeleteLedgerMarker, err = RRdb.Dbrr.Prepare("DELETE FROM LedgerMarker WHERE LMID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.DeleteLedgerMarkersOnDate, err = RRdb.Dbrr.Prepare("DELETE FROM LedgerMarker WHERE BID=? AND Dt=? AND State!=3")
+	Errcheck(err)
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertLedgerMarker, err = RRdb.Dbrr.Prepare("INSERT INTO LedgerMarker (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
@@ -888,6 +894,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetAllRentablesByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Rentable WHERE BID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetRentablesByRTID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Rentable WHERE BID=? AND RID IN (SELECT RID FROM RentableTypeRef WHERE RTID=? AND DtStart<? AND DtStop>?) ORDER BY RentableName ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertRentable, err = RRdb.Dbrr.Prepare("INSERT INTO Rentable (" + s1 + ") VALUES(" + s2 + ")")
@@ -1168,6 +1176,36 @@ func buildPreparedStatements() {
 	RRdb.Prepstmt.DeleteRentableMarketRateInstance, err = RRdb.Dbrr.Prepare("DELETE from RentableMarketRate WHERE RMRID=?")
 	Errcheck(err)
 
+	//==========================================
+	// REPORT SUBSCRIPTION
+	//==========================================
+	flds = "RSUBID,BID,ReportName,DateRange,Format,Recipients,Cycle,DtNext,DtLast,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ReportSubscription"] = flds
+	RRdb.Prepstmt.GetReportSubscription, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ReportSubscription WHERE RSUBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetReportSubscriptionsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ReportSubscription WHERE BID=? ORDER BY ReportName ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetDueReportSubscriptions, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ReportSubscription WHERE (FLAGS & 1)=0 AND DtNext<=? ORDER BY DtNext ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertReportSubscription, err = RRdb.Dbrr.Prepare("INSERT INTO ReportSubscription (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateReportSubscription, err = RRdb.Dbrr.Prepare("UPDATE ReportSubscription SET " + s3 + " WHERE RSUBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteReportSubscription, err = RRdb.Dbrr.Prepare("DELETE FROM ReportSubscription WHERE RSUBID=?")
+	Errcheck(err)
+
+	//==========================================
+	// REPORT DELIVERY
+	//==========================================
+	flds = "RDID,RSUBID,BID,Dt,DtStart,DtStop,Recipients,Status,Message,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ReportDelivery"] = flds
+	RRdb.Prepstmt.GetReportDeliveries, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ReportDelivery WHERE RSUBID=? ORDER BY Dt DESC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertReportDelivery, err = RRdb.Dbrr.Prepare("INSERT INTO ReportDelivery (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
 	//==========================================
 	// SOURCE
 	//==========================================
@@ -1432,4 +1470,623 @@ func buildPreparedStatements() {
 	RRdb.Prepstmt.DeleteVehicle, err = RRdb.Dbrr.Prepare("DELETE from Vehicle WHERE VID=?")
 	Errcheck(err)
 
+	//==========================================
+	// WEBHOOK SUBSCRIPTION
+	//==========================================
+	flds = "WHSID,BID,URL,Secret,Events,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["WebhookSubscription"] = flds
+	RRdb.Prepstmt.GetWebhookSubscription, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WebhookSubscription WHERE WHSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetWebhookSubscriptionsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WebhookSubscription WHERE BID=? ORDER BY WHSID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertWebhookSubscription, err = RRdb.Dbrr.Prepare("INSERT INTO WebhookSubscription (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateWebhookSubscription, err = RRdb.Dbrr.Prepare("UPDATE WebhookSubscription SET " + s3 + " WHERE WHSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteWebhookSubscription, err = RRdb.Dbrr.Prepare("DELETE FROM WebhookSubscription WHERE WHSID=?")
+	Errcheck(err)
+
+	//==========================================
+	// WEBHOOK DELIVERY
+	//==========================================
+	flds = "WHDID,WHSID,BID,Event,Payload,Status,Attempts,DtNext,DtLast,ResponseCode,Message,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["WebhookDelivery"] = flds
+	RRdb.Prepstmt.GetDueWebhookDeliveries, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WebhookDelivery WHERE Status=0 AND DtNext<=? ORDER BY WHDID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetWebhookDeliveries, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WebhookDelivery WHERE WHSID=? ORDER BY WHDID DESC LIMIT ?")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertWebhookDelivery, err = RRdb.Dbrr.Prepare("INSERT INTO WebhookDelivery (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateWebhookDelivery, err = RRdb.Dbrr.Prepare("UPDATE WebhookDelivery SET " + s3 + " WHERE WHDID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CLOSE PERIOD REOPEN
+	//==========================================
+	flds = "CPRID,BID,CPID,TLID,Dt,Reason,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ClosePeriodReopen"] = flds
+	RRdb.Prepstmt.GetClosePeriodReopens, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ClosePeriodReopen WHERE BID=? ORDER BY CPRID DESC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertClosePeriodReopen, err = RRdb.Dbrr.Prepare("INSERT INTO ClosePeriodReopen (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
+	//==========================================
+	// USER SESSION
+	//==========================================
+	flds = "Token,Username,Name,UID,CoCode,ImageURL,Expire,RoleID"
+	RRdb.DBFields["UserSession"] = flds
+	RRdb.Prepstmt.GetUserSession, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UserSession WHERE Token=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUserSessions, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UserSession ORDER BY Expire ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.InsertUserSession, err = RRdb.Dbrr.Prepare("REPLACE INTO UserSession (" + flds + ") VALUES(?,?,?,?,?,?,?,?)")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateUserSessionExpire, err = RRdb.Dbrr.Prepare("UPDATE UserSession SET Expire=? WHERE Token=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUserSession, err = RRdb.Dbrr.Prepare("DELETE FROM UserSession WHERE Token=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteExpiredUserSessions, err = RRdb.Dbrr.Prepare("DELETE FROM UserSession WHERE Expire<?")
+	Errcheck(err)
+
+	//==========================================
+	// PORTAL USER
+	//==========================================
+	flds = "PUID,BID,TCID,Username,PassHash,Salt,Token,TokenExpire,LastLogin,FailedLogins,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PortalUser"] = flds
+	RRdb.Prepstmt.GetPortalUser, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PortalUser WHERE PUID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPortalUserByUsername, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PortalUser WHERE BID=? AND Username=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPortalUserByToken, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PortalUser WHERE Token=? AND Token!=''")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPortalUsersByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PortalUser WHERE BID=? ORDER BY Username ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPortalUser, err = RRdb.Dbrr.Prepare("INSERT INTO PortalUser (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdatePortalUser, err = RRdb.Dbrr.Prepare("UPDATE PortalUser SET " + s3 + " WHERE PUID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeletePortalUser, err = RRdb.Dbrr.Prepare("DELETE FROM PortalUser WHERE PUID=?")
+	Errcheck(err)
+
+	//==========================================
+	// MAINTENANCE REQUEST
+	//==========================================
+	flds = "MRQID,BID,RAID,RID,TCID,Dt,Subject,Descr,Status,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["MaintRequest"] = flds
+	RRdb.Prepstmt.GetMaintRequest, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintRequest WHERE MRQID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetMaintRequestsByTCID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintRequest WHERE BID=? AND TCID=? ORDER BY Dt DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOpenMaintRequests, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintRequest WHERE BID=? AND Status<2 ORDER BY Dt ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertMaintRequest, err = RRdb.Dbrr.Prepare("INSERT INTO MaintRequest (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateMaintRequest, err = RRdb.Dbrr.Prepare("UPDATE MaintRequest SET " + s3 + " WHERE MRQID=?")
+	Errcheck(err)
+
+	//==========================================
+	// WORK ORDER
+	//==========================================
+	flds = "WOID,BID,RID,RAID,MRQID,NLID,Category,Priority,Status,AssignedUID,AssignedTo,Descr,DtOpen,DtDone,Cost,ChargeASMID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["WorkOrder"] = flds
+	RRdb.Prepstmt.GetWorkOrder, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE WOID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOpenWorkOrders, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM WorkOrder WHERE BID=? AND Status<4 AND DtOpen<? ORDER BY DtOpen ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertWorkOrder, err = RRdb.Dbrr.Prepare("INSERT INTO WorkOrder (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateWorkOrder, err = RRdb.Dbrr.Prepare("UPDATE WorkOrder SET " + s3 + " WHERE WOID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteWorkOrder, err = RRdb.Dbrr.Prepare("DELETE FROM WorkOrder WHERE WOID=?")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR
+	//==========================================
+	flds = "VID,BID,Name,Contact,Address,Address2,City,State,PostalCode,Country,Email,Phone,TaxID,DefaultLID,Terms,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Vendor"] = flds
+	RRdb.Prepstmt.GetVendor, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Vendor WHERE VID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Vendor WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendor, err = RRdb.Dbrr.Prepare("INSERT INTO Vendor (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendor, err = RRdb.Dbrr.Prepare("UPDATE Vendor SET " + s3 + " WHERE VID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteVendor, err = RRdb.Dbrr.Prepare("DELETE FROM Vendor WHERE VID=?")
+	Errcheck(err)
+
+	//==========================================
+	// BILL
+	//==========================================
+	flds = "BILLID,BID,VID,InvoiceNo,Dt,DtDue,Amount,APLID,JID,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Bill"] = flds
+	RRdb.Prepstmt.GetBill, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Bill WHERE BILLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBillsByDateRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Bill WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, BILLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBillsByVID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Bill WHERE VID=? ORDER BY DtDue ASC, BILLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBillsAsOf, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Bill WHERE BID=? AND Dt<=? AND (FLAGS & 1)=0 ORDER BY DtDue ASC, BILLID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBill, err = RRdb.Dbrr.Prepare("INSERT INTO Bill (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBill, err = RRdb.Dbrr.Prepare("UPDATE Bill SET " + s3 + " WHERE BILLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// BILL LINE
+	//==========================================
+	flds = "BLID,BID,BILLID,LID,RID,Amount,Descr,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["BillLine"] = flds
+	RRdb.Prepstmt.GetBillLines, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BillLine WHERE BILLID=? ORDER BY BLID ASC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBillLine, err = RRdb.Dbrr.Prepare("INSERT INTO BillLine (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR PAYMENT
+	//==========================================
+	flds = "VPID,BID,VID,DEPID,Dt,Amount,Method,DocNo,JID,FLAGS,Comment,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["VendorPayment"] = flds
+	RRdb.Prepstmt.GetVendorPayment, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorPayment WHERE VPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendorPaymentsByDateRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorPayment WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, VPID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendorPayment, err = RRdb.Dbrr.Prepare("INSERT INTO VendorPayment (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateVendorPayment, err = RRdb.Dbrr.Prepare("UPDATE VendorPayment SET " + s3 + " WHERE VPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetVendor1099Totals, err = RRdb.Dbrr.Prepare("SELECT VendorPayment.VID,Vendor.Name,Vendor.TaxID,SUM(VendorPayment.Amount) FROM VendorPayment INNER JOIN Vendor ON Vendor.VID=VendorPayment.VID WHERE VendorPayment.BID=? AND ?<=VendorPayment.Dt AND VendorPayment.Dt<? AND (VendorPayment.FLAGS & 1)=0 AND (Vendor.FLAGS & 2)<>0 GROUP BY VendorPayment.VID,Vendor.Name,Vendor.TaxID ORDER BY Vendor.Name ASC")
+	Errcheck(err)
+
+	//==========================================
+	// VENDOR PAYMENT ALLOCATION
+	//==========================================
+	flds = "VPAID,BID,VPID,BILLID,Amount,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["VendorPaymentAllocation"] = flds
+	RRdb.Prepstmt.GetVendorPaymentAllocations, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM VendorPaymentAllocation WHERE VPID=? ORDER BY VPAID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBillPayments, err = RRdb.Dbrr.Prepare("SELECT VendorPaymentAllocation.VPAID,VendorPaymentAllocation.BID,VendorPaymentAllocation.VPID,VendorPaymentAllocation.BILLID,VendorPaymentAllocation.Amount,VendorPaymentAllocation.CreateTS,VendorPaymentAllocation.CreateBy,VendorPaymentAllocation.LastModTime,VendorPaymentAllocation.LastModBy FROM VendorPaymentAllocation INNER JOIN VendorPayment ON VendorPayment.VPID=VendorPaymentAllocation.VPID WHERE VendorPaymentAllocation.BILLID=? AND (VendorPayment.FLAGS & 1)=0 ORDER BY VendorPaymentAllocation.VPAID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBillPaidAmount, err = RRdb.Dbrr.Prepare("SELECT COALESCE(SUM(VendorPaymentAllocation.Amount),0) FROM VendorPaymentAllocation INNER JOIN VendorPayment ON VendorPayment.VPID=VendorPaymentAllocation.VPID WHERE VendorPaymentAllocation.BILLID=? AND VendorPayment.Dt<=? AND (VendorPayment.FLAGS & 1)=0")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertVendorPaymentAllocation, err = RRdb.Dbrr.Prepare("INSERT INTO VendorPaymentAllocation (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
+
+	//==========================================
+	// CHECK ACCOUNT
+	//==========================================
+	flds = "CKAID,BID,DEPID,BankName,BankAddress,RoutingNo,FractionalNo,PayerName,PayerAddress,NextCheckNo,PPFID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CheckAccount"] = flds
+	RRdb.Prepstmt.GetCheckAccount, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CheckAccount WHERE CKAID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCheckAccountByDEPID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CheckAccount WHERE DEPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCheckAccountForUpdate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CheckAccount WHERE DEPID=? FOR UPDATE")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCheckAccountsWithPositivePay, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CheckAccount WHERE PPFID>0 ORDER BY BID ASC, DEPID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCheckAccount, err = RRdb.Dbrr.Prepare("INSERT INTO CheckAccount (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCheckAccount, err = RRdb.Dbrr.Prepare("UPDATE CheckAccount SET " + s3 + " WHERE CKAID=?")
+	Errcheck(err)
+
+	//==========================================
+	// BANK CHECK
+	//==========================================
+	flds = "CKID,BID,DEPID,CheckNo,Dt,Payee,PayeeAddress,Amount,Memo,DebitLID,VPID,TCID,RAID,JID,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["BankCheck"] = flds
+	RRdb.Prepstmt.GetBankCheck, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankCheck WHERE CKID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankCheckByVPID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankCheck WHERE VPID=? AND (FLAGS & 1)=0")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankChecksByDEPID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankCheck WHERE DEPID=? AND ?<=Dt AND Dt<? ORDER BY CheckNo ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBankChecksByDateRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankCheck WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY DEPID ASC, CheckNo ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPositivePayChecks, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM BankCheck WHERE DEPID=? AND Dt<? AND (((FLAGS & 4)=0 AND ?<=Dt) OR (FLAGS & 9)=1) ORDER BY CheckNo ASC FOR UPDATE")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBankCheck, err = RRdb.Dbrr.Prepare("INSERT INTO BankCheck (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBankCheck, err = RRdb.Dbrr.Prepare("UPDATE BankCheck SET " + s3 + " WHERE CKID=?")
+	Errcheck(err)
+	//==========================================
+	// POSITIVE PAY FORMAT
+	//==========================================
+	flds = "PPFID,BID,Name,Header,Detail,Trailer,FileExt,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PositivePayFormat"] = flds
+	RRdb.Prepstmt.GetPositivePayFormat, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PositivePayFormat WHERE PPFID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPositivePayFormatsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PositivePayFormat WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPositivePayFormat, err = RRdb.Dbrr.Prepare("INSERT INTO PositivePayFormat (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdatePositivePayFormat, err = RRdb.Dbrr.Prepare("UPDATE PositivePayFormat SET " + s3 + " WHERE PPFID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeletePositivePayFormat, err = RRdb.Dbrr.Prepare("DELETE FROM PositivePayFormat WHERE PPFID=?")
+	Errcheck(err)
+
+	//==========================================
+	// POSITIVE PAY EXPORT
+	//==========================================
+	flds = "PPXID,BID,DEPID,PPFID,DtStart,DtStop,Items,IssuedTotal,VoidTotal,FileName,Content,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PositivePayExport"] = flds
+	RRdb.Prepstmt.GetPositivePayExport, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PositivePayExport WHERE PPXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPositivePayExportsByDEPID, err = RRdb.Dbrr.Prepare("SELECT PPXID,BID,DEPID,PPFID,DtStart,DtStop,Items,IssuedTotal,VoidTotal,FileName,'',CreateTS,CreateBy,LastModTime,LastModBy FROM PositivePayExport WHERE DEPID=? ORDER BY PPXID DESC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPositivePayExport, err = RRdb.Dbrr.Prepare("INSERT INTO PositivePayExport (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
+	//==========================================
+	// LEASE TEMPLATE
+	//==========================================
+	flds = "LTID,BID,RATID,Title,Body,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["LeaseTemplate"] = flds
+	RRdb.Prepstmt.GetLeaseTemplate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeaseTemplate WHERE LTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLeaseTemplateByRATID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeaseTemplate WHERE RATID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLeaseTemplatesByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeaseTemplate WHERE BID=? ORDER BY Title ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertLeaseTemplate, err = RRdb.Dbrr.Prepare("INSERT INTO LeaseTemplate (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateLeaseTemplate, err = RRdb.Dbrr.Prepare("UPDATE LeaseTemplate SET " + s3 + " WHERE LTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteLeaseTemplate, err = RRdb.Dbrr.Prepare("DELETE FROM LeaseTemplate WHERE LTID=?")
+	Errcheck(err)
+
+	//==========================================
+	// LEASE DOCUMENT
+	//==========================================
+	flds = "LDID,BID,RAID,FlowID,LTID,FileName,Content,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["LeaseDocument"] = flds
+	RRdb.Prepstmt.GetLeaseDocument, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeaseDocument WHERE LDID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLeaseDocumentsByRAID, err = RRdb.Dbrr.Prepare("SELECT LDID,BID,RAID,FlowID,LTID,FileName,'',CreateTS,CreateBy,LastModTime,LastModBy FROM LeaseDocument WHERE RAID=? ORDER BY LDID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLeaseDocumentsByFlowID, err = RRdb.Dbrr.Prepare("SELECT LDID,BID,RAID,FlowID,LTID,FileName,'',CreateTS,CreateBy,LastModTime,LastModBy FROM LeaseDocument WHERE FlowID=? AND RAID=0 ORDER BY LDID DESC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertLeaseDocument, err = RRdb.Dbrr.Prepare("INSERT INTO LeaseDocument (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateLeaseDocumentsRAID, err = RRdb.Dbrr.Prepare("UPDATE LeaseDocument SET RAID=?,LastModBy=? WHERE FlowID=? AND RAID=0")
+	Errcheck(err)
+
+	//==========================================
+	// CAM POOL
+	//==========================================
+	flds = "CAMPID,BID,Name,LIDs,EstimateARID,TrueUpARID,CreditARID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CAMPool"] = flds
+	RRdb.Prepstmt.GetCAMPool, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMPool WHERE CAMPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMPoolsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMPool WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCAMPool, err = RRdb.Dbrr.Prepare("INSERT INTO CAMPool (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCAMPool, err = RRdb.Dbrr.Prepare("UPDATE CAMPool SET " + s3 + " WHERE CAMPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCAMPool, err = RRdb.Dbrr.Prepare("DELETE FROM CAMPool WHERE CAMPID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CAM RECON
+	//==========================================
+	flds = "CAMRID,BID,CAMPID,DtStart,DtStop,Expenses,TotalSqft,DtPosted,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CAMRecon"] = flds
+	RRdb.Prepstmt.GetCAMRecon, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMRecon WHERE CAMRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMReconsByCAMPID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMRecon WHERE CAMPID=? ORDER BY DtStart DESC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCAMRecon, err = RRdb.Dbrr.Prepare("INSERT INTO CAMRecon (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCAMRecon, err = RRdb.Dbrr.Prepare("UPDATE CAMRecon SET " + s3 + " WHERE CAMRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCAMRecon, err = RRdb.Dbrr.Prepare("DELETE FROM CAMRecon WHERE CAMRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CAM RECON ITEM
+	//==========================================
+	flds = "CAMRIID,CAMRID,BID,RAID,RID,Sqft,Days,Share,Allocated,BaseYear,Due,Estimates,TrueUp,ASMID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CAMReconItem"] = flds
+	RRdb.Prepstmt.GetCAMReconItem, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconItem WHERE CAMRIID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCAMReconItems, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CAMReconItem WHERE CAMRID=? ORDER BY RAID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCAMReconItem, err = RRdb.Dbrr.Prepare("INSERT INTO CAMReconItem (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCAMReconItem, err = RRdb.Dbrr.Prepare("UPDATE CAMReconItem SET " + s3 + " WHERE CAMRIID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCAMReconItems, err = RRdb.Dbrr.Prepare("DELETE FROM CAMReconItem WHERE CAMRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ASSESSMENTS BY ACCOUNT RULE
+	//==========================================
+	flds = RRdb.DBFields["Assessments"]
+	//    description -------->>>                                                                                   an instance or not recurring        not reversed    happens in this period
+	RRdb.Prepstmt.GetAssessmentsByRAIDARID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE RAID=? AND ARID=? AND (PASMID>0 OR RentCycle=0) AND (FLAGS & 4)=0 AND ?<=Start AND Start<?")
+	Errcheck(err)
+
+	//==========================================
+	// PERCENTAGE RENT
+	//==========================================
+	flds = "PRID,BID,RAID,BreakpointType,BaseARID,OverageARID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PctRent"] = flds
+	RRdb.Prepstmt.GetPctRent, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PctRent WHERE PRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPctRentByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PctRent WHERE RAID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPctRentsByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PctRent WHERE BID=? ORDER BY RAID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPctRent, err = RRdb.Dbrr.Prepare("INSERT INTO PctRent (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdatePctRent, err = RRdb.Dbrr.Prepare("UPDATE PctRent SET " + s3 + " WHERE PRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeletePctRent, err = RRdb.Dbrr.Prepare("DELETE FROM PctRent WHERE PRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// PERCENTAGE RENT TIER
+	//==========================================
+	flds = "PRTID,PRID,BID,Breakpoint,Rate,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PctRentTier"] = flds
+	RRdb.Prepstmt.GetPctRentTiers, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PctRentTier WHERE PRID=? ORDER BY Breakpoint ASC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPctRentTier, err = RRdb.Dbrr.Prepare("INSERT INTO PctRentTier (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeletePctRentTiers, err = RRdb.Dbrr.Prepare("DELETE FROM PctRentTier WHERE PRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// SALES REPORT
+	//==========================================
+	flds = "SRID,BID,RAID,DtStart,DtStop,GrossSales,Breakpoint,Overage,ASMID,Comment,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["SalesReport"] = flds
+	RRdb.Prepstmt.GetSalesReport, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SalesReport WHERE SRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetSalesReportsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SalesReport WHERE RAID=? ORDER BY DtStart DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetSalesReportsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SalesReport WHERE BID=? AND ?<=DtStart AND DtStart<? ORDER BY RAID ASC, DtStart ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetSalesReportsToCompute, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM SalesReport WHERE (FLAGS & 1)=0 AND DtStop<=? ORDER BY BID ASC, DtStart ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertSalesReport, err = RRdb.Dbrr.Prepare("INSERT INTO SalesReport (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateSalesReport, err = RRdb.Dbrr.Prepare("UPDATE SalesReport SET " + s3 + " WHERE SRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteSalesReport, err = RRdb.Dbrr.Prepare("DELETE FROM SalesReport WHERE SRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RESERVATION
+	//==========================================
+	flds = "RESID,BID,RTID,RID,TCID,RPID,DtArrive,DtDepart,Adults,Children,Rate,Deposit,RAID,Comment,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Reservation"] = flds
+	RRdb.Prepstmt.GetReservation, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Reservation WHERE RESID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetReservationsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Reservation WHERE BID=? AND DtArrive<? AND DtDepart>? ORDER BY DtArrive ASC, RESID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBookedReservationsByRID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Reservation WHERE RID=? AND (FLAGS & 3)=0 AND DtArrive<? AND DtDepart>? ORDER BY DtArrive ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUnassignedReservations, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Reservation WHERE BID=? AND RTID=? AND RID=0 AND (FLAGS & 3)=0 AND DtArrive<? AND DtDepart>? ORDER BY DtArrive ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertReservation, err = RRdb.Dbrr.Prepare("INSERT INTO Reservation (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateReservation, err = RRdb.Dbrr.Prepare("UPDATE Reservation SET " + s3 + " WHERE RESID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteReservation, err = RRdb.Dbrr.Prepare("DELETE FROM Reservation WHERE RESID=?")
+	Errcheck(err)
+	//==========================================
+	// CONCESSION
+	//==========================================
+	flds = "CONID,BID,Name,Description,ARID,Amount,Pct,Cycles,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Concession"] = flds
+	RRdb.Prepstmt.GetConcession, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Concession WHERE CONID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetConcessionsByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Concession WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertConcession, err = RRdb.Dbrr.Prepare("INSERT INTO Concession (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateConcession, err = RRdb.Dbrr.Prepare("UPDATE Concession SET " + s3 + " WHERE CONID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteConcession, err = RRdb.Dbrr.Prepare("DELETE FROM Concession WHERE CONID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RENTAL AGREEMENT CONCESSION
+	//==========================================
+	flds = "RACID,BID,RAID,RID,CONID,ARID,Amount,Pct,Cycles,Applied,DtStart,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RentalAgreementConcession"] = flds
+	RRdb.Prepstmt.GetRentalAgreementConcession, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementConcession WHERE RACID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentalAgreementConcessionsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementConcession WHERE RAID=? ORDER BY RID ASC, DtStart ASC, RACID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentalAgreementConcessionsByRID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementConcession WHERE RAID=? AND RID=? AND Applied<Cycles AND DtStart<=? ORDER BY DtStart ASC, RACID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentalAgreementConcession, err = RRdb.Dbrr.Prepare("INSERT INTO RentalAgreementConcession (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRentalAgreementConcession, err = RRdb.Dbrr.Prepare("UPDATE RentalAgreementConcession SET " + s3 + " WHERE RACID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentalAgreementConcession, err = RRdb.Dbrr.Prepare("DELETE FROM RentalAgreementConcession WHERE RACID=?")
+	Errcheck(err)
+
+	flds = RRdb.DBFields["Assessments"]
+	RRdb.Prepstmt.GetConcessionAssessments, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE BID=? AND AssocElemType=? AND AssocElemID>0 AND (FLAGS & 4)=0 AND ?<=Start AND Start<? ORDER BY Start ASC, RAID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetConcessionAssessmentsByASMID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE AssocElemType=? AND AssocElemID=? ORDER BY ASMID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentCollectedByRentable, err = RRdb.Dbrr.Prepare("SELECT Assessments.RID,SUM(ReceiptAllocation.Amount) FROM ReceiptAllocation INNER JOIN Assessments ON (Assessments.ASMID=ReceiptAllocation.ASMID AND Assessments.BID=ReceiptAllocation.BID) INNER JOIN AR ON (AR.ARID=Assessments.ARID AND AR.BID=Assessments.BID) WHERE ReceiptAllocation.BID=? AND ?<=ReceiptAllocation.Dt AND ReceiptAllocation.Dt<? AND (ReceiptAllocation.FLAGS & 4)=0 AND (AR.FLAGS & ?)>0 AND Assessments.RID>0 GROUP BY Assessments.RID")
+	Errcheck(err)
+	//==========================================
+	// UTILITY BILL
+	//==========================================
+	flds = "UBID,BID,Utility,ARID,Method,Amount,CommonPct,DtStart,DtStop,DtPosted,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["UtilityBill"] = flds
+	RRdb.Prepstmt.GetUtilityBill, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UtilityBill WHERE UBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUtilityBillsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UtilityBill WHERE BID=? AND DtStart<? AND ?<DtStop ORDER BY DtStart ASC, Utility ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertUtilityBill, err = RRdb.Dbrr.Prepare("INSERT INTO UtilityBill (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateUtilityBill, err = RRdb.Dbrr.Prepare("UPDATE UtilityBill SET " + s3 + " WHERE UBID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUtilityBill, err = RRdb.Dbrr.Prepare("DELETE FROM UtilityBill WHERE UBID=?")
+	Errcheck(err)
+
+	//==========================================
+	// UTILITY CHARGE
+	//==========================================
+	flds = "UCID,UBID,BID,RAID,RID,DtStart,DtStop,Days,Sqft,Occupants,MeterUsage,Ratio,Share,Amount,ASMID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["UtilityCharge"] = flds
+	RRdb.Prepstmt.GetUtilityCharges, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UtilityCharge WHERE UBID=? ORDER BY RID ASC, DtStart ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUtilityChargeByASMID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UtilityCharge WHERE ASMID=?")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertUtilityCharge, err = RRdb.Dbrr.Prepare("INSERT INTO UtilityCharge (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateUtilityCharge, err = RRdb.Dbrr.Prepare("UPDATE UtilityCharge SET " + s3 + " WHERE UCID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUtilityCharges, err = RRdb.Dbrr.Prepare("DELETE FROM UtilityCharge WHERE UBID=?")
+	Errcheck(err)
+
+	//==========================================
+	// UTILITY RATIO
+	//==========================================
+	flds = "URID,UBID,BID,RID,Ratio,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["UtilityRatio"] = flds
+	RRdb.Prepstmt.GetUtilityRatios, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UtilityRatio WHERE UBID=? ORDER BY RID ASC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertUtilityRatio, err = RRdb.Dbrr.Prepare("INSERT INTO UtilityRatio (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUtilityRatios, err = RRdb.Dbrr.Prepare("DELETE FROM UtilityRatio WHERE UBID=?")
+	Errcheck(err)
+
+	//==========================================
+	// METER READING
+	//==========================================
+	flds = "MRID,BID,RID,Utility,Dt,Reading,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["MeterReading"] = flds
+	RRdb.Prepstmt.GetMeterReading, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MeterReading WHERE MRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetMeterReadingsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MeterReading WHERE BID=? AND Utility=? AND ?<=Dt AND Dt<=? ORDER BY RID ASC, Dt ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertMeterReading, err = RRdb.Dbrr.Prepare("INSERT INTO MeterReading (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateMeterReading, err = RRdb.Dbrr.Prepare("UPDATE MeterReading SET " + s3 + " WHERE MRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteMeterReading, err = RRdb.Dbrr.Prepare("DELETE FROM MeterReading WHERE MRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ASSESSMENT STEP
+	//==========================================
+	flds = "ASTID,BID,RAID,ASMID,Dt,Amount,Pct,NewASMID,DtApplied,Comment,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["AssessmentStep"] = flds
+	RRdb.Prepstmt.GetAssessmentStep, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentStep WHERE ASTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentStepsByASMID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentStep WHERE ASMID=? ORDER BY Dt ASC, ASTID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentStepsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentStep WHERE RAID=? ORDER BY Dt ASC, ASTID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentStepsDue, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentStep WHERE FLAGS&1=0 AND Dt<=? ORDER BY Dt ASC, ASTID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAssessmentStepsPending, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentStep WHERE BID=? AND FLAGS&1=0 AND Dt<? ORDER BY RAID ASC, ASMID ASC, Dt ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertAssessmentStep, err = RRdb.Dbrr.Prepare("INSERT INTO AssessmentStep (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateAssessmentStep, err = RRdb.Dbrr.Prepare("UPDATE AssessmentStep SET " + s3 + " WHERE ASTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteAssessmentStep, err = RRdb.Dbrr.Prepare("DELETE FROM AssessmentStep WHERE ASTID=?")
+	Errcheck(err)
+
+	//==========================================
+	// PAYMENT GATEWAY
+	//==========================================
+	flds = "PGID,BID,Name,URL,APIKey,PMTID,DEPID,ARID,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["PaymentGateway"] = flds
+	RRdb.Prepstmt.GetPaymentGateway, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PaymentGateway WHERE PGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetPaymentGatewaysByBID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PaymentGateway WHERE BID=? ORDER BY PGID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllPaymentGateways, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM PaymentGateway WHERE FLAGS&1=0 ORDER BY BID ASC, PGID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertPaymentGateway, err = RRdb.Dbrr.Prepare("INSERT INTO PaymentGateway (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdatePaymentGateway, err = RRdb.Dbrr.Prepare("UPDATE PaymentGateway SET " + s3 + " WHERE PGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeletePaymentGateway, err = RRdb.Dbrr.Prepare("DELETE FROM PaymentGateway WHERE PGID=?")
+	Errcheck(err)
+
+	//==========================================
+	// CARD TOKEN
+	//==========================================
+	flds = "CTID,BID,TCID,PGID,Token,Brand,Last4,ExpMonth,ExpYear,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CardToken"] = flds
+	RRdb.Prepstmt.GetCardToken, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CardToken WHERE CTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCardTokensByTCID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CardToken WHERE TCID=? AND FLAGS&1=0 ORDER BY CTID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCardToken, err = RRdb.Dbrr.Prepare("INSERT INTO CardToken (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCardToken, err = RRdb.Dbrr.Prepare("UPDATE CardToken SET " + s3 + " WHERE CTID=?")
+	Errcheck(err)
+
+	//==========================================
+	// GATEWAY TRANSACTION
+	//==========================================
+	flds = "GTID,BID,PGID,TCID,CTID,Type,ParentGTID,TxnID,Amount,Dt,Status,Message,RCPTID,PayoutID,DID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["GatewayTransaction"] = flds
+	RRdb.Prepstmt.GetGatewayTransaction, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE GTID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGatewayTransactionForUpdate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE GTID=? FOR UPDATE")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGatewayTransactionByTxnID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE PGID=? AND TxnID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGatewayTransactionsByTCID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE TCID=? ORDER BY Dt DESC, GTID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGatewayTransactionsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY Dt ASC, GTID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGatewayTransactionsByParent, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GatewayTransaction WHERE ParentGTID=? ORDER BY GTID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertGatewayTransaction, err = RRdb.Dbrr.Prepare("INSERT INTO GatewayTransaction (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateGatewayTransaction, err = RRdb.Dbrr.Prepare("UPDATE GatewayTransaction SET " + s3 + " WHERE GTID=?")
+	Errcheck(err)
 }
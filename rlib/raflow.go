@@ -261,7 +261,21 @@ type RARentablesFlowData struct {
 	// SalesTaxAmt    float64 // FUTURE RELEASE
 	TransOccTax float64 `validate:"number:float,min=0.00"`
 	// TransOccAmt    float64 // FUTURE RELEASE
-	Fees []RAFeesData `validate:"-"`
+	Fees        []RAFeesData       `validate:"-"`
+	Concessions []RAConcessionData `validate:"-"`
+}
+
+// RAConcessionData is a Concession given on the rent of a rentable in raflow
+type RAConcessionData struct {
+	RACID   int64    `validate:"number,min=0"` // the RentalAgreementConcession id if it is an existing RAID
+	CONID   int64    `validate:"number,min=1"`
+	Name    string   `validate:"string,min=1,max=100"`
+	ARID    int64    `validate:"number,min=1"`
+	Amount  float64  `validate:"number:float,min=0.00"`
+	Pct     float64  `validate:"number:float,min=0.00"`
+	Cycles  int64    `validate:"number,min=1"`
+	Applied int64    `validate:"number,min=0"`
+	Start   JSONDate `validate:"date"`
 }
 
 // RAFeesData struct used for pet, vehicles, rentable fees
@@ -1105,6 +1119,10 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 	if err != nil {
 		return raf, nil
 	}
+	racs, err := GetRentalAgreementConcessionsByRAID(ctx, ra.RAID)
+	if err != nil {
+		return raf, nil
+	}
 	for i := 0; i < len(o); i++ {
 		rnt, err := GetRentable(ctx, o[i].RID)
 		if err != nil {
@@ -1125,6 +1143,7 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 			RentableName: rnt.RentableName,
 			RentCycle:    rt.RentCycle,
 			Fees:         []RAFeesData{},
+			Concessions:  []RAConcessionData{},
 		}
 
 		//---------------------------------------------------------
@@ -1183,7 +1202,7 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 					ASMID:          asms[j].ASMID,
 					ARID:           asms[j].ARID,
 					ARName:         ar.Name,
-					ContractAmount: asms[j].Amount,
+					ContractAmount: asms[j].Amount.Float(),
 					RentCycle:      asms[j].RentCycle,
 					ProrationCycle: asms[j].ProrationCycle,
 					Start:          JSONDate(asms[j].Start),
@@ -1210,7 +1229,7 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 							ProrationCycle: asms[j].ProrationCycle,
 							Start:          JSONDate(asms[j].Start),
 							Stop:           JSONDate(asms[j].Stop),
-							ContractAmount: asms[j].Amount,
+							ContractAmount: asms[j].Amount.Float(),
 							Comment:        asms[j].Comment,
 						}
 						raf.Pets[k].Fees = append(raf.Pets[k].Fees, pf)
@@ -1231,7 +1250,7 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 							ARID:           asms[j].ARID,
 							ASMID:          asms[j].ASMID,
 							ARName:         ar.Name,
-							ContractAmount: asms[j].Amount,
+							ContractAmount: asms[j].Amount.Float(),
 							RentCycle:      asms[j].RentCycle,
 							ProrationCycle: asms[j].ProrationCycle,
 							Start:          JSONDate(asms[j].Start),
@@ -1245,6 +1264,34 @@ func ConvertRA2Flow(ctx context.Context, ra *RentalAgreement, EditFlag bool) (RA
 			}
 		}
 
+		//----------------------------------------------------------
+		// Add the concessions given on the Rentable's rent. When
+		// amending, the ones used up are left behind.
+		//----------------------------------------------------------
+		for j := 0; j < len(racs); j++ {
+			if racs[j].RID != rfd.RID || (EditFlag && racs[j].Applied >= racs[j].Cycles) {
+				continue
+			}
+			con, err := GetConcession(ctx, racs[j].CONID)
+			if err != nil {
+				return raf, nil
+			}
+			if con.CONID == 0 {
+				con.Name = fmt.Sprintf("Concession %d", racs[j].CONID) // the definition was deleted
+			}
+			rfd.Concessions = append(rfd.Concessions, RAConcessionData{
+				RACID:   racs[j].RACID,
+				CONID:   racs[j].CONID,
+				Name:    con.Name,
+				ARID:    racs[j].ARID,
+				Amount:  racs[j].Amount.Float(),
+				Pct:     racs[j].Pct,
+				Cycles:  racs[j].Cycles,
+				Applied: racs[j].Applied,
+				Start:   JSONDate(racs[j].DtStart),
+			})
+		}
+
 		raf.Rentables = append(raf.Rentables, rfd)
 	}
 
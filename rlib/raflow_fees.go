@@ -286,3 +286,43 @@ func GetCalculatedFeesFromBaseFees(ctx context.Context, BID int64, bizPropName s
 
 	return
 }
+
+// GetRAFlowConcession returns the raflow data of Concession CONID given on
+// a rentable whose rent starts on rStart. The terms are copied from the
+// Concession.
+//
+// INPUTS
+//             ctx  = db transaction context
+//             BID  = Business ID
+//           CONID  = the Concession
+//          rStart  = rent start date
+//
+// RETURNS
+//     the concession data
+//     any error encountered, the Concession must exist in BID and still be
+//     offered
+//-----------------------------------------------------------------------------
+func GetRAFlowConcession(ctx context.Context, BID, CONID int64, rStart time.Time) (c RAConcessionData, err error) {
+	var con Concession
+	if con, err = GetConcession(ctx, CONID); err != nil {
+		return
+	}
+	if con.CONID == 0 || con.BID != BID {
+		err = fmt.Errorf("concession %d not found", CONID)
+		return
+	}
+	if con.FLAGS&CONCESSIONInactive != 0 {
+		err = fmt.Errorf("concession %s is no longer offered", con.Name)
+		return
+	}
+	c = RAConcessionData{
+		CONID:  con.CONID,
+		Name:   con.Name,
+		ARID:   con.ARID,
+		Amount: con.Amount.Float(),
+		Pct:    con.Pct,
+		Cycles: con.Cycles,
+		Start:  JSONDate(rStart),
+	}
+	return
+}
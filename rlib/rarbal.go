@@ -12,7 +12,7 @@ type RARStmtEntry struct {
 	T       int                // 1 = assessment, 2 = Receipt
 	A       *Assessment        // for type==1, the pointer to the assessment
 	R       *ReceiptAllocation // for type ==2, the pointer to the receipt
-	Amt     float64            // amount of the receipt or assessment
+	Amt     Money              // amount of the receipt or assessment
 	Reverse bool               // is this a reversal?
 	Dt      time.Time          // date/time of this assessment or receipt
 	TCID    int64              // IF THIS IS FOR A PAYOR STATEMENT, the TCID of the Payor, otherwise 0
@@ -25,8 +25,8 @@ type BalanceCacheEntry struct {
 	raid   int64
 	d1     *time.Time
 	d2     *time.Time
-	begin  float64
-	end    float64
+	begin  Money
+	end    Money
 	expire *time.Time
 }
 
@@ -88,6 +88,7 @@ func getCachedRARBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *BalanceC
 	b, ok := balcache[k]
 	if ok {
 		if b == nil {
+			RARBalCacheCtx.miss()
 			return nil
 		}
 		t := time.Now().Add(RARBalCacheCtx.Expiry) // it's life gets extended
@@ -97,8 +98,10 @@ func getCachedRARBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *BalanceC
 		RARBalCacheCtx.SemAck <- 1                 // tell the controller we're done
 
 		// Console("Expire updated: %s,  key = %s\n", t.Format(RRDATETIMEINPFMT), k)
+		RARBalCacheCtx.hit()
 		return b
 	}
+	RARBalCacheCtx.miss()
 	return nil
 }
 
@@ -116,7 +119,7 @@ func getCachedRARBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *BalanceC
 // RETURNS
 //  nothing
 //-----------------------------------------------------------------------------
-func storeRARBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begin, end float64) {
+func storeRARBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begin, end Money) {
 	t := time.Now().Add(RARBalCacheCtx.Expiry) // it gets this much time
 	b := BalanceCacheEntry{
 		bid:    bid,
@@ -131,8 +134,11 @@ func storeRARBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begin,
 	k := getCacheKey(bid, rid, raid, d1, d2)
 	// Console("Expire: %s,  key: %s\n", t.Format(RRDATETIMEINPFMT), k)
 
-	RARBalCacheCtx.Sem <- 1    // request write access
-	<-RARBalCacheCtx.SemAck    // pause until we get access
+	RARBalCacheCtx.Sem <- 1 // request write access
+	<-RARBalCacheCtx.SemAck // pause until we get access
+	if balcache[k] == nil {
+		RARBalCacheCtx.added()
+	}
 	balcache[k] = &b           // <<<<<<<<<<<<<<<    do the cache update
 	RARBalCacheCtx.SemAck <- 1 // tell the controller we're done
 }
@@ -157,8 +163,11 @@ func CleanRARBalanceInfoCache(force bool) {
 			continue
 		}
 		if force || now.After(*v.expire) {
-			RARBalCacheCtx.Sem <- 1    // request write access
-			<-RARBalCacheCtx.SemAck    // pause until we get access
+			RARBalCacheCtx.Sem <- 1 // request write access
+			<-RARBalCacheCtx.SemAck // pause until we get access
+			if balcache[k] != nil {
+				RARBalCacheCtx.removed()
+			}
 			balcache[k] = nil          // <<<<<<<<<<<<<<<  do the cache update
 			RARBalCacheCtx.SemAck <- 1 // tell the controller we're done
 		}
@@ -191,11 +200,11 @@ func RARBalCacheSize() int {
 //   d2   - time for which balance is requested
 //
 // RETURNS
-//   float64 - the balance for the Rentable rid in Rental Agreement raid at
+//   Money   - the balance for the Rentable rid in Rental Agreement raid at
 //             time dt
 //   error   - any error encountered
 //-----------------------------------------------------------------------------
-func GetBeginEndRARBalance(ctx context.Context, bid, rid, raid int64, d1, d2 *time.Time) (float64, float64, error) {
+func GetBeginEndRARBalance(ctx context.Context, bid, rid, raid int64, d1, d2 *time.Time) (Money, Money, error) {
 	//----------------------------------------
 	// try to get it from the cache first...
 	//----------------------------------------
@@ -205,8 +214,8 @@ func GetBeginEndRARBalance(ctx context.Context, bid, rid, raid int64, d1, d2 *ti
 	}
 
 	var err error
-	begin := float64(0)
-	end := float64(0)
+	begin := Money(0)
+	end := Money(0)
 	begin, err = GetRARBalance(ctx, bid, rid, raid, d1)
 	if err != nil {
 		return b.begin, b.end, nil
@@ -232,15 +241,15 @@ func GetBeginEndRARBalance(ctx context.Context, bid, rid, raid int64, d1, d2 *ti
 //   dt      - time for which balance is requested
 //
 // RETURNS
-//   float64 - the balance for the Rentable rid in Rental Agreement raid at
+//   Money   - the balance for the Rentable rid in Rental Agreement raid at
 //             time dt
 //   error   - any error encountered
 //-----------------------------------------------------------------------------
-func GetRARBalance(ctx context.Context, bid, rid, raid int64, dt *time.Time) (float64, error) {
+func GetRARBalance(ctx context.Context, bid, rid, raid int64, dt *time.Time) (Money, error) {
 	const funcname = "GetRARBalance"
 
 	var (
-		bal float64
+		bal Money
 		err error
 	)
 
@@ -277,15 +286,15 @@ func GetRARBalance(ctx context.Context, bid, rid, raid int64, dt *time.Time) (fl
 //   d2   - time for which balance is requested
 //
 // RETURNS
-//   float64 - the balance for the Rentable rid in Rental Agreement raid at
+//   Money   - the balance for the Rentable rid in Rental Agreement raid at
 //             time dt
 //   error   - any error encountered
 //-----------------------------------------------------------------------------
-func GetRARAcctRange(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Time) (float64, error) {
+func GetRARAcctRange(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Time) (Money, error) {
 	const funcname = "GetRARAcctRange"
 	// Console("Entered %s\n", funcname)
 	var (
-		bal float64
+		bal Money
 		err error
 	)
 
@@ -1060,3 +1060,393 @@ func ReadVehicles(rows *sql.Rows, a *Vehicle) error {
 		&a.VIN, &a.LicensePlateState, &a.LicensePlateNumber, &a.ParkingPermitNumber, &a.DtStart, &a.DtStop,
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
+
+// ReadReportSubscription reads a full ReportSubscription structure from the database based on the supplied row object
+func ReadReportSubscription(row *sql.Row, a *ReportSubscription) error {
+	err := row.Scan(&a.RSUBID, &a.BID, &a.ReportName, &a.DateRange, &a.Format, &a.Recipients, &a.Cycle, &a.DtNext, &a.DtLast, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadReportSubscriptions reads a full ReportSubscription structure from the database based on the supplied rows object
+func ReadReportSubscriptions(rows *sql.Rows, a *ReportSubscription) error {
+	return rows.Scan(&a.RSUBID, &a.BID, &a.ReportName, &a.DateRange, &a.Format, &a.Recipients, &a.Cycle, &a.DtNext, &a.DtLast, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadReportDeliveries reads a full ReportDelivery structure from the database based on the supplied rows object
+func ReadReportDeliveries(rows *sql.Rows, a *ReportDelivery) error {
+	return rows.Scan(&a.RDID, &a.RSUBID, &a.BID, &a.Dt, &a.DtStart, &a.DtStop, &a.Recipients, &a.Status, &a.Message, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadWebhookSubscription reads a full WebhookSubscription structure from the database based on the supplied row object
+func ReadWebhookSubscription(row *sql.Row, a *WebhookSubscription) error {
+	err := row.Scan(&a.WHSID, &a.BID, &a.URL, &a.Secret, &a.Events, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadWebhookSubscriptions reads a full WebhookSubscription structure from the database based on the supplied rows object
+func ReadWebhookSubscriptions(rows *sql.Rows, a *WebhookSubscription) error {
+	return rows.Scan(&a.WHSID, &a.BID, &a.URL, &a.Secret, &a.Events, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadWebhookDeliveries reads a full WebhookDelivery structure from the database based on the supplied rows object
+func ReadWebhookDeliveries(rows *sql.Rows, a *WebhookDelivery) error {
+	return rows.Scan(&a.WHDID, &a.WHSID, &a.BID, &a.Event, &a.Payload, &a.Status, &a.Attempts, &a.DtNext, &a.DtLast, &a.ResponseCode, &a.Message, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadClosePeriodReopens reads a full ClosePeriodReopen structure from the database based on the supplied rows object
+func ReadClosePeriodReopens(rows *sql.Rows, a *ClosePeriodReopen) error {
+	return rows.Scan(&a.CPRID, &a.BID, &a.CPID, &a.TLID, &a.Dt, &a.Reason, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPortalUser reads a full PortalUser structure from the database based on the supplied row object
+func ReadPortalUser(row *sql.Row, a *PortalUser) error {
+	err := row.Scan(&a.PUID, &a.BID, &a.TCID, &a.Username, &a.PassHash, &a.Salt, &a.Token, &a.TokenExpire, &a.LastLogin, &a.FailedLogins, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPortalUsers reads a full PortalUser structure from the database based on the supplied rows object
+func ReadPortalUsers(rows *sql.Rows, a *PortalUser) error {
+	return rows.Scan(&a.PUID, &a.BID, &a.TCID, &a.Username, &a.PassHash, &a.Salt, &a.Token, &a.TokenExpire, &a.LastLogin, &a.FailedLogins, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadMaintRequest reads a full MaintRequest structure from the database based on the supplied row object
+func ReadMaintRequest(row *sql.Row, a *MaintRequest) error {
+	err := row.Scan(&a.MRQID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Subject, &a.Descr, &a.Status, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadMaintRequests reads a full MaintRequest structure from the database based on the supplied rows object
+func ReadMaintRequests(rows *sql.Rows, a *MaintRequest) error {
+	return rows.Scan(&a.MRQID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Subject, &a.Descr, &a.Status, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadWorkOrder reads a full WorkOrder structure from the database based on the supplied row object
+func ReadWorkOrder(row *sql.Row, a *WorkOrder) error {
+	err := row.Scan(&a.WOID, &a.BID, &a.RID, &a.RAID, &a.MRQID, &a.NLID, &a.Category, &a.Priority, &a.Status, &a.AssignedUID, &a.AssignedTo, &a.Descr, &a.DtOpen, &a.DtDone, &a.Cost, &a.ChargeASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadWorkOrders reads a full WorkOrder structure from the database based on the supplied rows object
+func ReadWorkOrders(rows *sql.Rows, a *WorkOrder) error {
+	return rows.Scan(&a.WOID, &a.BID, &a.RID, &a.RAID, &a.MRQID, &a.NLID, &a.Category, &a.Priority, &a.Status, &a.AssignedUID, &a.AssignedTo, &a.Descr, &a.DtOpen, &a.DtDone, &a.Cost, &a.ChargeASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendor reads a full Vendor structure from the database based on the supplied row object
+func ReadVendor(row *sql.Row, a *Vendor) error {
+	err := row.Scan(&a.VID, &a.BID, &a.Name, &a.Contact, &a.Address, &a.Address2, &a.City, &a.State, &a.PostalCode, &a.Country, &a.Email, &a.Phone, &a.TaxID, &a.DefaultLID, &a.Terms, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendors reads a full Vendor structure from the database based on the supplied rows object
+func ReadVendors(rows *sql.Rows, a *Vendor) error {
+	return rows.Scan(&a.VID, &a.BID, &a.Name, &a.Contact, &a.Address, &a.Address2, &a.City, &a.State, &a.PostalCode, &a.Country, &a.Email, &a.Phone, &a.TaxID, &a.DefaultLID, &a.Terms, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadBill reads a full Bill structure from the database based on the supplied row object
+func ReadBill(row *sql.Row, a *Bill) error {
+	err := row.Scan(&a.BILLID, &a.BID, &a.VID, &a.InvoiceNo, &a.Dt, &a.DtDue, &a.Amount, &a.APLID, &a.JID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadBills reads a full Bill structure from the database based on the supplied rows object
+func ReadBills(rows *sql.Rows, a *Bill) error {
+	return rows.Scan(&a.BILLID, &a.BID, &a.VID, &a.InvoiceNo, &a.Dt, &a.DtDue, &a.Amount, &a.APLID, &a.JID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadBillLines reads a full BillLine structure from the database based on the supplied rows object
+func ReadBillLines(rows *sql.Rows, a *BillLine) error {
+	return rows.Scan(&a.BLID, &a.BID, &a.BILLID, &a.LID, &a.RID, &a.Amount, &a.Descr, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendorPayment reads a full VendorPayment structure from the database based on the supplied row object
+func ReadVendorPayment(row *sql.Row, a *VendorPayment) error {
+	err := row.Scan(&a.VPID, &a.BID, &a.VID, &a.DEPID, &a.Dt, &a.Amount, &a.Method, &a.DocNo, &a.JID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadVendorPayments reads a full VendorPayment structure from the database based on the supplied rows object
+func ReadVendorPayments(rows *sql.Rows, a *VendorPayment) error {
+	return rows.Scan(&a.VPID, &a.BID, &a.VID, &a.DEPID, &a.Dt, &a.Amount, &a.Method, &a.DocNo, &a.JID, &a.FLAGS, &a.Comment, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadVendorPaymentAllocations reads a full VendorPaymentAllocation structure from the database based on the supplied rows object
+func ReadVendorPaymentAllocations(rows *sql.Rows, a *VendorPaymentAllocation) error {
+	return rows.Scan(&a.VPAID, &a.BID, &a.VPID, &a.BILLID, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCheckAccount reads a full CheckAccount structure from the database based on the supplied row object
+func ReadCheckAccount(row *sql.Row, a *CheckAccount) error {
+	err := row.Scan(&a.CKAID, &a.BID, &a.DEPID, &a.BankName, &a.BankAddress, &a.RoutingNo, &a.FractionalNo, &a.PayerName, &a.PayerAddress, &a.NextCheckNo, &a.PPFID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCheckAccounts reads a full CheckAccount structure from the database based on the supplied rows object
+func ReadCheckAccounts(rows *sql.Rows, a *CheckAccount) error {
+	return rows.Scan(&a.CKAID, &a.BID, &a.DEPID, &a.BankName, &a.BankAddress, &a.RoutingNo, &a.FractionalNo, &a.PayerName, &a.PayerAddress, &a.NextCheckNo, &a.PPFID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadBankCheck reads a full BankCheck structure from the database based on the supplied row object
+func ReadBankCheck(row *sql.Row, a *BankCheck) error {
+	err := row.Scan(&a.CKID, &a.BID, &a.DEPID, &a.CheckNo, &a.Dt, &a.Payee, &a.PayeeAddress, &a.Amount, &a.Memo, &a.DebitLID, &a.VPID, &a.TCID, &a.RAID, &a.JID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadBankChecks reads a full BankCheck structure from the database based on the supplied rows object
+func ReadBankChecks(rows *sql.Rows, a *BankCheck) error {
+	return rows.Scan(&a.CKID, &a.BID, &a.DEPID, &a.CheckNo, &a.Dt, &a.Payee, &a.PayeeAddress, &a.Amount, &a.Memo, &a.DebitLID, &a.VPID, &a.TCID, &a.RAID, &a.JID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPositivePayFormat reads a full PositivePayFormat structure from the database based on the supplied row object
+func ReadPositivePayFormat(row *sql.Row, a *PositivePayFormat) error {
+	err := row.Scan(&a.PPFID, &a.BID, &a.Name, &a.Header, &a.Detail, &a.Trailer, &a.FileExt, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPositivePayFormats reads a full PositivePayFormat structure from the database based on the supplied rows object
+func ReadPositivePayFormats(rows *sql.Rows, a *PositivePayFormat) error {
+	return rows.Scan(&a.PPFID, &a.BID, &a.Name, &a.Header, &a.Detail, &a.Trailer, &a.FileExt, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPositivePayExport reads a full PositivePayExport structure from the database based on the supplied row object
+func ReadPositivePayExport(row *sql.Row, a *PositivePayExport) error {
+	err := row.Scan(&a.PPXID, &a.BID, &a.DEPID, &a.PPFID, &a.DtStart, &a.DtStop, &a.Items, &a.IssuedTotal, &a.VoidTotal, &a.FileName, &a.Content, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPositivePayExports reads a full PositivePayExport structure from the database based on the supplied rows object
+func ReadPositivePayExports(rows *sql.Rows, a *PositivePayExport) error {
+	return rows.Scan(&a.PPXID, &a.BID, &a.DEPID, &a.PPFID, &a.DtStart, &a.DtStop, &a.Items, &a.IssuedTotal, &a.VoidTotal, &a.FileName, &a.Content, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadLeaseTemplate reads a full LeaseTemplate structure from the database based on the supplied row object
+func ReadLeaseTemplate(row *sql.Row, a *LeaseTemplate) error {
+	err := row.Scan(&a.LTID, &a.BID, &a.RATID, &a.Title, &a.Body, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadLeaseTemplates reads a full LeaseTemplate structure from the database based on the supplied rows object
+func ReadLeaseTemplates(rows *sql.Rows, a *LeaseTemplate) error {
+	return rows.Scan(&a.LTID, &a.BID, &a.RATID, &a.Title, &a.Body, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadLeaseDocument reads a full LeaseDocument structure from the database based on the supplied row object
+func ReadLeaseDocument(row *sql.Row, a *LeaseDocument) error {
+	err := row.Scan(&a.LDID, &a.BID, &a.RAID, &a.FlowID, &a.LTID, &a.FileName, &a.Content, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadLeaseDocuments reads a full LeaseDocument structure from the database based on the supplied rows object
+func ReadLeaseDocuments(rows *sql.Rows, a *LeaseDocument) error {
+	return rows.Scan(&a.LDID, &a.BID, &a.RAID, &a.FlowID, &a.LTID, &a.FileName, &a.Content, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCAMPool reads a full CAMPool structure from the database based on the supplied row object
+func ReadCAMPool(row *sql.Row, a *CAMPool) error {
+	err := row.Scan(&a.CAMPID, &a.BID, &a.Name, &a.LIDs, &a.EstimateARID, &a.TrueUpARID, &a.CreditARID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCAMPools reads a full CAMPool structure from the database based on the supplied rows object
+func ReadCAMPools(rows *sql.Rows, a *CAMPool) error {
+	return rows.Scan(&a.CAMPID, &a.BID, &a.Name, &a.LIDs, &a.EstimateARID, &a.TrueUpARID, &a.CreditARID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCAMRecon reads a full CAMRecon structure from the database based on the supplied row object
+func ReadCAMRecon(row *sql.Row, a *CAMRecon) error {
+	err := row.Scan(&a.CAMRID, &a.BID, &a.CAMPID, &a.DtStart, &a.DtStop, &a.Expenses, &a.TotalSqft, &a.DtPosted, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCAMRecons reads a full CAMRecon structure from the database based on the supplied rows object
+func ReadCAMRecons(rows *sql.Rows, a *CAMRecon) error {
+	return rows.Scan(&a.CAMRID, &a.BID, &a.CAMPID, &a.DtStart, &a.DtStop, &a.Expenses, &a.TotalSqft, &a.DtPosted, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCAMReconItem reads a full CAMReconItem structure from the database based on the supplied row object
+func ReadCAMReconItem(row *sql.Row, a *CAMReconItem) error {
+	err := row.Scan(&a.CAMRIID, &a.CAMRID, &a.BID, &a.RAID, &a.RID, &a.Sqft, &a.Days, &a.Share, &a.Allocated, &a.BaseYear, &a.Due, &a.Estimates, &a.TrueUp, &a.ASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCAMReconItems reads a full CAMReconItem structure from the database based on the supplied rows object
+func ReadCAMReconItems(rows *sql.Rows, a *CAMReconItem) error {
+	return rows.Scan(&a.CAMRIID, &a.CAMRID, &a.BID, &a.RAID, &a.RID, &a.Sqft, &a.Days, &a.Share, &a.Allocated, &a.BaseYear, &a.Due, &a.Estimates, &a.TrueUp, &a.ASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPctRent reads a full PctRent structure from the database based on the supplied row object
+func ReadPctRent(row *sql.Row, a *PctRent) error {
+	err := row.Scan(&a.PRID, &a.BID, &a.RAID, &a.BreakpointType, &a.BaseARID, &a.OverageARID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPctRents reads a full PctRent structure from the database based on the supplied rows object
+func ReadPctRents(rows *sql.Rows, a *PctRent) error {
+	return rows.Scan(&a.PRID, &a.BID, &a.RAID, &a.BreakpointType, &a.BaseARID, &a.OverageARID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPctRentTier reads a full PctRentTier structure from the database based on the supplied row object
+func ReadPctRentTier(row *sql.Row, a *PctRentTier) error {
+	err := row.Scan(&a.PRTID, &a.PRID, &a.BID, &a.Breakpoint, &a.Rate, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPctRentTiers reads a full PctRentTier structure from the database based on the supplied rows object
+func ReadPctRentTiers(rows *sql.Rows, a *PctRentTier) error {
+	return rows.Scan(&a.PRTID, &a.PRID, &a.BID, &a.Breakpoint, &a.Rate, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadSalesReport reads a full SalesReport structure from the database based on the supplied row object
+func ReadSalesReport(row *sql.Row, a *SalesReport) error {
+	err := row.Scan(&a.SRID, &a.BID, &a.RAID, &a.DtStart, &a.DtStop, &a.GrossSales, &a.Breakpoint, &a.Overage, &a.ASMID, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadSalesReports reads a full SalesReport structure from the database based on the supplied rows object
+func ReadSalesReports(rows *sql.Rows, a *SalesReport) error {
+	return rows.Scan(&a.SRID, &a.BID, &a.RAID, &a.DtStart, &a.DtStop, &a.GrossSales, &a.Breakpoint, &a.Overage, &a.ASMID, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadReservation reads a full Reservation structure from the database based on the supplied row object
+func ReadReservation(row *sql.Row, a *Reservation) error {
+	err := row.Scan(&a.RESID, &a.BID, &a.RTID, &a.RID, &a.TCID, &a.RPID, &a.DtArrive, &a.DtDepart, &a.Adults, &a.Children, &a.Rate, &a.Deposit, &a.RAID, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadReservations reads a full Reservation structure from the database based on the supplied rows object
+func ReadReservations(rows *sql.Rows, a *Reservation) error {
+	return rows.Scan(&a.RESID, &a.BID, &a.RTID, &a.RID, &a.TCID, &a.RPID, &a.DtArrive, &a.DtDepart, &a.Adults, &a.Children, &a.Rate, &a.Deposit, &a.RAID, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadConcession reads a full Concession structure from the database based on the supplied row object
+func ReadConcession(row *sql.Row, a *Concession) error {
+	err := row.Scan(&a.CONID, &a.BID, &a.Name, &a.Description, &a.ARID, &a.Amount, &a.Pct, &a.Cycles, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadConcessions reads a full Concession structure from the database based on the supplied rows object
+func ReadConcessions(rows *sql.Rows, a *Concession) error {
+	return rows.Scan(&a.CONID, &a.BID, &a.Name, &a.Description, &a.ARID, &a.Amount, &a.Pct, &a.Cycles, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRentalAgreementConcession reads a full RentalAgreementConcession structure from the database based on the supplied row object
+func ReadRentalAgreementConcession(row *sql.Row, a *RentalAgreementConcession) error {
+	err := row.Scan(&a.RACID, &a.BID, &a.RAID, &a.RID, &a.CONID, &a.ARID, &a.Amount, &a.Pct, &a.Cycles, &a.Applied, &a.DtStart, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadRentalAgreementConcessions reads a full RentalAgreementConcession structure from the database based on the supplied rows object
+func ReadRentalAgreementConcessions(rows *sql.Rows, a *RentalAgreementConcession) error {
+	return rows.Scan(&a.RACID, &a.BID, &a.RAID, &a.RID, &a.CONID, &a.ARID, &a.Amount, &a.Pct, &a.Cycles, &a.Applied, &a.DtStart, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadUtilityBill reads a full UtilityBill structure from the database based on the supplied row object
+func ReadUtilityBill(row *sql.Row, a *UtilityBill) error {
+	err := row.Scan(&a.UBID, &a.BID, &a.Utility, &a.ARID, &a.Method, &a.Amount, &a.CommonPct, &a.DtStart, &a.DtStop, &a.DtPosted, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadUtilityBills reads a full UtilityBill structure from the database based on the supplied rows object
+func ReadUtilityBills(rows *sql.Rows, a *UtilityBill) error {
+	return rows.Scan(&a.UBID, &a.BID, &a.Utility, &a.ARID, &a.Method, &a.Amount, &a.CommonPct, &a.DtStart, &a.DtStop, &a.DtPosted, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadUtilityCharge reads a full UtilityCharge structure from the database based on the supplied row object
+func ReadUtilityCharge(row *sql.Row, a *UtilityCharge) error {
+	err := row.Scan(&a.UCID, &a.UBID, &a.BID, &a.RAID, &a.RID, &a.DtStart, &a.DtStop, &a.Days, &a.Sqft, &a.Occupants, &a.MeterUsage, &a.Ratio, &a.Share, &a.Amount, &a.ASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadUtilityCharges reads a full UtilityCharge structure from the database based on the supplied rows object
+func ReadUtilityCharges(rows *sql.Rows, a *UtilityCharge) error {
+	return rows.Scan(&a.UCID, &a.UBID, &a.BID, &a.RAID, &a.RID, &a.DtStart, &a.DtStop, &a.Days, &a.Sqft, &a.Occupants, &a.MeterUsage, &a.Ratio, &a.Share, &a.Amount, &a.ASMID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadUtilityRatios reads a full UtilityRatio structure from the database based on the supplied rows object
+func ReadUtilityRatios(rows *sql.Rows, a *UtilityRatio) error {
+	return rows.Scan(&a.URID, &a.UBID, &a.BID, &a.RID, &a.Ratio, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadMeterReading reads a full MeterReading structure from the database based on the supplied row object
+func ReadMeterReading(row *sql.Row, a *MeterReading) error {
+	err := row.Scan(&a.MRID, &a.BID, &a.RID, &a.Utility, &a.Dt, &a.Reading, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadMeterReadings reads a full MeterReading structure from the database based on the supplied rows object
+func ReadMeterReadings(rows *sql.Rows, a *MeterReading) error {
+	return rows.Scan(&a.MRID, &a.BID, &a.RID, &a.Utility, &a.Dt, &a.Reading, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadAssessmentStep reads a full AssessmentStep structure from the database based on the supplied row object
+func ReadAssessmentStep(row *sql.Row, a *AssessmentStep) error {
+	err := row.Scan(&a.ASTID, &a.BID, &a.RAID, &a.ASMID, &a.Dt, &a.Amount, &a.Pct, &a.NewASMID, &a.DtApplied, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadAssessmentSteps reads a full AssessmentStep structure from the database based on the supplied rows object
+func ReadAssessmentSteps(rows *sql.Rows, a *AssessmentStep) error {
+	return rows.Scan(&a.ASTID, &a.BID, &a.RAID, &a.ASMID, &a.Dt, &a.Amount, &a.Pct, &a.NewASMID, &a.DtApplied, &a.Comment, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadPaymentGateway reads a full PaymentGateway structure from the database based on the supplied row object
+func ReadPaymentGateway(row *sql.Row, a *PaymentGateway) error {
+	err := row.Scan(&a.PGID, &a.BID, &a.Name, &a.URL, &a.APIKey, &a.PMTID, &a.DEPID, &a.ARID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadPaymentGateways reads a full PaymentGateway structure from the database based on the supplied rows object
+func ReadPaymentGateways(rows *sql.Rows, a *PaymentGateway) error {
+	return rows.Scan(&a.PGID, &a.BID, &a.Name, &a.URL, &a.APIKey, &a.PMTID, &a.DEPID, &a.ARID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCardToken reads a full CardToken structure from the database based on the supplied row object
+func ReadCardToken(row *sql.Row, a *CardToken) error {
+	err := row.Scan(&a.CTID, &a.BID, &a.TCID, &a.PGID, &a.Token, &a.Brand, &a.Last4, &a.ExpMonth, &a.ExpYear, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadCardTokens reads a full CardToken structure from the database based on the supplied rows object
+func ReadCardTokens(rows *sql.Rows, a *CardToken) error {
+	return rows.Scan(&a.CTID, &a.BID, &a.TCID, &a.PGID, &a.Token, &a.Brand, &a.Last4, &a.ExpMonth, &a.ExpYear, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadGatewayTransaction reads a full GatewayTransaction structure from the database based on the supplied row object
+func ReadGatewayTransaction(row *sql.Row, a *GatewayTransaction) error {
+	err := row.Scan(&a.GTID, &a.BID, &a.PGID, &a.TCID, &a.CTID, &a.Type, &a.ParentGTID, &a.TxnID, &a.Amount, &a.Dt, &a.Status, &a.Message, &a.RCPTID, &a.PayoutID, &a.DID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	SkipSQLNoRowsError(&err)
+	return err
+}
+
+// ReadGatewayTransactions reads a full GatewayTransaction structure from the database based on the supplied rows object
+func ReadGatewayTransactions(rows *sql.Rows, a *GatewayTransaction) error {
+	return rows.Scan(&a.GTID, &a.BID, &a.PGID, &a.TCID, &a.CTID, &a.Type, &a.ParentGTID, &a.TxnID, &a.Amount, &a.Dt, &a.Status, &a.Message, &a.RCPTID, &a.PayoutID, &a.DID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
@@ -0,0 +1,268 @@
+package rlib
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// RESSTATEBooked and the others are the states of a Reservation, kept in
+// bits 0-1 of its FLAGS
+const (
+	RESSTATEBooked    = 0 // booked, the guest has not arrived
+	RESSTATECheckedIn = 1 // checked in, RAID is the Rental Agreement of the stay
+	RESSTATECancelled = 2 // cancelled
+	RESSTATENoShow    = 3 // the guest did not arrive
+	RESSTATEMask      = 3 // mask for the state bits of FLAGS
+)
+
+// RoomFree is the time a room is free during a requested range
+type RoomFree struct {
+	RID  int64    // the room
+	Name string   // its name
+	Free []Period // the parts of the range in which it is free
+}
+
+// AvailNight is the availability of a type of room for one night
+type AvailNight struct {
+	Dt         time.Time // the night that starts on Dt
+	Rooms      int64     // rooms of the type
+	Free       int64     // rooms that are not occupied, reserved or out of service
+	Unassigned int64     // reservations for the type with no room assigned yet
+	Available  int64     // Free - Unassigned, < 0 means the type is overbooked
+}
+
+// RoomAvailability is the availability calendar of a type of room over a
+// range of nights
+type RoomAvailability struct {
+	RTID   int64        // the type of room
+	Rooms  []RoomFree   // when each room of the type is free
+	Nights []AvailNight // availability of the type for each night
+}
+
+// RentableBusyPeriods returns the periods during d1 - d2 in which Rentable
+// rid cannot be given to a new guest. A Rentable is busy while it is in a
+// Rental Agreement, while a booked Reservation holds it and while its use
+// status is anything other than in service. The periods are sorted by start
+// date and are not merged.
+//
+// INPUTS
+//  ctx    = db context
+//  rid    = the Rentable
+//  d1, d2 = the range of interest
+//  resid  = a Reservation to leave out, ex: the one being changed. 0 if none
+//
+// RETURNS
+//  the busy periods
+//  any error encountered
+//-----------------------------------------------------------------------------
+func RentableBusyPeriods(ctx context.Context, rid int64, d1, d2 *time.Time, resid int64) ([]Period, error) {
+	var m []Period
+
+	rars, err := GetAgreementsForRentable(ctx, rid, d1, d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(rars); i++ {
+		m = append(m, Period{D1: rars[i].RARDtStart, D2: rars[i].RARDtStop})
+	}
+
+	res, err := GetBookedReservationsByRID(ctx, rid, d1, d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(res); i++ {
+		if res[i].RESID != resid {
+			m = append(m, Period{D1: res[i].DtArrive, D2: res[i].DtDepart})
+		}
+	}
+
+	rs, err := GetRentableStatusByRange(ctx, rid, d1, d2)
+	if err != nil {
+		return m, err
+	}
+	for i := 0; i < len(rs); i++ {
+		if rs[i].UseStatus != USESTATUSinService && rs[i].UseStatus != USESTATUSunknown {
+			m = append(m, Period{D1: rs[i].DtStart, D2: rs[i].DtStop})
+		}
+	}
+
+	sort.Slice(m, func(i, j int) bool { return m[i].D1.Before(m[j].D1) })
+	return m, err
+}
+
+// RentableFreePeriods returns the parts of d1 - d2 in which Rentable rid can
+// be given to a new guest
+//
+// INPUTS
+//  ctx    = db context
+//  rid    = the Rentable
+//  d1, d2 = the range of interest
+//  resid  = a Reservation to leave out, 0 if none
+//
+// RETURNS
+//  the free periods
+//  any error encountered
+//-----------------------------------------------------------------------------
+func RentableFreePeriods(ctx context.Context, rid int64, d1, d2 *time.Time, resid int64) ([]Period, error) {
+	busy, err := RentableBusyPeriods(ctx, rid, d1, d2, resid)
+	if err != nil {
+		return nil, err
+	}
+	return FindGaps(d1, d2, busy), nil
+}
+
+// IsRentableFree returns true if Rentable rid is free for all of d1 - d2
+//
+// INPUTS
+//  ctx    = db context
+//  rid    = the Rentable
+//  d1, d2 = the range of interest
+//  resid  = a Reservation to leave out, 0 if none
+//
+// RETURNS
+//  true if it is free
+//  any error encountered
+//-----------------------------------------------------------------------------
+func IsRentableFree(ctx context.Context, rid int64, d1, d2 *time.Time, resid int64) (bool, error) {
+	free, err := RentableFreePeriods(ctx, rid, d1, d2, resid)
+	if err != nil {
+		return false, err
+	}
+	return len(free) == 1 && free[0].D1.Equal(*d1) && free[0].D2.Equal(*d2), nil
+}
+
+// GetRoomAvailability builds the availability calendar of the rooms of type
+// rtid for the nights from d1 up to d2
+//
+// INPUTS
+//  ctx    = db context
+//  bid    = the business
+//  rtid   = the type of room
+//  d1, d2 = the first night and the departure date
+//  resid  = a Reservation to leave out, 0 if none
+//
+// RETURNS
+//  the calendar
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetRoomAvailability(ctx context.Context, bid, rtid int64, d1, d2 *time.Time, resid int64) (RoomAvailability, error) {
+	var a = RoomAvailability{RTID: rtid}
+	var busy [][]Period
+
+	rooms, err := GetRentablesByRTID(ctx, bid, rtid, d1, d2)
+	if err != nil {
+		return a, err
+	}
+	for i := 0; i < len(rooms); i++ {
+		b, err := RentableBusyPeriods(ctx, rooms[i].RID, d1, d2, resid)
+		if err != nil {
+			return a, err
+		}
+		busy = append(busy, b)
+		a.Rooms = append(a.Rooms, RoomFree{RID: rooms[i].RID, Name: rooms[i].RentableName, Free: FindGaps(d1, d2, b)})
+	}
+
+	res, err := GetUnassignedReservations(ctx, bid, rtid, d1, d2)
+	if err != nil {
+		return a, err
+	}
+	var held []Period
+	for i := 0; i < len(res); i++ {
+		if res[i].RESID != resid {
+			held = append(held, Period{D1: res[i].DtArrive, D2: res[i].DtDepart})
+		}
+	}
+	a.Nights = availNights(d1, d2, busy, held)
+	return a, nil
+}
+
+// availNights counts, for each night from d1 up to d2, the rooms that are
+// free and the reservations that still need a room
+//
+// INPUTS
+//  d1, d2 = the first night and the departure date
+//  busy   = the busy periods of each room
+//  held   = the stays of the reservations that have no room yet
+//
+// RETURNS
+//  one AvailNight per night
+//-----------------------------------------------------------------------------
+func availNights(d1, d2 *time.Time, busy [][]Period, held []Period) []AvailNight {
+	var m []AvailNight
+	for dt := *d1; dt.Before(*d2); dt = dt.AddDate(0, 0, 1) {
+		next := dt.AddDate(0, 0, 1)
+		n := AvailNight{Dt: dt, Rooms: int64(len(busy))}
+		for i := 0; i < len(busy); i++ {
+			if !periodsCover(busy[i], &dt, &next) {
+				n.Free++
+			}
+		}
+		for i := 0; i < len(held); i++ {
+			if held[i].D1.Before(next) && held[i].D2.After(dt) {
+				n.Unassigned++
+			}
+		}
+		n.Available = n.Free - n.Unassigned
+		m = append(m, n)
+	}
+	return m
+}
+
+// periodsCover returns true if any of the periods in m overlaps d1 - d2
+//-----------------------------------------------------------------------------
+func periodsCover(m []Period, d1, d2 *time.Time) bool {
+	for i := 0; i < len(m); i++ {
+		if m[i].D1.Before(*d2) && m[i].D2.After(*d1) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReservationRate returns the nightly rate of a room of type rtid on date
+// dt. If rate plan rpid has a rate for the type on that date it is used,
+// either as a price or as a percentage of the market rate. Otherwise the
+// market rate of the type is used.
+//
+// INPUTS
+//  ctx  = db context
+//  xbiz = the business
+//  rtid = the type of room
+//  rpid = the rate plan, 0 for the market rate
+//  dt   = the night
+//
+// RETURNS
+//  the rate
+//  any error encountered
+//-----------------------------------------------------------------------------
+func ReservationRate(ctx context.Context, xbiz *XBusiness, rtid, rpid int64, dt *time.Time) (Money, error) {
+	next := dt.AddDate(0, 0, 1)
+	market := float64(0)
+	mr := xbiz.RT[rtid].MR
+	for i := 0; i < len(mr); i++ {
+		if DateRangeOverlap(dt, &next, &mr[i].DtStart, &mr[i].DtStop) {
+			market = mr[i].MarketRate
+			break
+		}
+	}
+	if rpid == 0 {
+		return MoneyFromFloat(market), nil
+	}
+
+	refs, err := GetRatePlanRefsInRange(ctx, rpid, dt, &next)
+	if err != nil || len(refs) == 0 {
+		return MoneyFromFloat(market), err
+	}
+	var r RatePlanRefRTRate
+	if err = GetRatePlanRefRTRate(ctx, refs[0].RPRID, rtid, &r); err != nil {
+		return MoneyFromFloat(market), err
+	}
+	if r.RPRID == 0 || r.FLAGS&FlRTRna != 0 { // the plan has no rate for this type
+		return MoneyFromFloat(market), nil
+	}
+	if r.FLAGS&FlRTRpct != 0 {
+		return MoneyFromFloat(market * r.Val), nil
+	}
+	return MoneyFromFloat(r.Val), nil
+}
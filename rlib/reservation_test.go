@@ -0,0 +1,43 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Room availability calendar tests.
+
+func TestAvailNights(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2018, time.March, d, 0, 0, 0, 0, time.UTC) }
+	d1, d2 := day(1), day(4) // the nights of the 1st, 2nd and 3rd
+	var tests = []struct {
+		busy      [][]Period
+		held      []Period
+		free      []int64
+		available []int64
+	}{
+		// two empty rooms
+		{[][]Period{nil, nil}, nil, []int64{2, 2, 2}, []int64{2, 2, 2}},
+		// a stay checking out on the 2nd frees the room that night
+		{[][]Period{{{D1: day(27).AddDate(0, -1, 0), D2: day(2)}}, nil}, nil, []int64{1, 2, 2}, []int64{1, 2, 2}},
+		// a room out of service on the 2nd only, and one booked from the 3rd on
+		{[][]Period{{{D1: day(2), D2: day(3)}}, {{D1: day(3), D2: day(10)}}}, nil, []int64{2, 1, 1}, []int64{2, 1, 1}},
+		// a reservation with no room yet takes one from the 1st to the 3rd
+		{[][]Period{nil, nil}, []Period{{D1: day(1), D2: day(3)}}, []int64{2, 2, 2}, []int64{1, 1, 2}},
+		// overbooked on the 2nd
+		{[][]Period{{{D1: day(1), D2: day(5)}}}, []Period{{D1: day(2), D2: day(3)}}, []int64{0, 0, 0}, []int64{0, -1, 0}},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		m := availNights(&d1, &d2, tc.busy, tc.held)
+		if len(m) != len(tc.free) {
+			t.Errorf("test %d: expected %d nights, got %d\n", i, len(tc.free), len(m))
+			continue
+		}
+		for j := 0; j < len(m); j++ {
+			if m[j].Free != tc.free[j] || m[j].Available != tc.available[j] || m[j].Rooms != int64(len(tc.busy)) {
+				t.Errorf("test %d, night %d: expected %d free, %d available, got %d free, %d available\n", i, j, tc.free[j], tc.available[j], m[j].Free, m[j].Available)
+			}
+		}
+	}
+}
@@ -17,16 +17,95 @@ type RpnCtx struct {
 	m      *[]AcctRule // READ-ONLY access to the rule array being created
 	xu     XRentable   // the Rentable associated with this rule, loaded only if needed
 	rid    int64       // Rentable id
+	raid   int64       // Rental Agreement id, 0 if not known
 	d1     *time.Time  // start of time range
 	d2     *time.Time  // end of time range
 	pf     float64     // proration factor
-	amount float64     // the full amount of the assessment or payment
-	stack  []float64   // the stack used by the rpn calculator
+	amount Money       // the full amount of the assessment or payment
+	stack  []rpnVal    // the stack used by the rpn calculator
 	GSRset bool        // initially false, set to true after GSR is calculated
-	GSR    float64     // this is a heavyweight calculation. If GSRset is true, then don't recalculate, just use current value
+	GSR    Money       // this is a heavyweight calculation. If GSRset is true, then don't recalculate, just use current value
 	r      *AcctRule   // the account rule in the process of being constructed
 }
 
+// rpnVal is a value on the rpn calculator's stack. Amounts -- the amount of
+// the assessment, ${GSR}, ${UMR}, ${CONTRACTRENT}, ${ASM.Amount} and aval()
+// -- are Money. Numbers in the formula, quantities such as ${SQFT} and
+// custom attributes are float64: they are rates and counts. An operator
+// with a Money operand produces Money, except that Money divided by Money
+// is a ratio.
+type rpnVal struct {
+	money bool    // true if the value is an amount of money
+	amt   Money   // the value when money is true
+	x     float64 // the value when money is false
+}
+
+// rpnMoney returns amt as a calculator value
+func rpnMoney(amt Money) rpnVal {
+	return rpnVal{money: true, amt: amt}
+}
+
+// rpnNum returns the number x as a calculator value
+func rpnNum(x float64) rpnVal {
+	return rpnVal{x: x}
+}
+
+// Money returns the value as Money, rounding a number to the nearest cent
+func (v rpnVal) Money() Money {
+	if v.money {
+		return v.amt
+	}
+	return MoneyFromFloat(v.x)
+}
+
+// Float returns the value as a float64
+func (v rpnVal) Float() float64 {
+	if v.money {
+		return v.amt.Float()
+	}
+	return v.x
+}
+
+// rpnApply applies the operator op to x and y. Money is only multiplied
+// or divided by a number, which rounds the result to the cent. A number
+// added to or subtracted from Money is taken to be dollars. Division by
+// zero gives 0.
+func rpnApply(op string, x, y rpnVal) rpnVal {
+	switch op {
+	case "+", "-":
+		if !x.money && !y.money {
+			if op == "+" {
+				return rpnNum(x.x + y.x)
+			}
+			return rpnNum(x.x - y.x)
+		}
+		if op == "+" {
+			return rpnMoney(x.Money() + y.Money())
+		}
+		return rpnMoney(x.Money() - y.Money())
+	case "*":
+		switch {
+		case x.money:
+			return rpnMoney(x.amt.Mul(y.Float()))
+		case y.money:
+			return rpnMoney(y.amt.Mul(x.x))
+		}
+		return rpnNum(x.x * y.x)
+	case "/":
+		if y.Float() == 0 {
+			if x.money {
+				return rpnMoney(0)
+			}
+			return rpnNum(0)
+		}
+		if x.money && !y.money {
+			return rpnMoney(x.amt.Mul(1 / y.x))
+		}
+		return rpnNum(x.Float() / y.Float())
+	}
+	return rpnNum(0)
+}
+
 var rpnVariable *regexp.Regexp
 var rpnOperator *regexp.Regexp
 var rpnNumber *regexp.Regexp
@@ -37,7 +116,7 @@ var rpnSUM *regexp.Regexp
 func rpnPrintStack(rpnCtx *RpnCtx) {
 	fmt.Printf("Stack --- size: %d\n", len(rpnCtx.stack))
 	for i := 0; i < len(rpnCtx.stack); i++ {
-		fmt.Printf("%2d: %f\n", i, rpnCtx.stack[i])
+		fmt.Printf("%2d: %f\n", i, rpnCtx.stack[i].Float())
 	}
 }
 
@@ -50,18 +129,19 @@ func RpnInit() {
 	rpnASM = regexp.MustCompile(`^ASM\(([^)]+)\)`)
 }
 
-func rpnPop(rpnCtx *RpnCtx) float64 {
+func rpnPop(rpnCtx *RpnCtx) rpnVal {
 	l := len(rpnCtx.stack)
 	if l > 0 {
 		x := rpnCtx.stack[l-1]
 		rpnCtx.stack = rpnCtx.stack[0 : l-1]
 		return x
 	}
-	return 0
+	return rpnNum(0)
 }
 
-func rpnPush(rpnCtx *RpnCtx, x float64) {
-	rpnCtx.stack = append(rpnCtx.stack, x*rpnCtx.pf)
+// rpnPush pushes the amount amt, prorated
+func rpnPush(rpnCtx *RpnCtx, amt Money) {
+	rpnCtx.stack = append(rpnCtx.stack, rpnMoney(amt.Mul(rpnCtx.pf)))
 }
 
 func rpnLoadRentable(ctx context.Context, rpnCtx *RpnCtx) error {
@@ -81,21 +161,21 @@ func rpnLoadRentable(ctx context.Context, rpnCtx *RpnCtx) error {
 }
 
 // RpnCreateCtx creates the context structure needed for use with all the Rpn functions
-func RpnCreateCtx(xbiz *XBusiness, rid int64, d1, d2 *time.Time, m *[]AcctRule, amount, pf float64) RpnCtx {
+func RpnCreateCtx(xbiz *XBusiness, rid int64, d1, d2 *time.Time, m *[]AcctRule, amount Money, pf float64) RpnCtx {
 	var rpnCtx RpnCtx
 	rpnCtx.xbiz = xbiz
 	rpnCtx.m = m
 	rpnCtx.d1 = d1
 	rpnCtx.d2 = d2
 	rpnCtx.rid = rid
-	rpnCtx.stack = make([]float64, 0)
+	rpnCtx.stack = make([]rpnVal, 0)
 	rpnCtx.pf = pf
 	rpnCtx.amount = amount
 	rpnCtx.GSRset = false
 	return rpnCtx
 }
 
-func rpnFunctionResolve(rpnCtx *RpnCtx, cmd, val string) float64 {
+func rpnFunctionResolve(ctx context.Context, rpnCtx *RpnCtx, cmd, val string) (rpnVal, error) {
 	switch {
 	case cmd == "aval":
 		if val[0] == '$' {
@@ -104,19 +184,122 @@ func rpnFunctionResolve(rpnCtx *RpnCtx, cmd, val string) float64 {
 		for i := 0; i < len(*rpnCtx.m); i++ {
 			if (*rpnCtx.m)[i].Account == val {
 				// fmt.Printf("rpnFunctionResolve: returning %f\n", (*rpnCtx.m)[i].Amount)
-				return (*rpnCtx.m)[i].Amount
+				return rpnMoney((*rpnCtx.m)[i].Amount), nil
 			}
 		}
+	case cmd == "CA":
+		x, err := rpnCustomAttribute(ctx, rpnCtx, val)
+		return rpnNum(x), err
 	default:
 		Ulog("rpnFunctionResolve: unrecognized function: %s\n", cmd)
 	}
-	return float64(0)
+	return rpnNum(0), nil
+}
+
+// rpnNeedRentable returns an error if the rule is not being evaluated for a
+// Rentable. Variable v needs one.
+func rpnNeedRentable(rpnCtx *RpnCtx, v string) error {
+	if rpnCtx.rid == 0 {
+		return fmt.Errorf("${%s} needs a Rentable", v)
+	}
+	return nil
+}
+
+// rpnRentableType returns the RentableType of the Rentable at the start of
+// the time range
+func rpnRentableType(ctx context.Context, rpnCtx *RpnCtx) (int64, error) {
+	rtr, err := GetRentableTypeRefForDate(ctx, rpnCtx.rid, rpnCtx.d1)
+	return rtr.RTID, err
+}
+
+// rpnSqft returns the square feet of the Rentable. It is the Square Feet
+// custom attribute of its RentableType, the one CAM reconciliation uses.
+func rpnSqft(ctx context.Context, rpnCtx *RpnCtx) (float64, error) {
+	if err := rpnNeedRentable(rpnCtx, "SQFT"); err != nil {
+		return 0, err
+	}
+	rtid, err := rpnRentableType(ctx, rpnCtx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := RentableTypeSqft(rpnCtx.xbiz, rtid)
+	return float64(n), err
 }
 
-func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error) {
+// rpnCustomAttribute returns the value of the custom attribute named name.
+// The Rentable's own attributes are looked at first, then those of its
+// RentableType. As the calculator splits formulas on spaces, an underscore
+// in name matches a space: ${CA(Square_Feet)}.
+func rpnCustomAttribute(ctx context.Context, rpnCtx *RpnCtx, name string) (float64, error) {
+	v := "CA(" + name + ")"
+	if err := rpnNeedRentable(rpnCtx, v); err != nil {
+		return 0, err
+	}
+	ca, err := GetAllCustomAttributes(ctx, ELEMRENTABLE, rpnCtx.rid)
+	if err != nil {
+		return 0, err
+	}
+	rtid, err := rpnRentableType(ctx, rpnCtx)
+	if err != nil {
+		return 0, err
+	}
+	names := []string{name, strings.Replace(name, "_", " ", -1)}
+	for _, m := range []map[string]CustomAttribute{ca, rpnCtx.xbiz.RT[rtid].CA} {
+		for _, n := range names {
+			c, ok := m[n]
+			if !ok {
+				continue
+			}
+			x, err := strconv.ParseFloat(strings.TrimSpace(c.Value), 64)
+			if err != nil {
+				return 0, fmt.Errorf("${%s}: %q is not a number", v, c.Value)
+			}
+			return x, nil
+		}
+	}
+	return 0, fmt.Errorf("${%s}: Rentable %d has no custom attribute %q", v, rpnCtx.rid, names[1])
+}
+
+// rpnContractRent returns the ContractRent of the Rentable in its Rental
+// Agreement. If the Rental Agreement is not known, the first one renting the
+// Rentable in the time range is used.
+func rpnContractRent(ctx context.Context, rpnCtx *RpnCtx) (Money, error) {
+	if err := rpnNeedRentable(rpnCtx, "CONTRACTRENT"); err != nil {
+		return 0, err
+	}
+	m, err := GetAgreementsForRentable(ctx, rpnCtx.rid, rpnCtx.d1, rpnCtx.d2)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(m); i++ {
+		if rpnCtx.raid == 0 || m[i].RAID == rpnCtx.raid {
+			return m[i].ContractRent, nil
+		}
+	}
+	return 0, fmt.Errorf("${CONTRACTRENT}: Rentable %d is not rented between %s and %s", rpnCtx.rid, rpnCtx.d1.Format(RRDATEFMT4), rpnCtx.d2.Format(RRDATEFMT4))
+}
+
+// rpnOccupants returns the number of people using the Rentable at any time
+// in the time range
+func rpnOccupants(ctx context.Context, rpnCtx *RpnCtx) (float64, error) {
+	if err := rpnNeedRentable(rpnCtx, "OCCUPANTS"); err != nil {
+		return 0, err
+	}
+	m, err := GetRentableUsersInRange(ctx, rpnCtx.rid, rpnCtx.d1, rpnCtx.d2)
+	if err != nil {
+		return 0, err
+	}
+	people := map[int64]bool{}
+	for i := 0; i < len(m); i++ {
+		people[m[i].TCID] = true
+	}
+	return float64(len(people)), nil
+}
+
+func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (rpnVal, error) {
 	var (
 		err error
-		val float64
+		val rpnVal
 	)
 
 	if s == "UMR" { // Unit MARKET RATE
@@ -129,12 +312,12 @@ func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error)
 		if err != nil {
 			return val, err
 		}
-		return rpnCtx.pf * mr, err
+		return rpnMoney(MoneyFromFloat(mr).Mul(rpnCtx.pf)), err
 	}
 
 	if s == "GSR" { // Gross Schedule Rent = Market Rate + Specialties
 		if rpnCtx.GSRset { // don't recalculate if already set
-			return rpnCtx.pf * rpnCtx.GSR, err
+			return rpnMoney(rpnCtx.GSR.Mul(rpnCtx.pf)), err
 		}
 		err = rpnLoadRentable(ctx, rpnCtx) // make sure it's loaded
 		if err != nil {
@@ -148,7 +331,7 @@ func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error)
 		// fmt.Printf("varResolve: amt = %f, d1 = %s, d2 = %s\n", amt, rpnCtx.d1.Format(RRDATEFMT4), rpnCtx.d2.Format(RRDATEFMT4))
 		rpnCtx.GSR = amt
 		rpnCtx.GSRset = true
-		return rpnCtx.pf * rpnCtx.GSR, err
+		return rpnMoney(rpnCtx.GSR.Mul(rpnCtx.pf)), err
 	}
 
 	if s == "ASM.Amount" { // the amount of the associated assessment
@@ -158,7 +341,26 @@ func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error)
 			return val, err
 		}
 
-		return rpnCtx.pf * a.Amount, err
+		return rpnMoney(a.Amount.Mul(rpnCtx.pf)), err
+	}
+
+	//------------------------------------------------------------------
+	// SQFT, OCCUPANTS and custom attributes are quantities, they are
+	// not prorated. Multiply them by a rate, which is: ${SQFT} 1.25 *
+	//------------------------------------------------------------------
+	if s == "SQFT" { // square feet of the Rentable
+		x, err := rpnSqft(ctx, rpnCtx)
+		return rpnNum(x), err
+	}
+
+	if s == "OCCUPANTS" { // number of people using the Rentable
+		x, err := rpnOccupants(ctx, rpnCtx)
+		return rpnNum(x), err
+	}
+
+	if s == "CONTRACTRENT" { // the rent in the Rental Agreement
+		amt, err := rpnContractRent(ctx, rpnCtx)
+		return rpnMoney(amt.Mul(rpnCtx.pf)), err
 	}
 
 	m1 := rpnFunction.FindAllStringSubmatchIndex(s, -1)
@@ -166,15 +368,16 @@ func varResolve(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error)
 		m := m1[0]
 		cmd := s[m[2]:m[3]]
 		val := s[m[4]:m[5]]
-		return rpnFunctionResolve(rpnCtx, cmd, val), err
+		return rpnFunctionResolve(ctx, rpnCtx, cmd, val)
 	}
 
 	return val, err
 }
 
-// RpnCalculateEquation takes a formula, parses and executes the formula and returns the number it calculates.
+// RpnCalculateEquation takes a formula, parses and executes the formula and returns the amount it calculates.
+// If the formula works out to a number rather than an amount, ex: ${SQFT} 1.25 *, it is rounded to the cent.
 // This may be helpful: https://play.golang.org/p/p842UZpQaK
-func RpnCalculateEquation(ctx context.Context, rpnCtx *RpnCtx, s string) (float64, error) {
+func RpnCalculateEquation(ctx context.Context, rpnCtx *RpnCtx, s string) (Money, error) {
 	// funcname := "RpnCalculateEquation"
 
 	var (
@@ -195,7 +398,7 @@ func RpnCalculateEquation(ctx context.Context, rpnCtx *RpnCtx, s string) (float6
 					match := s[m[2]:m[3]]
 					n, err := varResolve(ctx, rpnCtx, match)
 					if err != nil {
-						return float64(0), err
+						return Money(0), err
 					}
 
 					rpnCtx.stack = append(rpnCtx.stack, n)
@@ -207,30 +410,20 @@ func RpnCalculateEquation(ctx context.Context, rpnCtx *RpnCtx, s string) (float6
 				m := rpnNumber.FindStringSubmatchIndex(s)
 				match := s[m[0]:m[1]]
 				n, _ := strconv.ParseFloat(match, 64)
-				rpnCtx.stack = append(rpnCtx.stack, n*rpnCtx.pf)
+				rpnCtx.stack = append(rpnCtx.stack, rpnNum(n*rpnCtx.pf))
 			} else if len(s) > 1 && s[0] == '-' && (('0' <= s[1] && s[1] <= '9') || '.' == s[1]) {
 				m := rpnNumber.FindStringSubmatchIndex(s)
 				match := s[m[0]:m[1]]
 				n, _ := strconv.ParseFloat(match, 64)
-				rpnCtx.stack = append(rpnCtx.stack, n*rpnCtx.pf)
+				rpnCtx.stack = append(rpnCtx.stack, rpnNum(n*rpnCtx.pf))
 			} else if s[0] == '-' || s[0] == '+' || s[0] == '*' || s[0] == '/' { // is it an operator?
 				op := s[0:1]
-				var x, y float64
-				y = rpnPop(rpnCtx)
-				x = rpnPop(rpnCtx)
-				switch op {
-				case "+":
-					rpnCtx.stack = append(rpnCtx.stack, x+y)
-				case "-":
-					rpnCtx.stack = append(rpnCtx.stack, x-y)
-				case "*":
-					rpnCtx.stack = append(rpnCtx.stack, x*y)
-				case "/":
-					rpnCtx.stack = append(rpnCtx.stack, x/y)
-				}
+				y := rpnPop(rpnCtx)
+				x := rpnPop(rpnCtx)
+				rpnCtx.stack = append(rpnCtx.stack, rpnApply(op, x, y))
 			}
 		}
 		// rpnPrintStack(rpnCtx)
 	}
-	return rpnPop(rpnCtx), err
+	return rpnPop(rpnCtx).Money(), err
 }
@@ -0,0 +1,113 @@
+package rlib
+
+import (
+	"strings"
+	"time"
+)
+
+// RPTRANGEcurrentMonth et al. are the relative date ranges available to a
+// ReportSubscription. They are evaluated at the time the report is run.
+const (
+	RPTRANGEcurrentMonth = 0 // first of this month up to first of next month
+	RPTRANGElastMonth    = 1 // first of last month up to first of this month
+	RPTRANGEmonthToDate  = 2 // first of this month up to today
+	RPTRANGElast7Days    = 3 // the 7 days ending today
+	RPTRANGElastQuarter  = 4 // the last full calendar quarter
+	RPTRANGEyearToDate   = 5 // Jan 1 up to today
+	RPTRANGElastYear     = 6 // the last full calendar year
+	RPTRANGELAST         = 6 // keep in sync with last
+)
+
+// RptRangeNames are the readable names of the RPTRANGE values
+var RptRangeNames = []string{
+	"current month",
+	"last month",
+	"month to date",
+	"last 7 days",
+	"last quarter",
+	"year to date",
+	"last year",
+}
+
+// RptSubInactive is the ReportSubscription FLAGS bit indicating that the
+// subscription should not be delivered
+const RptSubInactive = 1 << 0
+
+// RptDelivered and RptDeliveryFailed are the ReportDelivery Status values
+const (
+	RptDelivered      = 0
+	RptDeliveryFailed = 1
+)
+
+// ReportSubscriptionRange returns the report date range described by dr
+// relative to now. The returned stop date is not inclusive.
+//
+// INPUTS
+//  dr  - one of the RPTRANGE values
+//  now - the date the report is being run
+//
+// RETURNS
+//  the start and stop dates of the range
+//-----------------------------------------------------------------------------
+func ReportSubscriptionRange(dr int64, now *time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	som := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	switch dr {
+	case RPTRANGElastMonth:
+		return som.AddDate(0, -1, 0), som
+	case RPTRANGEmonthToDate:
+		return som, tomorrow
+	case RPTRANGElast7Days:
+		return tomorrow.AddDate(0, 0, -7), tomorrow
+	case RPTRANGElastQuarter:
+		soq := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		return soq.AddDate(0, -3, 0), soq
+	case RPTRANGEyearToDate:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), tomorrow
+	case RPTRANGElastYear:
+		soy := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return soy.AddDate(-1, 0, 0), soy
+	default: // RPTRANGEcurrentMonth
+		return som, som.AddDate(0, 1, 0)
+	}
+}
+
+// NextReportSubscriptionTime returns the next delivery time for a subscription
+// that was due at dt. If the subscription has fallen behind (for example, the
+// server was down) it is advanced until it is after now so that a backlog of
+// identical reports is not sent.
+//
+// INPUTS
+//  dt    - the time the subscription was due
+//  cycle - one of the RECUR values
+//  now   - current time
+//
+// RETURNS
+//  the next delivery time. If cycle is RECURNONE, ENDOFTIME is returned
+//-----------------------------------------------------------------------------
+func NextReportSubscriptionTime(dt *time.Time, cycle int64, now *time.Time) time.Time {
+	if cycle < RECURDAILY || cycle > RECURLAST {
+		return ENDOFTIME
+	}
+	next := NextInstance(dt, cycle)
+	for !next.After(*now) {
+		next = NextInstance(&next, cycle)
+	}
+	return next
+}
+
+// ReportSubscriptionRecipients returns the list of valid email addresses in
+// the comma separated list s.
+//-----------------------------------------------------------------------------
+func ReportSubscriptionRecipients(s string) []string {
+	var m []string
+	sa := strings.Split(s, ",")
+	for i := 0; i < len(sa); i++ {
+		to := strings.TrimSpace(sa[i])
+		if ValidEmailAddress(to) {
+			m = append(m, to)
+		}
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Report subscription relative date range tests
+
+func TestReportSubscriptionRange(t *testing.T) {
+	var m = []struct {
+		dr     int64
+		now    string
+		d1, d2 string
+	}{
+		{RPTRANGEcurrentMonth, "2018-05-17", "2018-05-01", "2018-06-01"},
+		{RPTRANGElastMonth, "2018-05-17", "2018-04-01", "2018-05-01"},
+		{RPTRANGElastMonth, "2018-01-03", "2017-12-01", "2018-01-01"},
+		{RPTRANGEmonthToDate, "2018-05-17", "2018-05-01", "2018-05-18"},
+		{RPTRANGElast7Days, "2018-05-17", "2018-05-11", "2018-05-18"},
+		{RPTRANGElastQuarter, "2018-05-17", "2018-01-01", "2018-04-01"},
+		{RPTRANGElastQuarter, "2018-01-17", "2017-10-01", "2018-01-01"},
+		{RPTRANGElastQuarter, "2018-12-31", "2018-07-01", "2018-10-01"},
+		{RPTRANGEyearToDate, "2018-05-17", "2018-01-01", "2018-05-18"},
+		{RPTRANGElastYear, "2018-05-17", "2017-01-01", "2018-01-01"},
+	}
+
+	for i := 0; i < len(m); i++ {
+		now, _ := StringToDate(m[i].now)
+		e1, _ := StringToDate(m[i].d1)
+		e2, _ := StringToDate(m[i].d2)
+		d1, d2 := ReportSubscriptionRange(m[i].dr, &now)
+		t.Logf("ReportSubscriptionRange( %s, %s ) = %s - %s\n", RptRangeNames[m[i].dr], m[i].now, d1.Format(RRDATEINPFMT), d2.Format(RRDATEINPFMT))
+		if !d1.Equal(e1) || !d2.Equal(e2) {
+			t.Errorf("ReportSubscriptionRange( %s, %s ) expect %s - %s, got %s - %s\n", RptRangeNames[m[i].dr], m[i].now, m[i].d1, m[i].d2, d1.Format(RRDATEINPFMT), d2.Format(RRDATEINPFMT))
+		}
+	}
+}
+
+func TestNextReportSubscriptionTime(t *testing.T) {
+	var m = []struct {
+		dt, now string
+		cycle   int64
+		expect  string
+	}{
+		{"2018-05-01", "2018-05-01", RECURMONTHLY, "2018-06-01"},
+		{"2018-05-01", "2018-08-15", RECURMONTHLY, "2018-09-01"}, // fell behind, skip the backlog
+		{"2018-05-01", "2018-05-01", RECURWEEKLY, "2018-05-08"},
+		{"2018-05-01", "2018-05-01", RECURDAILY, "2018-05-02"},
+		{"2018-05-01", "2018-05-01", RECURNONE, "9999-12-31"},
+	}
+
+	for i := 0; i < len(m); i++ {
+		dt, _ := StringToDate(m[i].dt)
+		now, _ := StringToDate(m[i].now)
+		e := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+		if m[i].expect != "9999-12-31" {
+			e, _ = StringToDate(m[i].expect)
+		}
+		next := NextReportSubscriptionTime(&dt, m[i].cycle, &now)
+		if !next.Equal(e) {
+			t.Errorf("NextReportSubscriptionTime( %s, %d, %s ) expect %s, got %s\n", m[i].dt, m[i].cycle, m[i].now, m[i].expect, next.Format(RRDATEINPFMT))
+		}
+	}
+}
@@ -706,7 +706,7 @@ func rentrollMapGSRHandler(ctx context.Context, BID int64, startDt, stopDt time.
 
 	// Console("Entered rentrollMapGSRHandler: startDt = %s, stopDt = %s\n", startDt.Format(RRDATEREPORTFMT), stopDt.Format(RRDATEREPORTFMT))
 	for k, v := range *m { // for every component
-		var gsrAmt float64
+		var gsrAmt Money
 		raid := int64(-1)
 		for i := 0; i < len(v); i++ {
 			if raid == v[i].RAID.Int64 {
@@ -745,7 +745,7 @@ func rentrollMapGSRHandler(ctx context.Context, BID int64, startDt, stopDt time.
 			}
 
 			v[i].RentCycleGSR = NullFloat64{Float64: gsr, Valid: true}
-			v[i].PeriodGSR = NullFloat64{Float64: gsrAmt, Valid: true}
+			v[i].PeriodGSR = NullFloat64{Float64: gsrAmt.Float(), Valid: true}
 		}
 	}
 
@@ -1046,10 +1046,7 @@ func getReceivableAndSecDep(ctx context.Context, BID int64, startDt, stopDt time
 	subTTL *RentRollStaticInfo, raidMap *map[int64]int64) []error {
 
 	const funcname = "getReceivableAndSecDep"
-	var (
-		errList []error
-		d70     = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-	)
+	var errList []error
 	// Console("Entered in %s.  RARBalCache size: %d\n", funcname, RARBalCacheSize())
 
 	for raid, rid := range *raidMap {
@@ -1069,9 +1066,7 @@ func getReceivableAndSecDep(ctx context.Context, BID int64, startDt, stopDt time
 		deltaInRcv := (endingRcv - beginningRcv)*/
 
 		// BeginningSecDep
-		// TODO:  this should be updated to get the value on a specific date: the start date.
-		//        This is only possible after we add the LedgerMarkers
-		beginningSecDep, err := GetSecDepBalance(ctx, BID, raid, rid, &d70, &startDt)
+		beginningSecDep, err := GetSecDepBalanceOnDate(ctx, BID, raid, rid, &startDt)
 		if err != nil {
 			Console("%s: RAID: %d, RID: %d || Error while calculating BeginningSecDep:: %s\n",
 				funcname, raid, rid, err.Error())
@@ -1079,8 +1074,6 @@ func getReceivableAndSecDep(ctx context.Context, BID int64, startDt, stopDt time
 		}
 
 		// Change in SecDep
-		// TODO:  this should be updated to get the value on a specific date: the start date.
-		//        This is only possible after we add the LedgerMarkers
 		deltaInSecDep, err := GetSecDepBalance(ctx, BID, raid, rid, &startDt, &stopDt)
 		if err != nil {
 			Console("%s: RAID: %d, RID: %d || Error while calculating BeginningSecDep:: %s\n",
@@ -1092,12 +1085,12 @@ func getReceivableAndSecDep(ctx context.Context, BID int64, startDt, stopDt time
 		endingSecDep := (beginningSecDep + deltaInSecDep)*/
 
 		// now feed all those amount in subtotal row, for each iteration
-		subTTL.BeginReceivable += beginningRcv
-		subTTL.DeltaReceivable += (endingRcv - beginningRcv)
-		subTTL.EndReceivable += endingRcv
-		subTTL.BeginSecDep += beginningSecDep
-		subTTL.DeltaSecDep += deltaInSecDep
-		subTTL.EndSecDep += (beginningSecDep + deltaInSecDep)
+		subTTL.BeginReceivable += beginningRcv.Float()
+		subTTL.DeltaReceivable += (endingRcv - beginningRcv).Float()
+		subTTL.EndReceivable += endingRcv.Float()
+		subTTL.BeginSecDep += beginningSecDep.Float()
+		subTTL.DeltaSecDep += deltaInSecDep.Float()
+		subTTL.EndSecDep += (beginningSecDep + deltaInSecDep).Float()
 
 		// Console("BeginSecDep = %.2f, Delta = %.2f, End = %.2f\n", subTTL.BeginSecDep, subTTL.DeltaSecDep, subTTL.EndSecDep)
 	}
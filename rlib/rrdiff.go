@@ -0,0 +1,398 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RentRollSnapshotEntry describes a single Rentable in the rent roll as of a
+// specific date.
+type RentRollSnapshotEntry struct {
+	RID          int64   // the rentable
+	RentableName string  // its name
+	RTID         int64   // its type on the snapshot date
+	RentableType string  // name of the rentable type
+	RAID         int64   // rental agreement in effect, 0 if vacant
+	Payors       []int64 // TCIDs of the payors on RAID, sorted
+	PayorNames   string  // comma separated payor names
+	UseStatus    int64   // rentable use status on the snapshot date
+	LeaseStatus  int64   // rentable lease status on the snapshot date
+	ContractRent float64 // sum of the recurring rent assessments in effect
+	GSR          float64 // market rate for the rentable
+}
+
+// RentRollSnapshot is the rent roll as of a date, indexed by RID
+type RentRollSnapshot map[int64]RentRollSnapshotEntry
+
+// Rent roll change types
+const (
+	RRCHGmoveIn           = 1 // payor(s) moved into a rentable
+	RRCHGmoveOut          = 2 // payor(s) moved out of a rentable
+	RRCHGtransfer         = 3 // payor(s) moved from one rentable to another
+	RRCHGrentChange       = 4 // contract rent changed on the same agreement
+	RRCHGnewAgreement     = 5 // rental agreement started
+	RRCHGexpiredAgreement = 6 // rental agreement ended
+	RRCHGstatusChange     = 7 // rentable use or lease status changed
+)
+
+// RRChangeTypeNames maps the RRCHG values to their names
+var RRChangeTypeNames = map[int64]string{
+	RRCHGmoveIn:           "Move In",
+	RRCHGmoveOut:          "Move Out",
+	RRCHGtransfer:         "Transfer",
+	RRCHGrentChange:       "Rent Change",
+	RRCHGnewAgreement:     "New Agreement",
+	RRCHGexpiredAgreement: "Expired Agreement",
+	RRCHGstatusChange:     "Status Change",
+}
+
+// RentRollChange is a single difference between two rent roll snapshots
+type RentRollChange struct {
+	Type         int64   // one of the RRCHG values
+	RID          int64   // rentable affected (the "from" rentable for transfers)
+	RentableName string  // name of RID
+	ToRID        int64   // for transfers, the rentable moved to
+	ToRentable   string  // name of ToRID
+	RAID         int64   // rental agreement involved
+	Payors       string  // payor names
+	Old          string  // description of the old value
+	New          string  // description of the new value
+	OldRent      float64 // contract rent before the change
+	NewRent      float64 // contract rent after the change
+	RentDelta    float64 // NewRent - OldRent
+}
+
+// RentRollDiff is the set of changes between two rent roll dates along with
+// the totals on each date.
+type RentRollDiff struct {
+	Dt1            time.Time        // first as-of date
+	Dt2            time.Time        // second as-of date
+	Changes        []RentRollChange // list of changes, sorted by type then rentable
+	GSR1           float64          // total GSR on Dt1
+	GSR2           float64          // total GSR on Dt2
+	ScheduledRent1 float64          // total contract rent on Dt1
+	ScheduledRent2 float64          // total contract rent on Dt2
+}
+
+// GetRentRollSnapshot builds the rent roll for the business as of dt.
+//
+// INPUTS
+//  ctx  - db context
+//  xbiz - the business, InitBizInternals must have been called
+//  dt   - the as-of date
+//
+// RETURNS
+//  the snapshot
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetRentRollSnapshot(ctx context.Context, xbiz *XBusiness, dt *time.Time) (RentRollSnapshot, error) {
+	var m = RentRollSnapshot{}
+	d1 := *dt
+	d2 := d1.AddDate(0, 0, 1)
+
+	rows, err := RRdb.Prepstmt.GetAllRentablesByBusiness.Query(xbiz.P.BID)
+	if err != nil {
+		return m, err
+	}
+	defer rows.Close()
+
+	var rlist []Rentable
+	for rows.Next() {
+		var r Rentable
+		if err = ReadRentables(rows, &r); err != nil {
+			return m, err
+		}
+		rlist = append(rlist, r)
+	}
+	if err = rows.Err(); err != nil {
+		return m, err
+	}
+
+	for i := 0; i < len(rlist); i++ {
+		e := RentRollSnapshotEntry{RID: rlist[i].RID, RentableName: rlist[i].RentableName}
+		rtr, err := GetRentableTypeRefForDate(ctx, e.RID, &d1)
+		if err != nil {
+			return m, err
+		}
+		if rtr.RTID == 0 {
+			continue // rentable was not defined on this date
+		}
+		e.RTID = rtr.RTID
+		e.RentableType = xbiz.RT[rtr.RTID].Name
+
+		if e.GSR, err = GetRentableMarketRate(ctx, xbiz, e.RID, &d1, &d2); err != nil {
+			return m, err
+		}
+
+		rs, err := GetRentableStatusByRange(ctx, e.RID, &d1, &d2)
+		if err != nil {
+			return m, err
+		}
+		if len(rs) > 0 {
+			e.UseStatus = rs[0].UseStatus
+			e.LeaseStatus = rs[0].LeaseStatus
+		}
+
+		rar, err := GetAgreementsForRentable(ctx, e.RID, &d1, &d2)
+		if err != nil {
+			return m, err
+		}
+		if len(rar) > 0 {
+			e.RAID = rar[0].RAID
+			if err = rrSnapshotPayors(ctx, &e, &d1, &d2); err != nil {
+				return m, err
+			}
+			if err = rrSnapshotRent(ctx, xbiz, &e, &d1, &d2); err != nil {
+				return m, err
+			}
+		}
+		m[e.RID] = e
+	}
+	return m, nil
+}
+
+// rrSnapshotPayors fills in the payor information for e.RAID
+//-----------------------------------------------------------------------------
+func rrSnapshotPayors(ctx context.Context, e *RentRollSnapshotEntry, d1, d2 *time.Time) error {
+	p, err := GetRentalAgreementPayorsInRange(ctx, e.RAID, d1, d2)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for i := 0; i < len(p); i++ {
+		var t Transactant
+		if err = GetTransactant(ctx, p[i].TCID, &t); err != nil {
+			return err
+		}
+		e.Payors = append(e.Payors, p[i].TCID)
+		names = append(names, t.GetUserName())
+	}
+	sort.Slice(e.Payors, func(i, j int) bool { return e.Payors[i] < e.Payors[j] })
+	sort.Strings(names)
+	e.PayorNames = strings.Join(names, ", ")
+	return nil
+}
+
+// rrSnapshotRent computes the contract rent for the agreement on e from the
+// recurring rent assessments in effect on d1.
+//-----------------------------------------------------------------------------
+func rrSnapshotRent(ctx context.Context, xbiz *XBusiness, e *RentRollSnapshotEntry, d1, d2 *time.Time) error {
+	a, err := GetAllRentableAssessments(ctx, e.RID, d1, d2)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i].PASMID != 0 || a[i].RentCycle == 0 || a[i].RAID != e.RAID || a[i].FLAGS&(1<<2) != 0 {
+			continue // only unreversed recurring definitions for this agreement
+		}
+		ar, ok := RRdb.BizTypes[xbiz.P.BID].AR[a[i].ARID]
+		if !ok || ar.FLAGS&(1<<ARIsRentASM) == 0 {
+			continue
+		}
+		e.ContractRent += a[i].Amount.Float()
+	}
+	e.ContractRent = RoundToCent(e.ContractRent)
+	return nil
+}
+
+// rrStatusString returns a description of the status of e
+//-----------------------------------------------------------------------------
+func rrStatusString(e *RentRollSnapshotEntry) string {
+	return UseStatusString(e.UseStatus) + " / " + LeaseStatusString(e.LeaseStatus)
+}
+
+// DiffRentRollSnapshots compares two rent roll snapshots and returns the
+// list of changes needed to get from a to b. Moves are tracked by payor: a
+// payor who is on a rentable in a but not on any rentable in b has moved out,
+// a payor on a different rentable in b has transferred.
+//
+// INPUTS
+//  a - the earlier snapshot
+//  b - the later snapshot
+//
+// RETURNS
+//  the list of changes sorted by type then rentable name
+//-----------------------------------------------------------------------------
+func DiffRentRollSnapshots(a, b RentRollSnapshot) []RentRollChange {
+	var (
+		m    []RentRollChange
+		seen = map[string]bool{}
+	)
+	add := func(c RentRollChange) {
+		key := fmt.Sprintf("%d.%d.%d.%d", c.Type, c.RID, c.ToRID, c.RAID)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		c.RentDelta = RoundToCent(c.NewRent - c.OldRent)
+		m = append(m, c)
+	}
+
+	payorRIDs := func(s RentRollSnapshot) map[int64][]int64 {
+		p := map[int64][]int64{}
+		for rid, e := range s {
+			for _, tcid := range e.Payors {
+				p[tcid] = append(p[tcid], rid)
+			}
+		}
+		for tcid := range p {
+			sort.Slice(p[tcid], func(i, j int) bool { return p[tcid][i] < p[tcid][j] })
+		}
+		return p
+	}
+	pa := payorRIDs(a)
+	pb := payorRIDs(b)
+	var tcids []int64
+	for tcid := range pa {
+		tcids = append(tcids, tcid)
+	}
+	for tcid := range pb {
+		if _, ok := pa[tcid]; !ok {
+			tcids = append(tcids, tcid)
+		}
+	}
+	sort.Slice(tcids, func(i, j int) bool { return tcids[i] < tcids[j] })
+
+	//------------------------------------------------------------------
+	// moves, tracked by payor. A payor can be on several rentables.
+	// The rentables a payor left are paired, in RID order, with the
+	// rentables the payor arrived on as transfers; any left over are
+	// move outs or move ins.
+	//------------------------------------------------------------------
+	for _, tcid := range tcids {
+		left := rrRIDsNotIn(pa[tcid], pb[tcid])
+		arrived := rrRIDsNotIn(pb[tcid], pa[tcid])
+		for i := 0; i < len(left) || i < len(arrived); i++ {
+			switch {
+			case i < len(left) && i < len(arrived):
+				ea, eb := a[left[i]], b[arrived[i]]
+				add(RentRollChange{Type: RRCHGtransfer, RID: ea.RID, RentableName: ea.RentableName, ToRID: eb.RID, ToRentable: eb.RentableName, RAID: eb.RAID, Payors: eb.PayorNames, OldRent: ea.ContractRent, NewRent: eb.ContractRent})
+			case i < len(left):
+				ea := a[left[i]]
+				add(RentRollChange{Type: RRCHGmoveOut, RID: ea.RID, RentableName: ea.RentableName, RAID: ea.RAID, Payors: ea.PayorNames, OldRent: ea.ContractRent})
+			default:
+				eb := b[arrived[i]]
+				add(RentRollChange{Type: RRCHGmoveIn, RID: eb.RID, RentableName: eb.RentableName, RAID: eb.RAID, Payors: eb.PayorNames, NewRent: eb.ContractRent})
+			}
+		}
+	}
+
+	//------------------------------
+	// agreements
+	//------------------------------
+	raidA := map[int64]RentRollSnapshotEntry{}
+	raidB := map[int64]RentRollSnapshotEntry{}
+	for _, e := range a {
+		if p, ok := raidA[e.RAID]; e.RAID > 0 && (!ok || e.RID < p.RID) {
+			raidA[e.RAID] = e // an agreement on several rentables is reported on its lowest RID
+		}
+	}
+	for _, e := range b {
+		if p, ok := raidB[e.RAID]; e.RAID > 0 && (!ok || e.RID < p.RID) {
+			raidB[e.RAID] = e // an agreement on several rentables is reported on its lowest RID
+		}
+	}
+	for raid, e := range raidA {
+		if _, ok := raidB[raid]; !ok {
+			add(RentRollChange{Type: RRCHGexpiredAgreement, RID: e.RID, RentableName: e.RentableName, RAID: raid, Payors: e.PayorNames, OldRent: e.ContractRent})
+		}
+	}
+	for raid, e := range raidB {
+		if _, ok := raidA[raid]; !ok {
+			add(RentRollChange{Type: RRCHGnewAgreement, RID: e.RID, RentableName: e.RentableName, RAID: raid, Payors: e.PayorNames, NewRent: e.ContractRent})
+		}
+	}
+
+	//------------------------------
+	// per rentable changes
+	//------------------------------
+	for rid, ea := range a {
+		eb, ok := b[rid]
+		if !ok {
+			continue
+		}
+		if ea.RAID > 0 && ea.RAID == eb.RAID && ea.ContractRent != eb.ContractRent {
+			add(RentRollChange{Type: RRCHGrentChange, RID: rid, RentableName: ea.RentableName, RAID: ea.RAID, Payors: eb.PayorNames,
+				Old: fmt.Sprintf("%.2f", ea.ContractRent), New: fmt.Sprintf("%.2f", eb.ContractRent), OldRent: ea.ContractRent, NewRent: eb.ContractRent})
+		}
+		if ea.UseStatus != eb.UseStatus || ea.LeaseStatus != eb.LeaseStatus {
+			add(RentRollChange{Type: RRCHGstatusChange, RID: rid, RentableName: ea.RentableName, RAID: eb.RAID, Payors: eb.PayorNames,
+				Old: rrStatusString(&ea), New: rrStatusString(&eb)})
+		}
+	}
+
+	sort.Slice(m, func(i, j int) bool {
+		if m[i].Type != m[j].Type {
+			return m[i].Type < m[j].Type
+		}
+		if m[i].RentableName != m[j].RentableName {
+			return m[i].RentableName < m[j].RentableName
+		}
+		if m[i].RAID != m[j].RAID {
+			return m[i].RAID < m[j].RAID
+		}
+		return m[i].ToRID < m[j].ToRID
+	})
+	return m
+}
+
+// rrRIDsNotIn returns the RIDs in a that are not in b, in the order of a
+//-----------------------------------------------------------------------------
+func rrRIDsNotIn(a, b []int64) []int64 {
+	var m []int64
+	for i := 0; i < len(a); i++ {
+		found := false
+		for j := 0; j < len(b); j++ {
+			if a[i] == b[j] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			m = append(m, a[i])
+		}
+	}
+	return m
+}
+
+// GetRentRollDiff compares the rent roll for the business on d1 with the rent
+// roll on d2.
+//
+// INPUTS
+//  ctx  - db context
+//  xbiz - the business, InitBizInternals must have been called
+//  d1   - first as-of date
+//  d2   - second as-of date
+//
+// RETURNS
+//  the changes and the GSR / scheduled rent totals on each date
+//  any error encountered
+//-----------------------------------------------------------------------------
+func GetRentRollDiff(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time) (RentRollDiff, error) {
+	var d = RentRollDiff{Dt1: *d1, Dt2: *d2}
+	a, err := GetRentRollSnapshot(ctx, xbiz, d1)
+	if err != nil {
+		return d, err
+	}
+	b, err := GetRentRollSnapshot(ctx, xbiz, d2)
+	if err != nil {
+		return d, err
+	}
+	for _, e := range a {
+		d.GSR1 += e.GSR
+		d.ScheduledRent1 += e.ContractRent
+	}
+	for _, e := range b {
+		d.GSR2 += e.GSR
+		d.ScheduledRent2 += e.ContractRent
+	}
+	d.GSR1 = RoundToCent(d.GSR1)
+	d.GSR2 = RoundToCent(d.GSR2)
+	d.ScheduledRent1 = RoundToCent(d.ScheduledRent1)
+	d.ScheduledRent2 = RoundToCent(d.ScheduledRent2)
+	d.Changes = DiffRentRollSnapshots(a, b)
+	return d, nil
+}
@@ -0,0 +1,114 @@
+package rlib
+
+import "testing"
+
+// Rent roll diff tests. Snapshot a is the earlier date, b the later.
+
+func TestDiffRentRollSnapshots(t *testing.T) {
+	a := RentRollSnapshot{
+		1: {RID: 1, RentableName: "101", RAID: 10, Payors: []int64{100}, ContractRent: 1000, UseStatus: 1, LeaseStatus: 5}, // moves out
+		2: {RID: 2, RentableName: "102", RAID: 20, Payors: []int64{200}, ContractRent: 1100, UseStatus: 1, LeaseStatus: 5}, // transfers to 104
+		3: {RID: 3, RentableName: "103", RAID: 30, Payors: []int64{300}, ContractRent: 1200, UseStatus: 1, LeaseStatus: 5}, // rent change
+		4: {RID: 4, RentableName: "104", UseStatus: 1, LeaseStatus: 2},                                                     // vacant
+		5: {RID: 5, RentableName: "105", UseStatus: 1, LeaseStatus: 2},                                                     // vacant, move in
+		6: {RID: 6, RentableName: "106", UseStatus: 1, LeaseStatus: 2},                                                     // status only
+	}
+	b := RentRollSnapshot{
+		1: {RID: 1, RentableName: "101", UseStatus: 1, LeaseStatus: 2},
+		2: {RID: 2, RentableName: "102", UseStatus: 1, LeaseStatus: 2},
+		3: {RID: 3, RentableName: "103", RAID: 30, Payors: []int64{300}, ContractRent: 1250, UseStatus: 1, LeaseStatus: 5},
+		4: {RID: 4, RentableName: "104", RAID: 40, Payors: []int64{200}, ContractRent: 1300, UseStatus: 1, LeaseStatus: 5},
+		5: {RID: 5, RentableName: "105", RAID: 50, Payors: []int64{500}, ContractRent: 900, UseStatus: 1, LeaseStatus: 5},
+		6: {RID: 6, RentableName: "106", UseStatus: 6, LeaseStatus: 6},
+	}
+
+	var expect = []struct {
+		Type  int64
+		RID   int64
+		ToRID int64
+		RAID  int64
+		Delta float64
+	}{
+		{RRCHGmoveIn, 5, 0, 50, 900},
+		{RRCHGmoveOut, 1, 0, 10, -1000},
+		{RRCHGtransfer, 2, 4, 40, 200},
+		{RRCHGrentChange, 3, 0, 30, 50},
+		{RRCHGnewAgreement, 4, 0, 40, 1300},
+		{RRCHGnewAgreement, 5, 0, 50, 900},
+		{RRCHGexpiredAgreement, 1, 0, 10, -1000},
+		{RRCHGexpiredAgreement, 2, 0, 20, -1100},
+		{RRCHGstatusChange, 1, 0, 0, 0},
+		{RRCHGstatusChange, 2, 0, 0, 0},
+		{RRCHGstatusChange, 4, 0, 40, 0},
+		{RRCHGstatusChange, 5, 0, 50, 0},
+		{RRCHGstatusChange, 6, 0, 0, 0},
+	}
+
+	m := DiffRentRollSnapshots(a, b)
+	for i := 0; i < len(m); i++ {
+		t.Logf("%-17s RID=%d ToRID=%d RAID=%d delta=%.2f\n", RRChangeTypeNames[m[i].Type], m[i].RID, m[i].ToRID, m[i].RAID, m[i].RentDelta)
+	}
+	if len(m) != len(expect) {
+		t.Fatalf("DiffRentRollSnapshots: expect %d changes, got %d\n", len(expect), len(m))
+	}
+	for i := 0; i < len(expect); i++ {
+		if m[i].Type != expect[i].Type || m[i].RID != expect[i].RID || m[i].ToRID != expect[i].ToRID || m[i].RAID != expect[i].RAID || m[i].RentDelta != expect[i].Delta {
+			t.Errorf("DiffRentRollSnapshots: change %d, expect %s RID=%d ToRID=%d RAID=%d delta=%.2f, got %s RID=%d ToRID=%d RAID=%d delta=%.2f\n", i,
+				RRChangeTypeNames[expect[i].Type], expect[i].RID, expect[i].ToRID, expect[i].RAID, expect[i].Delta,
+				RRChangeTypeNames[m[i].Type], m[i].RID, m[i].ToRID, m[i].RAID, m[i].RentDelta)
+		}
+	}
+}
+
+// A payor can be on more than one rentable. The result must not depend on
+// map iteration order, so the diff is run several times.
+func TestDiffRentRollMultiRentablePayor(t *testing.T) {
+	a := RentRollSnapshot{
+		7:  {RID: 7, RentableName: "107", RAID: 70, Payors: []int64{700}, ContractRent: 500, UseStatus: 1, LeaseStatus: 5},  // transfers to 109
+		8:  {RID: 8, RentableName: "108", RAID: 70, Payors: []int64{700}, ContractRent: 600, UseStatus: 1, LeaseStatus: 5},  // stays
+		9:  {RID: 9, RentableName: "109", UseStatus: 1, LeaseStatus: 2},                                                     // vacant
+		10: {RID: 10, RentableName: "110", RAID: 80, Payors: []int64{800}, ContractRent: 300, UseStatus: 1, LeaseStatus: 5}, // moves out
+		11: {RID: 11, RentableName: "111", RAID: 80, Payors: []int64{800}, ContractRent: 400, UseStatus: 1, LeaseStatus: 5}, // moves out
+	}
+	b := RentRollSnapshot{
+		7:  {RID: 7, RentableName: "107", UseStatus: 1, LeaseStatus: 2},
+		8:  {RID: 8, RentableName: "108", RAID: 70, Payors: []int64{700}, ContractRent: 600, UseStatus: 1, LeaseStatus: 5},
+		9:  {RID: 9, RentableName: "109", RAID: 70, Payors: []int64{700}, ContractRent: 550, UseStatus: 1, LeaseStatus: 5},
+		10: {RID: 10, RentableName: "110", UseStatus: 1, LeaseStatus: 2},
+		11: {RID: 11, RentableName: "111", UseStatus: 1, LeaseStatus: 2},
+	}
+
+	var expect = []struct {
+		Type  int64
+		RID   int64
+		ToRID int64
+		RAID  int64
+		Delta float64
+	}{
+		{RRCHGmoveOut, 10, 0, 80, -300},
+		{RRCHGmoveOut, 11, 0, 80, -400},
+		{RRCHGtransfer, 7, 9, 70, 50},
+		{RRCHGexpiredAgreement, 10, 0, 80, -300},
+		{RRCHGstatusChange, 7, 0, 0, 0},
+		{RRCHGstatusChange, 9, 0, 70, 0},
+		{RRCHGstatusChange, 10, 0, 0, 0},
+		{RRCHGstatusChange, 11, 0, 0, 0},
+	}
+
+	for n := 0; n < 20; n++ {
+		m := DiffRentRollSnapshots(a, b)
+		if len(m) != len(expect) {
+			for i := 0; i < len(m); i++ {
+				t.Logf("%-17s RID=%d ToRID=%d RAID=%d delta=%.2f\n", RRChangeTypeNames[m[i].Type], m[i].RID, m[i].ToRID, m[i].RAID, m[i].RentDelta)
+			}
+			t.Fatalf("DiffRentRollSnapshots: run %d, expect %d changes, got %d\n", n, len(expect), len(m))
+		}
+		for i := 0; i < len(expect); i++ {
+			if m[i].Type != expect[i].Type || m[i].RID != expect[i].RID || m[i].ToRID != expect[i].ToRID || m[i].RAID != expect[i].RAID || m[i].RentDelta != expect[i].Delta {
+				t.Fatalf("DiffRentRollSnapshots: run %d, change %d, expect %s RID=%d ToRID=%d RAID=%d delta=%.2f, got %s RID=%d ToRID=%d RAID=%d delta=%.2f\n", n, i,
+					RRChangeTypeNames[expect[i].Type], expect[i].RID, expect[i].ToRID, expect[i].RAID, expect[i].Delta,
+					RRChangeTypeNames[m[i].Type], m[i].RID, m[i].ToRID, m[i].RAID, m[i].RentDelta)
+			}
+		}
+	}
+}
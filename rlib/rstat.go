@@ -134,9 +134,9 @@ func RStat(ctx context.Context, bid, rid int64, gaps []Period) ([]RStatInfo, err
 // RETURN
 //  the amount of GSR for the period
 //-----------------------------------------------------------------------------
-func VacancyGSR(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time.Time) (float64, error) {
+func VacancyGSR(ctx context.Context, xbiz *XBusiness, rid int64, d1, d2 *time.Time) (Money, error) {
 	var err error
-	amt := float64(0)
+	var amt Money
 	// Console("*** Calling VacancyDetect: %s - %s, rid = %d\n", d1.Format(RRDATEFMTSQL), d2.Format(RRDATEFMTSQL), rid)
 	m, err := VacancyDetect(ctx, xbiz, d1, d2, rid)
 	if err != nil {
@@ -48,6 +48,7 @@ func getSecDepCachedBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *Balan
 	b, ok := secdepcache[k]
 	if ok {
 		if b == nil {
+			SecDepBalCacheCtx.miss()
 			return nil
 		}
 		t := time.Now().Add(SecDepBalCacheCtx.Expiry) // it's life gets extended
@@ -56,8 +57,10 @@ func getSecDepCachedBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *Balan
 		secdepcache[k].expire = &t                    // <<<<<<<<<<<<<<<  do the cache update
 		SecDepBalCacheCtx.SemAck <- 1                 // tell the controller we're done
 		// Console("Expire updated: %s,  key = %s\n", t.Format(RRDATETIMEINPFMT), k)
+		SecDepBalCacheCtx.hit()
 		return b
 	}
+	SecDepBalCacheCtx.miss()
 	return nil
 }
 
@@ -75,7 +78,7 @@ func getSecDepCachedBalanceEntry(bid, rid, raid int64, d1, d2 *time.Time) *Balan
 // RETURNS
 //  nothing
 //-----------------------------------------------------------------------------
-func storeSecDepBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begin, end float64) {
+func storeSecDepBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begin, end Money) {
 	t := time.Now().Add(SecDepBalCacheCtx.Expiry) // it gets this much time
 	b := BalanceCacheEntry{
 		bid:    bid,
@@ -89,8 +92,11 @@ func storeSecDepBalanceInfoToCache(bid, rid, raid int64, d1, d2 *time.Time, begi
 	}
 	k := getCacheKey(bid, rid, raid, d1, d2)
 	// Console("Expire: %s,  key: %s\n", t.Format(RRDATETIMEINPFMT), k)
-	SecDepBalCacheCtx.Sem <- 1    // request write access
-	<-SecDepBalCacheCtx.SemAck    // pause until we get access
+	SecDepBalCacheCtx.Sem <- 1 // request write access
+	<-SecDepBalCacheCtx.SemAck // pause until we get access
+	if secdepcache[k] == nil {
+		SecDepBalCacheCtx.added()
+	}
 	secdepcache[k] = &b           // <<<<<<<<<<<<<<<    do the cache update
 	SecDepBalCacheCtx.SemAck <- 1 // tell the controller we're done
 }
@@ -114,8 +120,11 @@ func CleanSecDepBalanceInfoCache(force bool) {
 			continue
 		}
 		if force || now.After(*v.expire) {
-			SecDepBalCacheCtx.Sem <- 1    // request write access
-			<-SecDepBalCacheCtx.SemAck    // pause until we get access
+			SecDepBalCacheCtx.Sem <- 1 // request write access
+			<-SecDepBalCacheCtx.SemAck // pause until we get access
+			if secdepcache[k] != nil {
+				SecDepBalCacheCtx.removed()
+			}
 			secdepcache[k] = nil          // <<<<<<<<<<<<<<<  do the cache update
 			SecDepBalCacheCtx.SemAck <- 1 // tell the controller we're done
 		}
@@ -150,10 +159,10 @@ func PrintSecDepBalCache() {
 //  d2   - stop time; do not considder assessments on or after this date
 //
 // RETURNS
-// float64 - Amount of change in Security Deposit Balance between d1 and d2
+// Money   - Amount of change in Security Deposit Balance between d1 and d2
 //   error - any error encountered
 //-----------------------------------------------------------------------------
-func GetSecDepBalance(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Time) (float64, error) {
+func GetSecDepBalance(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Time) (Money, error) {
 	//-------------------------------
 	// first, check the cache...
 	//-------------------------------
@@ -162,8 +171,7 @@ func GetSecDepBalance(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Ti
 		return b.begin, nil
 	}
 
-	amt := float64(0)
-	sa := []string{}
+	amt := Money(0)
 	m, err := SecDepRules(bid)
 	if err != nil {
 		return amt, fmt.Errorf("Error in SecDepRules: %s", err.Error())
@@ -171,32 +179,118 @@ func GetSecDepBalance(ctx context.Context, bid, raid, rid int64, d1, d2 *time.Ti
 	if len(m) == 0 {
 		return amt, fmt.Errorf("There are no account rules that credit a %s account", LiabilitySecDep)
 	}
-	//-----------------------------------------------------------
-	// What Assessments use the account rules found above?
-	//-----------------------------------------------------------
-	sa = []string{}
-	for i := 0; i < len(m); i++ {
-		sa = append(sa, fmt.Sprintf("ARID=%d", m[i]))
+	if amt, err = secDepAssessed(bid, raid, rid, m, d1, d2); err != nil {
+		return amt, err
+	}
+	storeSecDepBalanceInfoToCache(bid, rid, raid, d1, d2, amt /* this is the val we'll use */, amt /*placeholder*/) // cache this value, maybe we'll hit it again
+	return amt, nil
+
+}
+
+// secDepAssessed returns the total of the assessments for Rentable rid in
+// Rental Agreement raid that use one of the account rules in rules and that
+// fall in the range d1 to d2.
+//-----------------------------------------------------------------------------
+func secDepAssessed(bid, raid, rid int64, rules []int64, d1, d2 *time.Time) (Money, error) {
+	amt := Money(0)
+	sa := []string{}
+	for i := 0; i < len(rules); i++ {
+		sa = append(sa, fmt.Sprintf("ARID=%d", rules[i]))
 	}
 	q := fmt.Sprintf("SELECT SUM(Amount) AS Amt FROM Assessments WHERE BID=%d AND RID=%d and RAID=%d AND %q<=Start AND Stop<%q AND (%s)",
 		bid, rid, raid, d1.Format(RRDATEFMTSQL), d2.Format(RRDATEFMTSQL), strings.Join(sa, " OR "))
 	// Console("=======>>>>>>  q:  %s\n", q)
 	rows, err := RRdb.Dbrr.Query(q)
+	if err != nil {
+		return amt, err
+	}
+	defer rows.Close()
 	for rows.Next() {
-		var x NullFloat64
+		var x Money // SUM is NULL if there are no assessments
 		err := rows.Scan(&x)
 		if err != nil {
 			return amt, err
 		}
-		amt += x.Float64
+		amt += x
 	}
-	err = rows.Err()
+	return amt, rows.Err()
+}
+
+// secDepAcctRules returns the security deposit account rules of business
+// bid that credit or debit the GLAccount lid.
+//-----------------------------------------------------------------------------
+func secDepAcctRules(bid, lid int64) ([]int64, error) {
+	var m []int64
+	rules, err := SecDepRules(bid)
 	if err != nil {
-		return amt, err
+		return m, err
 	}
-	storeSecDepBalanceInfoToCache(bid, rid, raid, d1, d2, amt /* this is the val we'll use */, amt /*placeholder*/) // cache this value, maybe we'll hit it again
-	return amt, nil
+	for i := 0; i < len(rules); i++ {
+		ar, ok := RRdb.BizTypes[bid].AR[rules[i]]
+		if ok && (ar.CreditLID == lid || ar.DebitLID == lid) {
+			m = append(m, rules[i])
+		}
+	}
+	return m, nil
+}
+
+// GetSecDepAcctBalanceOnDate returns the balance of the security deposit
+// GLAccount lid for the supplied Rental Agreement and Rentable on dt. The
+// balance starts from the latest LedgerMarker for lid, raid, rid on or before
+// dt. If there is no such marker it starts from 0 on TIME0.
+//
+// PARAMS
+//	bid  - business id
+//  lid  - the security deposit account
+//  raid - the Rental Agreement associated with the assessment
+//  rid  - the rentable for which the deposit was assessed
+//  dt   - the balance is for this date
+//
+// RETURNS
+// Money   - the security deposit balance on dt
+//   error - any error encountered
+//-----------------------------------------------------------------------------
+func GetSecDepAcctBalanceOnDate(ctx context.Context, bid, lid, raid, rid int64, dt *time.Time) (Money, error) {
+	lm, err := GetRARAcctLedgerMarkerOnOrBefore(ctx, bid, lid, raid, rid, dt)
+	if err != nil {
+		return Money(0), err
+	}
+	if lm.LMID == 0 {
+		lm.Dt = TIME0
+	}
+	m, err := secDepAcctRules(bid, lid)
+	if err != nil || len(m) == 0 {
+		return lm.Balance, err
+	}
+	amt, err := secDepAssessed(bid, raid, rid, m, &lm.Dt, dt)
+	return lm.Balance + amt, err
+}
 
+// GetSecDepBalanceOnDate returns the security deposit balance for the
+// supplied Rental Agreement and Rentable on dt.  It is the sum of the
+// balances of all the security deposit accounts of the business.
+//
+// PARAMS
+//	bid  - business id
+//  raid - the Rental Agreement associated with the assessment
+//  rid  - the rentable for which the deposit was assessed
+//  dt   - the balance is for this date
+//
+// RETURNS
+// Money   - the security deposit balance on dt
+//   error - any error encountered
+//-----------------------------------------------------------------------------
+func GetSecDepBalanceOnDate(ctx context.Context, bid, raid, rid int64, dt *time.Time) (Money, error) {
+	bal := Money(0)
+	sda := GetSecurityDepositsAccounts(bid)
+	for i := 0; i < len(sda); i++ {
+		b, err := GetSecDepAcctBalanceOnDate(ctx, bid, sda[i], raid, rid, dt)
+		if err != nil {
+			return bal, err
+		}
+		bal += b
+	}
+	return bal, nil
 }
 
 // GetSecDepBalanceOnDate
@@ -94,9 +94,6 @@ var ReqSessionMemAck chan int
 // expired sessions.
 var SessionCleanupTime time.Duration
 
-// sessions is the session list managed by this code
-var sessions map[string]*Session
-
 // SessionTimeout defines how long a session can remain idle before it expires.
 var SessionTimeout time.Duration // in minutes
 
@@ -112,6 +109,7 @@ func GetSessionCookieName() string {
 }
 
 // SessionDispatcher is a Go routine that controls access to shared memory.
+// The in-memory session store uses it to control access to its session list.
 //-----------------------------------------------------------------------------
 func SessionDispatcher() {
 	for {
@@ -123,36 +121,25 @@ func SessionDispatcher() {
 	}
 }
 
-// SessionCleanup a Go routine to periodically spin through the session list
-// and remove any sessions which have timed out.
+// SessionCleanup a Go routine to periodically ask the session store to
+// remove any sessions which have timed out.
 //-----------------------------------------------------------------------------
 func SessionCleanup() {
 	for {
 		select {
 		case <-time.After(SessionCleanupTime * time.Minute):
-			ReqSessionMem <- 1                 // ask to access the shared mem, blocks until granted
-			<-ReqSessionMemAck                 // make sure we got it
-			ss := make(map[string]*Session, 0) // here's the new Session list
-			n := 0                             // total number removed
-			now := time.Now()                  // this is the timestamp we'll compare against
-			// Console("Cleanup time: %s\n", now.Format(RRDATETIMEINPFMT))
-			for k, v := range sessions { // look at every Session
-				// Console("Found session: %s, expire time: %s\n", v.Name, v.Expire.Format(RRDATETIMEINPFMT))
-				if now.After(v.Expire) { // if it's still active...
-					n++ // removed another
-				} else {
-					ss[k] = v // ...copy it to the new list
-				}
+			now := time.Now() // this is the timestamp we'll compare against
+			if _, err := sessStore.RemoveExpired(&now); err != nil {
+				LogAndPrintError("SessionCleanup", err)
 			}
-			sessions = ss         // set the new list
-			ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
-			// Console("SessionCleanup completed. %d removed. Current Session list size = %d\n", n, len(sessions))
+			// Console("SessionCleanup completed. %d removed.\n", n)
 		}
 	}
 }
 
 // SessionInit must be called prior to using the session subsystem. It
-// initializes structures and starts the dispatcher
+// initializes structures and starts the dispatcher. Sessions are kept in
+// memory unless a different store was set with SetSessionStore.
 //
 // INPUT
 //  timeout - the number of minutes before a session times out
@@ -161,7 +148,9 @@ func SessionCleanup() {
 //  nothing at this time
 //-----------------------------------------------------------------------------
 func SessionInit(timeout int) {
-	sessions = make(map[string]*Session)
+	if sessStore == nil {
+		sessStore = NewMemSessionStore()
+	}
 	ReqSessionMem = make(chan int)
 	ReqSessionMemAck = make(chan int)
 	SessionCleanupTime = time.Duration(1)
@@ -182,7 +171,11 @@ func SessionInit(timeout int) {
 //  bool    - true if the session was found, false otherwise
 //-----------------------------------------------------------------------------
 func SessionGet(token string) (*Session, bool) {
-	s, ok := sessions[token]
+	s, ok, err := sessStore.Get(token)
+	if err != nil {
+		LogAndPrintError("SessionGet", err)
+		return nil, false
+	}
 	return s, ok
 }
 
@@ -205,11 +198,31 @@ func (s *Session) ToString() string {
 //  a string representation of the session entry
 //-----------------------------------------------------------------------------
 func DumpSessions() {
-	i := 0
-	for _, v := range sessions {
-		Console("%2d. %s\n", i, v.ToString())
-		i++
+	ss, err := sessStore.List()
+	if err != nil {
+		LogAndPrintError("DumpSessions", err)
+	}
+	for i := 0; i < len(ss); i++ {
+		Console("%2d. %s\n", i, ss[i].ToString())
+	}
+}
+
+// SessionCount returns the number of sessions in the session list that
+// have not expired.
+//-----------------------------------------------------------------------------
+func SessionCount() int {
+	n := 0
+	now := time.Now()
+	ss, err := sessStore.List()
+	if err != nil {
+		LogAndPrintError("SessionCount", err)
 	}
+	for i := 0; i < len(ss); i++ {
+		if ss[i].Expire.After(now) {
+			n++
+		}
+	}
+	return n
 }
 
 // SessionNew creates a new session and adds it to the session list
@@ -238,11 +251,9 @@ func SessionNew(token, username, name string, uid int64, imgurl string, rid int6
 		s.ImageURL = imgurl
 	}
 
-	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
-	<-ReqSessionMemAck // make sure we got it
-	sessions[token] = s
-	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
-
+	if err := sessStore.Put(s); err != nil {
+		LogAndPrintError("SessionNew", err)
+	}
 	return s
 }
 
@@ -391,7 +402,7 @@ func GetSession(ctx context.Context, w http.ResponseWriter, r *http.Request) (*S
 		return nil, err
 	}
 	// Console("GetSession 5\n")
-	sess, ok := sessions[cookie.Value]
+	sess, ok := SessionGet(cookie.Value)
 	if !ok || sess == nil {
 		// Console("GetSession 6\n")
 		//--------------------------------------------------------
@@ -485,10 +496,9 @@ func (s *Session) Refresh(w http.ResponseWriter, r *http.Request) int {
 	cookie, err := r.Cookie(SessionCookieName)
 	if nil != cookie && err == nil {
 		cookie.Expires = time.Now().Add(SessionTimeout)
-		ReqSessionMem <- 1        // ask to access the shared mem, blocks until granted
-		<-ReqSessionMemAck        // make sure we got it
-		s.Expire = cookie.Expires // update the Session information
-		ReqSessionMemAck <- 1     // tell SessionDispatcher we're done with the data
+		if err = sessStore.SetExpire(s, &cookie.Expires); err != nil { // update the Session information
+			LogAndPrintError("Session.Refresh", err)
+		}
 		cookie.Path = "/"
 		http.SetCookie(w, cookie)
 		return 0
@@ -534,17 +544,9 @@ func SessionDelete(s *Session, w http.ResponseWriter, r *http.Request) {
 	Console("sessions before delete:\n")
 	DumpSessions()
 
-	ss := make(map[string]*Session, 0)
-
-	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
-	<-ReqSessionMemAck // make sure we got it
-	for k, v := range sessions {
-		if s.Token != k {
-			ss[k] = v
-		}
+	if err := sessStore.Delete(s.Token); err != nil {
+		LogAndPrintError("SessionDelete", err)
 	}
-	sessions = ss
-	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
 	s.ExpireCookie(w, r)
 	Console("sessions after delete:\n")
 	DumpSessions()
@@ -0,0 +1,217 @@
+package rlib
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SessionStore is where the sessions are kept. The in-memory store is the
+// default. The SQL store keeps the sessions in the database so that they
+// survive a restart and can be shared by several RentRoll servers behind a
+// load balancer.
+type SessionStore interface {
+	Get(token string) (*Session, bool, error)      // find the session with the supplied token
+	Put(s *Session) error                          // add s or replace the session with the same token
+	Delete(token string) error                     // remove the session with the supplied token
+	SetExpire(s *Session, expire *time.Time) error // change the expire time of s
+	RemoveExpired(now *time.Time) (int, error)     // remove all the sessions that expired before now
+	List() ([]*Session, error)                     // all the sessions in the store
+}
+
+// sessStore is the SessionStore in use
+var sessStore SessionStore
+
+// SetSessionStore sets the store used for sessions. It must be called before
+// SessionInit if the in-memory store is not wanted.
+//-----------------------------------------------------------------------------
+func SetSessionStore(s SessionStore) {
+	sessStore = s
+}
+
+//-----------------------------------------------------------------------------
+//  IN-MEMORY STORE
+//-----------------------------------------------------------------------------
+
+// MemSessionStore keeps the sessions in a map.  Access to the map is
+// controlled by SessionDispatcher.
+type MemSessionStore struct {
+	sessions map[string]*Session
+}
+
+// NewMemSessionStore returns a new, empty, in-memory session store
+//-----------------------------------------------------------------------------
+func NewMemSessionStore() *MemSessionStore {
+	return &MemSessionStore{sessions: make(map[string]*Session)}
+}
+
+// Get returns the session with the supplied token.
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) Get(token string) (*Session, bool, error) {
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	s, ok := m.sessions[token]
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return s, ok, nil
+}
+
+// Put adds s to the store, replacing any session with the same token
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) Put(s *Session) error {
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	m.sessions[s.Token] = s
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return nil
+}
+
+// Delete removes the session with the supplied token
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) Delete(token string) error {
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	delete(m.sessions, token)
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return nil
+}
+
+// SetExpire changes the expire time of s
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) SetExpire(s *Session, expire *time.Time) error {
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	s.Expire = *expire
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return nil
+}
+
+// RemoveExpired removes every session that expired before now
+//
+// RETURNS
+//  the number of sessions removed
+//  any error encountered
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) RemoveExpired(now *time.Time) (int, error) {
+	n := 0
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	for k, v := range m.sessions {
+		if now.After(v.Expire) {
+			delete(m.sessions, k)
+			n++
+		}
+	}
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return n, nil
+}
+
+// List returns all the sessions in the store
+//-----------------------------------------------------------------------------
+func (m *MemSessionStore) List() ([]*Session, error) {
+	var ss []*Session
+	ReqSessionMem <- 1 // ask to access the shared mem, blocks until granted
+	<-ReqSessionMemAck // make sure we got it
+	for _, v := range m.sessions {
+		ss = append(ss, v)
+	}
+	ReqSessionMemAck <- 1 // tell SessionDispatcher we're done with the data
+	return ss, nil
+}
+
+//-----------------------------------------------------------------------------
+//  SQL STORE
+//-----------------------------------------------------------------------------
+
+// SQLSessionStore keeps the sessions in the UserSession table of the
+// RentRoll database. Each call returns a new copy of the session, changes
+// must be written back through the store.
+type SQLSessionStore struct{}
+
+// NewSQLSessionStore returns a session store that uses the database. The
+// database must be initialized with InitDBHelpers before it is used.
+//-----------------------------------------------------------------------------
+func NewSQLSessionStore() *SQLSessionStore {
+	return &SQLSessionStore{}
+}
+
+// readUserSession reads a session from the supplied row
+func readUserSession(row *sql.Row, s *Session) error {
+	return row.Scan(&s.Token, &s.Username, &s.Name, &s.UID, &s.CoCode, &s.ImageURL, &s.Expire, &s.RoleID)
+}
+
+// readUserSessions reads a session from the supplied rows
+func readUserSessions(rows *sql.Rows, s *Session) error {
+	return rows.Scan(&s.Token, &s.Username, &s.Name, &s.UID, &s.CoCode, &s.ImageURL, &s.Expire, &s.RoleID)
+}
+
+// Get returns the session with the supplied token.
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) Get(token string) (*Session, bool, error) {
+	var s Session
+	err := readUserSession(RRdb.Prepstmt.GetUserSession.QueryRow(token), &s)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &s, true, nil
+}
+
+// Put adds s to the store, replacing any session with the same token
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) Put(s *Session) error {
+	_, err := RRdb.Prepstmt.InsertUserSession.Exec(s.Token, s.Username, s.Name, s.UID, s.CoCode, s.ImageURL, s.Expire, s.RoleID)
+	return err
+}
+
+// Delete removes the session with the supplied token
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) Delete(token string) error {
+	_, err := RRdb.Prepstmt.DeleteUserSession.Exec(token)
+	return err
+}
+
+// SetExpire changes the expire time of s
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) SetExpire(s *Session, expire *time.Time) error {
+	if _, err := RRdb.Prepstmt.UpdateUserSessionExpire.Exec(*expire, s.Token); err != nil {
+		return err
+	}
+	s.Expire = *expire
+	return nil
+}
+
+// RemoveExpired removes every session that expired before now. All the
+// servers sharing the database do this, which is harmless.
+//
+// RETURNS
+//  the number of sessions removed
+//  any error encountered
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) RemoveExpired(now *time.Time) (int, error) {
+	res, err := RRdb.Prepstmt.DeleteExpiredUserSessions.Exec(*now)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// List returns all the sessions in the store
+//-----------------------------------------------------------------------------
+func (m *SQLSessionStore) List() ([]*Session, error) {
+	var ss []*Session
+	rows, err := RRdb.Prepstmt.GetUserSessions.Query()
+	if err != nil {
+		return ss, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s Session
+		if err = readUserSessions(rows, &s); err != nil {
+			return ss, err
+		}
+		ss = append(ss, &s)
+	}
+	return ss, rows.Err()
+}
@@ -0,0 +1,46 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemSessionStore(t *testing.T) {
+	ReqSessionMem = make(chan int)
+	ReqSessionMemAck = make(chan int)
+	go SessionDispatcher()
+
+	m := NewMemSessionStore()
+	now := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	m.Put(&Session{Token: "a", Username: "alice", UID: 1, Expire: now.Add(time.Minute)})
+	m.Put(&Session{Token: "b", Username: "bob", UID: 2, Expire: now.Add(-time.Minute)})
+
+	if s, ok, _ := m.Get("a"); !ok || s.UID != 1 {
+		t.Errorf("MemSessionStore.Get( a ): expect UID 1, got ok = %t, s = %s\n", ok, s.ToString())
+	}
+	if _, ok, _ := m.Get("z"); ok {
+		t.Errorf("MemSessionStore.Get( z ): expect not found\n")
+	}
+
+	//-----------------------------------------------
+	// extend b so that it survives the cleanup
+	//-----------------------------------------------
+	b, _, _ := m.Get("b")
+	exp := now.Add(time.Hour)
+	m.SetExpire(b, &exp)
+	if n, _ := m.RemoveExpired(&now); n != 0 {
+		t.Errorf("MemSessionStore.RemoveExpired: expect 0 removed, got %d\n", n)
+	}
+	later := now.Add(2 * time.Minute)
+	if n, _ := m.RemoveExpired(&later); n != 1 {
+		t.Errorf("MemSessionStore.RemoveExpired: expect 1 removed, got %d\n", n)
+	}
+	if _, ok, _ := m.Get("a"); ok {
+		t.Errorf("MemSessionStore: expect a to have been removed\n")
+	}
+
+	m.Delete("b")
+	if ss, _ := m.List(); len(ss) != 0 {
+		t.Errorf("MemSessionStore.List: expect empty store, got %d sessions\n", len(ss))
+	}
+}
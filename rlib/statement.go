@@ -13,7 +13,7 @@ type RAStmtEntry struct {
 	A       *Assessment        // for type==1, the pointer to the assessment
 	R       *ReceiptAllocation // for type ==2, the pointer to the receipt
 	RNT     *Rentable          // the associated rentable, if known
-	Amt     float64            // amount of the receipt or assessment
+	Amt     Money              // amount of the receipt or assessment
 	Reverse bool               // is this a reversal?
 	Dt      time.Time          // date/time of this assessment or receipt
 	TCID    int64              // IF THIS IS FOR A PAYOR STATEMENT, the TCID of the Payor, otherwise 0
@@ -23,8 +23,8 @@ type RAStmtEntry struct {
 // a payors statement
 type ReceiptListEntry struct {
 	R           Receipt
-	Allocated   float64
-	Unallocated float64
+	Allocated   Money
+	Unallocated Money
 }
 
 // RAStmtEntries is needed to sort the array
@@ -52,9 +52,9 @@ type RAAcctBal struct {
 	DtStop     time.Time     // Period Stop -- up to but not including
 	LmStart    LedgerMarker  // this is the starting point for the calculations
 	Gap        RAStmtEntries // these entries cover the gap between the LmStart and Period DtStart
-	OpeningBal float64       // balance at the open of period DtStart
+	OpeningBal Money         // balance at the open of period DtStart
 	Stmt       RAStmtEntries // these are the actual statement entries
-	ClosingBal float64       // balance at close of period
+	ClosingBal Money         // balance at close of period
 	RAID       int64         // which RentalAgreement is this for
 }
 
@@ -86,8 +86,8 @@ func GetNameFromTransactantCache(ctx context.Context, tcid int64, payorcache map
 //      err  = any error that occurred or nil if no errors
 //
 //=============================================================================
-func GetRAIDBalance(ctx context.Context, raid int64, dt *time.Time) (float64, error) {
-	bal := float64(0)
+func GetRAIDBalance(ctx context.Context, raid int64, dt *time.Time) (Money, error) {
+	bal := Money(0)
 	lm, err := GetRALedgerMarkerOnOrBefore(ctx, raid, dt)
 	if lm.LMID == 0 {
 		err := fmt.Errorf("*** ERROR ***  could not find ledger marker for RAID %d on or before %s", raid, dt.Format(RRDATEFMTSQL))
@@ -200,8 +200,8 @@ func GetRAIDStatementInfo(ctx context.Context, raid int64, d1, d2 *time.Time) (R
 // GetRAIDAcctRange gets the assessment and receipt allocation entries for the
 // supplied time range and returns the balance of these entries.
 //=============================================================================
-func GetRAIDAcctRange(ctx context.Context, raid int64, d1, d2 *time.Time, p *RAStmtEntries) (float64, error) {
-	bal := float64(0)
+func GetRAIDAcctRange(ctx context.Context, raid int64, d1, d2 *time.Time, p *RAStmtEntries) (Money, error) {
+	bal := Money(0)
 	//----------------------------------------------------------------
 	// Total all assessments in the supplied range that involve RAID.
 	//----------------------------------------------------------------
@@ -75,7 +75,7 @@ func UpdateAssessment(ctx context.Context, a *Assessment) error {
 		Console("\n **** WARNING ****   **** WARNING ****  stop date prior to start\n\n")
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	fields := []interface{}{
 		a.PASMID,
 		a.RPASMID,
@@ -348,7 +348,7 @@ func UpdateExpense(ctx context.Context, a *Expense) error {
 		a.LastModBy = sess.UID
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	fields := []interface{}{a.RPEXPID, a.BID, a.RID, a.RAID, a.Amount, a.Dt, a.AcctRule, a.ARID, a.FLAGS, a.Comment, a.LastModBy, a.EXPID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
 		stmt := tx.Stmt(RRdb.Prepstmt.UpdateExpense)
@@ -863,7 +863,7 @@ func UpdateReceipt(ctx context.Context, a *Receipt) error {
 		a.LastModBy = sess.UID
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	fields := []interface{}{a.PRCPTID, a.BID, a.TCID, a.PMTID, a.DEPID, a.DID, a.RAID, a.Dt, a.DocNo, a.Amount, a.AcctRuleReceive, a.ARID, a.AcctRuleApply, a.FLAGS, a.Comment, a.OtherPayorName, a.LastModBy, a.RCPTID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
 		stmt := tx.Stmt(RRdb.Prepstmt.UpdateReceipt)
@@ -889,7 +889,7 @@ func UpdateReceiptAllocation(ctx context.Context, a *ReceiptAllocation) error {
 		a.LastModBy = sess.UID
 	}
 
-	a.Amount = Round(a.Amount, .5, 2)
+	a.Amount = a.Amount.Round(2)
 	fields := []interface{}{a.RCPTID, a.BID, a.RAID, a.Dt, a.Amount, a.ASMID, a.FLAGS, a.AcctRule, a.LastModBy, a.RCPAID}
 	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
 		stmt := tx.Stmt(RRdb.Prepstmt.UpdateReceiptAllocation)
@@ -1571,3 +1571,499 @@ func UpdateVehicle(ctx context.Context, a *Vehicle) error {
 	}
 	return updateError(err, "Vehicle", *a)
 }
+
+// UpdateReportSubscription updates a ReportSubscription record in the database
+func UpdateReportSubscription(ctx context.Context, a *ReportSubscription) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.ReportName, a.DateRange, a.Format, a.Recipients, a.Cycle, a.DtNext, a.DtLast, a.FLAGS, a.LastModBy, a.RSUBID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateReportSubscription)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateReportSubscription.Exec(fields...)
+	}
+	return updateError(err, "ReportSubscription", *a)
+}
+
+// UpdateWebhookSubscription updates a WebhookSubscription record in the database
+func UpdateWebhookSubscription(ctx context.Context, a *WebhookSubscription) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.URL, a.Secret, a.Events, a.FLAGS, a.LastModBy, a.WHSID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateWebhookSubscription)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateWebhookSubscription.Exec(fields...)
+	}
+	return updateError(err, "WebhookSubscription", *a)
+}
+
+// UpdateWebhookDelivery updates a WebhookDelivery record in the database
+func UpdateWebhookDelivery(ctx context.Context, a *WebhookDelivery) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.WHSID, a.BID, a.Event, a.Payload, a.Status, a.Attempts, a.DtNext, a.DtLast, a.ResponseCode, a.Message, a.LastModBy, a.WHDID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateWebhookDelivery)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateWebhookDelivery.Exec(fields...)
+	}
+	return updateError(err, "WebhookDelivery", *a)
+}
+
+// UpdatePortalUser updates a PortalUser record in the database
+func UpdatePortalUser(ctx context.Context, a *PortalUser) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.TCID, a.Username, a.PassHash, a.Salt, a.Token, a.TokenExpire, a.LastLogin, a.FailedLogins, a.FLAGS, a.LastModBy, a.PUID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdatePortalUser)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdatePortalUser.Exec(fields...)
+	}
+	return updateError(err, "PortalUser", *a)
+}
+
+// UpdateMaintRequest updates a MaintRequest record in the database
+func UpdateMaintRequest(ctx context.Context, a *MaintRequest) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.TCID, a.Dt, a.Subject, a.Descr, a.Status, a.LastModBy, a.MRQID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateMaintRequest)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateMaintRequest.Exec(fields...)
+	}
+	return updateError(err, "MaintRequest", *a)
+}
+
+// UpdateWorkOrder updates a WorkOrder record in the database
+func UpdateWorkOrder(ctx context.Context, a *WorkOrder) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RID, a.RAID, a.MRQID, a.NLID, a.Category, a.Priority, a.Status, a.AssignedUID, a.AssignedTo, a.Descr, a.DtOpen, a.DtDone, a.Cost, a.ChargeASMID, a.LastModBy, a.WOID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateWorkOrder)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateWorkOrder.Exec(fields...)
+	}
+	return updateError(err, "WorkOrder", *a)
+}
+
+// UpdateVendor updates a Vendor record in the database
+func UpdateVendor(ctx context.Context, a *Vendor) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Name, a.Contact, a.Address, a.Address2, a.City, a.State, a.PostalCode, a.Country, a.Email, a.Phone, a.TaxID, a.DefaultLID, a.Terms, a.FLAGS, a.LastModBy, a.VID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendor)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendor.Exec(fields...)
+	}
+	return updateError(err, "Vendor", *a)
+}
+
+// UpdateBill updates a Bill record in the database
+func UpdateBill(ctx context.Context, a *Bill) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.VID, a.InvoiceNo, a.Dt, a.DtDue, a.Amount, a.APLID, a.JID, a.FLAGS, a.Comment, a.LastModBy, a.BILLID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateBill)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateBill.Exec(fields...)
+	}
+	return updateError(err, "Bill", *a)
+}
+
+// UpdateVendorPayment updates a VendorPayment record in the database
+func UpdateVendorPayment(ctx context.Context, a *VendorPayment) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.VID, a.DEPID, a.Dt, a.Amount, a.Method, a.DocNo, a.JID, a.FLAGS, a.Comment, a.LastModBy, a.VPID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateVendorPayment)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateVendorPayment.Exec(fields...)
+	}
+	return updateError(err, "VendorPayment", *a)
+}
+
+// UpdateCheckAccount updates an existing CheckAccount record in the database
+func UpdateCheckAccount(ctx context.Context, a *CheckAccount) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.DEPID, a.BankName, a.BankAddress, a.RoutingNo, a.FractionalNo, a.PayerName, a.PayerAddress, a.NextCheckNo, a.PPFID, a.LastModBy, a.CKAID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCheckAccount)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCheckAccount.Exec(fields...)
+	}
+	return updateError(err, "CheckAccount", *a)
+}
+
+// UpdateBankCheck updates an existing BankCheck record in the database
+func UpdateBankCheck(ctx context.Context, a *BankCheck) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.DEPID, a.CheckNo, a.Dt, a.Payee, a.PayeeAddress, a.Amount, a.Memo, a.DebitLID, a.VPID, a.TCID, a.RAID, a.JID, a.FLAGS, a.LastModBy, a.CKID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateBankCheck)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateBankCheck.Exec(fields...)
+	}
+	return updateError(err, "BankCheck", *a)
+}
+
+// UpdatePositivePayFormat updates an existing PositivePayFormat record in the database
+func UpdatePositivePayFormat(ctx context.Context, a *PositivePayFormat) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Name, a.Header, a.Detail, a.Trailer, a.FileExt, a.FLAGS, a.LastModBy, a.PPFID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdatePositivePayFormat)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdatePositivePayFormat.Exec(fields...)
+	}
+	return updateError(err, "PositivePayFormat", *a)
+}
+
+// UpdateLeaseTemplate updates an existing LeaseTemplate record in the database
+func UpdateLeaseTemplate(ctx context.Context, a *LeaseTemplate) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RATID, a.Title, a.Body, a.LastModBy, a.LTID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateLeaseTemplate)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateLeaseTemplate.Exec(fields...)
+	}
+	return updateError(err, "LeaseTemplate", *a)
+}
+
+// UpdateLeaseDocumentsRAID moves the LeaseDocuments generated from flow
+// flowid to Rental Agreement version raid once the flow has been saved as
+// that Rental Agreement.
+func UpdateLeaseDocumentsRAID(ctx context.Context, flowid, raid int64) error {
+	var err error
+	var uid int64
+	if authProblem(ctx, &uid) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{raid, uid, flowid}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateLeaseDocumentsRAID)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateLeaseDocumentsRAID.Exec(fields...)
+	}
+	return err
+}
+
+// UpdateCAMPool updates an existing CAMPool record in the database
+func UpdateCAMPool(ctx context.Context, a *CAMPool) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Name, a.LIDs, a.EstimateARID, a.TrueUpARID, a.CreditARID, a.LastModBy, a.CAMPID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCAMPool)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCAMPool.Exec(fields...)
+	}
+	return updateError(err, "CAMPool", *a)
+}
+
+// UpdateCAMRecon updates an existing CAMRecon record in the database
+func UpdateCAMRecon(ctx context.Context, a *CAMRecon) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.CAMPID, a.DtStart, a.DtStop, a.Expenses, a.TotalSqft, a.DtPosted, a.FLAGS, a.LastModBy, a.CAMRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCAMRecon)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCAMRecon.Exec(fields...)
+	}
+	return updateError(err, "CAMRecon", *a)
+}
+
+// UpdateCAMReconItem updates an existing CAMReconItem record in the database
+func UpdateCAMReconItem(ctx context.Context, a *CAMReconItem) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.CAMRID, a.BID, a.RAID, a.RID, a.Sqft, a.Days, a.Share, a.Allocated, a.BaseYear, a.Due, a.Estimates, a.TrueUp, a.ASMID, a.LastModBy, a.CAMRIID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCAMReconItem)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCAMReconItem.Exec(fields...)
+	}
+	return updateError(err, "CAMReconItem", *a)
+}
+
+// UpdatePctRent updates an existing PctRent record in the database
+func UpdatePctRent(ctx context.Context, a *PctRent) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RAID, a.BreakpointType, a.BaseARID, a.OverageARID, a.LastModBy, a.PRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdatePctRent)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdatePctRent.Exec(fields...)
+	}
+	return updateError(err, "PctRent", *a)
+}
+
+// UpdateSalesReport updates an existing SalesReport record in the database
+func UpdateSalesReport(ctx context.Context, a *SalesReport) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RAID, a.DtStart, a.DtStop, a.GrossSales, a.Breakpoint, a.Overage, a.ASMID, a.Comment, a.FLAGS, a.LastModBy, a.SRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateSalesReport)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateSalesReport.Exec(fields...)
+	}
+	return updateError(err, "SalesReport", *a)
+}
+
+// UpdateReservation updates an existing Reservation record in the database
+func UpdateReservation(ctx context.Context, a *Reservation) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RTID, a.RID, a.TCID, a.RPID, a.DtArrive, a.DtDepart, a.Adults, a.Children, a.Rate, a.Deposit, a.RAID, a.Comment, a.FLAGS, a.LastModBy, a.RESID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateReservation)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateReservation.Exec(fields...)
+	}
+	return updateError(err, "Reservation", *a)
+}
+
+// UpdateConcession updates an existing Concession record in the database
+func UpdateConcession(ctx context.Context, a *Concession) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Name, a.Description, a.ARID, a.Amount, a.Pct, a.Cycles, a.FLAGS, a.LastModBy, a.CONID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateConcession)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateConcession.Exec(fields...)
+	}
+	return updateError(err, "Concession", *a)
+}
+
+// UpdateRentalAgreementConcession updates an existing RentalAgreementConcession record in the database
+func UpdateRentalAgreementConcession(ctx context.Context, a *RentalAgreementConcession) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RAID, a.RID, a.CONID, a.ARID, a.Amount, a.Pct, a.Cycles, a.Applied, a.DtStart, a.FLAGS, a.LastModBy, a.RACID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateRentalAgreementConcession)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateRentalAgreementConcession.Exec(fields...)
+	}
+	return updateError(err, "RentalAgreementConcession", *a)
+}
+
+// UpdateUtilityBill updates an existing UtilityBill record in the database
+func UpdateUtilityBill(ctx context.Context, a *UtilityBill) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Utility, a.ARID, a.Method, a.Amount, a.CommonPct, a.DtStart, a.DtStop, a.DtPosted, a.FLAGS, a.LastModBy, a.UBID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateUtilityBill)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateUtilityBill.Exec(fields...)
+	}
+	return updateError(err, "UtilityBill", *a)
+}
+
+// UpdateUtilityCharge updates an existing UtilityCharge record in the database
+func UpdateUtilityCharge(ctx context.Context, a *UtilityCharge) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.UBID, a.BID, a.RAID, a.RID, a.DtStart, a.DtStop, a.Days, a.Sqft, a.Occupants, a.MeterUsage, a.Ratio, a.Share, a.Amount, a.ASMID, a.LastModBy, a.UCID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateUtilityCharge)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateUtilityCharge.Exec(fields...)
+	}
+	return updateError(err, "UtilityCharge", *a)
+}
+
+// UpdateMeterReading updates an existing MeterReading record in the database
+func UpdateMeterReading(ctx context.Context, a *MeterReading) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RID, a.Utility, a.Dt, a.Reading, a.LastModBy, a.MRID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateMeterReading)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateMeterReading.Exec(fields...)
+	}
+	return updateError(err, "MeterReading", *a)
+}
+
+// UpdateAssessmentStep updates an existing AssessmentStep record in the database
+func UpdateAssessmentStep(ctx context.Context, a *AssessmentStep) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.RAID, a.ASMID, a.Dt, a.Amount, a.Pct, a.NewASMID, a.DtApplied, a.Comment, a.FLAGS, a.LastModBy, a.ASTID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateAssessmentStep)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateAssessmentStep.Exec(fields...)
+	}
+	return updateError(err, "AssessmentStep", *a)
+}
+
+// UpdatePaymentGateway updates an existing PaymentGateway record in the database
+func UpdatePaymentGateway(ctx context.Context, a *PaymentGateway) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.Name, a.URL, a.APIKey, a.PMTID, a.DEPID, a.ARID, a.FLAGS, a.LastModBy, a.PGID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdatePaymentGateway)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdatePaymentGateway.Exec(fields...)
+	}
+	return updateError(err, "PaymentGateway", *a)
+}
+
+// UpdateCardToken updates an existing CardToken record in the database
+func UpdateCardToken(ctx context.Context, a *CardToken) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.TCID, a.PGID, a.Token, a.Brand, a.Last4, a.ExpMonth, a.ExpYear, a.FLAGS, a.LastModBy, a.CTID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateCardToken)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateCardToken.Exec(fields...)
+	}
+	return updateError(err, "CardToken", *a)
+}
+
+// UpdateGatewayTransaction updates an existing GatewayTransaction record in the database
+func UpdateGatewayTransaction(ctx context.Context, a *GatewayTransaction) error {
+	var err error
+	if authProblem(ctx, &a.LastModBy) {
+		return ErrSessionRequired
+	}
+	fields := []interface{}{a.BID, a.PGID, a.TCID, a.CTID, a.Type, a.ParentGTID, a.TxnID, a.Amount, a.Dt, a.Status, a.Message, a.RCPTID, a.PayoutID, a.DID, a.LastModBy, a.GTID}
+	if tx, ok := DBTxFromContext(ctx); ok { // if transaction is supplied
+		stmt := tx.Stmt(RRdb.Prepstmt.UpdateGatewayTransaction)
+		defer stmt.Close()
+		_, err = stmt.Exec(fields...)
+	} else {
+		_, err = RRdb.Prepstmt.UpdateGatewayTransaction.Exec(fields...)
+	}
+	return updateError(err, "GatewayTransaction", *a)
+}
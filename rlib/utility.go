@@ -0,0 +1,124 @@
+package rlib
+
+import (
+	"fmt"
+	"math"
+)
+
+// UTILMETHODSqft et al are the values of UtilityBill.Method, how a bill is
+// split between the units.
+//
+//  Sqft       Ratio Utility Billing on square feet x days
+//  Occupants  Ratio Utility Billing on occupants x days
+//  Equal      Ratio Utility Billing on days, each unit pays the same
+//  Submeter   on the usage read on each unit's sub-meter
+//  Ratio      Ratio Utility Billing on the fixed ratio of each unit stored
+//             with the bill x days, ex: 40/35/25
+const (
+	UTILMETHODSqft      = 1
+	UTILMETHODOccupants = 2
+	UTILMETHODEqual     = 3
+	UTILMETHODSubmeter  = 4
+	UTILMETHODRatio     = 5
+)
+
+// UTILBILLPosted is the UtilityBill FLAGS bit set once the resident charges
+// have been assessed
+const UTILBILLPosted = 1 << 0
+
+// UtilityMethods maps the UTILMETHOD values to their names
+var UtilityMethods = map[int64]string{
+	UTILMETHODSqft:      "square feet",
+	UTILMETHODOccupants: "occupants",
+	UTILMETHODEqual:     "equal split",
+	UTILMETHODSubmeter:  "sub-meter",
+	UTILMETHODRatio:     "fixed ratio",
+}
+
+// UtilityWeight returns the weight of part c of a bill split with method.
+// Sub-meter usage is weighed in thousandths, fixed ratios in ten
+// thousandths.
+//
+// INPUTS
+//  method = UtilityBill.Method
+//  c      = the part, Days, Sqft, Occupants, MeterUsage and Ratio must be set
+//
+// RETURNS
+//  the weight
+//-----------------------------------------------------------------------------
+func UtilityWeight(method int64, c *UtilityCharge) int64 {
+	switch method {
+	case UTILMETHODSqft:
+		return c.Sqft * c.Days
+	case UTILMETHODOccupants:
+		return c.Occupants * c.Days
+	case UTILMETHODEqual:
+		return c.Days
+	case UTILMETHODSubmeter:
+		return int64(math.Round(c.MeterUsage * 1000))
+	case UTILMETHODRatio:
+		return int64(math.Round(c.Ratio*10000)) * c.Days
+	}
+	return 0
+}
+
+// UtilityAllocate splits bill b between its parts m. The CommonPct part of
+// the bill is taken out first, the rest is allocated by the weight of each
+// part. Parts with no Rental Agreement, RAID 0, are the vacant time of a
+// unit: they take their share but it is not charged to anyone. The Share
+// and Amount of each part are set. The amounts add up to the part of the
+// bill that is not for the common areas.
+//
+// INPUTS
+//  b = the bill
+//  m = its parts
+//-----------------------------------------------------------------------------
+func UtilityAllocate(b *UtilityBill, m []UtilityCharge) {
+	billed := b.Amount - b.Amount.Mul(b.CommonPct/100)
+	w := make([]int64, len(m))
+	total := int64(0)
+	for i := 0; i < len(m); i++ {
+		w[i] = UtilityWeight(b.Method, &m[i])
+		total += w[i]
+	}
+	parts := billed.Allocate(w)
+	for i := 0; i < len(m); i++ {
+		m[i].Amount = parts[i]
+		m[i].Share = 0
+		if total > 0 {
+			m[i].Share = math.Round(float64(w[i])/float64(total)*1e8) / 1e8
+		}
+	}
+}
+
+// UtilityChargeDescr returns the breakdown of charge c of bill b as it is
+// shown to the resident, ex:
+//
+//  Water 1/1/2018 - 1/31/2018, 4100.00 less 10% common area: 850 sqft x 31 days, 2.05% share
+//
+// INPUTS
+//  b = the bill
+//  c = the charge
+//
+// RETURNS
+//  the breakdown
+//-----------------------------------------------------------------------------
+func UtilityChargeDescr(b *UtilityBill, c *UtilityCharge) string {
+	s := fmt.Sprintf("%s %s - %s, %s", b.Utility, b.DtStart.Format(RRDATEFMT3), b.DtStop.AddDate(0, 0, -1).Format(RRDATEFMT3), b.Amount)
+	if b.CommonPct > 0 {
+		s += fmt.Sprintf(" less %g%% common area", b.CommonPct)
+	}
+	switch b.Method {
+	case UTILMETHODSqft:
+		s += fmt.Sprintf(": %d sqft x %d days", c.Sqft, c.Days)
+	case UTILMETHODOccupants:
+		s += fmt.Sprintf(": %d occupants x %d days", c.Occupants, c.Days)
+	case UTILMETHODEqual:
+		s += fmt.Sprintf(": %d days", c.Days)
+	case UTILMETHODSubmeter:
+		s += fmt.Sprintf(": %g used", c.MeterUsage)
+	case UTILMETHODRatio:
+		s += fmt.Sprintf(": ratio %g x %d days", c.Ratio, c.Days)
+	}
+	return s + fmt.Sprintf(", %.2f%% share", c.Share*100)
+}
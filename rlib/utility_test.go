@@ -0,0 +1,46 @@
+package rlib
+
+import (
+	"testing"
+)
+
+// Utility bill allocation tests.
+
+func TestUtilityAllocate(t *testing.T) {
+	var tests = []struct {
+		method    int64
+		amount    Money
+		commonPct float64
+		parts     []UtilityCharge // RAID 0 is vacant
+		expect    []Money
+	}{
+		// square feet, a full month each
+		{UTILMETHODSqft, 300000, 0, []UtilityCharge{{RAID: 1, Sqft: 1000, Days: 30}, {RAID: 2, Sqft: 500, Days: 30}}, []Money{200000, 100000}},
+		// 10% for the common areas
+		{UTILMETHODSqft, 300000, 10, []UtilityCharge{{RAID: 1, Sqft: 1000, Days: 30}, {RAID: 2, Sqft: 500, Days: 30}}, []Money{180000, 90000}},
+		// the vacant half of a unit is the owner's
+		{UTILMETHODSqft, 100000, 0, []UtilityCharge{{RAID: 1, Sqft: 800, Days: 15}, {RAID: 0, Sqft: 800, Days: 15}, {RAID: 2, Sqft: 800, Days: 30}}, []Money{25000, 25000, 50000}},
+		// occupants, a vacant unit has none
+		{UTILMETHODOccupants, 60000, 0, []UtilityCharge{{RAID: 1, Occupants: 1, Days: 30}, {RAID: 2, Occupants: 2, Days: 30}, {RAID: 0, Days: 30}}, []Money{20000, 40000, 0}},
+		// equal split, the cents left over go to one unit
+		{UTILMETHODEqual, 10000, 0, []UtilityCharge{{RAID: 1, Days: 31}, {RAID: 2, Days: 31}, {RAID: 3, Days: 31}}, []Money{3334, 3333, 3333}},
+		// sub-meter usage
+		{UTILMETHODSubmeter, 50000, 0, []UtilityCharge{{RAID: 1, MeterUsage: 1.5}, {RAID: 2, MeterUsage: 3.5}}, []Money{15000, 35000}},
+		// fixed ratios 40/35/25
+		{UTILMETHODRatio, 100000, 0, []UtilityCharge{{RAID: 1, Ratio: 40, Days: 31}, {RAID: 2, Ratio: 35, Days: 31}, {RAID: 3, Ratio: 25, Days: 31}}, []Money{40000, 35000, 25000}},
+		// fixed ratios, the unit at 40 is vacant for 11 of its 31 days
+		{UTILMETHODRatio, 100000, 0, []UtilityCharge{{RAID: 1, Ratio: 40, Days: 20}, {RAID: 0, Ratio: 40, Days: 11}, {RAID: 2, Ratio: 35, Days: 31}, {RAID: 3, Ratio: 25, Days: 31}}, []Money{25806, 14194, 35000, 25000}},
+		// nothing to weigh
+		{UTILMETHODSqft, 50000, 0, []UtilityCharge{{RAID: 1, Days: 30}}, []Money{0}},
+	}
+	for i := 0; i < len(tests); i++ {
+		tc := &tests[i]
+		b := UtilityBill{Method: tc.method, Amount: tc.amount, CommonPct: tc.commonPct}
+		UtilityAllocate(&b, tc.parts)
+		for j := 0; j < len(tc.parts); j++ {
+			if tc.parts[j].Amount != tc.expect[j] {
+				t.Errorf("test %d, part %d: expected %s, got %s\n", i, j, tc.expect[j], tc.parts[j].Amount)
+			}
+		}
+	}
+}
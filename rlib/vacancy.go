@@ -60,7 +60,7 @@ func ProcessRentable(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time, r
 		// each entry accordingly
 		var j Journal
 		j.BID = xbiz.P.BID
-		j.Amount = RoundToCent(m[i].Amount)
+		j.Amount = m[i].Amount
 		// TODO: fix the next line
 		j.Dt = m[i].DtStop.AddDate(0, 0, -1) // associated date is period end - 1 proration cycle (or 1 sec if no proration)
 		j.Type = JNLTYPEUNAS                 // this is an unassociated entry
@@ -3,6 +3,7 @@ package rlib
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 )
 
@@ -10,10 +11,11 @@ import (
 type VacancyMarker struct {
 	DtStart    time.Time // a period start time
 	DtStop     time.Time // end of period
-	Amount     float64   // unit market rate during this period
+	Amount     Money     // unit market rate during this period
 	Comment    string    // comment to include with Journal
 	UseState   int64
 	LeaseState int64
+	cents      *big.Rat // Amount before rounding, so increments add up exactly
 }
 
 // VacancyDetect scans the time range specified and looks for pro[rate] periods of time when the
@@ -135,7 +137,7 @@ func VacancyDetect(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time, rid
 		// 	rlib.Console("rta[%d] = (%s - %s) RTID = %d\n", iq, rta[iq].DtStart.Format("1/2/06"), rta[iq].DtStop.Format("1/2/06"), rta[iq].RTID)
 		// }
 
-		rentThisPeriod, err := CalculateGSR(ctx, dt, dtNext, rid, &rta, rsa, xbiz)
+		rentThisPeriod, err := calculateGSRCents(ctx, dt, dtNext, rid, &rta, rsa, xbiz)
 		if err != nil {
 			return m, err
 		}
@@ -147,18 +149,20 @@ func VacancyDetect(ctx context.Context, xbiz *XBusiness, d1, d2 *time.Time, rid
 		if k > 0 { // If the last entry's DtStop is the same time this one's DtStart...
 			if m[k-1].DtStop.Equal(dt) && m[k-1].UseState == useState { // and the umr is at the same rate...
 				// m[k-1].Amount += umr * pf // add another increment to the amount
-				m[k-1].DtStop = dtNext          // then we'll just adjust the end of that range to include this range too.
-				m[k-1].Amount += rentThisPeriod // add the rent for this time increment
+				m[k-1].DtStop = dtNext                         // then we'll just adjust the end of that range to include this range too.
+				m[k-1].cents.Add(m[k-1].cents, rentThisPeriod) // add the rent for this time increment
+				m[k-1].Amount = moneyFromRat(m[k-1].cents)
 				m[k-1].Comment = fmt.Sprintf("vacant %s - %s", m[k-1].DtStart.Format("Jan 2"), m[k-1].DtStop.Format("Jan 2"))
 				continue // Range extended.  Next!
 			}
 		}
 
-		var v VacancyMarker       // ok, this is either the first entry or
-		v.DtStart = dt            // it is disjoint from the last range
-		v.DtStop = dtNext         // fill it out and
-		v.UseState = useState     // note the cause of the vacancy
-		v.Amount = rentThisPeriod // save the rate so we don't need to look it up later
+		var v VacancyMarker      // ok, this is either the first entry or
+		v.DtStart = dt           // it is disjoint from the last range
+		v.DtStop = dtNext        // fill it out and
+		v.UseState = useState    // note the cause of the vacancy
+		v.cents = rentThisPeriod // save the rate so we don't need to look it up later
+		v.Amount = moneyFromRat(v.cents)
 		// v.Amount = umr * pf // save the rate so we don't need to look it up later
 		v.Comment = fmt.Sprintf("vacant %s - %s", v.DtStart.Format("Jan 2"), v.DtStop.Format("Jan 2"))
 		m = append(m, v) // add the new VacancyMarker to the list
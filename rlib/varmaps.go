@@ -59,6 +59,8 @@ var assignmap = []struct {
 	{a: "int", b: "XJSONAsmFLAGS", mapper: MigrateInt64ToString, valmap: &AsmFLAGS},
 	{a: "XJSONRcptFLAGS", b: "int", mapper: MigrateStrToInt64, valmap: &RcptFLAGS},
 	{a: "int", b: "XJSONRcptFLAGS", mapper: MigrateInt64ToString, valmap: &RcptFLAGS},
+	{a: "Money", b: "float64", mapper: Money2Float64},
+	{a: "float64", b: "Money", mapper: Float642Money},
 }
 
 var xjson = string("XJSON")
@@ -197,6 +199,23 @@ func Bool2Int(a, b *reflect.Value, m *Str2Int64Map) error {
 	return nil
 }
 
+// Money2Float64 maps Money to a float64 in dollars. Without it a Money
+// would be converted to its number of cents.
+// a must point to a Money
+// b must point to a float64
+func Money2Float64(a, b *reflect.Value, m *Str2Int64Map) error {
+	(*b).SetFloat((*a).Interface().(Money).Float())
+	return nil
+}
+
+// Float642Money is the exact inverse of Money2Float64
+// a must point to a float64
+// b must point to a Money
+func Float642Money(a, b *reflect.Value, m *Str2Int64Map) error {
+	(*b).Set(reflect.ValueOf(MoneyFromFloat((*a).Float())))
+	return nil
+}
+
 // Int642Bool copies an int into a bool value as follows
 // if the int is 0, the bool value is false
 // for any other value of the int the bool is true
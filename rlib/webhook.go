@@ -0,0 +1,276 @@
+package rlib
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WHEVTreceiptCreated et al. are the names of the events that can be sent to
+// a WebhookSubscription
+const (
+	WHEVTreceiptCreated     = "receipt.created"
+	WHEVTreceiptReversed    = "receipt.reversed"
+	WHEVTassessmentCreated  = "assessment.created"
+	WHEVTassessmentReversed = "assessment.reversed"
+	WHEVTraStateChanged     = "rentalagreement.statechanged"
+	WHEVTperiodClosed       = "period.closed"
+)
+
+// WebhookEvents is the list of all webhook event names
+var WebhookEvents = []string{
+	WHEVTreceiptCreated,
+	WHEVTreceiptReversed,
+	WHEVTassessmentCreated,
+	WHEVTassessmentReversed,
+	WHEVTraStateChanged,
+	WHEVTperiodClosed,
+}
+
+// WebhookInactive is the WebhookSubscription FLAGS bit indicating that no
+// events should be sent to the subscription
+const WebhookInactive = 1 << 0
+
+// WebhookPending et al. are the WebhookDelivery Status values
+const (
+	WebhookPending   = 0 // waiting for the first or a retry attempt
+	WebhookDelivered = 1 // the receiver accepted the event
+	WebhookFailed    = 2 // gave up after WebhookMaxAttempts
+)
+
+// WebhookMaxAttempts is the number of times delivery of an event is attempted
+// before it is marked as failed
+const WebhookMaxAttempts = 10
+
+// WebhookSignatureHeader is the http header that holds the payload signature
+const WebhookSignatureHeader = "X-RentRoll-Signature"
+
+// WebhookEvent is the JSON body sent to webhook receivers
+type WebhookEvent struct {
+	Event string      `json:"event"` // one of the WHEVT names
+	BID   int64       `json:"bid"`
+	BUD   string      `json:"bud"`
+	Dt    time.Time   `json:"dt"`   // when the event happened
+	Data  interface{} `json:"data"` // the record that the event is about
+}
+
+// WebhookRAState is the data of a rentalagreement.statechanged event. It
+// holds the FLAGS of the rental agreement before and after the action, the
+// state is in their low 4 bits. RAID is 0 while the agreement is a flow
+// that has not been made into a rental agreement yet.
+type WebhookRAState struct {
+	RAID        int64     `json:"raid"`
+	UserRefNo   string    `json:"userRefNo"`   // reference number of the flow, if any
+	OldFLAGS    uint64    `json:"oldFlags"`    // FLAGS before the action
+	NewFLAGS    uint64    `json:"newFlags"`    // FLAGS after the action
+	OldState    uint64    `json:"oldState"`    // state before the action
+	NewState    uint64    `json:"newState"`    // state after the action
+	DtEffective time.Time `json:"dtEffective"` // date the new state took effect
+}
+
+// NewWebhookRAState returns the data of a rentalagreement.statechanged event
+// for a rental agreement that went from oldFlags to the state in meta. The
+// effective date is the date meta holds for the new state.
+//-----------------------------------------------------------------------------
+func NewWebhookRAState(oldFlags uint64, meta *RAFlowMetaInfo) *WebhookRAState {
+	e := WebhookRAState{
+		RAID:     meta.RAID,
+		OldFLAGS: oldFlags,
+		NewFLAGS: meta.RAFLAGS,
+		OldState: oldFlags & 0xF,
+		NewState: meta.RAFLAGS & 0xF,
+	}
+	switch e.NewState {
+	case RASTATEPendingApproval1:
+		e.DtEffective = time.Time(meta.ApplicationReadyDate)
+	case RASTATEPendingApproval2:
+		e.DtEffective = time.Time(meta.DecisionDate1)
+	case RASTATEMoveIn:
+		e.DtEffective = time.Time(meta.MoveInDate)
+	case RASTATEActive:
+		e.DtEffective = time.Time(meta.ActiveDate)
+	case RASTATENoticeToMove:
+		e.DtEffective = time.Time(meta.NoticeToMoveDate)
+	case RASTATETerminated:
+		e.DtEffective = time.Time(meta.TerminationDate)
+	}
+	if e.DtEffective.IsZero() {
+		e.DtEffective = time.Now().UTC()
+	}
+	return &e
+}
+
+// WebhookEventMatch returns true if event is in the comma separated list of
+// event names. An empty list matches all events.
+//-----------------------------------------------------------------------------
+func WebhookEventMatch(events, event string) bool {
+	if len(strings.TrimSpace(events)) == 0 {
+		return true
+	}
+	sa := strings.Split(events, ",")
+	for i := 0; i < len(sa); i++ {
+		if strings.TrimSpace(sa[i]) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSignature returns the signature of body using key secret. It is the
+// hex encoded HMAC-SHA256 prefixed with "sha256=". Receivers verify a payload
+// by computing the same value and comparing it with WebhookSignatureHeader.
+//-----------------------------------------------------------------------------
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff returns how long to wait before the next delivery attempt
+// after the supplied number of failed attempts. The wait doubles after each
+// attempt, starting at 1 minute and topping out at 8 hours.
+//-----------------------------------------------------------------------------
+func WebhookBackoff(attempts int64) time.Duration {
+	d := time.Minute
+	for i := int64(1); i < attempts && d < 8*time.Hour; i++ {
+		d *= 2
+	}
+	if d > 8*time.Hour {
+		d = 8 * time.Hour
+	}
+	return d
+}
+
+// QueueWebhookEvent creates a WebhookDelivery for each active subscription of
+// business bid that wants event. The deliveries are made later by the webhook
+// worker. If ctx has a transaction the deliveries are only queued if it is
+// committed.
+//
+// INPUTS
+//  ctx   - context which may include a database transaction in progress
+//  bid   - the business where the event happened
+//  event - one of the WHEVT names
+//  data  - the record the event is about. It is sent as JSON.
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func QueueWebhookEvent(ctx context.Context, bid int64, event string, data interface{}) error {
+	m, err := GetWebhookSubscriptionsByBID(ctx, bid)
+	if err != nil {
+		return err
+	}
+	var b []byte
+	now := time.Now()
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&WebhookInactive != 0 || !WebhookEventMatch(m[i].Events, event) {
+			continue
+		}
+		if b == nil {
+			e := WebhookEvent{
+				Event: event,
+				BID:   bid,
+				BUD:   string(GetBUDFromBIDList(bid)),
+				Dt:    now,
+				Data:  data,
+			}
+			if b, err = json.Marshal(&e); err != nil {
+				return err
+			}
+		}
+		d := WebhookDelivery{
+			WHSID:   m[i].WHSID,
+			BID:     bid,
+			Event:   event,
+			Payload: string(b),
+			Status:  WebhookPending,
+			DtNext:  now,
+		}
+		if err = InsertWebhookDelivery(ctx, &d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmitWebhookEvent queues event with QueueWebhookEvent. An error queueing
+// the event is logged but is not returned, the action that caused the
+// event has already happened.
+//-----------------------------------------------------------------------------
+func EmitWebhookEvent(ctx context.Context, bid int64, event string, data interface{}) {
+	if err := QueueWebhookEvent(ctx, bid, event, data); err != nil {
+		LogAndPrintError("EmitWebhookEvent: "+event, err)
+	}
+}
+
+// PostWebhook sends the payload of a to s.URL, signed with s.Secret.
+//
+// INPUTS
+//  client - the http client to use
+//  s      - the subscription
+//  a      - the delivery
+//
+// RETURNS
+//  the http status code, 0 if there was no response
+//  any error encountered, including a non-2xx response
+//-----------------------------------------------------------------------------
+func PostWebhook(client *http.Client, s *WebhookSubscription, a *WebhookDelivery) (int, error) {
+	body := []byte(a.Payload)
+	req, err := http.NewRequest("POST", s.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "RentRoll-Webhook")
+	req.Header.Set("X-RentRoll-Event", a.Event)
+	req.Header.Set("X-RentRoll-Delivery", fmt.Sprintf("%d", a.WHDID))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(s.Secret, body))
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096)) // let the connection be reused
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// DeliverWebhook makes one delivery attempt of a to s and updates a with the
+// result. If the attempt fails, the next attempt is scheduled using
+// WebhookBackoff, or a is marked failed if it has run out of attempts. a is
+// not saved.
+//
+// INPUTS
+//  client - the http client to use
+//  s      - the subscription
+//  a      - the delivery
+//  now    - current time
+//-----------------------------------------------------------------------------
+func DeliverWebhook(client *http.Client, s *WebhookSubscription, a *WebhookDelivery, now *time.Time) {
+	a.Attempts++
+	a.DtLast = *now
+	code, err := PostWebhook(client, s, a)
+	a.ResponseCode = int64(code)
+	if err == nil {
+		a.Status = WebhookDelivered
+		a.Message = ""
+		return
+	}
+	a.Message = err.Error()
+	if a.Attempts >= WebhookMaxAttempts {
+		a.Status = WebhookFailed
+		return
+	}
+	a.DtNext = now.Add(WebhookBackoff(a.Attempts))
+}
@@ -0,0 +1,121 @@
+package rlib
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Webhook tests. Deliveries are made to a local http receiver.
+
+func TestWebhookEventMatch(t *testing.T) {
+	var m = []struct {
+		events string
+		event  string
+		expect bool
+	}{
+		{"", WHEVTreceiptCreated, true},
+		{"receipt.created,receipt.reversed", WHEVTreceiptReversed, true},
+		{"receipt.created, period.closed", WHEVTperiodClosed, true},
+		{"receipt.created", WHEVTassessmentCreated, false},
+		{"receipt", WHEVTreceiptCreated, false},
+	}
+	for i := 0; i < len(m); i++ {
+		if r := WebhookEventMatch(m[i].events, m[i].event); r != m[i].expect {
+			t.Errorf("WebhookEventMatch( %q, %q ) expect %t, got %t\n", m[i].events, m[i].event, m[i].expect, r)
+		}
+	}
+}
+
+func TestNewWebhookRAState(t *testing.T) {
+	jan := time.Date(2018, time.January, 5, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2018, time.February, 9, 0, 0, 0, 0, time.UTC)
+	var m = []struct {
+		oldFlags uint64
+		meta     RAFlowMetaInfo
+		dt       time.Time
+	}{
+		{0, RAFlowMetaInfo{RAID: 3, RAFLAGS: 1, ApplicationReadyDate: JSONDateTime(jan)}, jan},
+		{1<<4 | 3, RAFlowMetaInfo{RAID: 3, RAFLAGS: 1<<4 | 4, ActiveDate: JSONDateTime(jan), NoticeToMoveDate: JSONDateTime(feb)}, jan},
+		{1<<4 | 4, RAFlowMetaInfo{RAID: 3, RAFLAGS: 1<<4 | 5, ActiveDate: JSONDateTime(jan), NoticeToMoveDate: JSONDateTime(feb)}, feb},
+		{4, RAFlowMetaInfo{RAID: 3, RAFLAGS: 6, TerminationDate: JSONDateTime(feb)}, feb},
+	}
+	for i := 0; i < len(m); i++ {
+		e := NewWebhookRAState(m[i].oldFlags, &m[i].meta)
+		if e.RAID != 3 || e.OldFLAGS != m[i].oldFlags || e.NewFLAGS != m[i].meta.RAFLAGS ||
+			e.OldState != m[i].oldFlags&0xF || e.NewState != m[i].meta.RAFLAGS&0xF || !e.DtEffective.Equal(m[i].dt) {
+			t.Errorf("NewWebhookRAState( %d, %d ): got %+v, expect effective date %s\n", m[i].oldFlags, m[i].meta.RAFLAGS, *e, m[i].dt.Format(RRDATEFMT4))
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	var m = []struct {
+		attempts int64
+		expect   time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 8 * time.Hour},
+		{50, 8 * time.Hour},
+	}
+	for i := 0; i < len(m); i++ {
+		if d := WebhookBackoff(m[i].attempts); d != m[i].expect {
+			t.Errorf("WebhookBackoff( %d ) expect %s, got %s\n", m[i].attempts, m[i].expect, d)
+		}
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	status := http.StatusOK
+	var gotSig, gotEvent, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(b)
+		gotSig = r.Header.Get(WebhookSignatureHeader)
+		gotEvent = r.Header.Get("X-RentRoll-Event")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := WebhookSubscription{WHSID: 1, BID: 1, URL: srv.URL, Secret: "s3cr3t"}
+	now := time.Date(2018, time.May, 17, 10, 0, 0, 0, time.UTC)
+
+	//-----------------------------------------------
+	// successful delivery, verify the signature
+	//-----------------------------------------------
+	a := WebhookDelivery{WHDID: 7, WHSID: 1, BID: 1, Event: WHEVTreceiptCreated, Payload: `{"event":"receipt.created"}`}
+	DeliverWebhook(srv.Client(), &s, &a, &now)
+	if a.Status != WebhookDelivered || a.Attempts != 1 || a.ResponseCode != 200 {
+		t.Errorf("DeliverWebhook: expect delivered after 1 attempt with code 200, got status %d, attempts %d, code %d, msg %s\n", a.Status, a.Attempts, a.ResponseCode, a.Message)
+	}
+	if gotBody != a.Payload || gotEvent != a.Event {
+		t.Errorf("DeliverWebhook: receiver got event %q body %q\n", gotEvent, gotBody)
+	}
+	if gotSig != WebhookSignature("s3cr3t", []byte(a.Payload)) || gotSig == WebhookSignature("wrong", []byte(a.Payload)) {
+		t.Errorf("DeliverWebhook: bad signature %s\n", gotSig)
+	}
+
+	//-----------------------------------------------
+	// receiver error, should be retried
+	//-----------------------------------------------
+	status = http.StatusInternalServerError
+	a = WebhookDelivery{WHDID: 8, WHSID: 1, BID: 1, Event: WHEVTperiodClosed, Payload: `{}`}
+	DeliverWebhook(srv.Client(), &s, &a, &now)
+	if a.Status != WebhookPending || a.ResponseCode != 500 || !a.DtNext.Equal(now.Add(time.Minute)) || len(a.Message) == 0 {
+		t.Errorf("DeliverWebhook: expect pending with retry at %s, got status %d, code %d, next %s\n", now.Add(time.Minute), a.Status, a.ResponseCode, a.DtNext)
+	}
+
+	//-----------------------------------------------
+	// out of attempts, should be marked failed
+	//-----------------------------------------------
+	a.Attempts = WebhookMaxAttempts - 1
+	DeliverWebhook(srv.Client(), &s, &a, &now)
+	if a.Status != WebhookFailed {
+		t.Errorf("DeliverWebhook: expect failed after %d attempts, got status %d\n", a.Attempts, a.Status)
+	}
+}
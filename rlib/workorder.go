@@ -0,0 +1,163 @@
+package rlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WOPRIORITYlow et al are the WorkOrder priorities
+const (
+	WOPRIORITYlow       = 0
+	WOPRIORITYnormal    = 1
+	WOPRIORITYhigh      = 2
+	WOPRIORITYemergency = 3
+)
+
+// WOSTATUSopen et al are the WorkOrder states
+const (
+	WOSTATUSopen       = 0
+	WOSTATUSassigned   = 1
+	WOSTATUSinProgress = 2
+	WOSTATUSonHold     = 3
+	WOSTATUScompleted  = 4
+	WOSTATUScancelled  = 5
+)
+
+// MRQSTATUSnew et al are the MaintRequest states
+const (
+	MRQSTATUSnew        = 0
+	MRQSTATUSinProgress = 1
+	MRQSTATUSclosed     = 2
+)
+
+// WOPriorityNames are the readable names of the WorkOrder priorities
+var WOPriorityNames = []string{"Low", "Normal", "High", "Emergency"}
+
+// WOStatusNames are the readable names of the WorkOrder states
+var WOStatusNames = []string{"Open", "Assigned", "In Progress", "On Hold", "Completed", "Cancelled"}
+
+// woTransitions lists the states a WorkOrder can move to from each state.
+// A completed or cancelled work order can be reopened.
+var woTransitions = map[int64][]int64{
+	WOSTATUSopen:       {WOSTATUSassigned, WOSTATUSinProgress, WOSTATUSonHold, WOSTATUScompleted, WOSTATUScancelled},
+	WOSTATUSassigned:   {WOSTATUSopen, WOSTATUSinProgress, WOSTATUSonHold, WOSTATUScompleted, WOSTATUScancelled},
+	WOSTATUSinProgress: {WOSTATUSassigned, WOSTATUSonHold, WOSTATUScompleted, WOSTATUScancelled},
+	WOSTATUSonHold:     {WOSTATUSopen, WOSTATUSassigned, WOSTATUSinProgress, WOSTATUScancelled},
+	WOSTATUScompleted:  {WOSTATUSopen},
+	WOSTATUScancelled:  {WOSTATUSopen},
+}
+
+// WorkOrderAgingDays are the upper limits, in days, of the aging buckets
+// for open work orders. Anything older falls into a last bucket.
+var WorkOrderAgingDays = []int64{7, 30, 60}
+
+// WorkOrderStatusName returns the readable name of WorkOrder status s
+//-----------------------------------------------------------------------------
+func WorkOrderStatusName(s int64) string {
+	if s < 0 || s >= int64(len(WOStatusNames)) {
+		return fmt.Sprintf("unknown (%d)", s)
+	}
+	return WOStatusNames[s]
+}
+
+// WorkOrderPriorityName returns the readable name of WorkOrder priority p
+//-----------------------------------------------------------------------------
+func WorkOrderPriorityName(p int64) string {
+	if p < 0 || p >= int64(len(WOPriorityNames)) {
+		return fmt.Sprintf("unknown (%d)", p)
+	}
+	return WOPriorityNames[p]
+}
+
+// WorkOrderIsOpen returns true if a has not been completed or cancelled
+//-----------------------------------------------------------------------------
+func WorkOrderIsOpen(a *WorkOrder) bool {
+	return a.Status != WOSTATUScompleted && a.Status != WOSTATUScancelled
+}
+
+// SetWorkOrderStatus moves a to state status if the transition is allowed.
+// DtDone is set when the work order is completed or cancelled and cleared
+// when it is reopened. It does not write a to the database.
+//
+// INPUTS
+//  a      - the work order
+//  status - the new state
+//  now    - current time
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func SetWorkOrderStatus(a *WorkOrder, status int64, now *time.Time) error {
+	if status == a.Status {
+		return nil
+	}
+	if !Int64InSlice(status, woTransitions[a.Status]) {
+		return fmt.Errorf("a work order cannot go from %s to %s", WorkOrderStatusName(a.Status), WorkOrderStatusName(status))
+	}
+	if status == WOSTATUScompleted && a.AssignedUID == 0 && len(a.AssignedTo) == 0 {
+		return fmt.Errorf("a work order must be assigned before it is completed")
+	}
+	a.Status = status
+	if WorkOrderIsOpen(a) {
+		a.DtDone = TIME0
+	} else {
+		a.DtDone = *now
+	}
+	return nil
+}
+
+// WorkOrderAge returns the number of whole days a has been open as of now
+//-----------------------------------------------------------------------------
+func WorkOrderAge(a *WorkOrder, now *time.Time) int64 {
+	if now.Before(a.DtOpen) {
+		return 0
+	}
+	return int64(now.Sub(a.DtOpen) / (24 * time.Hour))
+}
+
+// WorkOrderAgingBucket returns the index of the aging bucket for a work
+// order open for days days. The last index, len(WorkOrderAgingDays), is for
+// anything older than the last limit.
+//-----------------------------------------------------------------------------
+func WorkOrderAgingBucket(days int64) int {
+	for i := 0; i < len(WorkOrderAgingDays); i++ {
+		if days <= WorkOrderAgingDays[i] {
+			return i
+		}
+	}
+	return len(WorkOrderAgingDays)
+}
+
+// UpdateWorkOrderMaintRequest brings the status of the tenant's
+// MaintRequest, if any, in line with work order a. The request is in
+// progress while the work order is open and closed once it is done.
+//
+// INPUTS
+//  ctx - db context
+//  a   - the work order
+//
+// RETURNS
+//  any error encountered
+//-----------------------------------------------------------------------------
+func UpdateWorkOrderMaintRequest(ctx context.Context, a *WorkOrder) error {
+	if a.MRQID == 0 {
+		return nil
+	}
+	m, err := GetMaintRequest(ctx, a.MRQID)
+	if err != nil {
+		return err
+	}
+	if m.MRQID == 0 || m.BID != a.BID {
+		return fmt.Errorf("maintenance request %d not found", a.MRQID)
+	}
+	status := int64(MRQSTATUSinProgress)
+	if !WorkOrderIsOpen(a) {
+		status = MRQSTATUSclosed
+	}
+	if m.Status == status {
+		return nil
+	}
+	m.Status = status
+	return UpdateMaintRequest(ctx, &m)
+}
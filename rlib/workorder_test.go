@@ -0,0 +1,69 @@
+package rlib
+
+import (
+	"testing"
+	"time"
+)
+
+// Work order lifecycle and aging tests.
+
+func TestSetWorkOrderStatus(t *testing.T) {
+	now := time.Date(2018, time.March, 10, 0, 0, 0, 0, time.UTC)
+	var m = []struct {
+		from     int64
+		to       int64
+		assigned string
+		ok       bool
+		done     bool
+	}{
+		{WOSTATUSopen, WOSTATUSassigned, "Bob", true, false},
+		{WOSTATUSopen, WOSTATUScompleted, "Bob", true, true},
+		{WOSTATUSopen, WOSTATUScompleted, "", false, false},
+		{WOSTATUSinProgress, WOSTATUSopen, "Bob", false, false},
+		{WOSTATUSonHold, WOSTATUScancelled, "", true, true},
+		{WOSTATUScompleted, WOSTATUSinProgress, "Bob", false, true},
+		{WOSTATUScompleted, WOSTATUSopen, "Bob", true, false},
+		{WOSTATUScancelled, WOSTATUSopen, "", true, false},
+	}
+	for i := 0; i < len(m); i++ {
+		a := WorkOrder{Status: m[i].from, AssignedTo: m[i].assigned}
+		if !WorkOrderIsOpen(&a) {
+			a.DtDone = now
+		}
+		err := SetWorkOrderStatus(&a, m[i].to, &now)
+		if (err == nil) != m[i].ok {
+			t.Errorf("%d: SetWorkOrderStatus( %s -> %s ) expect ok = %t, got err = %v\n", i, WorkOrderStatusName(m[i].from), WorkOrderStatusName(m[i].to), m[i].ok, err)
+			continue
+		}
+		if done := a.DtDone.Equal(now); done != m[i].done {
+			t.Errorf("%d: SetWorkOrderStatus( %s -> %s ) expect DtDone set = %t, got %t\n", i, WorkOrderStatusName(m[i].from), WorkOrderStatusName(m[i].to), m[i].done, done)
+		}
+	}
+}
+
+func TestWorkOrderAgingBucket(t *testing.T) {
+	now := time.Date(2018, time.March, 10, 12, 0, 0, 0, time.UTC)
+	var m = []struct {
+		opened time.Time
+		days   int64
+		bucket int
+	}{
+		{time.Date(2018, time.March, 11, 0, 0, 0, 0, time.UTC), 0, 0},
+		{time.Date(2018, time.March, 10, 0, 0, 0, 0, time.UTC), 0, 0},
+		{time.Date(2018, time.March, 3, 0, 0, 0, 0, time.UTC), 7, 0},
+		{time.Date(2018, time.March, 2, 0, 0, 0, 0, time.UTC), 8, 1},
+		{time.Date(2018, time.February, 8, 0, 0, 0, 0, time.UTC), 30, 1},
+		{time.Date(2018, time.January, 9, 0, 0, 0, 0, time.UTC), 60, 2},
+		{time.Date(2018, time.January, 8, 0, 0, 0, 0, time.UTC), 61, 3},
+	}
+	for i := 0; i < len(m); i++ {
+		a := WorkOrder{DtOpen: m[i].opened}
+		days := WorkOrderAge(&a, &now)
+		if days != m[i].days {
+			t.Errorf("%d: WorkOrderAge expect %d, got %d\n", i, m[i].days, days)
+		}
+		if b := WorkOrderAgingBucket(days); b != m[i].bucket {
+			t.Errorf("%d: WorkOrderAgingBucket( %d ) expect %d, got %d\n", i, days, m[i].bucket, b)
+		}
+	}
+}
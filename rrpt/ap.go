@@ -0,0 +1,173 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"time"
+)
+
+// APAgingReportTable lists the unpaid bills of the business as of ri.D2
+// with the balance of each in the aging bucket for the number of days it is
+// past due.
+func APAgingReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "APAgingReportTable"
+	var (
+		err     error
+		vendors = map[int64]string{}
+	)
+
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	const (
+		Vendor    = 0
+		Bill      = iota
+		InvoiceNo = iota
+		Dt        = iota
+		DtDue     = iota
+		Amount    = iota
+		Balance   = iota
+		Bucket0   = iota // first aging bucket, one column per bucket follows
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Vendor", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Bill", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Invoice", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Due", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Balance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	sumcols := []int{Amount, Balance}
+	for i := 0; i < len(rlib.APAgingNames); i++ {
+		tbl.AddColumn(rlib.APAgingNames[i], 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+		sumcols = append(sumcols, Bucket0+i)
+	}
+
+	err = TableReportHeaderBlock(ctx, &tbl, "Accounts Payable Aging", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetBillsAsOf(ctx, ri.Xbiz.P.BID, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	for i := 0; i < len(m); i++ {
+		paid, err := rlib.GetBillPaidAmount(ctx, m[i].BILLID, &ri.D2)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			tbl.SetSection3(err.Error())
+			return tbl
+		}
+		bal := m[i].Amount - paid
+		if bal == 0 {
+			continue
+		}
+		vn, ok := vendors[m[i].VID]
+		if !ok {
+			v, err := rlib.GetVendor(ctx, m[i].VID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			vn = v.Name
+			vendors[m[i].VID] = vn
+		}
+
+		tbl.AddRow()
+		tbl.Puts(-1, Vendor, vn)
+		tbl.Puts(-1, Bill, rlib.IDtoShortString("BILL", m[i].BILLID))
+		tbl.Puts(-1, InvoiceNo, m[i].InvoiceNo)
+		tbl.Putd(-1, Dt, m[i].Dt)
+		tbl.Putd(-1, DtDue, m[i].DtDue)
+		tbl.Putf(-1, Amount, m[i].Amount.Float())
+		tbl.Putf(-1, Balance, bal.Float())
+		for j := 0; j < len(rlib.APAgingNames); j++ {
+			tbl.Putf(-1, Bucket0+j, 0)
+		}
+		tbl.Putf(-1, Bucket0+rlib.APAgingBucket(rlib.BillDaysPastDue(&m[i], &ri.D2)), bal.Float())
+	}
+
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, sumcols)
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// APAgingReport returns a text based report from APAgingReportTable
+func APAgingReport(ctx context.Context, ri *ReporterInfo) string {
+	tbl := APAgingReportTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// Vendor1099ReportTable lists the total paid during the year of ri.D2 to
+// each vendor whose payments are reported on a 1099.
+func Vendor1099ReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "Vendor1099ReportTable"
+
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = false
+
+	const (
+		Vendor     = 0
+		TaxID      = iota
+		Total      = iota
+		Reportable = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Vendor", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Tax ID", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Total Paid", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Reportable", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	yr := ri.D2.Year()
+	if ri.D2.Month() == time.January && ri.D2.Day() == 1 {
+		yr-- // ri.D2 is the first day after the range
+	}
+	err := TableReportHeaderBlock(ctx, &tbl, fmt.Sprintf("1099 Vendor Totals %d", yr), funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	d1 := time.Date(yr, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(1, 0, 0)
+	m, err := rlib.GetVendor1099Totals(ctx, ri.Xbiz.P.BID, &d1, &d2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); i++ {
+		s := "no"
+		if m[i].Total >= rlib.Vendor1099Threshold {
+			s = "yes"
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, Vendor, m[i].Name)
+		tbl.Puts(-1, TaxID, m[i].TaxID)
+		tbl.Putf(-1, Total, m[i].Total.Float())
+		tbl.Puts(-1, Reportable, s)
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// Vendor1099Report returns a text based report from Vendor1099ReportTable
+func Vendor1099Report(ctx context.Context, ri *ReporterInfo) string {
+	tbl := Vendor1099ReportTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
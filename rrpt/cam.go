@@ -0,0 +1,240 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// CAMReconTable generates the landlord's view of CAM reconciliation ri.ID:
+// one row per tenant with its share and true-up.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information, ri.ID is the CAMRID
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func CAMReconTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "CAMReconTable"
+
+	const (
+		RAID      = 0
+		Tenant    = iota
+		Rentable  = iota
+		Sqft      = iota
+		Days      = iota
+		Share     = iota
+		Allocated = iota
+		BaseYear  = iota
+		Due       = iota
+		Estimates = iota
+		TrueUp    = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Tenant", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Sq Ft", 8, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Days", 5, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Share %", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Allocated", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Base Year", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Due", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Estimates Billed", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("True-Up", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	r, err := rlib.GetCAMRecon(ctx, ri.ID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	if r.CAMRID == 0 || r.BID != ri.Bid {
+		tbl.SetSection3(fmt.Sprintf("CAM reconciliation %d not found", ri.ID))
+		return tbl
+	}
+	p, err := rlib.GetCAMPool(ctx, r.CAMPID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	ri.D1 = r.DtStart
+	ri.D2 = r.DtStop
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+	err = TableReportHeaderBlock(ctx, &tbl, p.Name+" Reconciliation", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetCAMReconItems(ctx, r.CAMRID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); i++ {
+		tenant, rentable, err := camTenantNames(ctx, &m[i], &r)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			tbl.SetSection3(err.Error())
+			return tbl
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, rlib.IDtoShortString("RA", m[i].RAID))
+		tbl.Puts(-1, Tenant, tenant)
+		tbl.Puts(-1, Rentable, rentable)
+		tbl.Puti(-1, Sqft, m[i].Sqft)
+		tbl.Puti(-1, Days, m[i].Days)
+		tbl.Puts(-1, Share, fmt.Sprintf("%.4f", m[i].Share*100))
+		tbl.Putf(-1, Allocated, m[i].Allocated.Float())
+		tbl.Putf(-1, BaseYear, m[i].BaseYear.Float())
+		tbl.Putf(-1, Due, m[i].Due.Float())
+		tbl.Putf(-1, Estimates, m[i].Estimates.Float())
+		tbl.Putf(-1, TrueUp, m[i].TrueUp.Float())
+	}
+
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Allocated, BaseYear, Due, Estimates, TrueUp})
+	}
+	tbl.AddRow()
+	tbl.Puts(-1, Tenant, "Property expenses")
+	tbl.Putf(-1, Allocated, r.Expenses.Float())
+	tbl.AddRow()
+	tbl.Puts(-1, Tenant, "Property square feet")
+	tbl.Puti(-1, Sqft, r.TotalSqft)
+	tbl.TightenColumns()
+	return tbl
+}
+
+// CAMStatementTable generates the statement sent to a tenant for its part
+// of a CAM reconciliation: how its share was computed and what it owes or
+// is owed.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information, ri.ID is the CAMRIID
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func CAMStatementTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "CAMStatementTable"
+
+	const (
+		Descr  = 0
+		Amount = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Description", 50, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 15, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	a, err := rlib.GetCAMReconItem(ctx, ri.ID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	if a.CAMRIID == 0 || a.BID != ri.Bid {
+		tbl.SetSection3(fmt.Sprintf("CAM reconciliation item %d not found", ri.ID))
+		return tbl
+	}
+	r, err := rlib.GetCAMRecon(ctx, a.CAMRID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	p, err := rlib.GetCAMPool(ctx, r.CAMPID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	ra, err := rlib.GetRentalAgreement(ctx, a.RAID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	ri.D1 = r.DtStart
+	ri.D2 = r.DtStop
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+	err = TableReportHeaderBlock(ctx, &tbl, p.Name+" Reconciliation Statement", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	tenant, rentable, err := camTenantNames(ctx, &a, &r)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	line := func(s, amt string) {
+		tbl.AddRow()
+		tbl.Puts(-1, Descr, s)
+		tbl.Puts(-1, Amount, amt)
+	}
+	line("Tenant: "+tenant, "")
+	line("Rental Agreement: "+rlib.IDtoShortString("RA", a.RAID), "")
+	line("Premises: "+rentable, "")
+	line("", "")
+	line("Operating expenses of the property", rlib.RRCommaf(r.Expenses.Float()))
+	line("Square feet of the property", fmt.Sprintf("%d", r.TotalSqft))
+	line("Your square feet", fmt.Sprintf("%d", a.Sqft))
+	line(fmt.Sprintf("Days leased (of %d)", int64(r.DtStop.Sub(r.DtStart).Hours()/24+0.5)), fmt.Sprintf("%d", a.Days))
+	line("Your pro-rata share", fmt.Sprintf("%.4f%%", a.Share*100))
+	line("Your share of the expenses", rlib.RRCommaf(a.Allocated.Float()))
+	switch ra.ExpenseAdjustmentType {
+	case rlib.EXPADJBaseYear:
+		line(fmt.Sprintf("Less your share of base year expenses (year ending %s)", ra.BaseYearEnd.Format(rlib.RRDATEFMT3)), "("+rlib.RRCommaf(a.BaseYear.Float())+")")
+	}
+	if a.Due < a.Allocated-a.BaseYear && ra.ExpensesStop > 0 {
+		line(fmt.Sprintf("Limited by the expense stop of %s per year", rlib.RRCommaf(ra.ExpensesStop.Float())), "")
+	}
+	line("Your expenses for the year", rlib.RRCommaf(a.Due.Float()))
+	line("Less estimated charges billed", "("+rlib.RRCommaf(a.Estimates.Float())+")")
+	tbl.AddLineAfter(tbl.RowCount() - 1)
+	switch {
+	case a.TrueUp > 0:
+		line("Balance due", rlib.RRCommaf(a.TrueUp.Float()))
+	case a.TrueUp < 0:
+		line("Credit to your account", "("+rlib.RRCommaf(-a.TrueUp.Float())+")")
+	default:
+		line("Nothing is due", "0.00")
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// camTenantNames returns the payor names and the rentable name of
+// reconciliation item a
+func camTenantNames(ctx context.Context, a *rlib.CAMReconItem, r *rlib.CAMRecon) (string, string, error) {
+	ra, err := rlib.GetRentalAgreement(ctx, a.RAID)
+	if err != nil {
+		return "", "", err
+	}
+	names, err := ra.GetPayorNameList(ctx, &r.DtStart, &r.DtStop)
+	if err != nil {
+		return "", "", err
+	}
+	rnt, err := rlib.GetRentable(ctx, a.RID)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.Join(names, ", "), rnt.RentableName, nil
+}
@@ -0,0 +1,316 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// CheckPDFProps holds the override properties needed to print a check on
+// standard US Letter check stock: the check at the top of the page and two
+// stubs below it. The stock is preprinted with nothing but the security
+// background, so there are no margins, headers or footers.
+var CheckPDFProps = []*gotable.PDFProperty{
+	//
+	{Option: "--no-collate"},
+	// margins, the template positions everything
+	{Option: "-T", Value: "0"},
+	{Option: "-B", Value: "0"},
+	{Option: "-L", Value: "0"},
+	{Option: "-R", Value: "0"},
+	// page size
+	{Option: "--page-size", Value: "Letter"},
+	// orientation
+	{Option: "--orientation", Value: "Portrait"},
+	// sizing
+	{Option: "--dpi", Value: "1600"},
+}
+
+// checkMICRCSS is the style of the MICR line. The MICR font must be
+// installed on the server; the line has to be printed with magnetic toner to
+// be read by the bank's sorters.
+var checkMICRCSS = []*gotable.CSSProperty{
+	{Name: "font-family", Value: "'GnuMICR', 'MICR E13B', monospace"},
+	{Name: "font-size", Value: "12pt"},
+	{Name: "padding-top", Value: "0.25in"},
+}
+
+// checkSignatureCSS puts the signature line above the text in the cell
+var checkSignatureCSS = []*gotable.CSSProperty{
+	{Name: "border-top", Value: "1px solid black"},
+	{Name: "font-size", Value: "7pt"},
+}
+
+// checkAmountCSS draws the box around the amount
+var checkAmountCSS = []*gotable.CSSProperty{
+	{Name: "border", Value: "1px solid black"},
+	{Name: "font-weight", Value: "bold"},
+}
+
+// checkStubGapCSS separates the check and the stubs so that each lands on
+// its own part of the check stock
+var checkStubGapCSS = []*gotable.CSSProperty{
+	{Name: "height", Value: "0.6in"},
+}
+
+// RRCheckTable generates the check ri.ID for printing on check stock
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information, ri.ID is the CKID
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func RRCheckTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "RRCheckTable"
+
+	const (
+		Left   = 0
+		Middle = iota
+		Right  = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("", 60, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("", 30, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	c, err := rlib.GetBankCheck(ctx, ri.ID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	if c.CKID == 0 || c.BID != ri.Bid {
+		tbl.SetSection3(fmt.Sprintf("check %d not found", ri.ID))
+		return tbl
+	}
+	if c.FLAGS&rlib.CHECKVoid != 0 {
+		tbl.SetSection3(fmt.Sprintf("check %d is void", c.CheckNo))
+		return tbl
+	}
+	ca, err := rlib.GetCheckAccountByDEPID(ctx, c.DEPID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	dep, err := rlib.GetDepository(ctx, c.DEPID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	//----------------------------------------------
+	// the check: payer and bank on the left, the
+	// number and date on the right
+	//----------------------------------------------
+	payer := append([]string{ca.PayerName}, addressLines(ca.PayerAddress)...)
+	bank := append([]string{ca.BankName}, addressLines(ca.BankAddress)...)
+	right := []string{fmt.Sprintf("%d", c.CheckNo), ca.FractionalNo}
+	for i := 0; i < len(payer) || i < len(bank) || i < len(right); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, Left, lineAt(payer, i))
+		tbl.Puts(-1, Middle, lineAt(bank, i))
+		tbl.Puts(-1, Right, lineAt(right, i))
+	}
+
+	tbl.AddRow()
+	tbl.AddRow()
+	tbl.Puts(-1, Middle, "DATE")
+	tbl.Puts(-1, Right, c.Dt.Format(rlib.RRDATERECEIPTFMT))
+
+	tbl.AddRow()
+	tbl.Puts(-1, Left, "PAY TO THE ORDER OF  "+c.Payee)
+	tbl.Puts(-1, Right, "$"+rlib.CheckAmountString(c.Amount))
+	tbl.SetCellCSS(tbl.RowCount()-1, Right, checkAmountCSS)
+
+	tbl.AddRow()
+	tbl.Puts(-1, Left, rlib.AmountInWords(c.Amount)+" ********")
+	tbl.Puts(-1, Right, "DOLLARS")
+
+	tbl.AddRow()
+	for _, s := range append([]string{c.Payee}, addressLines(c.PayeeAddress)...) {
+		tbl.AddRow()
+		tbl.Puts(-1, Left, "        "+s)
+	}
+
+	tbl.AddRow()
+	tbl.AddRow()
+	tbl.Puts(-1, Left, "MEMO  "+c.Memo)
+	tbl.Puts(-1, Right, "AUTHORIZED SIGNATURE")
+	tbl.SetCellCSS(tbl.RowCount()-1, Right, checkSignatureCSS)
+
+	tbl.AddRow()
+	tbl.Puts(-1, Left, rlib.MICRLine(c.CheckNo, ca.RoutingNo, dep.AccountNo))
+	tbl.SetCellCSS(tbl.RowCount()-1, Left, checkMICRCSS)
+
+	//----------------------------------------------
+	// the two stubs, one for the payee and one
+	// for our files
+	//----------------------------------------------
+	for i := 0; i < 2; i++ {
+		tbl.AddRow()
+		tbl.SetCellCSS(tbl.RowCount()-1, Left, checkStubGapCSS)
+		if err = checkStub(ctx, &tbl, &c, &ca); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			tbl.SetSection3(err.Error())
+			return tbl
+		}
+	}
+	return tbl
+}
+
+// checkStub adds the rows of a check stub to tbl: the payer, check number,
+// date, payee and amount and, for a vendor payment, the bills it pays.
+//-----------------------------------------------------------------------------
+func checkStub(ctx context.Context, tbl *gotable.Table, c *rlib.BankCheck, ca *rlib.CheckAccount) error {
+	tbl.AddRow()
+	tbl.Puts(-1, 0, ca.PayerName)
+	tbl.Puts(-1, 1, c.Dt.Format(rlib.RRDATEFMT4))
+	tbl.Puts(-1, 2, fmt.Sprintf("Check %d", c.CheckNo))
+	tbl.AddLineAfter(tbl.RowCount() - 1)
+
+	tbl.AddRow()
+	tbl.Puts(-1, 0, c.Payee)
+	tbl.Puts(-1, 1, c.Memo)
+	tbl.Puts(-1, 2, "$"+rlib.RRCommaf(c.Amount.Float()))
+
+	if c.VPID == 0 {
+		return nil
+	}
+	m, err := rlib.GetVendorPaymentAllocations(ctx, c.VPID)
+	if err != nil {
+		return err
+	}
+	tbl.AddRow()
+	for i := 0; i < len(m); i++ {
+		b, err := rlib.GetBill(ctx, m[i].BILLID)
+		if err != nil {
+			return err
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, fmt.Sprintf("Invoice %s (%s)", b.InvoiceNo, rlib.IDtoShortString("BILL", b.BILLID)))
+		tbl.Puts(-1, 1, b.Dt.Format(rlib.RRDATEFMT4))
+		tbl.Puts(-1, 2, "$"+rlib.RRCommaf(m[i].Amount.Float()))
+	}
+	return nil
+}
+
+// addressLines splits an address stored with newlines into its non-blank
+// lines
+func addressLines(s string) []string {
+	var m []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); len(l) > 0 {
+			m = append(m, l)
+		}
+	}
+	return m
+}
+
+// lineAt returns m[i] or "" if m has no line i
+func lineAt(m []string, i int) string {
+	if i < len(m) {
+		return m[i]
+	}
+	return ""
+}
+
+// RRCheck generates a text version of the check
+func RRCheck(ctx context.Context, ri *ReporterInfo) string {
+	tbl := RRCheckTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
+
+// CheckRegisterTable lists the checks written in the range ri.D1 - ri.D2
+// for each Depository of the business, in check number order. Void checks
+// are listed with an amount of 0 so they do not count in the total.
+func CheckRegisterTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "CheckRegisterTable"
+	var (
+		err  error
+		deps = map[int64]string{}
+	)
+
+	const (
+		Depository = 0
+		CheckNo    = iota
+		Dt         = iota
+		Payee      = iota
+		Memo       = iota
+		Status     = iota
+		Amount     = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Depository", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Check", 8, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payee", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Memo", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Status", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	err = TableReportHeaderBlock(ctx, &tbl, "Check Register", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetBankChecksByDateRange(ctx, ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); i++ {
+		dn, ok := deps[m[i].DEPID]
+		if !ok {
+			d, err := rlib.GetDepository(ctx, m[i].DEPID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			dn = d.Name
+			deps[m[i].DEPID] = dn
+		}
+		amt := m[i].Amount
+		status := "open"
+		switch {
+		case m[i].FLAGS&rlib.CHECKVoid != 0:
+			status = "VOID"
+			amt = 0
+		case m[i].FLAGS&rlib.CHECKPrinted != 0:
+			status = "printed"
+		}
+
+		tbl.AddRow()
+		tbl.Puts(-1, Depository, dn)
+		tbl.Puti(-1, CheckNo, m[i].CheckNo)
+		tbl.Putd(-1, Dt, m[i].Dt)
+		tbl.Puts(-1, Payee, m[i].Payee)
+		tbl.Puts(-1, Memo, m[i].Memo)
+		tbl.Puts(-1, Status, status)
+		tbl.Putf(-1, Amount, amt.Float())
+	}
+
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Amount})
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// CheckRegister returns a text based report from CheckRegisterTable
+func CheckRegister(ctx context.Context, ri *ReporterInfo) string {
+	tbl := CheckRegisterTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
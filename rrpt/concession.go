@@ -0,0 +1,82 @@
+package rrpt
+
+import (
+	"context"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// ConcessionsReportTable lists the concessions posted on rent charges dated
+// in the range ri.D1 - ri.D2: the Rental Agreement, the rentable, the
+// concession account rule and the amount taken off the rent.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func ConcessionsReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "ConcessionsReportTable"
+	var names = map[int64]string{}
+
+	const (
+		Date       = 0
+		RAID       = iota
+		Rentable   = iota
+		Concession = iota
+		ASMID      = iota
+		Amount     = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Concession", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Assessment", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Concessions", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	bid := ri.Xbiz.P.BID
+	m, err := rlib.GetConcessionAssessments(ctx, bid, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); i++ {
+		rname, ok := names[m[i].RID]
+		if !ok {
+			r, err := rlib.GetRentable(ctx, m[i].RID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			rname = r.RentableName
+			names[m[i].RID] = rname
+		}
+
+		tbl.AddRow()
+		tbl.Putd(-1, Date, m[i].Start)
+		tbl.Puts(-1, RAID, rlib.IDtoShortString("RA", m[i].RAID))
+		tbl.Puts(-1, Rentable, rname)
+		tbl.Puts(-1, Concession, rlib.RRdb.BizTypes[bid].AR[m[i].ARID].Name)
+		tbl.Puts(-1, ASMID, rlib.IDtoShortString("ASM", m[i].ASMID))
+		tbl.Putf(-1, Amount, m[i].Amount.Float())
+	}
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Amount})
+	}
+	tbl.TightenColumns()
+	return tbl
+}
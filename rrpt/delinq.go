@@ -168,10 +168,10 @@ func DelinquencyReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table
 			tbl.Puts(-1, RAgr, ra.IDtoString())
 			tbl.Puts(-1, RPayors, payornames)
 			tbl.Puts(-1, RUsers, usernames)
-			tbl.Putf(-1, D0, d2Bal)
-			tbl.Putf(-1, D30, d30Bal)
-			tbl.Putf(-1, D60, d60Bal)
-			tbl.Putf(-1, D90, d90Bal)
+			tbl.Putf(-1, D0, d2Bal.Float())
+			tbl.Putf(-1, D30, d30Bal.Float())
+			tbl.Putf(-1, D60, d60Bal.Float())
+			tbl.Putf(-1, D90, d90Bal.Float())
 		}
 	}
 
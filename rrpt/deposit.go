@@ -47,7 +47,7 @@ func RRreportDeposits(ctx context.Context, ri *ReporterInfo) string {
 		t.Putd(-1, 0, m[i].Dt)
 		t.Puts(-1, 1, m[i].IDtoString())
 		t.Puts(-1, 2, rlib.IDtoString("B", m[i].BID))
-		t.Putf(-1, 3, m[i].Amount)
+		t.Putf(-1, 3, m[i].Amount.Float())
 		t.Puts(-1, 4, s)
 	}
 
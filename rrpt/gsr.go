@@ -88,7 +88,7 @@ func GSRReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
 		tbl.Puts(-1, 1, r.RentableName)
 		tbl.Puts(-1, 2, ri.Xbiz.RT[rtr.RTID].Name)
 		tbl.Puts(-1, 3, ri.Xbiz.RT[rtr.RTID].Style)
-		tbl.Putf(-1, 4, amt)
+		tbl.Putf(-1, 4, amt.Float())
 		tbl.Puts(-1, 5, rlib.RentalPeriodToString(rc))
 		tbl.Puts(-1, 6, rlib.RentalPeriodToString(pc))
 	}
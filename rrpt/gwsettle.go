@@ -0,0 +1,114 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// GatewaySettlementReportTable lists the payouts the payment gateways made
+// in the range ri.D1 - ri.D2 and the Deposit each one was matched to. A
+// payout with no matching deposit, or with transactions that were not made
+// through RentRoll, is flagged. ri.ID is the PGID of the gateway, or 0 for
+// all the active gateways of the business.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func GatewaySettlementReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "GatewaySettlementReportTable"
+
+	const (
+		Gateway = 0
+		Payout  = iota
+		Dt      = iota
+		Txns    = iota
+		Gross   = iota
+		Fee     = iota
+		Net     = iota
+		DID     = iota
+		DepAmt  = iota
+		Status  = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Gateway", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payout", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Arrival", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Transactions", 5, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Gross", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Fee", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Net", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Deposit", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Deposit Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Status", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Card Payment Settlement", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	bid := ri.Xbiz.P.BID
+	m, err := rlib.GetPaymentGatewaysByBID(ctx, bid)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	var msgs []string
+	for i := 0; i < len(m); i++ {
+		if (ri.ID > 0 && m[i].PGID != ri.ID) || (ri.ID == 0 && m[i].FLAGS&rlib.PGInactive != 0) {
+			continue
+		}
+		s, err := rlib.GatewaySettlements(ctx, rlib.NewPaymentGatewayAPI(&m[i]), &m[i], &ri.D1, &ri.D2)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			msgs = append(msgs, fmt.Sprintf("%s: %s", m[i].Name, err.Error()))
+			continue
+		}
+		for j := 0; j < len(s); j++ {
+			tbl.AddRow()
+			tbl.Puts(-1, Gateway, m[i].Name)
+			tbl.Puts(-1, Payout, s[j].Payout.ID)
+			tbl.Putd(-1, Dt, s[j].Payout.Dt)
+			tbl.Puti(-1, Txns, int64(len(s[j].GTIDs)+len(s[j].Unknown)))
+			tbl.Putf(-1, Gross, s[j].Gross.Float())
+			tbl.Putf(-1, Fee, s[j].Payout.Fee.Float())
+			tbl.Putf(-1, Net, s[j].Payout.Amount.Float())
+			status := "matched"
+			if s[j].DID > 0 {
+				dep, err := rlib.GetDeposit(ctx, s[j].DID)
+				if err != nil {
+					rlib.LogAndPrintError(funcname, err)
+					tbl.SetSection3(err.Error())
+					return tbl
+				}
+				tbl.Puts(-1, DID, rlib.IDtoShortString("DEP", dep.DID))
+				tbl.Putf(-1, DepAmt, dep.Amount.Float())
+			} else {
+				status = "no matching deposit"
+			}
+			if len(s[j].Unknown) > 0 {
+				status += fmt.Sprintf(", %d not recorded: %s", len(s[j].Unknown), strings.Join(s[j].Unknown, " "))
+			}
+			tbl.Puts(-1, Status, status)
+		}
+	}
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Gross, Fee, Net, DepAmt})
+	}
+	if len(msgs) > 0 {
+		tbl.SetSection3(strings.Join(msgs, "\n"))
+	}
+	tbl.TightenColumns()
+	return tbl
+}
@@ -0,0 +1,94 @@
+package rrpt
+
+import "strings"
+
+// SingleTableReports is the list of reports that are rendered from a single
+// table. The first ReportName is the report id used in requests, the second
+// is the readable name used for attachment names.
+var SingleTableReports = []SingleTableReportHandler{
+	{ReportNames: []string{"RPT1099", "vendor 1099 totals"}, TableHandler: Vendor1099ReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTapaging", "ap aging"}, TableHandler: APAgingReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTar", "account rules"}, TableHandler: RRARTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTasmrpt", "assessments"}, TableHandler: RRAssessmentsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTb", "business"}, TableHandler: RRreportBusinessTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTc", "custom attributes"}, TableHandler: RRreportCustomAttributesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTcamrecon", "cam reconciliation"}, TableHandler: CAMReconTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTcamstmt", "cam statement"}, TableHandler: CAMStatementTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTcheck", "check"}, TableHandler: RRCheckTable, PDFprops: CheckPDFProps, HTMLTemplate: "check.html", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
+	{ReportNames: []string{"RPTckreg", "check register"}, TableHandler: CheckRegisterTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTcoa", "chart of accounts"}, TableHandler: RRreportChartOfAccountsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTconcessions", "concessions"}, TableHandler: ConcessionsReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTcr", "custom attribute refs"}, TableHandler: RRreportCustomAttributeRefsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTdelinq", "delinquency"}, TableHandler: DelinquencyReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTdep", "depositories"}, TableHandler: RRreportDepositoryTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTdpm", "deposit methods"}, TableHandler: RRreportDepositMethodsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTgsr", "gsr"}, TableHandler: GSRReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTgwsettle", "card payment settlement"}, TableHandler: GatewaySettlementReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTj", "journals"}, TableHandler: JournalReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTnightaudit", "night audit"}, TableHandler: NightAuditReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTpayorstmt", "payor statements"}, TableHandler: RRPayorStatement, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTpctrent", "percentage rent"}, TableHandler: PctRentReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTpeople", "people"}, TableHandler: RRreportPeopleTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTpmt", "payment types"}, TableHandler: RRreportPaymentTypesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTr", "rentables"}, TableHandler: RRreportRentablesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTra", "rental agreements"}, TableHandler: RRreportRentalAgreementsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrastmt", "rental agreement statements"}, TableHandler: RRRentalAgreementStatements, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrat", "rental agreement templates"}, TableHandler: RRreportRentalAgreementTemplatesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrcbt", "rentable type counts"}, TableHandler: RentableCountByRentableTypeReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrcpt", "receipt"}, TableHandler: RRRcptOnlyReceiptTable, PDFprops: ReceiptPDFProps, HTMLTemplate: "receipt.html", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
+	{ReportNames: []string{"RPTrcpthotel", ""}, TableHandler: RRRcptHotelReceiptTable, PDFprops: ReceiptPDFProps, HTMLTemplate: "rcpthotel.html", NeedsCustomPDFDimension: false, NeedsPDFTitle: false},
+	{ReportNames: []string{"RPTrcptlist", "receipts"}, TableHandler: RRReceiptsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrentchanges", "upcoming rent changes"}, TableHandler: RentChangesReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrr", "rentroll"}, TableHandler: RRReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTrt", "rentable types"}, TableHandler: RRreportRentableTypesTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTsl", "string lists"}, TableHandler: RRreportStringListsTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTt", "people"}, TableHandler: RRreportPeopleTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTtb", "trial balance"}, TableHandler: LedgerBalanceReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTtl", "task list"}, TableHandler: TaskListReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTutilitybill", "utility bill"}, TableHandler: UtilityBillTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+	{ReportNames: []string{"RPTwoaging", "work order aging"}, TableHandler: WorkOrderAgingReportTable, PDFprops: nil, HTMLTemplate: "", NeedsCustomPDFDimension: true, NeedsPDFTitle: true},
+}
+
+// MultiTableReports is the list of reports that are rendered from more than
+// one table.
+var MultiTableReports = []MultiTableReportHandler{
+	{ReportTitle: "Ledger", ReportNames: []string{"RPTl", "ledger"}, TableHandler: LedgerReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+	{ReportTitle: "Ledger Activity", ReportNames: []string{"RPTla", "ledger activity"}, TableHandler: LedgerActivityReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+	{ReportTitle: "Occupancy Trend", ReportNames: []string{"RPToccupancy", "occupancy trend"}, TableHandler: OccupancyTrendReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+	{ReportTitle: "Rent Roll Changes", ReportNames: []string{"RPTrrdiff", "rent roll changes"}, TableHandler: RentRollDiffReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+	{ReportTitle: "Report Statements", ReportNames: []string{"RPTstatements", "report statements"}, TableHandler: RptStatementReportTable, PDFprops: nil, NeedsCustomPDFDimension: true},
+}
+
+// FindSingleTableReport returns the handler for the single table report with
+// the supplied name. The returned handler's Found flag is false if there is
+// no such report.
+func FindSingleTableReport(name string) SingleTableReportHandler {
+	var tsh SingleTableReportHandler
+	for j := 0; j < len(SingleTableReports); j++ {
+		for _, rn := range SingleTableReports[j].ReportNames {
+			if strings.ToLower(rn) == strings.ToLower(name) {
+				tsh = SingleTableReports[j]
+				tsh.Found = true
+				return tsh
+			}
+		}
+	}
+	return tsh
+}
+
+// FindMultiTableReport returns the handler for the multi table report with
+// the supplied name. The returned handler's Found flag is false if there is
+// no such report.
+func FindMultiTableReport(name string) MultiTableReportHandler {
+	var tmh MultiTableReportHandler
+	for j := 0; j < len(MultiTableReports); j++ {
+		for _, rn := range MultiTableReports[j].ReportNames {
+			if strings.ToLower(rn) == strings.ToLower(name) {
+				tmh = MultiTableReports[j]
+				tmh.Found = true
+				return tmh
+			}
+		}
+	}
+	return tmh
+}
@@ -82,7 +82,7 @@ func InvoiceTextReport(ctx context.Context, id int64) error {
 	}
 	fmt.Printf("%-15s %s\n\n", "Delivered By:", inv.DeliveredBy)
 
-	fmt.Printf("%-15s %s\n", "Amount Due:", rlib.RRCommaf(inv.Amount))
+	fmt.Printf("%-15s %s\n", "Amount Due:", rlib.RRCommaf(inv.Amount.Float()))
 	fmt.Printf("%-15s %s\n", "Date Due:", inv.DtDue.Format(rlib.RRDATEFMT3))
 	fmt.Printf("\n")
 
@@ -96,7 +96,7 @@ func InvoiceTextReport(ctx context.Context, id int64) error {
 		sep += "-"
 	}
 	fmt.Printf("%s\n", sep)
-	var tot = float64(0)
+	var tot = rlib.Money(0)
 	for i := 0; i < len(inv.A); i++ {
 		a, err := rlib.GetAssessment(ctx, inv.A[i].ASMID)
 		if err != nil {
@@ -112,11 +112,11 @@ func InvoiceTextReport(ctx context.Context, id int64) error {
 		fmt.Printf("%-10s  %-12s  %-15s  %-40.40s  %12s  %20s\n", a.Start.Format(rlib.RRDATEFMT3), a.IDtoString(),
 			r.RentableName,
 			"", /*rlib.RRdb.BizTypes[biz.BID].GLAccounts[a.ATypeLID].Name*/
-			rlib.RRCommaf(a.Amount), a.Comment)
+			rlib.RRCommaf(a.Amount.Float()), a.Comment)
 		tot += a.Amount
 	}
 	fmt.Printf("%s\n", sep)
-	fmt.Printf("%-10s  %12s  %15s  %-40s  %12s\n", "Total", " ", " ", " ", rlib.RRCommaf(tot))
+	fmt.Printf("%-10s  %12s  %15s  %-40s  %12s\n", "Total", " ", " ", " ", rlib.RRCommaf(tot.Float()))
 
 	return err
 }
@@ -154,7 +154,7 @@ func RRreportInvoices(ctx context.Context, ri *ReporterInfo) string {
 		tbl.Puts(-1, 1, m[i].IDtoString())
 		tbl.Puts(-1, 2, rlib.IDtoString("B", m[i].BID))
 		tbl.Putd(-1, 3, m[i].DtDue)
-		tbl.Putf(-1, 4, m[i].Amount)
+		tbl.Putf(-1, 4, m[i].Amount.Float())
 		tbl.Puts(-1, 5, m[i].DeliveredBy)
 	}
 
@@ -20,7 +20,7 @@ type jprintctx struct {
 // 	tbl.SetTitle(s)
 // }
 
-func processAcctRuleAmount(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBusiness, rid int64, d time.Time, rule string, raid int64, r *rlib.Rentable, amt float64) error {
+func processAcctRuleAmount(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBusiness, rid int64, d time.Time, rule string, raid int64, r *rlib.Rentable, amt rlib.Money) error {
 	const funcname = "processAcctRuleAmount"
 	var (
 		err error
@@ -37,17 +37,9 @@ func processAcctRuleAmount(ctx context.Context, tbl *gotable.Table, xbiz *rlib.X
 			amt = -amt
 		}
 
-		// ---------------------------------------------------------
-		// This code essentially skips amounts that calculate
-		// to something less than .0001 cents (a rounding error)
-		x := amt
-		if x < 0 {
-			x = -x
+		if amt == 0 {
+			continue // skip amounts that calculate to 0.00
 		}
-		if x < 0.0001 {
-			continue
-		}
-		// ---------------------------------------------------------
 
 		l, err := rlib.GetLedgerByGLNo(ctx, xbiz.P.BID, m[i].Account)
 		if err != nil {
@@ -73,7 +65,7 @@ func processAcctRuleAmount(ctx context.Context, tbl *gotable.Table, xbiz *rlib.X
 		tbl.Puts(-1, 3, rlib.IDtoShortString("RA", raid))
 		tbl.Puts(-1, 4, r.RentableName)
 		tbl.Puts(-1, 5, m[i].Account)
-		tbl.Putf(-1, 6, amt)
+		tbl.Putf(-1, 6, amt.Float())
 	}
 
 	return err
@@ -212,7 +204,7 @@ func printJournalExpense(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBu
 		tbl.Puts(-1, 3, raid)
 		tbl.Puts(-1, 4, rn)
 		tbl.Puts(-1, 5, rlib.RRdb.BizTypes[j.BID].GLAccounts[dlid].GLNumber)
-		tbl.Putf(-1, 6, j.Amount)
+		tbl.Putf(-1, 6, j.Amount.Float())
 
 		tbl.AddRow()
 		tbl.Puts(-1, 1, rlib.RRdb.BizTypes[j.BID].GLAccounts[clid].Name)
@@ -220,7 +212,7 @@ func printJournalExpense(ctx context.Context, tbl *gotable.Table, xbiz *rlib.XBu
 		tbl.Puts(-1, 3, raid)
 		tbl.Puts(-1, 4, rn)
 		tbl.Puts(-1, 5, rlib.RRdb.BizTypes[j.BID].GLAccounts[clid].GLNumber)
-		tbl.Putf(-1, 6, -j.Amount)
+		tbl.Putf(-1, 6, -j.Amount.Float())
 	}
 
 	tbl.AddRow() // nothing in this line, it's blank
@@ -360,7 +352,7 @@ func textPrintJournalReceipt(ctx context.Context, tbl *gotable.Table, ri *Report
 			tbl.Puts(-1, 3, rs)
 			tbl.Puts(-1, 4, r.RentableName)
 			tbl.Puts(-1, 5, m[k].Account)
-			tbl.Putf(-1, 6, amt)
+			tbl.Putf(-1, 6, amt.Float())
 		}
 	}
 	tbl.AddRow() // nothing in this line, it's blank
@@ -429,14 +421,14 @@ func textPrintJournalXfer(tbl *gotable.Table, ri *ReporterInfo, jctx *jprintctx,
 			tbl.Putd(-1, 2, j.Dt)
 			tbl.Puts(-1, 3, rlib.IDtoShortString("RA", j.JA[0].RAID))
 			tbl.Puts(-1, 5, rlib.RRdb.BizTypes[j.BID].GLAccounts[clid].GLNumber)
-			tbl.Putf(-1, 6, -j.Amount)
+			tbl.Putf(-1, 6, -j.Amount.Float())
 
 			tbl.AddRow()
 			tbl.Puts(-1, 1, "to "+rlib.RRdb.BizTypes[j.BID].GLAccounts[dlid].Name)
 			tbl.Putd(-1, 2, j.Dt)
 			tbl.Puts(-1, 3, rlib.IDtoShortString("RA", j.JA[0].RAID))
 			tbl.Puts(-1, 5, rlib.RRdb.BizTypes[j.BID].GLAccounts[dlid].GLNumber)
-			tbl.Putf(-1, 6, j.Amount)
+			tbl.Putf(-1, 6, j.Amount.Float())
 		}
 	}
 	tbl.AddRow() // nothing in this line, it's blank
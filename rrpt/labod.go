@@ -14,12 +14,12 @@ func LdgAcctBalOnDateTextReport(ctx context.Context, xbiz *rlib.XBusiness, lid,
 		fmt.Printf("Error while getting balance for RA%08d - %s\n", raid, err.Error())
 	}
 	fmt.Printf("Account Balance of Ledger L%08d (%s) for RA%08d as of %s:  %s\n",
-		lid, rlib.RRdb.BizTypes[xbiz.P.BID].GLAccounts[lid].Name, raid, dt.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal))
+		lid, rlib.RRdb.BizTypes[xbiz.P.BID].GLAccounts[lid].Name, raid, dt.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal.Float()))
 }
 
 // RAAccountActivityRangeDetail generates a report of the ledger entries that affect the RentalAgreements ledger during d1-d2
 func RAAccountActivityRangeDetail(ctx context.Context, xbiz *rlib.XBusiness, lid, raid int64, d1, d2 *time.Time) {
-	var bal = float64(0)
+	var bal = rlib.Money(0)
 	m, err := rlib.GetLedgerEntriesForRAID(ctx, d1, d2, raid, lid)
 	if err != nil {
 		fmt.Printf("RAAccountActivityRangeDetail: GetLedgerEntriesForRAID returned error: %s\n", err.Error())
@@ -58,7 +58,7 @@ func RAAccountActivityRangeDetail(ctx context.Context, xbiz *rlib.XBusiness, lid
 	displayD2 := d2
 	rlib.HandleStopDateEDI(xbiz.P.BID, displayD2)
 
-	fmt.Printf("Account Balance on %10s  -  %10s\n", d1.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal1))
-	fmt.Printf("Account Balance on %10s  -  %10s\n", displayD2.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal2))
+	fmt.Printf("Account Balance on %10s  -  %10s\n", d1.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal1.Float()))
+	fmt.Printf("Account Balance on %10s  -  %10s\n", displayD2.Format(rlib.RRDATEFMT4), rlib.RRCommaf(bal2.Float()))
 	fmt.Printf("Change ---> %8.2f\n", bal2-bal1)
 }
@@ -46,7 +46,7 @@ func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Tab
 				tbl.SetSection3(err.Error())
 				return tbl
 			}
-			tbl.Putf(-1, 3, b)
+			tbl.Putf(-1, 3, b.Float())
 		} else {
 			b, err := rlib.GetAccountBalance(ctx, bid, acct.LID, &ri.D2)
 			if err != nil {
@@ -54,7 +54,7 @@ func LedgerBalanceReportTable(ctx context.Context, ri *ReporterInfo) gotable.Tab
 				tbl.SetSection3(err.Error())
 				return tbl
 			}
-			tbl.Putf(-1, 2, b)
+			tbl.Putf(-1, 2, b.Float())
 		}
 	}
 	tbl.Sort(0, len(tbl.Row)-1, 0)
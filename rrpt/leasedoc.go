@@ -0,0 +1,53 @@
+package rrpt
+
+import (
+	"bytes"
+	"fmt"
+	"gotable"
+	"os/exec"
+	"strings"
+)
+
+// LeaseDocPDFProps are the wkhtmltopdf properties used to print a lease:
+// US Letter with room for a page number in the footer.
+var LeaseDocPDFProps = []*gotable.PDFProperty{
+	{Option: "--page-size", Value: "Letter"},
+	{Option: "--orientation", Value: "Portrait"},
+	{Option: "--margin-top", Value: "20"},
+	{Option: "--margin-bottom", Value: "20"},
+	{Option: "--margin-left", Value: "20"},
+	{Option: "--margin-right", Value: "20"},
+	{Option: "--footer-font-size", Value: "8"},
+	{Option: "--footer-spacing", Value: "5"},
+	{Option: "--footer-center", Value: "Page [page] of [toPage]"},
+}
+
+// LeaseDocPDF converts the HTML of a lease into a PDF with wkhtmltopdf.
+//
+// INPUTS
+//  html = the lease, usually from rlib.RenderLeaseDocument
+//
+// RETURNS
+//  the PDF
+//  any error encountered
+//-----------------------------------------------------------------------------
+func LeaseDocPDF(html string) ([]byte, error) {
+	var args []string
+	for _, p := range LeaseDocPDFProps {
+		args = append(args, p.Option)
+		if len(p.Value) > 0 {
+			args = append(args, p.Value)
+		}
+	}
+	args = append(args, "--quiet", "-", "-") // read stdin, write stdout
+
+	var out, stderr bytes.Buffer
+	cmd := exec.Command("wkhtmltopdf", args...)
+	cmd.Stdin = strings.NewReader(html)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("wkhtmltopdf: %s %s", err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return out.Bytes(), nil
+}
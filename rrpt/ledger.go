@@ -127,7 +127,7 @@ func reportTextProcessLedgerMarker(ctx context.Context, tbl *gotable.Table, xbiz
 	// printLedgerDescrAndBal("Opening Balance", *d1, lm.Balance)
 	tbl.AddRow()
 	tbl.Puts(-1, 0, "Opening Balance")
-	tbl.Putf(-1, 6, lm.Balance)
+	tbl.Putf(-1, 6, lm.Balance.Float())
 
 	// rows, err := rlib.RRdb.Prepstmt.GetLedgerEntriesInRangeByGLNo.Query(l.BID, l.GLNumber, d1, d2)
 	rows, err := rlib.RRdb.Prepstmt.GetLedgerEntriesInRangeByLID.Query(l.BID, l.LID, d1, d2)
@@ -153,8 +153,8 @@ func reportTextProcessLedgerMarker(ctx context.Context, tbl *gotable.Table, xbiz
 		tbl.Puts(-1, 2, rlib.IDtoString("J", l.JID))
 		tbl.Puts(-1, 3, sra)
 		tbl.Puts(-1, 4, rn)
-		tbl.Putf(-1, 5, l.Amount)
-		tbl.Putf(-1, 6, bal)
+		tbl.Putf(-1, 5, l.Amount.Float())
+		tbl.Putf(-1, 6, bal.Float())
 	}
 	err = rows.Err()
 	if err != nil {
@@ -167,7 +167,7 @@ func reportTextProcessLedgerMarker(ctx context.Context, tbl *gotable.Table, xbiz
 	tbl.AddRow()
 	tbl.Puts(-1, 0, "Closing Balance")
 	tbl.Putd(-1, 1, d2.AddDate(0, 0, -1))
-	tbl.Putf(-1, 6, bal)
+	tbl.Putf(-1, 6, bal.Float())
 	// fmt.Printf("\n\n")
 }
 
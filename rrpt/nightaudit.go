@@ -0,0 +1,95 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// NightAuditReportTable is the night audit report of the night of ri.D1:
+// the room statistics, the revenue by account rule, the problems found with
+// the guests in house and the no-shows.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func NightAuditReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "NightAuditReportTable"
+
+	const (
+		Section = 0
+		Item    = iota
+		Value   = iota
+		Amount  = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Section", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Item", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Value", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = false
+	err := TableReportHeaderBlock(ctx, &tbl, "Night Audit", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	r, err := rlib.GetNightAuditReport(ctx, ri.Xbiz, &ri.D1)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	if !r.Locked {
+		tbl.SetSection3("The night audit has not closed this day, the figures may change.")
+	}
+
+	stat := func(item, val string) {
+		tbl.AddRow()
+		tbl.Puts(-1, Section, "Statistics")
+		tbl.Puts(-1, Item, item)
+		tbl.Puts(-1, Value, val)
+	}
+	stat("Rooms", fmt.Sprintf("%d", r.Rooms))
+	stat("Out of order", fmt.Sprintf("%d", r.OutOfOrder))
+	stat("Occupied", fmt.Sprintf("%d", r.Occupied))
+	stat("Occupancy", fmt.Sprintf("%.1f%%", r.Occupancy*100))
+	stat("ADR", r.ADR.String())
+	stat("RevPAR", r.RevPAR.String())
+	stat("Room revenue", r.RoomRevenue.String())
+
+	for i := 0; i < len(r.Revenue); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, Section, "Revenue")
+		tbl.Puts(-1, Item, r.Revenue[i].Name)
+		tbl.Putf(-1, Amount, r.Revenue[i].Amount.Float())
+	}
+	for i := 0; i < len(r.InHouse); i++ {
+		if len(r.InHouse[i].Problems) == 0 {
+			continue
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, Section, "Exception")
+		tbl.Puts(-1, Item, fmt.Sprintf("Reservation %d, %s", r.InHouse[i].Res.RESID, rlib.IDtoShortString("RA", r.InHouse[i].Res.RAID)))
+		tbl.Puts(-1, Value, strings.Join(r.InHouse[i].Problems, "; "))
+	}
+	for i := 0; i < len(r.NoShows); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, Section, "No-show")
+		tbl.Puts(-1, Item, fmt.Sprintf("Reservation %d", r.NoShows[i].RESID))
+		tbl.Puts(-1, Value, fmt.Sprintf("%s - %s", r.NoShows[i].DtArrive.Format(rlib.RRDATEFMT3), r.NoShows[i].DtDepart.Format(rlib.RRDATEFMT3)))
+		tbl.Putf(-1, Amount, r.NoShows[i].Rate.Float())
+	}
+	tbl.TightenColumns()
+	return tbl
+}
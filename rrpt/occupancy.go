@@ -0,0 +1,98 @@
+package rrpt
+
+import (
+	"context"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// initOccupancyColumns adds the columns common to both occupancy trend tables
+func initOccupancyColumns(tbl *gotable.Table) {
+	tbl.AddColumn("Month", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)          // 0  period
+	tbl.AddColumn("Rentable Type", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)  // 1  rentable type
+	tbl.AddColumn("Rentables", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)         // 2  count
+	tbl.AddColumn("Physical Occ %", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT) // 3  physical occupancy
+	tbl.AddColumn("GSR", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)            // 4  gross scheduled rent
+	tbl.AddColumn("Collected", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)      // 5  rent collected
+	tbl.AddColumn("Economic Occ %", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT) // 6  economic occupancy
+	tbl.AddColumn("Vacancy Loss", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)   // 7  vacancy loss
+	tbl.AddColumn("Concessions", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)    // 8  concessions
+	tbl.AddColumn("Avg Days Vacant", 9, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT) // 9  average days vacant
+}
+
+// addOccupancyRow adds a row for the supplied stats to tbl
+func addOccupancyRow(tbl *gotable.Table, s *rlib.OccupancyStats) {
+	tbl.AddRow()
+	tbl.Puts(-1, 0, s.DtStart.Format("Jan 2006"))
+	tbl.Puts(-1, 1, s.RentableType)
+	tbl.Puti(-1, 2, s.Rentables)
+	tbl.Putf(-1, 3, s.PhysicalOccupancy)
+	tbl.Putf(-1, 4, s.GSR.Float())
+	tbl.Putf(-1, 5, s.Collected.Float())
+	tbl.Putf(-1, 6, s.EconomicOccupancy)
+	tbl.Putf(-1, 7, s.VacancyLoss.Float())
+	tbl.Putf(-1, 8, s.Concessions.Float())
+	tbl.Putf(-1, 9, s.AvgDaysVacant)
+}
+
+// OccupancyTrendReportTable generates the month-by-month occupancy trend for
+// the business over ri.D1 - ri.D2. The first table has the business totals for
+// each month, the second breaks each month down by RentableType.
+func OccupancyTrendReportTable(ctx context.Context, ri *ReporterInfo) ([]gotable.Table, error) {
+	const funcname = "OccupancyTrendReportTable"
+	var (
+		err error
+		m   []gotable.Table
+	)
+
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	tbl := getRRTable()
+	initOccupancyColumns(&tbl)
+	err = TableReportHeaderBlock(ctx, &tbl, "Occupancy Trend", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return m, err
+	}
+
+	dtl := getRRTable()
+	initOccupancyColumns(&dtl)
+	err = TableReportHeaderBlock(ctx, &dtl, "Occupancy Trend By Rentable Type", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		dtl.SetSection3(err.Error())
+		return m, err
+	}
+
+	trend, err := rlib.GetOccupancyTrend(ctx, ri.Xbiz, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return m, err
+	}
+
+	for i := 0; i < len(trend); i++ {
+		addOccupancyRow(&tbl, &trend[i].Total)
+		for j := 0; j < len(trend[i].Types); j++ {
+			addOccupancyRow(&dtl, &trend[i].Types[j])
+		}
+	}
+
+	m = append(m, tbl, dtl)
+	return m, err
+}
+
+// OccupancyTrendReport returns a text based report from OccupancyTrendReportTable
+func OccupancyTrendReport(ctx context.Context, ri *ReporterInfo) string {
+	m, err := OccupancyTrendReportTable(ctx, ri)
+	if err != nil {
+		return "Error while creating occupancy trend report: " + err.Error()
+	}
+	var s string
+	for _, tbl := range m {
+		s += ReportToString(&tbl, ri) + "\n"
+	}
+	return s
+}
@@ -125,9 +125,9 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 			t.Puts(-1, Payor, rlib.GetNameFromTransactantCache(ctx, m.RL[i].R.TCID, payorcache))
 			t.Puts(-1, RCPTID, rlib.IDtoShortString("RCPT", m.RL[i].R.RCPTID))
 			t.Puts(-1, Description, "Receipt "+m.RL[i].R.DocNo)
-			t.Putf(-1, UnappliedFunds, m.RL[i].Unallocated)
-			t.Putf(-1, AppliedFunds, m.RL[i].Allocated)
-			t.Putf(-1, Balance, m.RL[i].R.Amount)
+			t.Putf(-1, UnappliedFunds, m.RL[i].Unallocated.Float())
+			t.Putf(-1, AppliedFunds, m.RL[i].Allocated.Float())
+			t.Putf(-1, Balance, m.RL[i].R.Amount.Float())
 		}
 	}
 	t.AddRow()
@@ -173,9 +173,9 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 					t.Puts(-1, RAID, rlib.IDtoShortString("RA", l1[0].RAID))
 					t.Puts(-1, RCPTID, rlib.IDtoShortString("RCPT", m.RL[i].R.RCPTID))
 					t.Puts(-1, Description, "Receipt "+m.RL[i].R.DocNo)
-					t.Putf(-1, UnappliedFunds, m.RL[i].Unallocated)
-					t.Putf(-1, AppliedFunds, m.RL[i].Allocated)
-					t.Putf(-1, Balance, m.RL[i].R.Amount)
+					t.Putf(-1, UnappliedFunds, m.RL[i].Unallocated.Float())
+					t.Putf(-1, AppliedFunds, m.RL[i].Allocated.Float())
+					t.Putf(-1, Balance, m.RL[i].R.Amount.Float())
 				} else {
 					rlib.Console("PayorStatment: J\n")
 					t.Puts(-1, Description, "TBD")
@@ -207,13 +207,13 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 		t.AddRow()
 		t.Puts(-1, Description, "Opening balance")
 		t.Putd(-1, Date, m.RAB[i].DtStart)
-		t.Putf(-1, Balance, m.RAB[i].OpeningBal)
+		t.Putf(-1, Balance, m.RAB[i].OpeningBal.Float())
 
 		//------------------------
 		// init running totals
 		//------------------------
 		bal := m.RAB[i].OpeningBal
-		asmts := float64(0)
+		asmts := rlib.Money(0)
 		applied := asmts
 		// unapplied := asmts
 
@@ -234,7 +234,7 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 
 			switch m.RAB[i].Stmt[j].T {
 			case 1: // assessments
-				t.Putf(-1, Assessment, amt)
+				t.Putf(-1, Assessment, amt.Float())
 				if m.RAB[i].Stmt[j].A.ARID > 0 { // The description will be the name of the Account Rule...
 					descr += rlib.RRdb.BizTypes[bid].AR[m.RAB[i].Stmt[j].A.ARID].Name
 				} /*else {descr += rlib.RRdb.BizTypes[bid].GLAccounts[m.RAB[i].Stmt[j].A.ATypeLID].Name }*/
@@ -262,7 +262,7 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 					descr += " (" + m.RAB[i].Stmt[j].A.Comment + ")"
 				}
 			case 2: // receipts
-				t.Putf(-1, AppliedFunds, amt)
+				t.Putf(-1, AppliedFunds, amt.Float())
 				rcptid := m.RAB[i].Stmt[j].R.RCPTID
 				descr += "Receipt allocation"
 				if rcptid > 0 {
@@ -296,16 +296,16 @@ func PayorStatement(ctx context.Context, bid, tcid int64, d1, d2 *time.Time, int
 					}
 				}
 			}
-			t.Putf(-1, Balance, bal)
+			t.Putf(-1, Balance, bal.Float())
 			t.Puts(-1, Description, descr)
 		}
 		t.AddLineAfter(len(t.Row) - 1)
 		t.AddRow()
 		t.Putd(-1, Date, m.RAB[i].DtStop)
 		t.Puts(-1, Description, "Closing balance")
-		t.Putf(-1, AppliedFunds, applied)
-		t.Putf(-1, Assessment, asmts)
-		t.Putf(-1, Balance, m.RAB[i].ClosingBal)
+		t.Putf(-1, AppliedFunds, applied.Float())
+		t.Putf(-1, Assessment, asmts.Float())
+		t.Putf(-1, Balance, m.RAB[i].ClosingBal.Float())
 		t.AddRow()
 	}
 	return t
@@ -0,0 +1,104 @@
+package rrpt
+
+import (
+	"context"
+	"gotable"
+	"rentroll/rlib"
+	"strings"
+)
+
+// PctRentReportTable lists the sales reports of the tenants paying
+// percentage rent for periods starting in the range ri.D1 - ri.D2, with the
+// breakpoint and the overage rent of each. Reports whose period has not
+// ended or that the PctRentBot has not processed yet are shown as pending.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func PctRentReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "PctRentReportTable"
+	var names = map[int64]string{}
+
+	const (
+		RAID       = 0
+		Tenant     = iota
+		DtStart    = iota
+		DtStop     = iota
+		GrossSales = iota
+		Breakpoint = iota
+		Overage    = iota
+		Status     = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Tenant", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Period Start", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Period End", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Gross Sales", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Breakpoint", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Overage", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Assessment", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Percentage Rent", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetSalesReportsByRange(ctx, ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); i++ {
+		tenant, ok := names[m[i].RAID]
+		if !ok {
+			ra, err := rlib.GetRentalAgreement(ctx, m[i].RAID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			payors, err := ra.GetPayorNameList(ctx, &m[i].DtStart, &m[i].DtStop)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			tenant = strings.Join(payors, ", ")
+			names[m[i].RAID] = tenant
+		}
+
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, rlib.IDtoShortString("RA", m[i].RAID))
+		tbl.Puts(-1, Tenant, tenant)
+		tbl.Putd(-1, DtStart, m[i].DtStart)
+		tbl.Putd(-1, DtStop, m[i].DtStop.AddDate(0, 0, -1))
+		tbl.Putf(-1, GrossSales, m[i].GrossSales.Float())
+		switch {
+		case m[i].FLAGS&rlib.SALESRPTComputed == 0:
+			tbl.Puts(-1, Status, "pending")
+		case m[i].ASMID > 0:
+			tbl.Putf(-1, Breakpoint, m[i].Breakpoint.Float())
+			tbl.Putf(-1, Overage, m[i].Overage.Float())
+			tbl.Puts(-1, Status, rlib.IDtoShortString("ASM", m[i].ASMID))
+		default:
+			tbl.Putf(-1, Breakpoint, m[i].Breakpoint.Float())
+			tbl.Putf(-1, Overage, m[i].Overage.Float())
+			tbl.Puts(-1, Status, "none due")
+		}
+	}
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{GrossSales, Overage})
+	}
+	tbl.TightenColumns()
+	return tbl
+}
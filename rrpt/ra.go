@@ -233,7 +233,7 @@ func RRRentalAgreementStatementTable(ctx context.Context, BID, RAID int64, d1, d
 	//--------------------------------------------
 	// Set the opening balance.
 	//--------------------------------------------
-	var b, c, d float64
+	var b, c, d rlib.Money
 	b = m.OpeningBal
 
 	tbl.AddRow()
@@ -241,9 +241,9 @@ func RRRentalAgreementStatementTable(ctx context.Context, BID, RAID int64, d1, d
 	tbl.Puts(-1, ID, "")
 	tbl.Puts(-1, Rentable, "")
 	tbl.Puts(-1, Description, "Opening Balance")
-	tbl.Putf(-1, Assessment, c)
-	tbl.Putf(-1, AppliedFunds, d)
-	tbl.Putf(-1, Balance, m.ClosingBal)
+	tbl.Putf(-1, Assessment, c.Float())
+	tbl.Putf(-1, AppliedFunds, d.Float())
+	tbl.Putf(-1, Balance, m.ClosingBal.Float())
 
 	if len(m.Stmt) == 0 {
 		tbl.AddRow()
@@ -306,9 +306,9 @@ func RRRentalAgreementStatementTable(ctx context.Context, BID, RAID int64, d1, d
 			tbl.Puts(-1, ID, id)
 			tbl.Puts(-1, Rentable, m.Stmt[i].RNT.RentableName)
 			tbl.Puts(-1, Description, descr)
-			tbl.Putf(-1, Assessment, c)
-			tbl.Putf(-1, AppliedFunds, d)
-			tbl.Putf(-1, Balance, b)
+			tbl.Putf(-1, Assessment, c.Float())
+			tbl.Putf(-1, AppliedFunds, d.Float())
+			tbl.Putf(-1, Balance, b.Float())
 		}
 	}
 
@@ -318,9 +318,9 @@ func RRRentalAgreementStatementTable(ctx context.Context, BID, RAID int64, d1, d
 	tbl.Puts(-1, ID, "")
 	tbl.Puts(-1, Rentable, "")
 	tbl.Puts(-1, Description, "Closing Balance")
-	tbl.Putf(-1, Assessment, c)
-	tbl.Putf(-1, AppliedFunds, d)
-	tbl.Putf(-1, Balance, m.ClosingBal)
+	tbl.Putf(-1, Assessment, c.Float())
+	tbl.Putf(-1, AppliedFunds, d.Float())
+	tbl.Putf(-1, Balance, m.ClosingBal.Float())
 
 	return tbl
 }
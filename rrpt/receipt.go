@@ -132,7 +132,7 @@ func rcptOnlyReceiptTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
 
 	tbl.AddRow()
 	tbl.Puts(-1, 0, "Amount")
-	tbl.Puts(-1, 1, "$"+rlib.RRCommaf(m.Amount))
+	tbl.Puts(-1, 1, "$"+rlib.RRCommaf(m.Amount.Float()))
 
 	tbl.AddRow()
 	tbl.Puts(-1, 0, "Form of Payment")
@@ -74,7 +74,7 @@ func RRReceiptsTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
 		tbl.Puts(-1, PRCPTID, rlib.IDtoString("RCPT", a.PRCPTID))
 		tbl.Puts(-1, PMTID, n[a.PMTID].Name)
 		tbl.Puts(-1, DocNo, a.DocNo)
-		tbl.Putf(-1, Amount, a.Amount)
+		tbl.Putf(-1, Amount, a.Amount.Float())
 		tbl.Puts(-1, Payor, a.OtherPayorName)
 		tbl.Puts(-1, ReceivedBy, rlib.GetNameForUID(ctx, a.CreateBy))
 		tbl.Puts(-1, Reversal, rev)
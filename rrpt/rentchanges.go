@@ -0,0 +1,111 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// RentChangesReportTable lists the changes the pending assessment steps
+// will make to recurring charges in the range ri.D1 - ri.D2, by Rental
+// Agreement. Annual escalations are shown on each anniversary in the range,
+// the amounts projected from the amount in force today.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func RentChangesReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "RentChangesReportTable"
+	var names = map[int64]string{}
+
+	const (
+		RAID     = 0
+		Rentable = iota
+		Charge   = iota
+		Dt       = iota
+		ASMID    = iota
+		Old      = iota
+		New      = iota
+		Change   = iota
+		Pct      = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Charge", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Effective", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Assessment", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Current", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("New", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Change", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Change %", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+
+	err := TableReportHeaderBlock(ctx, &tbl, "Upcoming Rent Changes", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	bid := ri.Xbiz.P.BID
+	m, err := rlib.GetAssessmentStepsPending(ctx, bid, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	for i := 0; i < len(m); {
+		j := i
+		for j < len(m) && m[j].ASMID == m[i].ASMID {
+			j++
+		}
+		a, err := rlib.GetAssessment(ctx, m[i].ASMID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			tbl.SetSection3(err.Error())
+			return tbl
+		}
+		rname, ok := names[a.RID]
+		if !ok {
+			r, err := rlib.GetRentable(ctx, a.RID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			rname = r.RentableName
+			names[a.RID] = rname
+		}
+		c := rlib.ProjectAssessmentSteps(a.Amount, m[i:j], &a.Stop, &ri.D2)
+		for k := 0; k < len(c); k++ {
+			if c[k].Dt.Before(ri.D1) {
+				continue
+			}
+			tbl.AddRow()
+			tbl.Puts(-1, RAID, rlib.IDtoShortString("RA", a.RAID))
+			tbl.Puts(-1, Rentable, rname)
+			tbl.Puts(-1, Charge, rlib.RRdb.BizTypes[bid].AR[a.ARID].Name)
+			tbl.Putd(-1, Dt, c[k].Dt)
+			tbl.Puts(-1, ASMID, rlib.IDtoShortString("ASM", a.ASMID))
+			tbl.Putf(-1, Old, c[k].Old.Float())
+			tbl.Putf(-1, New, c[k].New.Float())
+			tbl.Putf(-1, Change, (c[k].New - c[k].Old).Float())
+			if c[k].Old != 0 {
+				tbl.Puts(-1, Pct, fmt.Sprintf("%.2f", float64(c[k].New-c[k].Old)/float64(c[k].Old)*100))
+			}
+		}
+		i = j
+	}
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Change})
+	}
+	tbl.TightenColumns()
+	return tbl
+}
@@ -0,0 +1,104 @@
+package rrpt
+
+import (
+	"context"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// RentRollDiffReportTable compares the rent roll on ri.D1 with the rent roll
+// on ri.D2. The first table lists the changes, the second summarizes the net
+// effect on GSR and scheduled rent.
+func RentRollDiffReportTable(ctx context.Context, ri *ReporterInfo) ([]gotable.Table, error) {
+	const funcname = "RentRollDiffReportTable"
+	var (
+		err error
+		m   []gotable.Table
+	)
+
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	tbl := getRRTable()
+	tbl.AddColumn("Change", 17, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)      // 0  change type
+	tbl.AddColumn("Rentable", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)    // 1  rentable name
+	tbl.AddColumn("To Rentable", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT) // 2  transfers only
+	tbl.AddColumn("Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)   // 3  RAID
+	tbl.AddColumn("Payors", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)      // 4  payor names
+	tbl.AddColumn("Old", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)         // 5  old value
+	tbl.AddColumn("New", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)         // 6  new value
+	tbl.AddColumn("Old Rent", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)    // 7  contract rent before
+	tbl.AddColumn("New Rent", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)    // 8  contract rent after
+	tbl.AddColumn("Net Change", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)  // 9  difference
+
+	err = TableReportHeaderBlock(ctx, &tbl, "Rent Roll Changes", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return m, err
+	}
+
+	sum := getRRTable()
+	sum.AddColumn("Item", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)                        // 0  description
+	sum.AddColumn(ri.D1.Format(rlib.RRDATEFMT4), 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT) // 1  first date
+	sum.AddColumn(ri.D2.Format(rlib.RRDATEFMT4), 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT) // 2  second date
+	sum.AddColumn("Net Change", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)                  // 3  difference
+
+	err = TableReportHeaderBlock(ctx, &sum, "Rent Roll Change Summary", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		sum.SetSection3(err.Error())
+		return m, err
+	}
+
+	d, err := rlib.GetRentRollDiff(ctx, ri.Xbiz, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return m, err
+	}
+
+	for i := 0; i < len(d.Changes); i++ {
+		c := &d.Changes[i]
+		tbl.AddRow()
+		tbl.Puts(-1, 0, rlib.RRChangeTypeNames[c.Type])
+		tbl.Puts(-1, 1, c.RentableName)
+		tbl.Puts(-1, 2, c.ToRentable)
+		if c.RAID > 0 {
+			tbl.Puts(-1, 3, rlib.IDtoShortString("RA", c.RAID))
+		}
+		tbl.Puts(-1, 4, c.Payors)
+		tbl.Puts(-1, 5, c.Old)
+		tbl.Puts(-1, 6, c.New)
+		tbl.Putf(-1, 7, c.OldRent)
+		tbl.Putf(-1, 8, c.NewRent)
+		tbl.Putf(-1, 9, c.RentDelta)
+	}
+
+	sum.AddRow()
+	sum.Puts(-1, 0, "GSR")
+	sum.Putf(-1, 1, d.GSR1)
+	sum.Putf(-1, 2, d.GSR2)
+	sum.Putf(-1, 3, rlib.RoundToCent(d.GSR2-d.GSR1))
+	sum.AddRow()
+	sum.Puts(-1, 0, "Scheduled Rent")
+	sum.Putf(-1, 1, d.ScheduledRent1)
+	sum.Putf(-1, 2, d.ScheduledRent2)
+	sum.Putf(-1, 3, rlib.RoundToCent(d.ScheduledRent2-d.ScheduledRent1))
+
+	m = append(m, tbl, sum)
+	return m, err
+}
+
+// RentRollDiffReport returns a text based report from RentRollDiffReportTable
+func RentRollDiffReport(ctx context.Context, ri *ReporterInfo) string {
+	m, err := RentRollDiffReportTable(ctx, ri)
+	if err != nil {
+		return "Error while creating rent roll change report: " + err.Error()
+	}
+	var s string
+	for _, tbl := range m {
+		s += ReportToString(&tbl, ri) + "\n"
+	}
+	return s
+}
@@ -21,7 +21,7 @@ type StmtEntry struct {
 	ID  int64            // ASMID if t==1, RCPTID if t==2, n/a if t==3
 	A   *rlib.Assessment // for type==1, the pointer to the assessment
 	R   *rlib.Receipt    // for type ==2, the pointer to the receipt
-	Amt float64
+	Amt rlib.Money
 	Dt  time.Time
 }
 
@@ -209,9 +209,9 @@ func RptStatementForRA(ctx context.Context, ri *ReporterInfo, ra *rlib.RentalAgr
 		return tbl
 	}
 
-	var b = rlib.RoundToCent(m[0].Amt) // element 0 is always the account balance
-	var c = float64(0)                 // credit
-	var d = float64(0)                 // debit
+	var b = m[0].Amt      // element 0 is always the account balance
+	var c = rlib.Money(0) // credit
+	var d = rlib.Money(0) // debit
 	for i := 0; i < len(m); i++ {
 		tbl.AddRow()
 		descr := ""
@@ -222,14 +222,14 @@ func RptStatementForRA(ctx context.Context, ri *ReporterInfo, ra *rlib.RentalAgr
 		}
 		switch m[i].T {
 		case 1: // assessments
-			amt := rlib.RoundToCent(m[i].Amt)
+			amt := m[i].Amt
 			c += amt
 			b += amt
 			tbl.Puts(-1, 1, rlib.IDtoString("ASM", m[i].ID))
 			tbl.Puts(-1, 2, descr)
-			tbl.Putf(-1, 3, amt)
+			tbl.Putf(-1, 3, amt.Float())
 		case 2: // receipts
-			amt := rlib.RoundToCent(m[i].Amt)
+			amt := m[i].Amt
 			d += amt
 			b += amt
 			if m[i].A.ASMID > 0 {
@@ -237,18 +237,40 @@ func RptStatementForRA(ctx context.Context, ri *ReporterInfo, ra *rlib.RentalAgr
 			}
 			tbl.Puts(-1, 1, rlib.IDtoString("RCPT", m[i].ID))
 			tbl.Puts(-1, 2, descr)
-			tbl.Putf(-1, 4, amt)
+			tbl.Putf(-1, 4, amt.Float())
 		case 3: // opening balance
 			tbl.Puts(-1, 2, "Opening Balance")
 		}
 		tbl.Putd(-1, 0, m[i].Dt)
-		tbl.Putf(-1, 5, b)
+		tbl.Putf(-1, 5, b.Float())
+
+		//------------------------------------------------------
+		// a utility charge is followed by how it was computed
+		//------------------------------------------------------
+		if m[i].T == 1 {
+			uc, err := rlib.GetUtilityChargeByASMID(ctx, m[i].ID)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				tbl.SetSection3(err.Error())
+				return tbl
+			}
+			if uc.UCID > 0 {
+				ub, err := rlib.GetUtilityBill(ctx, uc.UBID)
+				if err != nil {
+					rlib.LogAndPrintError(funcname, err)
+					tbl.SetSection3(err.Error())
+					return tbl
+				}
+				tbl.AddRow()
+				tbl.Puts(-1, 2, rlib.UtilityChargeDescr(&ub, &uc))
+			}
+		}
 	}
 	tbl.AddLineAfter(tbl.RowCount() - 1)
 	tbl.AddRow()
-	tbl.Putf(-1, 3, c)
-	tbl.Putf(-1, 4, d)
-	tbl.Putf(-1, 5, c+d+m[0].Amt)
+	tbl.Putf(-1, 3, c.Float())
+	tbl.Putf(-1, 4, d.Float())
+	tbl.Putf(-1, 5, (c + d + m[0].Amt).Float())
 
 	return tbl
 }
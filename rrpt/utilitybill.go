@@ -0,0 +1,125 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// UtilityBillTable generates the split of utility bill ri.ID between the
+// residents: one row per Rental Agreement charge with the figures it was
+// computed from, then what was not charged to anyone.
+//
+// INPUT
+//  ctx    - context containing session, existing db transactions, etc.
+//  ri     - report information, ri.ID is the UBID
+//
+// RETURNS
+//  the gotable
+//-----------------------------------------------------------------------------
+func UtilityBillTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "UtilityBillTable"
+
+	const (
+		RAID     = 0
+		Rentable = iota
+		DtStart  = iota
+		DtStop   = iota
+		Days     = iota
+		Basis    = iota
+		Share    = iota
+		Amount   = iota
+		ASMID    = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("From", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Through", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Days", 5, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Basis", 15, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Share %", 8, gotable.CELLSTRING, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Assessment", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	b, err := rlib.GetUtilityBill(ctx, ri.ID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	if b.UBID == 0 || b.BID != ri.Bid {
+		tbl.SetSection3(fmt.Sprintf("utility bill %d not found", ri.ID))
+		return tbl
+	}
+	ri.D1 = b.DtStart
+	ri.D2 = b.DtStop
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+	err = TableReportHeaderBlock(ctx, &tbl, b.Utility+" Bill, "+rlib.UtilityMethods[b.Method], funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetUtilityCharges(ctx, b.UBID)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	var charged rlib.Money
+	for i := 0; i < len(m); i++ {
+		rnt, err := rlib.GetRentable(ctx, m[i].RID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			tbl.SetSection3(err.Error())
+			return tbl
+		}
+		var basis string
+		switch b.Method {
+		case rlib.UTILMETHODSqft:
+			basis = fmt.Sprintf("%d sqft", m[i].Sqft)
+		case rlib.UTILMETHODOccupants:
+			basis = fmt.Sprintf("%d occupants", m[i].Occupants)
+		case rlib.UTILMETHODSubmeter:
+			basis = fmt.Sprintf("%g used", m[i].MeterUsage)
+		case rlib.UTILMETHODRatio:
+			basis = fmt.Sprintf("ratio %g", m[i].Ratio)
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, RAID, rlib.IDtoShortString("RA", m[i].RAID))
+		tbl.Puts(-1, Rentable, rnt.RentableName)
+		tbl.Putd(-1, DtStart, m[i].DtStart)
+		tbl.Putd(-1, DtStop, m[i].DtStop.AddDate(0, 0, -1))
+		tbl.Puti(-1, Days, m[i].Days)
+		tbl.Puts(-1, Basis, basis)
+		tbl.Puts(-1, Share, fmt.Sprintf("%.4f", m[i].Share*100))
+		tbl.Putf(-1, Amount, m[i].Amount.Float())
+		if m[i].ASMID > 0 {
+			tbl.Puts(-1, ASMID, rlib.IDtoShortString("ASM", m[i].ASMID))
+		}
+		charged += m[i].Amount
+	}
+
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Amount})
+	}
+	common := b.Amount.Mul(b.CommonPct / 100)
+	tbl.AddRow()
+	tbl.Puts(-1, Rentable, "Common areas")
+	tbl.Puts(-1, Share, fmt.Sprintf("%g", b.CommonPct))
+	tbl.Putf(-1, Amount, common.Float())
+	tbl.AddRow()
+	tbl.Puts(-1, Rentable, "Vacant units")
+	tbl.Putf(-1, Amount, (b.Amount - common - charged).Float())
+	tbl.AddRow()
+	tbl.Puts(-1, Rentable, "Bill amount")
+	tbl.Putf(-1, Amount, b.Amount.Float())
+	tbl.TightenColumns()
+	return tbl
+}
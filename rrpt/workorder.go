@@ -0,0 +1,125 @@
+package rrpt
+
+import (
+	"context"
+	"fmt"
+	"gotable"
+	"rentroll/rlib"
+)
+
+// WorkOrderAgingReportTable lists the work orders of the business that are
+// open as of ri.D2, oldest first, with the number of days each has been open
+// and a count in the aging bucket it falls into.
+func WorkOrderAgingReportTable(ctx context.Context, ri *ReporterInfo) gotable.Table {
+	const funcname = "WorkOrderAgingReportTable"
+	var (
+		err       error
+		totalErrs = 0
+		rnames    = map[int64]string{}
+	)
+
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	const (
+		WOID     = 0
+		Rentable = iota
+		Category = iota
+		Priority = iota
+		Status   = iota
+		Assigned = iota
+		DtOpen   = iota
+		Days     = iota
+		Bucket0  = iota
+		Bucket1  = iota
+		Bucket2  = iota
+		Bucket3  = iota
+		Cost     = iota
+	)
+
+	tbl := getRRTable()
+	tbl.AddColumn("Work Order", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Category", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Priority", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Status", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Assigned To", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Opened", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Days Open", 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+
+	// one column per aging bucket, the last one for anything older
+	lo := int64(0)
+	for i := 0; i < len(rlib.WorkOrderAgingDays); i++ {
+		tbl.AddColumn(fmt.Sprintf("%d-%d", lo, rlib.WorkOrderAgingDays[i]), 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+		lo = rlib.WorkOrderAgingDays[i] + 1
+	}
+	tbl.AddColumn(fmt.Sprintf("Over %d", lo-1), 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Cost", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	err = TableReportHeaderBlock(ctx, &tbl, "Work Order Aging", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m, err := rlib.GetOpenWorkOrders(ctx, ri.Xbiz.P.BID, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	for i := 0; i < len(m); i++ {
+		rn, ok := rnames[m[i].RID]
+		if !ok {
+			r, err := rlib.GetRentable(ctx, m[i].RID)
+			if err != nil {
+				totalErrs++
+				rlib.Console("Error loading Rentable %d: err = %s\n", m[i].RID, err.Error())
+				continue
+			}
+			rn = r.RentableName
+			rnames[m[i].RID] = rn
+		}
+		assigned := m[i].AssignedTo
+		if len(assigned) == 0 && m[i].AssignedUID > 0 {
+			assigned = fmt.Sprintf("UID %d", m[i].AssignedUID)
+		}
+		days := rlib.WorkOrderAge(&m[i], &ri.D2)
+
+		tbl.AddRow()
+		tbl.Puts(-1, WOID, rlib.IDtoShortString("WO", m[i].WOID))
+		tbl.Puts(-1, Rentable, rn)
+		tbl.Puts(-1, Category, m[i].Category)
+		tbl.Puts(-1, Priority, rlib.WorkOrderPriorityName(m[i].Priority))
+		tbl.Puts(-1, Status, rlib.WorkOrderStatusName(m[i].Status))
+		tbl.Puts(-1, Assigned, assigned)
+		tbl.Putd(-1, DtOpen, m[i].DtOpen)
+		tbl.Puti(-1, Days, days)
+		for j := Bucket0; j <= Bucket3; j++ {
+			tbl.Puti(-1, j, 0)
+		}
+		tbl.Puti(-1, Bucket0+rlib.WorkOrderAgingBucket(days), 1)
+		tbl.Putf(-1, Cost, m[i].Cost.Float())
+	}
+
+	if tbl.RowCount() > 0 {
+		tbl.AddLineAfter(tbl.RowCount() - 1)
+		tbl.InsertSumRow(tbl.RowCount(), 0, tbl.RowCount()-1, []int{Bucket0, Bucket1, Bucket2, Bucket3, Cost})
+	}
+	tbl.TightenColumns()
+
+	if totalErrs > 0 {
+		errMsg := fmt.Sprintf("Encountered %d errors while creating this report. See log.", totalErrs)
+		tbl.SetSection3(errMsg)
+	}
+	return tbl
+}
+
+// WorkOrderAgingReport returns a text based report from
+// WorkOrderAgingReportTable
+func WorkOrderAgingReport(ctx context.Context, ri *ReporterInfo) string {
+	tbl := WorkOrderAgingReportTable(ctx, ri)
+	return ReportToString(&tbl, ri)
+}
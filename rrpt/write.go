@@ -1,6 +1,7 @@
 package rrpt
 
 import (
+	"context"
 	"fmt"
 	"gotable"
 	"io"
@@ -8,6 +9,14 @@ import (
 	"strings"
 )
 
+// ReportTemplateFile returns the path of html template tfname for business
+// bid, which is in the folder named after its BUD
+//-----------------------------------------------------------------------------
+func ReportTemplateFile(bid int64, tfname string) string {
+	bud := rlib.GetBUDFromBIDList(bid)
+	return "webclient/html/rpt-templates/" + strings.ToUpper(string(bud)) + "/" + tfname
+}
+
 // WritePDFReport writes the report to the supplied io.writer
 //
 // INPUTS:
@@ -25,8 +34,7 @@ func WritePDFReport(w io.Writer, tsh *SingleTableReportHandler, ri *ReporterInfo
 	// rlib.Console("report.go:  tfname = %s\n", tfname)
 	if len(tfname) > 0 {
 		var err error
-		bud := rlib.GetBUDFromBIDList(ri.Bid)
-		tfname = "webclient/html/rpt-templates/" + strings.ToUpper(string(bud)) + "/" + tfname
+		tfname = ReportTemplateFile(ri.Bid, tfname)
 
 		// cwd, err := os.Getwd()
 		// rlib.Console("report.go:  cwd = %s\n", cwd)
@@ -73,3 +81,78 @@ func WritePDFReport(w io.Writer, tsh *SingleTableReportHandler, ri *ReporterInfo
 
 	return err
 }
+
+// ReportFileExtension returns the file extension for the gotable output
+// format f
+func ReportFileExtension(f int) string {
+	switch f {
+	case gotable.TABLEOUTTEXT:
+		return ".txt"
+	case gotable.TABLEOUTCSV:
+		return ".csv"
+	case gotable.TABLEOUTPDF:
+		return ".pdf"
+	}
+	return ".html"
+}
+
+// WriteReport renders the named report to w in the output format
+// rctx.ReportOutputFormat. The report can be any report in SingleTableReports
+// or MultiTableReports. This is the non-http equivalent of the report web
+// service, it is used by routines that need to save or send a report.
+//
+// INPUTS:
+//    ctx  - db context
+//    w    - where to write the report
+//    name - report name
+//    ri   - Report formatting info. ri.Xbiz must be initialized
+//    rctx - output format and pdf page dimensions
+//
+// RETURNS:
+//    the title of the report
+//    any error encountered
+//-----------------------------------------------------------------------------
+func WriteReport(ctx context.Context, w io.Writer, name string, ri *ReporterInfo, rctx *ReportContext) (string, error) {
+	if tsh := FindSingleTableReport(name); tsh.Found {
+		tbl := tsh.TableHandler(ctx, ri)
+		switch rctx.ReportOutputFormat {
+		case gotable.TABLEOUTTEXT:
+			return tbl.Title, tbl.TextprintTable(w)
+		case gotable.TABLEOUTHTML:
+			return tbl.Title, tbl.HTMLprintTable(w)
+		case gotable.TABLEOUTCSV:
+			return tbl.Title, tbl.CSVprintTable(w)
+		case gotable.TABLEOUTPDF:
+			return tbl.Title, WritePDFReport(w, &tsh, ri, rctx, &tbl)
+		}
+		return tbl.Title, fmt.Errorf("unsupported report output format: %d", rctx.ReportOutputFormat)
+	}
+
+	tmh := FindMultiTableReport(name)
+	if !tmh.Found {
+		return "", fmt.Errorf("unknown report: %s", name)
+	}
+	m, err := tmh.TableHandler(ctx, ri)
+	if err != nil {
+		return tmh.ReportTitle, err
+	}
+	switch rctx.ReportOutputFormat {
+	case gotable.TABLEOUTTEXT:
+		err = gotable.MultiTableTextPrint(m, w)
+	case gotable.TABLEOUTHTML:
+		err = gotable.MultiTableHTMLPrint(m, w)
+	case gotable.TABLEOUTCSV:
+		err = gotable.MultiTableCSVPrint(m, w)
+	case gotable.TABLEOUTPDF:
+		pdfProps := GetReportPDFProps()
+		pdfProps = SetPDFOption(pdfProps, "--header-center", tmh.ReportTitle)
+		if tmh.NeedsCustomPDFDimension {
+			pdfProps = SetPDFOption(pdfProps, "--page-width", rlib.Float64ToString(rctx.PDFPageWidth)+rctx.PDFPageSizeUnit)
+			pdfProps = SetPDFOption(pdfProps, "--page-height", rlib.Float64ToString(rctx.PDFPageHeight)+rctx.PDFPageSizeUnit)
+		}
+		err = gotable.MultiTablePDFPrint(m, w, pdfProps)
+	default:
+		err = fmt.Errorf("unsupported report output format: %d", rctx.ReportOutputFormat)
+	}
+	return tmh.ReportTitle, err
+}
@@ -104,7 +104,7 @@ func main() {
 	var m []rlib.AcctRule
 	var xbiz rlib.XBusiness
 	now := time.Now()
-	rpnCtx := rlib.RpnCreateCtx(&xbiz, 1, &now, &now, &m, float64(-2000), float64(1.0))
+	rpnCtx := rlib.RpnCreateCtx(&xbiz, 1, &now, &now, &m, rlib.MoneyFromFloat(-2000), float64(1.0))
 
 	rlib.RpnInit()
 	var expr = []string{
@@ -0,0 +1,85 @@
+#!/bin/bash
+TESTNAME="ACCOUNTS PAYABLE test"
+TESTSUMMARY="Post vendor bills and payments and void them"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+#------------------------------------------------------------------------------
+#  REX has no Accounts Payable account. Add one before the server loads the
+#  chart of accounts, it gets LID 75.
+#------------------------------------------------------------------------------
+mysql --no-defaults rentroll -e "INSERT INTO GLAccount (PLID,BID,RAID,TCID,GLNumber,Name,AcctType,AllowPost,FLAGS,Description) VALUES (0,1,0,0,'20000','Accounts Payable','Liabilities',1,0,'')"
+
+source ../share/base.sh
+
+echo "BEGIN ACCOUNTS PAYABLE FUNCTIONAL TEST" >>${LOGFILE}
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  AP is the sum of the ledger entries of the bill (Journal Type 5) and
+#  vendor payment (Journal Type 6) journal entries by GL account
+#------------------------------------------------------------------------------
+AP="SELECT j.Type,e.LID,SUM(e.Amount) AS Amount FROM LedgerEntry e JOIN Journal j ON j.JID=e.JID WHERE j.BID=1 AND j.Type IN (5,6) GROUP BY j.Type,e.LID ORDER BY j.Type,e.LID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Enter a bill and pay it
+#
+#  Scenario:
+#		Vendor Acme Plumbing, 30 day terms, default account 50999. A $500.00
+#		bill dated 3/5/2018: $300.00 to the vendor's default account and
+#		$200.00 to 50003. It is paid by ACH from Depository 1 (10104) on
+#		3/20/2018.
+#
+#  Expected Results:
+#	1.	The bill is due in 30 days, on 4/4/2018, and is credited to the
+#		Accounts Payable account of the business, LID 75
+#	2.	The bill debits 50999 (LID 73) 300.00 and 50003 (LID 72) 200.00 and
+#		credits Accounts Payable 500.00
+#	3.	The payment debits Accounts Payable 500.00 and credits 10104 (LID 3)
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","record":{"VID":0,"BUD":"REX","Name":"Acme Plumbing","DefaultLID":73,"Terms":30,"FLAGS":1}}' > request
+dojsonPOST "http://localhost:8270/v1/vendor/1/0" "request" "a0"  "AP-SaveVendor"
+
+echo '{"cmd":"save","record":{"BILLID":0,"BUD":"REX","VID":1,"InvoiceNo":"A-100","Dt":"3/5/2018","Amount":500,"APLID":0,"Comment":"repairs"},"lines":[{"LID":0,"RID":0,"Amount":300,"Descr":"plumbing"},{"LID":72,"RID":0,"Amount":200,"Descr":"service fee"}]}' > request
+dojsonPOST "http://localhost:8270/v1/bill/1/0" "request" "a1"  "AP-SaveBill"
+mysql --no-defaults rentroll -e "SELECT BILLID,VID,InvoiceNo,Dt,DtDue,Amount,APLID,FLAGS FROM Bill WHERE BID=1; SELECT ja.AcctRule FROM JournalAllocation ja JOIN Journal j ON j.JID=ja.JID WHERE j.Type=5 AND j.ID=1 ORDER BY ja.JAID" > a2
+doValidateFile "a2" "AP-BillPosting"
+
+echo '{"cmd":"save","record":{"BUD":"REX","VID":1,"DEPID":1,"Dt":"3/20/2018","Amount":500,"Method":2,"DocNo":"ACH-7001","Comment":"A-100"},"allocations":[{"BILLID":1,"Amount":500}]}' > request
+dojsonPOST "http://localhost:8270/v1/vendorpayment/1/0" "request" "a3"  "AP-SaveVendorPayment"
+mysql --no-defaults rentroll -e "${AP}" > a4
+doValidateFile "a4" "AP-Postings"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Void the payment and the bill
+#
+#  Scenario:
+#		Try to void the bill while it is paid. Void the payment, then the
+#		bill.
+#
+#  Expected Results:
+#	1.	A bill with payments cannot be voided
+#	2.	Each void posts the reverse of the entry it voids on the same date,
+#		the period is open. Every account nets to 0.
+#	3.	The bill and the payment are flagged void
+#------------------------------------------------------------------------------
+echo '{"cmd":"void"}' > request
+dojsonPOST "http://localhost:8270/v1/bill/1/1" "request" "b0"  "AP-VoidPaidBill"
+dojsonPOST "http://localhost:8270/v1/vendorpayment/1/1" "request" "b1"  "AP-VoidVendorPayment"
+dojsonPOST "http://localhost:8270/v1/bill/1/1" "request" "b2"  "AP-VoidBill"
+mysql --no-defaults rentroll -e "${AP}; SELECT j.Type,j.Dt,COUNT(*) AS Entries FROM Journal j WHERE j.BID=1 AND j.Type IN (5,6) GROUP BY j.Type,j.Dt ORDER BY j.Type,j.Dt; SELECT BILLID,FLAGS FROM Bill WHERE BID=1; SELECT VPID,FLAGS FROM VendorPayment WHERE BID=1" > b3
+doValidateFile "b3" "AP-Voids"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
@@ -0,0 +1,97 @@
+#!/bin/bash
+TESTNAME="ASSESSMENT STEPS test"
+TESTSUMMARY="Apply scheduled changes of a recurring assessment"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+#------------------------------------------------------------------------------
+#  The rent of RA 1, recurring assessment 1, is $1000.00 a month from
+#  3/1/2018. End it on 9/1/2018 so that the splits make few instances.
+#------------------------------------------------------------------------------
+mysql --no-defaults rentroll -e "UPDATE Assessments SET Stop='2018-09-01' WHERE ASMID=1"
+
+source ../share/base.sh
+
+echo "BEGIN ASSESSMENT STEPS FUNCTIONAL TEST" >>${LOGFILE}
+
+WLOG=asmstep.log
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  ASM lists the rent assessments of RA 1 from 5/1/2018 and the ones made by
+#  the test, STEP the state of the steps
+#------------------------------------------------------------------------------
+ASM="SELECT ASMID,PASMID,RPASMID,Amount,Start,Stop,RentCycle,FLAGS,Comment FROM Assessments WHERE BID=1 AND ARID=26 AND RAID=1 AND (ASMID=1 OR Start>='2018-05-01') ORDER BY ASMID"
+STEP="SELECT ASTID,RAID,ASMID,Dt,Amount,Pct,NewASMID,DtApplied,Comment,FLAGS FROM AssessmentStep ORDER BY ASTID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Schedule the steps
+#
+#  Scenario:
+#		Step the rent of assessment 1 up to $1100.00 on 5/1/2018, then by
+#		5% on 8/1/2018. Try a step on 10/1/2018, after the assessment
+#		ends.
+#
+#  Expected Results:
+#	1.	Both steps are saved for RA 1, pending
+#	2.	The step on 10/1/2018 is refused
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","Record":{"recid":0,"ASTID":0,"BID":1,"ASMID":1,"Dt":"5/1/2018","Amount":1100,"Pct":0,"Comment":"Renewal","Annual":false}}' > request
+dojsonPOST "http://localhost:8270/v1/asmstep/1/0" "request" "a0"  "AsmStep-SaveAmount"
+echo '{"cmd":"save","Record":{"recid":0,"ASTID":0,"BID":1,"ASMID":1,"Dt":"8/1/2018","Amount":0,"Pct":5,"Comment":"","Annual":false}}' > request
+dojsonPOST "http://localhost:8270/v1/asmstep/1/0" "request" "a1"  "AsmStep-SavePct"
+echo '{"cmd":"save","Record":{"recid":0,"ASTID":0,"BID":1,"ASMID":1,"Dt":"10/1/2018","Amount":1200,"Pct":0,"Comment":"","Annual":false}}' > request
+dojsonPOST "http://localhost:8270/v1/asmstep/1/0" "request" "a2"  "AsmStep-SaveAfterStop"
+mysql --no-defaults rentroll -e "${STEP}" > a3
+doValidateFile "a3" "AsmStep-Pending"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Apply the first step
+#
+#  Scenario:
+#		Run the assessment step worker on 7/15/2018
+#
+#  Expected Results:
+#	1.	The instances of 5/1, 6/1 and 7/1/2018 at $1000.00 are reversed
+#	2.	Assessment 1 now stops on 5/1/2018
+#	3.	A copy at $1100.00 runs from 5/1/2018 to 9/1/2018, it makes the
+#		instances of 5/1 to 8/1/2018 again
+#	4.	Step 1 is applied, step 2 moves to the copy
+#	5.	An applied step cannot be deleted
+#------------------------------------------------------------------------------
+./asmstep -dt "7/15/2018" >> ${WLOG} 2>&1
+mysql --no-defaults rentroll -e "${ASM}; ${STEP}" > b0
+doValidateFile "b0" "AsmStep-ApplyAmount"
+echo '{"cmd":"delete"}' > request
+dojsonPOST "http://localhost:8270/v1/asmstep/1/1" "request" "b1"  "AsmStep-DeleteApplied"
+
+#------------------------------------------------------------------------------
+#  TEST c
+#  Apply the escalation
+#
+#  Scenario:
+#		Run the assessment step worker on 8/15/2018
+#
+#  Expected Results:
+#	1.	The $1100.00 instance of 8/1/2018 is reversed
+#	2.	The copy now stops on 8/1/2018
+#	3.	A copy at $1155.00 runs from 8/1/2018 to 9/1/2018
+#	4.	Step 2 is applied
+#------------------------------------------------------------------------------
+./asmstep -dt "8/15/2018" >> ${WLOG} 2>&1
+mysql --no-defaults rentroll -e "${ASM}; ${STEP}" > c0
+doValidateFile "c0" "AsmStep-ApplyPct"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
@@ -0,0 +1,93 @@
+package main
+
+//=============================================================================
+// Runs the assessment step worker on a given date
+//=============================================================================
+
+import (
+	"context"
+	"database/sql"
+	"extres"
+	"flag"
+	"fmt"
+	"os"
+	"rentroll/rlib"
+	"rentroll/worker"
+	"time"
+)
+
+// App is the global application structure
+var App struct {
+	dbdir *sql.DB   // phonebook db
+	dbrr  *sql.DB   // rentroll db
+	Dt    time.Time // call worker with this date time
+}
+
+func readCommandLineArgs() {
+	var err error
+	dtptr := flag.String("dt", "2018-03-01", "apply the assessment steps due on this date")
+	flag.Parse()
+
+	App.Dt, err = rlib.StringToDate(*dtptr)
+	if err != nil {
+		rlib.LogAndPrintError("readCommandLineArgs", err)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	var err error
+	readCommandLineArgs()
+
+	//----------------------------
+	// Open database
+	//----------------------------
+	if err = rlib.RRReadConfig(); err != nil {
+		fmt.Printf("sql.Open for database=%s, dbuser=%s: Error = %v\n", rlib.AppConfig.RRDbname, rlib.AppConfig.RRDbuser, err)
+		os.Exit(1)
+	}
+
+	s := extres.GetSQLOpenString(rlib.AppConfig.RRDbname, &rlib.AppConfig)
+	App.dbrr, err = sql.Open("mysql", s)
+	if nil != err {
+		fmt.Printf("sql.Open for database=%s, dbuser=%s: Error = %v\n", rlib.AppConfig.RRDbname, rlib.AppConfig.RRDbuser, err)
+		os.Exit(1)
+	}
+	defer App.dbrr.Close()
+	err = App.dbrr.Ping()
+	if nil != err {
+		fmt.Printf("dbrr.Ping for database=%s, dbuser=%s: Error = %v\n", rlib.AppConfig.RRDbname, rlib.AppConfig.RRDbuser, err)
+		os.Exit(1)
+	}
+
+	//----------------------------
+	// Open Phonebook database
+	//----------------------------
+	s = extres.GetSQLOpenString(rlib.AppConfig.Dbname, &rlib.AppConfig)
+	App.dbdir, err = sql.Open("mysql", s)
+	if nil != err {
+		fmt.Printf("sql.Open: Error = %v\n", err)
+		os.Exit(1)
+	}
+	err = App.dbdir.Ping()
+	if nil != err {
+		fmt.Printf("dbdir.Ping: Error = %v\n", err)
+		os.Exit(1)
+	}
+
+	rlib.InitDBHelpers(App.dbrr, App.dbdir)
+	rlib.SessionInit(15)
+
+	//-----------------------------------------
+	// Apply the steps due on App.Dt...
+	//-----------------------------------------
+	expire := time.Now().Add(10 * time.Minute)
+	sess := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.AsmStepBot, "", -1, &expire)
+	ctx := rlib.SetSessionContextKey(context.Background(), sess)
+	if err = worker.AsmStepApplyAll(ctx, &App.Dt); err != nil {
+		os.Exit(1)
+	}
+}
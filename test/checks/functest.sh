@@ -0,0 +1,90 @@
+#!/bin/bash
+TESTNAME="CHECK WRITING test"
+TESTSUMMARY="Write refund and vendor payment checks and void them"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+#------------------------------------------------------------------------------
+#  REX has no Accounts Payable account. Add one before the server loads the
+#  chart of accounts, it gets LID 75.
+#------------------------------------------------------------------------------
+mysql --no-defaults rentroll -e "INSERT INTO GLAccount (PLID,BID,RAID,TCID,GLNumber,Name,AcctType,AllowPost,FLAGS,Description) VALUES (0,1,0,0,'20000','Accounts Payable','Liabilities',1,0,'')"
+
+source ../share/base.sh
+
+echo "BEGIN CHECK WRITING FUNCTIONAL TEST" >>${LOGFILE}
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  CHK is the sum of the ledger entries of the vendor payment (Journal Type 6)
+#  and check (Journal Type 7) journal entries by GL account
+#------------------------------------------------------------------------------
+CHK="SELECT j.Type,e.LID,SUM(e.Amount) AS Amount FROM LedgerEntry e JOIN Journal j ON j.JID=e.JID WHERE j.BID=1 AND j.Type IN (6,7) GROUP BY j.Type,e.LID ORDER BY j.Type,e.LID"
+CHECKS="SELECT CKID,CheckNo,Dt,Payee,Amount,DebitLID,VPID,RAID,TCID,FLAGS FROM BankCheck WHERE BID=1 ORDER BY CKID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Write checks
+#
+#  Scenario:
+#		Depository 1 (10104) is set up for checks starting at number 1001.
+#		A $250.00 security deposit refund check is written to Nakia Horton,
+#		the payor of RA 1, on 3/12/2018. Then a $400.00 bill of Acme
+#		Plumbing dated 3/5/2018 is paid by check on 3/20/2018 with no
+#		check number.
+#
+#  Expected Results:
+#	1.	The refund is check 1001. It debits Security Deposit Liability
+#		(LID 11) and credits 10104 (LID 3).
+#	2.	The vendor payment gets check 1002 written for it, its DocNo is the
+#		check number. The check is not posted again, the payment debits
+#		Accounts Payable (LID 75) and credits 10104.
+#	3.	The next check number is 1003
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","record":{"BUD":"REX","BankName":"Wells Fargo","RoutingNo":"121000248","PayerName":"JGM First, LLC","NextCheckNo":1001}}' > request
+dojsonPOST "http://localhost:8270/v1/checkaccount/1/1" "request" "a0"  "Checks-SaveCheckAccount"
+
+echo '{"cmd":"save","record":{"CKID":0,"BUD":"REX","DEPID":1,"Dt":"3/12/2018","Payee":"Nakia Horton","Amount":250,"Memo":"security deposit refund","DebitLID":11,"VPID":0,"TCID":1,"RAID":1}}' > request
+dojsonPOST "http://localhost:8270/v1/check/1/0" "request" "a1"  "Checks-WriteRefundCheck"
+
+echo '{"cmd":"save","record":{"VID":0,"BUD":"REX","Name":"Acme Plumbing","DefaultLID":73,"Terms":30}}' > request
+dojsonPOST "http://localhost:8270/v1/vendor/1/0" "request" "a2"  "Checks-SaveVendor"
+echo '{"cmd":"save","record":{"BILLID":0,"BUD":"REX","VID":1,"InvoiceNo":"A-200","Dt":"3/5/2018","Amount":400},"lines":[{"LID":73,"Amount":400,"Descr":"plumbing"}]}' > request
+dojsonPOST "http://localhost:8270/v1/bill/1/0" "request" "a3"  "Checks-SaveBill"
+echo '{"cmd":"save","record":{"BUD":"REX","VID":1,"DEPID":1,"Dt":"3/20/2018","Amount":400,"Method":1,"DocNo":"","Comment":"A-200"},"allocations":[{"BILLID":1,"Amount":400}]}' > request
+dojsonPOST "http://localhost:8270/v1/vendorpayment/1/0" "request" "a4"  "Checks-PayBillByCheck"
+
+mysql --no-defaults rentroll -e "${CHECKS}; SELECT VPID,DocNo FROM VendorPayment WHERE BID=1; SELECT NextCheckNo FROM CheckAccount WHERE DEPID=1; ${CHK}" > a5
+doValidateFile "a5" "Checks-Written"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Void the checks
+#
+#  Scenario:
+#		Void the refund check, then the vendor payment check
+#
+#  Expected Results:
+#	1.	The refund check is reversed on its date, the period is open
+#	2.	Voiding the vendor payment check voids the payment, which is
+#		reversed. The bill is not void, it is open again.
+#	3.	Every account nets to 0, both checks are void and their numbers
+#		stay used
+#------------------------------------------------------------------------------
+echo '{"cmd":"void"}' > request
+dojsonPOST "http://localhost:8270/v1/check/1/1" "request" "b0"  "Checks-VoidRefundCheck"
+dojsonPOST "http://localhost:8270/v1/check/1/2" "request" "b1"  "Checks-VoidVendorPaymentCheck"
+mysql --no-defaults rentroll -e "${CHK}; SELECT j.Type,j.Dt,COUNT(*) AS Entries FROM Journal j WHERE j.BID=1 AND j.Type IN (6,7) GROUP BY j.Type,j.Dt ORDER BY j.Type,j.Dt; SELECT CKID,CheckNo,FLAGS FROM BankCheck WHERE BID=1 ORDER BY CKID; SELECT VPID,FLAGS FROM VendorPayment WHERE BID=1; SELECT BILLID,FLAGS FROM Bill WHERE BID=1; SELECT NextCheckNo FROM CheckAccount WHERE DEPID=1" > b2
+doValidateFile "b2" "Checks-Voided"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
@@ -12,4 +12,72 @@ source ../share/base.sh
 
 echo "BEGIN CLOSE PERIOD FUNCTIONAL TEST" >>${LOGFILE}
 
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  The close date of TaskList 1 is 2018-02-28 17:00. MARKERS are the ledger
+#  markers written on it. After the first close they are saved in
+#  zCloseMarkers so that later markers on the same date can be compared with
+#  them.
+#------------------------------------------------------------------------------
+CLOSEDT="2018-02-28 17:00:00"
+MARKERS="SELECT LID,RAID,RID,TCID,Balance FROM LedgerMarker WHERE BID=1 AND Dt='${CLOSEDT}'"
+SAMEKEY="m.LID=z.LID AND m.RAID=z.RAID AND m.RID=z.RID AND m.TCID=z.TCID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Close a period, then reopen it
+#
+#  Scenario:
+#		Close the period of TaskList 1. Then reopen it.
+#
+#  Expected Results:
+#	1.	A GL account marker is written on the close date for each of the 74
+#		GL accounts of the business
+#	2.	The reopen deletes the ClosePeriod and writes an audit record
+#	3.	The markers on the close date are rebuilt, they are the same as the
+#		ones written by the close and none is duplicated
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","record":{"BID":1,"TLIDTarget":1}}' > request
+dojsonPOST "http://localhost:8270/v1/closeperiod/1/1" "request" "a0"  "ClosePeriod-Close"
+mysql --no-defaults rentroll -e "CREATE TABLE zCloseMarkers ${MARKERS}"
+mysql --no-defaults rentroll -e "SELECT COUNT(*) AS GLMarkers FROM zCloseMarkers WHERE RAID=0 AND RID=0 AND TCID=0" > a1
+doValidateFile "a1" "ClosePeriod-GLMarkers"
+
+echo '{"cmd":"delete","reason":"late bank fee"}' > request
+dojsonPOST "http://localhost:8270/v1/closeperiod/1/1" "request" "a2"  "ClosePeriod-Reopen"
+mysql --no-defaults rentroll -e "SELECT COUNT(*) AS ClosePeriods FROM ClosePeriod WHERE BID=1; SELECT CPRID,CPID,TLID,Dt,Reason FROM ClosePeriodReopen WHERE BID=1" > a3
+doValidateFile "a3" "ClosePeriod-ReopenAudit"
+mysql --no-defaults rentroll -e "SELECT COUNT(*) AS Markers FROM (${MARKERS}) m LEFT JOIN zCloseMarkers z ON ${SAMEKEY} AND m.Balance=z.Balance WHERE z.LID IS NULL; SELECT (SELECT COUNT(*) FROM (${MARKERS}) m)=(SELECT COUNT(*) FROM zCloseMarkers) AS SameCount" > a4
+doValidateFile "a4" "ClosePeriod-RebuiltMarkers"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Post into the reopened period and close it again
+#
+#  Scenario:
+#		A $100.00 bank service fee (ARID 6: debit 50003, credit 10104) dated
+#		2/15/2018 is posted while the period is open. Then the period is
+#		closed again.
+#
+#  Expected Results:
+#	1.	The expense is accepted, the period is open
+#	2.	The period closes
+#	3.	The only markers on the close date that differ from the first close
+#		are those of 50003 and its parent 50000 (+100) and of 10104 and its
+#		parent 10000 (-100). No marker is duplicated.
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","recid":0,"name":"expenseForm","record":{"recid":0,"EXPID":0,"BID":1,"BUD":"REX","RID":0,"RAID":0,"Dt":"2/15/2018","Amount":100,"ARID":6,"Comment":"late bank fee","FLAGS":0}}' > request
+dojsonPOST "http://localhost:8270/v1/expense/1/0" "request" "b0"  "ClosePeriod-PostIntoReopenedPeriod"
+
+echo '{"cmd":"save","record":{"BID":1,"TLIDTarget":1}}' > request
+dojsonPOST "http://localhost:8270/v1/closeperiod/1/1" "request" "b1"  "ClosePeriod-CloseAgain"
+mysql --no-defaults rentroll -e "SELECT m.LID,m.RAID,m.RID,m.TCID,m.Balance-z.Balance AS Delta FROM (${MARKERS}) m JOIN zCloseMarkers z ON ${SAMEKEY} WHERE m.Balance<>z.Balance ORDER BY m.LID,m.RAID,m.RID,m.TCID; SELECT (SELECT COUNT(*) FROM (${MARKERS}) m)=(SELECT COUNT(*) FROM zCloseMarkers) AS SameCount" > b2
+doValidateFile "b2" "ClosePeriod-MarkersAfterReclose"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
 logcheck
@@ -0,0 +1,88 @@
+#!/bin/bash
+TESTNAME="CONCESSIONS test"
+TESTSUMMARY="Post concessions on rent charges and reverse them with the charge"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+#------------------------------------------------------------------------------
+#  Before the server loads the account rules add AR 43, Resident Concession
+#  (debit 41104, credit 12001). Concession 1 takes 50% off 2 rent charges,
+#  Rental Agreement 1 was given it on the rent of Rentable 1 from 3/1/2018.
+#------------------------------------------------------------------------------
+mysql --no-defaults rentroll -e "INSERT INTO AR (BID,Name,ARType,RARequired,DebitLID,CreditLID,Description,DtStart,DtStop,FLAGS,DefaultAmount) VALUES (1,'Resident Concession',0,0,23,9,'','2018-01-01','9999-12-31',0,0)"
+mysql --no-defaults rentroll -e "INSERT INTO Concession (BID,Name,Description,ARID,Amount,Pct,Cycles,FLAGS) VALUES (1,'Half off 2 months','',43,0,50,2,0)"
+mysql --no-defaults rentroll -e "INSERT INTO RentalAgreementConcession (BID,RAID,RID,CONID,ARID,Amount,Pct,Cycles,Applied,DtStart,FLAGS) VALUES (1,1,1,1,43,0,50,2,0,'2018-03-01',0)"
+
+source ../share/base.sh
+
+echo "BEGIN CONCESSIONS FUNCTIONAL TEST" >>${LOGFILE}
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  ASM lists the assessments made by the test, CON the state of the
+#  concession and LEDG the ledger totals of their journal entries
+#------------------------------------------------------------------------------
+ASM="SELECT ASMID,RPASMID,ARID,Amount,Start,AssocElemType,AssocElemID,FLAGS FROM Assessments WHERE BID=1 AND ASMID>=168 ORDER BY ASMID"
+CON="SELECT RACID,Cycles,Applied FROM RentalAgreementConcession WHERE RAID=1"
+LEDG="SELECT e.LID,SUM(e.Amount) AS Amount FROM LedgerEntry e JOIN Journal j ON j.JID=e.JID WHERE j.BID=1 AND j.Type=1 AND j.ID>=168 GROUP BY e.LID ORDER BY e.LID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Apply the concession
+#
+#  Scenario:
+#		Charge RA 1 rent for Rentable 1 (AR 39, Rent 1BR) of $1000.00 on
+#		3/15/2018 and $800.00 on 3/20/2018
+#
+#  Expected Results:
+#	1.	Each rent charge gets a concession of half its amount, an offset
+#		linked to the charge (AssocElemType 16, AssocElemID = its ASMID)
+#	2.	The concession has been applied to both of its rent charges
+#	3.	12001 is debited $900.00, 41001 credited $1800.00 and 41104
+#		debited $900.00
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","recid":0,"name":"asmEpochForm","record":{"recid":0,"ASMID":0,"PASMID":0,"BID":1,"BUD":"REX","ARID":39,"RID":1,"RAID":1,"Start":"3/15/2018","Stop":"3/15/2018","RentCycle":0,"ProrationCycle":0,"InvoiceNo":0,"Amount":1000,"Comment":"","ExpandPastInst":false,"FLAGS":0,"Mode":0}}' > request
+dojsonPOST "http://localhost:8270/v1/asm/1/0" "request" "a0"  "Concession-RentCharge-1000"
+echo '{"cmd":"save","recid":0,"name":"asmEpochForm","record":{"recid":0,"ASMID":0,"PASMID":0,"BID":1,"BUD":"REX","ARID":39,"RID":1,"RAID":1,"Start":"3/20/2018","Stop":"3/20/2018","RentCycle":0,"ProrationCycle":0,"InvoiceNo":0,"Amount":800,"Comment":"","ExpandPastInst":false,"FLAGS":0,"Mode":0}}' > request
+dojsonPOST "http://localhost:8270/v1/asm/1/0" "request" "a1"  "Concession-RentCharge-800"
+mysql --no-defaults rentroll -e "${ASM}; ${CON}; ${LEDG}" > a2
+doValidateFile "a2" "Concession-Applied"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Reverse a rent charge
+#
+#  Scenario:
+#		Reverse the $1000.00 rent charge. Then charge $600.00 rent on
+#		3/25/2018.
+#
+#  Expected Results:
+#	1.	The reversal of the rent charge also reverses the concession
+#		posted on it
+#	2.	The concession gets the rent charge back, it has been applied
+#		to 1 of 2
+#	3.	So the $600.00 charge gets a $300.00 concession and the
+#		concession is used up again
+#	4.	12001 is debited $700.00, 41001 credited $1400.00 and 41104
+#		debited $700.00
+#------------------------------------------------------------------------------
+echo '{"cmd":"delete","ASMID":168,"ReverseMode":0}' > request
+dojsonPOST "http://localhost:8270/v1/asm/1/168" "request" "b0"  "Concession-ReverseRentCharge"
+mysql --no-defaults rentroll -e "${ASM}; ${CON}" > b1
+doValidateFile "b1" "Concession-Unapplied"
+echo '{"cmd":"save","recid":0,"name":"asmEpochForm","record":{"recid":0,"ASMID":0,"PASMID":0,"BID":1,"BUD":"REX","ARID":39,"RID":1,"RAID":1,"Start":"3/25/2018","Stop":"3/25/2018","RentCycle":0,"ProrationCycle":0,"InvoiceNo":0,"Amount":600,"Comment":"","ExpandPastInst":false,"FLAGS":0,"Mode":0}}' > request
+dojsonPOST "http://localhost:8270/v1/asm/1/0" "request" "b2"  "Concession-RentCharge-600"
+mysql --no-defaults rentroll -e "${ASM}; ${CON}; ${LEDG}" > b3
+doValidateFile "b3" "Concession-Reapplied"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
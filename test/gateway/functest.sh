@@ -0,0 +1,95 @@
+#!/bin/bash
+TESTNAME="CARD PAYMENTS test"
+TESTSUMMARY="Charge and refund saved cards through a payment gateway"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+source ../share/base.sh
+
+echo "BEGIN CARD PAYMENTS FUNCTIONAL TEST" >>${LOGFILE}
+
+#------------------------------------------------------------------------------
+#  The fake gateway stands in for the real one. Its ids count up across
+#  tokens, charges and refunds: tok_000001, tok_000002, ch_000003, ...
+#------------------------------------------------------------------------------
+GWLOG=gateway.log
+./gateway -p 8272 -k sk_test > ${GWLOG} 2>&1 &
+GWPID=$!
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  GT lists the gateway transactions, RCPT the receipts the test makes
+#------------------------------------------------------------------------------
+GT="SELECT GTID,PGID,TCID,CTID,Type,ParentGTID,TxnID,Amount,Dt,Status,Message,RCPTID FROM GatewayTransaction ORDER BY GTID"
+RCPT="SELECT RCPTID,PRCPTID,TCID,PMTID,DEPID,Dt,DocNo,Amount,ARID,FLAGS&4 AS Void,Comment FROM Receipt WHERE RCPTID>=47 ORDER BY RCPTID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Charge a card
+#
+#  Scenario:
+#		Set up a gateway whose payments are VISA receipts to Wells Fargo.
+#		Save two cards of payor 1, one that is always declined. Charge
+#		$100.00 to the declined card, then $500.00 to the other.
+#
+#  Expected Results:
+#	1.	The gateway and the cards are saved
+#	2.	The declined charge is an error, it is kept as a failed transaction
+#		with no receipt
+#	3.	The $500.00 charge succeeds and is receipt 47, with the gateway's
+#		transaction id as its DocNo
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","Record":{"recid":0,"PGID":0,"BID":1,"Name":"Card payments","URL":"http://localhost:8272","APIKey":"sk_test","PMTID":3,"DEPID":1,"ARID":25,"Inactive":false}}' > request
+dojsonPOST "http://localhost:8270/v1/paymentgateway/1/0" "request" "a0"  "PaymentGateway-Save"
+echo '{"cmd":"savecard","PGID":1,"TCID":1,"Card":{"Number":"4242424242424242","ExpMonth":12,"ExpYear":2099,"CVC":"123"}}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "a1"  "CardPayment-SaveCard"
+echo '{"cmd":"savecard","PGID":1,"TCID":1,"Card":{"Number":"4000000000000002","ExpMonth":12,"ExpYear":2099,"CVC":"123"}}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "a2"  "CardPayment-SaveDeclinedCard"
+echo '{"cmd":"cards","TCID":1}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "a3"  "CardPayment-Cards"
+echo '{"cmd":"charge","CTID":2,"Amount":100,"Dt":"3/12/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "a4"  "CardPayment-ChargeDeclined"
+echo '{"cmd":"charge","CTID":1,"Amount":500,"Dt":"3/12/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "a5"  "CardPayment-Charge"
+mysql --no-defaults rentroll -e "${GT}; ${RCPT}" > a6
+doValidateFile "a6" "CardPayment-ChargeReceipt"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Refund the charge
+#
+#  Scenario:
+#		Refund $200.00 of the charge on 3/14/2018. Try to refund $400.00
+#		more. Refund the rest on 3/15/2018.
+#
+#  Expected Results:
+#	1.	Receipt 47 is reversed by receipt 48 and the $300.00 not refunded
+#		is received again as receipt 49
+#	2.	The charge's receipt is now 49
+#	3.	The $400.00 refund is refused, nothing is recorded
+#	4.	Refunding the rest reverses receipt 49 with receipt 50, the
+#		charge's receipt stays 49
+#------------------------------------------------------------------------------
+echo '{"cmd":"refund","GTID":2,"Amount":200,"Dt":"3/14/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "b0"  "CardPayment-RefundPart"
+mysql --no-defaults rentroll -e "${GT}; ${RCPT}" > b1
+doValidateFile "b1" "CardPayment-RefundPartReceipts"
+echo '{"cmd":"refund","GTID":2,"Amount":400,"Dt":"3/14/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "b2"  "CardPayment-RefundTooMuch"
+echo '{"cmd":"refund","GTID":2,"Amount":0,"Dt":"3/15/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/cardpayment/1" "request" "b3"  "CardPayment-RefundRest"
+mysql --no-defaults rentroll -e "${GT}; ${RCPT}" > b4
+doValidateFile "b4" "CardPayment-RefundRestReceipts"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+kill ${GWPID}
+
+logcheck
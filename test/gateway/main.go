@@ -0,0 +1,40 @@
+package main
+
+//=============================================================================
+// Serves the fake card payment gateway for the gateway functional test
+//=============================================================================
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"rentroll/rlib"
+)
+
+// App is the global application structure
+var App struct {
+	Port   int    // listen on this port
+	APIKey string // the gateway's secret key
+}
+
+func readCommandLineArgs() {
+	portptr := flag.Int("p", 8272, "port on which the fake gateway listens")
+	keyptr := flag.String("k", "sk_test", "API key the fake gateway accepts")
+	flag.Parse()
+	App.Port = *portptr
+	App.APIKey = *keyptr
+}
+
+func main() {
+	readCommandLineArgs()
+
+	//-----------------------------------------
+	// Answer the gateway calls until killed...
+	//-----------------------------------------
+	err := http.ListenAndServe(fmt.Sprintf(":%d", App.Port), rlib.NewFakeGateway(App.APIKey))
+	if err != nil {
+		fmt.Printf("ListenAndServe: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -112,7 +112,7 @@ func createReceipt(ctx context.Context, bid int64, amt float64, docno string, dt
 			BID:    bid,
 			Dt:     *dt,
 			TCID:   2, // for test purposes, this is the payor for all receipts
-			Amount: amt,
+			Amount: rlib.MoneyFromFloat(amt),
 			DocNo:  docno,
 			PMTID:  2,
 		}
@@ -0,0 +1,93 @@
+#!/bin/bash
+TESTNAME="NIGHT AUDIT test"
+TESTSUMMARY="Post the nightly room and tax charges, mark no-shows and lock the day"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+#------------------------------------------------------------------------------
+#  Make REX a hotel before the server loads it:
+#    *  AR 43 Room Rent (debit 12001, credit 41000) and AR 44 Room Tax
+#       (debit 12001, credit 30102)
+#    *  RentableType 5, King Room, rented daily with AR 43 as its rent
+#       account rule. The vacant Rentables 24 and 25 are its rooms.
+#    *  the "general" business properties, a 12% room tax
+#------------------------------------------------------------------------------
+mysql --no-defaults rentroll -e "INSERT INTO AR (BID,Name,ARType,RARequired,DebitLID,CreditLID,Description,DtStart,DtStop,FLAGS,DefaultAmount) VALUES (1,'Room Rent',0,0,9,17,'','2018-01-01','9999-12-31',0,0),(1,'Room Tax',0,0,9,15,'','2018-01-01','9999-12-31',0,0)"
+mysql --no-defaults rentroll -e "INSERT INTO RentableTypes (BID,Style,Name,RentCycle,Proration,GSRPC,FLAGS,ARID) VALUES (1,'KNG','King Room',4,4,4,0,43)"
+mysql --no-defaults rentroll -e "INSERT INTO RentableMarketRate (RTID,BID,MarketRate,DtStart,DtStop) VALUES (5,1,100,'2018-01-01','9999-12-31')"
+mysql --no-defaults rentroll -e "UPDATE RentableTypeRef SET RTID=5 WHERE RID IN (24,25)"
+mysql --no-defaults rentroll -e "INSERT INTO BusinessProperties (BID,Name,FLAGS,Data) VALUES (1,'general',0,'{\"NightAudit\":false,\"RoomTaxRate\":0.12,\"RoomTaxAR\":\"Room Tax\"}')"
+
+source ../share/base.sh
+
+echo "BEGIN NIGHT AUDIT FUNCTIONAL TEST" >>${LOGFILE}
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Audit the first night
+#
+#  Scenario:
+#		Nakia Horton (TCID 1) books room 24 for the nights of 3/10 and
+#		3/11/2018 at $100.00 a night and checks in. Tynisha Hogan (TCID 2)
+#		books room 25 for the night of 3/10/2018 at $120.00 and does not
+#		arrive. The night audit of 3/10/2018 is run.
+#
+#  Expected Results:
+#	1.	The check-in creates Rental Agreement 24 for the stay
+#	2.	Room 24 is charged $100.00 and $12.00 room tax for the night, the
+#		charges are dated 3/10/2018
+#	3.	Reservation 2 is a no-show
+#	4.	Occupancy is 1 of 2 rooms, ADR $100.00, RevPAR $50.00
+#	5.	The night is locked, the business date is 3/11/2018
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","record":{"RESID":0,"BUD":"REX","RTID":5,"RID":24,"TCID":1,"RPID":0,"DtArrive":"3/10/2018","DtDepart":"3/12/2018","Adults":2,"Children":0,"Rate":100,"Deposit":0,"Comment":""}}' > request
+dojsonPOST "http://localhost:8270/v1/reservation/1/0" "request" "a0"  "NightAudit-BookRoom24"
+echo '{"cmd":"save","record":{"RESID":0,"BUD":"REX","RTID":5,"RID":25,"TCID":2,"RPID":0,"DtArrive":"3/10/2018","DtDepart":"3/11/2018","Adults":1,"Children":0,"Rate":120,"Deposit":0,"Comment":""}}' > request
+dojsonPOST "http://localhost:8270/v1/reservation/1/0" "request" "a1"  "NightAudit-BookRoom25"
+echo '{"cmd":"checkin"}' > request
+dojsonPOST "http://localhost:8270/v1/reservation/1/1" "request" "a2"  "NightAudit-CheckIn"
+
+echo '{"cmd":"run","Dt":"3/10/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/nightaudit/1" "request" "a3"  "NightAudit-Run-3/10"
+mysql --no-defaults rentroll -e "SELECT ASMID,RID,RAID,ARID,Amount,Start,Stop,RentCycle,Comment FROM Assessments WHERE BID=1 AND ASMID>=168 ORDER BY ASMID; SELECT RESID,RAID,FLAGS FROM Reservation WHERE BID=1 ORDER BY RESID; SELECT State,DtStart,DtStop FROM JournalMarker WHERE BID=1 ORDER BY JMID" > a4
+doValidateFile "a4" "NightAudit-Postings-3/10"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Audit the next night, the business date
+#
+#  Scenario:
+#		Try to audit 3/12/2018, skipping a night. Then run the night audit
+#		without a date. Then post an expense dated on the locked night of
+#		3/11/2018.
+#
+#  Expected Results:
+#	1.	Only the business date can be audited
+#	2.	With no date the business date, 3/11/2018, is audited. Room 24 is
+#		charged again, reservation 2 is not a no-show of this night.
+#	3.	Nothing can be posted on a locked night, the first open date is
+#		3/12/2018
+#	4.	12001 is debited $224.00, 41000 credited $200.00 and 30102
+#		credited $24.00
+#------------------------------------------------------------------------------
+echo '{"cmd":"run","Dt":"3/12/2018"}' > request
+dojsonPOST "http://localhost:8270/v1/nightaudit/1" "request" "b0"  "NightAudit-WrongDate"
+echo '{"cmd":"run"}' > request
+dojsonPOST "http://localhost:8270/v1/nightaudit/1" "request" "b1"  "NightAudit-Run-BusinessDate"
+echo '{"cmd":"save","recid":0,"name":"expenseForm","record":{"recid":0,"EXPID":0,"BID":1,"BUD":"REX","RID":0,"RAID":0,"Dt":"3/11/2018","Amount":100,"ARID":6,"Comment":"bank fee","FLAGS":0}}' > request
+dojsonPOST "http://localhost:8270/v1/expense/1/0" "request" "b2"  "NightAudit-PostOnLockedNight"
+mysql --no-defaults rentroll -e "SELECT e.LID,SUM(e.Amount) AS Amount FROM LedgerEntry e JOIN Journal j ON j.JID=e.JID WHERE j.BID=1 AND j.Type=1 AND j.ID>=168 GROUP BY e.LID ORDER BY e.LID; SELECT State,DtStart,DtStop FROM JournalMarker WHERE BID=1 ORDER BY JMID" > b3
+doValidateFile "b3" "NightAudit-Ledgers"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
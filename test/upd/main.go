@@ -114,7 +114,7 @@ func main() {
 }
 
 func updateRAR(ctx context.Context, biz *rlib.Business) {
-	var rar = rlib.RentalAgreementRentable{BID: 1, RAID: 2, RID: 3, ContractRent: float64(4500.00),
+	var rar = rlib.RentalAgreementRentable{BID: 1, RAID: 2, RID: 3, ContractRent: rlib.MoneyFromFloat(4500.00),
 		RARDtStart: time.Date(2017, time.March, 7, 0, 0, 0, 0, time.UTC),
 		RARDtStop:  time.Date(2018, time.March, 7, 0, 0, 0, 0, time.UTC)}
 	rarid, err := rlib.InsertRentalAgreementRentable(ctx, &rar)
@@ -239,7 +239,7 @@ func updateRAPayor(ctx context.Context, biz *rlib.Business) {
 func updateReceipt(ctx context.Context, biz *rlib.Business) {
 	var r rlib.Receipt
 	r.BID = biz.BID
-	r.Amount = float64(42.17)
+	r.Amount = rlib.Money(4217)
 	r.Dt = time.Date(2017, time.February, 14, 0, 0, 0, 0, time.UTC)
 	r.DocNo = "12345"
 	r.PMTID = 1
@@ -0,0 +1,79 @@
+#!/bin/bash
+TESTNAME="UTILITY BILL test"
+TESTSUMMARY="Split a utility bill on fixed ratios and assess the resident charges"
+
+RRDATERANGE="-j 2018-03-01 -k 2018-04-01"
+CREATENEWDB=0
+
+echo "Create new database..."
+mysql --no-defaults rentroll < ../closeperiod/rr.sql
+
+source ../share/base.sh
+
+echo "BEGIN UTILITY BILL FUNCTIONAL TEST" >>${LOGFILE}
+
+echo "STARTING RENTROLL SERVER"
+RENTROLLSERVERAUTH="-noauth"
+startRentRollServer
+
+#------------------------------------------------------------------------------
+#  UC lists the charges of the bill, UR its fixed ratios, UB its state, ASM
+#  the assessments made by the test and LEDG the ledger totals of their
+#  journal entries
+#------------------------------------------------------------------------------
+UC="SELECT UCID,RAID,RID,DtStart,DtStop,Days,Ratio,Share,Amount,ASMID FROM UtilityCharge WHERE UBID=1 ORDER BY UCID"
+UR="SELECT URID,RID,Ratio FROM UtilityRatio WHERE UBID=1 ORDER BY URID"
+UB="SELECT UBID,Method,Amount,CommonPct,DtPosted,FLAGS FROM UtilityBill WHERE UBID=1"
+ASM="SELECT ASMID,RAID,RID,ARID,Amount,Start,Stop,RentCycle,Comment FROM Assessments WHERE BID=1 AND ASMID>=168 ORDER BY ASMID"
+LEDG="SELECT e.LID,SUM(e.Amount) AS Amount FROM LedgerEntry e JOIN Journal j ON j.JID=e.JID WHERE j.BID=1 AND j.Type=1 AND j.ID>=168 GROUP BY e.LID ORDER BY e.LID"
+
+#------------------------------------------------------------------------------
+#  TEST a
+#  Save a fixed ratio utility bill
+#
+#  Scenario:
+#		A $1000.00 water bill for March 2018, 10% for the common areas,
+#		charged with AR 34 (Water and Sewer Base Fee). It is split on the
+#		fixed ratios 40/35/25 of Rentables 1, 2 and 24. Rentable 1 is
+#		rented by RA 1 and Rentable 2 by RA 2 all month, Rentable 24 is
+#		vacant.
+#
+#  Expected Results:
+#	1.	$100.00 goes to the common areas, $900.00 is split 40/35/25
+#	2.	RA 1 is charged $360.00 and RA 2 $315.00
+#	3.	The $225.00 share of the vacant Rentable 24 is not charged
+#	4.	The ratios are saved with the bill
+#------------------------------------------------------------------------------
+echo '{"cmd":"save","Record":{"recid":0,"UBID":0,"BID":1,"Utility":"Water","ARID":34,"Method":5,"Amount":1000,"CommonPct":10,"DtStart":"3/1/2018","DtStop":"4/1/2018"},"Ratios":[{"recid":1,"URID":0,"RID":1,"Ratio":40},{"recid":2,"URID":0,"RID":2,"Ratio":35},{"recid":3,"URID":0,"RID":24,"Ratio":25}]}' > request
+dojsonPOST "http://localhost:8270/v1/utilitybill/1/0" "request" "a0"  "UtilityBill-SaveFixedRatio"
+mysql --no-defaults rentroll -e "${UC}; ${UR}" > a1
+doValidateFile "a1" "UtilityBill-Charges"
+
+#------------------------------------------------------------------------------
+#  TEST b
+#  Post the utility bill
+#
+#  Scenario:
+#		Post the bill with no date. Then try to delete it and to post it
+#		again.
+#
+#  Expected Results:
+#	1.	A one time assessment with AR 34 is made for each charge on the
+#		last day of the billing period, 3/31/2018
+#	2.	Each charge is linked to its assessment, the bill is posted
+#	3.	12001 is debited $675.00 and 41303 credited $675.00
+#	4.	A posted bill cannot be deleted or posted again
+#------------------------------------------------------------------------------
+echo '{"cmd":"post"}' > request
+dojsonPOST "http://localhost:8270/v1/utilitybill/1/1" "request" "b0"  "UtilityBill-Post"
+mysql --no-defaults rentroll -e "${UB}; ${UC}; ${ASM}; ${LEDG}" > b1
+doValidateFile "b1" "UtilityBill-Posted"
+echo '{"cmd":"delete"}' > request
+dojsonPOST "http://localhost:8270/v1/utilitybill/1/1" "request" "b2"  "UtilityBill-DeletePosted"
+echo '{"cmd":"post"}' > request
+dojsonPOST "http://localhost:8270/v1/utilitybill/1/1" "request" "b3"  "UtilityBill-PostAgain"
+
+stopRentRollServer
+echo "RENTROLL SERVER STOPPED"
+
+logcheck
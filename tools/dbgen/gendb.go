@@ -845,7 +845,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 		rar.RARDtStart = d1
 		rar.RARDtStop = d2
 		rar.RID = RID
-		rar.ContractRent = RIDMktRate
+		rar.ContractRent = rlib.MoneyFromFloat(RIDMktRate)
 		_, err = rlib.InsertRentalAgreementRentable(ctx, &rar)
 		if err != nil {
 			return err
@@ -907,7 +907,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 		asmRent.BID = BID
 		asmRent.RID = RID
 		asmRent.RAID = ra.RAID
-		asmRent.Amount = RIDMktRate
+		asmRent.Amount = rlib.MoneyFromFloat(RIDMktRate)
 		asmRent.RentCycle = dbConf.xbiz.RT[rtr.RTID].RentCycle
 		asmRent.ProrationCycle = dbConf.xbiz.RT[rtr.RTID].Proration
 		asmRent.Start = epoch
@@ -924,7 +924,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 		asmSecDep.BID = BID
 		asmSecDep.RID = RID
 		asmSecDep.RAID = ra.RAID
-		asmSecDep.Amount = RIDMktRate * float64(2.0)
+		asmSecDep.Amount = rlib.MoneyFromFloat(RIDMktRate * float64(2.0))
 		asmSecDep.RentCycle = rlib.RECURNONE
 		asmSecDep.ProrationCycle = rlib.RECURNONE
 		asmSecDep.Start = d1
@@ -958,7 +958,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 					BID:            BID,
 					RID:            RID,
 					RAID:           ra.RAID,
-					Amount:         dbConf.PetFees[j].DefaultAmount,
+					Amount:         rlib.MoneyFromFloat(dbConf.PetFees[j].DefaultAmount),
 					RentCycle:      dbConf.xbiz.RT[rtr.RTID].RentCycle,
 					ProrationCycle: dbConf.xbiz.RT[rtr.RTID].Proration,
 					FLAGS:          1 << 3, // PETID required
@@ -1001,7 +1001,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 					BID:            BID,
 					RID:            RID,
 					RAID:           ra.RAID,
-					Amount:         dbConf.VehicleFees[j].DefaultAmount,
+					Amount:         rlib.MoneyFromFloat(dbConf.VehicleFees[j].DefaultAmount),
 					RentCycle:      dbConf.xbiz.RT[rtr.RTID].RentCycle,
 					ProrationCycle: dbConf.xbiz.RT[rtr.RTID].Proration,
 					FLAGS:          1 << 4, // VID required
@@ -1034,8 +1034,8 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 			a.RID = RID
 			a.RAID = ra.RAID
 			tot, np, tp := rlib.SimpleProrateAmount(RIDMktRate, asmRent.RentCycle, asmRent.ProrationCycle, &d1, &td2, &epoch)
-			a.Amount = tot
-			if a.Amount < RIDMktRate {
+			a.Amount = rlib.MoneyFromFloat(tot)
+			if tot < RIDMktRate {
 				a.Comment = fmt.Sprintf("prorated for %d of %d %s", np, tp, rlib.ProrationUnits(asmRent.ProrationCycle))
 			}
 			a.RentCycle = rlib.RECURNONE
@@ -1086,7 +1086,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 						BID:            BID,
 						RID:            RID,
 						RAID:           ra.RAID,
-						Amount:         tot,
+						Amount:         rlib.MoneyFromFloat(tot),
 						RentCycle:      rlib.RECURNONE,
 						ProrationCycle: rlib.RECURNONE,
 						AssocElemType:  rlib.ELEMPET,
@@ -1139,7 +1139,7 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 						BID:            BID,
 						RID:            RID,
 						RAID:           ra.RAID,
-						Amount:         tot,
+						Amount:         rlib.MoneyFromFloat(tot),
 						RentCycle:      rlib.RECURNONE,
 						ProrationCycle: rlib.RECURNONE,
 						AssocElemType:  rlib.ELEMVEHICLE,
@@ -1180,8 +1180,8 @@ func createRentalAgreements(ctx context.Context, dbConf *GenDBConf) error {
 //     OpDeps   - pointer to a slice of RCPTIDs that are being deposited in
 //                 the operational account
 //-----------------------------------------------------------------------------
-func makeDeposits(ctx context.Context, dbConf *GenDBConf, SecDepAmt, OpDepAmt float64, dt *time.Time, SecDeps, OpDeps *[]int64) error {
-	if SecDepAmt > float64(0) {
+func makeDeposits(ctx context.Context, dbConf *GenDBConf, SecDepAmt, OpDepAmt rlib.Money, dt *time.Time, SecDeps, OpDeps *[]int64) error {
+	if SecDepAmt > 0 {
 		var b = rlib.Deposit{
 			BID:    dbConf.BIZ[0].BID,
 			DEPID:  dbConf.SecDepDepository,
@@ -1194,7 +1194,7 @@ func makeDeposits(ctx context.Context, dbConf *GenDBConf, SecDepAmt, OpDepAmt fl
 			return bizlogic.BizErrorListToError(e)
 		}
 	}
-	if OpDepAmt > float64(0) {
+	if OpDepAmt > 0 {
 		var c = rlib.Deposit{
 			BID:    dbConf.BIZ[0].BID,
 			DEPID:  dbConf.OpDepository,
@@ -1227,8 +1227,8 @@ func CreateDeposits(ctx context.Context, dbConf *GenDBConf) error {
 	//------------------------------------------------------------------------
 	// Collect the payments, separate security deposits from other payments
 	//------------------------------------------------------------------------
-	SecDepAmt := float64(0)
-	OpDepAmt := float64(0)
+	SecDepAmt := rlib.Money(0)
+	OpDepAmt := rlib.Money(0)
 	lastdep := rlib.TIME0
 	lastrcpt := rlib.TIME0
 	lastdepNotInitialized := true
@@ -1257,8 +1257,8 @@ func CreateDeposits(ctx context.Context, dbConf *GenDBConf) error {
 			if err != nil {
 				return err
 			}
-			SecDepAmt = float64(0)
-			OpDepAmt = float64(0)
+			SecDepAmt = rlib.Money(0)
+			OpDepAmt = rlib.Money(0)
 			SecDeps = []int64{}
 			OpDeps = []int64{}
 			lastdep = dt
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// AsmStepApplyBot is a worker that is called by TWS once a day to split the
+// recurring assessments whose scheduled steps have come due.
+//-----------------------------------------------------------------------------
+func AsmStepApplyBot(item *tws.Item) {
+	checkInterval := 24 * time.Hour
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(time.Hour)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.BotReg[rlib.AsmStepBot].Designator,
+		rlib.AsmStepBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	workerError(item, AsmStepApplyAll(ctx, &now))
+
+	//---------------------------------------------
+	// schedule this again tomorrow...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// AsmStepApplyAll applies every assessment step that takes effect on or
+// before the day of now. Each step is applied in its own transaction so that
+// one failure, a step dated in a closed period for example, does not hold up
+// the others; it is tried again the next day.
+//
+// INPUTS
+//    ctx - context with the bot's session
+//    now - current time
+//
+// RETURNS
+//    any error encountered reading the steps
+//-----------------------------------------------------------------------------
+func AsmStepApplyAll(ctx context.Context, now *time.Time) error {
+	funcname := "AsmStepApplyAll"
+	dt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	m, err := rlib.GetAssessmentStepsDue(ctx, &dt)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			return err
+		}
+
+		//------------------------------------------------------------
+		// an earlier step of the same assessment moved this one to
+		// the new definition, read it again
+		//------------------------------------------------------------
+		s, err := rlib.GetAssessmentStep(tctx, m[i].ASTID)
+		if err != nil {
+			tx.Rollback()
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if errlist := bizlogic.ApplyAssessmentStep(tctx, &s, &dt); len(errlist) > 0 {
+			tx.Rollback()
+			err = bizlogic.BizErrorListToError(errlist)
+			rlib.LogAndPrintError(funcname, fmt.Errorf("assessment step %d: %s", s.ASTID, err.Error()))
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		rlib.Ulog("%s: assessment step %d, ASMID %d split on %s, new ASMID %d\n", funcname, s.ASTID, s.ASMID, s.Dt.Format(rlib.RRDATEFMT3), s.NewASMID)
+	}
+	return nil
+}
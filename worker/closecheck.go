@@ -0,0 +1,593 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+	"tws"
+)
+
+// Close checks are tasks in a business's close period task list that verify
+// the books before the period is closed. Each check is a worker that users
+// can select as the Worker of a TaskDescriptor.  Once the due date of the
+// task list has arrived the worker runs its check every day on the tasks
+// that are not yet done. A task whose check passes is marked done. A task
+// whose check fails gets the list of failures in its Comment.  The checks
+// are run again when the close is attempted and the close is blocked until
+// all of them pass.
+
+// CloseCheck verifies one aspect of the books of business bid for the period
+// d1 to d2.  It returns a description of each problem found.
+type CloseCheck func(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error)
+
+// CloseChecks maps the designator of a close check worker to its check
+var CloseChecks = map[string]CloseCheck{
+	rlib.BotReg[rlib.CloseChkAsmBot].Designator:   CheckRecurringInstances,
+	rlib.BotReg[rlib.CloseChkDepBot].Designator:   CheckUndepositedReceipts,
+	rlib.BotReg[rlib.CloseChkUnalBot].Designator:  CheckUnallocatedFunds,
+	rlib.BotReg[rlib.CloseChkTBBot].Designator:    CheckTrialBalance,
+	rlib.BotReg[rlib.CloseChkReconBot].Designator: CheckDepositoryReconcile,
+}
+
+// CloseCheckBot returns the tws handler for the close check worker with the
+// supplied bot uid.
+//
+// INPUTS
+//  uid - uid of the close check bot
+//
+// RETURNS
+//  the handler
+//-----------------------------------------------------------------------------
+func CloseCheckBot(uid int64) func(*tws.Item) {
+	return func(item *tws.Item) {
+		tws.ItemWorking(item)
+		now := time.Now()
+		expire := now.Add(10 * time.Minute)
+		s := rlib.SessionNew("BotToken-"+rlib.BotReg[uid].Designator,
+			rlib.BotReg[uid].Designator,
+			rlib.BotReg[uid].Designator,
+			uid, "", -1, &expire)
+		ctx := context.Background()
+		ctx = rlib.SetSessionContextKey(ctx, s)
+		workerError(item, CloseCheckCore(ctx, rlib.BotReg[uid].Designator, &now))
+
+		// reschedule for midnight tomorrow...
+		resched := now.AddDate(0, 0, 1)
+		tws.RescheduleItem(item, resched)
+	}
+}
+
+// CloseCheckCore provides a more testable calling routine for the close
+// check workers.  It runs the check named by designator on the open tasks of
+// the next close period task list of each business, if that task list is due.
+//
+// INPUTS
+//  ctx        - context which may include a database transaction in progress
+//  designator - designator of the close check worker
+//  now        - current time
+//
+// RETURNS
+//  any error encountered reading the businesses
+//-----------------------------------------------------------------------------
+func CloseCheckCore(ctx context.Context, designator string, now *time.Time) error {
+	funcname := "CloseCheckCore"
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		tl, err := CloseTargetTaskList(ctx, m[i].BID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if tl.TLID == 0 || tl.DtDue.After(*now) {
+			continue
+		}
+		t, err := rlib.GetTasks(ctx, tl.TLID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		for j := 0; j < len(t); j++ {
+			if t[j].Worker != designator || t[j].DtDone.After(rlib.TIME0) {
+				continue
+			}
+			if _, err = runCloseCheck(ctx, &tl, &t[j], now); err != nil {
+				rlib.LogAndPrintError(funcname, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CloseTargetTaskList returns the instance of the close period task list for
+// the first period of business bid that has not been closed.
+//
+// INPUTS
+//  ctx - context which may include a database transaction in progress
+//  bid - the business
+//
+// RETURNS
+//  the task list, TLID will be 0 if the business has no close period task
+//  list or its next instance has not been created
+//  any error encountered
+//-----------------------------------------------------------------------------
+func CloseTargetTaskList(ctx context.Context, bid int64) (rlib.TaskList, error) {
+	var tl rlib.TaskList
+	var b rlib.Business
+	if err := rlib.GetBusiness(ctx, bid, &b); err != nil {
+		return tl, err
+	}
+	if b.ClosePeriodTLID == 0 {
+		return tl, nil
+	}
+	lcp, err := rlib.GetLastClosePeriod(ctx, bid)
+	if err != nil {
+		return tl, err
+	}
+	if lcp.CPID == 0 {
+		return rlib.GetTaskList(ctx, b.ClosePeriodTLID)
+	}
+	last, err := rlib.GetTaskList(ctx, lcp.TLID)
+	if err != nil {
+		return tl, err
+	}
+	target := rlib.NextInstance(&last.DtDue, last.Cycle)
+	dt1 := target.Add(-1 * time.Hour)
+	dt2 := target.Add(1 * time.Hour)
+	id := last.PTLID
+	if id == 0 {
+		id = last.TLID
+	}
+	return rlib.GetTaskListInstanceInRange(ctx, id, &dt1, &dt2)
+}
+
+// RunCloseChecks runs every close check in task list tl, which must be the
+// close period task list for the period being closed.  All the checks are
+// run, even those already done, as the books may have changed since.  The
+// tasks are updated with the results.
+//
+// INPUTS
+//  ctx - context which may include a database transaction in progress
+//  tl  - the close period task list
+//
+// RETURNS
+//  the failures, each prefixed with the name of its task. The close must
+//  not proceed unless this is empty.
+//  any error encountered
+//-----------------------------------------------------------------------------
+func RunCloseChecks(ctx context.Context, tl *rlib.TaskList) ([]string, error) {
+	now := time.Now()
+	t, err := rlib.GetTasks(ctx, tl.TLID)
+	if err != nil {
+		return nil, err
+	}
+	return runCloseChecks(t, func(t *rlib.Task) ([]string, error) {
+		return runCloseCheck(ctx, tl, t, &now)
+	})
+}
+
+// runCloseChecks calls run for each task in t that is a close check and
+// collects the failures, each prefixed with the name of its task.  It stops
+// at the first error.
+//-----------------------------------------------------------------------------
+func runCloseChecks(t []rlib.Task, run func(t *rlib.Task) ([]string, error)) ([]string, error) {
+	var errs []string
+	for i := 0; i < len(t); i++ {
+		if _, ok := CloseChecks[t[i].Worker]; !ok {
+			continue
+		}
+		m, err := run(&t[i])
+		if err != nil {
+			return errs, err
+		}
+		for j := 0; j < len(m); j++ {
+			errs = append(errs, t[i].Name+": "+m[j])
+		}
+	}
+	return errs, nil
+}
+
+// runCloseCheck runs the check for task t of close period task list tl and
+// saves the result in t.
+//
+// RETURNS
+//  the failures
+//  any error encountered
+//-----------------------------------------------------------------------------
+func runCloseCheck(ctx context.Context, tl *rlib.TaskList, t *rlib.Task, now *time.Time) ([]string, error) {
+	d1, d2 := ClosePeriodRange(tl)
+	m, err := CloseChecks[t.Worker](ctx, tl.BID, &d1, &d2)
+	if err != nil {
+		return m, err
+	}
+	setCloseCheckResult(t, m, now)
+	return m, rlib.UpdateTask(ctx, t)
+}
+
+// setCloseCheckResult marks task t done at now if its check found no
+// failures m, otherwise it marks it not done and puts the failures in its
+// Comment.
+//-----------------------------------------------------------------------------
+func setCloseCheckResult(t *rlib.Task, m []string, now *time.Time) {
+	if len(m) == 0 {
+		t.DtDone = *now
+		t.DoneUID = closeCheckBotUID(t.Worker)
+		t.Comment = ""
+		return
+	}
+	t.DtDone = rlib.TIME0
+	t.DoneUID = 0
+	t.Comment = strings.Join(m, "\n")
+}
+
+// closeCheckBotUID returns the uid of the close check bot with the supplied
+// designator
+//-----------------------------------------------------------------------------
+func closeCheckBotUID(designator string) int64 {
+	for k, v := range rlib.BotReg {
+		if v.Designator == designator {
+			return k
+		}
+	}
+	return 0
+}
+
+// ClosePeriodRange returns the period covered by close period task list tl.
+// The period ends on the task list's due date and starts one cycle earlier.
+//-----------------------------------------------------------------------------
+func ClosePeriodRange(tl *rlib.TaskList) (time.Time, time.Time) {
+	return rlib.GetPreviousInstance(tl.DtDue, tl.Cycle), tl.DtDue
+}
+
+// Each check below reads what it needs from the database and passes it to a
+// function that does the checking, with lookups for anything it needs per
+// record.  The checking functions do not touch the database.
+
+// CheckRecurringInstances verifies that every recurring assessment in effect
+// during the period has an instance for each of its occurrences.
+//-----------------------------------------------------------------------------
+func CheckRecurringInstances(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error) {
+	m, err := rlib.GetRecurringAssessmentDefsByBusiness(ctx, bid, d1, d2)
+	if err != nil {
+		return nil, err
+	}
+	return missingInstances(m, d1, d2, func(dt *time.Time, asmid int64) (int64, error) {
+		a, err := rlib.GetAssessmentInstance(ctx, dt, asmid)
+		return a.ASMID, err
+	})
+}
+
+// missingInstances returns a failure for each occurrence during d1 to d2 of
+// the recurring assessments m for which instance returns 0.
+//-----------------------------------------------------------------------------
+func missingInstances(m []rlib.Assessment, d1, d2 *time.Time, instance func(dt *time.Time, asmid int64) (int64, error)) ([]string, error) {
+	var errs []string
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&4 != 0 { // reversed
+			continue
+		}
+		dl := m[i].GetRecurrences(d1, d2)
+		for j := 0; j < len(dl); j++ {
+			id, err := instance(&dl[j], m[i].ASMID)
+			if err != nil {
+				return errs, err
+			}
+			if id == 0 {
+				errs = append(errs, fmt.Sprintf("%s has no instance for %s", m[i].IDtoShortString(), dl[j].Format(rlib.RRDATEFMT4)))
+			}
+		}
+	}
+	return errs, nil
+}
+
+// CheckUndepositedReceipts verifies that every receipt of the period has
+// been deposited.
+//-----------------------------------------------------------------------------
+func CheckUndepositedReceipts(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error) {
+	m, err := rlib.GetReceipts(ctx, bid, d1, d2)
+	if err != nil {
+		return nil, err
+	}
+	return undepositedReceipts(m), nil
+}
+
+// undepositedReceipts returns a failure for each receipt in m that has
+// neither been reversed nor deposited.
+//-----------------------------------------------------------------------------
+func undepositedReceipts(m []rlib.Receipt) []string {
+	var errs []string
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&4 != 0 || m[i].DID != 0 { // reversed or deposited
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("%s for %.2f dated %s has not been deposited", m[i].IDtoShortString(), m[i].Amount, m[i].Dt.Format(rlib.RRDATEFMT4)))
+	}
+	return errs
+}
+
+// CheckUnallocatedFunds verifies that the funds received through the end of
+// the period that have not been allocated do not exceed the business's
+// CloseUnallocatedLimit.  The business must have its general properties,
+// the check cannot be made without the limit.
+//-----------------------------------------------------------------------------
+func CheckUnallocatedFunds(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error) {
+	bp, err := rlib.GetDataFromBusinessPropertyName(ctx, "general", bid)
+	if err != nil {
+		return nil, err
+	}
+	m, err := rlib.GetUnallocatedReceipts(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	return unallocatedFunds(m, d2, bp.CloseUnallocatedLimit, func(rcptid int64) (rlib.Money, error) {
+		_, _, unalloc, err := rlib.GetReceiptAllocationAmountsOnDate(ctx, rcptid, d2)
+		return unalloc, err
+	})
+}
+
+// unallocatedFunds returns a failure if the total that unallocated returns
+// for the receipts in m dated before d2 exceeds limit.
+//-----------------------------------------------------------------------------
+func unallocatedFunds(m []rlib.Receipt, d2 *time.Time, limit rlib.Money, unallocated func(rcptid int64) (rlib.Money, error)) ([]string, error) {
+	var errs []string
+	total := rlib.Money(0)
+	for i := 0; i < len(m); i++ {
+		if !m[i].Dt.Before(*d2) {
+			continue
+		}
+		unalloc, err := unallocated(m[i].RCPTID)
+		if err != nil {
+			return errs, err
+		}
+		total += unalloc
+	}
+	if total > limit {
+		errs = append(errs, fmt.Sprintf("unallocated funds of %.2f exceed the limit of %.2f", total, limit))
+	}
+	return errs, nil
+}
+
+// CheckTrialBalance verifies that the balances of all the accounts that
+// allow posts net to zero at the end of the period.
+//-----------------------------------------------------------------------------
+func CheckTrialBalance(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error) {
+	m, err := rlib.GetLedgerList(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	return trialBalance(m, func(lid int64) (rlib.Money, error) {
+		return rlib.GetAccountBalance(ctx, bid, lid, d2)
+	})
+}
+
+// trialBalance returns a failure if the balances that balance returns for
+// the accounts in m that allow posts do not net to zero.
+//-----------------------------------------------------------------------------
+func trialBalance(m []rlib.GLAccount, balance func(lid int64) (rlib.Money, error)) ([]string, error) {
+	var errs []string
+	total := rlib.Money(0)
+	for i := 0; i < len(m); i++ {
+		if !m[i].AllowPost {
+			continue
+		}
+		bal, err := balance(m[i].LID)
+		if err != nil {
+			return errs, err
+		}
+		total += bal
+	}
+	if total != 0 {
+		errs = append(errs, fmt.Sprintf("the trial balance is out of balance by %.2f", total))
+	}
+	return errs, nil
+}
+
+// CheckDepositoryReconcile verifies that the GL account of each depository
+// is explained by the deposits made to it.  The balance of the account at
+// the end of the period must equal the balance of its initial ledger
+// marker, plus the deposits made to the depository through the end of the
+// period, plus what the receipts that are not in one of those deposits yet
+// posted to the account (the items in transit), plus what the vendor
+// payments and checks drawn on the depository posted.  Anything else posted
+// to the account, such as a journal made by hand, puts it out of balance,
+// as does a receipt that posted to the account something other than what
+// was deposited.
+//
+// Each deposit made during the period is then compared with what its
+// receipts posted to the account, to point at the deposit that is wrong.
+// Only the entries of the receipts in the deposit count: the journals of
+// the receipts themselves, which post to the depository account when the
+// receipt's account rule debits it, and the transfers made when they were
+// deposited.  Negative entries, such as those of reversal receipts, count
+// too, so a deposit that was reduced when part of it was taken back still
+// reconciles.
+//-----------------------------------------------------------------------------
+func CheckDepositoryReconcile(ctx context.Context, bid int64, d1, d2 *time.Time) ([]string, error) {
+	dl, err := rlib.GetAllDepositories(ctx, bid)
+	if err != nil {
+		return nil, err
+	}
+	all, err := rlib.GetAllDepositsInRange(ctx, bid, &rlib.TIME0, d2)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := depositoryBalances(dl, d2, func(dep *rlib.Depository) (depositoryBook, error) {
+		return getDepositoryBook(ctx, bid, dep, all, d2)
+	})
+	if err != nil {
+		return errs, err
+	}
+	m, err := rlib.GetAllDepositsInRange(ctx, bid, d1, d2)
+	if err != nil {
+		return errs, err
+	}
+	e, err := depositoryReconcile(dl, m, func(d *rlib.Deposit, lid int64) (rlib.Money, error) {
+		return depositPosted(ctx, d, lid)
+	})
+	return append(errs, e...), err
+}
+
+// depositoryBook is what the GL account of a depository holds at the end of
+// the period, broken down by where it came from.
+type depositoryBook struct {
+	balance  rlib.Money // balance of the account
+	opening  rlib.Money // balance of its initial ledger marker
+	deposits rlib.Money // deposits made to the depository
+	transit  rlib.Money // posted by receipts not deposited to it yet
+	drawn    rlib.Money // posted by vendor payments and checks drawn on it
+	other    rlib.Money // posted by any other journal
+}
+
+// depositoryBalances returns a failure for each depository in dl whose
+// account balance on d2 differs from what book says the deposits, the items
+// in transit and the payments drawn on it account for.
+//-----------------------------------------------------------------------------
+func depositoryBalances(dl []rlib.Depository, d2 *time.Time, book func(dep *rlib.Depository) (depositoryBook, error)) ([]string, error) {
+	var errs []string
+	for i := 0; i < len(dl); i++ {
+		b, err := book(&dl[i])
+		if err != nil {
+			return errs, err
+		}
+		expect := b.opening + b.deposits + b.transit + b.drawn
+		if b.balance != expect {
+			errs = append(errs, fmt.Sprintf("%s account balance on %s is %.2f but its opening balance of %.2f, deposits of %.2f, %.2f in transit and %.2f drawn come to %.2f, other journals posted %.2f", dl[i].Name, d2.Format(rlib.RRDATEFMT4), b.balance, b.opening, b.deposits, b.transit, b.drawn, expect, b.other))
+		}
+	}
+	return errs, nil
+}
+
+// getDepositoryBook reads the depositoryBook of depository dep on d2. all
+// holds the deposits of the business made before d2.
+//-----------------------------------------------------------------------------
+func getDepositoryBook(ctx context.Context, bid int64, dep *rlib.Depository, all []rlib.Deposit, d2 *time.Time) (depositoryBook, error) {
+	var b depositoryBook
+	var err error
+	if b.balance, err = rlib.GetAccountBalance(ctx, bid, dep.LID, d2); err != nil {
+		return b, err
+	}
+	lm, err := rlib.GetLedgerMarkerOnOrBefore(ctx, bid, dep.LID, &rlib.TIME0)
+	if err != nil {
+		return b, err
+	}
+	b.opening = lm.Balance
+	deposited := map[int64]bool{} // deposits to dep
+	for i := 0; i < len(all); i++ {
+		if all[i].DEPID == dep.DEPID {
+			b.deposits += all[i].Amount
+			deposited[all[i].DID] = true
+		}
+	}
+	le, err := rlib.GetLedgerEntriesInRange(ctx, &rlib.TIME0, d2, bid, dep.LID)
+	if err != nil {
+		return b, err
+	}
+	jnl := map[int64]rlib.Journal{}
+	rcpt := map[int64]rlib.Receipt{}
+	for i := 0; i < len(le); i++ {
+		j, ok := jnl[le[i].JID]
+		if !ok {
+			if j, err = rlib.GetJournal(ctx, le[i].JID); err != nil {
+				return b, err
+			}
+			jnl[le[i].JID] = j
+		}
+		switch j.Type {
+		case rlib.JNLTYPERCPT, rlib.JNLTYPEXFER:
+			r, ok := rcpt[j.ID]
+			if !ok {
+				if r, err = rlib.GetReceipt(ctx, j.ID); err != nil {
+					return b, err
+				}
+				rcpt[j.ID] = r
+			}
+			if !deposited[r.DID] {
+				b.transit += le[i].Amount
+			}
+		case rlib.JNLTYPEVPMT, rlib.JNLTYPECHK:
+			b.drawn += le[i].Amount
+		default:
+			b.other += le[i].Amount
+		}
+	}
+	return b, nil
+}
+
+// depositoryReconcile returns a failure for each deposit in m whose amount
+// differs from what posted returns for it and the GL account of its
+// depository in dl.  Other activity in the account, like checks or
+// transfers between accounts, is not part of any deposit and is left to
+// depositoryBalances.
+//-----------------------------------------------------------------------------
+func depositoryReconcile(dl []rlib.Depository, m []rlib.Deposit, posted func(d *rlib.Deposit, lid int64) (rlib.Money, error)) ([]string, error) {
+	var errs []string
+	for i := 0; i < len(dl); i++ {
+		for j := 0; j < len(m); j++ {
+			if m[j].DEPID != dl[i].DEPID {
+				continue
+			}
+			gl, err := posted(&m[j], dl[i].LID)
+			if err != nil {
+				return errs, err
+			}
+			if gl != m[j].Amount {
+				errs = append(errs, fmt.Sprintf("deposit %d to %s on %s is %.2f but its receipts posted %.2f to the account", m[j].DID, dl[i].Name, m[j].Dt.Format(rlib.RRDATEFMT4), m[j].Amount, gl))
+			}
+		}
+	}
+	return errs, nil
+}
+
+// depositPosted returns the net of the ledger entries to GL account lid made
+// by the receipts of deposit d, whatever their date.
+//-----------------------------------------------------------------------------
+func depositPosted(ctx context.Context, d *rlib.Deposit, lid int64) (rlib.Money, error) {
+	total := rlib.Money(0)
+	dp, err := rlib.GetDepositParts(ctx, d.DID)
+	if err != nil {
+		return total, err
+	}
+	for i := 0; i < len(dp); i++ {
+		jl, err := rlib.GetJournalsByReceiptID(ctx, dp[i].RCPTID)
+		if err != nil {
+			return total, err
+		}
+		x, err := rlib.GetJournalByTypeAndID(ctx, rlib.JNLTYPEXFER, dp[i].RCPTID)
+		if err != nil {
+			return total, err
+		}
+		if x.JID > 0 {
+			jl = append(jl, x)
+		}
+		for j := 0; j < len(jl); j++ {
+			if err = rlib.GetJournalAllocations(ctx, &jl[j]); err != nil {
+				return total, err
+			}
+			for k := 0; k < len(jl[j].JA); k++ {
+				le, err := rlib.GetLedgerEntriesByJAID(ctx, d.BID, jl[j].JA[k].JAID)
+				if err != nil {
+					return total, err
+				}
+				total += netPosted(le, lid)
+			}
+		}
+	}
+	return total, nil
+}
+
+// netPosted returns the net of the entries in le to GL account lid
+//-----------------------------------------------------------------------------
+func netPosted(le []rlib.LedgerEntry, lid int64) rlib.Money {
+	total := rlib.Money(0)
+	for i := 0; i < len(le); i++ {
+		if le[i].LID == lid {
+			total += le[i].Amount
+		}
+	}
+	return total
+}
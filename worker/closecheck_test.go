@@ -0,0 +1,274 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	ccMar1 = time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)
+	ccApr1 = time.Date(2018, time.April, 1, 0, 0, 0, 0, time.UTC)
+	ccErr  = fmt.Errorf("database is down")
+)
+
+// checkFailures compares the failures a check returned with those expected,
+// each expected failure need only be contained in the one returned.
+func checkFailures(t *testing.T, name string, got []string, expect []string) {
+	if len(got) != len(expect) {
+		t.Errorf("%s: expect %d failures, got %d: %q\n", name, len(expect), len(got), got)
+		return
+	}
+	for i := 0; i < len(expect); i++ {
+		if !strings.Contains(got[i], expect[i]) {
+			t.Errorf("%s: expect failure %d to contain %q, got %q\n", name, i, expect[i], got[i])
+		}
+	}
+}
+
+func TestMissingInstances(t *testing.T) {
+	monthly := rlib.Assessment{ASMID: 10, RentCycle: rlib.RECURMONTHLY, Start: time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), Stop: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	reversed := monthly
+	reversed.ASMID = 11
+	reversed.FLAGS = 4
+	var m = []struct {
+		name   string
+		asm    []rlib.Assessment
+		have   map[int64]int64 // instance ASMID of each recurring ASMID
+		err    error
+		expect []string
+	}{
+		{"instance present", []rlib.Assessment{monthly}, map[int64]int64{10: 20}, nil, nil},
+		{"instance missing", []rlib.Assessment{monthly}, map[int64]int64{}, nil, []string{"ASM-10 has no instance for 03/01/2018"}},
+		{"reversed definition", []rlib.Assessment{reversed}, map[int64]int64{}, nil, nil},
+		{"lookup error", []rlib.Assessment{monthly}, nil, ccErr, nil},
+	}
+	for i := 0; i < len(m); i++ {
+		errs, err := missingInstances(m[i].asm, &ccMar1, &ccApr1, func(dt *time.Time, asmid int64) (int64, error) {
+			if !dt.Equal(ccMar1) {
+				t.Errorf("%s: unexpected occurrence %s\n", m[i].name, dt)
+			}
+			return m[i].have[asmid], m[i].err
+		})
+		if err != m[i].err {
+			t.Errorf("%s: expect error %v, got %v\n", m[i].name, m[i].err, err)
+		}
+		checkFailures(t, m[i].name, errs, m[i].expect)
+	}
+}
+
+func TestUndepositedReceipts(t *testing.T) {
+	var m = []struct {
+		name   string
+		r      rlib.Receipt
+		expect []string
+	}{
+		{"deposited", rlib.Receipt{RCPTID: 1, DID: 5, Amount: 100, Dt: ccMar1}, nil},
+		{"reversed", rlib.Receipt{RCPTID: 2, FLAGS: 4, Amount: 100, Dt: ccMar1}, nil},
+		{"undeposited", rlib.Receipt{RCPTID: 3, Amount: 12345, Dt: ccMar1}, []string{"for 123.45 dated 03/01/2018 has not been deposited"}},
+	}
+	for i := 0; i < len(m); i++ {
+		checkFailures(t, m[i].name, undepositedReceipts([]rlib.Receipt{m[i].r}), m[i].expect)
+	}
+}
+
+func TestUnallocatedFunds(t *testing.T) {
+	rcpts := []rlib.Receipt{
+		{RCPTID: 1, Dt: ccMar1},
+		{RCPTID: 2, Dt: ccMar1.AddDate(0, 0, 14)},
+		{RCPTID: 3, Dt: ccApr1}, // after the period, never counted
+	}
+	var m = []struct {
+		name    string
+		unalloc map[int64]rlib.Money
+		limit   rlib.Money
+		err     error
+		expect  []string
+	}{
+		{"nothing unallocated", map[int64]rlib.Money{3: 90000}, 0, nil, nil},
+		{"under the limit", map[int64]rlib.Money{1: 1000, 2: 2000}, 5000, nil, nil},
+		{"at the limit", map[int64]rlib.Money{1: 1000, 2: 4000}, 5000, nil, nil},
+		{"over the limit", map[int64]rlib.Money{1: 1000, 2: 4001}, 5000, nil, []string{"unallocated funds of 50.01 exceed the limit of 50.00"}},
+		{"no limit", map[int64]rlib.Money{1: 1}, 0, nil, []string{"unallocated funds of 0.01 exceed the limit of 0.00"}},
+		{"lookup error", nil, 0, ccErr, nil},
+	}
+	for i := 0; i < len(m); i++ {
+		errs, err := unallocatedFunds(rcpts, &ccApr1, m[i].limit, func(rcptid int64) (rlib.Money, error) {
+			if rcptid == 3 {
+				t.Errorf("%s: receipt 3 is after the period\n", m[i].name)
+			}
+			return m[i].unalloc[rcptid], m[i].err
+		})
+		if err != m[i].err {
+			t.Errorf("%s: expect error %v, got %v\n", m[i].name, m[i].err, err)
+		}
+		checkFailures(t, m[i].name, errs, m[i].expect)
+	}
+}
+
+func TestTrialBalance(t *testing.T) {
+	accts := []rlib.GLAccount{
+		{LID: 1, AllowPost: true},
+		{LID: 2, AllowPost: true},
+		{LID: 3}, // parent account, its balance is that of its children
+	}
+	var m = []struct {
+		name   string
+		bal    map[int64]rlib.Money
+		err    error
+		expect []string
+	}{
+		{"in balance", map[int64]rlib.Money{1: 5000, 2: -5000, 3: 5000}, nil, nil},
+		{"out of balance", map[int64]rlib.Money{1: 5000, 2: -4900}, nil, []string{"out of balance by 1.00"}},
+		{"lookup error", nil, ccErr, nil},
+	}
+	for i := 0; i < len(m); i++ {
+		errs, err := trialBalance(accts, func(lid int64) (rlib.Money, error) {
+			return m[i].bal[lid], m[i].err
+		})
+		if err != m[i].err {
+			t.Errorf("%s: expect error %v, got %v\n", m[i].name, m[i].err, err)
+		}
+		checkFailures(t, m[i].name, errs, m[i].expect)
+	}
+}
+
+func TestDepositoryReconcile(t *testing.T) {
+	dl := []rlib.Depository{
+		{DEPID: 1, LID: 10, Name: "First Bank"},
+		{DEPID: 2, LID: 20, Name: "Second Bank"},
+	}
+	var m = []struct {
+		name   string
+		d      []rlib.Deposit
+		posted map[int64]rlib.Money // what the receipts of each DID posted
+		err    error
+		expect []string
+	}{
+		{"reconciles", []rlib.Deposit{{DID: 1, DEPID: 1, Amount: 50000}, {DID: 2, DEPID: 2, Amount: 700}}, map[int64]rlib.Money{1: 50000, 2: 700}, nil, nil},
+		{"short", []rlib.Deposit{{DID: 1, DEPID: 1, Amount: 50000, Dt: ccMar1}}, map[int64]rlib.Money{1: 40000}, nil, []string{"deposit 1 to First Bank on 03/01/2018 is 500.00 but its receipts posted 400.00"}},
+		{"each deposit on its own", []rlib.Deposit{{DID: 1, DEPID: 1, Amount: 100}, {DID: 2, DEPID: 1, Amount: 200}}, map[int64]rlib.Money{1: 200, 2: 100}, nil, []string{"deposit 1", "deposit 2"}},
+		{"unknown depository", []rlib.Deposit{{DID: 1, DEPID: 3, Amount: 100}}, nil, nil, nil},
+		{"lookup error", []rlib.Deposit{{DID: 1, DEPID: 1, Amount: 100}}, nil, ccErr, nil},
+	}
+	for i := 0; i < len(m); i++ {
+		errs, err := depositoryReconcile(dl, m[i].d, func(d *rlib.Deposit, lid int64) (rlib.Money, error) {
+			if lid != dl[d.DEPID-1].LID {
+				t.Errorf("%s: deposit %d looked up on account %d\n", m[i].name, d.DID, lid)
+			}
+			return m[i].posted[d.DID], m[i].err
+		})
+		if err != m[i].err {
+			t.Errorf("%s: expect error %v, got %v\n", m[i].name, m[i].err, err)
+		}
+		checkFailures(t, m[i].name, errs, m[i].expect)
+	}
+}
+
+func TestDepositoryBalances(t *testing.T) {
+	dl := []rlib.Depository{{DEPID: 1, LID: 10, Name: "First Bank"}}
+	var m = []struct {
+		name   string
+		b      depositoryBook
+		err    error
+		expect []string
+	}{
+		{"deposits only", depositoryBook{balance: 60000, opening: 10000, deposits: 50000}, nil, nil},
+		{"in transit and drawn", depositoryBook{balance: 45000, deposits: 50000, transit: 2500, drawn: -7500}, nil, nil},
+		{"journal by hand", depositoryBook{balance: 51000, deposits: 50000, other: 1000}, nil, []string{"First Bank account balance on 04/01/2018 is 510.00 but its opening balance of 0.00, deposits of 500.00, 0.00 in transit and 0.00 drawn come to 500.00, other journals posted 10.00"}},
+		{"receipt misposted", depositoryBook{balance: 40000, deposits: 50000}, nil, []string{"is 400.00 but"}},
+		{"lookup error", depositoryBook{}, ccErr, nil},
+	}
+	for i := 0; i < len(m); i++ {
+		errs, err := depositoryBalances(dl, &ccApr1, func(dep *rlib.Depository) (depositoryBook, error) {
+			if dep.DEPID != 1 {
+				t.Errorf("%s: unexpected depository %d\n", m[i].name, dep.DEPID)
+			}
+			return m[i].b, m[i].err
+		})
+		if err != m[i].err {
+			t.Errorf("%s: expect error %v, got %v\n", m[i].name, m[i].err, err)
+		}
+		checkFailures(t, m[i].name, errs, m[i].expect)
+	}
+}
+
+func TestNetPosted(t *testing.T) {
+	var m = []struct {
+		name   string
+		le     []rlib.LedgerEntry
+		expect rlib.Money
+	}{
+		{"receipt to undeposited funds, then transfer", []rlib.LedgerEntry{{LID: 5, Amount: 10000}, {LID: 10, Amount: 10000}, {LID: 5, Amount: -10000}}, 10000},
+		{"partly taken back", []rlib.LedgerEntry{{LID: 10, Amount: 10000}, {LID: 10, Amount: -10000}, {LID: 10, Amount: 7500}}, 7500},
+		{"reversed", []rlib.LedgerEntry{{LID: 10, Amount: 10000}, {LID: 10, Amount: -10000}}, 0},
+		{"other accounts only", []rlib.LedgerEntry{{LID: 5, Amount: 10000}, {LID: 11, Amount: -10000}}, 0},
+	}
+	for i := 0; i < len(m); i++ {
+		if got := netPosted(m[i].le, 10); got != m[i].expect {
+			t.Errorf("%s: expect %s, got %s\n", m[i].name, m[i].expect, got)
+		}
+	}
+}
+
+func TestRunCloseChecks(t *testing.T) {
+	tasks := []rlib.Task{
+		{TID: 1, Name: "Instances", Worker: rlib.BotReg[rlib.CloseChkAsmBot].Designator},
+		{TID: 2, Name: "Walk the property", Worker: ""},
+		{TID: 3, Name: "Deposits", Worker: rlib.BotReg[rlib.CloseChkDepBot].Designator},
+		{TID: 4, Name: "Trial balance", Worker: rlib.BotReg[rlib.CloseChkTBBot].Designator},
+		{TID: 5, Name: "Report", Worker: rlib.BotReg[rlib.TLReportBot].Designator},
+	}
+	var m = []struct {
+		name   string
+		fail   map[int64][]string
+		errTID int64
+		ran    []int64
+		expect []string
+	}{
+		{"all pass", nil, 0, []int64{1, 3, 4}, nil},
+		{"failures prefixed with task name", map[int64][]string{1: {"a", "b"}, 4: {"c"}}, 0, []int64{1, 3, 4}, []string{"Instances: a", "Instances: b", "Trial balance: c"}},
+		{"error stops the checks", map[int64][]string{1: {"a"}}, 3, []int64{1, 3}, []string{"Instances: a"}},
+	}
+	for i := 0; i < len(m); i++ {
+		var ran []int64
+		errs, err := runCloseChecks(tasks, func(t *rlib.Task) ([]string, error) {
+			ran = append(ran, t.TID)
+			if t.TID == m[i].errTID {
+				return nil, ccErr
+			}
+			return m[i].fail[t.TID], nil
+		})
+		if (err != nil) != (m[i].errTID != 0) {
+			t.Errorf("%s: unexpected error %v\n", m[i].name, err)
+		}
+		if fmt.Sprint(ran) != fmt.Sprint(m[i].ran) {
+			t.Errorf("%s: expect tasks %v to run, got %v\n", m[i].name, m[i].ran, ran)
+		}
+		if fmt.Sprint(errs) != fmt.Sprint(m[i].expect) {
+			t.Errorf("%s: expect %q, got %q\n", m[i].name, m[i].expect, errs)
+		}
+	}
+}
+
+func TestSetCloseCheckResult(t *testing.T) {
+	now := ccApr1.Add(2 * time.Hour)
+	var m = []struct {
+		fail    []string
+		done    time.Time
+		uid     int64
+		comment string
+	}{
+		{nil, now, rlib.CloseChkReconBot, ""},
+		{[]string{"a", "b"}, rlib.TIME0, 0, "a\nb"},
+	}
+	for i := 0; i < len(m); i++ {
+		tk := rlib.Task{Worker: rlib.BotReg[rlib.CloseChkReconBot].Designator, DtDone: ccMar1, DoneUID: 7, Comment: "old"}
+		setCloseCheckResult(&tk, m[i].fail, &now)
+		if !tk.DtDone.Equal(m[i].done) || tk.DoneUID != m[i].uid || tk.Comment != m[i].comment {
+			t.Errorf("setCloseCheckResult( %q ): expect done %s by %d %q, got %s by %d %q\n", m[i].fail, m[i].done, m[i].uid, m[i].comment, tk.DtDone, tk.DoneUID, tk.Comment)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// gatewayDisputeDays is how far back the bot asks the gateways for disputes.
+// Disputes already recorded are skipped, so the overlap from one day to the
+// next does no harm and a day the gateway could not be reached is caught up.
+const gatewayDisputeDays = 30
+
+// GatewayDisputeBot is a worker that is called by TWS once a day to record
+// the chargebacks reported by the payment gateways.
+//-----------------------------------------------------------------------------
+func GatewayDisputeBot(item *tws.Item) {
+	checkInterval := 24 * time.Hour
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(time.Hour)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.GatewayBot].Designator,
+		rlib.BotReg[rlib.GatewayBot].Designator,
+		rlib.BotReg[rlib.GatewayBot].Designator,
+		rlib.GatewayBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	workerError(item, GatewaySyncDisputesAll(ctx, &now))
+
+	//---------------------------------------------
+	// schedule this again tomorrow...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// GatewaySyncDisputesAll records as chargebacks the disputes each active
+// payment gateway reports for the last gatewayDisputeDays. Each dispute is
+// recorded in its own transaction so that one failure does not hold up the
+// others; it is tried again the next day.
+//
+// INPUTS
+//    ctx - context with the bot's session
+//    now - current time
+//
+// RETURNS
+//    any error encountered reading the gateways
+//-----------------------------------------------------------------------------
+func GatewaySyncDisputesAll(ctx context.Context, now *time.Time) error {
+	funcname := "GatewaySyncDisputesAll"
+	dt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	d1 := dt.AddDate(0, 0, -gatewayDisputeDays)
+	d2 := dt.AddDate(0, 0, 1)
+	m, err := rlib.GetAllPaymentGateways(ctx)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		api := rlib.NewPaymentGatewayAPI(&m[i])
+		dm, err := api.Disputes(&d1, &d2)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, fmt.Errorf("gateway %d: %s", m[i].PGID, err.Error()))
+			continue
+		}
+		for j := 0; j < len(dm); j++ {
+			tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+			if err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				return err
+			}
+			gt, errlist := bizlogic.RecordGatewayDispute(tctx, &m[i], &dm[j], &dt)
+			if len(errlist) > 0 {
+				tx.Rollback()
+				err = bizlogic.BizErrorListToError(errlist)
+				rlib.LogAndPrintError(funcname, fmt.Errorf("gateway %d, dispute %s: %s", m[i].PGID, dm[j].ID, err.Error()))
+				continue
+			}
+			if err = tx.Commit(); err != nil {
+				tx.Rollback()
+				rlib.LogAndPrintError(funcname, err)
+				continue
+			}
+			if gt.GTID > 0 {
+				rlib.Ulog("%s: gateway %d, dispute %s of charge %s recorded as chargeback %d for %s\n", funcname, m[i].PGID, dm[j].ID, dm[j].ChargeID, gt.GTID, gt.Amount)
+			}
+		}
+	}
+	return nil
+}
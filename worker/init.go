@@ -3,6 +3,7 @@ package worker
 import (
 	"os"
 	"rentroll/rlib"
+	"sync"
 	"time"
 	"tws"
 )
@@ -26,11 +27,23 @@ var WorkerRegistry = map[string]Worker{
 	rlib.BotReg[rlib.ARSliceCacheBot].Designator:   {rlib.BotReg[rlib.ARSliceCacheBot], uint64(0), CleanARSliceCache},
 	rlib.BotReg[rlib.TLReportBot].Designator:       {rlib.BotReg[rlib.TLReportBot], uint64(0), TLChecker},
 	rlib.BotReg[rlib.TLInstanceBot].Designator:     {rlib.BotReg[rlib.TLInstanceBot], uint64(0), TLInstanceBot},
+	rlib.BotReg[rlib.RptSubBot].Designator:         {rlib.BotReg[rlib.RptSubBot], uint64(0), ReportSubscriptionBot},
+	rlib.BotReg[rlib.WebhookBot].Designator:        {rlib.BotReg[rlib.WebhookBot], uint64(0), WebhookDeliveryBot},
+	rlib.BotReg[rlib.PositivePayBot].Designator:    {rlib.BotReg[rlib.PositivePayBot], uint64(0), PositivePayExportBot},
+	rlib.BotReg[rlib.PctRentBot].Designator:        {rlib.BotReg[rlib.PctRentBot], uint64(0), PctRentAssessBot},
+	rlib.BotReg[rlib.NightAuditBot].Designator:     {rlib.BotReg[rlib.NightAuditBot], uint64(0), NightAuditWorker},
+	rlib.BotReg[rlib.AsmStepBot].Designator:        {rlib.BotReg[rlib.AsmStepBot], uint64(0), AsmStepApplyBot},
+	rlib.BotReg[rlib.GatewayBot].Designator:        {rlib.BotReg[rlib.GatewayBot], uint64(0), GatewayDisputeBot},
 
 	//------------------------------------------------------------------
 	// The following workers ARE available to users for tasklists
 	//------------------------------------------------------------------
-	rlib.BotReg[rlib.TaskManual].Designator: {rlib.BotReg[rlib.TaskManual], uint64(1), ProcessManualTask},
+	rlib.BotReg[rlib.TaskManual].Designator:       {rlib.BotReg[rlib.TaskManual], uint64(1), ProcessManualTask},
+	rlib.BotReg[rlib.CloseChkAsmBot].Designator:   {rlib.BotReg[rlib.CloseChkAsmBot], uint64(1), CloseCheckBot(rlib.CloseChkAsmBot)},
+	rlib.BotReg[rlib.CloseChkDepBot].Designator:   {rlib.BotReg[rlib.CloseChkDepBot], uint64(1), CloseCheckBot(rlib.CloseChkDepBot)},
+	rlib.BotReg[rlib.CloseChkUnalBot].Designator:  {rlib.BotReg[rlib.CloseChkUnalBot], uint64(1), CloseCheckBot(rlib.CloseChkUnalBot)},
+	rlib.BotReg[rlib.CloseChkTBBot].Designator:    {rlib.BotReg[rlib.CloseChkTBBot], uint64(1), CloseCheckBot(rlib.CloseChkTBBot)},
+	rlib.BotReg[rlib.CloseChkReconBot].Designator: {rlib.BotReg[rlib.CloseChkReconBot], uint64(1), CloseCheckBot(rlib.CloseChkReconBot)},
 }
 
 // Init registers the TWS functions needed by RentRoll
@@ -50,8 +63,8 @@ func Init() {
 func InitCore(w map[string]Worker) {
 	funcname := "worker.InitCore"
 	for k, v := range w {
-		tws.RegisterWorker(k, v.Handler) // first, register our handler
-		m, err := tws.FindItem(k)        // next, see if we are already registered
+		tws.RegisterWorker(k, metricsWorker(k, v.Handler)) // first, register our handler
+		m, err := tws.FindItem(k)                          // next, see if we are already registered
 		if err != nil {
 			rlib.LogAndPrintError(funcname, err)
 			os.Exit(1)
@@ -87,3 +100,44 @@ func GetWorkerList() []string {
 	}
 	return m
 }
+
+// failedRuns holds the tws items whose current run has reported an error
+var failedRuns sync.Map
+
+// workerError is called by a worker handler with the error returned by the
+// work it did. If err is not nil the current run of item is recorded as a
+// failure in rlib.WorkerMetrics. Logging the error is left to the routine
+// that returned it.
+//-----------------------------------------------------------------------------
+func workerError(item *tws.Item, err error) {
+	if err != nil {
+		failedRuns.Store(item, true)
+	}
+}
+
+// metricsWorker wraps a worker handler so that each run's duration is
+// recorded in rlib.WorkerMetrics. A run that panics, or whose handler
+// reported an error through workerError, is recorded as a failure. A panic
+// is passed along to tws.
+//
+// INPUTS
+//  name - worker designator
+//  f    - the worker's handler
+//
+// RETURNS
+//  the wrapped handler
+//-----------------------------------------------------------------------------
+func metricsWorker(name string, f func(*tws.Item)) func(*tws.Item) {
+	return func(item *tws.Item) {
+		start := time.Now()
+		failed := true
+		defer func() {
+			if _, ok := failedRuns.LoadAndDelete(item); ok {
+				failed = true
+			}
+			rlib.WorkerMetrics.Record(name, time.Since(start), failed)
+		}()
+		f(item)
+		failed = false
+	}
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// NightAuditWorker is a worker that is called by TWS once a day to run the
+// night audit of the hotels.
+//-----------------------------------------------------------------------------
+func NightAuditWorker(item *tws.Item) {
+	checkInterval := 24 * time.Hour
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(time.Hour)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.BotReg[rlib.NightAuditBot].Designator,
+		rlib.NightAuditBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	workerError(item, NightAuditAll(ctx, &now))
+
+	//---------------------------------------------
+	// schedule this again tomorrow...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// NightAuditAll runs the night audit of every business whose "general"
+// properties have NightAudit set. Each business is audited from its
+// business date through the night before now, one transaction per night.
+// A business that has never been audited starts with the night before now.
+// If a night fails, the business is left at that night and it is tried
+// again the next day.
+//
+// INPUTS
+//    ctx - context with the bot's session
+//    now - current time
+//
+// RETURNS
+//    any error encountered reading the businesses
+//-----------------------------------------------------------------------------
+func NightAuditAll(ctx context.Context, now *time.Time) error {
+	funcname := "NightAuditAll"
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	m, err := rlib.GetAllBusinesses(ctx)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		bp, err := rlib.GetDataFromBusinessPropertyName(ctx, "general", m[i].BID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if !bp.NightAudit {
+			continue
+		}
+		var xbiz rlib.XBusiness
+		if err = rlib.GetXBusiness(ctx, m[i].BID, &xbiz); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		dt, err := rlib.NightAuditDate(ctx, m[i].BID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if dt.Equal(rlib.TIME0) {
+			dt = today.AddDate(0, 0, -1)
+		}
+		for ; dt.Before(today); dt = dt.AddDate(0, 0, 1) {
+			if err = nightAuditOne(ctx, &xbiz, &dt); err != nil {
+				rlib.LogAndPrintError(funcname, fmt.Errorf("%s, night of %s: %s", m[i].Designation, dt.Format(rlib.RRDATEFMT3), err.Error()))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// nightAuditOne runs the night audit of the night of dt in its own
+// transaction and logs the results.
+//-----------------------------------------------------------------------------
+func nightAuditOne(ctx context.Context, xbiz *rlib.XBusiness, dt *time.Time) error {
+	funcname := "nightAuditOne"
+	tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	rpt, errlist := bizlogic.NightAudit(tctx, xbiz, dt)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		return bizlogic.BizErrorListToError(errlist)
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return err
+	}
+	rlib.Ulog("%s: %s, night of %s: %d of %d rooms occupied, ADR %s, RevPAR %s, %d no-shows\n", funcname, xbiz.P.Designation,
+		dt.Format(rlib.RRDATEFMT3), rpt.Occupied, rpt.Rooms, rpt.ADR, rpt.RevPAR, len(rpt.NoShows))
+	for i := 0; i < len(rpt.InHouse); i++ {
+		for _, p := range rpt.InHouse[i].Problems {
+			rlib.Ulog("%s: %s, reservation %d: %s\n", funcname, xbiz.P.Designation, rpt.InHouse[i].Res.RESID, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// PctRentAssessBot is a worker that is called by TWS once a day to compute
+// the percentage rent due on the sales reports whose period has ended and to
+// assess any overage.
+//-----------------------------------------------------------------------------
+func PctRentAssessBot(item *tws.Item) {
+	checkInterval := 24 * time.Hour
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(time.Hour)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.PctRentBot].Designator,
+		rlib.BotReg[rlib.PctRentBot].Designator,
+		rlib.BotReg[rlib.PctRentBot].Designator,
+		rlib.PctRentBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	workerError(item, PctRentAssessAll(ctx, &now))
+
+	//---------------------------------------------
+	// schedule this again tomorrow...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// PctRentAssessAll computes the percentage rent of every sales report whose
+// period ended by now and that has not been computed. The overage is
+// assessed on the day of now. Each report is done in its own transaction so
+// that one failure, a report of a Rental Agreement without percentage rent
+// terms for example, does not hold up the others; it is tried again the next
+// day.
+//
+// INPUTS
+//    ctx - context with the bot's session
+//    now - current time
+//
+// RETURNS
+//    any error encountered reading the sales reports
+//-----------------------------------------------------------------------------
+func PctRentAssessAll(ctx context.Context, now *time.Time) error {
+	funcname := "PctRentAssessAll"
+	dt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	m, err := rlib.GetSalesReportsToCompute(ctx, &dt)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			return err
+		}
+		if errlist := bizlogic.ComputePercentageRent(tctx, &m[i], &dt); len(errlist) > 0 {
+			tx.Rollback()
+			err = bizlogic.BizErrorListToError(errlist)
+			rlib.LogAndPrintError(funcname, fmt.Errorf("sales report %d: %s", m[i].SRID, err.Error()))
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if m[i].ASMID > 0 {
+			rlib.Ulog("%s: sales report %d, overage %.2f, assessment %d\n", funcname, m[i].SRID, m[i].Overage, m[i].ASMID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+	"tws"
+)
+
+// PositivePayExportBot is a worker that is called by TWS once a day to
+// produce the positive pay file of every Depository whose bank uses positive
+// pay. Each file holds the checks issued or voided since the last file. The
+// files are kept in PositivePayExport for download.
+//-----------------------------------------------------------------------------
+func PositivePayExportBot(item *tws.Item) {
+	checkInterval := 24 * time.Hour
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(time.Hour)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.PositivePayBot].Designator,
+		rlib.BotReg[rlib.PositivePayBot].Designator,
+		rlib.BotReg[rlib.PositivePayBot].Designator,
+		rlib.PositivePayBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	workerError(item, PositivePayExportAll(ctx, &now))
+
+	//---------------------------------------------
+	// schedule this again tomorrow...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// PositivePayExportAll produces a positive pay file for each Depository
+// whose bank uses positive pay and that has checks not yet sent. Every
+// unsent check dated through the day of now is included. Each Depository is
+// exported in its own transaction so that one failure does not hold up the
+// others.
+//
+// INPUTS
+//    ctx - context with the bot's session
+//    now - current time
+//
+// RETURNS
+//    any error encountered reading the check accounts
+//-----------------------------------------------------------------------------
+func PositivePayExportAll(ctx context.Context, now *time.Time) error {
+	funcname := "PositivePayExportAll"
+	m, err := rlib.GetCheckAccountsWithPositivePay(ctx)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	d2 := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	for i := 0; i < len(m); i++ {
+		tx, tctx, err := rlib.NewTransactionWithContext(ctx)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			return err
+		}
+		x, err := PositivePayExportCore(tctx, &m[i], &rlib.TIME0, &d2, now)
+		if err != nil {
+			tx.Rollback()
+			rlib.LogAndPrintError(funcname, fmt.Errorf("depository %d: %s", m[i].DEPID, err.Error()))
+			continue
+		}
+		if err = tx.Commit(); err != nil {
+			tx.Rollback()
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		if x.PPXID > 0 {
+			rlib.Ulog("%s: depository %d, positive pay file %s, %d checks\n", funcname, m[i].DEPID, x.FileName, x.Items)
+		}
+	}
+	return nil
+}
+
+// PositivePayExportCore produces the positive pay file of the Depository of
+// check account ca. The file lists the checks dated in the range d1 - d2 that
+// have not been sent as issued, and every void check dated before d2 whose
+// void has not been sent, no matter how old. The checks are marked sent so
+// the next file only has new items. No file is written if there are no
+// items. It must be called with a transaction in ctx.
+//
+// INPUTS
+//    ctx - context with a transaction
+//    ca  - the check account, ca.PPFID is the format of the file
+//    d1  - start of the date range
+//    d2  - end of the date range, not included
+//    now - current time
+//
+// RETURNS
+//    the file, PPXID is 0 if there was nothing to send
+//    any error encountered
+//-----------------------------------------------------------------------------
+func PositivePayExportCore(ctx context.Context, ca *rlib.CheckAccount, d1, d2, now *time.Time) (rlib.PositivePayExport, error) {
+	var x rlib.PositivePayExport
+	f, err := rlib.GetPositivePayFormat(ctx, ca.PPFID)
+	if err != nil {
+		return x, err
+	}
+	if f.PPFID == 0 || f.BID != ca.BID {
+		return x, fmt.Errorf("positive pay format %d not found", ca.PPFID)
+	}
+	dep, err := rlib.GetDepository(ctx, ca.DEPID)
+	if err != nil {
+		return x, err
+	}
+	m, err := rlib.GetPositivePayChecks(ctx, ca.DEPID, d1, d2)
+	if err != nil || len(m) == 0 {
+		return x, err
+	}
+
+	d := rlib.NewPositivePayData(ca, dep.AccountNo, m, now)
+	content, err := rlib.RenderPositivePay(&f, &d)
+	if err != nil {
+		return x, err
+	}
+	ext := strings.TrimPrefix(f.FileExt, ".")
+	if len(ext) == 0 {
+		ext = "txt"
+	}
+	x = rlib.PositivePayExport{
+		BID:         ca.BID,
+		DEPID:       ca.DEPID,
+		PPFID:       f.PPFID,
+		DtStart:     *d1,
+		DtStop:      *d2,
+		Items:       int64(d.Count),
+		IssuedTotal: d.IssuedTotal,
+		VoidTotal:   d.VoidTotal,
+		FileName:    fmt.Sprintf("posipay-%d-%s.%s", ca.DEPID, now.Format("20060102-150405"), ext),
+		Content:     content,
+	}
+	if err = rlib.InsertPositivePayExport(ctx, &x); err != nil {
+		return x, err
+	}
+	for i := 0; i < len(m); i++ {
+		m[i].FLAGS |= rlib.CHECKIssueSent
+		if m[i].FLAGS&rlib.CHECKVoid != 0 {
+			m[i].FLAGS |= rlib.CHECKVoidSent
+		}
+		if err = rlib.UpdateBankCheck(ctx, &m[i]); err != nil {
+			return x, err
+		}
+	}
+	return x, nil
+}
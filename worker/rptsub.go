@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"strings"
+	"time"
+	"tws"
+
+	"gopkg.in/gomail.v2"
+)
+
+// BotEmailFrom is the address used as the sender for email sent by bots
+const BotEmailFrom = "sman@accordinterests.com"
+
+// ReportSubscriptionBot is a worker that is called by TWS periodically to
+// render and email any ReportSubscriptions whose delivery time has arrived.
+//-----------------------------------------------------------------------------
+func ReportSubscriptionBot(item *tws.Item) {
+	checkInterval := 5 * time.Minute // this may come from a config file in the future
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(checkInterval)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.RptSubBot].Designator,
+		rlib.BotReg[rlib.RptSubBot].Designator,
+		rlib.BotReg[rlib.RptSubBot].Designator,
+		rlib.RptSubBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	d := gomail.NewDialer(rlib.AppConfig.SMTPHost, rlib.AppConfig.SMTPPort, rlib.AppConfig.SMTPLogin, rlib.AppConfig.SMTPPass)
+	workerError(item, ReportSubscriptionCore(ctx, &now, d))
+
+	//---------------------------------------------
+	// schedule this check again in a few mins...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// ReportSubscriptionCore provides a more testable calling routine for
+// delivering report subscriptions. Each subscription that is due is rendered,
+// emailed, recorded in the delivery history, and rescheduled.
+//
+// INPUTS:
+//    ctx  - context which may include a database transaction in progress
+//    now  - current time
+//    d    - email dialer
+//
+// RETURNS:
+//    any error encountered reading the subscriptions. Delivery errors are
+//    recorded in the delivery history.
+//-----------------------------------------------------------------------------
+func ReportSubscriptionCore(ctx context.Context, now *time.Time, d *gomail.Dialer) error {
+	funcname := "ReportSubscriptionCore"
+	m, err := rlib.GetDueReportSubscriptions(ctx, now)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	for i := 0; i < len(m); i++ {
+		rd := DeliverReportSubscription(ctx, &m[i], now, d)
+		if err = rlib.InsertReportDelivery(ctx, &rd); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+		}
+		m[i].DtLast = *now
+		m[i].DtNext = rlib.NextReportSubscriptionTime(&m[i].DtNext, m[i].Cycle, now)
+		if err = rlib.UpdateReportSubscription(ctx, &m[i]); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+		}
+	}
+	return nil
+}
+
+// DeliverReportSubscription renders the report described by a and emails it
+// to a's recipients.
+//
+// INPUTS:
+//    ctx  - context which may include a database transaction in progress
+//    a    - the subscription to deliver
+//    now  - current time, used to evaluate the relative date range
+//    d    - email dialer
+//
+// RETURNS:
+//    a ReportDelivery describing the result. It has not been saved.
+//-----------------------------------------------------------------------------
+func DeliverReportSubscription(ctx context.Context, a *rlib.ReportSubscription, now *time.Time, d *gomail.Dialer) rlib.ReportDelivery {
+	d1, d2 := rlib.ReportSubscriptionRange(a.DateRange, now)
+	rd := rlib.ReportDelivery{
+		RSUBID:     a.RSUBID,
+		BID:        a.BID,
+		Dt:         *now,
+		DtStart:    d1,
+		DtStop:     d2,
+		Recipients: a.Recipients,
+		Status:     rlib.RptDelivered,
+	}
+	if err := sendReportSubscription(ctx, a, &d1, &d2, d); err != nil {
+		rd.Status = rlib.RptDeliveryFailed
+		rd.Message = err.Error()
+	}
+	return rd
+}
+
+// sendReportSubscription does the work for DeliverReportSubscription
+//-----------------------------------------------------------------------------
+func sendReportSubscription(ctx context.Context, a *rlib.ReportSubscription, d1, d2 *time.Time, d *gomail.Dialer) error {
+	to := rlib.ReportSubscriptionRecipients(a.Recipients)
+	if len(to) == 0 {
+		return fmt.Errorf("no valid recipients")
+	}
+
+	var xbiz rlib.XBusiness
+	if err := rlib.InitBizInternals(a.BID, &xbiz); err != nil {
+		return err
+	}
+	ri := rrpt.ReporterInfo{
+		OutputFormat:          int(a.Format),
+		Bid:                   a.BID,
+		D1:                    *d1,
+		D2:                    *d2,
+		Xbiz:                  &xbiz,
+		BlankLineAfterRptName: true,
+	}
+	rctx := rrpt.ReportContext{
+		D1:                 *d1,
+		D2:                 *d2,
+		ReportOutputFormat: int(a.Format),
+		PDFPageSizeUnit:    "in",
+		PDFPageWidth:       float64(11),
+		PDFPageHeight:      float64(8.5),
+	}
+
+	var b bytes.Buffer
+	title, err := rrpt.WriteReport(ctx, &b, a.ReportName, &ri, &rctx)
+	if err != nil {
+		return err
+	}
+	if len(title) == 0 {
+		title = a.ReportName
+	}
+
+	dtLast := d2.AddDate(0, 0, -1) // stop date is not inclusive
+	rng := d1.Format(rlib.RRDATEREPORTFMT) + " - " + dtLast.Format(rlib.RRDATEREPORTFMT)
+	fname := xbiz.P.Designation + "-" + strings.Replace(title, " ", "", -1) + "-" + d1.Format(rlib.RRDATEINPFMT) + rrpt.ReportFileExtension(int(a.Format))
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", BotEmailFrom)
+	msg.SetHeader("To", to...)
+	msg.SetHeader("Subject", fmt.Sprintf("%s %s: %s", xbiz.P.Designation, title, rng))
+	msg.SetBody("text/html", fmt.Sprintf("<html><body><p>Attached is the %s report for %s, %s.</p><p>-%s</p></body></html>",
+		title, xbiz.P.Designation, rng, rlib.BotReg[rlib.RptSubBot].Designator))
+	msg.Attach(fname, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(b.Bytes())
+		return err
+	}))
+	return d.DialAndSend(msg)
+}
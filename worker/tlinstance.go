@@ -30,7 +30,7 @@ func TLInstanceBot(item *tws.Item) {
 		rlib.TLInstanceBot, "", -1, &expire)
 	ctx := context.Background()
 	ctx = rlib.SetSessionContextKey(ctx, s)
-	TLInstanceBotCore(ctx, &now)
+	workerError(item, TLInstanceBotCore(ctx, &now))
 
 	//---------------------------------------------
 	// schedule this check again in a few mins...
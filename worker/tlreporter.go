@@ -36,7 +36,7 @@ func TLChecker(item *tws.Item) {
 		rlib.TLReportBot, "", -1, &expire)
 	ctx := context.Background()
 	ctx = rlib.SetSessionContextKey(ctx, s)
-	TLCheckerCore(ctx)
+	workerError(item, TLCheckerCore(ctx))
 
 	//---------------------------------------------
 	// schedule this check again in a few mins...
@@ -258,7 +258,7 @@ Next check for this task list: {{.DtNextNotify}}
 	// Send message...
 	//----------------------------------
 	msg := gomail.NewMessage()
-	msg.SetHeader("From", "sman@accordinterests.com")
+	msg.SetHeader("From", BotEmailFrom)
 	msg.SetHeader("To", e)
 	msg.SetHeader("Subject", subj)
 	msg.SetBody("text/html", b.String())
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// WebhookDeliveryBot is a worker that is called by TWS periodically to send
+// queued webhook events to their subscribers.
+//-----------------------------------------------------------------------------
+func WebhookDeliveryBot(item *tws.Item) {
+	checkInterval := 1 * time.Minute
+	tws.ItemWorking(item)
+	now := time.Now()
+	expire := now.Add(checkInterval)
+	s := rlib.SessionNew("BotToken-"+rlib.BotReg[rlib.WebhookBot].Designator,
+		rlib.BotReg[rlib.WebhookBot].Designator,
+		rlib.BotReg[rlib.WebhookBot].Designator,
+		rlib.WebhookBot, "", -1, &expire)
+	ctx := context.Background()
+	ctx = rlib.SetSessionContextKey(ctx, s)
+	client := &http.Client{Timeout: 15 * time.Second}
+	workerError(item, WebhookDeliveryCore(ctx, &now, client))
+
+	//---------------------------------------------
+	// schedule this check again in a minute...
+	//---------------------------------------------
+	resched := now.Add(checkInterval)
+	tws.RescheduleItem(item, resched)
+}
+
+// WebhookDeliveryCore provides a more testable calling routine for sending
+// webhook events. Every pending delivery that is due gets one attempt. The
+// result is saved in the delivery record, which serves as the delivery log.
+//
+// INPUTS
+//    ctx    - context which may include a database transaction in progress
+//    now    - current time
+//    client - http client used to post the events
+//
+// RETURNS
+//    any error encountered reading the deliveries
+//-----------------------------------------------------------------------------
+func WebhookDeliveryCore(ctx context.Context, now *time.Time, client *http.Client) error {
+	funcname := "WebhookDeliveryCore"
+	m, err := rlib.GetDueWebhookDeliveries(ctx, now)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return err
+	}
+	subs := map[int64]rlib.WebhookSubscription{}
+	for i := 0; i < len(m); i++ {
+		s, ok := subs[m[i].WHSID]
+		if !ok {
+			if s, err = rlib.GetWebhookSubscription(ctx, m[i].WHSID); err != nil {
+				rlib.LogAndPrintError(funcname, err)
+				continue
+			}
+			subs[m[i].WHSID] = s
+		}
+		if s.WHSID == 0 || s.FLAGS&rlib.WebhookInactive != 0 {
+			m[i].Status = rlib.WebhookFailed
+			m[i].Message = fmt.Sprintf("subscription %d was deleted or is inactive", m[i].WHSID)
+		} else {
+			rlib.DeliverWebhook(client, &s, &m[i], now)
+		}
+		if err = rlib.UpdateWebhookDelivery(ctx, &m[i]); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+		}
+	}
+	return nil
+}
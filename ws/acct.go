@@ -11,7 +11,6 @@ import (
 	"rentroll/importers/core"
 	"rentroll/rlib"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -875,7 +874,7 @@ func SvcExportGLAccounts(w http.ResponseWriter, r *http.Request, d *ServiceData)
 			SvcErrorReturn(w, err, funcname)
 			return
 		}
-		s64Bal := strconv.FormatFloat(bal, 'f', 2, 64)
+		s64Bal := bal.String()
 		rec = append(rec, s64Bal)
 
 		// append Status, CreateDate, Description
@@ -1072,11 +1071,11 @@ func SvcImportGLAccounts(w http.ResponseWriter, r *http.Request, d *ServiceData)
 			State:   3,
 			Dt:      time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
 			LID:     ngl.LID,
-			Balance: float64(0),
+			Balance: rlib.Money(0),
 		}
-		// now if balance provided, then parse it to float64
+		// now if balance provided, then parse it to Money
 		balStr := recs[ri][acctCSVIndexMap["balance"]]
-		bal, _ := strconv.ParseFloat(balStr, 64)
+		bal, _ := rlib.ParseMoney(balStr)
 		lm.Balance = bal                                   // don't worry about balance if can't parsed from string, default will be 0
 		_, err = rlib.InsertLedgerMarker(r.Context(), &lm) // insert ledger marker
 		if err != nil {
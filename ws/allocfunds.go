@@ -32,9 +32,9 @@ type UnpaidAsm struct {
 	ASMID      int64            `json:"ASMID"`
 	ARID       int64            `json:"ARID"`
 	Name       string           `json:"Assessment"`
-	Amount     float64          `json:"Amount"`
-	AmountPaid float64          `json:"AmountPaid"`
-	AmountOwed float64          `json:"AmountOwed"`
+	Amount     rlib.Money       `json:"Amount"`
+	AmountPaid rlib.Money       `json:"AmountPaid"`
+	AmountOwed rlib.Money       `json:"AmountOwed"`
 	Dt         rlib.JSONDate    `json:"Dt"`
 	Allocate   rlib.NullFloat64 `json:"Allocate"`
 }
@@ -49,7 +49,7 @@ type PayorUnpaidAsmsResponse struct {
 
 // PayorFund is used to get total unallocated fund for a payor
 type PayorFund struct {
-	Fund float64 `json:"fund"`
+	Fund rlib.Money `json:"fund"`
 }
 
 // PayorFundResponse response of payor fund request
@@ -235,10 +235,10 @@ func allocatePayorFund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	for _, asmRec := range foo.Records {
 
 		// This is how much the user wanted to allocate for this assessment...
-		amt := asmRec.Allocate.Float64
+		amt := rlib.MoneyFromFloat(asmRec.Allocate.Float64)
 
 		// The user may have decided not to pay anything here. If so, skip to the next assessment.
-		if amt == float64(0) {
+		if amt == 0 {
 			continue
 		}
 
@@ -265,7 +265,7 @@ func allocatePayorFund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 				SvcErrorReturn(w, err, funcname)
 				return
 			}
-			if amt < bizlogic.ROUNDINGERR { // if we've applied the requested amount...
+			if amt <= 0 { // if we've applied the requested amount...
 				rlib.Console("ASMID %d is paid off, moving on to next record\n", asm.ASMID)
 				break // ... then break out of the loop; we're done
 			}
@@ -0,0 +1,120 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"time"
+)
+
+// AcctRuleSimRequest is the request data of the arsim command. The rule
+// evaluated is AcctRule if it is set, otherwise the rule of ARID.
+type AcctRuleSimRequest struct {
+	ARID     int64         // account rule to evaluate
+	AcctRule string        // or an ad hoc rule, ex: d 12000 _, c 40001 _
+	RID      int64         // the Rentable, 0 if none
+	RAID     int64         // the Rental Agreement, 0 if none
+	DtStart  rlib.JSONDate // start of the time range, today if not set
+	DtStop   rlib.JSONDate // end of the time range, DtStart if not set
+	Amount   rlib.Money    // the amount of the transaction
+	PF       float64       // proration factor, 1 if not set
+}
+
+// AcctRuleSimGrid is the UI representation of a line of a simulated
+// account rule
+type AcctRuleSimGrid struct {
+	Recid    int64 `json:"recid"`
+	Action   string
+	GLNumber string
+	Name     string
+	Expr     string
+	Debit    rlib.Money
+	Credit   rlib.Money
+}
+
+// AcctRuleSimResponse is the response to an arsim request
+type AcctRuleSimResponse struct {
+	Status   string            `json:"status"`
+	Rule     string            `json:"rule"`
+	Debits   rlib.Money        `json:"debits"`
+	Credits  rlib.Money        `json:"credits"`
+	Problems []string          `json:"problems"`
+	Total    int64             `json:"total"`
+	Records  []AcctRuleSimGrid `json:"records"`
+}
+
+// SvcAcctRuleSim evaluates an account rule for a Rentable or Rental
+// Agreement and returns the debits and credits it would make. Nothing is
+// posted.
+// wsdoc {
+//  @Title  Account Rule Simulator
+//	@URL /v1/arsim/:BUI
+//  @Method  POST
+//	@Synopsis Evaluate an account rule without posting it
+//  @Descr  Evaluates AcctRule, or the rule of account rule ARID if AcctRule
+//  @Descr  is empty, for Rentable RID and Rental Agreement RAID over
+//  @Descr  DtStart - DtStop. Amount is the value of _ in the formulas.
+//  @Descr  Problems lists what would be wrong with the journal entry:
+//  @Descr  unknown accounts, ignored parts, unbalanced debits and credits.
+//	@Input AcctRuleSimRequest
+//  @Response AcctRuleSimResponse
+// wsdoc }
+func SvcAcctRuleSim(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcAcctRuleSim"
+	var (
+		req  AcctRuleSimRequest
+		g    AcctRuleSimResponse
+		xbiz rlib.XBusiness
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if req.ARID == 0 && len(req.AcctRule) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("an ARID or an AcctRule is required"), funcname)
+		return
+	}
+	if err := rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	d1 := time.Time(req.DtStart)
+	if d1.IsZero() {
+		now := time.Now()
+		d1 = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	d2 := time.Time(req.DtStop)
+	if d2.IsZero() {
+		d2 = d1
+	}
+	if req.PF == 0 {
+		req.PF = 1.0
+	}
+
+	sim, err := rlib.SimulateAcctRule(r.Context(), &xbiz, req.ARID, req.AcctRule, req.RID, req.RAID, &d1, &d2, req.Amount, req.PF)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i, l := range sim.Lines {
+		q := AcctRuleSimGrid{Recid: int64(i + 1), Action: l.Action, GLNumber: l.GLNumber, Name: l.Name, Expr: l.Expr}
+		if l.Action == "d" {
+			q.Debit = l.Amount
+		} else {
+			q.Credit = l.Amount
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Rule = sim.Rule
+	g.Debits = sim.Debits
+	g.Credits = sim.Credits
+	g.Problems = sim.Problems
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,210 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// AssessmentStepGrid is the UI representation of an AssessmentStep
+type AssessmentStepGrid struct {
+	Recid     int64 `json:"recid"`
+	ASTID     int64
+	BID       int64
+	RAID      int64
+	ASMID     int64
+	Dt        rlib.JSONDate
+	Amount    rlib.Money
+	Pct       float64
+	NewASMID  int64
+	DtApplied rlib.JSONDate
+	Comment   string
+	FLAGS     uint64
+	Annual    bool
+	Applied   bool
+}
+
+// AssessmentStepSearchResponse lists assessment steps
+type AssessmentStepSearchResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []AssessmentStepGrid `json:"records"`
+}
+
+// AssessmentStepRequest is the request data of the asmstep commands. get
+// lists the steps of recurring assessment ASMID, or if it is 0 those of
+// Rental Agreement RAID. Record is the step for save.
+type AssessmentStepRequest struct {
+	RAID   int64
+	ASMID  int64
+	Record AssessmentStepGrid
+}
+
+// SvcHandlerAssessmentStep handles the scheduled changes of the recurring
+// assessments of a business. For this call, we expect the URI to contain
+// the BID and the ASTID as follows:
+//       0    1         2     3
+// 		/v1/asmstep/BID/ASTID
+//
+// The request data is an AssessmentStepRequest. The upcoming changes are
+// printed with /v1/report, report RPTrentchanges.
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerAssessmentStep(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerAssessmentStep"
+	var req AssessmentStepRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  ASTID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getAssessmentSteps(w, r, d, &req)
+	case "save":
+		saveAssessmentStep(w, r, d, &req)
+	case "delete":
+		deleteAssessmentStep(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getAssessmentSteps returns the steps of an assessment or Rental Agreement
+// wsdoc {
+//  @Title  Assessment Steps
+//	@URL /v1/asmstep/:BUI
+//  @Method  POST
+//	@Synopsis List the scheduled changes of recurring assessments
+//  @Descr  Returns the steps of recurring assessment ASMID, or if it is 0
+//  @Descr  the steps of the assessments of Rental Agreement RAID, applied
+//  @Descr  and pending, sorted by date.
+//	@Input AssessmentStepRequest
+//  @Response AssessmentStepSearchResponse
+// wsdoc }
+func getAssessmentSteps(w http.ResponseWriter, r *http.Request, d *ServiceData, req *AssessmentStepRequest) {
+	const funcname = "getAssessmentSteps"
+	var (
+		g   AssessmentStepSearchResponse
+		m   []rlib.AssessmentStep
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.ASMID > 0 {
+		m, err = rlib.GetAssessmentStepsByASMID(r.Context(), req.ASMID)
+	} else {
+		m, err = rlib.GetAssessmentStepsByRAID(r.Context(), req.RAID)
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []AssessmentStepGrid{}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q AssessmentStepGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].ASTID
+		q.Annual = m[i].FLAGS&rlib.ASTEPAnnual != 0
+		q.Applied = m[i].FLAGS&rlib.ASTEPApplied != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveAssessmentStep creates or updates an assessment step
+// wsdoc {
+//  @Title  Save Assessment Step
+//	@URL /v1/asmstep/:BUI/:ASTID
+//  @Method  POST
+//	@Synopsis Schedule a change of a recurring assessment
+//  @Description  Saves step Record. If ASTID is 0 a new step is created.
+//  @Description  From Dt recurring assessment ASMID is charged Amount, or
+//  @Description  the amount in force escalated by Pct percent. Set Annual
+//  @Description  to repeat an escalation each anniversary. The change is
+//  @Description  made on Dt by the AsmStepBot. An applied step cannot be
+//  @Description  changed.
+//	@Input AssessmentStepRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveAssessmentStep(w http.ResponseWriter, r *http.Request, d *ServiceData, req *AssessmentStepRequest) {
+	const funcname = "saveAssessmentStep"
+	var (
+		a   rlib.AssessmentStep
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.Record.ASTID > 0 {
+		if a, err = rlib.GetAssessmentStep(r.Context(), req.Record.ASTID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.ASTID == 0 || a.BID != d.BID {
+			SvcErrorReturn(w, fmt.Errorf("assessment step %d not found", req.Record.ASTID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(&req.Record, &a)
+	a.BID = d.BID
+	a.FLAGS = 0
+	if req.Record.Annual {
+		a.FLAGS |= rlib.ASTEPAnnual
+	}
+
+	if errlist := bizlogic.SaveAssessmentStep(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.ASTID)
+}
+
+// deleteAssessmentStep deletes a pending assessment step
+// wsdoc {
+//  @Title  Delete Assessment Step
+//	@URL /v1/asmstep/:BUI/:ASTID
+//  @Method  POST
+//	@Synopsis Delete a scheduled change of a recurring assessment
+//  @Desc  This service deletes step :ASTID. A step that has been applied
+//  @Desc  cannot be deleted.
+//	@Input AssessmentStepRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteAssessmentStep(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteAssessmentStep"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetAssessmentStep(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.ASTID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("assessment step %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteAssessmentStep(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,680 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// BillLineGrid is the UI representation of a BillLine
+type BillLineGrid struct {
+	BLID   int64
+	LID    int64
+	RID    int64
+	Amount rlib.Money
+	Descr  string
+}
+
+// BillGrid is the UI representation of a Bill
+type BillGrid struct {
+	Recid       int64 `json:"recid"`
+	BILLID      int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	VID         int64
+	VendorName  string
+	InvoiceNo   string
+	Dt          rlib.JSONDate
+	DtDue       rlib.JSONDate
+	Amount      rlib.Money
+	Balance     rlib.Money // unpaid portion of Amount
+	APLID       int64
+	JID         int64
+	FLAGS       uint64
+	Comment     string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// BillSearchResponse is the response to a search request for Bill records
+type BillSearchResponse struct {
+	Status  string     `json:"status"`
+	Total   int64      `json:"total"`
+	Records []BillGrid `json:"records"`
+}
+
+// BillGetResponse is the response to a get request for a single Bill. It
+// includes the bill's lines and the payments made on it.
+type BillGetResponse struct {
+	Status   string                         `json:"status"`
+	Record   BillGrid                       `json:"record"`
+	Lines    []BillLineGrid                 `json:"lines"`
+	Payments []rlib.VendorPaymentAllocation `json:"payments"`
+}
+
+// BillSaveForm is the form data for a Bill. Only InvoiceNo, DtDue and
+// Comment can be changed once a bill is saved.
+type BillSaveForm struct {
+	Recid     int64 `json:"recid"`
+	BILLID    int64
+	BUD       rlib.XJSONBud
+	VID       int64
+	InvoiceNo string
+	Dt        rlib.JSONDate
+	DtDue     rlib.JSONDate
+	Amount    rlib.Money
+	APLID     int64 // 0 to use the business's Accounts Payable account
+	Comment   string
+}
+
+// SaveBillInput is the input data format for a Save command
+type SaveBillInput struct {
+	Recid    int64          `json:"recid"`
+	Status   string         `json:"status"`
+	FormName string         `json:"name"`
+	Record   BillSaveForm   `json:"record"`
+	Lines    []BillLineGrid `json:"lines"`
+}
+
+// VendorPaymentGrid is the UI representation of a VendorPayment
+type VendorPaymentGrid struct {
+	Recid       int64 `json:"recid"`
+	VPID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	VID         int64
+	VendorName  string
+	DEPID       int64
+	Dt          rlib.JSONDate
+	Amount      rlib.Money
+	Method      int64
+	DocNo       string
+	JID         int64
+	FLAGS       uint64
+	Comment     string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// VendorPaymentSearchResponse is the response to a search request for
+// VendorPayment records
+type VendorPaymentSearchResponse struct {
+	Status  string              `json:"status"`
+	Total   int64               `json:"total"`
+	Records []VendorPaymentGrid `json:"records"`
+}
+
+// VendorPaymentGetResponse is the response to a get request for a single
+// VendorPayment. It includes the bills it paid.
+type VendorPaymentGetResponse struct {
+	Status      string                         `json:"status"`
+	Record      VendorPaymentGrid              `json:"record"`
+	Allocations []rlib.VendorPaymentAllocation `json:"allocations"`
+}
+
+// VendorPaymentAllocGrid is the part of a new payment applied to a bill
+type VendorPaymentAllocGrid struct {
+	BILLID int64
+	Amount rlib.Money
+}
+
+// VendorPaymentSaveForm is the form data for a new VendorPayment
+type VendorPaymentSaveForm struct {
+	Recid   int64 `json:"recid"`
+	BUD     rlib.XJSONBud
+	VID     int64
+	DEPID   int64
+	Dt      rlib.JSONDate
+	Amount  rlib.Money
+	Method  int64
+	DocNo   string
+	Comment string
+}
+
+// SaveVendorPaymentInput is the input data format for a Save command
+type SaveVendorPaymentInput struct {
+	Recid       int64                    `json:"recid"`
+	Status      string                   `json:"status"`
+	FormName    string                   `json:"name"`
+	Record      VendorPaymentSaveForm    `json:"record"`
+	Allocations []VendorPaymentAllocGrid `json:"allocations"`
+}
+
+// SvcHandlerBill handles the vendor bills of a business. For this call, we
+// expect the URI to contain the BID and the BILLID as follows:
+//       0    1    2     3
+// 		/v1/bill/BID/BILLID
+//
+// The server command can be:
+//      get
+//      save
+//      void
+//-----------------------------------------------------------------------------------
+func SvcHandlerBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerBill"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  BILLID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerBills(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("BILLID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getBill(w, r, d)
+		}
+	case "save":
+		saveBill(w, r, d)
+	case "void":
+		voidBill(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// billToGrid fills in the grid record for bill b with its balance as of now
+func billToGrid(r *http.Request, b *rlib.Bill, names map[int64]string, now *time.Time) (BillGrid, error) {
+	var q BillGrid
+	rlib.MigrateStructVals(b, &q)
+	q.BUD = rlib.GetBUDFromBIDList(b.BID)
+	q.VendorName = vendorName(r, b.VID, names)
+	if b.FLAGS&rlib.BILLVoid != 0 {
+		return q, nil
+	}
+	var err error
+	q.Balance, err = bizlogic.BillBalance(r.Context(), b, now)
+	return q, err
+}
+
+// SvcSearchHandlerBills returns the bills for business d.BID
+// wsdoc {
+//  @Title  Search Bills
+//	@URL /v1/bill/:BUI
+//  @Method  POST
+//	@Synopsis Search Bills
+//  @Descr  Return the bills of the business dated in the range
+//  @Descr  searchDtStart - searchDtStop along with their unpaid balance.
+//	@Input WebGridSearchRequest
+//  @Response BillSearchResponse
+// wsdoc }
+func SvcSearchHandlerBills(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerBills"
+	var (
+		g     BillSearchResponse
+		names = map[int64]string{}
+		now   = time.Now()
+		d1    = time.Time(d.wsSearchReq.SearchDtStart)
+		d2    = time.Time(d.wsSearchReq.SearchDtStop)
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetBillsByDateRange(r.Context(), d.BID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		q, err := billToGrid(r, &m[i], names, &now)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		q.Recid = int64(i)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getBill returns the requested Bill
+// wsdoc {
+//  @Title  Get Bill
+//	@URL /v1/bill/:BUI/:BILLID
+//  @Method  GET
+//	@Synopsis Get information on a Bill
+//  @Description  Return all fields, the lines and the payments for bill
+//  @Description  :BILLID
+//	@Input WebGridSearchRequest
+//  @Response BillGetResponse
+// wsdoc }
+func getBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getBill"
+	var (
+		g   BillGetResponse
+		now = time.Now()
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	b, err := rlib.GetBill(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.BILLID > 0 && b.BID == d.BID {
+		if g.Record, err = billToGrid(r, &b, map[int64]string{}, &now); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		g.Record.Recid = b.BILLID
+		bl, err := rlib.GetBillLines(r.Context(), b.BILLID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		for i := 0; i < len(bl); i++ {
+			var q BillLineGrid
+			rlib.MigrateStructVals(&bl[i], &q)
+			g.Lines = append(g.Lines, q)
+		}
+		if g.Payments, err = rlib.GetBillPayments(r.Context(), b.BILLID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveBill creates a Bill or updates the descriptive fields of one
+// wsdoc {
+//  @Title  Save Bill
+//	@URL /v1/bill/:BUI/:BILLID
+//  @Method  POST
+//	@Synopsis Create or update a Bill
+//  @Description  If BILLID is 0 a new bill is created from the record and
+//  @Description  its lines and posted: each line's GL Account is debited and
+//  @Description  the Accounts Payable account credited. If DtDue is not set
+//  @Description  it is computed from the vendor's terms. For an existing bill
+//  @Description  only InvoiceNo, DtDue and Comment are changed; to change
+//  @Description  anything else void the bill and enter a new one.
+//	@Input SaveBillInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveBill"
+	var (
+		foo SaveBillInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	//------------------------------------------------------------------
+	// An existing bill has been posted, only its descriptive fields
+	// can change.
+	//------------------------------------------------------------------
+	if f.BILLID > 0 {
+		b, err := rlib.GetBill(r.Context(), f.BILLID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if b.BILLID == 0 || b.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("bill %d not found", f.BILLID), funcname)
+			return
+		}
+		b.InvoiceNo = strings.TrimSpace(f.InvoiceNo)
+		b.DtDue = time.Time(f.DtDue)
+		b.Comment = f.Comment
+		if err = rlib.UpdateBill(r.Context(), &b); err != nil {
+			e := fmt.Errorf("%s: Error saving bill: %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		SvcWriteSuccessResponseWithID(d.BID, w, b.BILLID)
+		return
+	}
+
+	b := rlib.Bill{
+		BID:       bid,
+		VID:       f.VID,
+		InvoiceNo: strings.TrimSpace(f.InvoiceNo),
+		Dt:        time.Time(f.Dt),
+		DtDue:     time.Time(f.DtDue),
+		Amount:    f.Amount,
+		APLID:     f.APLID,
+		Comment:   f.Comment,
+	}
+	for i := 0; i < len(foo.Lines); i++ {
+		b.BL = append(b.BL, rlib.BillLine{
+			LID:    foo.Lines[i].LID,
+			RID:    foo.Lines[i].RID,
+			Amount: foo.Lines[i].Amount,
+			Descr:  foo.Lines[i].Descr,
+		})
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.InsertBill(ctx, &b); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, b.BILLID)
+}
+
+// voidBill voids a Bill
+// wsdoc {
+//  @Title  Void Bill
+//	@URL /v1/bill/:BUI/:BILLID
+//  @Method  POST
+//	@Synopsis Void a Bill
+//  @Desc  Voids bill :BILLID by posting the reverse of its journal entry.
+//  @Desc  A bill with payments cannot be voided until they are voided.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func voidBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "voidBill"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	b, err := rlib.GetBill(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.BILLID == 0 || b.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("bill %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.VoidBill(ctx, &b); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerVendorPayment handles the payments to vendors of a business.
+// For this call, we expect the URI to contain the BID and the VPID as
+// follows:
+//       0    1             2     3
+// 		/v1/vendorpayment/BID/VPID
+//
+// The server command can be:
+//      get
+//      save
+//      void
+//-----------------------------------------------------------------------------------
+func SvcHandlerVendorPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerVendorPayment"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  VPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerVendorPayments(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("VPID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getVendorPayment(w, r, d)
+		}
+	case "save":
+		saveVendorPayment(w, r, d)
+	case "void":
+		voidVendorPayment(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerVendorPayments returns the vendor payments for business
+// d.BID
+// wsdoc {
+//  @Title  Search Vendor Payments
+//	@URL /v1/vendorpayment/:BUI
+//  @Method  POST
+//	@Synopsis Search Vendor Payments
+//  @Descr  Return the payments to vendors dated in the range
+//  @Descr  searchDtStart - searchDtStop.
+//	@Input WebGridSearchRequest
+//  @Response VendorPaymentSearchResponse
+// wsdoc }
+func SvcSearchHandlerVendorPayments(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerVendorPayments"
+	var (
+		g     VendorPaymentSearchResponse
+		names = map[int64]string{}
+		d1    = time.Time(d.wsSearchReq.SearchDtStart)
+		d2    = time.Time(d.wsSearchReq.SearchDtStop)
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetVendorPaymentsByDateRange(r.Context(), d.BID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q VendorPaymentGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		q.VendorName = vendorName(r, q.VID, names)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getVendorPayment returns the requested VendorPayment
+// wsdoc {
+//  @Title  Get Vendor Payment
+//	@URL /v1/vendorpayment/:BUI/:VPID
+//  @Method  GET
+//	@Synopsis Get information on a Vendor Payment
+//  @Description  Return all fields and the bills paid for payment :VPID
+//	@Input WebGridSearchRequest
+//  @Response VendorPaymentGetResponse
+// wsdoc }
+func getVendorPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getVendorPayment"
+	var g VendorPaymentGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetVendorPayment(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.VPID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.VPID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+		g.Record.VendorName = vendorName(r, a.VID, map[int64]string{})
+		if g.Allocations, err = rlib.GetVendorPaymentAllocations(r.Context(), a.VPID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveVendorPayment creates a VendorPayment
+// wsdoc {
+//  @Title  Save Vendor Payment
+//	@URL /v1/vendorpayment/:BUI/0
+//  @Method  POST
+//	@Synopsis Pay a vendor's bills
+//  @Description  Creates and posts a check or ACH payment to a vendor. The
+//  @Description  allocations list the bills paid and must add up to Amount.
+//  @Description  The Accounts Payable account of each bill is debited and
+//  @Description  the GL Account of the Depository credited. A payment cannot
+//  @Description  be changed, void it and enter a new one. A check payment
+//  @Description  with no DocNo from a Depository that is set up for checks
+//  @Description  gets a check written for it, DocNo is set to its number.
+//	@Input SaveVendorPaymentInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveVendorPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveVendorPayment"
+	var (
+		foo SaveVendorPaymentInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	p := rlib.VendorPayment{
+		BID:     bid,
+		VID:     f.VID,
+		DEPID:   f.DEPID,
+		Dt:      time.Time(f.Dt),
+		Amount:  f.Amount,
+		Method:  f.Method,
+		DocNo:   strings.TrimSpace(f.DocNo),
+		Comment: f.Comment,
+	}
+	for i := 0; i < len(foo.Allocations); i++ {
+		p.VPA = append(p.VPA, rlib.VendorPaymentAllocation{
+			BILLID: foo.Allocations[i].BILLID,
+			Amount: foo.Allocations[i].Amount,
+		})
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist := bizlogic.InsertVendorPayment(ctx, &p); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if p.Method == rlib.VPMTMETHODcheck && len(p.DocNo) == 0 {
+		ca, err := rlib.GetCheckAccountByDEPID(ctx, p.DEPID)
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if ca.CKAID > 0 {
+			var c rlib.BankCheck
+			if errlist := bizlogic.WriteVendorPaymentCheck(ctx, &p, &c); len(errlist) > 0 {
+				tx.Rollback()
+				SvcErrListReturn(w, errlist, funcname)
+				return
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, p.VPID)
+}
+
+// voidVendorPayment voids a VendorPayment
+// wsdoc {
+//  @Title  Void Vendor Payment
+//	@URL /v1/vendorpayment/:BUI/:VPID
+//  @Method  POST
+//	@Synopsis Void a Vendor Payment
+//  @Desc  Voids payment :VPID by posting the reverse of its journal entry.
+//  @Desc  The bills it paid are open again.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func voidVendorPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "voidVendorPayment"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	p, err := rlib.GetVendorPayment(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if p.VPID == 0 || p.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("vendor payment %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.VoidVendorPayment(ctx, &p); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
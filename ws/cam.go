@@ -0,0 +1,670 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// CAMPoolGrid is the UI representation of a CAMPool
+type CAMPoolGrid struct {
+	Recid        int64 `json:"recid"`
+	CAMPID       int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	Name         string
+	LIDs         string
+	EstimateARID int64
+	TrueUpARID   int64
+	CreditARID   int64
+	LastModTime  rlib.JSONDateTime
+	LastModBy    int64
+	CreateTS     rlib.JSONDateTime
+	CreateBy     int64
+}
+
+// CAMPoolSearchResponse is the response to a search request for CAMPools
+type CAMPoolSearchResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []CAMPoolGrid `json:"records"`
+}
+
+// CAMPoolGetResponse is the response to a get request for a single CAMPool
+type CAMPoolGetResponse struct {
+	Status string      `json:"status"`
+	Record CAMPoolGrid `json:"record"`
+}
+
+// SaveCAMPoolInput is the input data format for a Save command
+type SaveCAMPoolInput struct {
+	Recid    int64       `json:"recid"`
+	Status   string      `json:"status"`
+	FormName string      `json:"name"`
+	Record   CAMPoolGrid `json:"record"`
+}
+
+// CAMReconGrid is the UI representation of a CAMRecon
+type CAMReconGrid struct {
+	Recid     int64 `json:"recid"`
+	CAMRID    int64
+	BID       int64
+	CAMPID    int64
+	DtStart   rlib.JSONDate
+	DtStop    rlib.JSONDate
+	Expenses  float64
+	TotalSqft int64
+	DtPosted  rlib.JSONDate
+	FLAGS     uint64
+	Posted    bool
+}
+
+// CAMReconItemGrid is the UI representation of a CAMReconItem. The
+// tenant's statement is report RPTcamstmt with the CAMRIID.
+type CAMReconItemGrid struct {
+	Recid     int64 `json:"recid"`
+	CAMRIID   int64
+	CAMRID    int64
+	RAID      int64
+	RID       int64
+	Sqft      int64
+	Days      int64
+	Share     float64
+	Allocated float64
+	BaseYear  float64
+	Due       float64
+	Estimates float64
+	TrueUp    float64
+	ASMID     int64
+}
+
+// CAMReconSearchResponse lists the reconciliations of a CAMPool
+type CAMReconSearchResponse struct {
+	Status  string         `json:"status"`
+	Total   int64          `json:"total"`
+	Records []CAMReconGrid `json:"records"`
+}
+
+// CAMReconResponse is a reconciliation with its items
+type CAMReconResponse struct {
+	Status string             `json:"status"`
+	Record CAMReconGrid       `json:"record"`
+	Items  []CAMReconItemGrid `json:"items"`
+}
+
+// CAMReconRequest is the request data of the camrecon commands. CAMPID is
+// the pool for list, compute and save. Dt is the date of the true-up
+// assessments for post.
+type CAMReconRequest struct {
+	CAMPID int64
+	Dt     rlib.JSONDate
+}
+
+// SvcHandlerCAMPool handles the CAM pools of a business. For this call, we
+// expect the URI to contain the BID and the CAMPID as follows:
+//       0    1          2     3
+// 		/v1/campool/BID/CAMPID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerCAMPool(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCAMPool"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  CAMPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerCAMPools(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("CAMPID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getCAMPool(w, r, d)
+		}
+	case "save":
+		saveCAMPool(w, r, d)
+	case "delete":
+		deleteCAMPool(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerCAMPools returns the CAM pools of business d.BID
+// wsdoc {
+//  @Title  Search CAM Pools
+//	@URL /v1/campool/:BUI
+//  @Method  POST
+//	@Synopsis Search CAM Pools
+//  @Descr  Return the CAM pools of the business sorted by name.
+//	@Input WebGridSearchRequest
+//  @Response CAMPoolSearchResponse
+// wsdoc }
+func SvcSearchHandlerCAMPools(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerCAMPools"
+	var g CAMPoolSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetCAMPoolsByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q CAMPoolGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CAMPID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getCAMPool returns the requested CAMPool
+// wsdoc {
+//  @Title  Get CAM Pool
+//	@URL /v1/campool/:BUI/:CAMPID
+//  @Method  GET
+//	@Synopsis Get a CAM Pool
+//  @Description  Return all fields for CAM pool :CAMPID
+//	@Input WebGridSearchRequest
+//  @Response CAMPoolGetResponse
+// wsdoc }
+func getCAMPool(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getCAMPool"
+	var g CAMPoolGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetCAMPool(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CAMPID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.CAMPID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveCAMPool creates or updates a CAMPool
+// wsdoc {
+//  @Title  Save CAM Pool
+//	@URL /v1/campool/:BUI/:CAMPID
+//  @Method  POST
+//	@Synopsis Create or update a CAM Pool
+//  @Description  Saves the CAM pool. LIDs is a comma separated list of the
+//  @Description  GL accounts whose expenses are pooled. EstimateARID is the
+//  @Description  account rule the estimated charges are billed with.
+//  @Description  TrueUpARID and CreditARID are used for the true-up
+//  @Description  assessments when a reconciliation is posted.
+//	@Input SaveCAMPoolInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveCAMPool(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveCAMPool"
+	var (
+		foo SaveCAMPoolInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if len(f.Name) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("Name is required"), funcname)
+		return
+	}
+
+	//------------------------------------------------------------------
+	// The accounts and account rules must belong to the business
+	//------------------------------------------------------------------
+	lids, err := rlib.CAMPoolLIDs(f.LIDs)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if len(lids) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("at least one GL account is required"), funcname)
+		return
+	}
+	var s []string
+	for i := 0; i < len(lids); i++ {
+		l, err := rlib.GetLedger(r.Context(), lids[i])
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if l.LID == 0 || l.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("GL account %d not found", lids[i]), funcname)
+			return
+		}
+		s = append(s, fmt.Sprintf("%d", lids[i]))
+	}
+	f.LIDs = strings.Join(s, ",")
+	for _, arid := range []int64{f.EstimateARID, f.TrueUpARID, f.CreditARID} {
+		if arid == 0 {
+			continue
+		}
+		ar, err := rlib.GetAR(r.Context(), arid)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if ar.ARID == 0 || ar.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("account rule %d not found", arid), funcname)
+			return
+		}
+	}
+
+	var a rlib.CAMPool
+	if f.CAMPID > 0 {
+		if a, err = rlib.GetCAMPool(r.Context(), f.CAMPID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.CAMPID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("CAM pool %d not found", f.CAMPID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(f, &a)
+	a.BID = bid
+	if a.CAMPID == 0 {
+		err = rlib.InsertCAMPool(r.Context(), &a)
+	} else {
+		err = rlib.UpdateCAMPool(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving CAM pool: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.CAMPID)
+}
+
+// deleteCAMPool deletes a CAMPool that has no reconciliations
+// wsdoc {
+//  @Title  Delete CAM Pool
+//	@URL /v1/campool/:BUI/:CAMPID
+//  @Method  POST
+//	@Synopsis Delete a CAM Pool
+//  @Desc  This service deletes a CAM pool. A pool that has been reconciled
+//  @Desc  cannot be deleted.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteCAMPool(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteCAMPool"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetCAMPool(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CAMPID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("CAM pool %d not found", del.ID), funcname)
+		return
+	}
+	m, err := rlib.GetCAMReconsByCAMPID(r.Context(), a.CAMPID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if len(m) > 0 {
+		SvcErrorReturn(w, fmt.Errorf("%s has %d reconciliations and cannot be deleted", a.Name, len(m)), funcname)
+		return
+	}
+	if err = rlib.DeleteCAMPool(r.Context(), a.CAMPID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerCAMRecon handles the annual reconciliations of the CAM pools of
+// a business. For this call, we expect the URI to contain the BID and the
+// CAMRID as follows:
+//       0    1           2     3
+// 		/v1/camrecon/BID/CAMRID
+//
+// The request data is a CAMReconRequest. The landlord's summary and the
+// tenant statements are printed with /v1/report, reports RPTcamrecon (ID is
+// the CAMRID) and RPTcamstmt (ID is the CAMRIID).
+//
+// The server command can be:
+//      list
+//      get
+//      compute
+//      save
+//      post
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerCAMRecon(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCAMRecon"
+	var req CAMReconRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  CAMRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "list":
+		listCAMRecons(w, r, d, &req)
+	case "get":
+		getCAMRecon(w, r, d)
+	case "compute", "save":
+		computeCAMRecon(w, r, d, &req)
+	case "post":
+		postCAMRecon(w, r, d, &req)
+	case "delete":
+		deleteCAMRecon(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// camReconResponse fills g with reconciliation a and its items
+func camReconResponse(g *CAMReconResponse, a *rlib.CAMRecon, m []rlib.CAMReconItem) {
+	rlib.MigrateStructVals(a, &g.Record)
+	g.Record.Recid = a.CAMRID
+	g.Record.Posted = a.FLAGS&rlib.CAMRECONPosted != 0
+	g.Items = []CAMReconItemGrid{}
+	for i := 0; i < len(m); i++ {
+		var q CAMReconItemGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CAMRIID
+		if q.Recid == 0 {
+			q.Recid = int64(i + 1) // not saved yet
+		}
+		g.Items = append(g.Items, q)
+	}
+}
+
+// listCAMRecons lists the reconciliations of a CAM pool
+// wsdoc {
+//  @Title  CAM Reconciliations
+//	@URL /v1/camrecon/:BUI
+//  @Method  POST
+//	@Synopsis List the reconciliations of a CAM Pool
+//  @Descr  Returns the reconciliations of CAM pool CAMPID, latest year
+//  @Descr  first.
+//	@Input CAMReconRequest
+//  @Response CAMReconSearchResponse
+// wsdoc }
+func listCAMRecons(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CAMReconRequest) {
+	const funcname = "listCAMRecons"
+	var g CAMReconSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	p, err := rlib.GetCAMPool(r.Context(), req.CAMPID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if p.CAMPID == 0 || p.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("CAM pool %d not found", req.CAMPID), funcname)
+		return
+	}
+	m, err := rlib.GetCAMReconsByCAMPID(r.Context(), p.CAMPID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []CAMReconGrid{}
+	for i := 0; i < len(m); i++ {
+		var q CAMReconGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CAMRID
+		q.Posted = m[i].FLAGS&rlib.CAMRECONPosted != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getCAMRecon returns a reconciliation with its items
+// wsdoc {
+//  @Title  Get CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Get a CAM Reconciliation
+//  @Descr  Returns reconciliation :CAMRID and the computation for each
+//  @Descr  tenant.
+//	@Input CAMReconRequest
+//  @Response CAMReconResponse
+// wsdoc }
+func getCAMRecon(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getCAMRecon"
+	var g CAMReconResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetCAMRecon(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CAMRID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("CAM reconciliation %d not found", d.ID), funcname)
+		return
+	}
+	m, err := rlib.GetCAMReconItems(r.Context(), a.CAMRID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	camReconResponse(&g, &a, m)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// computeCAMRecon computes the reconciliation of a CAM pool for a year. The
+// compute command only returns it, save also saves it.
+// wsdoc {
+//  @Title  Compute CAM Reconciliation
+//	@URL /v1/camrecon/:BUI
+//  @Method  POST
+//	@Synopsis Compute the reconciliation of a CAM Pool
+//  @Descr  Computes the reconciliation of CAM pool CAMPID for the year
+//  @Descr  searchDtStart - searchDtStop. With the save command it replaces
+//  @Descr  any reconciliation of that year that has not been posted.
+//	@Input CAMReconRequest
+//  @Response CAMReconResponse
+// wsdoc }
+func computeCAMRecon(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CAMReconRequest) {
+	const funcname = "computeCAMRecon"
+	var g CAMReconResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1 := time.Time(d.wsSearchReq.SearchDtStart)
+	d2 := time.Time(d.wsSearchReq.SearchDtStop)
+	if !d2.After(d1) {
+		SvcErrorReturn(w, fmt.Errorf("the stop date must be after the start date"), funcname)
+		return
+	}
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	p, err := rlib.GetCAMPool(ctx, req.CAMPID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if p.CAMPID == 0 || p.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("CAM pool %d not found", req.CAMPID), funcname)
+		return
+	}
+
+	var (
+		a       rlib.CAMRecon
+		m       []rlib.CAMReconItem
+		errlist []bizlogic.BizError
+	)
+	if d.wsSearchReq.Cmd == "save" {
+		if a, errlist = bizlogic.SaveCAMRecon(ctx, &p, &d1, &d2); len(errlist) == 0 {
+			if m, err = rlib.GetCAMReconItems(ctx, a.CAMRID); err != nil {
+				tx.Rollback()
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+		}
+	} else {
+		a, m, errlist = bizlogic.ComputeCAMRecon(ctx, &p, &d1, &d2)
+	}
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	camReconResponse(&g, &a, m)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// postCAMRecon makes the true-up assessments of a reconciliation
+// wsdoc {
+//  @Title  Post CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Post the true-up assessments of a CAM Reconciliation
+//  @Descr  Adds a one time assessment on date Dt for each tenant of
+//  @Descr  reconciliation :CAMRID whose true-up is not 0: a charge with the
+//  @Descr  pool's TrueUpARID or a credit with its CreditARID. A posted
+//  @Descr  reconciliation cannot be changed or deleted.
+//	@Input CAMReconRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func postCAMRecon(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CAMReconRequest) {
+	const funcname = "postCAMRecon"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetCAMRecon(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CAMRID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("CAM reconciliation %d not found", d.ID), funcname)
+		return
+	}
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		dt = a.DtStop
+	}
+	if errlist := bizlogic.PostCAMRecon(ctx, &a, &dt); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.CAMRID)
+}
+
+// deleteCAMRecon deletes a reconciliation that has not been posted
+// wsdoc {
+//  @Title  Delete CAM Reconciliation
+//	@URL /v1/camrecon/:BUI/:CAMRID
+//  @Method  POST
+//	@Synopsis Delete a CAM Reconciliation
+//  @Desc  This service deletes reconciliation :CAMRID and its items. A
+//  @Desc  posted reconciliation cannot be deleted.
+//	@Input CAMReconRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteCAMRecon(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteCAMRecon"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetCAMRecon(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CAMRID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("CAM reconciliation %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteCAMRecon(ctx, &a); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
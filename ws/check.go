@@ -0,0 +1,608 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"net/url"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"strings"
+	"time"
+)
+
+// CheckAccountGrid is the UI representation of a CheckAccount
+type CheckAccountGrid struct {
+	Recid        int64 `json:"recid"`
+	CKAID        int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	DEPID        int64
+	BankName     string
+	BankAddress  string
+	RoutingNo    string
+	FractionalNo string
+	PayerName    string
+	PayerAddress string
+	NextCheckNo  int64
+	PPFID        int64
+	LastModTime  rlib.JSONDateTime
+	LastModBy    int64
+	CreateTS     rlib.JSONDateTime
+	CreateBy     int64
+}
+
+// CheckAccountGetResponse is the response to a get request for the
+// CheckAccount of a Depository
+type CheckAccountGetResponse struct {
+	Status string           `json:"status"`
+	Record CheckAccountGrid `json:"record"`
+}
+
+// SaveCheckAccountInput is the input data format for a Save command
+type SaveCheckAccountInput struct {
+	Recid    int64            `json:"recid"`
+	Status   string           `json:"status"`
+	FormName string           `json:"name"`
+	Record   CheckAccountGrid `json:"record"`
+}
+
+// CheckGrid is the UI representation of a BankCheck
+type CheckGrid struct {
+	Recid        int64 `json:"recid"`
+	CKID         int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	DEPID        int64
+	CheckNo      int64
+	Dt           rlib.JSONDate
+	Payee        string
+	PayeeAddress string
+	Amount       rlib.Money
+	Memo         string
+	DebitLID     int64
+	VPID         int64
+	TCID         int64
+	RAID         int64
+	JID          int64
+	FLAGS        uint64
+	LastModTime  rlib.JSONDateTime
+	LastModBy    int64
+	CreateTS     rlib.JSONDateTime
+	CreateBy     int64
+}
+
+// CheckSearchResponse is the response to a search request for the checks
+// of a Depository
+type CheckSearchResponse struct {
+	Status  string      `json:"status"`
+	Total   int64       `json:"total"`
+	Records []CheckGrid `json:"records"`
+}
+
+// CheckGetResponse is the response to a get request for a single check
+type CheckGetResponse struct {
+	Status string    `json:"status"`
+	Record CheckGrid `json:"record"`
+}
+
+// CheckSaveForm is the form data for a check. If VPID is set the check is
+// written for that vendor payment and only Memo is used. Otherwise DEPID,
+// Dt, Payee, Amount and DebitLID are required. Once a check is written only
+// Memo and PayeeAddress can be changed, and only until it is printed.
+type CheckSaveForm struct {
+	Recid        int64 `json:"recid"`
+	CKID         int64
+	BUD          rlib.XJSONBud
+	DEPID        int64
+	Dt           rlib.JSONDate
+	Payee        string
+	PayeeAddress string
+	Amount       rlib.Money
+	Memo         string
+	DebitLID     int64
+	VPID         int64
+	TCID         int64
+	RAID         int64
+}
+
+// SaveCheckInput is the input data format for a Save command
+type SaveCheckInput struct {
+	Recid    int64         `json:"recid"`
+	Status   string        `json:"status"`
+	FormName string        `json:"name"`
+	Record   CheckSaveForm `json:"record"`
+}
+
+// SvcHandlerCheckAccount handles the check printing setup of a Depository.
+// For this call, we expect the URI to contain the BID and the DEPID as
+// follows:
+//       0    1            2     3
+// 		/v1/checkaccount/BID/DEPID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerCheckAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCheckAccount"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  DEPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getCheckAccount(w, r, d)
+	case "save":
+		saveCheckAccount(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getCheckAccount returns the check printing setup of a Depository
+// wsdoc {
+//  @Title  Get Check Account
+//	@URL /v1/checkaccount/:BUI/:DEPID
+//  @Method  GET
+//	@Synopsis Get the check printing setup of a Depository
+//  @Description  Returns the bank, routing number, payer and next check
+//  @Description  number of Depository :DEPID. CKAID is 0 if the depository
+//  @Description  is not set up for checks.
+//	@Input WebGridSearchRequest
+//  @Response CheckAccountGetResponse
+// wsdoc }
+func getCheckAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getCheckAccount"
+	var g CheckAccountGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetCheckAccountByDEPID(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CKAID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.CKAID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveCheckAccount creates or updates the check printing setup of a
+// Depository
+// wsdoc {
+//  @Title  Save Check Account
+//	@URL /v1/checkaccount/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Create or update the check printing setup of a Depository
+//  @Description  Saves the check printing setup of depository :DEPID. The
+//  @Description  routing number must be a valid ABA routing number.
+//  @Description  NextCheckNo can be set to match the check stock but it
+//  @Description  cannot go back to a number that has already been used.
+//  @Description  PPFID is the positive pay format of the bank, 0 if the
+//  @Description  bank does not use positive pay.
+//	@Input SaveCheckAccountInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveCheckAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveCheckAccount"
+	var (
+		foo SaveCheckAccountInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.DEPID = d.ID
+	f.RoutingNo = strings.TrimSpace(f.RoutingNo)
+	if !rlib.ValidRoutingNumber(f.RoutingNo) {
+		SvcErrorReturn(w, fmt.Errorf("%q is not a valid routing number", f.RoutingNo), funcname)
+		return
+	}
+	if f.NextCheckNo <= 0 {
+		SvcErrorReturn(w, fmt.Errorf("NextCheckNo must be greater than 0"), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dep, err := rlib.GetDepository(ctx, f.DEPID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if dep.DEPID == 0 || dep.BID != bid {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("depository %d not found", f.DEPID), funcname)
+		return
+	}
+	if f.PPFID > 0 {
+		ppf, err := rlib.GetPositivePayFormat(ctx, f.PPFID)
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if ppf.PPFID == 0 || ppf.BID != bid {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("positive pay format %d not found", f.PPFID), funcname)
+			return
+		}
+	}
+	a, err := rlib.GetCheckAccountForUpdate(ctx, f.DEPID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	//------------------------------------------------------------------
+	// Check numbers already used stay used. Moving NextCheckNo forward
+	// to skip damaged stock is fine, moving it back is not.
+	//------------------------------------------------------------------
+	if a.CKAID > 0 && f.NextCheckNo < a.NextCheckNo {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("NextCheckNo cannot be less than %d", a.NextCheckNo), funcname)
+		return
+	}
+	ckaid := a.CKAID
+	rlib.MigrateStructVals(f, &a)
+	a.CKAID = ckaid
+	a.BID = bid
+
+	if a.CKAID == 0 {
+		err = rlib.InsertCheckAccount(ctx, &a)
+	} else {
+		err = rlib.UpdateCheckAccount(ctx, &a)
+	}
+	if err != nil {
+		tx.Rollback()
+		e := fmt.Errorf("%s: Error saving check account: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.CKAID)
+}
+
+// SvcCheckRegister returns the check register of a Depository: the checks
+// written in the search date range in check number order.
+// wsdoc {
+//  @Title  Check Register
+//	@URL /v1/checkregister/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis List the checks drawn on a Depository
+//  @Descr  Returns the checks drawn on depository :DEPID dated from
+//  @Descr  searchDtStart up to searchDtStop, void checks included.
+//	@Input WebGridSearchRequest
+//  @Response CheckSearchResponse
+// wsdoc }
+func SvcCheckRegister(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcCheckRegister"
+	var g CheckSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	dep, err := rlib.GetDepository(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if dep.DEPID == 0 || dep.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("depository %d not found", d.ID), funcname)
+		return
+	}
+	d1 := time.Time(d.wsSearchReq.SearchDtStart)
+	d2 := time.Time(d.wsSearchReq.SearchDtStop)
+	m, err := rlib.GetBankChecksByDEPID(r.Context(), dep.DEPID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q CheckGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CKID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcHandlerCheck handles the checks of a business. For this call, we
+// expect the URI to contain the BID and the CKID as follows:
+//       0    1     2     3
+// 		/v1/check/BID/CKID
+//
+// The server command can be:
+//      get
+//      save
+//      void
+//      print
+//-----------------------------------------------------------------------------------
+func SvcHandlerCheck(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCheck"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  CKID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getCheck(w, r, d)
+	case "save":
+		saveCheck(w, r, d)
+	case "void":
+		voidCheck(w, r, d)
+	case "print":
+		printCheck(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getCheck returns the requested check
+// wsdoc {
+//  @Title  Get Check
+//	@URL /v1/check/:BUI/:CKID
+//  @Method  GET
+//	@Synopsis Get information on a check
+//  @Description  Return all fields for check :CKID
+//	@Input WebGridSearchRequest
+//  @Response CheckGetResponse
+// wsdoc }
+func getCheck(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getCheck"
+	var g CheckGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetBankCheck(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CKID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.CKID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveCheck writes a new check or updates one that has not been printed
+// wsdoc {
+//  @Title  Save Check
+//	@URL /v1/check/:BUI/:CKID
+//  @Method  POST
+//	@Synopsis Write a check
+//  @Description  If CKID is 0 a new check is written with the next number of
+//  @Description  its depository. With VPID set the check pays that vendor
+//  @Description  payment, which must be a check payment. Otherwise the check
+//  @Description  is posted on its own: DebitLID is debited and the
+//  @Description  depository's account credited. A security deposit refund,
+//  @Description  for example, debits the security deposit account and sets
+//  @Description  RAID and TCID. An existing check can only have its Memo and
+//  @Description  PayeeAddress changed, and only until it is printed.
+//	@Input SaveCheckInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveCheck(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveCheck"
+	var (
+		foo SaveCheckInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	if f.CKID > 0 {
+		c, err := rlib.GetBankCheck(r.Context(), f.CKID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if c.CKID == 0 || c.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("check %d not found", f.CKID), funcname)
+			return
+		}
+		if c.FLAGS&(rlib.CHECKVoid|rlib.CHECKPrinted) != 0 {
+			SvcErrorReturn(w, fmt.Errorf("check %d has been printed or voided and cannot be changed", c.CheckNo), funcname)
+			return
+		}
+		c.Memo = f.Memo
+		c.PayeeAddress = f.PayeeAddress
+		if err = rlib.UpdateBankCheck(r.Context(), &c); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		SvcWriteSuccessResponseWithID(d.BID, w, c.CKID)
+		return
+	}
+
+	c := rlib.BankCheck{
+		BID:          bid,
+		DEPID:        f.DEPID,
+		Dt:           time.Time(f.Dt),
+		Payee:        f.Payee,
+		PayeeAddress: f.PayeeAddress,
+		Amount:       f.Amount,
+		Memo:         f.Memo,
+		DebitLID:     f.DebitLID,
+		TCID:         f.TCID,
+		RAID:         f.RAID,
+	}
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	var errlist []bizlogic.BizError
+	if f.VPID > 0 {
+		p, err := rlib.GetVendorPayment(ctx, f.VPID)
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if p.VPID == 0 || p.BID != bid {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("vendor payment %d not found", f.VPID), funcname)
+			return
+		}
+		errlist = bizlogic.WriteVendorPaymentCheck(ctx, &p, &c)
+	} else {
+		errlist = bizlogic.WriteCheck(ctx, &c)
+	}
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, c.CKID)
+}
+
+// voidCheck voids a check
+// wsdoc {
+//  @Title  Void Check
+//	@URL /v1/check/:BUI/:CKID
+//  @Method  POST
+//	@Synopsis Void a check
+//  @Desc  Voids check :CKID. Voiding a check written for a vendor payment
+//  @Desc  voids the payment, which reopens the bills it paid. Any other
+//  @Desc  check is reversed. The check number stays used.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func voidCheck(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "voidCheck"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	c, err := rlib.GetBankCheck(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CKID == 0 || c.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("check %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.VoidCheck(ctx, &c); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// printCheck returns the check as a PDF for printing on check stock and
+// marks it printed
+// wsdoc {
+//  @Title  Print Check
+//	@URL /v1/check/:BUI/:CKID
+//  @Method  GET
+//	@Synopsis Print a check
+//  @Desc  Returns check :CKID as a PDF laid out for US Letter check stock
+//  @Desc  with the check on top and two stubs. A void check cannot be
+//  @Desc  printed. The check is marked printed and can no longer be changed.
+//	@Input WebGridSearchRequest
+//  @Response PDF document
+// wsdoc }
+func printCheck(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "printCheck"
+	var (
+		ui   rrpt.ReportContext
+		xbiz rlib.XBusiness
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	c, err := rlib.GetBankCheck(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.CKID == 0 || c.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("check %d not found", d.ID), funcname)
+		return
+	}
+	if c.FLAGS&rlib.CHECKVoid != 0 {
+		SvcErrorReturn(w, fmt.Errorf("check %d is void", c.CheckNo), funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if c.FLAGS&rlib.CHECKPrinted == 0 {
+		c.FLAGS |= rlib.CHECKPrinted
+		if err = rlib.UpdateBankCheck(r.Context(), &c); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+
+	ui.ID = c.CKID
+	ui.D1 = c.Dt
+	ui.D2 = c.Dt.AddDate(0, 0, 1)
+	ui.ReportOutputFormat = gotable.TABLEOUTPDF
+	ui.PDFPageSizeUnit = "in"
+	qp := url.Values{}
+	v1ReportHandler(r.Context(), "RPTcheck", &xbiz, &ui, w, &qp)
+}
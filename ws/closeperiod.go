@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"rentroll/rlib"
+	"rentroll/worker"
+	"strings"
 	"time"
 )
 
@@ -43,6 +45,12 @@ type SaveClosePeriod struct {
 	Record FormClosePeriod `json:"record"`
 }
 
+// ReopenClosePeriod is the request to reopen the last period closed
+type ReopenClosePeriod struct {
+	Cmd    string `json:"cmd"`
+	Reason string `json:"reason"` // why the period is being reopened
+}
+
 //-------------------------------------------------------------------
 //                         **** GET ****
 //-------------------------------------------------------------------
@@ -134,6 +142,21 @@ func saveClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		SvcErrorReturn(w, e, funcname)
 		return
 	}
+
+	//-------------------------------------------------------------
+	// The books must pass all the close checks in the tasklist...
+	//-------------------------------------------------------------
+	failures, err := worker.RunCloseChecks(r.Context(), &tl)
+	if err != nil {
+		e := fmt.Errorf("%s: Error running close checks: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if len(failures) > 0 {
+		e := fmt.Errorf("The period cannot be closed until these checks pass:\n%s", strings.Join(failures, "\n"))
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
 	//-------------------------------------------------------------
 	// TRANSACTION:
 	//    1 - write the ClosePeriod entry
@@ -161,17 +184,17 @@ func saveClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	//-------------------------------------------------------------
-	//  Generate Ledger Markers...
+	//  Generate the GL and RAID Ledger Markers. If this period
+	//  was reopened, the markers written at the reopen are
+	//  replaced so that anything posted since is included...
 	//-------------------------------------------------------------
-	if err = rlib.GenerateLedgerMarkers(ctx, &xbiz, &tl.DtDue); err != nil {
+	if err = rlib.RebuildLedgerMarkers(ctx, &xbiz, &tl.DtDue); err != nil {
 		tx.Rollback()
 		SvcErrorReturn(w, err, funcname)
 		return
 	}
 
-	//-------------------------------------------------------------
-	//  Generate RAID Ledger Markers...
-	//-------------------------------------------------------------
+	rlib.EmitWebhookEvent(ctx, d.BID, rlib.WHEVTperiodClosed, &cp)
 
 	//-------------------------------------------------------------
 	// COMMIT TRANSACTION
@@ -307,17 +330,78 @@ EXITNOW:
 	SvcWriteResponse(d.BID, &g, w)
 }
 
-// deleteClosePeriod reopens the ClosePeriod specified
+// deleteClosePeriod reopens the last period closed
 // wsdoc {
-//  @Title  Delete ClosePeriod
-//	@URL /v1/closeperiod/:BUI/TID
+//  @Title  Reopen ClosePeriod
+//	@URL /v1/closeperiod/:BUI
 //  @Method  POST
-//	@Synopsis Reopen ClosePeriod with the supplied date
-//  @Desc  This service deletes the ClosePeriod with the supplied TDID.
-//	@Input DeletePmtForm
+//	@Synopsis Reopen the last period closed
+//  @Desc  This service reopens the last period closed. Only the users listed
+//  @Desc  in the business property PeriodReopeners may do this. The reopen is
+//  @Desc  recorded along with the supplied reason, and the LedgerMarkers
+//  @Desc  written by the close are removed. They are rebuilt when the period
+//  @Desc  is closed again.
+//	@Input ReopenClosePeriod
 //  @Response SvcStatusResponse
 // wsdoc }
 //-----------------------------------------------------------------------------
 func deleteClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
-	// const funcname = "deleteClosePeriod"
+	const funcname = "deleteClosePeriod"
+	var foo ReopenClosePeriod
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if len(strings.TrimSpace(foo.Reason)) == 0 {
+		e := fmt.Errorf("%s: a reason is required to reopen a period", funcname)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	//-------------------------------------------------------------
+	//  Is this user allowed to reopen periods?
+	//-------------------------------------------------------------
+	if !rlib.NoAuthEnabled() {
+		if d.sess == nil {
+			e := fmt.Errorf("%s: session required, please log in", funcname)
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		ok, err := rlib.CanReopenPeriod(r.Context(), d.BID, d.sess.UID)
+		if err != nil {
+			e := fmt.Errorf("%s: Error checking permission to reopen period: %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		if !ok {
+			e := fmt.Errorf("%s: user %s is not permitted to reopen a closed period", funcname, d.sess.Username)
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	var xbiz rlib.XBusiness
+	if err := rlib.InitBizInternals(d.BID, &xbiz); err != nil {
+		e := fmt.Errorf("%s: Error InitBizInternals BID = %d: %s", funcname, d.BID, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if _, err = rlib.ReopenLastClosePeriod(ctx, &xbiz, foo.Reason); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(d.BID, w)
 }
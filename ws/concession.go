@@ -0,0 +1,244 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// ConcessionGrid is the UI representation of a Concession
+type ConcessionGrid struct {
+	Recid       int64 `json:"recid"`
+	CONID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	Description string
+	ARID        int64
+	Amount      rlib.Money
+	Pct         float64
+	Cycles      int64
+	FLAGS       uint64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// ConcessionSearchResponse is the response to a search request for
+// Concession records
+type ConcessionSearchResponse struct {
+	Status  string           `json:"status"`
+	Total   int64            `json:"total"`
+	Records []ConcessionGrid `json:"records"`
+}
+
+// ConcessionGetResponse is the response to a get request for a single
+// Concession
+type ConcessionGetResponse struct {
+	Status string         `json:"status"`
+	Record ConcessionGrid `json:"record"`
+}
+
+// SaveConcessionInput is the input data format for a Save command
+type SaveConcessionInput struct {
+	Recid    int64          `json:"recid"`
+	Status   string         `json:"status"`
+	FormName string         `json:"name"`
+	Record   ConcessionGrid `json:"record"`
+}
+
+// SvcHandlerConcession handles the concession definitions of a business.
+// For this call, we expect the URI to contain the BID and the CONID as
+// follows:
+//       0    1          2     3
+// 		/v1/concession/BID/CONID
+//
+// Concessions are given on the rent of a rentable in the raflow, see the
+// addconcession command of /v1/raflow-rentable.
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerConcession(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerConcession"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  CONID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerConcessions(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("CONID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getConcession(w, r, d)
+		}
+	case "save":
+		saveConcession(w, r, d)
+	case "delete":
+		deleteConcession(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerConcessions returns the concessions of business d.BID
+// wsdoc {
+//  @Title  Search Concessions
+//	@URL /v1/concession/:BUI
+//  @Method  POST
+//	@Synopsis Search Concessions
+//  @Descr  Return the concessions of the business sorted by name.
+//	@Input WebGridSearchRequest
+//  @Response ConcessionSearchResponse
+// wsdoc }
+func SvcSearchHandlerConcessions(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerConcessions"
+	var g ConcessionSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetConcessionsByBusiness(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q ConcessionGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getConcession returns the requested Concession
+// wsdoc {
+//  @Title  Get Concession
+//	@URL /v1/concession/:BUI/:CONID
+//  @Method  GET
+//	@Synopsis Get information on a Concession
+//  @Description  Return all fields for concession :CONID
+//	@Input WebGridSearchRequest
+//  @Response ConcessionGetResponse
+// wsdoc }
+func getConcession(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getConcession"
+	var g ConcessionGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetConcession(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CONID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.CONID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveConcession creates or updates a Concession
+// wsdoc {
+//  @Title  Save Concession
+//	@URL /v1/concession/:BUI/:CONID
+//  @Method  POST
+//	@Synopsis Create or update a Concession
+//  @Description  Saves the concession with the supplied data. If CONID is 0
+//  @Description  a new concession is created. Set either Amount or Pct, the
+//  @Description  amount or percent off each of the first Cycles rent charges.
+//  @Description  Set FLAGS bit 0 when the concession is no longer offered.
+//	@Input SaveConcessionInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveConcession(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveConcession"
+	var (
+		foo SaveConcessionInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.Concession
+	if f.CONID > 0 {
+		if a, err = rlib.GetConcession(r.Context(), f.CONID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(f, &a)
+	a.BID = bid
+
+	if errlist := bizlogic.SaveConcession(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.CONID)
+}
+
+// deleteConcession deletes a Concession
+// wsdoc {
+//  @Title  Delete Concession
+//	@URL /v1/concession/:BUI/:CONID
+//  @Method  POST
+//	@Synopsis Delete a Concession
+//  @Desc  This service deletes a concession definition. Rental Agreements
+//  @Desc  that were given the concession keep it.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteConcession(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteConcession"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetConcession(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.CONID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("concession %d not found", del.ID), funcname)
+		return
+	}
+	if err = rlib.DeleteConcession(r.Context(), a.CONID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
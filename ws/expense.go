@@ -396,11 +396,9 @@ func saveExpense(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	if a.EXPID == 0 && d.ID == 0 {
-		_, err = rlib.InsertExpense(r.Context(), &a)
-		var xbiz rlib.XBusiness
-		err = rlib.ProcessNewExpense(r.Context(), &a, &xbiz)
-		if err != nil {
-			SvcErrorReturn(w, err, funcname)
+		errlist := bizlogic.InsertExpense(r.Context(), &a)
+		if len(errlist) > 0 {
+			SvcErrListReturn(w, errlist, funcname)
 			return
 		}
 	} else {
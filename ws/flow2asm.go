@@ -35,6 +35,19 @@ func Fees2RA(ctx context.Context, x *WriteHandlerContext) error {
 		x.lastClose.Dt = rlib.TIME0 // use TIME0 if not set
 	}
 
+	//--------------------------------------------------
+	// Concessions go in before the fees so that they
+	// apply to the rent assessments the fees create
+	//--------------------------------------------------
+	rlib.Console("Fees2RA: Rentables concessions\n")
+	for i := 0; i < len(x.raf.Rentables); i++ {
+		for j := 0; j < len(x.raf.Rentables[i].Concessions); j++ {
+			if err = F2RASaveConcession(ctx, x, &x.raf.Rentables[i].Concessions[j], x.raf.Rentables[i].RID); err != nil {
+				return err
+			}
+		}
+	}
+
 	//--------------------------------------------------
 	// Add Rentable fees to new RA first...
 	//--------------------------------------------------
@@ -277,9 +290,9 @@ func F2RAHandleOldAssessments(ctx context.Context, x *WriteHandlerContext) error
 						// that covers from target to x.ra.RentStart
 						//-----------------------------------------------------
 						asm := n[0]
-						amt, count, totcount := rlib.SimpleProrateAmount(v.Amount, v.RentCycle, v.ProrationCycle, &target, &x.ra.RentStart, &target)
+						amt, count, totcount := rlib.SimpleProrateAmount(v.Amount.Float(), v.RentCycle, v.ProrationCycle, &target, &x.ra.RentStart, &target)
 						asm.AppendComment(fmt.Sprintf("prorated for %d of %d %s", count, totcount, rlib.ProrationUnits(v.ProrationCycle)))
-						asm.Amount = amt
+						asm.Amount = rlib.MoneyFromFloat(amt)
 						asm.RentCycle = rlib.RECURNONE      // not part of a series
 						asm.ProrationCycle = rlib.RECURNONE // no proration here
 						asm.FLAGS = 0
@@ -446,7 +459,7 @@ func F2RASaveNewFee(ctx context.Context, x *WriteHandlerContext, fee *rlib.RAFee
 	}
 
 	// rlib.Console("bid = %d, fee ARID = %d\n", b.BID, fee.ARID)
-	b.Amount = fee.ContractAmount
+	b.Amount = rlib.MoneyFromFloat(fee.ContractAmount)
 	b.AcctRule = ""
 	b.RentCycle = fee.RentCycle
 	b.RAID = x.ra.RAID
@@ -475,6 +488,41 @@ func F2RASaveNewFee(ctx context.Context, x *WriteHandlerContext, fee *rlib.RAFee
 	return nil
 }
 
+// F2RASaveConcession gives the supplied concession on the rent of Rentable
+// rid in the new rental agreement. If it continues a concession of the old
+// rental agreement, the rent charges already discounted are carried over.
+//
+// INPUTS
+//     ctx  - db context for transactions
+//     x    - all the contextual info we need for performing this operation
+//     c    - the concession
+//     rid  - the Rentable
+//
+// RETURNS
+//     Any errors encountered
+//-----------------------------------------------------------------------------
+func F2RASaveConcession(ctx context.Context, x *WriteHandlerContext, c *rlib.RAConcessionData, rid int64) error {
+	if c.Applied >= c.Cycles {
+		return nil // nothing left to discount
+	}
+	a := rlib.RentalAgreementConcession{
+		BID:     x.raf.Meta.BID,
+		RAID:    x.ra.RAID,
+		RID:     rid,
+		CONID:   c.CONID,
+		ARID:    c.ARID,
+		Amount:  rlib.MoneyFromFloat(c.Amount),
+		Pct:     c.Pct,
+		Cycles:  c.Cycles,
+		Applied: c.Applied,
+		DtStart: time.Time(c.Start),
+	}
+	if errlist := bizlogic.ValidateRentalAgreementConcession(ctx, &a); len(errlist) > 0 {
+		return bizlogic.BizErrorListToError(errlist)
+	}
+	return rlib.InsertRentalAgreementConcession(ctx, &a)
+}
+
 // F2RAUpdateExistingAssessment handles all the updates necessary to move the
 // supplied fee into the permanent tables.
 //
@@ -122,6 +122,14 @@ func Flow2RA(ctx context.Context, flowid int64) (int64, error) {
 		rlib.Console("\tMetaData data updated on RAID=%d\n", nraid)
 	}
 
+	//-------------------------------------------------------------
+	// Lease documents generated from the flow now belong to the
+	// Rental Agreement version it was saved as
+	//-------------------------------------------------------------
+	if err = rlib.UpdateLeaseDocumentsRAID(ctx, flowid, x.newRAID); err != nil {
+		return nraid, err
+	}
+
 	// REMOVE FLOW IF MIGRATION DONE SUCCESSFULLY
 	// Delete only if state is active or above active
 	var state = x.ra.FLAGS & uint64(0xF)
@@ -437,7 +445,7 @@ func FlowSaveRA(ctx context.Context, x *WriteHandlerContext) (int64, error) {
 		RAID:    x.newRAID,
 		RID:     0,
 		Dt:      x.ra.AgreementStart,
-		Balance: float64(0),
+		Balance: rlib.Money(0),
 		State:   rlib.LMINITIAL,
 	}
 	_, err = rlib.InsertLedgerMarker(ctx, &lm)
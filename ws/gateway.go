@@ -0,0 +1,728 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// PaymentGatewayGrid is the UI representation of a PaymentGateway. The API
+// key is never returned.
+type PaymentGatewayGrid struct {
+	Recid    int64 `json:"recid"`
+	PGID     int64
+	BID      int64
+	Name     string
+	URL      string
+	APIKey   string // if blank on save, an existing gateway keeps its key
+	PMTID    int64
+	DEPID    int64
+	ARID     int64
+	Inactive bool
+}
+
+// PaymentGatewaySearchResponse lists payment gateways
+type PaymentGatewaySearchResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []PaymentGatewayGrid `json:"records"`
+}
+
+// PaymentGatewayRequest is the request data of the paymentgateway commands
+type PaymentGatewayRequest struct {
+	Record PaymentGatewayGrid
+}
+
+// CardTokenGrid is the UI representation of a saved card
+type CardTokenGrid struct {
+	Recid    int64 `json:"recid"`
+	CTID     int64
+	TCID     int64
+	PGID     int64
+	Brand    string
+	Last4    string
+	ExpMonth int64
+	ExpYear  int64
+}
+
+// CardTokenSearchResponse lists the saved cards of a payor
+type CardTokenSearchResponse struct {
+	Status  string          `json:"status"`
+	Total   int64           `json:"total"`
+	Records []CardTokenGrid `json:"records"`
+}
+
+// GatewayTransactionGrid is the UI representation of a GatewayTransaction
+type GatewayTransactionGrid struct {
+	Recid      int64 `json:"recid"`
+	GTID       int64
+	PGID       int64
+	TCID       int64
+	CTID       int64
+	Type       int64
+	ParentGTID int64
+	TxnID      string
+	Amount     rlib.Money
+	Dt         rlib.JSONDate
+	Status     int64
+	Message    string
+	RCPTID     int64
+	PayoutID   string
+	DID        int64
+}
+
+// GatewayTransactionSearchResponse lists gateway transactions
+type GatewayTransactionSearchResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []GatewayTransactionGrid `json:"records"`
+}
+
+// GatewayTransactionResponse is the result of a charge, refund or chargeback
+type GatewayTransactionResponse struct {
+	Status string                 `json:"status"`
+	Record GatewayTransactionGrid `json:"record"`
+}
+
+// GatewaySettlementGrid is a gateway payout and the Deposit it matched
+type GatewaySettlementGrid struct {
+	Recid        int64 `json:"recid"`
+	PayoutID     string
+	Dt           rlib.JSONDate
+	Amount       rlib.Money // net amount paid out
+	Fee          rlib.Money
+	Gross        rlib.Money // transactions settled
+	Transactions int64      // number of transactions settled
+	Unknown      []string   // gateway transactions not recorded here
+	DID          int64
+}
+
+// GatewaySettlementResponse lists the settlements of a gateway
+type GatewaySettlementResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []GatewaySettlementGrid `json:"records"`
+}
+
+// PayorAmountDueResponse is the balance due of a payor
+type PayorAmountDueResponse struct {
+	Status string     `json:"status"`
+	TCID   int64      `json:"TCID"`
+	Amount rlib.Money `json:"Amount"`
+}
+
+// CardPaymentRequest is the request data of the cardpayment commands. Dt
+// is the date of a payment, refund or chargeback, today if not set. D1 - D2
+// is the range of the list, syncdisputes and settle commands.
+type CardPaymentRequest struct {
+	PGID      int64
+	TCID      int64
+	CTID      int64
+	GTID      int64
+	Amount    rlib.Money // 0 charges the balance due or refunds the rest of the charge
+	Dt        rlib.JSONDate
+	D1        rlib.JSONDate
+	D2        rlib.JSONDate
+	DisputeID string // gateway id of a chargeback entered by hand
+	Card      rlib.GatewayCard
+}
+
+// SvcHandlerPaymentGateway handles the payment gateways of a business. For
+// this call, we expect the URI to contain the BID and the PGID as follows:
+//       0    1                2     3
+// 		/v1/paymentgateway/BID/PGID
+//
+// The request data is a PaymentGatewayRequest. A gateway is not deleted, it
+// is made Inactive.
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerPaymentGateway(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerPaymentGateway"
+	var req PaymentGatewayRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  PGID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getPaymentGateways(w, r, d)
+	case "save":
+		savePaymentGateway(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getPaymentGateways returns the payment gateways of a business
+// wsdoc {
+//  @Title  Payment Gateways
+//	@URL /v1/paymentgateway/:BUI/:PGID
+//  @Method  POST
+//	@Synopsis List the card payment gateways of a business
+//  @Descr  Returns gateway :PGID, or all the gateways of the business if
+//  @Descr  :PGID is 0. API keys are not returned.
+//	@Input PaymentGatewayRequest
+//  @Response PaymentGatewaySearchResponse
+// wsdoc }
+func getPaymentGateways(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getPaymentGateways"
+	var g PaymentGatewaySearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetPaymentGatewaysByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []PaymentGatewayGrid{}
+	for i := 0; i < len(m); i++ {
+		if d.ID > 0 && m[i].PGID != d.ID {
+			continue
+		}
+		var q PaymentGatewayGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].PGID
+		q.APIKey = ""
+		q.Inactive = m[i].FLAGS&rlib.PGInactive != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// savePaymentGateway creates or updates a payment gateway
+// wsdoc {
+//  @Title  Save Payment Gateway
+//	@URL /v1/paymentgateway/:BUI/:PGID
+//  @Method  POST
+//	@Synopsis Configure a card payment gateway
+//  @Description  Saves gateway Record. If PGID is 0 a new gateway is
+//  @Description  created. Charges become receipts with payment type PMTID
+//  @Description  and account rule ARID, paid out to depository DEPID. If
+//  @Description  APIKey is blank an existing gateway keeps its key.
+//	@Input PaymentGatewayRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func savePaymentGateway(w http.ResponseWriter, r *http.Request, d *ServiceData, req *PaymentGatewayRequest) {
+	const funcname = "savePaymentGateway"
+	var (
+		a   rlib.PaymentGateway
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.Record.PGID > 0 {
+		if a, err = rlib.GetPaymentGateway(r.Context(), req.Record.PGID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.PGID == 0 || a.BID != d.BID {
+			SvcErrorReturn(w, fmt.Errorf("payment gateway %d not found", req.Record.PGID), funcname)
+			return
+		}
+	}
+	key := a.APIKey
+	rlib.MigrateStructVals(&req.Record, &a)
+	a.BID = d.BID
+	if len(a.APIKey) == 0 {
+		a.APIKey = key
+	}
+	a.FLAGS &^= rlib.PGInactive
+	if req.Record.Inactive {
+		a.FLAGS |= rlib.PGInactive
+	}
+
+	if errlist := bizlogic.SavePaymentGateway(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.PGID)
+}
+
+// SvcHandlerCardPayment handles card payments made through the payment
+// gateways of a business. For this call, we expect the URI to contain the
+// BID as follows:
+//       0    1             2
+// 		/v1/cardpayment/BID
+//
+// The request data is a CardPaymentRequest. The settlement of the payouts
+// is printed with /v1/report, report RPTgwsettle.
+//
+// The server command can be:
+//      cards
+//      savecard
+//      removecard
+//      due
+//      charge
+//      refund
+//      chargeback
+//      list
+//      syncdisputes
+//      settle
+//-----------------------------------------------------------------------------------
+func SvcHandlerCardPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerCardPayment"
+	var req CardPaymentRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "cards":
+		getCards(w, r, d, &req)
+	case "savecard":
+		saveCard(w, r, d, &req)
+	case "removecard":
+		removeCard(w, r, d, &req)
+	case "due":
+		getPayorAmountDue(w, r, d, &req)
+	case "charge", "refund", "chargeback":
+		cardPaymentTransaction(w, r, d, &req)
+	case "list":
+		getGatewayTransactions(w, r, d, &req)
+	case "syncdisputes":
+		syncGatewayDisputes(w, r, d, &req)
+	case "settle":
+		settleGatewayPayouts(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// cardPaymentDate returns req.Dt, or today if it is not set
+func cardPaymentDate(req *CardPaymentRequest) time.Time {
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		now := time.Now()
+		dt = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return dt
+}
+
+// getCards returns the saved cards of a payor
+// wsdoc {
+//  @Title  Saved Cards
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis List the saved cards of a payor
+//  @Descr  Returns the cards of payor TCID that have not been removed.
+//	@Input CardPaymentRequest
+//  @Response CardTokenSearchResponse
+// wsdoc }
+func getCards(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "getCards"
+	var g CardTokenSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetCardTokensByTCID(r.Context(), req.TCID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []CardTokenGrid{}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q CardTokenGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CTID
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveCard saves a card of a payor at a gateway
+// wsdoc {
+//  @Title  Save Card
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Save a payment card of a payor
+//  @Description  Card is tokenized at gateway PGID and saved as a card of
+//  @Description  payor TCID. Only the gateway's token, the brand, the last
+//  @Description  4 digits and the expiration date are kept. Returns the CTID.
+//	@Input CardPaymentRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveCard(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "saveCard"
+
+	fmt.Printf("Entered %s\n", funcname)
+	pg, errlist := bizlogic.GetActivePaymentGateway(r.Context(), d.BID, req.PGID)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	ct, errlist := bizlogic.SaveCard(r.Context(), rlib.NewPaymentGatewayAPI(&pg), &pg, req.TCID, &req.Card)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, ct.CTID)
+}
+
+// removeCard removes a saved card
+// wsdoc {
+//  @Title  Remove Card
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Remove a saved card
+//  @Desc  Card CTID can no longer be charged. Its transactions are kept.
+//	@Input CardPaymentRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func removeCard(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "removeCard"
+
+	fmt.Printf("Entered %s\n", funcname)
+	ct, err := rlib.GetCardToken(r.Context(), req.CTID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ct.CTID == 0 || ct.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("card %d not found", req.CTID), funcname)
+		return
+	}
+	if errlist := bizlogic.RemoveCard(r.Context(), &ct); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// getPayorAmountDue returns the balance due of a payor
+// wsdoc {
+//  @Title  Payor Amount Due
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Get the amount a payor owes
+//  @Descr  Returns what payor TCID owes on Dt: the unpaid assessments less
+//  @Descr  unallocated funds. This is what a charge of Amount 0 charges.
+//	@Input CardPaymentRequest
+//  @Response PayorAmountDueResponse
+// wsdoc }
+func getPayorAmountDue(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "getPayorAmountDue"
+	var g PayorAmountDueResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	dt := cardPaymentDate(req)
+	amt, err := bizlogic.PayorAmountDue(r.Context(), d.BID, req.TCID, &dt)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.TCID = req.TCID
+	g.Amount = amt
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// cardPaymentTransaction charges a card, or refunds or charges back a charge
+// wsdoc {
+//  @Title  Card Charge, Refund or Chargeback
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Charge a saved card, refund a charge or record a chargeback
+//  @Description  charge: charges Amount to card CTID, or the payor's
+//  @Description  balance due if Amount is 0. The payment becomes a receipt
+//  @Description  on Dt with the gateway transaction id as its document
+//  @Description  number and is allocated to the payor's unpaid assessments.
+//  @Description  A declined charge is recorded and returned as an error.
+//  @Description  The charge is recorded as pending before the card is
+//  @Description  charged. If the payment then cannot be recorded the charge
+//  @Description  is refunded; if that fails it stays pending for review.
+//  @Description  refund: refunds Amount of charge GTID, or the rest of it if
+//  @Description  Amount is 0. The receipt is reversed on Dt and any part not
+//  @Description  refunded is received again. chargeback: records that the
+//  @Description  bank took back Amount of charge GTID in dispute DisputeID.
+//	@Input CardPaymentRequest
+//  @Response GatewayTransactionResponse
+// wsdoc }
+func cardPaymentTransaction(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "cardPaymentTransaction"
+	var (
+		g       GatewayTransactionResponse
+		gt      rlib.GatewayTransaction
+		pg      rlib.PaymentGateway
+		errlist []bizlogic.BizError
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	dt := cardPaymentDate(req)
+	if d.wsSearchReq.Cmd == "charge" {
+		ct, err := rlib.GetCardToken(r.Context(), req.CTID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if ct.CTID == 0 || ct.BID != d.BID {
+			SvcErrorReturn(w, fmt.Errorf("card %d not found", req.CTID), funcname)
+			return
+		}
+		if pg, errlist = bizlogic.GetActivePaymentGateway(r.Context(), d.BID, ct.PGID); len(errlist) == 0 {
+			//------------------------------------------------------------
+			// ChargeCard runs its own transactions, the card must not be
+			// charged inside one that could still be rolled back
+			//------------------------------------------------------------
+			gt, errlist = bizlogic.ChargeCard(r.Context(), rlib.NewPaymentGatewayAPI(&pg), &pg, &ct, req.Amount, &dt)
+		}
+	} else {
+		charge, err := rlib.GetGatewayTransaction(r.Context(), req.GTID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if charge.GTID == 0 || charge.BID != d.BID {
+			SvcErrorReturn(w, fmt.Errorf("gateway transaction %d not found", req.GTID), funcname)
+			return
+		}
+		if d.wsSearchReq.Cmd == "chargeback" {
+			tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			if gt, errlist = bizlogic.RecordChargeback(ctx, &charge, req.DisputeID, req.Amount, &dt); len(errlist) > 0 {
+				tx.Rollback()
+				SvcErrListReturn(w, errlist, funcname)
+				return
+			}
+			if err = tx.Commit(); err != nil {
+				tx.Rollback()
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+		} else {
+			//------------------------------------------------------------
+			// charges made before a gateway was made inactive can still
+			// be refunded through it
+			//------------------------------------------------------------
+			if pg, err = rlib.GetPaymentGateway(r.Context(), charge.PGID); err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			gt, errlist = bizlogic.RefundCharge(r.Context(), rlib.NewPaymentGatewayAPI(&pg), &pg, &charge, req.Amount, &dt)
+		}
+	}
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	//------------------------------------------------------------
+	// a declined charge is kept as a record of the attempt, but
+	// the caller needs to know it failed
+	//------------------------------------------------------------
+	if gt.Status == rlib.GTSTATUSFailed {
+		e := bizlogic.BizError{Errno: bizlogic.GatewayDeclined, Message: fmt.Sprintf(bizlogic.BizErrors[bizlogic.GatewayDeclined].Message, gt.Message)}
+		SvcErrListReturn(w, []bizlogic.BizError{e}, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&gt, &g.Record)
+	g.Record.Recid = gt.GTID
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getGatewayTransactions lists gateway transactions
+// wsdoc {
+//  @Title  Gateway Transactions
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis List card charges, refunds and chargebacks
+//  @Descr  Returns the gateway transactions of payor TCID, or if it is 0
+//  @Descr  those of the business dated in the range D1 - D2.
+//	@Input CardPaymentRequest
+//  @Response GatewayTransactionSearchResponse
+// wsdoc }
+func getGatewayTransactions(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "getGatewayTransactions"
+	var (
+		g   GatewayTransactionSearchResponse
+		m   []rlib.GatewayTransaction
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.TCID > 0 {
+		m, err = rlib.GetGatewayTransactionsByTCID(r.Context(), req.TCID)
+	} else {
+		d1, d2 := time.Time(req.D1), time.Time(req.D2)
+		m, err = rlib.GetGatewayTransactionsByRange(r.Context(), d.BID, &d1, &d2)
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []GatewayTransactionGrid{}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q GatewayTransactionGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].GTID
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// syncGatewayDisputes records the disputes a gateway reports
+// wsdoc {
+//  @Title  Sync Gateway Disputes
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Record the chargebacks reported by a gateway
+//  @Descr  Asks gateway PGID for the disputes opened in the range D1 - D2
+//  @Descr  and records each one not yet recorded as a chargeback on Dt. The
+//  @Descr  GatewayBot does this every day for the last 30 days.
+//	@Input CardPaymentRequest
+//  @Response GatewayTransactionSearchResponse
+// wsdoc }
+func syncGatewayDisputes(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "syncGatewayDisputes"
+	var g GatewayTransactionSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	dt := cardPaymentDate(req)
+	d1, d2 := time.Time(req.D1), time.Time(req.D2)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	pg, errlist := bizlogic.GetActivePaymentGateway(ctx, d.BID, req.PGID)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	dm, err := rlib.NewPaymentGatewayAPI(&pg).Disputes(&d1, &d2)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []GatewayTransactionGrid{}
+	for i := 0; i < len(dm); i++ {
+		gt, errlist := bizlogic.RecordGatewayDispute(ctx, &pg, &dm[i], &dt)
+		if len(errlist) > 0 {
+			tx.Rollback()
+			SvcErrListReturn(w, errlist, funcname)
+			return
+		}
+		if gt.GTID == 0 {
+			continue
+		}
+		var q GatewayTransactionGrid
+		rlib.MigrateStructVals(&gt, &q)
+		q.Recid = gt.GTID
+		g.Records = append(g.Records, q)
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// settleGatewayPayouts matches gateway payouts to deposits
+// wsdoc {
+//  @Title  Settle Gateway Payouts
+//	@URL /v1/cardpayment/:BUI
+//  @Method  POST
+//	@Synopsis Match the payouts of a gateway to deposits
+//  @Descr  Gets the payouts of gateway PGID that arrived in the range
+//  @Descr  D1 - D2 and matches each one to a deposit in the gateway's
+//  @Descr  depository for the gross or net amount dated within 3 days of
+//  @Descr  its arrival. The transactions of each payout are marked with
+//  @Descr  the payout and the deposit.
+//	@Input CardPaymentRequest
+//  @Response GatewaySettlementResponse
+// wsdoc }
+func settleGatewayPayouts(w http.ResponseWriter, r *http.Request, d *ServiceData, req *CardPaymentRequest) {
+	const funcname = "settleGatewayPayouts"
+	var g GatewaySettlementResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1, d2 := time.Time(req.D1), time.Time(req.D2)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	pg, errlist := bizlogic.GetActivePaymentGateway(ctx, d.BID, req.PGID)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	m, err := rlib.GatewaySettlements(ctx, rlib.NewPaymentGatewayAPI(&pg), &pg, &d1, &d2)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if errlist = bizlogic.SaveGatewaySettlements(ctx, m); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []GatewaySettlementGrid{}
+	for i := 0; i < len(m); i++ {
+		q := GatewaySettlementGrid{
+			Recid:        int64(i),
+			PayoutID:     m[i].Payout.ID,
+			Dt:           rlib.JSONDate(m[i].Payout.Dt),
+			Amount:       m[i].Payout.Amount,
+			Fee:          m[i].Payout.Fee,
+			Gross:        m[i].Gross,
+			Transactions: int64(len(m[i].GTIDs)),
+			Unknown:      m[i].Unknown,
+			DID:          m[i].DID,
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,593 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"strings"
+	"time"
+)
+
+// LeaseTemplateGrid is the UI representation of a LeaseTemplate
+type LeaseTemplateGrid struct {
+	Recid          int64 `json:"recid"`
+	LTID           int64
+	BID            int64
+	BUD            rlib.XJSONBud
+	RATID          int64
+	RATemplateName string
+	Title          string
+	Body           string
+	LastModTime    rlib.JSONDateTime
+	LastModBy      int64
+	CreateTS       rlib.JSONDateTime
+	CreateBy       int64
+}
+
+// LeaseTemplateSearchResponse is the response to a search request for
+// LeaseTemplate records
+type LeaseTemplateSearchResponse struct {
+	Status  string              `json:"status"`
+	Total   int64               `json:"total"`
+	Records []LeaseTemplateGrid `json:"records"`
+}
+
+// LeaseTemplateGetResponse is the response to a get request for a single
+// LeaseTemplate
+type LeaseTemplateGetResponse struct {
+	Status string            `json:"status"`
+	Record LeaseTemplateGrid `json:"record"`
+}
+
+// SaveLeaseTemplateInput is the input data format for a Save command
+type SaveLeaseTemplateInput struct {
+	Recid    int64             `json:"recid"`
+	Status   string            `json:"status"`
+	FormName string            `json:"name"`
+	Record   LeaseTemplateGrid `json:"record"`
+}
+
+// LeaseDocGrid is the UI representation of a LeaseDocument. The PDF itself
+// is downloaded with /v1/leasedocfile.
+type LeaseDocGrid struct {
+	Recid    int64 `json:"recid"`
+	LDID     int64
+	BID      int64
+	RAID     int64
+	FlowID   int64
+	LTID     int64
+	FileName string
+	CreateTS rlib.JSONDateTime
+	CreateBy int64
+}
+
+// LeaseDocSearchResponse lists the lease documents of a Rental Agreement
+// version or a flow
+type LeaseDocSearchResponse struct {
+	Status  string         `json:"status"`
+	Total   int64          `json:"total"`
+	Records []LeaseDocGrid `json:"records"`
+}
+
+// LeaseDocResponse is the response to a generate request
+type LeaseDocResponse struct {
+	Status string       `json:"status"`
+	Record LeaseDocGrid `json:"record"`
+}
+
+// LeaseDocRequest selects the flow and the template of a lease document
+// request. FlowID is used when the URI RAID is 0. LTID is only needed for
+// generate if the template of the Rental Agreement's RentalAgreementTemplate
+// is not the one wanted.
+type LeaseDocRequest struct {
+	FlowID int64
+	LTID   int64
+}
+
+// SvcHandlerLeaseTemplate handles the lease templates of a business. For this
+// call, we expect the URI to contain the BID and the LTID as follows:
+//       0    1              2     3
+// 		/v1/leasetemplate/BID/LTID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerLeaseTemplate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerLeaseTemplate"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  LTID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerLeaseTemplates(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("LTID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getLeaseTemplate(w, r, d)
+		}
+	case "save":
+		saveLeaseTemplate(w, r, d)
+	case "delete":
+		deleteLeaseTemplate(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerLeaseTemplates returns the lease templates of business
+// d.BID
+// wsdoc {
+//  @Title  Search Lease Templates
+//	@URL /v1/leasetemplate/:BUI
+//  @Method  POST
+//	@Synopsis Search Lease Templates
+//  @Descr  Return the lease templates of the business sorted by title. Body
+//  @Descr  is not returned.
+//	@Input WebGridSearchRequest
+//  @Response LeaseTemplateSearchResponse
+// wsdoc }
+func SvcSearchHandlerLeaseTemplates(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerLeaseTemplates"
+	var g LeaseTemplateSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetLeaseTemplatesByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q LeaseTemplateGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].LTID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		q.Body = ""
+		rat, err := rlib.GetRentalAgreementTemplate(r.Context(), q.RATID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		q.RATemplateName = rat.RATemplateName
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getLeaseTemplate returns the requested LeaseTemplate. LTID 0 returns a
+// new template with the default body.
+// wsdoc {
+//  @Title  Get Lease Template
+//	@URL /v1/leasetemplate/:BUI/:LTID
+//  @Method  GET
+//	@Synopsis Get a Lease Template
+//  @Description  Return all fields for lease template :LTID. If :LTID is 0
+//  @Description  a template with a default body is returned to start from.
+//	@Input WebGridSearchRequest
+//  @Response LeaseTemplateGetResponse
+// wsdoc }
+func getLeaseTemplate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getLeaseTemplate"
+	var (
+		g   LeaseTemplateGetResponse
+		a   rlib.LeaseTemplate
+		err error
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	if d.ID == 0 {
+		a.BID = d.BID
+		a.Body = rlib.LeaseDefaultBody
+	} else if a, err = rlib.GetLeaseTemplate(r.Context(), d.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.LTID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+		if a.RATID > 0 {
+			rat, err := rlib.GetRentalAgreementTemplate(r.Context(), a.RATID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			g.Record.RATemplateName = rat.RATemplateName
+		}
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveLeaseTemplate creates or updates a LeaseTemplate
+// wsdoc {
+//  @Title  Save Lease Template
+//	@URL /v1/leasetemplate/:BUI/:LTID
+//  @Method  POST
+//	@Synopsis Create or update a Lease Template
+//  @Description  Saves the lease template. RATID is the Rental Agreement
+//  @Description  Template it is the document of; each one has at most one.
+//  @Description  Body is an HTML template, see rlib.LeaseDocData for the merge
+//  @Description  fields and rlib.LeaseDocFuncs for the functions it can use.
+//  @Description  The body is tried on an empty agreement before it is saved.
+//	@Input SaveLeaseTemplateInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveLeaseTemplate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveLeaseTemplate"
+	var (
+		foo SaveLeaseTemplateInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.Title = strings.TrimSpace(f.Title)
+	if len(f.Title) == 0 || len(strings.TrimSpace(f.Body)) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("Title and Body are required"), funcname)
+		return
+	}
+	rat, err := rlib.GetRentalAgreementTemplate(r.Context(), f.RATID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if rat.RATID == 0 || rat.BID != bid {
+		SvcErrorReturn(w, fmt.Errorf("rental agreement template %d not found", f.RATID), funcname)
+		return
+	}
+	other, err := rlib.GetLeaseTemplateByRATID(r.Context(), f.RATID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if other.LTID > 0 && other.LTID != f.LTID {
+		SvcErrorReturn(w, fmt.Errorf("%s already has lease template %d", rat.RATemplateName, other.LTID), funcname)
+		return
+	}
+
+	var a rlib.LeaseTemplate
+	if f.LTID > 0 {
+		if a, err = rlib.GetLeaseTemplate(r.Context(), f.LTID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.LTID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("lease template %d not found", f.LTID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(f, &a)
+	a.BID = bid
+
+	//------------------------------------------------------------------
+	// Catch template errors now rather than when a lease is generated
+	//------------------------------------------------------------------
+	var biz rlib.Business
+	var raf rlib.RAFlowJSONData
+	now := time.Now()
+	sample := rlib.NewLeaseDocData(&biz, 0, &raf, &now)
+	if _, err = rlib.RenderLeaseDocument(a.Body, &sample); err != nil {
+		SvcErrorReturn(w, fmt.Errorf("template error: %s", err.Error()), funcname)
+		return
+	}
+
+	if a.LTID == 0 {
+		err = rlib.InsertLeaseTemplate(r.Context(), &a)
+	} else {
+		err = rlib.UpdateLeaseTemplate(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving lease template: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.LTID)
+}
+
+// deleteLeaseTemplate deletes a LeaseTemplate. Documents already generated
+// from it are kept.
+// wsdoc {
+//  @Title  Delete Lease Template
+//	@URL /v1/leasetemplate/:BUI/:LTID
+//  @Method  POST
+//	@Synopsis Delete a Lease Template
+//  @Desc  This service deletes a lease template. The lease documents that
+//  @Desc  were generated from it are kept.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteLeaseTemplate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteLeaseTemplate"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetLeaseTemplate(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.LTID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("lease template %d not found", del.ID), funcname)
+		return
+	}
+	if err = rlib.DeleteLeaseTemplate(r.Context(), a.LTID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerLeaseDoc handles the lease documents of a Rental Agreement
+// version or of a flow. For this call, we expect the URI to contain the BID
+// and the RAID as follows:
+//       0    1          2     3
+// 		/v1/leasedoc/BID/RAID
+//
+// If RAID is 0 the request data holds the FlowID, see LeaseDocRequest.
+//
+// The server command can be:
+//      get
+//      generate
+//-----------------------------------------------------------------------------------
+func SvcHandlerLeaseDoc(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerLeaseDoc"
+	var req LeaseDocRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RAID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+	if d.ID > 0 {
+		req.FlowID = 0
+	} else if req.FlowID <= 0 {
+		SvcErrorReturn(w, fmt.Errorf("RAID or FlowID is required"), funcname)
+		return
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getLeaseDocs(w, r, d, &req)
+	case "generate":
+		generateLeaseDoc(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getLeaseDocs lists the lease documents of a Rental Agreement version or a
+// flow
+// wsdoc {
+//  @Title  Lease Documents
+//	@URL /v1/leasedoc/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis List the lease documents of a Rental Agreement
+//  @Descr  Returns the lease documents of Rental Agreement version :RAID,
+//  @Descr  newest first. If :RAID is 0, returns the documents generated from
+//  @Descr  flow FlowID that has not been saved as a Rental Agreement yet.
+//	@Input LeaseDocRequest
+//  @Response LeaseDocSearchResponse
+// wsdoc }
+func getLeaseDocs(w http.ResponseWriter, r *http.Request, d *ServiceData, req *LeaseDocRequest) {
+	const funcname = "getLeaseDocs"
+	var (
+		g   LeaseDocSearchResponse
+		m   []rlib.LeaseDocument
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.FlowID > 0 {
+		m, err = rlib.GetLeaseDocumentsByFlowID(r.Context(), req.FlowID)
+	} else {
+		m, err = rlib.GetLeaseDocumentsByRAID(r.Context(), d.ID)
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q LeaseDocGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].LDID
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// generateLeaseDoc generates a lease document
+// wsdoc {
+//  @Title  Generate Lease Document
+//	@URL /v1/leasedoc/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Generate a lease PDF for a Rental Agreement
+//  @Descr  Fills in the lease template with the data of Rental Agreement
+//  @Descr  version :RAID, or of flow FlowID if :RAID is 0, and stores the
+//  @Descr  PDF with the Rental Agreement version. A document generated from
+//  @Descr  a flow is moved to the Rental Agreement version the flow is saved
+//  @Descr  as. The template is LTID if set, otherwise the one for the Rental
+//  @Descr  Agreement's RentalAgreementTemplate. Download the PDF with
+//  @Descr  /v1/leasedocfile/:BUI/:LDID.
+//	@Input LeaseDocRequest
+//  @Response LeaseDocResponse
+// wsdoc }
+func generateLeaseDoc(w http.ResponseWriter, r *http.Request, d *ServiceData, req *LeaseDocRequest) {
+	const funcname = "generateLeaseDoc"
+	var g LeaseDocResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	now := time.Now()
+	a, err := GenerateLeaseDocument(r.Context(), d.BID, d.ID, req.FlowID, req.LTID, &now)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&a, &g.Record)
+	g.Record.Recid = a.LDID
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// GenerateLeaseDocument generates the lease of a Rental Agreement version or
+// of a flow and saves it as a LeaseDocument.
+//
+// INPUTS
+//     ctx    - db context
+//     bid    - the business
+//     raid   - the Rental Agreement version, used if flowid is 0
+//     flowid - the flow, 0 to generate from raid
+//     ltid   - the LeaseTemplate, 0 to use the one of the Rental Agreement's
+//              RentalAgreementTemplate
+//     now    - current time
+//
+// RETURNS
+//     the LeaseDocument
+//     any error encountered
+//-----------------------------------------------------------------------------
+func GenerateLeaseDocument(ctx context.Context, bid, raid, flowid, ltid int64, now *time.Time) (rlib.LeaseDocument, error) {
+	var (
+		a     rlib.LeaseDocument
+		raf   rlib.RAFlowJSONData
+		ratid int64
+		err   error
+	)
+	if flowid > 0 {
+		flow, err := rlib.GetFlow(ctx, flowid)
+		if err != nil {
+			return a, err
+		}
+		if flow.FlowID == 0 || flow.BID != bid || flow.FlowType != rlib.RAFlow {
+			return a, fmt.Errorf("rental agreement flow %d not found", flowid)
+		}
+		if err = json.Unmarshal(flow.Data, &raf); err != nil {
+			return a, err
+		}
+		raid = 0
+		if raf.Meta.RAID > 0 {
+			ra, err := rlib.GetRentalAgreement(ctx, raf.Meta.RAID)
+			if err != nil {
+				return a, err
+			}
+			ratid = ra.RATID
+		}
+	} else {
+		ra, err := rlib.GetRentalAgreement(ctx, raid)
+		if err != nil {
+			return a, err
+		}
+		if ra.RAID == 0 || ra.BID != bid {
+			return a, fmt.Errorf("rental agreement %d not found", raid)
+		}
+		if raf, err = rlib.ConvertRA2Flow(ctx, &ra, false); err != nil {
+			return a, err
+		}
+		ratid = ra.RATID
+	}
+
+	var t rlib.LeaseTemplate
+	if ltid > 0 {
+		t, err = rlib.GetLeaseTemplate(ctx, ltid)
+	} else if ratid > 0 {
+		t, err = rlib.GetLeaseTemplateByRATID(ctx, ratid)
+	}
+	if err != nil {
+		return a, err
+	}
+	if t.LTID == 0 || t.BID != bid {
+		return a, fmt.Errorf("no lease template, select one")
+	}
+
+	var biz rlib.Business
+	if err = rlib.GetBusiness(ctx, bid, &biz); err != nil {
+		return a, err
+	}
+	ld := rlib.NewLeaseDocData(&biz, raid, &raf, now)
+	html, err := rlib.RenderLeaseDocument(t.Body, &ld)
+	if err != nil {
+		return a, err
+	}
+	pdf, err := rrpt.LeaseDocPDF(html)
+	if err != nil {
+		return a, err
+	}
+	a = rlib.LeaseDocument{
+		BID:      bid,
+		RAID:     raid,
+		FlowID:   flowid,
+		LTID:     t.LTID,
+		FileName: rlib.LeaseDocFileName(t.Title, raid, flowid, now),
+		Content:  pdf,
+	}
+	err = rlib.InsertLeaseDocument(ctx, &a)
+	return a, err
+}
+
+// SvcLeaseDocFile downloads a lease document
+// wsdoc {
+//  @Title  Download Lease Document
+//	@URL /v1/leasedocfile/:BUI/:LDID
+//  @Method  GET
+//	@Synopsis Download a lease document
+//  @Descr  Returns the PDF of lease document :LDID.
+//	@Input WebGridSearchRequest
+//  @Response the PDF
+// wsdoc }
+func SvcLeaseDocFile(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcLeaseDocFile"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetLeaseDocument(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.LDID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("lease document %d not found", d.ID), funcname)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", a.FileName))
+	w.Write(a.Content)
+}
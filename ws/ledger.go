@@ -27,9 +27,9 @@ type LedgerGrid struct {
 	Name      string
 	Active    string
 	AllowPost bool
-	Balance   float64
+	Balance   rlib.Money
 	LMDate    string
-	LMAmount  float64
+	LMAmount  rlib.Money
 	LMState   string
 }
 
@@ -103,8 +103,8 @@ func SvcLedgerHandler(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 // GetAccountBalance returns the balance of the account at time dt
 //
 //-----------------------------------------------------------------------------
-func GetAccountBalance(ctx context.Context, bid, lid int64, dt *time.Time) (float64, rlib.LedgerMarker) {
-	var bal float64
+func GetAccountBalance(ctx context.Context, bid, lid int64, dt *time.Time) (rlib.Money, rlib.LedgerMarker) {
+	var bal rlib.Money
 	lm, err := rlib.GetRALedgerMarkerOnOrBeforeDeprecated(ctx, bid, lid, 0, dt) // find nearest ledgermarker, use it as a starting point
 	if err != nil {
 		return bal, lm
@@ -138,7 +138,7 @@ func searchLedgers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var req LedgerGridRequest
 	var rows *sql.Rows
 	// var lm rlib.LedgerMarker
-	var bal float64
+	var bal rlib.Money
 
 	rlib.Console("Entered %s\n", funcname)
 	rlib.Console("record data = %s\n", d.data)
@@ -0,0 +1,62 @@
+package ws
+
+import (
+	"net/http"
+	"rentroll/rlib"
+	"time"
+)
+
+// svcMetricsWriter is the ResponseWriter handed to service handlers by
+// V1ServiceHandler. It remembers whether the handler returned an error so
+// that the request can be counted as a failure.
+type svcMetricsWriter struct {
+	http.ResponseWriter
+	failed bool
+}
+
+// WriteHeader notes any http error status before passing it on
+func (mw *svcMetricsWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		mw.failed = true
+	}
+	mw.ResponseWriter.WriteHeader(code)
+}
+
+// svcMarkFailed flags the request being written to w as a failure if w is
+// being tracked for metrics.
+func svcMarkFailed(w http.ResponseWriter) {
+	if mw, ok := w.(*svcMetricsWriter); ok {
+		mw.failed = true
+	}
+}
+
+// svcRecordMetrics records the outcome of a web service request. Requests
+// for commands that are not in Svcs are counted under "unknown" so that
+// arbitrary URLs cannot create new metrics.
+func svcRecordMetrics(cmd string, start time.Time, mw *svcMetricsWriter) {
+	name := "unknown"
+	for i := 0; i < len(Svcs); i++ {
+		if Svcs[i].Cmd == cmd {
+			name = cmd
+			break
+		}
+	}
+	rlib.SvcMetrics.Record(name, time.Since(start), mw.failed)
+}
+
+// MetricsHandler writes the server metrics in the Prometheus text format.
+// wsdoc {
+//  @Title  Metrics
+//	@URL /metrics
+//  @Method  GET
+//	@Synopsis Server metrics for Prometheus
+//  @Description  Returns web service request counts, latencies and errors,
+//  @Description  cache sizes and hit ratios, active sessions, and tws worker
+//  @Description  run durations and failures.
+//  @Input
+//  @Response Prometheus text exposition format
+// wsdoc }
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	rlib.WriteMetrics(w)
+}
@@ -0,0 +1,224 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// NightAuditRequest is the request data of the nightaudit commands. Dt is
+// the night, see SvcHandlerNightAudit for its default.
+type NightAuditRequest struct {
+	Dt rlib.JSONDate
+}
+
+// NightAuditSummary is the UI representation of the statistics of a night
+type NightAuditSummary struct {
+	Dt           rlib.JSONDate // the night
+	BusinessDate rlib.JSONDate // the next night to audit
+	Locked       bool          // the night audit has locked the day
+	Rooms        int64
+	OutOfOrder   int64
+	Occupied     int64
+	Occupancy    float64 // 0.0 - 1.0
+	ADR          rlib.Money
+	RevPAR       rlib.Money
+	RoomRevenue  rlib.Money
+}
+
+// NightAuditRevenueGrid is the revenue of an account rule on the night
+type NightAuditRevenueGrid struct {
+	Recid  int64 `json:"recid"`
+	ARID   int64
+	Name   string
+	Amount rlib.Money
+}
+
+// NightAuditExceptionGrid is a problem found with a guest in house
+type NightAuditExceptionGrid struct {
+	Recid   int64 `json:"recid"`
+	RESID   int64
+	RAID    int64
+	RID     int64
+	Problem string
+}
+
+// NightAuditResponse is the response to the nightaudit commands
+type NightAuditResponse struct {
+	Status     string                    `json:"status"`
+	Record     NightAuditSummary         `json:"record"`
+	Revenue    []NightAuditRevenueGrid   `json:"revenue"`
+	Exceptions []NightAuditExceptionGrid `json:"exceptions"`
+	NoShows    []ReservationGrid         `json:"noshows"`
+}
+
+// SvcHandlerNightAudit handles the night audit of a hotel. For this call,
+// we expect the URI to contain the BID as follows:
+//       0    1              2
+// 		/v1/nightaudit/BID
+//
+// The night audit is normally run by the NightAuditBot for businesses with
+// NightAudit set in their "general" properties. The printable report is
+// /v1/report, RPTnightaudit.
+//
+// The server command can be:
+//      get
+//      run
+//-----------------------------------------------------------------------------------
+func SvcHandlerNightAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerNightAudit"
+	var req NightAuditRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getNightAudit(w, r, d, &req)
+	case "run":
+		runNightAudit(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getNightAudit returns the night audit report of a night
+// wsdoc {
+//  @Title  Get Night Audit
+//	@URL /v1/nightaudit/:BUI
+//  @Method  POST
+//	@Synopsis Return the night audit report of a night
+//  @Desc  Returns the occupancy, ADR, RevPAR, revenue by account rule,
+//  @Desc  exceptions and no-shows of the night of Dt. If Dt is not set it
+//  @Desc  is the last night audited, or yesterday if none has been.
+//	@Input NightAuditRequest
+//  @Response NightAuditResponse
+// wsdoc }
+func getNightAudit(w http.ResponseWriter, r *http.Request, d *ServiceData, req *NightAuditRequest) {
+	const funcname = "getNightAudit"
+	var xbiz rlib.XBusiness
+
+	fmt.Printf("Entered %s\n", funcname)
+	ctx := r.Context()
+	if err := rlib.GetXBusiness(ctx, d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	bizdt, err := rlib.NightAuditDate(ctx, d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		if bizdt.Equal(rlib.TIME0) {
+			now := time.Now()
+			dt = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		} else {
+			dt = bizdt.AddDate(0, 0, -1)
+		}
+	}
+	rpt, err := rlib.GetNightAuditReport(ctx, &xbiz, &dt)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g := nightAuditResponse(&rpt, &bizdt)
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// runNightAudit runs the night audit
+// wsdoc {
+//  @Title  Run Night Audit
+//	@URL /v1/nightaudit/:BUI
+//  @Method  POST
+//	@Synopsis Run the night audit of the business date
+//  @Desc  Posts the room and tax charges of the guests in house, marks the
+//  @Desc  no-shows and locks the night of Dt, which must be the business
+//  @Desc  date. If Dt is not set it is the business date, or yesterday if
+//  @Desc  the night audit has never been run. Returns the night's report.
+//	@Input NightAuditRequest
+//  @Response NightAuditResponse
+// wsdoc }
+func runNightAudit(w http.ResponseWriter, r *http.Request, d *ServiceData, req *NightAuditRequest) {
+	const funcname = "runNightAudit"
+	var xbiz rlib.XBusiness
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(ctx, d.BID, &xbiz); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		if dt, err = rlib.NightAuditDate(ctx, d.BID); err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if dt.Equal(rlib.TIME0) {
+			now := time.Now()
+			dt = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		}
+	}
+	rpt, errlist := bizlogic.NightAudit(ctx, &xbiz, &dt)
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	bizdt := dt.AddDate(0, 0, 1)
+	g := nightAuditResponse(&rpt, &bizdt)
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// nightAuditResponse returns the UI representation of night audit report
+// rpt. bizdt is the business date.
+func nightAuditResponse(rpt *rlib.NightAuditReport, bizdt *time.Time) NightAuditResponse {
+	var g NightAuditResponse
+	rlib.MigrateStructVals(rpt, &g.Record)
+	g.Record.BusinessDate = rlib.JSONDate(*bizdt)
+
+	g.Revenue = []NightAuditRevenueGrid{}
+	for i := 0; i < len(rpt.Revenue); i++ {
+		q := NightAuditRevenueGrid{Recid: int64(i + 1), ARID: rpt.Revenue[i].ARID, Name: rpt.Revenue[i].Name, Amount: rpt.Revenue[i].Amount}
+		g.Revenue = append(g.Revenue, q)
+	}
+	g.Exceptions = []NightAuditExceptionGrid{}
+	for i := 0; i < len(rpt.InHouse); i++ {
+		res := &rpt.InHouse[i].Res
+		for _, p := range rpt.InHouse[i].Problems {
+			q := NightAuditExceptionGrid{Recid: int64(len(g.Exceptions) + 1), RESID: res.RESID, RAID: res.RAID, RID: res.RID, Problem: p}
+			g.Exceptions = append(g.Exceptions, q)
+		}
+	}
+	g.NoShows = []ReservationGrid{}
+	for i := 0; i < len(rpt.NoShows); i++ {
+		g.NoShows = append(g.NoShows, reservationGrid(&rpt.NoShows[i]))
+	}
+	g.Status = "success"
+	return g
+}
@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+)
+
+// OccupancyStatsRecord is the JSON form of rlib.OccupancyStats
+type OccupancyStatsRecord struct {
+	RTID              int64
+	RentableType      string
+	DtStart           rlib.JSONDate
+	DtStop            rlib.JSONDate
+	Rentables         int64
+	VacantRentables   int64
+	RentableDays      float64
+	OccupiedDays      float64
+	VacantDays        float64
+	PhysicalOccupancy float64
+	GSR               rlib.Money
+	Collected         rlib.Money
+	EconomicOccupancy float64
+	VacancyLoss       rlib.Money
+	Concessions       rlib.Money
+	AvgDaysVacant     float64
+}
+
+// OccupancyTrendRecord holds the stats for one month of the trend
+type OccupancyTrendRecord struct {
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	Types   []OccupancyStatsRecord
+	Total   OccupancyStatsRecord
+}
+
+// OccupancyTrendResponse is the response to an occupancy trend request
+type OccupancyTrendResponse struct {
+	Status  string                 `json:"status"`
+	Total   int64                  `json:"total"`
+	Records []OccupancyTrendRecord `json:"records"`
+}
+
+// SvcOccupancyTrend returns the month-by-month occupancy trend for a business.
+//
+// wsdoc {
+//  @Title  Occupancy Trend
+//	@URL /v1/occupancy/:BUI
+//  @Method  POST
+//	@Synopsis Get the monthly occupancy trend for a business
+//  @Description  Returns physical occupancy, economic occupancy, vacancy loss,
+//  @Description  concessions and average days vacant for each month in the
+//  @Description  range searchDtStart - searchDtStop, totaled for the business
+//  @Description  and broken down by RentableType.
+//	@Input WebGridSearchRequest
+//  @Response OccupancyTrendResponse
+// wsdoc }
+//-----------------------------------------------------------------------------
+func SvcOccupancyTrend(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcOccupancyTrend"
+	var (
+		g    OccupancyTrendResponse
+		xbiz rlib.XBusiness
+	)
+	rlib.Console("Entered %s\n", funcname)
+
+	d1 := d.wsSearchReq.SearchDtStart
+	d2 := d.wsSearchReq.SearchDtStop
+	if !d1.Before(d2) {
+		err := fmt.Errorf("%s: searchDtStart must be before searchDtStop", funcname)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if err := rlib.InitBizInternals(d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	trend, err := rlib.GetOccupancyTrend(r.Context(), &xbiz, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	for i := 0; i < len(trend); i++ {
+		var t OccupancyTrendRecord
+		t.DtStart = rlib.JSONDate(trend[i].DtStart)
+		t.DtStop = rlib.JSONDate(trend[i].DtStop)
+		rlib.MigrateStructVals(&trend[i].Total, &t.Total)
+		for j := 0; j < len(trend[i].Types); j++ {
+			var s OccupancyStatsRecord
+			rlib.MigrateStructVals(&trend[i].Types[j], &s)
+			t.Types = append(t.Types, s)
+		}
+		g.Records = append(g.Records, t)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,570 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// PctRentGrid is the UI representation of the percentage rent terms of a
+// Rental Agreement
+type PctRentGrid struct {
+	Recid          int64 `json:"recid"`
+	PRID           int64
+	BID            int64
+	BUD            rlib.XJSONBud
+	RAID           int64
+	BreakpointType int64
+	BaseARID       int64
+	OverageARID    int64
+	LastModTime    rlib.JSONDateTime
+	LastModBy      int64
+	CreateTS       rlib.JSONDateTime
+	CreateBy       int64
+}
+
+// PctRentTierGrid is the UI representation of a PctRentTier
+type PctRentTierGrid struct {
+	Recid      int64 `json:"recid"`
+	PRTID      int64
+	Breakpoint rlib.Money
+	Rate       float64
+}
+
+// PctRentResponse is the response to a get request for the percentage rent
+// terms of a Rental Agreement
+type PctRentResponse struct {
+	Status string            `json:"status"`
+	Record PctRentGrid       `json:"record"`
+	Tiers  []PctRentTierGrid `json:"tiers"`
+}
+
+// SavePctRentInput is the input data format for a Save command
+type SavePctRentInput struct {
+	Recid    int64             `json:"recid"`
+	Status   string            `json:"status"`
+	FormName string            `json:"name"`
+	Record   PctRentGrid       `json:"record"`
+	Tiers    []PctRentTierGrid `json:"tiers"`
+}
+
+// SalesReportGrid is the UI representation of a SalesReport
+type SalesReportGrid struct {
+	Recid       int64 `json:"recid"`
+	SRID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	RAID        int64
+	DtStart     rlib.JSONDate
+	DtStop      rlib.JSONDate
+	GrossSales  rlib.Money
+	Breakpoint  rlib.Money
+	Overage     rlib.Money
+	ASMID       int64
+	Comment     string
+	FLAGS       uint64
+	Computed    bool
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// SalesReportSearchResponse lists the sales reports of a Rental Agreement
+type SalesReportSearchResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []SalesReportGrid `json:"records"`
+}
+
+// SalesReportGetResponse is the response to a get request for a single
+// SalesReport
+type SalesReportGetResponse struct {
+	Status string          `json:"status"`
+	Record SalesReportGrid `json:"record"`
+}
+
+// SaveSalesReportInput is the input data format for a Save command
+type SaveSalesReportInput struct {
+	Recid    int64           `json:"recid"`
+	Status   string          `json:"status"`
+	FormName string          `json:"name"`
+	Record   SalesReportGrid `json:"record"`
+}
+
+// SalesReportRequest is the request data of the salesreport get and compute
+// commands. RAID selects the reports listed when the URI SRID is 0. Dt is
+// the date of the overage assessment made by compute, today if not set.
+type SalesReportRequest struct {
+	RAID int64
+	Dt   rlib.JSONDate
+}
+
+// SvcHandlerPctRent handles the percentage rent terms of a Rental Agreement.
+// For this call, we expect the URI to contain the BID and the RAID as
+// follows:
+//       0    1          2     3
+// 		/v1/pctrent/BID/RAID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerPctRent(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerPctRent"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RAID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 {
+			err := fmt.Errorf("RAID is required but was not specified")
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		getPctRent(w, r, d)
+	case "save":
+		savePctRent(w, r, d)
+	case "delete":
+		deletePctRent(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getPctRent returns the percentage rent terms of a Rental Agreement
+// wsdoc {
+//  @Title  Get Percentage Rent
+//	@URL /v1/pctrent/:BUI/:RAID
+//  @Method  GET
+//	@Synopsis Get the percentage rent terms of a Rental Agreement
+//  @Description  Return the percentage rent terms of Rental Agreement :RAID
+//  @Description  and their tiers, lowest breakpoint first. PRID is 0 if it
+//  @Description  does not pay percentage rent.
+//	@Input WebGridSearchRequest
+//  @Response PctRentResponse
+// wsdoc }
+func getPctRent(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getPctRent"
+	var g PctRentResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetPctRentByRAID(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Tiers = []PctRentTierGrid{}
+	if a.PRID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.PRID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+		m, err := rlib.GetPctRentTiers(r.Context(), a.PRID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		for i := 0; i < len(m); i++ {
+			var q PctRentTierGrid
+			rlib.MigrateStructVals(&m[i], &q)
+			q.Recid = m[i].PRTID
+			g.Tiers = append(g.Tiers, q)
+		}
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// savePctRent creates or updates the percentage rent terms of a Rental
+// Agreement
+// wsdoc {
+//  @Title  Save Percentage Rent
+//	@URL /v1/pctrent/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Create or update the percentage rent terms of a Rental Agreement
+//  @Description  Saves the percentage rent terms and replaces their tiers.
+//  @Description  BreakpointType is 1 for a natural breakpoint, computed from
+//  @Description  the BaseARID assessments, or 2 for fixed breakpoints. Each
+//  @Description  tier's Breakpoint is an annual amount and Rate a fraction,
+//  @Description  0.06 for 6%. The overage is assessed with OverageARID.
+//	@Input SavePctRentInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func savePctRent(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "savePctRent"
+	var (
+		foo SavePctRentInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetPctRentByRAID(ctx, f.RAID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.PRID > 0 && a.BID != bid {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("percentage rent %d not found", a.PRID), funcname)
+		return
+	}
+	prid := a.PRID // one set of terms per Rental Agreement
+	rlib.MigrateStructVals(f, &a)
+	a.PRID = prid
+	a.BID = bid
+
+	var tiers []rlib.PctRentTier
+	for i := 0; i < len(foo.Tiers); i++ {
+		tiers = append(tiers, rlib.PctRentTier{Breakpoint: foo.Tiers[i].Breakpoint, Rate: foo.Tiers[i].Rate})
+	}
+	if errlist := bizlogic.SavePctRent(ctx, &a, tiers); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.PRID)
+}
+
+// deletePctRent deletes the percentage rent terms of a Rental Agreement
+// wsdoc {
+//  @Title  Delete Percentage Rent
+//	@URL /v1/pctrent/:BUI/:RAID
+//  @Method  POST
+//	@Synopsis Delete the percentage rent terms of a Rental Agreement
+//  @Desc  This service deletes the percentage rent terms of Rental Agreement
+//  @Desc  :RAID and their tiers. Its sales reports and the overage already
+//  @Desc  assessed are kept.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deletePctRent(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deletePctRent"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetPctRentByRAID(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.PRID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("Rental Agreement %d has no percentage rent terms", d.ID), funcname)
+		return
+	}
+	if err = rlib.DeletePctRentTiers(ctx, a.PRID); err == nil {
+		err = rlib.DeletePctRent(ctx, a.PRID)
+	}
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerSalesReport handles the gross sales reported by the tenants
+// that pay percentage rent. For this call, we expect the URI to contain the
+// BID and the SRID as follows:
+//       0    1              2     3
+// 		/v1/salesreport/BID/SRID
+//
+// The overage is normally computed and assessed by the PctRentBot once the
+// period has ended. The report of all tenants is /v1/report, RPTpctrent.
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//      compute
+//-----------------------------------------------------------------------------------
+func SvcHandlerSalesReport(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerSalesReport"
+	var req SalesReportRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  SRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if d.wsSearchReq.Cmd != "save" && len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 {
+			SvcSearchHandlerSalesReports(w, r, d, &req)
+		} else {
+			getSalesReport(w, r, d)
+		}
+	case "save":
+		saveSalesReport(w, r, d)
+	case "delete":
+		deleteSalesReport(w, r, d)
+	case "compute":
+		computeSalesReport(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerSalesReports returns the sales reports of a Rental
+// Agreement
+// wsdoc {
+//  @Title  Search Sales Reports
+//	@URL /v1/salesreport/:BUI
+//  @Method  POST
+//	@Synopsis List the sales reports of a Rental Agreement
+//  @Descr  Return the sales reports of Rental Agreement RAID, latest period
+//  @Descr  first.
+//	@Input SalesReportRequest
+//  @Response SalesReportSearchResponse
+// wsdoc }
+func SvcSearchHandlerSalesReports(w http.ResponseWriter, r *http.Request, d *ServiceData, req *SalesReportRequest) {
+	const funcname = "SvcSearchHandlerSalesReports"
+	var g SalesReportSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetSalesReportsByRAID(r.Context(), req.RAID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []SalesReportGrid{}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		g.Records = append(g.Records, salesReportGrid(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// salesReportGrid returns the UI representation of sales report a
+func salesReportGrid(a *rlib.SalesReport) SalesReportGrid {
+	var q SalesReportGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.SRID
+	q.BUD = rlib.GetBUDFromBIDList(a.BID)
+	q.Computed = a.FLAGS&rlib.SALESRPTComputed != 0
+	return q
+}
+
+// getSalesReport returns the requested SalesReport
+// wsdoc {
+//  @Title  Get Sales Report
+//	@URL /v1/salesreport/:BUI/:SRID
+//  @Method  GET
+//	@Synopsis Get a Sales Report
+//  @Description  Return all fields for sales report :SRID
+//	@Input WebGridSearchRequest
+//  @Response SalesReportGetResponse
+// wsdoc }
+func getSalesReport(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getSalesReport"
+	var g SalesReportGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetSalesReport(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.SRID > 0 && a.BID == d.BID {
+		g.Record = salesReportGrid(&a)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveSalesReport creates or updates a SalesReport
+// wsdoc {
+//  @Title  Save Sales Report
+//	@URL /v1/salesreport/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Create or update a Sales Report
+//  @Description  Saves the gross sales a tenant reported for DtStart -
+//  @Description  DtStop; DtStop is not included. Periods of a Rental
+//  @Description  Agreement cannot overlap. A report whose overage has been
+//  @Description  assessed cannot be changed.
+//	@Input SaveSalesReportInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveSalesReport(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveSalesReport"
+	var (
+		foo SaveSalesReportInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.SalesReport
+	if f.SRID > 0 {
+		if a, err = rlib.GetSalesReport(r.Context(), f.SRID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.SRID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("sales report %d not found", f.SRID), funcname)
+			return
+		}
+	}
+	a.BID = bid
+	a.RAID = f.RAID
+	a.DtStart = time.Time(f.DtStart)
+	a.DtStop = time.Time(f.DtStop)
+	a.GrossSales = f.GrossSales
+	a.Comment = strings.TrimSpace(f.Comment)
+	if errlist := bizlogic.SaveSalesReport(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.SRID)
+}
+
+// deleteSalesReport deletes a SalesReport
+// wsdoc {
+//  @Title  Delete Sales Report
+//	@URL /v1/salesreport/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Delete a Sales Report
+//  @Desc  This service deletes sales report :SRID. A report whose overage
+//  @Desc  has been assessed cannot be deleted.
+//	@Input SalesReportRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteSalesReport(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteSalesReport"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetSalesReport(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.SRID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("sales report %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteSalesReport(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// computeSalesReport computes and assesses the percentage rent of a sales
+// report without waiting for the PctRentBot
+// wsdoc {
+//  @Title  Compute Sales Report
+//	@URL /v1/salesreport/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Compute the percentage rent of a Sales Report
+//  @Desc  Computes the breakpoint and the overage rent of sales report
+//  @Desc  :SRID. If any is due, a one time assessment dated Dt is added to
+//  @Desc  the Rental Agreement.
+//	@Input SalesReportRequest
+//  @Response SalesReportGetResponse
+// wsdoc }
+func computeSalesReport(w http.ResponseWriter, r *http.Request, d *ServiceData, req *SalesReportRequest) {
+	const funcname = "computeSalesReport"
+	var g SalesReportGetResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetSalesReport(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.SRID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("sales report %d not found", d.ID), funcname)
+		return
+	}
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		now := time.Now()
+		dt = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if errlist := bizlogic.ComputePercentageRent(ctx, &a, &dt); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = salesReportGrid(&a)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
@@ -0,0 +1,681 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"net/url"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"strings"
+	"time"
+)
+
+// PortalCookieName is the name of the cookie that holds a tenant portal
+// session token. It is separate from the staff session cookie.
+var PortalCookieName = string("rrportal")
+
+// PortalLoginData is the username and password a tenant logs in with
+type PortalLoginData struct {
+	User string `json:"user"`
+	Pass string `json:"pass"`
+}
+
+// PortalLoginResponse is the response to a successful portal login. Clients
+// that do not use cookies send the Token in an "Authorization: Bearer"
+// header.
+type PortalLoginResponse struct {
+	Status string `json:"status"`
+	Token  string
+	TCID   int64
+	Name   string
+	Expire rlib.JSONDateTime
+}
+
+// PortalRA is a Rental Agreement of the tenant and its current balance
+type PortalRA struct {
+	Recid          int64 `json:"recid"`
+	RAID           int64
+	AgreementStart rlib.JSONDate
+	AgreementStop  rlib.JSONDate
+	Rentables      string // names of the rentables, comma separated
+	Balance        rlib.Money
+}
+
+// PortalRAResponse lists the Rental Agreements of the tenant
+type PortalRAResponse struct {
+	Status  string     `json:"status"`
+	Total   int64      `json:"total"`
+	Records []PortalRA `json:"records"`
+}
+
+// PortalContact is the contact information a tenant may change
+type PortalContact struct {
+	TCID           int64
+	FirstName      string // read only
+	LastName       string // read only
+	PrimaryEmail   string
+	SecondaryEmail string
+	WorkPhone      string
+	CellPhone      string
+	Address        string
+	Address2       string
+	City           string
+	State          string
+	PostalCode     string
+	Country        string
+}
+
+// PortalContactResponse is the response to a contact get
+type PortalContactResponse struct {
+	Status string        `json:"status"`
+	Record PortalContact `json:"record"`
+}
+
+// PortalContactSave is the input for a contact save
+type PortalContactSave struct {
+	Cmd    string        `json:"cmd"`
+	Record PortalContact `json:"record"`
+}
+
+// PortalVehicle is a vehicle of the tenant
+type PortalVehicle struct {
+	Recid               int64 `json:"recid"`
+	VID                 int64
+	VehicleType         string
+	VehicleMake         string
+	VehicleModel        string
+	VehicleColor        string
+	VehicleYear         int64
+	VIN                 string
+	LicensePlateState   string
+	LicensePlateNumber  string
+	ParkingPermitNumber string // read only, assigned by the office
+}
+
+// PortalVehicleResponse lists the vehicles of the tenant
+type PortalVehicleResponse struct {
+	Status  string          `json:"status"`
+	Total   int64           `json:"total"`
+	Records []PortalVehicle `json:"records"`
+}
+
+// PortalVehicleSave is the input for a vehicle save
+type PortalVehicleSave struct {
+	Cmd    string        `json:"cmd"`
+	Record PortalVehicle `json:"record"`
+}
+
+// PortalMaintRequest is a maintenance request of the tenant
+type PortalMaintRequest struct {
+	Recid   int64 `json:"recid"`
+	MRQID   int64
+	RAID    int64
+	RID     int64
+	Dt      rlib.JSONDateTime
+	Subject string
+	Descr   string
+	Status  int64 // read only. 0 = new, 1 = in progress, 2 = closed
+}
+
+// PortalMaintResponse lists the maintenance requests of the tenant
+type PortalMaintResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []PortalMaintRequest `json:"records"`
+}
+
+// PortalMaintSave is the input for a new maintenance request
+type PortalMaintSave struct {
+	Cmd    string             `json:"cmd"`
+	Record PortalMaintRequest `json:"record"`
+}
+
+// portalRequest is the state of a tenant portal request after the session
+// has been found
+type portalRequest struct {
+	pu    rlib.PortalUser // the tenant's credential
+	raids []int64         // the Rental Agreements the tenant may see
+}
+
+// SvcPortal handles the tenant portal. Tenants have their own credentials
+// (see PortalUser) and can only reach the data of the Rental Agreements for
+// which they are a payor. For this call, we expect the URI to contain the
+// BID, the portal command, and for some commands an ID, as follows:
+//       0    1      2     3      4
+// 		/v1/portal/BUI/command/ID
+//
+// The portal commands are:
+//      login
+//      logoff
+//      balance
+//      statement/RAID
+//      receipt/RCPTID
+//      contact
+//      vehicles
+//      maint
+//-----------------------------------------------------------------------------------
+func SvcPortal(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPortal"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  portal cmd = %s\n", d.wsSearchReq.Cmd, d.BID, d.DetVal)
+
+	//------------------------------------------------------------------
+	// The staff session, if any, plays no part in the portal. All db
+	// work is done as the TenantPortalBot.
+	//------------------------------------------------------------------
+	r = r.WithContext(rlib.PortalContext(r.Context()))
+	now := time.Now()
+
+	if d.DetVal == "login" {
+		portalLogin(w, r, d, &now)
+		return
+	}
+
+	var p portalRequest
+	var err error
+	p.pu, err = rlib.PortalSession(r.Context(), portalToken(r), &now)
+	if err == nil && p.pu.BID != d.BID {
+		err = rlib.ErrPortalSession
+	}
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if p.raids, err = rlib.PortalRentalAgreements(r.Context(), &p.pu); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	switch d.DetVal {
+	case "logoff":
+		portalLogoff(w, r, d, &p)
+	case "balance":
+		portalBalance(w, r, d, &p, &now)
+	case "statement":
+		portalStatement(w, r, d, &p)
+	case "receipt":
+		portalReceipt(w, r, d, &p)
+	case "contact":
+		portalContact(w, r, d, &p)
+	case "vehicles":
+		portalVehicles(w, r, d, &p)
+	case "maint":
+		portalMaint(w, r, d, &p, &now)
+	default:
+		err := fmt.Errorf("Unhandled portal command: %s", d.DetVal)
+		SvcErrorReturn(w, err, funcname)
+	}
+}
+
+// portalToken returns the portal session token from the request. It is
+// taken from the portal cookie or an "Authorization: Bearer" header.
+func portalToken(r *http.Request) string {
+	if c, err := r.Cookie(PortalCookieName); err == nil && len(c.Value) > 0 {
+		return c.Value
+	}
+	h := r.Header.Get("Authorization")
+	if strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(h[len("Bearer "):])
+	}
+	return ""
+}
+
+// portalID returns the ID that follows the portal command in the URI, or 0
+// if there is none
+func portalID(d *ServiceData) int64 {
+	if len(d.pathElements) < 5 {
+		return 0
+	}
+	id, err := rlib.IntFromString(d.pathElements[4], "bad request integer value")
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// canSeeRA returns true if the tenant may see Rental Agreement raid
+func (p *portalRequest) canSeeRA(raid int64) bool {
+	return raid > 0 && rlib.Int64InSlice(raid, p.raids)
+}
+
+// portalLogin logs a tenant in to the portal
+// wsdoc {
+//  @Title  Tenant Portal Login
+//	@URL /v1/portal/:BUI/login
+//  @Method  POST
+//	@Synopsis Log in to the tenant portal
+//  @Descr  Checks the tenant's username and password. If they are good a
+//  @Descr  session is started, its token is set in the rrportal cookie and
+//  @Descr  returned in the response. The login is locked after 5 consecutive
+//  @Descr  failures and must be reset by the office.
+//	@Input PortalLoginData
+//  @Response PortalLoginResponse
+// wsdoc }
+func portalLogin(w http.ResponseWriter, r *http.Request, d *ServiceData, now *time.Time) {
+	const funcname = "portalLogin"
+	var a PortalLoginData
+	var g PortalLoginResponse
+
+	// d.data has the tenant's password, do not print it
+	if err := json.Unmarshal([]byte(d.data), &a); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	token, pu, err := rlib.PortalLogin(r.Context(), d.BID, a.User, a.Pass, now)
+	if err != nil {
+		if err == rlib.ErrPortalLogin || err == rlib.ErrPortalLocked {
+			rlib.Ulog("portal login failed for user %q in business %d: %s\n", a.User, d.BID, err.Error())
+		}
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	var t rlib.Transactant
+	if err = rlib.GetTransactant(r.Context(), pu.TCID, &t); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	cookie := http.Cookie{Name: PortalCookieName, Value: token, Expires: pu.TokenExpire, Path: "/", HttpOnly: true}
+	http.SetCookie(w, &cookie) // a cookie cannot be set after writing anything to a response writer
+	rlib.Ulog("portal user %s (TCID %d) logged in to business %d\n", pu.Username, pu.TCID, d.BID)
+
+	g.Status = "success"
+	g.Token = token
+	g.TCID = pu.TCID
+	g.Name = t.FirstName
+	if len(t.PreferredName) > 0 {
+		g.Name = t.PreferredName
+	}
+	g.Expire = rlib.JSONDateTime(pu.TokenExpire)
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// portalLogoff ends the tenant's portal session
+// wsdoc {
+//  @Title  Tenant Portal Logoff
+//	@URL /v1/portal/:BUI/logoff
+//  @Method  POST
+//	@Synopsis End the tenant portal session
+//  @Descr  Ends the session and clears the rrportal cookie.
+//	@Input
+//  @Response SvcStatusResponse
+// wsdoc }
+func portalLogoff(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest) {
+	const funcname = "portalLogoff"
+	if err := rlib.PortalLogoff(r.Context(), &p.pu); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	cookie := http.Cookie{Name: PortalCookieName, Value: "", Expires: rlib.TIME0, MaxAge: -1, Path: "/", HttpOnly: true}
+	http.SetCookie(w, &cookie)
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// portalBalance returns the tenant's Rental Agreements and their balances
+// wsdoc {
+//  @Title  Tenant Portal Balance
+//	@URL /v1/portal/:BUI/balance
+//  @Method  GET
+//	@Synopsis Get the tenant's current balances
+//  @Descr  Returns each Rental Agreement for which the tenant is a payor
+//  @Descr  along with its rentables and its balance as of now.
+//	@Input
+//  @Response PortalRAResponse
+// wsdoc }
+func portalBalance(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest, now *time.Time) {
+	const funcname = "portalBalance"
+	var g PortalRAResponse
+
+	for i := 0; i < len(p.raids); i++ {
+		ra, err := rlib.GetRentalAgreement(r.Context(), p.raids[i])
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		bal, err := rlib.GetRAIDBalance(r.Context(), ra.RAID, now)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		rars, err := rlib.GetRentalAgreementRentables(r.Context(), ra.RAID, &ra.AgreementStart, &ra.AgreementStop)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		var names []string
+		for j := 0; j < len(rars); j++ {
+			rnt, err := rlib.GetRentable(r.Context(), rars[j].RID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			names = append(names, rnt.RentableName)
+		}
+		q := PortalRA{
+			Recid:          ra.RAID,
+			RAID:           ra.RAID,
+			AgreementStart: rlib.JSONDate(ra.AgreementStart),
+			AgreementStop:  rlib.JSONDate(ra.AgreementStop),
+			Rentables:      strings.Join(names, ", "),
+			Balance:        bal,
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// portalStatement returns the statement of one of the tenant's Rental
+// Agreements
+// wsdoc {
+//  @Title  Tenant Portal Statement
+//	@URL /v1/portal/:BUI/statement/:RAID
+//  @Method  POST
+//	@Synopsis Get a statement for one of the tenant's Rental Agreements
+//  @Descr  Returns the assessments and receipts of Rental Agreement :RAID
+//  @Descr  for the searchDtStart - searchDtStop range. The tenant must be a
+//  @Descr  payor on the Rental Agreement.
+//	@Input WebGridSearchRequest
+//  @Response StmtDetailResponse
+// wsdoc }
+func portalStatement(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest) {
+	const funcname = "portalStatement"
+	raid := portalID(d)
+	if !p.canSeeRA(raid) {
+		SvcErrorReturn(w, fmt.Errorf("Rental Agreement %d not found", raid), funcname)
+		return
+	}
+	d.ID = raid
+	SvcStatementDetail(w, r, d)
+}
+
+// portalReceipt returns one of the tenant's receipts. The output format
+// is set by the rof query parameter, the default is PDF.
+// wsdoc {
+//  @Title  Tenant Portal Receipt
+//	@URL /v1/portal/:BUI/receipt/:RCPTID
+//  @Method  GET
+//	@Synopsis Download a receipt
+//  @Descr  Returns receipt :RCPTID. The tenant must be the payor of the
+//  @Descr  receipt or a payor of its Rental Agreement.
+//	@Input
+//  @Response the receipt
+// wsdoc }
+func portalReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest) {
+	const funcname = "portalReceipt"
+	var (
+		ui   rrpt.ReportContext
+		xbiz rlib.XBusiness
+	)
+	id := portalID(d)
+	rcpt, err := rlib.GetReceipt(r.Context(), id)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if rcpt.RCPTID == 0 || rcpt.BID != p.pu.BID || (rcpt.TCID != p.pu.TCID && !p.canSeeRA(rcpt.RAID)) {
+		SvcErrorReturn(w, fmt.Errorf("receipt %d not found", id), funcname)
+		return
+	}
+	if err = rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	//------------------------------------------------------------------
+	// Only the output format is taken from the query. The tenant does
+	// not get to pick the report or its parameters.
+	//------------------------------------------------------------------
+	ui.ID = rcpt.RCPTID
+	ui.D1 = rcpt.Dt
+	ui.D2 = rcpt.Dt.AddDate(0, 0, 1)
+	ui.ReportOutputFormat = gotable.TABLEOUTPDF
+	if rof, ok := rlib.StringToInt(r.URL.Query().Get("rof")); ok {
+		switch rof {
+		case gotable.TABLEOUTHTML, gotable.TABLEOUTPDF:
+			ui.ReportOutputFormat = rof
+		}
+	}
+	ui.PDFPageSizeUnit = "in"
+	qp := url.Values{}
+	v1ReportHandler(r.Context(), "RPTrcpt", &xbiz, &ui, w, &qp)
+}
+
+// portalContact gets or saves the tenant's contact information
+// wsdoc {
+//  @Title  Tenant Portal Contact Information
+//	@URL /v1/portal/:BUI/contact
+//  @Method  POST
+//	@Synopsis Get or update the tenant's contact information
+//  @Descr  With cmd "get" returns the tenant's email addresses, phone
+//  @Descr  numbers and address. With cmd "save" updates them. The name
+//  @Descr  cannot be changed here.
+//	@Input PortalContactSave
+//  @Response PortalContactResponse
+// wsdoc }
+func portalContact(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest) {
+	const funcname = "portalContact"
+	var t rlib.Transactant
+	if err := rlib.GetTransactant(r.Context(), p.pu.TCID, &t); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		var g PortalContactResponse
+		rlib.MigrateStructVals(&t, &g.Record)
+		g.Status = "success"
+		SvcWriteResponse(d.BID, &g, w)
+	case "save":
+		var foo PortalContactSave
+		if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		c := foo.Record
+		t.PrimaryEmail = strings.TrimSpace(c.PrimaryEmail)
+		t.SecondaryEmail = strings.TrimSpace(c.SecondaryEmail)
+		t.WorkPhone = strings.TrimSpace(c.WorkPhone)
+		t.CellPhone = strings.TrimSpace(c.CellPhone)
+		t.Address = strings.TrimSpace(c.Address)
+		t.Address2 = strings.TrimSpace(c.Address2)
+		t.City = strings.TrimSpace(c.City)
+		t.State = strings.TrimSpace(c.State)
+		t.PostalCode = strings.TrimSpace(c.PostalCode)
+		t.Country = strings.TrimSpace(c.Country)
+		if err := rlib.UpdateTransactant(r.Context(), &t); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		rlib.Ulog("portal user %s (TCID %d) updated contact information\n", p.pu.Username, p.pu.TCID)
+		SvcWriteSuccessResponse(d.BID, w)
+	default:
+		SvcErrorReturn(w, fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd), funcname)
+	}
+}
+
+// portalVehicles lists, saves or deletes the tenant's vehicles
+// wsdoc {
+//  @Title  Tenant Portal Vehicles
+//	@URL /v1/portal/:BUI/vehicles/:VID
+//  @Method  POST
+//	@Synopsis Manage the tenant's vehicles
+//  @Descr  With cmd "get" returns the tenant's vehicles. With cmd "save"
+//  @Descr  adds a vehicle if VID is 0 or updates it. With cmd "delete"
+//  @Descr  removes vehicle :VID. Parking permits are assigned by the
+//  @Descr  office and cannot be changed here.
+//	@Input PortalVehicleSave
+//  @Response PortalVehicleResponse
+// wsdoc }
+func portalVehicles(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest) {
+	const funcname = "portalVehicles"
+	m, err := rlib.GetVehiclesByTransactant(r.Context(), p.pu.TCID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	find := func(vid int64) (rlib.Vehicle, bool) {
+		for i := 0; i < len(m); i++ {
+			if m[i].VID == vid && m[i].BID == p.pu.BID {
+				return m[i], true
+			}
+		}
+		return rlib.Vehicle{}, false
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		var g PortalVehicleResponse
+		for i := 0; i < len(m); i++ {
+			if m[i].BID != p.pu.BID {
+				continue
+			}
+			var q PortalVehicle
+			rlib.MigrateStructVals(&m[i], &q)
+			q.Recid = m[i].VID
+			g.Records = append(g.Records, q)
+		}
+		g.Total = int64(len(g.Records))
+		g.Status = "success"
+		SvcWriteResponse(d.BID, &g, w)
+	case "save":
+		var foo PortalVehicleSave
+		if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		var a rlib.Vehicle
+		if foo.Record.VID > 0 {
+			var ok bool
+			if a, ok = find(foo.Record.VID); !ok {
+				SvcErrorReturn(w, fmt.Errorf("vehicle %d not found", foo.Record.VID), funcname)
+				return
+			}
+		} else {
+			a.BID = p.pu.BID
+			a.TCID = p.pu.TCID
+			a.DtStart = time.Now()
+			a.DtStop = rlib.ENDOFTIME
+		}
+		v := foo.Record
+		a.VehicleType = v.VehicleType
+		a.VehicleMake = v.VehicleMake
+		a.VehicleModel = v.VehicleModel
+		a.VehicleColor = v.VehicleColor
+		a.VehicleYear = v.VehicleYear
+		a.VIN = v.VIN
+		a.LicensePlateState = v.LicensePlateState
+		a.LicensePlateNumber = v.LicensePlateNumber
+		if a.VID > 0 {
+			err = rlib.UpdateVehicle(r.Context(), &a)
+		} else {
+			a.VID, err = rlib.InsertVehicle(r.Context(), &a)
+		}
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		SvcWriteSuccessResponseWithID(d.BID, w, a.VID)
+	case "delete":
+		vid := portalID(d)
+		if _, ok := find(vid); !ok {
+			SvcErrorReturn(w, fmt.Errorf("vehicle %d not found", vid), funcname)
+			return
+		}
+		if err = rlib.DeleteVehicle(r.Context(), vid); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		SvcWriteSuccessResponse(d.BID, w)
+	default:
+		SvcErrorReturn(w, fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd), funcname)
+	}
+}
+
+// portalMaint lists or submits the tenant's maintenance requests
+// wsdoc {
+//  @Title  Tenant Portal Maintenance Requests
+//	@URL /v1/portal/:BUI/maint
+//  @Method  POST
+//	@Synopsis List or submit maintenance requests
+//  @Descr  With cmd "get" returns the tenant's maintenance requests, most
+//  @Descr  recent first. With cmd "save" submits a new request for a
+//  @Descr  rentable of one of the tenant's Rental Agreements.
+//	@Input PortalMaintSave
+//  @Response PortalMaintResponse
+// wsdoc }
+func portalMaint(w http.ResponseWriter, r *http.Request, d *ServiceData, p *portalRequest, now *time.Time) {
+	const funcname = "portalMaint"
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		var g PortalMaintResponse
+		m, err := rlib.GetMaintRequestsByTCID(r.Context(), p.pu.BID, p.pu.TCID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		for i := 0; i < len(m); i++ {
+			var q PortalMaintRequest
+			rlib.MigrateStructVals(&m[i], &q)
+			q.Recid = m[i].MRQID
+			g.Records = append(g.Records, q)
+		}
+		g.Total = int64(len(g.Records))
+		g.Status = "success"
+		SvcWriteResponse(d.BID, &g, w)
+	case "save":
+		var foo PortalMaintSave
+		if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		q := foo.Record
+		if !p.canSeeRA(q.RAID) {
+			SvcErrorReturn(w, fmt.Errorf("Rental Agreement %d not found", q.RAID), funcname)
+			return
+		}
+		if q.RID > 0 {
+			rars, err := rlib.GetRentalAgreementRentables(r.Context(), q.RAID, &rlib.TIME0, &rlib.ENDOFTIME)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			found := false
+			for i := 0; i < len(rars) && !found; i++ {
+				found = rars[i].RID == q.RID
+			}
+			if !found {
+				SvcErrorReturn(w, fmt.Errorf("rentable %d is not part of Rental Agreement %d", q.RID, q.RAID), funcname)
+				return
+			}
+		}
+		if len(strings.TrimSpace(q.Subject)) == 0 {
+			SvcErrorReturn(w, fmt.Errorf("a subject is required"), funcname)
+			return
+		}
+		a := rlib.MaintRequest{
+			BID:     p.pu.BID,
+			RAID:    q.RAID,
+			RID:     q.RID,
+			TCID:    p.pu.TCID,
+			Dt:      *now,
+			Subject: strings.TrimSpace(q.Subject),
+			Descr:   q.Descr,
+		}
+		if err := rlib.InsertMaintRequest(r.Context(), &a); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		SvcWriteSuccessResponseWithID(d.BID, w, a.MRQID)
+	default:
+		SvcErrorReturn(w, fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd), funcname)
+	}
+}
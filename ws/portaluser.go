@@ -0,0 +1,314 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"strings"
+)
+
+// PortalUserGrid is the UI representation of a PortalUser. The password
+// hash and session token are never returned.
+type PortalUserGrid struct {
+	Recid        int64 `json:"recid"`
+	PUID         int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	TCID         int64
+	Username     string
+	LastLogin    rlib.JSONDateTime
+	FailedLogins int64
+	FLAGS        uint64
+	LastModTime  rlib.JSONDateTime
+	LastModBy    int64
+	CreateTS     rlib.JSONDateTime
+	CreateBy     int64
+}
+
+// PortalUserSearchResponse is the response to a search request for
+// PortalUser records
+type PortalUserSearchResponse struct {
+	Status  string           `json:"status"`
+	Total   int64            `json:"total"`
+	Records []PortalUserGrid `json:"records"`
+}
+
+// PortalUserGetResponse is the response to a get request for a single
+// PortalUser
+type PortalUserGetResponse struct {
+	Status string         `json:"status"`
+	Record PortalUserGrid `json:"record"`
+}
+
+// PortalUserSaveForm is the form data for a PortalUser
+type PortalUserSaveForm struct {
+	Recid    int64 `json:"recid"`
+	PUID     int64
+	BUD      rlib.XJSONBud
+	TCID     int64
+	Username string
+	Password string // required for a new login. If set for an existing login the password is changed and the login is unlocked
+	FLAGS    uint64
+}
+
+// SavePortalUserInput is the input data format for a Save command
+type SavePortalUserInput struct {
+	Recid    int64              `json:"recid"`
+	Status   string             `json:"status"`
+	FormName string             `json:"name"`
+	Record   PortalUserSaveForm `json:"record"`
+}
+
+// SvcHandlerPortalUser lets staff manage the tenant portal logins of a
+// business. For this call, we expect the URI to contain the BID and the
+// PUID as follows:
+//       0    1          2     3
+// 		/v1/portaluser/BID/PUID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerPortalUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerPortalUser"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  PUID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerPortalUsers(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("PUID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getPortalUser(w, r, d)
+		}
+	case "save":
+		savePortalUser(w, r, d)
+	case "delete":
+		deletePortalUser(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerPortalUsers returns the tenant portal logins for business
+// d.BID
+// wsdoc {
+//  @Title  Search Tenant Portal Logins
+//	@URL /v1/portaluser/:BUI
+//  @Method  POST
+//	@Synopsis Search Tenant Portal Logins
+//  @Descr  Return the tenant portal logins for the business.
+//	@Input WebGridSearchRequest
+//  @Response PortalUserSearchResponse
+// wsdoc }
+func SvcSearchHandlerPortalUsers(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerPortalUsers"
+	var g PortalUserSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetPortalUsersByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q PortalUserGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getPortalUser returns the requested PortalUser
+// wsdoc {
+//  @Title  Get Tenant Portal Login
+//	@URL /v1/portaluser/:BUI/:PUID
+//  @Method  GET
+//	@Synopsis Get information on a tenant portal login
+//  @Description  Return the fields of tenant portal login :PUID other than
+//  @Description  its password and session.
+//	@Input WebGridSearchRequest
+//  @Response PortalUserGetResponse
+// wsdoc }
+func getPortalUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getPortalUser"
+	var g PortalUserGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetPortalUser(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.PUID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.PUID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// savePortalUser creates or updates a PortalUser
+// wsdoc {
+//  @Title  Save Tenant Portal Login
+//	@URL /v1/portaluser/:BUI/:PUID
+//  @Method  POST
+//	@Synopsis Create or update a tenant portal login
+//  @Description  Saves the tenant portal login with the supplied data. If
+//  @Description  PUID is 0 a new login is created and Password is required.
+//  @Description  Setting the Password of an existing login changes it,
+//  @Description  unlocks the login and ends its session.
+//	@Input SavePortalUserInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func savePortalUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "savePortalUser"
+	var (
+		foo SavePortalUserInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	// d.data may have a password, do not print it
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.Username = strings.TrimSpace(f.Username)
+	if len(f.Username) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("Username is required"), funcname)
+		return
+	}
+
+	var a rlib.PortalUser
+	if f.PUID > 0 {
+		if a, err = rlib.GetPortalUser(r.Context(), f.PUID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.PUID == 0 || a.BID != bid {
+			e := fmt.Errorf("%s: tenant portal login %d not found", funcname, f.PUID)
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	} else if len(f.Password) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("a password is required for a new login"), funcname)
+		return
+	}
+
+	//------------------------------------------------------------------
+	// The login must belong to a transactant of this business and the
+	// username must not be in use by another login.
+	//------------------------------------------------------------------
+	var t rlib.Transactant
+	if err = rlib.GetTransactant(r.Context(), f.TCID, &t); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if t.TCID == 0 || t.BID != bid {
+		SvcErrorReturn(w, fmt.Errorf("transactant %d not found", f.TCID), funcname)
+		return
+	}
+	b, err := rlib.GetPortalUserByUsername(r.Context(), bid, f.Username)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.PUID > 0 && b.PUID != a.PUID {
+		SvcErrorReturn(w, fmt.Errorf("username %s is already in use", f.Username), funcname)
+		return
+	}
+
+	a.BID = bid
+	a.TCID = f.TCID
+	a.Username = f.Username
+	a.FLAGS = f.FLAGS
+	if len(f.Password) > 0 {
+		if err = rlib.SetPortalPassword(&a, f.Password); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		a.FailedLogins = 0
+		a.Token = ""
+		a.TokenExpire = rlib.TIME0
+	}
+	if a.FLAGS&rlib.PortalUserDisabled != 0 {
+		a.Token = ""
+		a.TokenExpire = rlib.TIME0
+	}
+
+	if a.PUID == 0 {
+		a.LastLogin = rlib.TIME0
+		a.TokenExpire = rlib.TIME0
+		err = rlib.InsertPortalUser(r.Context(), &a)
+	} else {
+		err = rlib.UpdatePortalUser(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving tenant portal login: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.PUID)
+}
+
+// deletePortalUser deletes a PortalUser
+// wsdoc {
+//  @Title  Delete Tenant Portal Login
+//	@URL /v1/portaluser/:BUI/:PUID
+//  @Method  POST
+//	@Synopsis Delete a tenant portal login
+//  @Desc  This service deletes a tenant portal login. Its session ends
+//  @Desc  immediately.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deletePortalUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deletePortalUser"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetPortalUser(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.PUID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("tenant portal login %d not found", del.ID), funcname)
+		return
+	}
+	if err := rlib.DeletePortalUser(r.Context(), del.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,474 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"rentroll/worker"
+	"strings"
+	"time"
+)
+
+// PositivePayFormatGrid is the UI representation of a PositivePayFormat
+type PositivePayFormatGrid struct {
+	Recid       int64 `json:"recid"`
+	PPFID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	Header      string
+	Detail      string
+	Trailer     string
+	FileExt     string
+	FLAGS       uint64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// PositivePayFormatSearchResponse is the response to a search request for
+// PositivePayFormat records
+type PositivePayFormatSearchResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []PositivePayFormatGrid `json:"records"`
+}
+
+// PositivePayFormatGetResponse is the response to a get request for a
+// single PositivePayFormat
+type PositivePayFormatGetResponse struct {
+	Status string                `json:"status"`
+	Record PositivePayFormatGrid `json:"record"`
+}
+
+// SavePositivePayFormatInput is the input data format for a Save command
+type SavePositivePayFormatInput struct {
+	Recid    int64                 `json:"recid"`
+	Status   string                `json:"status"`
+	FormName string                `json:"name"`
+	Record   PositivePayFormatGrid `json:"record"`
+}
+
+// PositivePayExportGrid is the UI representation of a PositivePayExport.
+// The file itself is downloaded with /v1/positivepayfile.
+type PositivePayExportGrid struct {
+	Recid       int64 `json:"recid"`
+	PPXID       int64
+	BID         int64
+	DEPID       int64
+	PPFID       int64
+	DtStart     rlib.JSONDate
+	DtStop      rlib.JSONDate
+	Items       int64
+	IssuedTotal rlib.Money
+	VoidTotal   rlib.Money
+	FileName    string
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// PositivePayExportSearchResponse lists the positive pay files of a
+// Depository
+type PositivePayExportSearchResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []PositivePayExportGrid `json:"records"`
+}
+
+// PositivePayExportResponse is the response to an export request
+type PositivePayExportResponse struct {
+	Status string                `json:"status"`
+	Record PositivePayExportGrid `json:"record"`
+}
+
+// SvcHandlerPositivePayFormat handles the positive pay file formats of a
+// business. For this call, we expect the URI to contain the BID and the
+// PPFID as follows:
+//       0    1                  2     3
+// 		/v1/positivepayformat/BID/PPFID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerPositivePayFormat(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerPositivePayFormat"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  PPFID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerPositivePayFormats(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("PPFID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getPositivePayFormat(w, r, d)
+		}
+	case "save":
+		savePositivePayFormat(w, r, d)
+	case "delete":
+		deletePositivePayFormat(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerPositivePayFormats returns the positive pay formats of
+// business d.BID
+// wsdoc {
+//  @Title  Search Positive Pay Formats
+//	@URL /v1/positivepayformat/:BUI
+//  @Method  POST
+//	@Synopsis Search Positive Pay Formats
+//  @Descr  Return the positive pay formats of the business sorted by name.
+//	@Input WebGridSearchRequest
+//  @Response PositivePayFormatSearchResponse
+// wsdoc }
+func SvcSearchHandlerPositivePayFormats(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerPositivePayFormats"
+	var g PositivePayFormatSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetPositivePayFormatsByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q PositivePayFormatGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].PPFID
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getPositivePayFormat returns the requested PositivePayFormat. PPFID 0
+// returns the default fixed-width format, which has not been saved.
+// wsdoc {
+//  @Title  Get Positive Pay Format
+//	@URL /v1/positivepayformat/:BUI/:PPFID
+//  @Method  GET
+//	@Synopsis Get a Positive Pay Format
+//  @Description  Return all fields for positive pay format :PPFID. If
+//  @Description  :PPFID is 0 a default fixed-width format is returned to
+//  @Description  start from.
+//	@Input WebGridSearchRequest
+//  @Response PositivePayFormatGetResponse
+// wsdoc }
+func getPositivePayFormat(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getPositivePayFormat"
+	var (
+		g   PositivePayFormatGetResponse
+		a   rlib.PositivePayFormat
+		err error
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	if d.ID == 0 {
+		a = rlib.PositivePayDefaultFormats[0]
+		a.BID = d.BID
+	} else if a, err = rlib.GetPositivePayFormat(r.Context(), d.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.PPFID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// savePositivePayFormat creates or updates a PositivePayFormat
+// wsdoc {
+//  @Title  Save Positive Pay Format
+//	@URL /v1/positivepayformat/:BUI/:PPFID
+//  @Method  POST
+//	@Synopsis Create or update a Positive Pay Format
+//  @Description  Saves the format. Header, Detail and Trailer are Go
+//  @Description  text/templates, see rlib.PositivePayFuncs for the functions
+//  @Description  they can use. Detail is required. The templates are tried
+//  @Description  on a sample check before the format is saved.
+//	@Input SavePositivePayFormatInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func savePositivePayFormat(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "savePositivePayFormat"
+	var (
+		foo SavePositivePayFormatInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if len(f.Name) == 0 || len(strings.TrimSpace(f.Detail)) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("Name and Detail are required"), funcname)
+		return
+	}
+
+	var a rlib.PositivePayFormat
+	if f.PPFID > 0 {
+		if a, err = rlib.GetPositivePayFormat(r.Context(), f.PPFID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.PPFID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("positive pay format %d not found", f.PPFID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(f, &a)
+	a.BID = bid
+
+	//------------------------------------------------------------------
+	// Catch template errors now rather than when the bot runs
+	//------------------------------------------------------------------
+	now := time.Now()
+	ca := rlib.CheckAccount{RoutingNo: "011000015", BankName: "Sample Bank", PayerName: "Sample"}
+	sample := []rlib.BankCheck{{CheckNo: 1001, Dt: now, Amount: 12345, Payee: "Sample Payee"}}
+	pd := rlib.NewPositivePayData(&ca, "123456789", sample, &now)
+	if _, err = rlib.RenderPositivePay(&a, &pd); err != nil {
+		SvcErrorReturn(w, fmt.Errorf("template error: %s", err.Error()), funcname)
+		return
+	}
+
+	if a.PPFID == 0 {
+		err = rlib.InsertPositivePayFormat(r.Context(), &a)
+	} else {
+		err = rlib.UpdatePositivePayFormat(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving positive pay format: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.PPFID)
+}
+
+// deletePositivePayFormat deletes a PositivePayFormat
+// wsdoc {
+//  @Title  Delete Positive Pay Format
+//	@URL /v1/positivepayformat/:BUI/:PPFID
+//  @Method  POST
+//	@Synopsis Delete a Positive Pay Format
+//  @Desc  This service deletes a positive pay format. A format that is used
+//  @Desc  by a depository cannot be deleted.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deletePositivePayFormat(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deletePositivePayFormat"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetPositivePayFormat(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.PPFID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("positive pay format %d not found", del.ID), funcname)
+		return
+	}
+	m, err := rlib.GetCheckAccountsWithPositivePay(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].PPFID == a.PPFID {
+			SvcErrorReturn(w, fmt.Errorf("positive pay format %d is used by depository %d", a.PPFID, m[i].DEPID), funcname)
+			return
+		}
+	}
+	if err = rlib.DeletePositivePayFormat(r.Context(), a.PPFID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerPositivePay handles the positive pay files of a Depository. For
+// this call, we expect the URI to contain the BID and the DEPID as follows:
+//       0    1            2     3
+// 		/v1/positivepay/BID/DEPID
+//
+// The server command can be:
+//      get
+//      export
+//-----------------------------------------------------------------------------------
+func SvcHandlerPositivePay(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerPositivePay"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  DEPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getPositivePayExports(w, r, d)
+	case "export":
+		exportPositivePay(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getPositivePayExports lists the positive pay files of a Depository
+// wsdoc {
+//  @Title  Positive Pay Files
+//	@URL /v1/positivepay/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis List the positive pay files of a Depository
+//  @Descr  Returns the positive pay files produced for depository :DEPID,
+//  @Descr  newest first.
+//	@Input WebGridSearchRequest
+//  @Response PositivePayExportSearchResponse
+// wsdoc }
+func getPositivePayExports(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getPositivePayExports"
+	var g PositivePayExportSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	dep, err := rlib.GetDepository(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if dep.DEPID == 0 || dep.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("depository %d not found", d.ID), funcname)
+		return
+	}
+	m, err := rlib.GetPositivePayExportsByDEPID(r.Context(), dep.DEPID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q PositivePayExportGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].PPXID
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// exportPositivePay produces a positive pay file for a Depository now
+// wsdoc {
+//  @Title  Export Positive Pay
+//	@URL /v1/positivepay/:BUI/:DEPID
+//  @Method  POST
+//	@Synopsis Produce a positive pay file for a Depository
+//  @Descr  Produces the positive pay file of depository :DEPID for the
+//  @Descr  checks dated from searchDtStart up to searchDtStop that have not
+//  @Descr  been sent, plus any void checks whose void has not been sent.
+//  @Descr  The checks are marked sent. PPXID is 0 if there was nothing to
+//  @Descr  send. Download the file with /v1/positivepayfile/:BUI/:PPXID.
+//	@Input WebGridSearchRequest
+//  @Response PositivePayExportResponse
+// wsdoc }
+func exportPositivePay(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "exportPositivePay"
+	var g PositivePayExportResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1 := time.Time(d.wsSearchReq.SearchDtStart)
+	d2 := time.Time(d.wsSearchReq.SearchDtStop)
+	now := time.Now()
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	ca, err := rlib.GetCheckAccountByDEPID(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if ca.CKAID == 0 || ca.BID != d.BID || ca.PPFID == 0 {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("depository %d does not use positive pay", d.ID), funcname)
+		return
+	}
+	x, err := worker.PositivePayExportCore(ctx, &ca, &d1, &d2, &now)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	rlib.MigrateStructVals(&x, &g.Record)
+	g.Record.Recid = x.PPXID
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcPositivePayFile downloads a positive pay file
+// wsdoc {
+//  @Title  Download Positive Pay File
+//	@URL /v1/positivepayfile/:BUI/:PPXID
+//  @Method  GET
+//	@Synopsis Download a positive pay file
+//  @Descr  Returns positive pay file :PPXID exactly as it was produced so it
+//  @Descr  can be sent to the bank again if needed.
+//	@Input WebGridSearchRequest
+//  @Response the file
+// wsdoc }
+func SvcPositivePayFile(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcPositivePayFile"
+
+	fmt.Printf("Entered %s\n", funcname)
+	x, err := rlib.GetPositivePayExport(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if x.PPXID == 0 || x.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("positive pay file %d not found", d.ID), funcname)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", x.FileName))
+	w.Write([]byte(x.Content))
+}
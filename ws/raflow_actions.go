@@ -98,13 +98,15 @@ func SvcSetRAState(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	var flow rlib.Flow
+	var ev *rlib.WebhookRAState
 
 	switch foo.Version {
 	case "raid":
-		flow, err = handleRAIDVersion(ctx, d, foo, raFlowData)
+		flow, ev, err = handleRAIDVersion(ctx, d, foo, raFlowData)
 		if err != nil {
 			return
 		}
+		emitRAStateEvent(ctx, d.BID, ev)
 
 		// -------------------
 		// WRITE FLOW RESPONSE
@@ -116,10 +118,11 @@ func SvcSetRAState(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		var resp FlowResponse
 		var raflowRespData RAFlowResponse
 
-		raflowRespData, err = handleRefNoVersion(ctx, d, foo, raFlowData)
+		raflowRespData, ev, err = handleRefNoVersion(ctx, d, foo, raFlowData)
 		if err != nil {
 			return
 		}
+		emitRAStateEvent(ctx, d.BID, ev)
 
 		// -------------------
 		// WRITE FLOW RESPONSE
@@ -131,7 +134,17 @@ func SvcSetRAState(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 
 }
 
-func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequest, raFlowData rlib.RAFlowJSONData) (rlib.Flow, error) {
+// emitRAStateEvent queues the webhook event for a rental agreement state
+// change once the action has been saved. ev is nil if the request did not
+// change anything.
+func emitRAStateEvent(ctx context.Context, bid int64, ev *rlib.WebhookRAState) {
+	if ev == nil {
+		return
+	}
+	rlib.EmitWebhookEvent(ctx, bid, rlib.WHEVTraStateChanged, ev)
+}
+
+func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequest, raFlowData rlib.RAFlowJSONData) (rlib.Flow, *rlib.WebhookRAState, error) {
 	RAID := foo.RAID
 	Action := foo.Action
 
@@ -139,6 +152,7 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 	State := raFlowData.Meta.RAFLAGS & uint64(0xF)
 
 	var flow rlib.Flow
+	var ev *rlib.WebhookRAState
 	var err error
 
 	switch Action {
@@ -150,7 +164,7 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		// If flow is present then get that flow
 		flow, err = rlib.GetFlowForRAID(ctx, "RA", RAID)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		if flow.FlowID > 0 {
@@ -158,7 +172,7 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 			// to indicate warning
 			blankData := []byte("{}")
 			flow = rlib.Flow{FlowID: -1, Data: blankData}
-			return flow, nil
+			return flow, nil, nil
 		}
 
 		// IF NOT FOUND THEN TRY TO CREATE NEW ONE FROM RAID
@@ -166,11 +180,11 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		var ra rlib.RentalAgreement
 		ra, err = rlib.GetRentalAgreement(ctx, RAID)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 		if ra.RAID == 0 {
 			err = fmt.Errorf("rental Agreement not found with given RAID: %d", RAID)
-			return flow, err
+			return flow, nil, err
 		}
 
 		// GET THE NEW FLOW ID CREATED USING PERMANENT DATA
@@ -178,13 +192,13 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		EditFlag := false // we're only creating this to update the state and meta info, so we don't need to filter fees
 		flowID, err = GetRA2FlowCore(ctx, &ra, d, EditFlag)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		// GET GENERATED FLOW USING NEW ID
 		flow, err = rlib.GetFlow(ctx, flowID)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		ApplicationReadyName, _ := rlib.GetDirectoryPerson(ctx, ra.ApplicationReadyUID)
@@ -236,26 +250,27 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		// MODIFY META DATA
 		err = SetActionMetaData(ctx, d, Action, &modRAFlowMeta)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		// UPDATE FLOW
 		var modMetaData []byte
 		modMetaData, err = json.Marshal(&modRAFlowMeta)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		err = rlib.UpdateFlowPartData(ctx, "meta", modMetaData, &flow)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		// get the updated flow
 		flow, err = rlib.GetFlow(ctx, flow.FlowID)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
+		ev = rlib.NewWebhookRAState(ra.FLAGS, &modRAFlowMeta)
 
 	case
 		rlib.RAActionCompleteMoveIn,
@@ -266,11 +281,11 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		var ra rlib.RentalAgreement
 		ra, err = rlib.GetRentalAgreement(ctx, RAID)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 		if ra.RAID == 0 {
 			err = fmt.Errorf("rental Agreement not found with given RAID: %d", RAID)
-			return flow, err
+			return flow, nil, err
 		}
 
 		ApplicationReadyName, _ := rlib.GetDirectoryPerson(ctx, ra.ApplicationReadyUID)
@@ -323,9 +338,10 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		// MODIFY META DATA
 		err = SetActionMetaData(ctx, d, Action, &modRAFlowMeta)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
+		oldFlags := ra.FLAGS
 		ra.RAID = modRAFlowMeta.RAID
 		ra.FLAGS = modRAFlowMeta.RAFLAGS
 		ra.ApplicationReadyUID = modRAFlowMeta.ApplicationReadyUID
@@ -351,8 +367,9 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		// UPDATE RA in REAL TABLE
 		err = rlib.UpdateRentalAgreement(ctx, &ra)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
+		ev = rlib.NewWebhookRAState(oldFlags, &modRAFlowMeta)
 
 		// EditFlag should be set to true only when we're creating a Flow that
 		// becomes a RefNo (an amended RentalAgreement)
@@ -362,13 +379,13 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 		var raf rlib.RAFlowJSONData
 		raf, err = rlib.ConvertRA2Flow(ctx, &ra, EditFlag)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		var raflowJSONData []byte
 		raflowJSONData, err = json.Marshal(&raf)
 		if err != nil {
-			return flow, err
+			return flow, nil, err
 		}
 
 		flow = rlib.Flow{
@@ -384,10 +401,10 @@ func handleRAIDVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequ
 	}
 
 	// RETURN FLOW
-	return flow, nil
+	return flow, ev, nil
 }
 
-func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequest, raFlowData rlib.RAFlowJSONData) (RAFlowResponse, error) {
+func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataRequest, raFlowData rlib.RAFlowJSONData) (RAFlowResponse, *rlib.WebhookRAState, error) {
 
 	UserRefNo := foo.UserRefNo
 	Action := foo.Action
@@ -408,17 +425,17 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 	// GET FLOW BY REFNO
 	flow, err = rlib.GetFlowByUserRefNo(ctx, d.BID, UserRefNo)
 	if err != nil {
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 	if flow.FlowID <= 0 {
 		err = fmt.Errorf("rental Agreement Flow not found with given Customer Reference No.: %s", UserRefNo)
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 
 	// get unmarshalled raflow data into struct
 	err = json.Unmarshal(flow.Data, &raFlowData)
 	if err != nil {
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 
 	raflowRespData.Flow = flow
@@ -433,7 +450,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 			raflowRespData.DataFulfilled.Pets && raflowRespData.DataFulfilled.Vehicles &&
 			raflowRespData.DataFulfilled.Rentables && raflowRespData.DataFulfilled.ParentChild &&
 			raflowRespData.DataFulfilled.Tie) {
-		return raflowRespData, nil
+		return raflowRespData, nil, nil
 	}
 
 	// get meta in modRAFlowMeta, we're going to modify it
@@ -458,7 +475,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 			// MODIFY META DATA
 			err = SetActionMetaData(ctx, d, Action, &modRAFlowMeta)
 			if err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			if Action == rlib.RAActionCompleteMoveIn {
@@ -467,14 +484,14 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 
 		default:
 			err = fmt.Errorf("invalid Action Taken")
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 	case "State":
 		// set location for time as UTC
 		var location *time.Location
 		location, err = time.LoadLocation("UTC")
 		if err != nil {
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 
 		// get current time in UTC
@@ -488,13 +505,13 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		case rlib.RASTATEPendingApproval1:
 			var data RAApprover1Data
 			if err = json.Unmarshal([]byte(d.data), &data); err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			var fullName string
 			fullName, err = getUserFullName(ctx, UID)
 			if err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			if data.Decision1 == 1 { // Approved
@@ -518,7 +535,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 					var xbiz rlib.XBusiness
 					err = rlib.InitBizInternals(d.BID, &xbiz)
 					if err != nil {
-						return raflowRespData, err
+						return raflowRespData, nil, err
 					}
 				}
 				// APPLICATION DECLINED SLSID IN LEASE TERMINATION REASON
@@ -529,7 +546,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 				modRAFlowMeta.RAFLAGS = (clearedState | 6)
 			} else {
 				err = fmt.Errorf("approver1 data is not valid")
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			modRAFlowMeta.Approver1 = UID
@@ -539,13 +556,13 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		case rlib.RASTATEPendingApproval2:
 			var data RAApprover2Data
 			if err = json.Unmarshal([]byte(d.data), &data); err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			var fullName string
 			fullName, err = getUserFullName(ctx, UID)
 			if err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			if data.Decision2 == 1 { // Approved
@@ -573,7 +590,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 					var xbiz rlib.XBusiness
 					err = rlib.InitBizInternals(d.BID, &xbiz)
 					if err != nil {
-						return raflowRespData, err
+						return raflowRespData, nil, err
 					}
 				}
 				// APPLICATION DECLINED SLSID IN LEASE TERMINATION REASON
@@ -584,7 +601,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 				modRAFlowMeta.RAFLAGS = (clearedState | 6)
 			} else {
 				err = fmt.Errorf("approver2 data is not valid")
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			modRAFlowMeta.Approver2 = UID
@@ -594,13 +611,13 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		case rlib.RASTATEMoveIn:
 			var data RAMoveInData
 			if err = json.Unmarshal([]byte(d.data), &data); err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			var fullName string
 			fullName, err = getUserFullName(ctx, UID)
 			if err != nil {
-				return raflowRespData, err
+				return raflowRespData, nil, err
 			}
 
 			modRAFlowMeta.MoveInUID = UID
@@ -615,39 +632,44 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 	var modMetaData []byte
 	modMetaData, err = json.Marshal(&modRAFlowMeta)
 	if err != nil {
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 
 	err = rlib.UpdateFlowPartData(ctx, "meta", modMetaData, &flow)
 	if err != nil {
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 
 	// GET UPDATED FLOW
 	flow, err = rlib.GetFlowByUserRefNo(ctx, flow.BID, flow.UserRefNo)
 	if err != nil {
-		return raflowRespData, err
+		return raflowRespData, nil, err
 	}
 	raflowRespData.Flow = flow
+	ev := rlib.NewWebhookRAState(raFlowData.Meta.RAFLAGS, &modRAFlowMeta)
+	ev.UserRefNo = UserRefNo
 
 	if migrateData {
 		// migrate data to real table via hook
 		var newRAID int64
 		newRAID, err = Flow2RA(ctx, flow.FlowID)
 		if err != nil {
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 
 		// GET RENTAL AGREEMENT
 		var ra rlib.RentalAgreement
 		ra, err = rlib.GetRentalAgreement(ctx, newRAID)
 		if err != nil {
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 		if ra.RAID == 0 {
 			err = fmt.Errorf("Rental Agreement not found with given RAID: %d", newRAID)
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
+		ev.RAID = ra.RAID
+		ev.NewFLAGS = ra.FLAGS
+		ev.NewState = ra.FLAGS & 0xF
 
 		// EditFlag should be set to true only when we're creating a Flow that
 		// becomes a RefNo (an amended RentalAgreement)
@@ -657,7 +679,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		var raf rlib.RAFlowJSONData
 		raf, err = rlib.ConvertRA2Flow(ctx, &ra, EditFlag)
 		if err != nil {
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 
 		//-------------------------------------------------------------------------
@@ -666,7 +688,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		var raflowJSONData []byte
 		raflowJSONData, err = json.Marshal(&raf)
 		if err != nil {
-			return raflowRespData, err
+			return raflowRespData, nil, err
 		}
 
 		// After Migration, flow will be deleted
@@ -683,7 +705,7 @@ func handleRefNoVersion(ctx context.Context, d *ServiceData, foo RAActionDataReq
 		}
 		raflowRespData.Flow = flow
 	}
-	return raflowRespData, nil
+	return raflowRespData, ev, nil
 }
 
 func getUserFullName(ctx context.Context, UID int64) (string, error) {
@@ -24,6 +24,8 @@ type RARentableDetailsRequest struct {
 // The server command can be:
 //      save
 //      delete
+//      addconcession
+//      deleteconcession
 //-----------------------------------------------------------------------------------
 func SvcRAFlowRentableHandler(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	const funcname = "SvcRAFlowRentableHandler"
@@ -40,6 +42,12 @@ func SvcRAFlowRentableHandler(w http.ResponseWriter, r *http.Request, d *Service
 	case "delete":
 		DeleteRAFlowRentable(w, r, d)
 		break
+	case "addconcession":
+		SaveRAFlowRentableConcession(w, r, d, false)
+		break
+	case "deleteconcession":
+		SaveRAFlowRentableConcession(w, r, d, true)
+		break
 	default:
 		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
 		SvcErrorReturn(w, err, funcname)
@@ -264,6 +272,7 @@ func SaveRAFlowRentableDetails(w http.ResponseWriter, r *http.Request, d *Servic
 		RTFLAGS:      rt.FLAGS,
 		RentCycle:    rt.RentCycle,
 		Fees:         rentableFees,
+		Concessions:  []rlib.RAConcessionData{},
 	}
 
 	// find this RID in flow data rentable list
@@ -278,6 +287,10 @@ func SaveRAFlowRentableDetails(w http.ResponseWriter, r *http.Request, d *Servic
 	if rIndex < 0 {
 		raFlowData.Rentables = append(raFlowData.Rentables, rfd)
 	} else {
+		// KEEP THE CONCESSIONS ALREADY GIVEN ON THIS RENTABLE
+		if raFlowData.Rentables[rIndex].Concessions != nil {
+			rfd.Concessions = raFlowData.Rentables[rIndex].Concessions
+		}
 		raFlowData.Rentables[rIndex] = rfd
 	}
 
@@ -465,3 +478,150 @@ func DeleteRAFlowRentable(w http.ResponseWriter, r *http.Request, d *ServiceData
 	SvcWriteFlowResponse(ctx, d.BID, flow, w)
 	return
 }
+
+// RAFlowConcessionRequest is struct for request to give or take back a
+// concession on a rentable in raflow json data
+type RAFlowConcessionRequest struct {
+	RID    int64
+	FlowID int64
+	CONID  int64
+}
+
+// SaveRAFlowRentableConcession gives a concession on the rent of a rentable,
+// or takes it back if remove is set. The terms of the concession are copied
+// from its definition and it applies from the rent start date.
+// wsdoc {
+//  @Title Give or take back a concession on a rentable
+//  @URL /v1/raflow-rentable/:BUI/
+//  @Method POST
+//  @Synopsis Add or remove a concession on a Rentable in RAFlow json data
+//  @Description Command addconcession adds concession CONID to the
+//  @Description concessions of rentable RID, deleteconcession removes it.
+//  @Input RAFlowConcessionRequest
+//  @Response FlowResponse
+// wsdoc }
+func SaveRAFlowRentableConcession(w http.ResponseWriter, r *http.Request, d *ServiceData, remove bool) {
+	const funcname = "SaveRAFlowRentableConcession"
+	var (
+		raFlowData rlib.RAFlowJSONData
+		foo        RAFlowConcessionRequest
+		err        error
+		tx         *sql.Tx
+		ctx        context.Context
+	)
+	fmt.Printf("Entered %s\n", funcname)
+
+	// ===============================================
+	// defer function to handle transactaion rollback
+	// ===============================================
+	defer func() {
+		if err != nil {
+			if tx != nil {
+				tx.Rollback()
+			}
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+
+		// COMMIT TRANSACTION
+		if tx != nil {
+			err = tx.Commit()
+		}
+	}()
+
+	// http method check
+	if r.Method != "POST" {
+		err = fmt.Errorf("Only POST method is allowed")
+		return
+	}
+
+	// unmarshal data into request data struct
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		return
+	}
+
+	//-------------------------------------------------------
+	// GET THE NEW `tx`, UPDATED CTX FROM THE REQUEST CONTEXT
+	//-------------------------------------------------------
+	tx, ctx, err = rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		return
+	}
+
+	// get flow and it must exist
+	var flow rlib.Flow
+	flow, err = rlib.GetFlow(ctx, foo.FlowID)
+	if err != nil {
+		return
+	}
+
+	// get unmarshalled raflow data into struct
+	err = json.Unmarshal(flow.Data, &raFlowData)
+	if err != nil {
+		return
+	}
+
+	// find this RID in flow data rentable list
+	var rfd *rlib.RARentablesFlowData
+	for i := range raFlowData.Rentables {
+		if raFlowData.Rentables[i].RID == foo.RID {
+			rfd = &raFlowData.Rentables[i]
+			break
+		}
+	}
+	if rfd == nil {
+		err = fmt.Errorf("rentable %d is not in this rental agreement", foo.RID)
+		return
+	}
+
+	// ----------------------------------------------
+	// ADD OR REMOVE THE CONCESSION
+	// ----------------------------------------------
+	var cIndex = -1
+	for i := range rfd.Concessions {
+		if rfd.Concessions[i].CONID == foo.CONID {
+			cIndex = i
+			break
+		}
+	}
+	if remove {
+		if cIndex >= 0 {
+			rfd.Concessions = append(rfd.Concessions[:cIndex], rfd.Concessions[cIndex+1:]...)
+		}
+	} else if cIndex < 0 {
+		var c rlib.RAConcessionData
+		c, err = rlib.GetRAFlowConcession(ctx, d.BID, foo.CONID, time.Time(raFlowData.Dates.RentStart))
+		if err != nil {
+			return
+		}
+		rfd.Concessions = append(rfd.Concessions, c)
+	}
+
+	// GET JSON DATA FROM THE STRUCT
+	var modFlowData []byte
+	modFlowData, err = json.Marshal(&raFlowData)
+	if err != nil {
+		return
+	}
+
+	// ASSIGN JSON MARSHALLED MODIFIED DATA
+	flow.Data = modFlowData
+
+	// NOW UPDATE THE WHOLE FLOW
+	err = rlib.UpdateRAFlowWithInitState(ctx, &flow)
+	if err != nil {
+		return
+	}
+
+	// get the modified flow
+	flow, err = rlib.GetFlow(ctx, flow.FlowID)
+	if err != nil {
+		return
+	}
+
+	// -------------------
+	// WRITE FLOW RESPONSE
+	// -------------------
+	SvcWriteFlowResponse(ctx, d.BID, flow, w)
+	return
+}
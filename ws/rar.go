@@ -20,7 +20,7 @@ type RAR struct {
 	BID          int64         // Business
 	RID          int64         // the Rentable
 	RentableName string        // name of RID
-	ContractRent float64       // the rent
+	ContractRent rlib.Money    // the rent
 	RARDtStart   rlib.JSONDate // start date/time for this Rentable
 	RARDtStop    rlib.JSONDate // stop date/time
 }
@@ -39,7 +39,7 @@ type RARentableFormSave struct {
 	RID          int64         // the rentable id
 	BUI          string        // in this case we could get an BID or a BUD
 	RentableName string        // name of RID
-	ContractRent float64       // the rent
+	ContractRent rlib.Money    // the rent
 	RARDtStart   rlib.JSONDate // start date/time for this Payor
 	RARDtStop    rlib.JSONDate // stop date/time
 }
@@ -206,7 +206,7 @@ func saveRARentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		RAID:    d.RAID,
 		RID:     a.RID,
 		Dt:      a.RARDtStart,
-		Balance: float64(0),
+		Balance: rlib.Money(0),
 		State:   rlib.LMINITIAL,
 	}
 	_, err = rlib.InsertLedgerMarker(r.Context(), &lm)
@@ -254,7 +254,7 @@ func SvcUpdateRARentable(w http.ResponseWriter, r *http.Request, d *ServiceData)
 			rec.RARDtStop = dt
 			changes++
 		}
-		if foo.Changes[i].ContractRent > float64(0) {
+		if foo.Changes[i].ContractRent > 0 {
 			rec.ContractRent = foo.Changes[i].ContractRent
 			changes++
 		}
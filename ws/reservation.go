@@ -0,0 +1,502 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReservationGrid is the UI representation of a Reservation
+type ReservationGrid struct {
+	Recid       int64 `json:"recid"`
+	RESID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	RTID        int64
+	RID         int64
+	TCID        int64
+	RPID        int64
+	DtArrive    rlib.JSONDate
+	DtDepart    rlib.JSONDate
+	Adults      int64
+	Children    int64
+	Rate        rlib.Money
+	Deposit     rlib.Money
+	RAID        int64
+	Comment     string
+	FLAGS       uint64
+	State       int64 // 0 = booked, 1 = checked in, 2 = cancelled, 3 = no show
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// ReservationSearchResponse lists the reservations for a range of dates
+type ReservationSearchResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []ReservationGrid `json:"records"`
+}
+
+// ReservationGetResponse is the response to a get request for a single
+// Reservation
+type ReservationGetResponse struct {
+	Status string          `json:"status"`
+	Record ReservationGrid `json:"record"`
+}
+
+// SaveReservationInput is the input data format for a Save command
+type SaveReservationInput struct {
+	Recid    int64           `json:"recid"`
+	Status   string          `json:"status"`
+	FormName string          `json:"name"`
+	Record   ReservationGrid `json:"record"`
+}
+
+// ReservationRequest is the request data of the reservation get and checkin
+// commands. DtStart - DtStop selects the stays listed when the URI RESID is
+// 0. RID is the room given to the guest at check-in, if the reservation
+// does not have one yet.
+type ReservationRequest struct {
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	RID     int64
+}
+
+// AvailabilityRequest is the request data of the availability command
+type AvailabilityRequest struct {
+	RTID    int64         // the type of room, 0 for all types
+	DtStart rlib.JSONDate // the first night
+	DtStop  rlib.JSONDate // the departure date
+	RESID   int64         // a reservation to leave out, ex: the one being changed
+}
+
+// AvailabilityGrid is the availability of a type of room for one night
+type AvailabilityGrid struct {
+	Recid      int64 `json:"recid"`
+	RTID       int64
+	Style      string
+	Dt         rlib.JSONDate
+	Rooms      int64
+	Free       int64
+	Unassigned int64
+	Available  int64
+}
+
+// RoomFreeGrid is a period in which a room is free
+type RoomFreeGrid struct {
+	Recid   int64 `json:"recid"`
+	RTID    int64
+	RID     int64
+	Name    string
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+}
+
+// AvailabilityResponse is the response to an availability request
+type AvailabilityResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []AvailabilityGrid `json:"records"`
+	Rooms   []RoomFreeGrid     `json:"rooms"`
+}
+
+// SvcHandlerReservation handles hotel reservations. For this call, we
+// expect the URI to contain the BID and the RESID as follows:
+//       0    1              2     3
+// 		/v1/reservation/BID/RESID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//      cancel
+//      checkin
+//-----------------------------------------------------------------------------------
+func SvcHandlerReservation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerReservation"
+	var req ReservationRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RESID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if d.wsSearchReq.Cmd != "save" && len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 {
+			SvcSearchHandlerReservations(w, r, d, &req)
+		} else {
+			getReservation(w, r, d)
+		}
+	case "save":
+		saveReservation(w, r, d)
+	case "delete":
+		deleteReservation(w, r, d)
+	case "cancel":
+		cancelReservation(w, r, d)
+	case "checkin":
+		checkInReservation(w, r, d, &req)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// reservationGrid returns the UI representation of reservation a
+func reservationGrid(a *rlib.Reservation) ReservationGrid {
+	var q ReservationGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.RESID
+	q.BUD = rlib.GetBUDFromBIDList(a.BID)
+	q.State = int64(a.FLAGS & rlib.RESSTATEMask)
+	return q
+}
+
+// SvcSearchHandlerReservations returns the reservations for stays that
+// overlap a range of dates
+// wsdoc {
+//  @Title  Search Reservations
+//	@URL /v1/reservation/:BUI
+//  @Method  POST
+//	@Synopsis List the reservations for a range of dates
+//  @Descr  Return the reservations, in any state, whose stay overlaps
+//  @Descr  DtStart - DtStop, earliest arrival first.
+//	@Input ReservationRequest
+//  @Response ReservationSearchResponse
+// wsdoc }
+func SvcSearchHandlerReservations(w http.ResponseWriter, r *http.Request, d *ServiceData, req *ReservationRequest) {
+	const funcname = "SvcSearchHandlerReservations"
+	var g ReservationSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1 := time.Time(req.DtStart)
+	d2 := time.Time(req.DtStop)
+	if !d2.After(d1) {
+		SvcErrorReturn(w, fmt.Errorf("DtStop must be after DtStart"), funcname)
+		return
+	}
+	m, err := rlib.GetReservationsByRange(r.Context(), d.BID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []ReservationGrid{}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, reservationGrid(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getReservation returns the requested Reservation
+// wsdoc {
+//  @Title  Get Reservation
+//	@URL /v1/reservation/:BUI/:RESID
+//  @Method  GET
+//	@Synopsis Get a Reservation
+//  @Description  Return all fields for reservation :RESID
+//	@Input WebGridSearchRequest
+//  @Response ReservationGetResponse
+// wsdoc }
+func getReservation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getReservation"
+	var g ReservationGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetReservation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RESID > 0 && a.BID == d.BID {
+		g.Record = reservationGrid(&a)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveReservation creates or updates a Reservation
+// wsdoc {
+//  @Title  Save Reservation
+//	@URL /v1/reservation/:BUI/:RESID
+//  @Method  POST
+//	@Synopsis Create or update a Reservation
+//  @Description  Books room type RTID for guest TCID from DtArrive to
+//  @Description  DtDepart. RID is the room, 0 to assign one later. The
+//  @Description  room, or a room of the type if none is assigned, must be
+//  @Description  available every night of the stay. If Rate is 0 the rate
+//  @Description  plan RPID, or the market rate, sets it. Only a booked
+//  @Description  reservation can be changed.
+//	@Input SaveReservationInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveReservation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveReservation"
+	var (
+		foo SaveReservationInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.Reservation
+	if f.RESID > 0 {
+		if a, err = rlib.GetReservation(r.Context(), f.RESID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.RESID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("reservation %d not found", f.RESID), funcname)
+			return
+		}
+	}
+	a.BID = bid
+	a.RTID = f.RTID
+	a.RID = f.RID
+	a.TCID = f.TCID
+	a.RPID = f.RPID
+	a.DtArrive = time.Time(f.DtArrive)
+	a.DtDepart = time.Time(f.DtDepart)
+	a.Adults = f.Adults
+	a.Children = f.Children
+	a.Rate = f.Rate
+	a.Deposit = f.Deposit
+	a.Comment = strings.TrimSpace(f.Comment)
+	if errlist := bizlogic.SaveReservation(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.RESID)
+}
+
+// deleteReservation deletes a Reservation
+// wsdoc {
+//  @Title  Delete Reservation
+//	@URL /v1/reservation/:BUI/:RESID
+//  @Method  POST
+//	@Synopsis Delete a Reservation
+//  @Desc  This service deletes reservation :RESID. A reservation that has
+//  @Desc  been checked in cannot be deleted, cancel it instead if the guest
+//  @Desc  will not come.
+//	@Input WebGridDelete
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteReservation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteReservation"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetReservation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RESID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("reservation %d not found", d.ID), funcname)
+		return
+	}
+	if a.FLAGS&rlib.RESSTATEMask == rlib.RESSTATECheckedIn {
+		SvcErrorReturn(w, fmt.Errorf("reservation %d has been checked in", d.ID), funcname)
+		return
+	}
+	if err = rlib.DeleteReservation(r.Context(), a.RESID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// cancelReservation cancels a Reservation
+// wsdoc {
+//  @Title  Cancel Reservation
+//	@URL /v1/reservation/:BUI/:RESID
+//  @Method  POST
+//	@Synopsis Cancel a Reservation
+//  @Desc  Cancels booked reservation :RESID. Its room, or its hold on the
+//  @Desc  room type, is released.
+//	@Input WebGridSearchRequest
+//  @Response ReservationGetResponse
+// wsdoc }
+func cancelReservation(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "cancelReservation"
+	var g ReservationGetResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetReservation(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RESID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("reservation %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.CancelReservation(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	g.Record = reservationGrid(&a)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// checkInReservation checks in the guest of a Reservation
+// wsdoc {
+//  @Title  Check In Reservation
+//	@URL /v1/reservation/:BUI/:RESID
+//  @Method  POST
+//	@Synopsis Check in the guest of a Reservation
+//  @Desc  Creates an active Rental Agreement for the stay of reservation
+//  @Desc  :RESID with the guest as payor and user of the room, and the
+//  @Desc  reservation's rate as the contract rent. The night audit posts
+//  @Desc  the room charges. RID assigns the room if the reservation has
+//  @Desc  none. The response has the RAID.
+//	@Input ReservationRequest
+//  @Response ReservationGetResponse
+// wsdoc }
+func checkInReservation(w http.ResponseWriter, r *http.Request, d *ServiceData, req *ReservationRequest) {
+	const funcname = "checkInReservation"
+	var g ReservationGetResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetReservation(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RESID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("reservation %d not found", d.ID), funcname)
+		return
+	}
+	if a.RID == 0 {
+		a.RID = req.RID
+	}
+	if errlist := bizlogic.CheckInReservation(ctx, &a); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Record = reservationGrid(&a)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// SvcAvailability returns the room availability calendar of a business
+// wsdoc {
+//  @Title  Room Availability
+//	@URL /v1/availability/:BUI
+//  @Method  POST
+//	@Synopsis Search for available rooms by date range and type
+//  @Descr  Returns, for each night from DtStart up to DtStop and each room
+//  @Descr  type, or only type RTID if it is set, the number of rooms, the
+//  @Descr  rooms that are free, the reservations that have no room yet and
+//  @Descr  the rooms still available. Rooms lists the periods in which each
+//  @Descr  room of the type is free. A room is not free while it is in a
+//  @Descr  Rental Agreement, is held by a reservation or is not in service.
+//	@Input AvailabilityRequest
+//  @Response AvailabilityResponse
+// wsdoc }
+func SvcAvailability(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcAvailability"
+	var (
+		req  AvailabilityRequest
+		g    AvailabilityResponse
+		xbiz rlib.XBusiness
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	d1 := time.Time(req.DtStart)
+	d2 := time.Time(req.DtStop)
+	if !d2.After(d1) {
+		SvcErrorReturn(w, fmt.Errorf("DtStop must be after DtStart"), funcname)
+		return
+	}
+	if err := rlib.GetXBusiness(r.Context(), d.BID, &xbiz); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	var rtids []int64
+	if req.RTID > 0 {
+		if _, ok := xbiz.RT[req.RTID]; !ok {
+			SvcErrorReturn(w, fmt.Errorf("rentable type %d not found", req.RTID), funcname)
+			return
+		}
+		rtids = append(rtids, req.RTID)
+	} else {
+		for rtid := range xbiz.RT {
+			rtids = append(rtids, rtid)
+		}
+		sort.Slice(rtids, func(i, j int) bool { return rtids[i] < rtids[j] })
+	}
+
+	g.Records = []AvailabilityGrid{}
+	g.Rooms = []RoomFreeGrid{}
+	for _, rtid := range rtids {
+		a, err := rlib.GetRoomAvailability(r.Context(), d.BID, rtid, &d1, &d2, req.RESID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if len(a.Rooms) == 0 {
+			continue
+		}
+		for _, n := range a.Nights {
+			q := AvailabilityGrid{RTID: rtid, Style: xbiz.RT[rtid].Style, Dt: rlib.JSONDate(n.Dt), Rooms: n.Rooms, Free: n.Free, Unassigned: n.Unassigned, Available: n.Available}
+			q.Recid = int64(len(g.Records) + 1)
+			g.Records = append(g.Records, q)
+		}
+		for _, rm := range a.Rooms {
+			for _, p := range rm.Free {
+				q := RoomFreeGrid{RTID: rtid, RID: rm.RID, Name: rm.Name, DtStart: rlib.JSONDate(p.D1), DtStop: rlib.JSONDate(p.D2)}
+				q.Recid = int64(len(g.Rooms) + 1)
+				g.Rooms = append(g.Rooms, q)
+			}
+		}
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
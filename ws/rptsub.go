@@ -0,0 +1,371 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"time"
+)
+
+// ReportSubscriptionGrid is the UI representation of a ReportSubscription
+type ReportSubscriptionGrid struct {
+	Recid       int64 `json:"recid"`
+	RSUBID      int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	ReportName  string
+	DateRange   int64
+	Format      int64
+	Recipients  string
+	Cycle       int64
+	DtNext      rlib.JSONDateTime
+	DtLast      rlib.JSONDateTime
+	FLAGS       uint64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// ReportSubscriptionSearchResponse is the response to a search request for
+// ReportSubscription records
+type ReportSubscriptionSearchResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []ReportSubscriptionGrid `json:"records"`
+}
+
+// ReportSubscriptionGetResponse is the response to a get request for a
+// single ReportSubscription
+type ReportSubscriptionGetResponse struct {
+	Status string                 `json:"status"`
+	Record ReportSubscriptionGrid `json:"record"`
+}
+
+// ReportSubscriptionSaveForm is the form data for a ReportSubscription
+type ReportSubscriptionSaveForm struct {
+	Recid      int64 `json:"recid"`
+	RSUBID     int64
+	BID        int64
+	BUD        rlib.XJSONBud
+	ReportName string
+	DateRange  int64
+	Format     int64
+	Recipients string
+	Cycle      int64
+	DtNext     rlib.JSONDateTime
+	FLAGS      uint64
+}
+
+// SaveReportSubscriptionInput is the input data format for a Save command
+type SaveReportSubscriptionInput struct {
+	Recid    int64                      `json:"recid"`
+	Status   string                     `json:"status"`
+	FormName string                     `json:"name"`
+	Record   ReportSubscriptionSaveForm `json:"record"`
+}
+
+// ReportDeliveryGrid is the UI representation of a ReportDelivery
+type ReportDeliveryGrid struct {
+	Recid      int64 `json:"recid"`
+	RDID       int64
+	RSUBID     int64
+	BID        int64
+	Dt         rlib.JSONDateTime
+	DtStart    rlib.JSONDate
+	DtStop     rlib.JSONDate
+	Recipients string
+	Status     int64
+	Message    string
+}
+
+// ReportDeliverySearchResponse is the delivery history of a ReportSubscription
+type ReportDeliverySearchResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []ReportDeliveryGrid `json:"records"`
+}
+
+// SvcHandlerReportSubscription handles the scheduled report subscriptions
+// for a business. For this call, we expect the URI to contain the BID and
+// the RSUBID as follows:
+//       0    1      2     3
+// 		/v1/rptsub/BID/RSUBID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerReportSubscription(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerReportSubscription"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RSUBID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerReportSubscriptions(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("RSUBID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getReportSubscription(w, r, d)
+		}
+	case "save":
+		saveReportSubscription(w, r, d)
+	case "delete":
+		deleteReportSubscription(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerReportSubscriptions returns the report subscriptions for
+// business d.BID
+// wsdoc {
+//  @Title  Search Report Subscriptions
+//	@URL /v1/rptsub/:BUI
+//  @Method  POST
+//	@Synopsis Search Report Subscriptions
+//  @Descr  Return the scheduled report subscriptions for the business.
+//	@Input WebGridSearchRequest
+//  @Response ReportSubscriptionSearchResponse
+// wsdoc }
+func SvcSearchHandlerReportSubscriptions(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerReportSubscriptions"
+	var g ReportSubscriptionSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetReportSubscriptionsByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q ReportSubscriptionGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getReportSubscription returns the requested ReportSubscription
+// wsdoc {
+//  @Title  Get Report Subscription
+//	@URL /v1/rptsub/:BUI/:RSUBID
+//  @Method  GET
+//	@Synopsis Get information on a ReportSubscription
+//  @Description  Return all fields for report subscription :RSUBID
+//	@Input WebGridSearchRequest
+//  @Response ReportSubscriptionGetResponse
+// wsdoc }
+func getReportSubscription(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getReportSubscription"
+	var g ReportSubscriptionGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetReportSubscription(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RSUBID > 0 && a.BID != d.BID {
+		e := fmt.Errorf("%s: report subscription %d not found", funcname, d.ID)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if a.RSUBID > 0 {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.RSUBID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveReportSubscription creates or updates a ReportSubscription
+// wsdoc {
+//  @Title  Save Report Subscription
+//	@URL /v1/rptsub/:BUI/:RSUBID
+//  @Method  POST
+//	@Synopsis Create or update a ReportSubscription
+//  @Description  Saves the report subscription with the supplied data. If
+//  @Description  RSUBID is 0 a new subscription is created.
+//	@Input SaveReportSubscriptionInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveReportSubscription(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveReportSubscription"
+	var (
+		foo SaveReportSubscriptionInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.ReportSubscription
+	rlib.MigrateStructVals(&foo.Record, &a)
+	a.BID = d.BID // the business is the one in the URL, not whatever the form says
+	if err = validateReportSubscription(&a); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if a.RSUBID > 0 {
+		// keep the delivery history fields from the existing record
+		b, err := rlib.GetReportSubscription(r.Context(), a.RSUBID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if b.RSUBID == 0 || b.BID != a.BID {
+			e := fmt.Errorf("%s: report subscription %d not found", funcname, a.RSUBID)
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		a.DtLast = b.DtLast
+		a.CreateTS = b.CreateTS
+		a.CreateBy = b.CreateBy
+	}
+	if !a.DtNext.After(rlib.TIME0) {
+		a.DtNext = time.Now()
+	}
+
+	if a.RSUBID == 0 {
+		err = rlib.InsertReportSubscription(r.Context(), &a)
+	} else {
+		err = rlib.UpdateReportSubscription(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving report subscription: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// validateReportSubscription checks the user supplied fields of a
+func validateReportSubscription(a *rlib.ReportSubscription) error {
+	if !rrpt.FindSingleTableReport(a.ReportName).Found && !rrpt.FindMultiTableReport(a.ReportName).Found {
+		return fmt.Errorf("unknown report: %s", a.ReportName)
+	}
+	switch a.Format {
+	case gotable.TABLEOUTTEXT, gotable.TABLEOUTHTML, gotable.TABLEOUTCSV, gotable.TABLEOUTPDF:
+	default:
+		return fmt.Errorf("unsupported report format: %d", a.Format)
+	}
+	if a.DateRange < 0 || a.DateRange > rlib.RPTRANGELAST {
+		return fmt.Errorf("unknown date range: %d", a.DateRange)
+	}
+	if a.Cycle != rlib.RECURNONE && (a.Cycle < rlib.RECURDAILY || a.Cycle > rlib.RECURLAST) {
+		return fmt.Errorf("report subscriptions can be delivered daily or less frequently")
+	}
+	if len(rlib.ReportSubscriptionRecipients(a.Recipients)) == 0 {
+		return fmt.Errorf("at least one valid recipient email address is required")
+	}
+	return nil
+}
+
+// deleteReportSubscription deletes a ReportSubscription
+// wsdoc {
+//  @Title  Delete Report Subscription
+//	@URL /v1/rptsub/:BUI/:RSUBID
+//  @Method  POST
+//	@Synopsis Delete a Report Subscription
+//  @Desc  This service deletes a ReportSubscription.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteReportSubscription(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteReportSubscription"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetReportSubscription(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RSUBID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: report subscription %d not found", funcname, del.ID)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err := rlib.DeleteReportSubscription(r.Context(), del.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcReportDeliveries returns the delivery history of a ReportSubscription
+// wsdoc {
+//  @Title  Report Subscription Delivery History
+//	@URL /v1/rptsubhist/:BUI/:RSUBID
+//  @Method  GET
+//	@Synopsis Get the delivery history of a ReportSubscription
+//  @Description  Return each delivery attempt for report subscription :RSUBID,
+//  @Description  most recent first.
+//	@Input WebGridSearchRequest
+//  @Response ReportDeliverySearchResponse
+// wsdoc }
+func SvcReportDeliveries(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcReportDeliveries"
+	var g ReportDeliverySearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	if d.ID <= 0 {
+		SvcErrorReturn(w, fmt.Errorf("RSUBID is required but was not specified"), funcname)
+		return
+	}
+	a, err := rlib.GetReportSubscription(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.RSUBID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: report subscription %d not found", funcname, d.ID)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	m, err := rlib.GetReportDeliveries(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q ReportDeliveryGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
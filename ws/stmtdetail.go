@@ -18,11 +18,11 @@ type StatementDetail struct {
 	Reverse      bool          // is this a reversal
 	Dt           rlib.JSONDate // date of the assessment or payment
 	Descr        string        // about the assessment/receipt
-	Receipt      float64       // amount of payment remitted by payor
-	AsmtAmount   float64       // amount of assessment
-	RcptAmount   float64       // amount of receipt allocation
+	Receipt      rlib.Money    // amount of payment remitted by payor
+	AsmtAmount   rlib.Money    // amount of assessment
+	RcptAmount   rlib.Money    // amount of receipt allocation
 	RentableName string        // associated rentable name
-	Balance      float64       // sum
+	Balance      rlib.Money    // sum
 	FLAGS        uint64        // Rcpt / Asmt flags
 }
 
@@ -97,7 +97,7 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	//--------------------------------------------
 	// Set the opening balance.
 	//--------------------------------------------
-	var b, c, d float64
+	var b, c, d rlib.Money
 	var a = StatementDetail{
 		BID:     sd.BID,
 		BUD:     rlib.XJSONBud(bud),
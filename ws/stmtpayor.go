@@ -240,10 +240,10 @@ type payorStmtEntry struct {
 	RCPTID          string
 	RentableName    string
 	Description     string
-	UnappliedAmount float64
-	AppliedAmount   float64
-	Assessment      float64
-	Balance         float64
+	UnappliedAmount rlib.Money
+	AppliedAmount   rlib.Money
+	Assessment      rlib.Money
+	Balance         rlib.Money
 }
 
 // PayorStmtDetailResponse is the response data for a detailed PayorStatement targeted for a grid
@@ -359,7 +359,7 @@ func getPayorStmt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		pe.Description = "No unapplied funds from other payors this period"
 		safeAddPayorStmtEntry(&pe, &psdr, &ctx)
 	} else {
-		totUnapplied := float64(0)
+		totUnapplied := rlib.Money(0)
 		for i := 0; i < lenmRL; i++ {
 			if m.RL[i].R.TCID == d.ID {
 				continue
@@ -390,7 +390,7 @@ func getPayorStmt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		}
 		if external { // if it is external view, indicate if there are other unapplied funds
 			var pe payorStmtEntry
-			if totUnapplied > float64(0) {
+			if totUnapplied > 0 {
 				pe.Description = "There are unapplied funds from other payors"
 			} else {
 				pe.Description = "No unapplied funds from other payors this period"
@@ -432,7 +432,7 @@ func getPayorStmt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		// init running totals
 		//------------------------
 		bal := m.RAB[i].OpeningBal
-		asmts := float64(0)
+		asmts := rlib.Money(0)
 		applied := asmts
 		// unapplied := asmts
 
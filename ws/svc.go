@@ -154,10 +154,21 @@ var Svcs = []ServiceHandler{
 	{Cmd: "ar", Handler: SvcFormHandlerAR, NeedBiz: true, NeedSession: true},
 	{Cmd: "ars", Handler: SvcSearchHandlerARs, NeedBiz: true, NeedSession: true},
 	{Cmd: "arslist", Handler: SvcARsList, NeedBiz: true, NeedSession: true},
+	{Cmd: "arsim", Handler: SvcAcctRuleSim, NeedBiz: true, NeedSession: true},
 	{Cmd: "asm", Handler: SvcFormHandlerAssessment, NeedBiz: true, NeedSession: true},
 	{Cmd: "asms", Handler: SvcSearchHandlerAssessments, NeedBiz: true, NeedSession: true},
+	{Cmd: "asmstep", Handler: SvcHandlerAssessmentStep, NeedBiz: true, NeedSession: true},
 	{Cmd: "authn", Handler: SvcAuthenticate, NeedBiz: false, NeedSession: false},
+	{Cmd: "availability", Handler: SvcAvailability, NeedBiz: true, NeedSession: true},
+	{Cmd: "bill", Handler: SvcHandlerBill, NeedBiz: true, NeedSession: true},
+	{Cmd: "campool", Handler: SvcHandlerCAMPool, NeedBiz: true, NeedSession: true},
+	{Cmd: "camrecon", Handler: SvcHandlerCAMRecon, NeedBiz: true, NeedSession: true},
+	{Cmd: "cardpayment", Handler: SvcHandlerCardPayment, NeedBiz: true, NeedSession: true},
+	{Cmd: "check", Handler: SvcHandlerCheck, NeedBiz: true, NeedSession: true},
+	{Cmd: "checkaccount", Handler: SvcHandlerCheckAccount, NeedBiz: true, NeedSession: true},
+	{Cmd: "checkregister", Handler: SvcCheckRegister, NeedBiz: true, NeedSession: true},
 	{Cmd: "closeperiod", Handler: SvcHandlerClosePeriod, NeedBiz: true, NeedSession: true},
+	{Cmd: "concession", Handler: SvcHandlerConcession, NeedBiz: true, NeedSession: true},
 	{Cmd: "dep", Handler: SvcHandlerDepository, NeedBiz: true, NeedSession: true},
 	{Cmd: "depmeth", Handler: SvcHandlerDepositMethod, NeedBiz: true, NeedSession: true},
 	{Cmd: "deposit", Handler: SvcHandlerDeposit, NeedBiz: true, NeedSession: true},
@@ -168,16 +179,30 @@ var Svcs = []ServiceHandler{
 	{Cmd: "exportaccounts", Handler: SvcExportGLAccounts, NeedBiz: true, NeedSession: true},
 	{Cmd: "flow", Handler: SvcHandlerFlow, NeedBiz: true, NeedSession: true},
 	{Cmd: "importaccounts", Handler: SvcImportGLAccounts, NeedBiz: true, NeedSession: true},
+	{Cmd: "leasedoc", Handler: SvcHandlerLeaseDoc, NeedBiz: true, NeedSession: true},
+	{Cmd: "leasedocfile", Handler: SvcLeaseDocFile, NeedBiz: true, NeedSession: true},
+	{Cmd: "leasetemplate", Handler: SvcHandlerLeaseTemplate, NeedBiz: true, NeedSession: true},
 	{Cmd: "ledger", Handler: SvcLedgerHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "ledgers", Handler: SvcLedgerHandler, NeedBiz: true, NeedSession: true},
 	{Cmd: "logoff", Handler: SvcLogoff, NeedBiz: false, NeedSession: true},
+	{Cmd: "maintrequests", Handler: SvcMaintRequests, NeedBiz: true, NeedSession: true},
+	{Cmd: "meterreading", Handler: SvcHandlerMeterReading, NeedBiz: true, NeedSession: true},
+	{Cmd: "nightaudit", Handler: SvcHandlerNightAudit, NeedBiz: true, NeedSession: true},
+	{Cmd: "occupancy", Handler: SvcOccupancyTrend, NeedBiz: true, NeedSession: true},
 	{Cmd: "parentaccounts", Handler: SvcParentAccountsList, NeedBiz: true, NeedSession: true},
+	{Cmd: "paymentgateway", Handler: SvcHandlerPaymentGateway, NeedBiz: true, NeedSession: true},
 	{Cmd: "payorfund", Handler: SvcHandlerTotalUnallocFund, NeedBiz: true, NeedSession: true},
 	{Cmd: "payorstmt", Handler: SvcPayorStmtDispatch, NeedBiz: true, NeedSession: true},
 	{Cmd: "payorstmtinfo", Handler: SvcGetPayorStmInfo, NeedBiz: true, NeedSession: true},
+	{Cmd: "pctrent", Handler: SvcHandlerPctRent, NeedBiz: true, NeedSession: true},
 	{Cmd: "person", Handler: SvcFormHandlerXPerson, NeedBiz: true, NeedSession: true},
 	{Cmd: "ping", Handler: SvcHandlerPing, NeedBiz: false, NeedSession: false},
 	{Cmd: "pmts", Handler: SvcHandlerPaymentType, NeedBiz: true, NeedSession: true},
+	{Cmd: "portal", Handler: SvcPortal, NeedBiz: true, NeedSession: false},
+	{Cmd: "portaluser", Handler: SvcHandlerPortalUser, NeedBiz: true, NeedSession: true},
+	{Cmd: "positivepay", Handler: SvcHandlerPositivePay, NeedBiz: true, NeedSession: true},
+	{Cmd: "positivepayfile", Handler: SvcPositivePayFile, NeedBiz: true, NeedSession: true},
+	{Cmd: "positivepayformat", Handler: SvcHandlerPositivePayFormat, NeedBiz: true, NeedSession: true},
 	{Cmd: "postaccounts", Handler: SvcPostAccountsList, NeedBiz: true, NeedSession: true},
 	{Cmd: "raactions", Handler: SvcSetRAState, NeedBiz: true, NeedSession: true},
 	{Cmd: "raflow-person", Handler: SvcRAFlowPersonHandler, NeedBiz: true, NeedSession: true},
@@ -194,11 +219,15 @@ var Svcs = []ServiceHandler{
 	{Cmd: "rentabletyperef", Handler: SvcHandlerRentableTypeRef, NeedBiz: true, NeedSession: true},
 	{Cmd: "rentalagrtd", Handler: SvcRentalAgreementTypeDown, NeedBiz: true, NeedSession: true},
 	{Cmd: "report", Handler: ReportServiceHandler, NeedBiz: true, NeedSession: true},
+	{Cmd: "reservation", Handler: SvcHandlerReservation, NeedBiz: true, NeedSession: true},
 	{Cmd: "resetpw", Handler: SvcResetPW, NeedBiz: false, NeedSession: false},
 	{Cmd: "rmr", Handler: SvcHandlerRentableMarketRates, NeedBiz: true, NeedSession: true},
 	{Cmd: "rr", Handler: SvcRR, NeedBiz: true, NeedSession: true},
+	{Cmd: "rptsub", Handler: SvcHandlerReportSubscription, NeedBiz: true, NeedSession: true},
+	{Cmd: "rptsubhist", Handler: SvcReportDeliveries, NeedBiz: true, NeedSession: true},
 	{Cmd: "rt", Handler: SvcHandlerRentableType, NeedBiz: true, NeedSession: true},
 	{Cmd: "rtlist", Handler: SvcRentableTypesTD, NeedBiz: true, NeedSession: true},
+	{Cmd: "salesreport", Handler: SvcHandlerSalesReport, NeedBiz: true, NeedSession: true},
 	{Cmd: "stmt", Handler: SvcStatement, NeedBiz: true, NeedSession: true},
 	{Cmd: "stmtdetail", Handler: SvcStatementDetail, NeedBiz: true, NeedSession: true},
 	{Cmd: "stmtinfo", Handler: SvcGetStatementInfo, NeedBiz: true, NeedSession: true},
@@ -217,8 +246,15 @@ var Svcs = []ServiceHandler{
 	{Cmd: "uival", Handler: SvcUIVal, NeedBiz: false, NeedSession: false},
 	{Cmd: "unpaidasms", Handler: SvcHandlerGetUnpaidAsms, NeedBiz: true, NeedSession: true},
 	{Cmd: "userprofile", Handler: SvcUserProfile, NeedBiz: false, NeedSession: true},
+	{Cmd: "utilitybill", Handler: SvcHandlerUtilityBill, NeedBiz: true, NeedSession: true},
 	{Cmd: "validate-raflow", Handler: SvcValidateRAFlow, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendor", Handler: SvcHandlerVendor, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendor1099", Handler: SvcVendor1099, NeedBiz: true, NeedSession: true},
+	{Cmd: "vendorpayment", Handler: SvcHandlerVendorPayment, NeedBiz: true, NeedSession: true},
 	{Cmd: "version", Handler: SvcHandlerVersion, NeedBiz: false, NeedSession: false},
+	{Cmd: "webhook", Handler: SvcHandlerWebhook, NeedBiz: true, NeedSession: true},
+	{Cmd: "webhooklog", Handler: SvcWebhookDeliveries, NeedBiz: true, NeedSession: true},
+	{Cmd: "workorder", Handler: SvcHandlerWorkOrder, NeedBiz: true, NeedSession: true},
 }
 
 // SvcCtx contains information global to the Svc handlers
@@ -329,6 +365,11 @@ func V1ServiceHandler(w http.ResponseWriter, r *http.Request) {
 	d.ID = -1  // indicates it has not been set
 	d.BID = -1 // indicates it has not been set
 
+	start := time.Now()
+	mw := &svcMetricsWriter{ResponseWriter: w}
+	w = mw
+	defer func() { svcRecordMetrics(d.Service, start, mw) }()
+
 	findSession(w, &r, &d) // we don't care about the error return here. We do further below
 
 	//-----------------------------------------------------------------------
@@ -484,6 +525,7 @@ func getBIDfromBUI(s string) (int64, error) {
 func SvcErrorReturn(w http.ResponseWriter, err error, funcname string) {
 	// rlib.Console("<Function>: %s | <Error>: %s\n", funcname, err.Error())
 	rlib.Console("%s: %s\n", funcname, err.Error())
+	svcMarkFailed(w)
 	var e SvcStatus
 	e.Status = "error"
 	e.Message = fmt.Sprintf("Error: %s\n", err.Error())
@@ -0,0 +1,583 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// UtilityBillGrid is the UI representation of a UtilityBill
+type UtilityBillGrid struct {
+	Recid     int64 `json:"recid"`
+	UBID      int64
+	BID       int64
+	Utility   string
+	ARID      int64
+	Method    int64
+	Amount    rlib.Money
+	CommonPct float64
+	DtStart   rlib.JSONDate
+	DtStop    rlib.JSONDate
+	DtPosted  rlib.JSONDate
+	FLAGS     uint64
+	Posted    bool
+}
+
+// UtilityChargeGrid is the UI representation of a UtilityCharge. Descr is
+// the breakdown shown to the resident.
+type UtilityChargeGrid struct {
+	Recid      int64 `json:"recid"`
+	UCID       int64
+	UBID       int64
+	RAID       int64
+	RID        int64
+	DtStart    rlib.JSONDate
+	DtStop     rlib.JSONDate
+	Days       int64
+	Sqft       int64
+	Occupants  int64
+	MeterUsage float64
+	Ratio      float64
+	Share      float64
+	Amount     rlib.Money
+	ASMID      int64
+	Descr      string
+}
+
+// UtilityRatioGrid is the UI representation of a UtilityRatio
+type UtilityRatioGrid struct {
+	Recid int64 `json:"recid"`
+	URID  int64
+	RID   int64
+	Ratio float64
+}
+
+// UtilityBillSearchResponse lists utility bills
+type UtilityBillSearchResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []UtilityBillGrid `json:"records"`
+}
+
+// UtilityBillResponse is a utility bill with its resident charges and, for
+// the fixed ratio method, the ratio of each unit
+type UtilityBillResponse struct {
+	Status  string              `json:"status"`
+	Record  UtilityBillGrid     `json:"record"`
+	Charges []UtilityChargeGrid `json:"charges"`
+	Ratios  []UtilityRatioGrid  `json:"ratios"`
+}
+
+// UtilityBillRequest is the request data of the utilitybill commands.
+// Record is the bill for compute and save, Ratios the fixed ratio of each
+// unit when Record.Method is 5. Dt is the date of the resident charges for
+// post.
+type UtilityBillRequest struct {
+	Record UtilityBillGrid
+	Ratios []UtilityRatioGrid
+	Dt     rlib.JSONDate
+}
+
+// MeterReadingGrid is the UI representation of a MeterReading
+type MeterReadingGrid struct {
+	Recid   int64 `json:"recid"`
+	MRID    int64
+	BID     int64
+	RID     int64
+	Utility string
+	Dt      rlib.JSONDate
+	Reading float64
+}
+
+// MeterReadingSearchResponse lists meter readings
+type MeterReadingSearchResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []MeterReadingGrid `json:"records"`
+}
+
+// MeterReadingRequest is the request data of the meterreading commands.
+// Utility selects the readings for get, Record is the reading for save.
+type MeterReadingRequest struct {
+	Utility string
+	Record  MeterReadingGrid
+}
+
+// SvcHandlerUtilityBill handles the master utility bills of a business and
+// their split between the residents. For this call, we expect the URI to
+// contain the BID and the UBID as follows:
+//       0    1              2     3
+// 		/v1/utilitybill/BID/UBID
+//
+// The request data is a UtilityBillRequest. The breakdown of the charges is
+// printed with /v1/report, report RPTutilitybill with the UBID.
+//
+// The server command can be:
+//      list
+//      get
+//      compute
+//      save
+//      post
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerUtilityBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerUtilityBill"
+	var req UtilityBillRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  UBID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "list":
+		listUtilityBills(w, r, d)
+	case "get":
+		getUtilityBill(w, r, d)
+	case "compute", "save":
+		computeUtilityBill(w, r, d, &req)
+	case "post":
+		postUtilityBill(w, r, d, &req)
+	case "delete":
+		deleteUtilityBill(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// utilityBillResponse fills g with bill b, its charges and its ratios
+func utilityBillResponse(g *UtilityBillResponse, b *rlib.UtilityBill, m []rlib.UtilityCharge, r []rlib.UtilityRatio) {
+	rlib.MigrateStructVals(b, &g.Record)
+	g.Record.Recid = b.UBID
+	g.Record.Posted = b.FLAGS&rlib.UTILBILLPosted != 0
+	g.Charges = []UtilityChargeGrid{}
+	for i := 0; i < len(m); i++ {
+		var q UtilityChargeGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].UCID
+		if q.Recid == 0 {
+			q.Recid = int64(i + 1) // not saved yet
+		}
+		q.Descr = rlib.UtilityChargeDescr(b, &m[i])
+		g.Charges = append(g.Charges, q)
+	}
+	g.Ratios = []UtilityRatioGrid{}
+	for i := 0; i < len(r); i++ {
+		q := UtilityRatioGrid{Recid: int64(i + 1), URID: r[i].URID, RID: r[i].RID, Ratio: r[i].Ratio}
+		g.Ratios = append(g.Ratios, q)
+	}
+}
+
+// listUtilityBills lists the utility bills of a business
+// wsdoc {
+//  @Title  Utility Bills
+//	@URL /v1/utilitybill/:BUI
+//  @Method  POST
+//	@Synopsis List the utility bills of a business
+//  @Descr  Returns the utility bills whose billing period overlaps
+//  @Descr  searchDtStart - searchDtStop.
+//	@Input WebGridSearchRequest
+//  @Response UtilityBillSearchResponse
+// wsdoc }
+func listUtilityBills(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "listUtilityBills"
+	var g UtilityBillSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1 := time.Time(d.wsSearchReq.SearchDtStart)
+	d2 := time.Time(d.wsSearchReq.SearchDtStop)
+	m, err := rlib.GetUtilityBillsByRange(r.Context(), d.BID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []UtilityBillGrid{}
+	for i := 0; i < len(m); i++ {
+		var q UtilityBillGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].UBID
+		q.Posted = m[i].FLAGS&rlib.UTILBILLPosted != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getUtilityBill returns a utility bill with its charges
+// wsdoc {
+//  @Title  Get Utility Bill
+//	@URL /v1/utilitybill/:BUI/:UBID
+//  @Method  POST
+//	@Synopsis Get a Utility Bill
+//  @Descr  Returns utility bill :UBID and the charge of each Rental
+//  @Descr  Agreement with its breakdown.
+//	@Input UtilityBillRequest
+//  @Response UtilityBillResponse
+// wsdoc }
+func getUtilityBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getUtilityBill"
+	var g UtilityBillResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	b, err := rlib.GetUtilityBill(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.UBID == 0 || b.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("utility bill %d not found", d.ID), funcname)
+		return
+	}
+	m, err := rlib.GetUtilityCharges(r.Context(), b.UBID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	ratios, err := rlib.GetUtilityRatios(r.Context(), b.UBID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	utilityBillResponse(&g, &b, m, ratios)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// computeUtilityBill splits a utility bill between the residents. The
+// compute command only returns the charges, save also saves them.
+// wsdoc {
+//  @Title  Compute Utility Bill
+//	@URL /v1/utilitybill/:BUI/:UBID
+//  @Method  POST
+//	@Synopsis Split a Utility Bill between the residents
+//  @Descr  Computes the charge of each Rental Agreement for bill Record.
+//  @Descr  Method is 1 square feet, 2 occupants, 3 equal split, 4
+//  @Descr  sub-meter or 5 fixed ratio. With the fixed ratio method Ratios
+//  @Descr  gives the ratio of each unit billed, ex: 40, 35 and 25, if it is
+//  @Descr  empty the ratios saved with bill :UBID are used. CommonPct percent of the bill is for the common areas
+//  @Descr  and is not charged. The share of the time a unit was vacant is
+//  @Descr  not charged either. With the save command the bill is saved, or
+//  @Descr  updated if it has not been posted.
+//	@Input UtilityBillRequest
+//  @Response UtilityBillResponse
+// wsdoc }
+func computeUtilityBill(w http.ResponseWriter, r *http.Request, d *ServiceData, req *UtilityBillRequest) {
+	const funcname = "computeUtilityBill"
+	var g UtilityBillResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	var b rlib.UtilityBill
+	if req.Record.UBID > 0 {
+		if b, err = rlib.GetUtilityBill(ctx, req.Record.UBID); err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if b.UBID == 0 || b.BID != d.BID {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("utility bill %d not found", req.Record.UBID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(&req.Record, &b)
+	b.BID = d.BID
+
+	var ratios []rlib.UtilityRatio
+	for i := 0; i < len(req.Ratios); i++ {
+		ratios = append(ratios, rlib.UtilityRatio{RID: req.Ratios[i].RID, Ratio: req.Ratios[i].Ratio})
+	}
+	if len(ratios) == 0 && b.UBID > 0 {
+		if ratios, err = rlib.GetUtilityRatios(ctx, b.UBID); err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	if b.Method != rlib.UTILMETHODRatio {
+		ratios = nil
+	}
+
+	var (
+		m       []rlib.UtilityCharge
+		errlist []bizlogic.BizError
+	)
+	if d.wsSearchReq.Cmd == "save" {
+		if errlist = bizlogic.SaveUtilityBill(ctx, &b, ratios); len(errlist) == 0 {
+			if m, err = rlib.GetUtilityCharges(ctx, b.UBID); err != nil {
+				tx.Rollback()
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+		}
+	} else {
+		m, errlist = bizlogic.ComputeUtilityBill(ctx, &b, ratios)
+	}
+	if len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	utilityBillResponse(&g, &b, m, ratios)
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// postUtilityBill assesses the charges of a utility bill
+// wsdoc {
+//  @Title  Post Utility Bill
+//	@URL /v1/utilitybill/:BUI/:UBID
+//  @Method  POST
+//	@Synopsis Post the resident charges of a Utility Bill
+//  @Descr  Adds a one time assessment on date Dt, with the bill's account
+//  @Descr  rule, for each charge of bill :UBID. Dt defaults to the last day
+//  @Descr  of the billing period. A posted bill cannot be changed or
+//  @Descr  deleted.
+//	@Input UtilityBillRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func postUtilityBill(w http.ResponseWriter, r *http.Request, d *ServiceData, req *UtilityBillRequest) {
+	const funcname = "postUtilityBill"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	b, err := rlib.GetUtilityBill(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.UBID == 0 || b.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("utility bill %d not found", d.ID), funcname)
+		return
+	}
+	dt := time.Time(req.Dt)
+	if dt.IsZero() {
+		dt = b.DtStop.AddDate(0, 0, -1)
+	}
+	if errlist := bizlogic.PostUtilityBill(ctx, &b, &dt); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, b.UBID)
+}
+
+// deleteUtilityBill deletes a utility bill that has not been posted
+// wsdoc {
+//  @Title  Delete Utility Bill
+//	@URL /v1/utilitybill/:BUI/:UBID
+//  @Method  POST
+//	@Synopsis Delete a Utility Bill
+//  @Desc  This service deletes utility bill :UBID and its charges. A posted
+//  @Desc  bill cannot be deleted.
+//	@Input UtilityBillRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteUtilityBill(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteUtilityBill"
+
+	fmt.Printf("Entered %s\n", funcname)
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	b, err := rlib.GetUtilityBill(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if b.UBID == 0 || b.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("utility bill %d not found", d.ID), funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteUtilityBill(ctx, &b); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcHandlerMeterReading handles the sub-meter readings of a business. For
+// this call, we expect the URI to contain the BID and the MRID as follows:
+//       0    1               2     3
+// 		/v1/meterreading/BID/MRID
+//
+// The request data is a MeterReadingRequest.
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerMeterReading(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerMeterReading"
+	var req MeterReadingRequest
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  MRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	if len(d.data) > 0 {
+		if err := json.Unmarshal([]byte(d.data), &req); err != nil {
+			e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getMeterReadings(w, r, d, &req)
+	case "save":
+		saveMeterReading(w, r, d, &req)
+	case "delete":
+		deleteMeterReading(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getMeterReadings returns the readings of a utility
+// wsdoc {
+//  @Title  Meter Readings
+//	@URL /v1/meterreading/:BUI
+//  @Method  POST
+//	@Synopsis List the sub-meter readings of a utility
+//  @Descr  Returns the readings of Utility taken in searchDtStart -
+//  @Descr  searchDtStop, sorted by rentable and date.
+//	@Input MeterReadingRequest
+//  @Response MeterReadingSearchResponse
+// wsdoc }
+func getMeterReadings(w http.ResponseWriter, r *http.Request, d *ServiceData, req *MeterReadingRequest) {
+	const funcname = "getMeterReadings"
+	var g MeterReadingSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	d1 := time.Time(d.wsSearchReq.SearchDtStart)
+	d2 := time.Time(d.wsSearchReq.SearchDtStop)
+	m, err := rlib.GetMeterReadingsByRange(r.Context(), d.BID, req.Utility, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Records = []MeterReadingGrid{}
+	for i := 0; i < len(m); i++ {
+		var q MeterReadingGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].MRID
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveMeterReading creates or updates a meter reading
+// wsdoc {
+//  @Title  Save Meter Reading
+//	@URL /v1/meterreading/:BUI/:MRID
+//  @Method  POST
+//	@Synopsis Create or update a sub-meter reading
+//  @Description  Saves reading Record. If MRID is 0 a new reading is
+//  @Description  created.
+//	@Input MeterReadingRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveMeterReading(w http.ResponseWriter, r *http.Request, d *ServiceData, req *MeterReadingRequest) {
+	const funcname = "saveMeterReading"
+	var (
+		a   rlib.MeterReading
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if req.Record.MRID > 0 {
+		if a, err = rlib.GetMeterReading(r.Context(), req.Record.MRID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.MRID == 0 || a.BID != d.BID {
+			SvcErrorReturn(w, fmt.Errorf("meter reading %d not found", req.Record.MRID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(&req.Record, &a)
+	a.BID = d.BID
+
+	if errlist := bizlogic.SaveMeterReading(r.Context(), &a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.MRID)
+}
+
+// deleteMeterReading deletes a meter reading
+// wsdoc {
+//  @Title  Delete Meter Reading
+//	@URL /v1/meterreading/:BUI/:MRID
+//  @Method  POST
+//	@Synopsis Delete a sub-meter reading
+//  @Desc  This service deletes reading :MRID. Bills already saved keep the
+//  @Desc  usage they were computed with.
+//	@Input MeterReadingRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteMeterReading(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteMeterReading"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetMeterReading(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.MRID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("meter reading %d not found", d.ID), funcname)
+		return
+	}
+	if err = rlib.DeleteMeterReading(r.Context(), a.MRID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
@@ -0,0 +1,352 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// VendorGrid is the UI representation of a Vendor
+type VendorGrid struct {
+	Recid       int64 `json:"recid"`
+	VID         int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	Contact     string
+	Address     string
+	Address2    string
+	City        string
+	State       string
+	PostalCode  string
+	Country     string
+	Email       string
+	Phone       string
+	TaxID       string
+	DefaultLID  int64
+	Terms       int64
+	FLAGS       uint64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// VendorSearchResponse is the response to a search request for Vendor
+// records
+type VendorSearchResponse struct {
+	Status  string       `json:"status"`
+	Total   int64        `json:"total"`
+	Records []VendorGrid `json:"records"`
+}
+
+// VendorGetResponse is the response to a get request for a single Vendor
+type VendorGetResponse struct {
+	Status string     `json:"status"`
+	Record VendorGrid `json:"record"`
+}
+
+// SaveVendorInput is the input data format for a Save command
+type SaveVendorInput struct {
+	Recid    int64      `json:"recid"`
+	Status   string     `json:"status"`
+	FormName string     `json:"name"`
+	Record   VendorGrid `json:"record"`
+}
+
+// Vendor1099Grid is the total paid to a 1099 vendor in a year
+type Vendor1099Grid struct {
+	Recid      int64 `json:"recid"`
+	VID        int64
+	Name       string
+	TaxID      string
+	Total      rlib.Money
+	Reportable bool // true if Total reaches rlib.Vendor1099Threshold
+}
+
+// Vendor1099Response lists the 1099 totals for a year
+type Vendor1099Response struct {
+	Status  string           `json:"status"`
+	Year    int              `json:"year"`
+	Total   int64            `json:"total"`
+	Records []Vendor1099Grid `json:"records"`
+}
+
+// SvcHandlerVendor handles the vendors of a business. For this call, we
+// expect the URI to contain the BID and the VID as follows:
+//       0    1      2     3
+// 		/v1/vendor/BID/VID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerVendor"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  VID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerVendors(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("VID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getVendor(w, r, d)
+		}
+	case "save":
+		saveVendor(w, r, d)
+	case "delete":
+		deleteVendor(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerVendors returns the vendors for business d.BID
+// wsdoc {
+//  @Title  Search Vendors
+//	@URL /v1/vendor/:BUI
+//  @Method  POST
+//	@Synopsis Search Vendors
+//  @Descr  Return the vendors of the business sorted by name.
+//	@Input WebGridSearchRequest
+//  @Response VendorSearchResponse
+// wsdoc }
+func SvcSearchHandlerVendors(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerVendors"
+	var g VendorSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetVendorsByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q VendorGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getVendor returns the requested Vendor
+// wsdoc {
+//  @Title  Get Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  GET
+//	@Synopsis Get information on a Vendor
+//  @Description  Return all fields for vendor :VID
+//	@Input WebGridSearchRequest
+//  @Response VendorGetResponse
+// wsdoc }
+func getVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getVendor"
+	var g VendorGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetVendor(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.VID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.VID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveVendor creates or updates a Vendor
+// wsdoc {
+//  @Title  Save Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  POST
+//	@Synopsis Create or update a Vendor
+//  @Description  Saves the vendor with the supplied data. If VID is 0 a new
+//  @Description  vendor is created. Set FLAGS bit 1 for vendors whose
+//  @Description  payments are reported on a 1099.
+//	@Input SaveVendorInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveVendor"
+	var (
+		foo SaveVendorInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if len(f.Name) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("Name is required"), funcname)
+		return
+	}
+	if f.Terms < 0 {
+		SvcErrorReturn(w, fmt.Errorf("Terms cannot be negative"), funcname)
+		return
+	}
+
+	var a rlib.Vendor
+	if f.VID > 0 {
+		if a, err = rlib.GetVendor(r.Context(), f.VID); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.VID == 0 || a.BID != bid {
+			SvcErrorReturn(w, fmt.Errorf("vendor %d not found", f.VID), funcname)
+			return
+		}
+	}
+	rlib.MigrateStructVals(f, &a)
+	a.BID = bid
+
+	if a.VID == 0 {
+		err = rlib.InsertVendor(r.Context(), &a)
+	} else {
+		err = rlib.UpdateVendor(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving vendor: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.VID)
+}
+
+// deleteVendor deletes a Vendor
+// wsdoc {
+//  @Title  Delete Vendor
+//	@URL /v1/vendor/:BUI/:VID
+//  @Method  POST
+//	@Synopsis Delete a Vendor
+//  @Desc  This service deletes a vendor. A vendor that has bills cannot be
+//  @Desc  deleted, mark it inactive instead.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteVendor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteVendor"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetVendor(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.VID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("vendor %d not found", del.ID), funcname)
+		return
+	}
+	m, err := rlib.GetBillsByVID(r.Context(), a.VID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if len(m) > 0 {
+		SvcErrorReturn(w, fmt.Errorf("vendor %d has bills and cannot be deleted", a.VID), funcname)
+		return
+	}
+	if err = rlib.DeleteVendor(r.Context(), a.VID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcVendor1099 returns the 1099 totals of business d.BID for a year.
+// The year is the ID in the URI; if it is missing the year of
+// searchDtStop is used.
+// wsdoc {
+//  @Title  Vendor 1099 Totals
+//	@URL /v1/vendor1099/:BUI/:YEAR
+//  @Method  POST
+//	@Synopsis Total payments per 1099 vendor for a year
+//  @Descr  Returns the total paid during the year to each vendor whose
+//  @Descr  payments are reported on a 1099. Void payments are not counted.
+//  @Descr  Reportable is set for the vendors paid at least the 1099
+//  @Descr  threshold.
+//	@Input WebGridSearchRequest
+//  @Response Vendor1099Response
+// wsdoc }
+func SvcVendor1099(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcVendor1099"
+	var g Vendor1099Response
+
+	fmt.Printf("Entered %s\n", funcname)
+	g.Year = int(d.ID)
+	if g.Year <= 0 {
+		g.Year = time.Time(d.wsSearchReq.SearchDtStop).Year()
+	}
+	d1 := time.Date(g.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(1, 0, 0)
+	m, err := rlib.GetVendor1099Totals(r.Context(), d.BID, &d1, &d2)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, Vendor1099Grid{
+			Recid:      int64(i),
+			VID:        m[i].VID,
+			Name:       m[i].Name,
+			TaxID:      m[i].TaxID,
+			Total:      m[i].Total,
+			Reportable: m[i].Total >= rlib.Vendor1099Threshold,
+		})
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// vendorName returns the name of vendor vid, using and filling the cache
+// in names
+func vendorName(r *http.Request, vid int64, names map[int64]string) string {
+	if s, ok := names[vid]; ok {
+		return s
+	}
+	v, err := rlib.GetVendor(r.Context(), vid)
+	if err != nil || v.VID == 0 {
+		return fmt.Sprintf("vendor %d", vid)
+	}
+	names[vid] = v.Name
+	return v.Name
+}
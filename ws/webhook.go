@@ -0,0 +1,381 @@
+package ws
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"rentroll/rlib"
+	"strings"
+)
+
+// WebhookGrid is the UI representation of a WebhookSubscription. The secret
+// is only returned when a single subscription is requested.
+type WebhookGrid struct {
+	Recid       int64 `json:"recid"`
+	WHSID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	URL         string
+	Secret      string
+	Events      string
+	FLAGS       uint64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// WebhookSearchResponse is the response to a search request for
+// WebhookSubscription records
+type WebhookSearchResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []WebhookGrid `json:"records"`
+}
+
+// WebhookGetResponse is the response to a get request for a single
+// WebhookSubscription
+type WebhookGetResponse struct {
+	Status string      `json:"status"`
+	Record WebhookGrid `json:"record"`
+}
+
+// WebhookSaveForm is the form data for a WebhookSubscription
+type WebhookSaveForm struct {
+	Recid  int64 `json:"recid"`
+	WHSID  int64
+	BID    int64
+	BUD    rlib.XJSONBud
+	URL    string
+	Secret string // if blank, a new subscription gets a generated secret and an existing one keeps its secret
+	Events string
+	FLAGS  uint64
+}
+
+// SaveWebhookInput is the input data format for a Save command
+type SaveWebhookInput struct {
+	Recid    int64           `json:"recid"`
+	Status   string          `json:"status"`
+	FormName string          `json:"name"`
+	Record   WebhookSaveForm `json:"record"`
+}
+
+// WebhookDeliveryGrid is the UI representation of a WebhookDelivery
+type WebhookDeliveryGrid struct {
+	Recid        int64 `json:"recid"`
+	WHDID        int64
+	WHSID        int64
+	BID          int64
+	Event        string
+	Payload      string
+	Status       int64
+	Attempts     int64
+	DtNext       rlib.JSONDateTime
+	DtLast       rlib.JSONDateTime
+	ResponseCode int64
+	Message      string
+	CreateTS     rlib.JSONDateTime
+}
+
+// WebhookDeliverySearchResponse is the delivery log of a WebhookSubscription
+type WebhookDeliverySearchResponse struct {
+	Status  string                `json:"status"`
+	Total   int64                 `json:"total"`
+	Records []WebhookDeliveryGrid `json:"records"`
+}
+
+// SvcHandlerWebhook handles the webhook subscriptions for a business. For
+// this call, we expect the URI to contain the BID and the WHSID as follows:
+//       0    1       2     3
+// 		/v1/webhook/BID/WHSID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerWebhook(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerWebhook"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  WHSID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerWebhooks(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("WHSID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getWebhook(w, r, d)
+		}
+	case "save":
+		saveWebhook(w, r, d)
+	case "delete":
+		deleteWebhook(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerWebhooks returns the webhook subscriptions for business
+// d.BID
+// wsdoc {
+//  @Title  Search Webhook Subscriptions
+//	@URL /v1/webhook/:BUI
+//  @Method  POST
+//	@Synopsis Search Webhook Subscriptions
+//  @Descr  Return the webhook subscriptions for the business. Secrets are
+//  @Descr  not included.
+//	@Input WebGridSearchRequest
+//  @Response WebhookSearchResponse
+// wsdoc }
+func SvcSearchHandlerWebhooks(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerWebhooks"
+	var g WebhookSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetWebhookSubscriptionsByBID(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Total = int64(len(m))
+	for i := d.wsSearchReq.Offset; i < len(m) && len(g.Records) < d.wsSearchReq.Limit; i++ {
+		var q WebhookGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		q.Secret = ""
+		g.Records = append(g.Records, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getWebhook returns the requested WebhookSubscription
+// wsdoc {
+//  @Title  Get Webhook Subscription
+//	@URL /v1/webhook/:BUI/:WHSID
+//  @Method  GET
+//	@Synopsis Get information on a WebhookSubscription
+//  @Description  Return all fields for webhook subscription :WHSID, including
+//  @Description  the secret used to sign its events.
+//	@Input WebGridSearchRequest
+//  @Response WebhookGetResponse
+// wsdoc }
+func getWebhook(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getWebhook"
+	var g WebhookGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetWebhookSubscription(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.WHSID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.WHSID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveWebhook creates or updates a WebhookSubscription
+// wsdoc {
+//  @Title  Save Webhook Subscription
+//	@URL /v1/webhook/:BUI/:WHSID
+//  @Method  POST
+//	@Synopsis Create or update a WebhookSubscription
+//  @Description  Saves the webhook subscription with the supplied data. If
+//  @Description  WHSID is 0 a new subscription is created. If Secret is blank
+//  @Description  a new subscription is given a random secret.
+//	@Input SaveWebhookInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveWebhook(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveWebhook"
+	var (
+		foo SaveWebhookInput
+		err error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.WebhookSubscription
+	rlib.MigrateStructVals(&foo.Record, &a)
+
+	var ok bool
+	a.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err = validateWebhook(&a); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if a.WHSID > 0 {
+		b, err := rlib.GetWebhookSubscription(r.Context(), a.WHSID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if b.WHSID == 0 || b.BID != a.BID {
+			e := fmt.Errorf("%s: webhook subscription %d not found", funcname, a.WHSID)
+			SvcErrorReturn(w, e, funcname)
+			return
+		}
+		if len(a.Secret) == 0 {
+			a.Secret = b.Secret
+		}
+		a.CreateTS = b.CreateTS
+		a.CreateBy = b.CreateBy
+	}
+	if len(a.Secret) == 0 {
+		if a.Secret, err = newWebhookSecret(); err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+
+	if a.WHSID == 0 {
+		err = rlib.InsertWebhookSubscription(r.Context(), &a)
+	} else {
+		err = rlib.UpdateWebhookSubscription(r.Context(), &a)
+	}
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving webhook subscription: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// validateWebhook checks the user supplied fields of a
+func validateWebhook(a *rlib.WebhookSubscription) error {
+	u, err := url.Parse(a.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("URL must be a valid http or https address: %s", a.URL)
+	}
+	var evts []string
+	sa := strings.Split(a.Events, ",")
+	for i := 0; i < len(sa); i++ {
+		s := strings.TrimSpace(sa[i])
+		if len(s) == 0 {
+			continue
+		}
+		found := false
+		for j := 0; j < len(rlib.WebhookEvents); j++ {
+			if s == rlib.WebhookEvents[j] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown event: %s", s)
+		}
+		evts = append(evts, s)
+	}
+	a.Events = strings.Join(evts, ",")
+	return nil
+}
+
+// newWebhookSecret returns a random key for signing webhook payloads
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// deleteWebhook deletes a WebhookSubscription
+// wsdoc {
+//  @Title  Delete Webhook Subscription
+//	@URL /v1/webhook/:BUI/:WHSID
+//  @Method  POST
+//	@Synopsis Delete a Webhook Subscription
+//  @Desc  This service deletes a WebhookSubscription. Events queued for it
+//  @Desc  are marked failed by the delivery bot.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteWebhook(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteWebhook"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err := rlib.DeleteWebhookSubscription(r.Context(), del.ID); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// SvcWebhookDeliveries returns the delivery log of a WebhookSubscription
+// wsdoc {
+//  @Title  Webhook Delivery Log
+//	@URL /v1/webhooklog/:BUI/:WHSID
+//  @Method  POST
+//	@Synopsis Get the delivery log of a WebhookSubscription
+//  @Description  Return the most recent events queued for webhook subscription
+//  @Description  :WHSID along with the result of the delivery attempts, most
+//  @Description  recent first. Limit defaults to 100.
+//	@Input WebGridSearchRequest
+//  @Response WebhookDeliverySearchResponse
+// wsdoc }
+func SvcWebhookDeliveries(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcWebhookDeliveries"
+	var g WebhookDeliverySearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	if d.ID <= 0 {
+		SvcErrorReturn(w, fmt.Errorf("WHSID is required but was not specified"), funcname)
+		return
+	}
+	limit := d.wsSearchReq.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	m, err := rlib.GetWebhookDeliveries(r.Context(), d.ID, limit)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q WebhookDeliveryGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
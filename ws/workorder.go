@@ -0,0 +1,739 @@
+package ws
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkOrderGrid is the UI representation of a WorkOrder
+type WorkOrderGrid struct {
+	Recid        int64 `json:"recid"`
+	WOID         int64
+	BID          int64
+	BUD          rlib.XJSONBud
+	RID          int64
+	RentableName string
+	RAID         int64
+	MRQID        int64
+	NLID         int64
+	Category     string
+	Priority     int64
+	Status       int64
+	AssignedUID  int64
+	AssignedTo   string
+	Descr        string
+	DtOpen       rlib.JSONDateTime
+	DtDone       rlib.JSONDateTime
+	Cost         rlib.Money
+	ChargeASMID  int64
+	LastModTime  rlib.JSONDateTime
+	LastModBy    int64
+	CreateTS     rlib.JSONDateTime
+	CreateBy     int64
+}
+
+// WorkOrderNote is a note on a WorkOrder
+type WorkOrderNote struct {
+	NID      int64
+	Comment  string
+	CreateTS rlib.JSONDateTime
+	CreateBy int64
+}
+
+// WorkOrderSearchResponse is the response to a search request for
+// WorkOrder records
+type WorkOrderSearchResponse struct {
+	Status  string          `json:"status"`
+	Total   int64           `json:"total"`
+	Records []WorkOrderGrid `json:"records"`
+}
+
+// WorkOrderGetResponse is the response to a get request for a single
+// WorkOrder. It includes the work order's notes, oldest first.
+type WorkOrderGetResponse struct {
+	Status string          `json:"status"`
+	Record WorkOrderGrid   `json:"record"`
+	Notes  []WorkOrderNote `json:"notes"`
+}
+
+// WorkOrderSaveForm is the form data for a WorkOrder
+type WorkOrderSaveForm struct {
+	Recid       int64 `json:"recid"`
+	WOID        int64
+	BUD         rlib.XJSONBud
+	RID         int64
+	RAID        int64
+	MRQID       int64
+	Category    string
+	Priority    int64
+	Status      int64
+	AssignedUID int64
+	AssignedTo  string
+	Descr       string
+	DtOpen      rlib.JSONDateTime
+	Cost        rlib.Money
+}
+
+// SaveWorkOrderInput is the input data format for a Save command
+type SaveWorkOrderInput struct {
+	Recid    int64             `json:"recid"`
+	Status   string            `json:"status"`
+	FormName string            `json:"name"`
+	Record   WorkOrderSaveForm `json:"record"`
+}
+
+// WorkOrderNoteInput is the input for adding a note to a WorkOrder
+type WorkOrderNoteInput struct {
+	Cmd     string `json:"cmd"`
+	Comment string
+}
+
+// WorkOrderChargeBackInput is the input for charging the tenant for a
+// WorkOrder
+type WorkOrderChargeBackInput struct {
+	Cmd    string `json:"cmd"`
+	ARID   int64
+	Amount rlib.Money
+	Dt     rlib.JSONDate
+}
+
+// MaintRequestGrid is the UI representation of a tenant's MaintRequest
+type MaintRequestGrid struct {
+	Recid   int64 `json:"recid"`
+	MRQID   int64
+	BID     int64
+	RAID    int64
+	RID     int64
+	TCID    int64
+	Dt      rlib.JSONDateTime
+	Subject string
+	Descr   string
+	Status  int64
+}
+
+// MaintRequestSearchResponse lists the open tenant maintenance requests
+type MaintRequestSearchResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []MaintRequestGrid `json:"records"`
+}
+
+var workOrderFieldsMap = map[string][]string{
+	"WOID":         {"WorkOrder.WOID"},
+	"RID":          {"WorkOrder.RID"},
+	"RentableName": {"Rentable.RentableName"},
+	"RAID":         {"WorkOrder.RAID"},
+	"MRQID":        {"WorkOrder.MRQID"},
+	"Category":     {"WorkOrder.Category"},
+	"Priority":     {"WorkOrder.Priority"},
+	"Status":       {"WorkOrder.Status"},
+	"AssignedUID":  {"WorkOrder.AssignedUID"},
+	"AssignedTo":   {"WorkOrder.AssignedTo"},
+	"Descr":        {"WorkOrder.Descr"},
+	"DtOpen":       {"WorkOrder.DtOpen"},
+	"DtDone":       {"WorkOrder.DtDone"},
+	"Cost":         {"WorkOrder.Cost"},
+}
+
+// which fields needs to be fetched to satisfy the struct
+var workOrderQuerySelectFields = []string{
+	"WorkOrder.WOID",
+	"WorkOrder.BID",
+	"WorkOrder.RID",
+	"Rentable.RentableName",
+	"WorkOrder.RAID",
+	"WorkOrder.MRQID",
+	"WorkOrder.NLID",
+	"WorkOrder.Category",
+	"WorkOrder.Priority",
+	"WorkOrder.Status",
+	"WorkOrder.AssignedUID",
+	"WorkOrder.AssignedTo",
+	"WorkOrder.Descr",
+	"WorkOrder.DtOpen",
+	"WorkOrder.DtDone",
+	"WorkOrder.Cost",
+	"WorkOrder.ChargeASMID",
+	"WorkOrder.LastModTime",
+	"WorkOrder.LastModBy",
+	"WorkOrder.CreateTS",
+	"WorkOrder.CreateBy",
+}
+
+// workOrderRowScan scans a result from sql row and dumps it in a
+// WorkOrderGrid struct
+func workOrderRowScan(rows *sql.Rows) (WorkOrderGrid, error) {
+	var q WorkOrderGrid
+	var rn rlib.NullString
+	err := rows.Scan(&q.WOID, &q.BID, &q.RID, &rn, &q.RAID, &q.MRQID, &q.NLID, &q.Category, &q.Priority, &q.Status, &q.AssignedUID, &q.AssignedTo, &q.Descr, &q.DtOpen, &q.DtDone, &q.Cost, &q.ChargeASMID, &q.LastModTime, &q.LastModBy, &q.CreateTS, &q.CreateBy)
+	if rn.Valid {
+		q.RentableName = rn.String
+	}
+	return q, err
+}
+
+// SvcHandlerWorkOrder handles the maintenance work orders of a business.
+// For this call, we expect the URI to contain the BID and the WOID as
+// follows:
+//       0    1         2     3
+// 		/v1/workorder/BID/WOID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//      note
+//      chargeback
+//-----------------------------------------------------------------------------------
+func SvcHandlerWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcHandlerWorkOrder"
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  WOID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerWorkOrders(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err := fmt.Errorf("WOID is required but was not specified")
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			getWorkOrder(w, r, d)
+		}
+	case "save":
+		saveWorkOrder(w, r, d)
+	case "delete":
+		deleteWorkOrder(w, r, d)
+	case "note":
+		addWorkOrderNote(w, r, d)
+	case "chargeback":
+		chargeBackWorkOrder(w, r, d)
+	default:
+		err := fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerWorkOrders returns the work orders for business d.BID
+// wsdoc {
+//  @Title  Search Work Orders
+//	@URL /v1/workorder/:BUI
+//  @Method  POST
+//	@Synopsis Search Work Orders
+//  @Descr  Search all work orders of the business and return those that
+//  @Descr  match the Search Logic. Work orders opened in the searchDtStart -
+//  @Descr  searchDtStop range are returned along with any that are still
+//  @Descr  open.
+//	@Input WebGridSearchRequest
+//  @Response WorkOrderSearchResponse
+// wsdoc }
+func SvcSearchHandlerWorkOrders(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcSearchHandlerWorkOrders"
+	var (
+		g     WorkOrderSearchResponse
+		err   error
+		order = "WorkOrder.DtOpen DESC" // default ORDER
+		whr   = fmt.Sprintf("WorkOrder.BID=%d AND ((%q <= WorkOrder.DtOpen AND WorkOrder.DtOpen < %q) OR WorkOrder.Status<%d)", d.BID,
+			d.wsSearchReq.SearchDtStart.Format(rlib.RRDATEFMTSQL),
+			d.wsSearchReq.SearchDtStop.Format(rlib.RRDATEFMTSQL),
+			rlib.WOSTATUScompleted)
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	// get where clause and order clause for sql query
+	whereClause, orderClause := GetSearchAndSortSQL(d, workOrderFieldsMap)
+	if len(whereClause) > 0 {
+		whr += " AND (" + whereClause + ")"
+	}
+	if len(orderClause) > 0 {
+		order = orderClause
+	}
+
+	theQuery := `
+	SELECT
+		{{.SelectClause}}
+	FROM WorkOrder
+	LEFT JOIN Rentable ON WorkOrder.RID = Rentable.RID
+	WHERE {{.WhereClause}}
+	ORDER BY {{.OrderClause}}`
+
+	qc := rlib.QueryClause{
+		"SelectClause": strings.Join(workOrderQuerySelectFields, ","),
+		"WhereClause":  whr,
+		"OrderClause":  order,
+	}
+
+	// get TOTAL COUNT First
+	countQuery := rlib.RenderSQLQuery(theQuery, qc)
+	g.Total, err = rlib.GetQueryCount(countQuery)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	// FETCH the records WITH LIMIT AND OFFSET
+	limitAndOffsetClause := `
+	LIMIT {{.LimitClause}}
+	OFFSET {{.OffsetClause}};`
+	qc["LimitClause"] = strconv.Itoa(d.wsSearchReq.Limit)
+	qc["OffsetClause"] = strconv.Itoa(d.wsSearchReq.Offset)
+	qry := rlib.RenderSQLQuery(theQuery+limitAndOffsetClause, qc)
+	fmt.Printf("db query = %s\n", qry)
+
+	rows, err := rlib.RRdb.Dbrr.Query(qry)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	defer rows.Close()
+
+	i := int64(d.wsSearchReq.Offset)
+	for rows.Next() {
+		q, err := workOrderRowScan(rows)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		q.Recid = i
+		q.BUD = rlib.GetBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+		i++
+	}
+	if err = rows.Err(); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// getWorkOrder returns the requested WorkOrder
+// wsdoc {
+//  @Title  Get Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  GET
+//	@Synopsis Get information on a Work Order
+//  @Description  Return all fields and the notes for work order :WOID
+//	@Input WebGridSearchRequest
+//  @Response WorkOrderGetResponse
+// wsdoc }
+func getWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "getWorkOrder"
+	var g WorkOrderGetResponse
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetWorkOrder(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.WOID > 0 && a.BID == d.BID {
+		rlib.MigrateStructVals(&a, &g.Record)
+		g.Record.Recid = a.WOID
+		g.Record.BUD = rlib.GetBUDFromBIDList(a.BID)
+		rnt, err := rlib.GetRentable(r.Context(), a.RID)
+		if err != nil {
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		g.Record.RentableName = rnt.RentableName
+		if a.NLID > 0 {
+			nl, err := rlib.GetNoteList(r.Context(), a.NLID)
+			if err != nil {
+				SvcErrorReturn(w, err, funcname)
+				return
+			}
+			for i := 0; i < len(nl.N); i++ {
+				g.Notes = append(g.Notes, WorkOrderNote{
+					NID:      nl.N[i].NID,
+					Comment:  nl.N[i].Comment,
+					CreateTS: rlib.JSONDateTime(nl.N[i].CreateTS),
+					CreateBy: nl.N[i].CreateBy,
+				})
+			}
+		}
+	}
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}
+
+// saveWorkOrder creates or updates a WorkOrder
+// wsdoc {
+//  @Title  Save Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Create or update a Work Order
+//  @Description  Saves the work order with the supplied data. If WOID is 0
+//  @Description  a new work order is created. A work order made from a
+//  @Description  tenant's maintenance request (MRQID) takes its Rentable and
+//  @Description  Rental Agreement from the request, and the request's status
+//  @Description  follows the work order. Status changes must follow the
+//  @Description  work order lifecycle.
+//	@Input SaveWorkOrderInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "saveWorkOrder"
+	var (
+		foo SaveWorkOrderInput
+		err error
+		now = time.Now()
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err = json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	f := &foo.Record
+	bid, ok := rlib.RRdb.BUDlist[string(f.BUD)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, f.BUD)
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	var a rlib.WorkOrder
+	if f.WOID > 0 {
+		if a, err = rlib.GetWorkOrder(ctx, f.WOID); err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+		if a.WOID == 0 || a.BID != bid {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("work order %d not found", f.WOID), funcname)
+			return
+		}
+		if a.ChargeASMID > 0 && (a.RID != f.RID || a.RAID != f.RAID) {
+			tx.Rollback()
+			SvcErrorReturn(w, fmt.Errorf("the rentable and rental agreement of a work order cannot change after it has been charged back"), funcname)
+			return
+		}
+	} else {
+		a.BID = bid
+		a.Status = rlib.WOSTATUSopen
+		a.DtOpen = time.Time(f.DtOpen)
+		if a.DtOpen.Year() <= 1970 {
+			a.DtOpen = now
+		}
+		nl := rlib.NoteList{BID: bid}
+		if a.NLID, err = rlib.InsertNoteList(ctx, &nl); err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+
+	a.MRQID = f.MRQID
+	a.RID = f.RID
+	a.RAID = f.RAID
+	a.Category = strings.TrimSpace(f.Category)
+	a.Priority = f.Priority
+	a.AssignedUID = f.AssignedUID
+	a.AssignedTo = strings.TrimSpace(f.AssignedTo)
+	a.Descr = f.Descr
+	a.Cost = f.Cost
+	if err = validateWorkOrder(ctx, &a); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = rlib.SetWorkOrderStatus(&a, f.Status, &now); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+
+	if a.WOID == 0 {
+		err = rlib.InsertWorkOrder(ctx, &a)
+	} else {
+		err = rlib.UpdateWorkOrder(ctx, &a)
+	}
+	if err == nil {
+		err = rlib.UpdateWorkOrderMaintRequest(ctx, &a)
+	}
+	if err != nil {
+		tx.Rollback()
+		e := fmt.Errorf("%s: Error saving work order: %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.WOID)
+}
+
+// validateWorkOrder checks the user supplied fields of a. If a was made
+// from a tenant's MaintRequest its Rentable and Rental Agreement are taken
+// from the request.
+func validateWorkOrder(ctx context.Context, a *rlib.WorkOrder) error {
+	if a.Priority < rlib.WOPRIORITYlow || a.Priority > rlib.WOPRIORITYemergency {
+		return fmt.Errorf("invalid priority: %d", a.Priority)
+	}
+	if a.Cost < 0 {
+		return fmt.Errorf("cost cannot be negative")
+	}
+	if a.MRQID > 0 {
+		m, err := rlib.GetMaintRequest(ctx, a.MRQID)
+		if err != nil {
+			return err
+		}
+		if m.MRQID == 0 || m.BID != a.BID {
+			return fmt.Errorf("maintenance request %d not found", a.MRQID)
+		}
+		if a.RAID == 0 {
+			a.RAID = m.RAID
+		}
+		if a.RID == 0 {
+			a.RID = m.RID
+		}
+		if len(a.Descr) == 0 {
+			a.Descr = m.Subject + "\n" + m.Descr
+		}
+	}
+	rnt, err := rlib.GetRentable(ctx, a.RID)
+	if err != nil {
+		return err
+	}
+	if rnt.RID == 0 || rnt.BID != a.BID {
+		return fmt.Errorf("rentable %d not found", a.RID)
+	}
+	if a.RAID > 0 {
+		rars, err := rlib.GetRentalAgreementRentables(ctx, a.RAID, &rlib.TIME0, &rlib.ENDOFTIME)
+		if err != nil {
+			return err
+		}
+		found := false
+		for i := 0; i < len(rars) && !found; i++ {
+			found = rars[i].RID == a.RID
+		}
+		if !found {
+			return fmt.Errorf("rentable %d is not part of Rental Agreement %d", a.RID, a.RAID)
+		}
+	}
+	return nil
+}
+
+// deleteWorkOrder deletes a WorkOrder
+// wsdoc {
+//  @Title  Delete Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Delete a Work Order
+//  @Desc  This service deletes a work order and its notes. A work order
+//  @Desc  that has been charged back cannot be deleted, cancel it instead.
+//	@Input DeletePmtForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "deleteWorkOrder"
+	var del DeletePmtForm
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	a, err := rlib.GetWorkOrder(r.Context(), del.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.WOID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("work order %d not found", del.ID), funcname)
+		return
+	}
+	if a.ChargeASMID > 0 {
+		SvcErrorReturn(w, fmt.Errorf("work order %d has been charged back and cannot be deleted", del.ID), funcname)
+		return
+	}
+
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.NLID > 0 {
+		nl, err := rlib.GetNoteList(ctx, a.NLID)
+		if err == nil {
+			err = rlib.DeleteNoteList(ctx, &nl)
+		}
+		if err != nil {
+			tx.Rollback()
+			SvcErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	if err = rlib.DeleteWorkOrder(ctx, a.WOID); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(d.BID, w)
+}
+
+// addWorkOrderNote adds a note to a WorkOrder
+// wsdoc {
+//  @Title  Add Work Order Note
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Add a note to a Work Order
+//  @Desc  Adds the supplied Comment to the notes of work order :WOID.
+//	@Input WorkOrderNoteInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func addWorkOrderNote(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "addWorkOrderNote"
+	var foo WorkOrderNoteInput
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	if len(strings.TrimSpace(foo.Comment)) == 0 {
+		SvcErrorReturn(w, fmt.Errorf("a note cannot be empty"), funcname)
+		return
+	}
+	a, err := rlib.GetWorkOrder(r.Context(), d.ID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.WOID == 0 || a.BID != d.BID {
+		SvcErrorReturn(w, fmt.Errorf("work order %d not found", d.ID), funcname)
+		return
+	}
+	n := rlib.Note{
+		BID:     a.BID,
+		NLID:    a.NLID,
+		RID:     a.RID,
+		RAID:    a.RAID,
+		Comment: foo.Comment,
+	}
+	if _, err = rlib.InsertNote(r.Context(), &n); err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, n.NID)
+}
+
+// chargeBackWorkOrder charges the tenant for a WorkOrder
+// wsdoc {
+//  @Title  Charge Back Work Order
+//	@URL /v1/workorder/:BUI/:WOID
+//  @Method  POST
+//	@Synopsis Charge the tenant for a Work Order
+//  @Desc  Adds a one time assessment for Amount on date Dt to the Rental
+//  @Desc  Agreement of work order :WOID using account rule ARID. A work
+//  @Desc  order can only be charged back once.
+//	@Input WorkOrderChargeBackInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func chargeBackWorkOrder(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "chargeBackWorkOrder"
+	var foo WorkOrderChargeBackInput
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcErrorReturn(w, e, funcname)
+		return
+	}
+	tx, ctx, err := rlib.NewTransactionWithContext(r.Context())
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	a, err := rlib.GetWorkOrder(ctx, d.ID)
+	if err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	if a.WOID == 0 || a.BID != d.BID {
+		tx.Rollback()
+		SvcErrorReturn(w, fmt.Errorf("work order %d not found", d.ID), funcname)
+		return
+	}
+	dt := time.Time(foo.Dt)
+	if errlist := bizlogic.ChargeBackWorkOrder(ctx, &a, foo.ARID, foo.Amount, &dt); len(errlist) > 0 {
+		tx.Rollback()
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(d.BID, w, a.ChargeASMID)
+}
+
+// SvcMaintRequests returns the tenant maintenance requests of business
+// d.BID that are not closed
+// wsdoc {
+//  @Title  Open Maintenance Requests
+//	@URL /v1/maintrequests/:BUI
+//  @Method  POST
+//	@Synopsis List the open tenant maintenance requests
+//  @Descr  Returns the maintenance requests submitted through the tenant
+//  @Descr  portal that are not closed, oldest first. A work order is made
+//  @Descr  from a request by saving one with its MRQID.
+//	@Input WebGridSearchRequest
+//  @Response MaintRequestSearchResponse
+// wsdoc }
+func SvcMaintRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	const funcname = "SvcMaintRequests"
+	var g MaintRequestSearchResponse
+
+	fmt.Printf("Entered %s\n", funcname)
+	m, err := rlib.GetOpenMaintRequests(r.Context(), d.BID)
+	if err != nil {
+		SvcErrorReturn(w, err, funcname)
+		return
+	}
+	for i := 0; i < len(m); i++ {
+		var q MaintRequestGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].MRQID
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	SvcWriteResponse(d.BID, &g, w)
+}